@@ -91,12 +91,7 @@ func (a *array) Release() {
 func (a *array) DataType() arrow.DataType { return a.data.dtype }
 
 // NullN returns the number of null values in the array.
-func (a *array) NullN() int {
-	if a.data.nulls < 0 {
-		a.data.nulls = a.data.length - bitutil.CountSetBits(a.nullBitmapBytes, a.data.offset, a.data.length)
-	}
-	return a.data.nulls
-}
+func (a *array) NullN() int { return a.data.NullN() }
 
 // NullBitmapBytes returns a byte slice of the validity bitmap.
 func (a *array) NullBitmapBytes() []byte { return a.nullBitmapBytes }
@@ -133,7 +128,7 @@ func (a *array) setData(data *Data) {
 type arrayConstructorFn func(*Data) Interface
 
 var (
-	makeArrayFn [32]arrayConstructorFn
+	makeArrayFn [64]arrayConstructorFn
 )
 
 func unsupportedArrayType(data *Data) Interface {
@@ -146,7 +141,7 @@ func invalidDataType(data *Data) Interface {
 
 // MakeFromData constructs a strongly-typed array instance from generic Data.
 func MakeFromData(data *Data) Interface {
-	return makeArrayFn[byte(data.dtype.ID()&0x1f)](data)
+	return makeArrayFn[byte(data.dtype.ID()&0x3f)](data)
 }
 
 // NewSlice constructs a zero-copy slice of the array with the indicated
@@ -174,7 +169,7 @@ func init() {
 		arrow.INT32:             func(data *Data) Interface { return NewInt32Data(data) },
 		arrow.UINT64:            func(data *Data) Interface { return NewUint64Data(data) },
 		arrow.INT64:             func(data *Data) Interface { return NewInt64Data(data) },
-		arrow.HALF_FLOAT:        unsupportedArrayType,
+		arrow.HALF_FLOAT:        func(data *Data) Interface { return NewFloat16Data(data) },
 		arrow.FLOAT32:           func(data *Data) Interface { return NewFloat32Data(data) },
 		arrow.FLOAT64:           func(data *Data) Interface { return NewFloat64Data(data) },
 		arrow.STRING:            func(data *Data) Interface { return NewStringData(data) },
@@ -185,18 +180,51 @@ func init() {
 		arrow.TIMESTAMP:         func(data *Data) Interface { return NewTimestampData(data) },
 		arrow.TIME32:            func(data *Data) Interface { return NewTime32Data(data) },
 		arrow.TIME64:            func(data *Data) Interface { return NewTime64Data(data) },
-		arrow.INTERVAL:          unsupportedArrayType,
+		arrow.INTERVAL:          func(data *Data) Interface { return newIntervalData(data) },
 		arrow.DECIMAL:           unsupportedArrayType,
 		arrow.LIST:              func(data *Data) Interface { return NewListData(data) },
 		arrow.STRUCT:            func(data *Data) Interface { return NewStructData(data) },
-		arrow.UNION:             unsupportedArrayType,
-		arrow.DICTIONARY:        unsupportedArrayType,
-		arrow.MAP:               unsupportedArrayType,
-
-		// invalid data types to fill out array size 2⁵-1
-		28: invalidDataType,
-		29: invalidDataType,
-		30: invalidDataType,
-		31: invalidDataType,
+		arrow.UNION:             func(data *Data) Interface { return NewUnionData(data) },
+		arrow.DICTIONARY:        func(data *Data) Interface { return NewDictionaryData(data) },
+		arrow.MAP:               func(data *Data) Interface { return NewMapData(data) },
+		arrow.DECIMAL256:        func(data *Data) Interface { return NewDecimal256Data(data) },
+
+		arrow.LARGE_STRING:    func(data *Data) Interface { return NewLargeStringData(data) },
+		arrow.LARGE_BINARY:    func(data *Data) Interface { return NewLargeBinaryData(data) },
+		arrow.LARGE_LIST:      func(data *Data) Interface { return NewLargeListData(data) },
+		arrow.EXTENSION:       func(data *Data) Interface { return NewExtensionData(data) },
+		arrow.RUN_END_ENCODED: func(data *Data) Interface { return NewRunEndEncodedData(data) },
+		arrow.DURATION:        func(data *Data) Interface { return NewDurationData(data) },
+		arrow.FIXED_SIZE_LIST: func(data *Data) Interface { return NewFixedSizeListData(data) },
+		arrow.STRING_VIEW:     func(data *Data) Interface { return NewStringViewData(data) },
+		arrow.BINARY_VIEW:     func(data *Data) Interface { return NewBinaryViewData(data) },
+
+		// invalid data types to fill out array size 2⁶-1
+		38: invalidDataType,
+		39: invalidDataType,
+		40: invalidDataType,
+		41: invalidDataType,
+		42: invalidDataType,
+		43: invalidDataType,
+		44: invalidDataType,
+		45: invalidDataType,
+		46: invalidDataType,
+		47: invalidDataType,
+		48: invalidDataType,
+		49: invalidDataType,
+		50: invalidDataType,
+		51: invalidDataType,
+		52: invalidDataType,
+		53: invalidDataType,
+		54: invalidDataType,
+		55: invalidDataType,
+		56: invalidDataType,
+		57: invalidDataType,
+		58: invalidDataType,
+		59: invalidDataType,
+		60: invalidDataType,
+		61: invalidDataType,
+		62: invalidDataType,
+		63: invalidDataType,
 	}
 }
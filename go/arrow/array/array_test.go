@@ -43,7 +43,8 @@ func TestMakeFromData(t *testing.T) {
 		expError string
 	}{
 		// unsupported types
-		{name: "map", d: &testDataType{arrow.MAP}, expPanic: true, expError: "unsupported data type: MAP"},
+		{name: "decimal", d: &testDataType{arrow.DECIMAL}, expPanic: true, expError: "unsupported data type: DECIMAL"},
+		{name: "interval", d: &testDataType{arrow.INTERVAL}, expPanic: true, expError: "unsupported data type: INTERVAL"},
 
 		// supported types
 		{name: "null", d: &testDataType{arrow.NULL}},
@@ -56,19 +57,48 @@ func TestMakeFromData(t *testing.T) {
 		{name: "int16", d: &testDataType{arrow.INT16}},
 		{name: "int32", d: &testDataType{arrow.INT32}},
 		{name: "int64", d: &testDataType{arrow.INT64}},
+		{name: "float16", d: &testDataType{arrow.HALF_FLOAT}},
 		{name: "float32", d: &testDataType{arrow.FLOAT32}},
 		{name: "float64", d: &testDataType{arrow.FLOAT64}},
 		{name: "binary", d: &testDataType{arrow.BINARY}, size: 3},
 		{name: "timestamp", d: &testDataType{arrow.TIMESTAMP}},
 		{name: "time32", d: &testDataType{arrow.TIME32}},
 		{name: "time64", d: &testDataType{arrow.TIME64}},
+		{name: "duration", d: &testDataType{arrow.DURATION}},
+		{name: "month_interval", d: arrow.FixedWidthTypes.MonthInterval},
+		{name: "day_time_interval", d: arrow.FixedWidthTypes.DayTimeInterval},
+		{name: "month_day_nano_interval", d: arrow.FixedWidthTypes.MonthDayNanoInterval},
 		{name: "fixed_size_binary", d: &testDataType{arrow.FIXED_SIZE_BINARY}, size: 3},
+		{name: "decimal256", d: &testDataType{arrow.DECIMAL256}},
+		{name: "large_string", d: &testDataType{arrow.LARGE_STRING}, size: 3},
+		{name: "large_binary", d: &testDataType{arrow.LARGE_BINARY}, size: 3},
+		{name: "string_view", d: &testDataType{arrow.STRING_VIEW}},
+		{name: "binary_view", d: &testDataType{arrow.BINARY_VIEW}, size: 3},
 
 		{name: "list", d: &testDataType{arrow.LIST}, child: []*array.Data{
 			array.NewData(&testDataType{arrow.INT64}, 0, make([]*memory.Buffer, 4), nil, 0, 0),
 			array.NewData(&testDataType{arrow.INT64}, 0, make([]*memory.Buffer, 4), nil, 0, 0),
 		}},
 
+		{name: "large_list", d: &testDataType{arrow.LARGE_LIST}, child: []*array.Data{
+			array.NewData(&testDataType{arrow.INT64}, 0, make([]*memory.Buffer, 4), nil, 0, 0),
+		}},
+
+		{name: "fixed_size_list", d: arrow.FixedSizeListOf(2, arrow.PrimitiveTypes.Int64), child: []*array.Data{
+			array.NewData(&testDataType{arrow.INT64}, 0, make([]*memory.Buffer, 4), nil, 0, 0),
+		}},
+
+		{name: "map", d: &testDataType{arrow.MAP}, child: []*array.Data{
+			array.NewData(&testDataType{arrow.STRUCT}, 0, make([]*memory.Buffer, 4), []*array.Data{
+				array.NewData(&testDataType{arrow.INT64}, 0, make([]*memory.Buffer, 4), nil, 0, 0),
+				array.NewData(&testDataType{arrow.INT64}, 0, make([]*memory.Buffer, 4), nil, 0, 0),
+			}, 0, 0),
+		}},
+
+		{name: "union", d: &testDataType{arrow.UNION}, child: []*array.Data{
+			array.NewData(&testDataType{arrow.INT64}, 0, make([]*memory.Buffer, 4), nil, 0, 0),
+		}},
+
 		{name: "struct", d: &testDataType{arrow.STRUCT}},
 		{name: "struct", d: &testDataType{arrow.STRUCT}, child: []*array.Data{
 			array.NewData(&testDataType{arrow.INT64}, 0, make([]*memory.Buffer, 4), nil, 0, 0),
@@ -77,7 +107,8 @@ func TestMakeFromData(t *testing.T) {
 
 		// invalid types
 		{name: "invalid(-1)", d: &testDataType{arrow.Type(-1)}, expPanic: true, expError: "invalid data type: Type(-1)"},
-		{name: "invalid(28)", d: &testDataType{arrow.Type(28)}, expPanic: true, expError: "invalid data type: Type(28)"},
+		{name: "invalid(38)", d: &testDataType{arrow.Type(38)}, expPanic: true, expError: "invalid data type: Type(38)"},
+		{name: "invalid(63)", d: &testDataType{arrow.Type(63)}, expPanic: true, expError: "invalid data type: Type(63)"},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -130,6 +161,79 @@ func TestArray_NullN(t *testing.T) {
 	}
 }
 
+func TestSliceNullN(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewInt32Builder(mem)
+	defer b.Release()
+	b.AppendValues([]int32{1, 2, 3, 4, 5, 6}, []bool{true, false, true, false, false, true})
+	arr := b.NewArray()
+	defer arr.Release()
+
+	for _, tc := range []struct {
+		i, j int64
+		want int
+	}{{0, 6, 3}, {1, 5, 3}, {2, 3, 0}, {3, 5, 2}, {6, 6, 0}} {
+		slice := array.NewSlice(arr, tc.i, tc.j)
+		assert.Equal(t, tc.want, slice.Data().NullN(), "slice [%d:%d]", tc.i, tc.j)
+		assert.Equal(t, tc.want, slice.NullN(), "slice [%d:%d]", tc.i, tc.j)
+		slice.Release()
+	}
+
+	// slices of all-null arrays are known to be all-null.
+	b.AppendNulls(4)
+	nulls := b.NewArray()
+	defer nulls.Release()
+	data := array.NewSliceData(nulls.Data(), 1, 3)
+	defer data.Release()
+	assert.Equal(t, 2, data.NullN())
+}
+
+func TestBuilderAppendNulls(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	for _, dt := range []arrow.DataType{
+		arrow.Null,
+		arrow.FixedWidthTypes.Boolean,
+		arrow.PrimitiveTypes.Int8,
+		arrow.FixedWidthTypes.Float16,
+		arrow.PrimitiveTypes.Float64,
+		arrow.BinaryTypes.String,
+		arrow.BinaryTypes.LargeBinary,
+		&arrow.FixedSizeBinaryType{ByteWidth: 3},
+		&arrow.Decimal256Type{Precision: 10, Scale: 2},
+		arrow.ListOf(arrow.PrimitiveTypes.Int32),
+		arrow.LargeListOf(arrow.PrimitiveTypes.Int32),
+		arrow.FixedSizeListOf(2, arrow.PrimitiveTypes.Int32),
+		arrow.StructOf(arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Int32, Nullable: true}),
+		arrow.MapOf(arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int32),
+		arrow.SparseUnionOf([]arrow.Field{{Name: "i", Type: arrow.PrimitiveTypes.Int32}}, nil),
+	} {
+		t.Run(dt.Name(), func(t *testing.T) {
+			b := array.NewBuilder(mem, dt)
+			defer b.Release()
+
+			b.AppendNull()
+			b.AppendNulls(70)
+			b.AppendNulls(0)
+			assert.Equal(t, 71, b.Len())
+			assert.Equal(t, 71, b.NullN())
+
+			arr := b.NewArray()
+			defer arr.Release()
+			assert.Equal(t, 71, arr.Len())
+			assert.Equal(t, 71, arr.NullN())
+			for i := 0; i < arr.Len() && dt.ID() != arrow.NULL; i++ {
+				if !arr.IsNull(i) {
+					t.Fatalf("value %d is not null", i)
+				}
+			}
+		})
+	}
+}
+
 func TestArraySlice(t *testing.T) {
 	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
 	defer pool.AssertSize(t, 0)
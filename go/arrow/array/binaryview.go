@@ -0,0 +1,150 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"encoding/binary"
+	"unsafe"
+
+	"github.com/apache/arrow/go/arrow"
+)
+
+// viewInlineSize is the largest size of a value stored inline in its view.
+//
+// A view is laid out as a little-endian int32 size, followed either by
+// the value itself, zero-padded to 12 bytes, or by the first 4 bytes of
+// the value, the index of the data buffer holding it and its offset in
+// that buffer.
+const viewInlineSize = 12
+
+// viewValue returns the value of the i-th view of views, whose data
+// buffers are bufs.
+func viewValue(views []byte, bufs [][]byte, i int) []byte {
+	v := views[i*arrow.ViewSizeBytes : (i+1)*arrow.ViewSizeBytes]
+	n := int(binary.LittleEndian.Uint32(v))
+	if n <= viewInlineSize {
+		return v[4 : 4+n]
+	}
+	buf := binary.LittleEndian.Uint32(v[8:])
+	off := int(binary.LittleEndian.Uint32(v[12:]))
+	return bufs[buf][off : off+n]
+}
+
+// viewLen returns the size of the value of the i-th view of views.
+func viewLen(views []byte, i int) int {
+	return int(binary.LittleEndian.Uint32(views[i*arrow.ViewSizeBytes:]))
+}
+
+// viewBuffers returns the bytes of the data buffers of data, which follow
+// its validity and views buffers.
+func viewBuffers(data *Data) [][]byte {
+	bufs := make([][]byte, len(data.buffers)-2)
+	for i, b := range data.buffers[2:] {
+		bufs[i] = bytesOf(b)
+	}
+	return bufs
+}
+
+// BinaryView represents an immutable sequence of variable-length binary
+// strings, stored as views into a variable number of data buffers.
+type BinaryView struct {
+	array
+	views   []byte
+	buffers [][]byte
+}
+
+// NewBinaryViewData constructs a new BinaryView array from data.
+func NewBinaryViewData(data *Data) *BinaryView {
+	a := &BinaryView{}
+	a.refCount = 1
+	a.setData(data)
+	return a
+}
+
+// Value returns the slice at index i. This value should not be mutated.
+func (a *BinaryView) Value(i int) []byte {
+	return viewValue(a.views, a.buffers, i+a.array.data.offset)
+}
+
+// ValueString returns the string at index i without performing additional allocations.
+// The string is only valid for the lifetime of the BinaryView array.
+func (a *BinaryView) ValueString(i int) string {
+	b := a.Value(i)
+	return *(*string)(unsafe.Pointer(&b))
+}
+
+// ValueLen returns the length of the value at index i.
+func (a *BinaryView) ValueLen(i int) int {
+	return viewLen(a.views, i+a.array.data.offset)
+}
+
+func (a *BinaryView) String() string { return formatString(a) }
+
+func (a *BinaryView) setData(data *Data) {
+	if len(data.buffers) < 2 {
+		panic("arrow/array: len(data.buffers) < 2")
+	}
+
+	a.array.setData(data)
+	a.views = bytesOf(data.buffers[1])
+	a.buffers = viewBuffers(data)
+}
+
+// StringView represents an immutable sequence of variable-length UTF-8
+// strings, stored as views into a variable number of data buffers.
+type StringView struct {
+	array
+	views   []byte
+	buffers [][]byte
+}
+
+// NewStringViewData constructs a new StringView array from data.
+func NewStringViewData(data *Data) *StringView {
+	a := &StringView{}
+	a.refCount = 1
+	a.setData(data)
+	return a
+}
+
+// Value returns the string at index i.
+// The string is only valid for the lifetime of the StringView array.
+func (a *StringView) Value(i int) string {
+	b := viewValue(a.views, a.buffers, i+a.array.data.offset)
+	return *(*string)(unsafe.Pointer(&b))
+}
+
+// ValueLen returns the length in bytes of the string at index i.
+func (a *StringView) ValueLen(i int) int {
+	return viewLen(a.views, i+a.array.data.offset)
+}
+
+func (a *StringView) String() string { return formatString(a) }
+
+func (a *StringView) setData(data *Data) {
+	if len(data.buffers) < 2 {
+		panic("arrow/array: len(data.buffers) < 2")
+	}
+
+	a.array.setData(data)
+	a.views = bytesOf(data.buffers[1])
+	a.buffers = viewBuffers(data)
+}
+
+var (
+	_ Interface = (*BinaryView)(nil)
+	_ Interface = (*StringView)(nil)
+)
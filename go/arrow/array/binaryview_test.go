@@ -0,0 +1,137 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringViewArray(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	var (
+		long   = strings.Repeat("x", 40)
+		want   = []string{"hello", "", "a string longer than 12 bytes", "", long, "twelve bytes"}
+		valids = []bool{true, true, true, false, true, true}
+	)
+
+	sb := array.NewBuilder(mem, arrow.BinaryTypes.StringView).(*array.StringViewBuilder)
+	defer sb.Release()
+
+	// 64 bytes blocks: the 40 bytes value does not fit after the first
+	// long value and starts a new data buffer.
+	sb.SetBlockSize(64)
+	sb.AppendValues(want[:3], nil)
+	sb.AppendNull()
+	sb.Append(want[4])
+	sb.Append(want[5])
+
+	if got, want := sb.Len(), len(want); got != want {
+		t.Fatalf("invalid len: got=%d, want=%d", got, want)
+	}
+	if got, want := sb.NullN(), 1; got != want {
+		t.Fatalf("invalid nulls: got=%d, want=%d", got, want)
+	}
+	assert.Equal(t, long, sb.Value(4))
+
+	arr := sb.NewStringViewArray()
+	defer arr.Release()
+
+	assert.Equal(t, arrow.STRING_VIEW, arr.DataType().ID())
+	for i := range want {
+		assert.Equal(t, valids[i], arr.IsValid(i))
+		assert.Equal(t, want[i], arr.Value(i))
+		assert.Equal(t, len(want[i]), arr.ValueLen(i))
+	}
+	assert.Equal(t, `["hello" "" "a string longer than 12 bytes" (null) "`+long+`" "twelve bytes"]`, arr.String())
+
+	// validity, views and two data buffers.
+	data := arr.Data()
+	assert.Len(t, data.Buffers(), 4)
+	assert.Equal(t, 6*arrow.ViewSizeBytes, data.Buffers()[1].Len())
+	assert.NoError(t, array.ValidateFull(arr, array.WithUTF8Validation(true)))
+
+	slice := array.NewSlice(arr, 2, 5).(*array.StringView)
+	defer slice.Release()
+	assert.Equal(t, "a string longer than 12 bytes", slice.Value(0))
+	assert.True(t, slice.IsNull(1))
+	assert.Equal(t, long, slice.Value(2))
+
+	// the builder can be reused.
+	sb.Append("again")
+	arr2 := sb.NewArray().(*array.StringView)
+	defer arr2.Release()
+	assert.Equal(t, `["again"]`, arr2.String())
+	assert.Len(t, arr2.Data().Buffers(), 2)
+}
+
+func TestBinaryViewArray(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewBinaryViewBuilder(mem, arrow.BinaryTypes.BinaryView)
+	defer b.Release()
+
+	b.AppendValues([][]byte{[]byte("abc"), nil, []byte("0123456789abcdef")}, []bool{true, false, true})
+	b.AppendNulls(2)
+	b.AppendString("xyz")
+
+	arr := b.NewBinaryViewArray()
+	defer arr.Release()
+
+	assert.Equal(t, 6, arr.Len())
+	assert.Equal(t, 3, arr.NullN())
+	assert.Equal(t, []byte("0123456789abcdef"), arr.Value(2))
+	assert.Equal(t, "xyz", arr.ValueString(5))
+	assert.Equal(t, `["abc" (null) "0123456789abcdef" (null) (null) "xyz"]`, arr.String())
+
+	other := array.NewBuilder(mem, arrow.BinaryTypes.BinaryView)
+	defer other.Release()
+	if err := other.UnmarshalJSON([]byte(`["YWJj", null, "MDEyMzQ1Njc4OWFiY2RlZg==", null, null, "eHl6"]`)); err != nil {
+		t.Fatal(err)
+	}
+	arr2 := other.NewArray()
+	defer arr2.Release()
+	assert.True(t, array.ArrayEqual(arr, arr2))
+}
+
+func TestBinaryViewValidateErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewStringViewBuilder(mem)
+	defer b.Release()
+	b.AppendValues([]string{"short", "a string longer than 12 bytes"}, nil)
+	arr := b.NewStringViewArray()
+	defer arr.Release()
+
+	// drop the data buffer the second view refers to.
+	data := array.NewData(arr.DataType(), arr.Len(), arr.Data().Buffers()[:2], nil, 0, 0)
+	defer data.Release()
+	bad := array.MakeFromData(data)
+	defer bad.Release()
+
+	assert.NoError(t, array.Validate(bad))
+	assert.EqualError(t, array.ValidateFull(bad), "arrow/array: view of value 1 refers to data buffer 0 of 0")
+}
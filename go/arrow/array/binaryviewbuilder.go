@@ -0,0 +1,335 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"encoding/binary"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// defaultViewBlockSize is the default size of the data buffers of a
+// BinaryViewBuilder.
+const defaultViewBlockSize = 32 << 10
+
+// A BinaryViewBuilder is used to build a BinaryView or StringView array
+// using the Append methods. Values that do not fit inline in their view
+// are copied into data buffers of a fixed block size; a new buffer is
+// started whenever a value does not fit in the current one.
+type BinaryViewBuilder struct {
+	builder
+
+	dtype     arrow.BinaryViewDataType
+	views     *byteBufferBuilder
+	blocks    []*memory.Buffer   // full data buffers
+	block     *byteBufferBuilder // data buffer being filled
+	blockSize int
+}
+
+func NewBinaryViewBuilder(mem memory.Allocator, dtype arrow.BinaryViewDataType) *BinaryViewBuilder {
+	return &BinaryViewBuilder{
+		builder:   builder{refCount: 1, mem: mem},
+		dtype:     dtype,
+		views:     newByteBufferBuilder(mem),
+		block:     newByteBufferBuilder(mem),
+		blockSize: defaultViewBlockSize,
+	}
+}
+
+// SetBlockSize sets the size of the data buffers allocated for values that
+// are not stored inline. Values larger than n get a buffer of their own.
+func (b *BinaryViewBuilder) SetBlockSize(n int) {
+	b.blockSize = n
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+// Release may be called simultaneously from multiple goroutines.
+func (b *BinaryViewBuilder) Release() {
+	debug.Assert(atomic.LoadInt64(&b.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&b.refCount, -1) == 0 {
+		if b.nullBitmap != nil {
+			b.nullBitmap.Release()
+			b.nullBitmap = nil
+		}
+		if b.views != nil {
+			b.views.Release()
+			b.views = nil
+		}
+		if b.block != nil {
+			b.block.Release()
+			b.block = nil
+		}
+		for _, buf := range b.blocks {
+			buf.Release()
+		}
+		b.blocks = nil
+	}
+}
+
+func (b *BinaryViewBuilder) Append(v []byte) {
+	b.Reserve(1)
+	b.appendView(v)
+	b.UnsafeAppendBoolToBitmap(true)
+}
+
+func (b *BinaryViewBuilder) AppendString(v string) {
+	b.Append([]byte(v))
+}
+
+func (b *BinaryViewBuilder) AppendNull() {
+	b.Reserve(1)
+	b.appendView(nil)
+	b.UnsafeAppendBoolToBitmap(false)
+}
+
+// AppendNulls appends n null values to the builder.
+func (b *BinaryViewBuilder) AppendNulls(n int) {
+	b.Reserve(n)
+	for i := 0; i < n; i++ {
+		b.appendView(nil)
+	}
+	b.unsafeAppendNulls(n)
+}
+
+// AppendValues will append the values in the v slice. The valid slice determines which values
+// in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
+// all values in v are appended and considered valid.
+func (b *BinaryViewBuilder) AppendValues(v [][]byte, valid []bool) {
+	if len(v) != len(valid) && len(valid) != 0 {
+		panic("len(v) != len(valid) && len(valid) != 0")
+	}
+
+	b.Reserve(len(v))
+	for _, vv := range v {
+		b.appendView(vv)
+	}
+
+	b.builder.unsafeAppendBoolsToBitmap(valid, len(v))
+}
+
+// AppendStringValues will append the values in the v slice. The valid slice determines which values
+// in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
+// all values in v are appended and considered valid.
+func (b *BinaryViewBuilder) AppendStringValues(v []string, valid []bool) {
+	if len(v) != len(valid) && len(valid) != 0 {
+		panic("len(v) != len(valid) && len(valid) != 0")
+	}
+
+	b.Reserve(len(v))
+	for _, vv := range v {
+		b.appendView([]byte(vv))
+	}
+
+	b.builder.unsafeAppendBoolsToBitmap(valid, len(v))
+}
+
+// appendView appends the view of v, copying v into the current data
+// buffer if it can not be stored inline.
+func (b *BinaryViewBuilder) appendView(v []byte) {
+	var view [arrow.ViewSizeBytes]byte
+	binary.LittleEndian.PutUint32(view[:], uint32(len(v)))
+	if len(v) <= viewInlineSize {
+		copy(view[4:], v)
+		b.views.Append(view[:])
+		return
+	}
+
+	if b.block.Len() > 0 && b.block.Len()+len(v) > b.block.Cap() {
+		b.blocks = append(b.blocks, b.block.Finish())
+	}
+	if b.block.Len() == 0 {
+		b.block.resize(max(b.blockSize, len(v)))
+	}
+	copy(view[4:8], v)
+	binary.LittleEndian.PutUint32(view[8:], uint32(len(b.blocks)))
+	binary.LittleEndian.PutUint32(view[12:], uint32(b.block.Len()))
+	b.block.Append(v)
+	b.views.Append(view[:])
+}
+
+// Value returns the i-th value appended to the builder. It is only valid
+// until the next call to an Append method.
+func (b *BinaryViewBuilder) Value(i int) []byte {
+	bufs := make([][]byte, len(b.blocks)+1)
+	for k, buf := range b.blocks {
+		bufs[k] = buf.Bytes()
+	}
+	bufs[len(b.blocks)] = b.block.Bytes()
+	return viewValue(b.views.Bytes(), bufs, i)
+}
+
+func (b *BinaryViewBuilder) init(capacity int) {
+	b.builder.init(capacity)
+	b.views.resize(capacity * arrow.ViewSizeBytes)
+}
+
+// Reserve ensures there is enough space for appending n elements
+// by checking the capacity and calling Resize if necessary.
+func (b *BinaryViewBuilder) Reserve(n int) {
+	b.builder.reserve(n, b.Resize)
+}
+
+// Resize adjusts the space allocated by b to n elements. If n is greater than b.Cap(),
+// additional memory will be allocated. If n is smaller, the allocated memory may reduced.
+func (b *BinaryViewBuilder) Resize(n int) {
+	b.views.resize(n * arrow.ViewSizeBytes)
+	b.builder.resize(n, b.init)
+}
+
+// NewArray creates a BinaryView or StringView array, depending on the data
+// type of the builder, from the memory buffers used by the builder and
+// resets the BinaryViewBuilder so it can be used to build a new array.
+func (b *BinaryViewBuilder) NewArray() Interface {
+	data := b.newData()
+	defer data.Release()
+	return MakeFromData(data)
+}
+
+// NewBinaryViewArray creates a BinaryView array from the memory buffers used by the builder and resets the BinaryViewBuilder
+// so it can be used to build a new array.
+func (b *BinaryViewBuilder) NewBinaryViewArray() (a *BinaryView) {
+	data := b.newData()
+	a = NewBinaryViewData(data)
+	data.Release()
+	return
+}
+
+func (b *BinaryViewBuilder) newData() (data *Data) {
+	if b.block.Len() > 0 {
+		b.blocks = append(b.blocks, b.block.Finish())
+	}
+	buffers := append([]*memory.Buffer{b.nullBitmap, b.views.Finish()}, b.blocks...)
+	data = NewData(b.dtype, b.length, buffers, nil, b.nulls, 0)
+	for _, buf := range buffers[1:] {
+		if buf != nil {
+			buf.Release()
+		}
+	}
+	b.blocks = nil
+
+	b.builder.reset()
+
+	return
+}
+
+// A StringViewBuilder is used to build a StringView array using the Append methods.
+type StringViewBuilder struct {
+	builder *BinaryViewBuilder
+}
+
+func NewStringViewBuilder(mem memory.Allocator) *StringViewBuilder {
+	return &StringViewBuilder{
+		builder: NewBinaryViewBuilder(mem, arrow.BinaryTypes.StringView),
+	}
+}
+
+// SetBlockSize sets the size of the data buffers allocated for strings that
+// are not stored inline. Strings larger than n get a buffer of their own.
+func (b *StringViewBuilder) SetBlockSize(n int) {
+	b.builder.SetBlockSize(n)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+// Release may be called simultaneously from multiple goroutines.
+func (b *StringViewBuilder) Release() {
+	b.builder.Release()
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (b *StringViewBuilder) Retain() {
+	b.builder.Retain()
+}
+
+// Len returns the number of elements in the array builder.
+func (b *StringViewBuilder) Len() int { return b.builder.Len() }
+
+// Cap returns the total number of elements that can be stored without allocating additional memory.
+func (b *StringViewBuilder) Cap() int { return b.builder.Cap() }
+
+// NullN returns the number of null values in the array builder.
+func (b *StringViewBuilder) NullN() int { return b.builder.NullN() }
+
+func (b *StringViewBuilder) Append(v string) {
+	b.builder.Append([]byte(v))
+}
+
+func (b *StringViewBuilder) AppendNull() {
+	b.builder.AppendNull()
+}
+
+// AppendNulls appends n null values to the builder.
+func (b *StringViewBuilder) AppendNulls(n int) {
+	b.builder.AppendNulls(n)
+}
+
+// AppendValues will append the values in the v slice. The valid slice determines which values
+// in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
+// all values in v are appended and considered valid.
+func (b *StringViewBuilder) AppendValues(v []string, valid []bool) {
+	b.builder.AppendStringValues(v, valid)
+}
+
+func (b *StringViewBuilder) Value(i int) string {
+	return string(b.builder.Value(i))
+}
+
+func (b *StringViewBuilder) init(capacity int) {
+	b.builder.init(capacity)
+}
+
+func (b *StringViewBuilder) resize(newBits int, init func(int)) {
+	b.builder.resize(newBits, init)
+}
+
+// Reserve ensures there is enough space for appending n elements
+// by checking the capacity and calling Resize if necessary.
+func (b *StringViewBuilder) Reserve(n int) {
+	b.builder.Reserve(n)
+}
+
+// Resize adjusts the space allocated by b to n elements. If n is greater than b.Cap(),
+// additional memory will be allocated. If n is smaller, the allocated memory may reduced.
+func (b *StringViewBuilder) Resize(n int) {
+	b.builder.Resize(n)
+}
+
+// NewArray creates a StringView array from the memory buffers used by the builder and resets the StringViewBuilder
+// so it can be used to build a new array.
+func (b *StringViewBuilder) NewArray() Interface {
+	return b.NewStringViewArray()
+}
+
+// NewStringViewArray creates a StringView array from the memory buffers used by the builder and resets the StringViewBuilder
+// so it can be used to build a new array.
+func (b *StringViewBuilder) NewStringViewArray() (a *StringView) {
+	data := b.builder.newData()
+	a = NewStringViewData(data)
+	data.Release()
+	return
+}
+
+var (
+	_ Builder = (*BinaryViewBuilder)(nil)
+	_ Builder = (*StringViewBuilder)(nil)
+)
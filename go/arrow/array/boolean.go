@@ -55,7 +55,7 @@ func (a *Boolean) Value(i int) bool {
 func (a *Boolean) String() string {
 	o := new(strings.Builder)
 	o.WriteString("[")
-	for i := range a.values {
+	for i := 0; i < a.Len(); i++ {
 		if i > 0 {
 			fmt.Fprintf(o, " ")
 		}
@@ -258,3 +258,30 @@ func TestBooleanSliceOutOfBounds(t *testing.T) {
 		})
 	}
 }
+
+func TestBooleanString(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	b := array.NewBooleanBuilder(pool)
+	defer b.Release()
+
+	b.AppendValues([]bool{true, false, true, false, true, false, true, false, true, true}, nil)
+	b.AppendNull()
+
+	arr := b.NewBooleanArray()
+	defer arr.Release()
+
+	want := "[true false true false true false true false true true (null)]"
+	if got := arr.String(); got != want {
+		t.Fatalf("invalid string:\ngot= %s\nwant=%s", got, want)
+	}
+
+	slice := array.NewSlice(arr, 8, 11).(*array.Boolean)
+	defer slice.Release()
+
+	want = "[true true (null)]"
+	if got := slice.String(); got != want {
+		t.Fatalf("invalid string:\ngot= %s\nwant=%s", got, want)
+	}
+}
@@ -51,6 +51,9 @@ type Builder interface {
 	// AppendNull adds a new null value to the array being built.
 	AppendNull()
 
+	// AppendNulls adds n null values to the array being built.
+	AppendNulls(n int)
+
 	// Reserve ensures there is enough space for appending n elements
 	// by checking the capacity and calling Resize if necessary.
 	Reserve(n int)
@@ -64,6 +67,10 @@ type Builder interface {
 	// a new array.
 	NewArray() Interface
 
+	// UnmarshalJSON appends the elements of data, a JSON array, to the
+	// builder.
+	UnmarshalJSON(data []byte) error
+
 	init(capacity int)
 	resize(newBits int, init func(int))
 }
@@ -199,6 +206,23 @@ func (b *builder) unsafeSetValid(length int) {
 	b.length = newLength
 }
 
+// UnsafeAppendBoolsToBitmap appends the contents of valid to the validity bitmap,
+// which must have enough capacity. If valid is empty, the next length entries
+// are valid.
+// Like UnsafeAppendBoolToBitmap, it only appends to the validity bitmap:
+// the corresponding values must be appended separately.
+func (b *builder) UnsafeAppendBoolsToBitmap(valid []bool, length int) {
+	b.unsafeAppendBoolsToBitmap(valid, length)
+}
+
+// unsafeAppendNulls appends n null entries to the validity bitmap, which
+// must have enough capacity.
+func (b *builder) unsafeAppendNulls(n int) {
+	bitutil.SetBitsTo(b.nullBitmap.Bytes(), b.length, n, false)
+	b.nulls += n
+	b.length += n
+}
+
 func (b *builder) UnsafeAppendBoolToBitmap(isValid bool) {
 	if isValid {
 		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
@@ -208,10 +232,13 @@ func (b *builder) UnsafeAppendBoolToBitmap(isValid bool) {
 	b.length++
 }
 
-func newBuilder(mem memory.Allocator, dtype arrow.DataType) Builder {
+// NewBuilder returns a builder for arrays of type dtype, using mem to allocate
+// memory. NewBuilder panics if dtype is not supported.
+func NewBuilder(mem memory.Allocator, dtype arrow.DataType) Builder {
 	// FIXME(sbinet): use a type switch on dtype instead?
 	switch dtype.ID() {
 	case arrow.NULL:
+		return NewNullBuilder(mem)
 	case arrow.BOOL:
 		return NewBooleanBuilder(mem)
 	case arrow.UINT8:
@@ -231,6 +258,7 @@ func newBuilder(mem memory.Allocator, dtype arrow.DataType) Builder {
 	case arrow.INT64:
 		return NewInt64Builder(mem)
 	case arrow.HALF_FLOAT:
+		return NewFloat16Builder(mem)
 	case arrow.FLOAT32:
 		return NewFloat32Builder(mem)
 	case arrow.FLOAT64:
@@ -243,8 +271,12 @@ func newBuilder(mem memory.Allocator, dtype arrow.DataType) Builder {
 		typ := dtype.(*arrow.FixedSizeBinaryType)
 		return NewFixedSizeBinaryBuilder(mem, typ)
 	case arrow.DATE32:
+		return NewDate32Builder(mem)
 	case arrow.DATE64:
+		return NewDate64Builder(mem)
 	case arrow.TIMESTAMP:
+		typ := dtype.(*arrow.TimestampType)
+		return NewTimestampBuilder(mem, typ)
 	case arrow.TIME32:
 		typ := dtype.(*arrow.Time32Type)
 		return NewTime32Builder(mem, typ)
@@ -252,6 +284,14 @@ func newBuilder(mem memory.Allocator, dtype arrow.DataType) Builder {
 		typ := dtype.(*arrow.Time64Type)
 		return NewTime64Builder(mem, typ)
 	case arrow.INTERVAL:
+		switch dtype.(type) {
+		case *arrow.MonthIntervalType:
+			return NewMonthIntervalBuilder(mem)
+		case *arrow.DayTimeIntervalType:
+			return NewDayTimeIntervalBuilder(mem)
+		case *arrow.MonthDayNanoIntervalType:
+			return NewMonthDayNanoIntervalBuilder(mem)
+		}
 	case arrow.DECIMAL:
 	case arrow.LIST:
 		typ := dtype.(*arrow.ListType)
@@ -260,8 +300,40 @@ func newBuilder(mem memory.Allocator, dtype arrow.DataType) Builder {
 		typ := dtype.(*arrow.StructType)
 		return NewStructBuilder(mem, typ)
 	case arrow.UNION:
+		typ := dtype.(*arrow.UnionType)
+		return NewUnionBuilder(mem, typ)
 	case arrow.DICTIONARY:
+		typ := dtype.(*arrow.DictionaryType)
+		return NewDictionaryBuilder(mem, typ)
 	case arrow.MAP:
+		typ := dtype.(*arrow.MapType)
+		return NewMapBuilder(mem, typ.KeyType(), typ.ItemType(), typ.KeysSorted)
+	case arrow.DECIMAL256:
+		typ := dtype.(*arrow.Decimal256Type)
+		return NewDecimal256Builder(mem, typ)
+	case arrow.LARGE_STRING:
+		return NewLargeStringBuilder(mem)
+	case arrow.LARGE_BINARY:
+		return NewLargeBinaryBuilder(mem, arrow.BinaryTypes.LargeBinary)
+	case arrow.LARGE_LIST:
+		typ := dtype.(*arrow.LargeListType)
+		return NewLargeListBuilder(mem, typ.Elem())
+	case arrow.FIXED_SIZE_LIST:
+		typ := dtype.(*arrow.FixedSizeListType)
+		return NewFixedSizeListBuilder(mem, typ.Len(), typ.Elem())
+	case arrow.EXTENSION:
+		typ := dtype.(arrow.ExtensionType)
+		return NewExtensionBuilder(mem, typ)
+	case arrow.RUN_END_ENCODED:
+		typ := dtype.(*arrow.RunEndEncodedType)
+		return NewRunEndEncodedBuilder(mem, typ.RunEnds(), typ.Encoded())
+	case arrow.DURATION:
+		typ := dtype.(*arrow.DurationType)
+		return NewDurationBuilder(mem, typ)
+	case arrow.STRING_VIEW:
+		return NewStringViewBuilder(mem)
+	case arrow.BINARY_VIEW:
+		return NewBinaryViewBuilder(mem, arrow.BinaryTypes.BinaryView)
 	}
 	panic(fmt.Errorf("arrow/array: unsupported builder for %T", dtype))
 }
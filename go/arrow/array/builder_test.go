@@ -56,6 +56,17 @@ func TestBuilder_UnsafeSetValid(t *testing.T) {
 	assert.Equal(t, []byte{0xe0, 0xff, 0x3f, 0}, ab.nullBitmap.Bytes())
 }
 
+func TestBuilder_UnsafeAppendNulls(t *testing.T) {
+	ab := &builder{mem: memory.NewGoAllocator()}
+	ab.init(32)
+	ab.UnsafeAppendBoolsToBitmap(nil, 3)
+	ab.unsafeAppendNulls(10)
+	ab.UnsafeAppendBoolsToBitmap(tools.Bools(1, 0, 1), 3)
+	assert.Equal(t, 16, ab.Len())
+	assert.Equal(t, 11, ab.NullN())
+	assert.Equal(t, []byte{0x07, 0xa0, 0, 0}, ab.nullBitmap.Bytes())
+}
+
 func TestBuilder_resize(t *testing.T) {
 	b := &builder{mem: memory.NewGoAllocator()}
 	n := 64
@@ -0,0 +1,353 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/apache/arrow/go/arrow"
+)
+
+// EqualOption configures the comparison of arrays and records.
+type EqualOption func(*equalConfig)
+
+type equalConfig struct {
+	approx      bool
+	atol        float64
+	nans        bool // whether NaNs are equal to each other
+	signedZeros bool // whether -0 and +0 differ
+	noNullable  bool // whether to ignore the nullability of fields
+	noMetadata  bool // whether to ignore the metadata of fields
+}
+
+const defaultAbsTolerance = 1e-5
+
+// WithAbsTolerance sets the absolute tolerance within which floating point
+// values are approximately equal. The default is 1e-5.
+// It only applies to ArrayApproxEqual and RecordApproxEqual.
+func WithAbsTolerance(atol float64) EqualOption {
+	return func(cfg *equalConfig) { cfg.atol = atol }
+}
+
+// WithNaNsEqual sets whether floating point NaN values are equal to each
+// other. The default is false, as for the == operator.
+func WithNaNsEqual(eq bool) EqualOption {
+	return func(cfg *equalConfig) { cfg.nans = eq }
+}
+
+// WithSignedZerosEqual sets whether the floating point values -0 and +0 are
+// equal. The default is true, as for the == operator.
+func WithSignedZerosEqual(eq bool) EqualOption {
+	return func(cfg *equalConfig) { cfg.signedZeros = !eq }
+}
+
+// WithIgnoreNullability sets whether the nullability of fields is ignored
+// when comparing data types and schemas. The default is false.
+func WithIgnoreNullability(ignore bool) EqualOption {
+	return func(cfg *equalConfig) { cfg.noNullable = ignore }
+}
+
+// WithIgnoreMetadata sets whether the metadata of fields is ignored when
+// comparing data types and schemas. The default is false.
+func WithIgnoreMetadata(ignore bool) EqualOption {
+	return func(cfg *equalConfig) { cfg.noMetadata = ignore }
+}
+
+func newEqualConfig(approx bool, opts []EqualOption) *equalConfig {
+	cfg := &equalConfig{approx: approx, atol: defaultAbsTolerance}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// ArrayEqual reports whether left and right have the same data type, the
+// same length, the same nulls and the same valid values.
+func ArrayEqual(left, right Interface, opts ...EqualOption) bool {
+	return arrayEqual(left, right, newEqualConfig(false, opts))
+}
+
+// ArrayApproxEqual reports whether left and right are equal, their
+// floating point values being compared within a tolerance.
+func ArrayApproxEqual(left, right Interface, opts ...EqualOption) bool {
+	return arrayEqual(left, right, newEqualConfig(true, opts))
+}
+
+// RecordEqual reports whether left and right have equal schemas, the same
+// number of rows and equal columns.
+func RecordEqual(left, right Record, opts ...EqualOption) bool {
+	return Diff(left, right, opts...) == nil
+}
+
+// RecordApproxEqual reports whether left and right are equal, the floating
+// point values of their columns being compared within a tolerance.
+func RecordApproxEqual(left, right Record, opts ...EqualOption) bool {
+	return diff(left, right, newEqualConfig(true, opts)) == nil
+}
+
+// Difference describes the first difference between two records.
+type Difference struct {
+	Row    int64  // Row is the index of the differing row, or -1.
+	Column int    // Column is the index of the differing column, or -1.
+	Left   string // Left describes the value on the left side.
+	Right  string // Right describes the value on the right side.
+	msg    string
+}
+
+func (d *Difference) String() string {
+	if d.Row < 0 {
+		return fmt.Sprintf("%s: left=%s, right=%s", d.msg, d.Left, d.Right)
+	}
+	return fmt.Sprintf("row %d, %s: left=%s, right=%s", d.Row, d.msg, d.Left, d.Right)
+}
+
+// Diff returns the first difference between left and right, scanning the
+// rows in order and the columns of each row in order, or nil if the
+// records are equal.
+// Values are rendered with FormatValue.
+func Diff(left, right Record, opts ...EqualOption) *Difference {
+	return diff(left, right, newEqualConfig(false, opts))
+}
+
+func diff(left, right Record, cfg *equalConfig) *Difference {
+	if !schemaEqual(left.Schema(), right.Schema(), cfg) {
+		return &Difference{
+			Row: -1, Column: -1, msg: "schemas differ",
+			Left: schemaString(left.Schema()), Right: schemaString(right.Schema()),
+		}
+	}
+	if left.NumRows() != right.NumRows() {
+		return &Difference{
+			Row: -1, Column: -1, msg: "numbers of rows differ",
+			Left: fmt.Sprint(left.NumRows()), Right: fmt.Sprint(right.NumRows()),
+		}
+	}
+	for i := 0; i < int(left.NumRows()); i++ {
+		for j := 0; j < int(left.NumCols()); j++ {
+			l, r := left.Column(j), right.Column(j)
+			if !elemEqual(l, r, i, i, cfg) {
+				return &Difference{
+					Row: int64(i), Column: j, msg: fmt.Sprintf("column %q", left.ColumnName(j)),
+					Left: FormatValue(l, i), Right: FormatValue(r, i),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func schemaString(sc *arrow.Schema) string {
+	o := new(bytes.Buffer)
+	o.WriteString("{")
+	for i, f := range sc.Fields() {
+		if i > 0 {
+			o.WriteString(", ")
+		}
+		fmt.Fprintf(o, "%s: %s", f.Name, f.Type.Name())
+		if f.Nullable {
+			o.WriteString("?")
+		}
+	}
+	o.WriteString("}")
+	return o.String()
+}
+
+func arrayEqual(left, right Interface, cfg *equalConfig) bool {
+	if !typeEqual(left.DataType(), right.DataType(), cfg) || left.Len() != right.Len() {
+		return false
+	}
+	return rangeEqual(left, right, 0, 0, left.Len(), cfg)
+}
+
+func schemaEqual(left, right *arrow.Schema, cfg *equalConfig) bool {
+	if !cfg.noNullable && !cfg.noMetadata {
+		return left.Equal(right)
+	}
+	if len(left.Fields()) != len(right.Fields()) {
+		return false
+	}
+	for i, f := range left.Fields() {
+		if !reflect.DeepEqual(normalizeField(f, cfg), normalizeField(right.Field(i), cfg)) {
+			return false
+		}
+	}
+	return true
+}
+
+func typeEqual(left, right arrow.DataType, cfg *equalConfig) bool {
+	if !cfg.noNullable && !cfg.noMetadata {
+		return reflect.DeepEqual(left, right)
+	}
+	return reflect.DeepEqual(normalizeType(left, cfg), normalizeType(right, cfg))
+}
+
+// normalizeField returns f, and the fields of its type, without the
+// nullability or metadata ignored by cfg.
+func normalizeField(f arrow.Field, cfg *equalConfig) arrow.Field {
+	f.Type = normalizeType(f.Type, cfg)
+	if cfg.noNullable {
+		f.Nullable = false
+	}
+	if cfg.noMetadata {
+		f.Metadata = arrow.Metadata{}
+	}
+	return f
+}
+
+func normalizeFields(fs []arrow.Field, cfg *equalConfig) []arrow.Field {
+	out := make([]arrow.Field, len(fs))
+	for i, f := range fs {
+		out[i] = normalizeField(f, cfg)
+	}
+	return out
+}
+
+// normalizeType returns dt, its nested fields being normalized by
+// normalizeField.
+func normalizeType(dt arrow.DataType, cfg *equalConfig) arrow.DataType {
+	switch dt := dt.(type) {
+	case *arrow.ListType:
+		return arrow.ListOf(normalizeType(dt.Elem(), cfg))
+	case *arrow.LargeListType:
+		return arrow.LargeListOf(normalizeType(dt.Elem(), cfg))
+	case *arrow.FixedSizeListType:
+		return arrow.FixedSizeListOf(dt.Len(), normalizeType(dt.Elem(), cfg))
+	case *arrow.StructType:
+		return arrow.StructOf(normalizeFields(dt.Fields(), cfg)...)
+	case *arrow.MapType:
+		m := arrow.MapOf(normalizeType(dt.KeyType(), cfg), normalizeType(dt.ItemType(), cfg))
+		m.KeysSorted = dt.KeysSorted
+		return m
+	case *arrow.UnionType:
+		return arrow.UnionOf(dt.Mode(), normalizeFields(dt.Fields(), cfg), dt.TypeCodes())
+	case *arrow.DictionaryType:
+		d := arrow.DictionaryOf(normalizeType(dt.IndexType(), cfg), normalizeType(dt.ValueType(), cfg))
+		d.Ordered = dt.Ordered
+		return d
+	case *arrow.RunEndEncodedType:
+		return arrow.RunEndEncodedOf(dt.RunEnds(), normalizeType(dt.Encoded(), cfg))
+	}
+	return dt
+}
+
+// rangeEqual reports whether the n elements of left starting at i are equal
+// to the n elements of right starting at j.
+func rangeEqual(left, right Interface, i, j, n int, cfg *equalConfig) bool {
+	for k := 0; k < n; k++ {
+		if !elemEqual(left, right, i+k, j+k, cfg) {
+			return false
+		}
+	}
+	return true
+}
+
+// elemEqual reports whether the i-th element of left is equal to the j-th
+// element of right, the arrays having the same data type.
+func elemEqual(left, right Interface, i, j int, cfg *equalConfig) bool {
+	if left.IsNull(i) || right.IsNull(j) {
+		return left.IsNull(i) && right.IsNull(j)
+	}
+
+	switch l := left.(type) {
+	case *Null:
+		return true
+	case *Boolean:
+		return l.Value(i) == right.(*Boolean).Value(j)
+	case *Float16:
+		return floatEqual(float64(l.Value(i).Float32()), float64(right.(*Float16).Value(j).Float32()), cfg)
+	case *Float32:
+		return floatEqual(float64(l.Value(i)), float64(right.(*Float32).Value(j)), cfg)
+	case *Float64:
+		return floatEqual(l.Value(i), right.(*Float64).Value(j), cfg)
+	case *String:
+		return l.Value(i) == right.(*String).Value(j)
+	case *LargeString:
+		return l.Value(i) == right.(*LargeString).Value(j)
+	case *StringView:
+		return l.Value(i) == right.(*StringView).Value(j)
+	case *Binary:
+		return bytes.Equal(l.Value(i), right.(*Binary).Value(j))
+	case *LargeBinary:
+		return bytes.Equal(l.Value(i), right.(*LargeBinary).Value(j))
+	case *BinaryView:
+		return bytes.Equal(l.Value(i), right.(*BinaryView).Value(j))
+	case *FixedSizeBinary:
+		return bytes.Equal(l.Value(i), right.(*FixedSizeBinary).Value(j))
+	case *Map:
+		r := right.(*Map)
+		lo, ro := l.Offsets(), r.Offsets()
+		li, ri := l.data.offset+i, r.data.offset+j
+		n := int(lo[li+1] - lo[li])
+		return n == int(ro[ri+1]-ro[ri]) && rangeEqual(l.values, r.values, int(lo[li]), int(ro[ri]), n, cfg)
+	case *List:
+		r := right.(*List)
+		lo, ro := l.Offsets(), r.Offsets()
+		li, ri := l.data.offset+i, r.data.offset+j
+		n := int(lo[li+1] - lo[li])
+		return n == int(ro[ri+1]-ro[ri]) && rangeEqual(l.values, r.values, int(lo[li]), int(ro[ri]), n, cfg)
+	case *LargeList:
+		r := right.(*LargeList)
+		lo, ro := l.Offsets(), r.Offsets()
+		li, ri := l.data.offset+i, r.data.offset+j
+		n := int(lo[li+1] - lo[li])
+		return n == int(ro[ri+1]-ro[ri]) && rangeEqual(l.values, r.values, int(lo[li]), int(ro[ri]), n, cfg)
+	case *FixedSizeList:
+		r := right.(*FixedSizeList)
+		lb, le := l.ValueOffsets(i)
+		rb, re := r.ValueOffsets(j)
+		return le-lb == re-rb && rangeEqual(l.values, r.values, int(lb), int(rb), int(le-lb), cfg)
+	case *Struct:
+		r := right.(*Struct)
+		for k, f := range l.fields {
+			if !elemEqual(f, r.fields[k], i, j, cfg) {
+				return false
+			}
+		}
+		return true
+	case *Union:
+		r := right.(*Union)
+		return l.TypeCode(i) == r.TypeCode(j) &&
+			elemEqual(l.Field(l.ChildID(i)), r.Field(r.ChildID(j)), l.ValueOffset(i), r.ValueOffset(j), cfg)
+	case *RunEndEncoded:
+		r := right.(*RunEndEncoded)
+		return elemEqual(l.values, r.values, l.PhysicalIndex(i), r.PhysicalIndex(j), cfg)
+	case *Dictionary:
+		r := right.(*Dictionary)
+		return elemEqual(l.dict, r.dict, l.GetValueIndex(i), r.GetValueIndex(j), cfg)
+	case ExtensionArray:
+		return elemEqual(l.Storage(), right.(ExtensionArray).Storage(), i, j, cfg)
+	}
+
+	// fixed-width values, compared by their bytes.
+	bw := left.DataType().(arrow.FixedWidthDataType).BitWidth() / 8
+	lb := left.Data().buffers[1].Bytes()[(left.Data().offset+i)*bw:]
+	rb := right.Data().buffers[1].Bytes()[(right.Data().offset+j)*bw:]
+	return bytes.Equal(lb[:bw], rb[:bw])
+}
+
+func floatEqual(x, y float64, cfg *equalConfig) bool {
+	switch {
+	case x == y:
+		return !cfg.signedZeros || x != 0 || math.Signbit(x) == math.Signbit(y)
+	case math.IsNaN(x) || math.IsNaN(y):
+		return cfg.nans && math.IsNaN(x) && math.IsNaN(y)
+	}
+	return cfg.approx && math.Abs(x-y) <= cfg.atol
+}
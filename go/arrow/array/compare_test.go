@@ -0,0 +1,220 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCompareRecord(mem memory.Allocator, ids []int32, xs []float64, tags [][]string) array.Record {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "x", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "tags", Type: arrow.ListOf(arrow.BinaryTypes.String), Nullable: true},
+	}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int32Builder).AppendValues(ids, nil)
+	xb := b.Field(1).(*array.Float64Builder)
+	for _, x := range xs {
+		if math.IsNaN(x) {
+			xb.AppendNull()
+			continue
+		}
+		xb.Append(x)
+	}
+	lb := b.Field(2).(*array.ListBuilder)
+	vb := lb.ValueBuilder().(*array.StringBuilder)
+	for _, tag := range tags {
+		if tag == nil {
+			lb.AppendNull()
+			continue
+		}
+		lb.Append(true)
+		vb.AppendValues(tag, nil)
+	}
+	return b.NewRecord()
+}
+
+func TestRecordEqual(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ids := []int32{1, 2, 3}
+	tags := [][]string{{"a"}, nil, {"b", "c"}}
+	rec := newCompareRecord(mem, ids, []float64{0.5, math.NaN(), 2}, tags)
+	defer rec.Release()
+	same := newCompareRecord(mem, ids, []float64{0.5, math.NaN(), 2}, tags)
+	defer same.Release()
+	near := newCompareRecord(mem, ids, []float64{0.5, math.NaN(), 2.000001}, tags)
+	defer near.Release()
+
+	assert.True(t, array.RecordEqual(rec, same))
+	assert.Nil(t, array.Diff(rec, same))
+	assert.False(t, array.RecordEqual(rec, near))
+	assert.True(t, array.RecordApproxEqual(rec, near))
+	assert.False(t, array.RecordApproxEqual(rec, near, array.WithAbsTolerance(1e-9)))
+
+	d := array.Diff(rec, near)
+	if assert.NotNil(t, d) {
+		assert.Equal(t, int64(2), d.Row)
+		assert.Equal(t, 1, d.Column)
+		assert.Equal(t, `row 2, column "x": left=2, right=2.000001`, d.String())
+	}
+
+	other := newCompareRecord(mem, ids, []float64{0.5, math.NaN(), 2}, [][]string{{"a"}, nil, {"b", "d"}})
+	defer other.Release()
+	d = array.Diff(rec, other)
+	if assert.NotNil(t, d) {
+		assert.Equal(t, `row 2, column "tags": left=["b" "c"], right=["b" "d"]`, d.String())
+	}
+
+	slice := rec.NewSlice(1, 3)
+	defer slice.Release()
+	d = array.Diff(rec, slice)
+	if assert.NotNil(t, d) {
+		assert.Equal(t, "numbers of rows differ: left=3, right=2", d.String())
+	}
+	tail := newCompareRecord(mem, ids[1:], []float64{math.NaN(), 2}, tags[1:])
+	defer tail.Release()
+	assert.True(t, array.RecordEqual(slice, tail))
+
+	proj := array.ProjectRecord(rec, 0, 1)
+	defer proj.Release()
+	d = array.Diff(rec, proj)
+	if assert.NotNil(t, d) {
+		assert.Equal(t, -1, d.Column)
+		assert.Equal(t, "schemas differ: left={id: int32, x: float64?, tags: list?}, right={id: int32, x: float64?}", d.String())
+	}
+}
+
+func TestArrayEqual(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ib := array.NewInt64Builder(mem)
+	defer ib.Release()
+	ib.AppendValues([]int64{1, 2, 3, 1, 2}, []bool{true, false, true, true, false})
+	ints := ib.NewInt64Array()
+	defer ints.Release()
+
+	a := array.NewSlice(ints, 0, 2)
+	defer a.Release()
+	b := array.NewSlice(ints, 3, 5)
+	defer b.Release()
+	c := array.NewSlice(ints, 1, 3)
+	defer c.Release()
+
+	assert.True(t, array.ArrayEqual(a, b))
+	assert.False(t, array.ArrayEqual(a, c))
+	assert.False(t, array.ArrayEqual(a, ints))
+
+	ub := array.NewUint64Builder(mem)
+	defer ub.Release()
+	ub.AppendValues([]uint64{1, 0}, []bool{true, false})
+	uints := ub.NewUint64Array()
+	defer uints.Release()
+	assert.False(t, array.ArrayEqual(a, uints))
+
+	fb := array.NewFloat32Builder(mem)
+	defer fb.Release()
+	fb.AppendValues([]float32{1, 2, 1.000001, float32(math.NaN())}, nil)
+	floats := fb.NewFloat32Array()
+	defer floats.Release()
+
+	x := array.NewSlice(floats, 0, 1)
+	defer x.Release()
+	y := array.NewSlice(floats, 2, 3)
+	defer y.Release()
+	nan := array.NewSlice(floats, 3, 4)
+	defer nan.Release()
+	assert.False(t, array.ArrayEqual(x, y))
+	assert.True(t, array.ArrayApproxEqual(x, y))
+	assert.False(t, array.ArrayEqual(nan, nan))
+}
+
+func TestEqualOptions(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	fb := array.NewFloat64Builder(mem)
+	defer fb.Release()
+	fb.AppendValues([]float64{math.NaN(), 0, math.Copysign(0, -1), 1}, nil)
+	floats := fb.NewFloat64Array()
+	defer floats.Release()
+
+	nan := array.NewSlice(floats, 0, 1)
+	defer nan.Release()
+	zero := array.NewSlice(floats, 1, 2)
+	defer zero.Release()
+	negZero := array.NewSlice(floats, 2, 3)
+	defer negZero.Release()
+	one := array.NewSlice(floats, 3, 4)
+	defer one.Release()
+
+	assert.False(t, array.ArrayEqual(nan, nan))
+	assert.True(t, array.ArrayEqual(nan, nan, array.WithNaNsEqual(true)))
+	assert.True(t, array.ArrayApproxEqual(nan, nan, array.WithNaNsEqual(true)))
+	assert.False(t, array.ArrayEqual(nan, one, array.WithNaNsEqual(true)))
+
+	assert.True(t, array.ArrayEqual(zero, negZero))
+	assert.False(t, array.ArrayEqual(zero, negZero, array.WithSignedZerosEqual(false)))
+	assert.True(t, array.ArrayEqual(zero, zero, array.WithSignedZerosEqual(false)))
+
+	// the same values, with a nested field differing in nullability and
+	// metadata.
+	typ := func(nullable bool, md arrow.Metadata) arrow.DataType {
+		return arrow.ListOf(arrow.StructOf(arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Int32, Nullable: nullable, Metadata: md}))
+	}
+	build := func(dt arrow.DataType) array.Interface {
+		b := array.NewBuilder(mem, dt)
+		defer b.Release()
+		if err := b.UnmarshalJSON([]byte(`[[{"x": 1}], null]`)); err != nil {
+			t.Fatal(err)
+		}
+		return b.NewArray()
+	}
+	left := build(typ(true, arrow.Metadata{}))
+	defer left.Release()
+	right := build(typ(false, arrow.NewMetadata([]string{"k"}, []string{"v"})))
+	defer right.Release()
+
+	assert.False(t, array.ArrayEqual(left, right))
+	assert.False(t, array.ArrayEqual(left, right, array.WithIgnoreNullability(true)))
+	assert.False(t, array.ArrayEqual(left, right, array.WithIgnoreMetadata(true)))
+	assert.True(t, array.ArrayEqual(left, right, array.WithIgnoreNullability(true), array.WithIgnoreMetadata(true)))
+
+	schema := func(nullable bool, md arrow.Metadata) *arrow.Schema {
+		return arrow.NewSchema([]arrow.Field{{Name: "v", Type: typ(nullable, md), Nullable: nullable, Metadata: md}}, nil)
+	}
+	lrec := array.NewRecord(schema(true, arrow.Metadata{}), []array.Interface{left}, int64(left.Len()))
+	defer lrec.Release()
+	rrec := array.NewRecord(schema(false, arrow.NewMetadata([]string{"k"}, []string{"v"})), []array.Interface{right}, int64(right.Len()))
+	defer rrec.Release()
+
+	assert.False(t, array.RecordEqual(lrec, rrec))
+	assert.False(t, array.RecordEqual(lrec, rrec, array.WithIgnoreMetadata(true)))
+	assert.True(t, array.RecordEqual(lrec, rrec, array.WithIgnoreNullability(true), array.WithIgnoreMetadata(true)))
+	assert.Nil(t, array.Diff(lrec, rrec, array.WithIgnoreNullability(true), array.WithIgnoreMetadata(true)))
+}
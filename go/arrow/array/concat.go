@@ -0,0 +1,518 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/internal/bitutil"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// Concatenate creates a new array holding the values of arrs, one after the
+// other, in a single contiguous set of buffers allocated with mem.
+//
+// All the arrays must have the same data type. The data buffers of binary
+// and string view arrays are shared with the result rather than copied.
+// The returned array must be Release()'d after use.
+func Concatenate(mem memory.Allocator, arrs ...Interface) (Interface, error) {
+	if len(arrs) == 0 {
+		return nil, errors.New("arrow/array: no arrays to concatenate")
+	}
+
+	in := make([]*Data, len(arrs))
+	for i, arr := range arrs {
+		in[i] = arr.Data()
+	}
+	data, err := concatData(mem, in)
+	if err != nil {
+		return nil, err
+	}
+	defer data.Release()
+	return MakeFromData(data), nil
+}
+
+// ConcatenateRecords creates a new record holding the rows of recs, one
+// after the other, each column being concatenated with Concatenate.
+//
+// All the records must have the same schema.
+// The returned record must be Release()'d after use.
+func ConcatenateRecords(mem memory.Allocator, recs ...Record) (Record, error) {
+	if len(recs) == 0 {
+		return nil, errors.New("arrow/array: no records to concatenate")
+	}
+
+	schema := recs[0].Schema()
+	for _, rec := range recs[1:] {
+		if !rec.Schema().Equal(schema) {
+			return nil, errors.New("arrow/array: mismatched record schemas")
+		}
+	}
+
+	var (
+		rows int64
+		cols = make([]Interface, len(schema.Fields()))
+		arrs = make([]Interface, len(recs))
+	)
+	for _, rec := range recs {
+		rows += rec.NumRows()
+	}
+	defer func() {
+		for _, col := range cols {
+			if col != nil {
+				col.Release()
+			}
+		}
+	}()
+
+	for i := range cols {
+		for j, rec := range recs {
+			arrs[j] = rec.Column(i)
+		}
+		col, err := Concatenate(mem, arrs...)
+		if err != nil {
+			return nil, fmt.Errorf("arrow/array: column %q: %w", schema.Field(i).Name, err)
+		}
+		cols[i] = col
+	}
+
+	return NewRecord(schema, cols, rows), nil
+}
+
+func concatData(mem memory.Allocator, in []*Data) (*Data, error) {
+	dtype := in[0].dtype
+	length := 0
+	for _, data := range in {
+		if !reflect.DeepEqual(data.dtype, dtype) {
+			return nil, fmt.Errorf("arrow/array: mismatched data types %s and %s", dtype.Name(), data.dtype.Name())
+		}
+		length += data.length
+	}
+
+	var (
+		buffers  []*memory.Buffer
+		children []*Data
+		nulls    = 0
+	)
+	defer func() {
+		for _, b := range buffers {
+			if b != nil {
+				b.Release()
+			}
+		}
+		for _, c := range children {
+			c.Release()
+		}
+	}()
+
+	validity := func() *memory.Buffer {
+		var buf *memory.Buffer
+		buf, nulls = concatValidity(mem, in, length)
+		return buf
+	}
+
+	switch dt := dtype.(type) {
+	case arrow.ExtensionType:
+		storage := make([]*Data, len(in))
+		for i, data := range in {
+			storage[i] = NewData(dt.StorageType(), data.length, data.buffers, data.childData, data.nulls, data.offset)
+			defer storage[i].Release()
+		}
+		data, err := concatData(mem, storage)
+		if err != nil {
+			return nil, err
+		}
+		defer data.Release()
+		return NewData(dt, data.length, data.buffers, data.childData, data.nulls, data.offset), nil
+
+	case *arrow.NullType:
+		return NewData(dt, length, []*memory.Buffer{nil}, nil, length, 0), nil
+
+	case *arrow.DictionaryType:
+		// the dictionaries are unified and the indices concatenated.
+		arrs := make([]Interface, len(in))
+		for i, data := range in {
+			arrs[i] = MakeFromData(data)
+			defer arrs[i].Release()
+		}
+		unified, err := UnifyDictionaries(mem, arrs)
+		if err != nil {
+			return nil, err
+		}
+		indices := make([]*Data, len(unified))
+		for i, arr := range unified {
+			defer arr.Release()
+			indices[i] = arr.(*Dictionary).indices.Data()
+		}
+		data, err := concatData(mem, indices)
+		if err != nil {
+			return nil, err
+		}
+		defer data.Release()
+		return NewDataWithDictionary(dt, length, data.buffers, data.nulls, 0, unified[0].Data().dictionary), nil
+
+	case *arrow.BooleanType:
+		buffers = []*memory.Buffer{validity(), concatBits(mem, in, length)}
+
+	case *arrow.FixedSizeBinaryType:
+		offsets := newBuffer(mem, (length+1)*arrow.Int32SizeBytes)
+		values := newBuffer(mem, length*dt.ByteWidth)
+		buffers = []*memory.Buffer{validity(), offsets, values}
+
+		offs := arrow.Int32Traits.CastFromBytes(offsets.Bytes())
+		for i := range offs {
+			offs[i] = int32(i * dt.ByteWidth)
+		}
+		pos := 0
+		for _, data := range in {
+			arr := NewFixedSizeBinaryData(data)
+			for i := 0; i < data.length; i++ {
+				copy(values.Bytes()[pos:pos+dt.ByteWidth], arr.Value(i))
+				pos += dt.ByteWidth
+			}
+			arr.Release()
+		}
+
+	case arrow.FixedWidthDataType:
+		width := dt.BitWidth() / 8
+		values := newBuffer(mem, length*width)
+		buffers = []*memory.Buffer{validity(), values}
+
+		pos := 0
+		for _, data := range in {
+			n := data.length * width
+			copy(values.Bytes()[pos:pos+n], bytesOf(data.buffers[1])[data.offset*width:])
+			pos += n
+		}
+
+	case *arrow.BinaryType, *arrow.StringType, *arrow.LargeBinaryType, *arrow.LargeStringType:
+		large := dt.ID() == arrow.LARGE_BINARY || dt.ID() == arrow.LARGE_STRING
+		offsets, ranges, err := concatOffsets(mem, in, length, large)
+		if err != nil {
+			return nil, err
+		}
+		buffers = []*memory.Buffer{validity(), offsets, nil}
+
+		size := 0
+		for _, r := range ranges {
+			size += r[1] - r[0]
+		}
+		values := newBuffer(mem, size)
+		buffers[2] = values
+		pos := 0
+		for i, data := range in {
+			pos += copy(values.Bytes()[pos:], bytesOf(data.buffers[2])[ranges[i][0]:ranges[i][1]])
+		}
+
+	case arrow.BinaryViewDataType:
+		// the data buffers are shared, and the views rebased on the
+		// index of the first data buffer of their input.
+		views := newBuffer(mem, length*arrow.ViewSizeBytes)
+		buffers = []*memory.Buffer{validity(), views}
+
+		pos, base := 0, 0
+		for _, data := range in {
+			src := bytesOf(data.buffers[1])[data.offset*arrow.ViewSizeBytes : (data.offset+data.length)*arrow.ViewSizeBytes]
+			dst := views.Bytes()[pos : pos+len(src)]
+			copy(dst, src)
+			for i := 0; i < len(dst); i += arrow.ViewSizeBytes {
+				if viewLen(dst, i/arrow.ViewSizeBytes) > viewInlineSize {
+					k := binary.LittleEndian.Uint32(dst[i+8:])
+					binary.LittleEndian.PutUint32(dst[i+8:], k+uint32(base))
+				}
+			}
+			pos += len(src)
+			for _, b := range data.buffers[2:] {
+				if b != nil {
+					b.Retain()
+				}
+				buffers = append(buffers, b)
+			}
+			base += len(data.buffers) - 2
+		}
+
+	case *arrow.ListType, *arrow.LargeListType, *arrow.MapType:
+		offsets, ranges, err := concatOffsets(mem, in, length, dt.ID() == arrow.LARGE_LIST)
+		if err != nil {
+			return nil, err
+		}
+		buffers = []*memory.Buffer{validity(), offsets}
+
+		child, err := concatChildren(mem, in, 0, func(i int) (int, int) { return ranges[i][0], ranges[i][1] })
+		if err != nil {
+			return nil, err
+		}
+		children = []*Data{child}
+
+	case *arrow.FixedSizeListType:
+		n := int(dt.Len())
+		buffers = []*memory.Buffer{validity()}
+
+		child, err := concatChildren(mem, in, 0, func(i int) (int, int) {
+			return in[i].offset * n, (in[i].offset + in[i].length) * n
+		})
+		if err != nil {
+			return nil, err
+		}
+		children = []*Data{child}
+
+	case *arrow.StructType:
+		buffers = []*memory.Buffer{validity(), nil}
+		for k := range dt.Fields() {
+			child, err := concatChildren(mem, in, k, func(i int) (int, int) {
+				return in[i].offset, in[i].offset + in[i].length
+			})
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+
+	case *arrow.UnionType:
+		types := newBuffer(mem, length)
+		buffers = []*memory.Buffer{validity(), types, nil}
+
+		pos := 0
+		for _, data := range in {
+			pos += copy(types.Bytes()[pos:], bytesOf(data.buffers[1])[data.offset:data.offset+data.length])
+		}
+
+		switch dt.Mode() {
+		case arrow.SparseMode:
+			for k := range dt.Fields() {
+				child, err := concatChildren(mem, in, k, func(i int) (int, int) {
+					return in[i].offset, in[i].offset + in[i].length
+				})
+				if err != nil {
+					return nil, err
+				}
+				children = append(children, child)
+			}
+		case arrow.DenseMode:
+			offsets := newBuffer(mem, length*arrow.Int32SizeBytes)
+			buffers[2] = offsets
+
+			var (
+				offs  = arrow.Int32Traits.CastFromBytes(offsets.Bytes())
+				bases = make([]int, len(dt.Fields()))
+				pos   = 0
+			)
+			for _, data := range in {
+				codes := arrow.Int8Traits.CastFromBytes(bytesOf(data.buffers[1]))
+				src := arrow.Int32Traits.CastFromBytes(bytesOf(data.buffers[2]))
+				for i := data.offset; i < data.offset+data.length; i++ {
+					v := int(src[i]) + bases[dt.ChildID(codes[i])]
+					if v > math.MaxInt32 {
+						return nil, errors.New("arrow/array: union offsets overflow int32")
+					}
+					offs[pos] = int32(v)
+					pos++
+				}
+				for k := range bases {
+					bases[k] += data.childData[k].length
+				}
+			}
+			for k := range dt.Fields() {
+				child, err := concatChildren(mem, in, k, func(i int) (int, int) {
+					return 0, in[i].childData[k].length
+				})
+				if err != nil {
+					return nil, err
+				}
+				children = append(children, child)
+			}
+		}
+
+	case *arrow.RunEndEncodedType:
+		if length > maxRunEnd(dt.RunEnds()) {
+			return nil, fmt.Errorf("arrow/array: run end %d overflows %s", length, dt.RunEnds().Name())
+		}
+
+		var (
+			ends   []int
+			ranges = make([][2]int, len(in))
+			base   = 0
+		)
+		for i, data := range in {
+			if data.length == 0 {
+				continue
+			}
+			arr := NewRunEndEncodedData(data)
+			beg, n := arr.PhysicalOffset(), arr.PhysicalLength()
+			for j := beg; j < beg+n; j++ {
+				end := arr.runEnd(j) - data.offset
+				if end > data.length {
+					end = data.length
+				}
+				ends = append(ends, base+end)
+			}
+			ranges[i] = [2]int{beg, beg + n}
+			base += data.length
+			arr.Release()
+		}
+		buffers = []*memory.Buffer{nil}
+
+		runEnds := newRunEnds(mem, dt.RunEnds(), ends)
+		defer runEnds.Release()
+		runEnds.Data().Retain()
+		children = append(children, runEnds.Data())
+
+		values, err := concatChildren(mem, in, 1, func(i int) (int, int) { return ranges[i][0], ranges[i][1] })
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, values)
+
+	default:
+		return nil, fmt.Errorf("arrow/array: unsupported data type %s for concatenation", dtype.Name())
+	}
+
+	return NewData(dtype, length, buffers, children, nulls, 0), nil
+}
+
+// concatChildren concatenates the k-th child of each element of in, within
+// the range returned by bounds.
+func concatChildren(mem memory.Allocator, in []*Data, k int, bounds func(i int) (beg, end int)) (*Data, error) {
+	children := make([]*Data, len(in))
+	for i, data := range in {
+		beg, end := bounds(i)
+		children[i] = NewSliceData(data.childData[k], int64(beg), int64(end))
+		defer children[i].Release()
+	}
+	return concatData(mem, children)
+}
+
+// concatOffsets concatenates the offsets of in, rebased so they index the
+// concatenation of the values of in. It also returns the range of values
+// spanned by each element of in.
+func concatOffsets(mem memory.Allocator, in []*Data, length int, large bool) (*memory.Buffer, [][2]int, error) {
+	var (
+		size   = arrow.Int32SizeBytes
+		ranges = make([][2]int, len(in))
+	)
+	if large {
+		size = arrow.Int64SizeBytes
+	}
+
+	buf := newBuffer(mem, (length+1)*size)
+	var (
+		offs32 []int32
+		offs64 []int64
+	)
+	if large {
+		offs64 = arrow.Int64Traits.CastFromBytes(buf.Bytes())
+	} else {
+		offs32 = arrow.Int32Traits.CastFromBytes(buf.Bytes())
+	}
+
+	pos, base := 0, 0
+	for i, data := range in {
+		if data.length == 0 {
+			continue
+		}
+		offset := func(j int) int {
+			if large {
+				return int(arrow.Int64Traits.CastFromBytes(data.buffers[1].Bytes())[j])
+			}
+			return int(arrow.Int32Traits.CastFromBytes(data.buffers[1].Bytes())[j])
+		}
+		beg, end := offset(data.offset), offset(data.offset+data.length)
+		if !large && base+end-beg > math.MaxInt32 {
+			buf.Release()
+			return nil, nil, errors.New("arrow/array: offsets overflow int32")
+		}
+		for j := 0; j < data.length; j++ {
+			v := offset(data.offset+j) - beg + base
+			if large {
+				offs64[pos+j] = int64(v)
+			} else {
+				offs32[pos+j] = int32(v)
+			}
+		}
+		ranges[i] = [2]int{beg, end}
+		pos += data.length
+		base += end - beg
+	}
+	if large {
+		offs64[length] = int64(base)
+	} else {
+		offs32[length] = int32(base)
+	}
+	return buf, ranges, nil
+}
+
+// concatValidity concatenates the validity bitmaps of in, returning a nil
+// bitmap if there are no nulls.
+func concatValidity(mem memory.Allocator, in []*Data, length int) (*memory.Buffer, int) {
+	nulls := 0
+	for _, data := range in {
+		nulls += data.NullN()
+	}
+	if nulls == 0 {
+		return nil, 0
+	}
+
+	buf := newBuffer(mem, bitutil.CeilByte(length)/8)
+	bits := buf.Bytes()
+	pos := 0
+	for _, data := range in {
+		src := bytesOf(data.buffers[0])
+		for i := 0; i < data.length; i++ {
+			if len(src) == 0 || bitutil.BitIsSet(src, data.offset+i) {
+				bitutil.SetBit(bits, pos)
+			}
+			pos++
+		}
+	}
+	return buf, nulls
+}
+
+// concatBits concatenates the bit-packed values buffers of in.
+func concatBits(mem memory.Allocator, in []*Data, length int) *memory.Buffer {
+	buf := newBuffer(mem, bitutil.CeilByte(length)/8)
+	bits := buf.Bytes()
+	pos := 0
+	for _, data := range in {
+		src := bytesOf(data.buffers[1])
+		for i := 0; i < data.length; i++ {
+			if bitutil.BitIsSet(src, data.offset+i) {
+				bitutil.SetBit(bits, pos)
+			}
+			pos++
+		}
+	}
+	return buf
+}
+
+func newBuffer(mem memory.Allocator, size int) *memory.Buffer {
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(size)
+	memory.Set(buf.Bytes(), 0)
+	return buf
+}
+
+func bytesOf(buf *memory.Buffer) []byte {
+	if buf == nil {
+		return nil
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,355 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/json"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+// jsonOf returns the line-delimited JSON representation of arr.
+func jsonOf(t *testing.T, arr array.Interface) string {
+	t.Helper()
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "v", Type: arr.DataType(), Nullable: true}}, nil)
+	rec := array.NewRecord(schema, []array.Interface{arr}, int64(arr.Len()))
+	defer rec.Release()
+
+	var buf bytes.Buffer
+	if err := json.NewWriter(&buf, schema).Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+// fromJSON returns an array of type dt from its line-delimited JSON values.
+func fromJSON(t *testing.T, mem memory.Allocator, dt arrow.DataType, data string) array.Interface {
+	t.Helper()
+
+	if data == "" {
+		bldr := array.NewBuilder(mem, dt)
+		defer bldr.Release()
+		return bldr.NewArray()
+	}
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "v", Type: dt, Nullable: true}}, nil)
+	r := json.NewReader(bytes.NewBufferString(data), schema, json.WithAllocator(mem), json.WithChunk(-1))
+	defer r.Release()
+	if !r.Next() {
+		t.Fatalf("could not read %s values: %v", dt.Name(), r.Err())
+	}
+	arr := r.Record().Column(0)
+	arr.Retain()
+	return arr
+}
+
+func TestConcatenate(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		dtype arrow.DataType
+		in    []string
+	}{
+		{
+			name:  "bool",
+			dtype: arrow.FixedWidthTypes.Boolean,
+			in:    []string{`{"v":true}` + "\n" + `{"v":null}`, `{"v":false}` + "\n" + `{"v":true}` + "\n" + `{"v":true}`},
+		},
+		{
+			name:  "int32",
+			dtype: arrow.PrimitiveTypes.Int32,
+			in:    []string{`{"v":1}` + "\n" + `{"v":2}`, ``, `{"v":null}` + "\n" + `{"v":4}`},
+		},
+		{
+			name:  "float64-no-nulls",
+			dtype: arrow.PrimitiveTypes.Float64,
+			in:    []string{`{"v":1.5}`, `{"v":2.5}` + "\n" + `{"v":-1}`},
+		},
+		{
+			name:  "timestamp",
+			dtype: &arrow.TimestampType{Unit: arrow.Second},
+			in:    []string{`{"v":"1970-01-01T00:00:01Z"}`, `{"v":null}` + "\n" + `{"v":"1969-12-31T23:59:59Z"}`},
+		},
+		{
+			name:  "string",
+			dtype: arrow.BinaryTypes.String,
+			in:    []string{`{"v":"a"}` + "\n" + `{"v":"bc"}`, `{"v":null}` + "\n" + `{"v":""}` + "\n" + `{"v":"def"}`},
+		},
+		{
+			name:  "large-binary",
+			dtype: arrow.BinaryTypes.LargeBinary,
+			in:    []string{`{"v":"YQ=="}`, `{"v":null}` + "\n" + `{"v":"YmM="}`},
+		},
+		{
+			name:  "string-view",
+			dtype: arrow.BinaryTypes.StringView,
+			in:    []string{`{"v":"a"}` + "\n" + `{"v":"a string longer than 12 bytes"}`, `{"v":null}` + "\n" + `{"v":"another long string"}`},
+		},
+		{
+			name:  "fixed-size-binary",
+			dtype: &arrow.FixedSizeBinaryType{ByteWidth: 2},
+			in:    []string{`{"v":"YWI="}` + "\n" + `{"v":null}`, `{"v":"Y2Q="}`},
+		},
+		{
+			name:  "list",
+			dtype: arrow.ListOf(arrow.PrimitiveTypes.Int64),
+			in:    []string{`{"v":[1,2]}` + "\n" + `{"v":null}`, `{"v":[]}` + "\n" + `{"v":[3,null,5]}`},
+		},
+		{
+			name:  "large-list",
+			dtype: arrow.LargeListOf(arrow.BinaryTypes.String),
+			in:    []string{`{"v":["a"]}`, `{"v":["b","c"]}` + "\n" + `{"v":null}`},
+		},
+		{
+			name:  "fixed-size-list",
+			dtype: arrow.FixedSizeListOf(2, arrow.PrimitiveTypes.Float32),
+			in:    []string{`{"v":[1,2]}` + "\n" + `{"v":null}`, `{"v":[null,4.5]}`},
+		},
+		{
+			name: "struct",
+			dtype: arrow.StructOf(
+				arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+				arrow.Field{Name: "s", Type: arrow.BinaryTypes.String, Nullable: true},
+			),
+			in: []string{`{"v":{"x":1,"s":"a"}}` + "\n" + `{"v":null}`, `{"v":{"x":null,"s":"c"}}`},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			var (
+				arrs   []array.Interface
+				slices []array.Interface
+				want   string
+				sliced string
+			)
+			for _, data := range tc.in {
+				arr := fromJSON(t, mem, tc.dtype, data)
+				defer arr.Release()
+				arrs = append(arrs, arr)
+				want += jsonOf(t, arr)
+
+				if arr.Len() > 0 {
+					slice := array.NewSlice(arr, 1, int64(arr.Len()))
+					defer slice.Release()
+					slices = append(slices, slice)
+					sliced += jsonOf(t, slice)
+				}
+			}
+
+			got, err := array.Concatenate(mem, arrs...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+			assert.Equal(t, want, jsonOf(t, got))
+
+			got, err = array.Concatenate(mem, slices...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+			assert.Equal(t, sliced, jsonOf(t, got))
+		})
+	}
+}
+
+func TestConcatenateUnion(t *testing.T) {
+	fields := []arrow.Field{
+		{Name: "i32", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+	}
+
+	for _, mode := range []arrow.UnionMode{arrow.SparseMode, arrow.DenseMode} {
+		t.Run(mode.String(), func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			bld := array.NewUnionBuilder(mem, arrow.UnionOf(mode, fields, nil))
+			defer bld.Release()
+			ib := bld.Child(0).(*array.Int32Builder)
+			sb := bld.Child(1).(*array.StringBuilder)
+
+			bld.Append(0)
+			ib.Append(1)
+			bld.Append(1)
+			sb.Append("a")
+			first := bld.NewUnionArray()
+			defer first.Release()
+
+			bld.AppendNull()
+			bld.Append(1)
+			sb.Append("b")
+			bld.Append(0)
+			ib.Append(2)
+			second := bld.NewUnionArray()
+			defer second.Release()
+			slice := array.NewSlice(second, 1, 3)
+			defer slice.Release()
+
+			got, err := array.Concatenate(mem, first, second, slice)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			arr := got.(*array.Union)
+			assert.Equal(t, 7, arr.Len())
+			assert.Equal(t, 1, arr.NullN())
+			want := []interface{}{int32(1), "a", nil, "b", int32(2), "b", int32(2)}
+			for i, v := range want {
+				if v == nil {
+					assert.True(t, arr.IsNull(i))
+					continue
+				}
+				switch child := arr.Field(arr.ChildID(i)).(type) {
+				case *array.Int32:
+					assert.Equal(t, v, child.Value(arr.ValueOffset(i)), "slot %d", i)
+				case *array.String:
+					assert.Equal(t, v, child.Value(arr.ValueOffset(i)), "slot %d", i)
+				}
+			}
+		})
+	}
+}
+
+func TestConcatenateRunEndEncoded(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	bld := array.NewRunEndEncodedBuilder(mem, arrow.PrimitiveTypes.Int32, arrow.BinaryTypes.String)
+	defer bld.Release()
+	vb := bld.ValueBuilder().(*array.StringBuilder)
+
+	bld.Append(2)
+	vb.Append("a")
+	bld.AppendNulls(3)
+	first := bld.NewArray()
+	defer first.Release()
+
+	bld.Append(4)
+	vb.Append("b")
+	bld.Append(1)
+	vb.Append("c")
+	second := bld.NewArray()
+	defer second.Release()
+	slice := array.NewSlice(second, 2, 5)
+	defer slice.Release()
+
+	got, err := array.Concatenate(mem, first, slice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	arr := got.(*array.RunEndEncoded)
+	assert.Equal(t, 8, arr.Len())
+	assert.Equal(t, 3, arr.LogicalNullN())
+	assert.Equal(t, []int32{2, 5, 7, 8}, arr.RunEnds().(*array.Int32).Int32Values())
+	assert.Equal(t, `["a" (null) "b" "c"]`, arr.Values().(*array.String).String())
+}
+
+func TestConcatenateExtension(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	dtype := &Int32Unit{ExtensionBase: arrow.ExtensionBase{Storage: arrow.PrimitiveTypes.Int32}, Unit: "s"}
+	bld := array.NewExtensionBuilder(mem, dtype)
+	defer bld.Release()
+
+	bld.StorageBuilder().(*array.Int32Builder).AppendValues([]int32{1, 2}, nil)
+	first := bld.NewArray()
+	defer first.Release()
+	bld.StorageBuilder().(*array.Int32Builder).AppendValues([]int32{3}, []bool{false})
+	second := bld.NewArray()
+	defer second.Release()
+
+	got, err := array.Concatenate(mem, first, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	arr, ok := got.(*Int32UnitArray)
+	if !ok {
+		t.Fatalf("invalid array type %T", got)
+	}
+	assert.Equal(t, 3, arr.Len())
+	assert.Equal(t, 1, arr.NullN())
+	assert.Equal(t, []int32{1, 2}, arr.Storage().(*array.Int32).Int32Values()[:2])
+}
+
+func TestConcatenateErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	i32 := fromJSON(t, mem, arrow.PrimitiveTypes.Int32, `{"v":1}`)
+	defer i32.Release()
+	i64 := fromJSON(t, mem, arrow.PrimitiveTypes.Int64, `{"v":1}`)
+	defer i64.Release()
+
+	_, err := array.Concatenate(mem)
+	assert.Error(t, err)
+
+	_, err = array.Concatenate(mem, i32, i64)
+	assert.EqualError(t, err, "arrow/array: mismatched data types int32 and int64")
+}
+
+func TestConcatenateRecords(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "i", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "s", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2}, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues([]string{"a", ""}, []bool{true, false})
+	r1 := b.NewRecord()
+	defer r1.Release()
+
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{3}, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues([]string{"c"}, nil)
+	r2 := b.NewRecord()
+	defer r2.Release()
+
+	rec, err := array.ConcatenateRecords(mem, r1, r2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+
+	assert.Equal(t, int64(3), rec.NumRows())
+	assert.Equal(t, []int64{1, 2, 3}, rec.Column(0).(*array.Int64).Int64Values())
+	assert.Equal(t, `["a" (null) "c"]`, rec.Column(1).(*array.String).String())
+
+	other := arrow.NewSchema([]arrow.Field{{Name: "i", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	r3 := array.NewRecord(other, []array.Interface{r1.Column(0)}, r1.NumRows())
+	defer r3.Release()
+	_, err = array.ConcatenateRecords(mem, r1, r3)
+	assert.Error(t, err)
+}
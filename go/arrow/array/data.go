@@ -20,19 +20,21 @@ import (
 	"sync/atomic"
 
 	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/internal/bitutil"
 	"github.com/apache/arrow/go/arrow/internal/debug"
 	"github.com/apache/arrow/go/arrow/memory"
 )
 
 // A type which represents the memory and metadata for an Arrow array.
 type Data struct {
-	refCount  int64
-	dtype     arrow.DataType
-	nulls     int
-	offset    int
-	length    int
-	buffers   []*memory.Buffer // TODO(sgc): should this be an interface?
-	childData []*Data          // TODO(sgc): managed by ListArray, StructArray and UnionArray types
+	refCount   int64
+	dtype      arrow.DataType
+	nulls      int
+	offset     int
+	length     int
+	buffers    []*memory.Buffer // TODO(sgc): should this be an interface?
+	childData  []*Data          // TODO(sgc): managed by ListArray, StructArray and UnionArray types
+	dictionary *Data            // dictionary values of dictionary-encoded data
 }
 
 func NewData(dtype arrow.DataType, length int, buffers []*memory.Buffer, childData []*Data, nulls, offset int) *Data {
@@ -59,6 +61,48 @@ func NewData(dtype arrow.DataType, length int, buffers []*memory.Buffer, childDa
 	}
 }
 
+// NewDataWithDictionary returns the data of a dictionary-encoded array of
+// type dtype, whose buffers hold the validity bitmap and indices and whose
+// dictionary values are dict.
+func NewDataWithDictionary(dtype arrow.DataType, length int, buffers []*memory.Buffer, nulls, offset int, dict *Data) *Data {
+	data := NewData(dtype, length, buffers, nil, nulls, offset)
+	if dict != nil {
+		dict.Retain()
+		data.dictionary = dict
+	}
+	return data
+}
+
+// newDataFromSlice returns the data of an array of type dtype with n
+// elements, whose values buffer borrows values. If valid is not empty, a
+// validity bitmap is built from it.
+func newDataFromSlice(dtype arrow.DataType, values []byte, n int, valid []bool) *Data {
+	if len(valid) != n && len(valid) != 0 {
+		panic("len(v) != len(valid) && len(valid) != 0")
+	}
+
+	var (
+		bitmap *memory.Buffer
+		nulls  = 0
+	)
+	if len(valid) != 0 {
+		bits := make([]byte, bitutil.CeilByte(n)/8)
+		for i, v := range valid {
+			if v {
+				bitutil.SetBit(bits, i)
+			} else {
+				nulls++
+			}
+		}
+		bitmap = memory.NewBufferOwned(bits)
+		defer bitmap.Release()
+	}
+
+	buf := memory.NewBufferBorrowed(values)
+	defer buf.Release()
+	return NewData(dtype, n, []*memory.Buffer{bitmap, buf}, nil, nulls, 0)
+}
+
 // Retain increases the reference count by 1.
 // Retain may be called simultaneously from multiple goroutines.
 func (d *Data) Retain() {
@@ -81,25 +125,46 @@ func (d *Data) Release() {
 		for _, b := range d.childData {
 			b.Release()
 		}
-		d.buffers, d.childData = nil, nil
+		if d.dictionary != nil {
+			d.dictionary.Release()
+		}
+		d.buffers, d.childData, d.dictionary = nil, nil, nil
 	}
 }
 
-func (d *Data) DataType() arrow.DataType  { return d.dtype }
-func (d *Data) NullN() int                { return d.nulls }
+func (d *Data) DataType() arrow.DataType { return d.dtype }
+
+// NullN returns the number of nulls, computing it from the validity
+// bitmap if it is unknown (e.g. for slices).
+func (d *Data) NullN() int {
+	if d.nulls < 0 {
+		d.nulls = 0
+		if len(d.buffers) > 0 && d.buffers[0] != nil {
+			d.nulls = d.length - bitutil.CountSetBits(d.buffers[0].Bytes(), d.offset, d.length)
+		}
+	}
+	return d.nulls
+}
+
 func (d *Data) Len() int                  { return d.length }
 func (d *Data) Offset() int               { return d.offset }
 func (d *Data) Buffers() []*memory.Buffer { return d.buffers }
+func (d *Data) Children() []*Data         { return d.childData }
+
+// Dictionary returns the dictionary values of dictionary-encoded data, or nil.
+func (d *Data) Dictionary() *Data { return d.dictionary }
 
 // NewSliceData returns a new slice that shares backing data with the input.
 // The returned Data slice starts at i and extends j-i elements, such as:
-//    slice := data[i:j]
+//
+//	slice := data[i:j]
+//
 // The returned value must be Release'd after use.
 //
 // NewSliceData panics if the slice is outside the valid range of the input Data.
 // NewSliceData panics if j < i.
 func NewSliceData(data *Data, i, j int64) *Data {
-	if j > int64(data.length) || i > j || data.offset+int(i) > data.length {
+	if i < 0 || j > int64(data.length) || i > j {
 		panic("arrow/array: index out of range")
 	}
 
@@ -115,18 +180,26 @@ func NewSliceData(data *Data, i, j int64) *Data {
 		}
 	}
 
+	if data.dictionary != nil {
+		data.dictionary.Retain()
+	}
+
 	o := &Data{
-		refCount:  1,
-		dtype:     data.dtype,
-		nulls:     UnknownNullCount,
-		length:    int(j - i),
-		offset:    data.offset + int(i),
-		buffers:   data.buffers,
-		childData: data.childData,
+		refCount:   1,
+		dtype:      data.dtype,
+		nulls:      UnknownNullCount,
+		length:     int(j - i),
+		offset:     data.offset + int(i),
+		buffers:    data.buffers,
+		childData:  data.childData,
+		dictionary: data.dictionary,
 	}
 
-	if data.nulls == 0 {
+	switch data.nulls {
+	case 0:
 		o.nulls = 0
+	case data.length:
+		o.nulls = o.length
 	}
 
 	return o
@@ -0,0 +1,80 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/decimal256"
+)
+
+// A type which represents an immutable sequence of 256-bit decimal values.
+type Decimal256 struct {
+	array
+	values []decimal256.Num
+}
+
+func NewDecimal256Data(data *Data) *Decimal256 {
+	a := &Decimal256{}
+	a.refCount = 1
+	a.setData(data)
+	return a
+}
+
+func (a *Decimal256) Value(i int) decimal256.Num { return a.values[i] }
+func (a *Decimal256) Values() []decimal256.Num   { return a.values }
+
+// ValueStr returns the decimal representation of the i-th value, taking
+// the scale of the data type into account.
+func (a *Decimal256) ValueStr(i int) string {
+	return a.values[i].ToString(a.DataType().(*arrow.Decimal256Type).Scale)
+}
+
+func (a *Decimal256) String() string {
+	o := new(strings.Builder)
+	o.WriteString("[")
+	for i := range a.values {
+		if i > 0 {
+			fmt.Fprintf(o, " ")
+		}
+		switch {
+		case a.IsNull(i):
+			o.WriteString("(null)")
+		default:
+			o.WriteString(a.ValueStr(i))
+		}
+	}
+	o.WriteString("]")
+	return o.String()
+}
+
+func (a *Decimal256) setData(data *Data) {
+	a.array.setData(data)
+	vals := data.buffers[1]
+	if vals != nil {
+		a.values = arrow.Decimal256Traits.CastFromBytes(vals.Bytes())
+		beg := a.array.data.offset
+		end := beg + a.array.data.length
+		a.values = a.values[beg:end]
+	}
+}
+
+var (
+	_ Interface = (*Decimal256)(nil)
+)
@@ -0,0 +1,71 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecimal256Builder(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	dtype := &arrow.Decimal256Type{Precision: 40, Scale: 2}
+	ab := array.NewDecimal256Builder(mem, dtype)
+	defer ab.Release()
+
+	ab.Append(decimal256.FromI64(-1))
+	ab.AppendNull()
+	ab.AppendValues([]decimal256.Num{decimal256.FromU64(12345), decimal256.New(1, 0, 0, 0)}, []bool{true, true})
+
+	assert.Equal(t, 4, ab.Len(), "unexpected Len()")
+	assert.Equal(t, 1, ab.NullN(), "unexpected NullN()")
+
+	a := ab.NewDecimal256Array()
+	defer a.Release()
+
+	assert.Zero(t, ab.Len(), "unexpected ArrayBuilder.Len(), NewDecimal256Array did not reset state")
+	assert.Zero(t, ab.Cap(), "unexpected ArrayBuilder.Cap(), NewDecimal256Array did not reset state")
+
+	assert.Equal(t, dtype, a.DataType())
+	assert.Equal(t, 1, a.NullN(), "unexpected null count")
+	assert.Equal(t, decimal256.FromI64(-1), a.Value(0))
+	assert.Equal(t, decimal256.FromU64(12345), a.Value(2))
+	assert.Equal(t, "123.45", a.ValueStr(2))
+	assert.Equal(t, "[-0.01 (null) 123.45 62771017353866807638357894232076664161023554444640345128.96]", a.String())
+
+	slice := array.NewSlice(a, 2, 4).(*array.Decimal256)
+	defer slice.Release()
+
+	assert.Equal(t, []decimal256.Num{decimal256.FromU64(12345), decimal256.New(1, 0, 0, 0)}, slice.Values())
+
+	// the builder must be usable through the generic constructor.
+	b := array.NewBuilder(mem, dtype).(*array.Decimal256Builder)
+	defer b.Release()
+
+	b.Append(decimal256.FromI64(7))
+	c := b.NewArray()
+	defer c.Release()
+
+	assert.Equal(t, "[0.07]", c.(*array.Decimal256).String())
+}
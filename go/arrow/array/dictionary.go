@@ -0,0 +1,767 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/internal/bitutil"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// Dictionary represents an immutable sequence of dictionary-encoded values.
+// Each slot holds the index of its value in the dictionary array.
+type Dictionary struct {
+	array
+	indices Interface
+	dict    Interface
+}
+
+// NewDictionaryData returns a new Dictionary array value, from data.
+func NewDictionaryData(data *Data) *Dictionary {
+	a := &Dictionary{}
+	a.refCount = 1
+	a.setData(data)
+	return a
+}
+
+// NewDictionaryArray returns the dictionary array of type dt whose slots are
+// the values of dict at indices. The validity of the array is that of indices.
+//
+// NewDictionaryArray panics if the types of indices or dict do not match dt.
+func NewDictionaryArray(dt *arrow.DictionaryType, indices, dict Interface) *Dictionary {
+	if !reflect.DeepEqual(indices.DataType(), dt.IndexType()) {
+		panic(fmt.Errorf("arrow/array: dictionary indices of type %s, expected %s", indices.DataType().Name(), dt.IndexType().Name()))
+	}
+	if !reflect.DeepEqual(dict.DataType(), dt.ValueType()) {
+		panic(fmt.Errorf("arrow/array: dictionary values of type %s, expected %s", dict.DataType().Name(), dt.ValueType().Name()))
+	}
+
+	idx := indices.Data()
+	data := NewDataWithDictionary(dt, idx.length, idx.buffers, idx.nulls, idx.offset, dict.Data())
+	defer data.Release()
+	return NewDictionaryData(data)
+}
+
+func (a *Dictionary) String() string { return formatString(a) }
+
+func (a *Dictionary) setData(data *Data) {
+	if data.dictionary == nil {
+		panic("arrow/array: dictionary array without dictionary")
+	}
+	a.array.setData(data)
+
+	dt := data.dtype.(*arrow.DictionaryType)
+	idx := NewData(dt.IndexType(), data.length, data.buffers, nil, data.nulls, data.offset)
+	defer idx.Release()
+	a.indices = MakeFromData(idx)
+	a.dict = MakeFromData(data.dictionary)
+}
+
+// Indices returns the array of the indices of the slots in the dictionary.
+func (a *Dictionary) Indices() Interface { return a.indices }
+
+// Dictionary returns the array of the dictionary values.
+func (a *Dictionary) Dictionary() Interface { return a.dict }
+
+// GetValueIndex returns the index in the dictionary of the value of slot i.
+func (a *Dictionary) GetValueIndex(i int) int { return indexValue(a.indices, i) }
+
+// Release decreases the reference count by 1.
+// Release may be called simultaneously from multiple goroutines.
+// When the reference count goes to zero, the memory is freed.
+func (a *Dictionary) Release() {
+	debug.Assert(atomic.LoadInt64(&a.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.data.Release()
+		a.indices.Release()
+		a.dict.Release()
+		a.data, a.nullBitmapBytes, a.indices, a.dict = nil, nil, nil, nil
+	}
+}
+
+// DictionaryOption configures a DictionaryBuilder.
+type DictionaryOption func(*dictionaryConfig)
+
+type dictionaryConfig struct {
+	maxSize int
+}
+
+// WithMaxDictionarySize caps the number of distinct values of the
+// dictionary at n. Appending a value that would grow the dictionary beyond
+// n makes the builder spill to plain encoding.
+func WithMaxDictionarySize(n int) DictionaryOption {
+	return func(cfg *dictionaryConfig) { cfg.maxSize = n }
+}
+
+// DictionaryBuilder builds dictionary arrays, memoizing the distinct values
+// appended to it.
+//
+// The dictionary is kept across calls to NewArray, so that the arrays built
+// one after the other share their dictionary values: the dictionary of each
+// array extends the dictionary of the previous one, and the indices of an
+// array are valid in the dictionaries of all the arrays built after it.
+// ResetFull clears the dictionary.
+//
+// When the dictionary reaches its maximum size, or when its index type
+// cannot address another value, appending a new value makes the builder
+// spill: it stops dictionary encoding, and NewArray returns plain arrays of
+// the dictionary value type until ResetFull is called.
+type DictionaryBuilder struct {
+	builder
+
+	dtype   *arrow.DictionaryType
+	maxSize int            // maximum number of dictionary values
+	memo    map[string]int // dictionary value -> index
+	values  []string       // dictionary values, by index
+	indices []int          // index of each slot, 0 for null slots
+	plain   []string       // value of each slot, once the builder spilled
+	spilled bool
+}
+
+// NewDictionaryBuilder returns a builder of dictionary arrays of type dtype,
+// using the provided memory allocator.
+//
+// NewDictionaryBuilder panics if the value type of dtype is not a
+// fixed-width type other than boolean, or a binary or string type.
+func NewDictionaryBuilder(mem memory.Allocator, dtype *arrow.DictionaryType, opts ...DictionaryOption) *DictionaryBuilder {
+	if !validDictionaryValueType(dtype.ValueType()) {
+		panic(fmt.Errorf("arrow/array: unsupported dictionary value type %s", dtype.ValueType().Name()))
+	}
+
+	cfg := dictionaryConfig{maxSize: math.MaxInt32}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if max := maxIndex(dtype.IndexType()); int64(cfg.maxSize)-1 > max {
+		cfg.maxSize = int(max) + 1
+	}
+
+	return &DictionaryBuilder{
+		builder: builder{refCount: 1, mem: mem},
+		dtype:   dtype,
+		maxSize: cfg.maxSize,
+		memo:    make(map[string]int),
+	}
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+func (b *DictionaryBuilder) Release() {
+	debug.Assert(atomic.LoadInt64(&b.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&b.refCount, -1) == 0 {
+		if b.nullBitmap != nil {
+			b.nullBitmap.Release()
+			b.nullBitmap = nil
+		}
+		b.memo, b.values, b.indices, b.plain = nil, nil, nil, nil
+	}
+}
+
+// DictionarySize returns the number of values in the dictionary.
+func (b *DictionaryBuilder) DictionarySize() int { return len(b.values) }
+
+// Spilled reports whether the builder stopped dictionary encoding.
+func (b *DictionaryBuilder) Spilled() bool { return b.spilled }
+
+func (b *DictionaryBuilder) AppendNull() {
+	b.Reserve(1)
+	b.UnsafeAppendBoolToBitmap(false)
+	b.appendSlot(0, "")
+}
+
+// AppendNulls appends n null values to the builder.
+func (b *DictionaryBuilder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+	for i := 0; i < n; i++ {
+		b.appendSlot(0, "")
+	}
+}
+
+// AppendString appends v to the builder of a dictionary of binary or
+// string values.
+func (b *DictionaryBuilder) AppendString(v string) {
+	switch dt := b.dtype.ValueType().(type) {
+	case *arrow.BinaryType, *arrow.StringType, *arrow.LargeBinaryType, *arrow.LargeStringType:
+	case *arrow.FixedSizeBinaryType:
+		if len(v) != dt.ByteWidth {
+			panic(fmt.Errorf("arrow/array: value of length %d, expected %d", len(v), dt.ByteWidth))
+		}
+	default:
+		panic(fmt.Errorf("arrow/array: cannot append a string to a dictionary of %s", dt.Name()))
+	}
+	b.appendValue(v)
+}
+
+// AppendBytes appends v to the builder of a dictionary of binary or
+// string values.
+func (b *DictionaryBuilder) AppendBytes(v []byte) { b.AppendString(string(v)) }
+
+// AppendValueFromArray appends the i-th value of arr, an array of the
+// dictionary value type, to the builder.
+func (b *DictionaryBuilder) AppendValueFromArray(arr Interface, i int) {
+	b.checkValueType(arr)
+	if arr.IsNull(i) {
+		b.AppendNull()
+		return
+	}
+	b.appendValue(dictionaryKey(arr, i))
+}
+
+// AppendArray appends the values of arr to the builder. arr is either an
+// array of the dictionary value type or a dictionary array with the same
+// value type, whose values are decoded.
+func (b *DictionaryBuilder) AppendArray(arr Interface) {
+	if d, ok := arr.(*Dictionary); ok {
+		b.checkValueType(d.dict)
+		for i := 0; i < d.Len(); i++ {
+			if d.IsNull(i) || d.dict.IsNull(d.GetValueIndex(i)) {
+				b.AppendNull()
+				continue
+			}
+			b.appendValue(dictionaryKey(d.dict, d.GetValueIndex(i)))
+		}
+		return
+	}
+
+	b.checkValueType(arr)
+	b.Reserve(arr.Len())
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			b.AppendNull()
+			continue
+		}
+		b.appendValue(dictionaryKey(arr, i))
+	}
+}
+
+func (b *DictionaryBuilder) checkValueType(arr Interface) {
+	if !reflect.DeepEqual(arr.DataType(), b.dtype.ValueType()) {
+		panic(fmt.Errorf("arrow/array: cannot append values of type %s to a dictionary of %s", arr.DataType().Name(), b.dtype.ValueType().Name()))
+	}
+}
+
+func (b *DictionaryBuilder) appendValue(key string) {
+	b.Reserve(1)
+	b.UnsafeAppendBoolToBitmap(true)
+	if b.spilled {
+		b.appendSlot(0, key)
+		return
+	}
+
+	idx, ok := b.memo[key]
+	if !ok {
+		if len(b.values) == b.maxSize {
+			b.spill()
+			b.appendSlot(0, key)
+			return
+		}
+		idx = len(b.values)
+		b.memo[key] = idx
+		b.values = append(b.values, key)
+	}
+	b.appendSlot(idx, key)
+}
+
+func (b *DictionaryBuilder) appendSlot(idx int, key string) {
+	if b.spilled {
+		b.plain = append(b.plain, key)
+		return
+	}
+	b.indices = append(b.indices, idx)
+}
+
+// spill switches the builder to plain encoding, decoding the slots appended
+// so far.
+func (b *DictionaryBuilder) spill() {
+	b.plain = make([]string, len(b.indices), cap(b.indices))
+	for i, idx := range b.indices {
+		if bitutil.BitIsSet(b.nullBitmap.Bytes(), i) {
+			b.plain[i] = b.values[idx]
+		}
+	}
+	b.spilled = true
+	b.memo, b.values, b.indices = nil, nil, nil
+}
+
+// Reserve ensures there is enough space for appending n elements
+// by checking the capacity and calling Resize if necessary.
+func (b *DictionaryBuilder) Reserve(n int) {
+	b.builder.reserve(n, b.Resize)
+}
+
+// Resize adjusts the space allocated by b to n elements. If n is greater than b.Cap(),
+// additional memory will be allocated. If n is smaller, the allocated memory may reduced.
+func (b *DictionaryBuilder) Resize(n int) {
+	if n < minBuilderCapacity {
+		n = minBuilderCapacity
+	}
+
+	if b.capacity == 0 {
+		b.init(n)
+		return
+	}
+	b.builder.resize(n, b.builder.init)
+	if len(b.indices) > b.length {
+		b.indices = b.indices[:b.length]
+	}
+	if len(b.plain) > b.length {
+		b.plain = b.plain[:b.length]
+	}
+}
+
+// ResetFull resets the builder and clears its dictionary, so the next array
+// built starts a new dictionary.
+func (b *DictionaryBuilder) ResetFull() {
+	b.reset()
+	b.memo = make(map[string]int)
+	b.values, b.indices, b.plain = nil, nil, nil
+	b.spilled = false
+}
+
+// NewArray creates a Dictionary array, or a plain array of the dictionary
+// value type if the builder spilled, from the values appended to the
+// builder, and resets the builder so it can be used to build a new array.
+// The dictionary is kept, see DictionaryBuilder.
+func (b *DictionaryBuilder) NewArray() Interface {
+	var data *Data
+	if b.spilled {
+		data = newDictionaryValues(b.mem, b.dtype.ValueType(), b.plain, b.nullBitmap, b.nulls)
+	} else {
+		dict := newDictionaryValues(b.mem, b.dtype.ValueType(), b.values, nil, 0)
+		defer dict.Release()
+		indices := newDictionaryIndices(b.mem, b.dtype.IndexType(), b.indices)
+		defer indices.Release()
+		data = NewDataWithDictionary(b.dtype, b.length, []*memory.Buffer{b.nullBitmap, indices}, b.nulls, 0, dict)
+	}
+	defer data.Release()
+
+	b.reset()
+	b.indices, b.plain = b.indices[:0], b.plain[:0]
+	return MakeFromData(data)
+}
+
+// UnmarshalJSON appends the elements of data, a JSON array of values of the
+// dictionary value type, to the builder.
+func (b *DictionaryBuilder) UnmarshalJSON(data []byte) error {
+	vb := NewBuilder(b.mem, b.dtype.ValueType())
+	defer vb.Release()
+	if err := vb.UnmarshalJSON(data); err != nil {
+		return err
+	}
+
+	arr := vb.NewArray()
+	defer arr.Release()
+	b.AppendArray(arr)
+	return nil
+}
+
+// DictionaryUnifier merges dictionaries of the same value type into a
+// single dictionary, so that dictionary arrays built separately, e.g. the
+// chunks of a column or the columns of successive records, can share one
+// dictionary.
+type DictionaryUnifier struct {
+	mem    memory.Allocator
+	dtype  arrow.DataType
+	memo   map[string]int
+	values []string
+}
+
+// NewDictionaryUnifier returns a unifier of dictionaries of values of type
+// dtype, using the provided memory allocator.
+//
+// NewDictionaryUnifier panics if dtype is not a supported dictionary value
+// type, see NewDictionaryBuilder.
+func NewDictionaryUnifier(mem memory.Allocator, dtype arrow.DataType) *DictionaryUnifier {
+	if !validDictionaryValueType(dtype) {
+		panic(fmt.Errorf("arrow/array: unsupported dictionary value type %s", dtype.Name()))
+	}
+	return &DictionaryUnifier{mem: mem, dtype: dtype, memo: make(map[string]int)}
+}
+
+// Len returns the number of values in the unified dictionary.
+func (u *DictionaryUnifier) Len() int { return len(u.values) }
+
+// Unify adds the values of dict missing from the unified dictionary, and
+// returns the transposition of dict: the index in the unified dictionary of
+// each value of dict, or -1 for null values.
+func (u *DictionaryUnifier) Unify(dict Interface) ([]int, error) {
+	if !reflect.DeepEqual(dict.DataType(), u.dtype) {
+		return nil, fmt.Errorf("arrow/array: cannot unify a dictionary of %s with a dictionary of %s", dict.DataType().Name(), u.dtype.Name())
+	}
+
+	transpose := make([]int, dict.Len())
+	for i := range transpose {
+		if dict.IsNull(i) {
+			transpose[i] = -1
+			continue
+		}
+		key := dictionaryKey(dict, i)
+		idx, ok := u.memo[key]
+		if !ok {
+			idx = len(u.values)
+			u.memo[key] = idx
+			u.values = append(u.values, key)
+		}
+		transpose[i] = idx
+	}
+	return transpose, nil
+}
+
+// Dictionary returns a new array holding the values of the unified
+// dictionary. The returned array must be Release()'d after use.
+func (u *DictionaryUnifier) Dictionary() Interface {
+	data := newDictionaryValues(u.mem, u.dtype, u.values, nil, 0)
+	defer data.Release()
+	return MakeFromData(data)
+}
+
+// UnifyDictionaries returns the dictionary arrays arrs, with their indices
+// remapped to a single dictionary merging their dictionaries.
+//
+// All the arrays must be dictionary arrays of the same data type.
+// The returned arrays must be Release()'d after use.
+func UnifyDictionaries(mem memory.Allocator, arrs []Interface) ([]Interface, error) {
+	if len(arrs) == 0 {
+		return nil, nil
+	}
+
+	dtype, ok := arrs[0].DataType().(*arrow.DictionaryType)
+	if !ok {
+		return nil, fmt.Errorf("arrow/array: cannot unify arrays of %s", arrs[0].DataType().Name())
+	}
+
+	var (
+		u          = NewDictionaryUnifier(mem, dtype.ValueType())
+		transposes = make([][]int, len(arrs))
+	)
+	for i, arr := range arrs {
+		if !reflect.DeepEqual(arr.DataType(), arrs[0].DataType()) {
+			return nil, fmt.Errorf("arrow/array: mismatched data types %s and %s", arrs[0].DataType().Name(), arr.DataType().Name())
+		}
+		transpose, err := u.Unify(arr.(*Dictionary).dict)
+		if err != nil {
+			return nil, err
+		}
+		transposes[i] = transpose
+	}
+	if max := maxIndex(dtype.IndexType()); int64(u.Len())-1 > max {
+		return nil, fmt.Errorf("arrow/array: unified dictionary of %d values overflows index type %s", u.Len(), dtype.IndexType().Name())
+	}
+
+	dict := u.Dictionary()
+	defer dict.Release()
+
+	out := make([]Interface, len(arrs))
+	for i, arr := range arrs {
+		out[i] = transposeDictionary(mem, arr.(*Dictionary), transposes[i], dict.Data())
+	}
+	return out, nil
+}
+
+// UnifyRecordDictionaries returns the records recs, with the dictionary
+// arrays of each of their columns remapped to a single dictionary per column,
+// as UnifyDictionaries does. The other columns are shared with recs.
+//
+// All the records must have the same schema.
+// The returned records must be Release()'d after use.
+func UnifyRecordDictionaries(mem memory.Allocator, recs []Record) ([]Record, error) {
+	if len(recs) == 0 {
+		return nil, nil
+	}
+
+	schema := recs[0].Schema()
+	for _, rec := range recs[1:] {
+		if !rec.Schema().Equal(schema) {
+			return nil, errors.New("arrow/array: mismatched record schemas")
+		}
+	}
+
+	cols := make([][]Interface, len(recs))
+	for j := range recs {
+		cols[j] = make([]Interface, len(schema.Fields()))
+	}
+	defer func() {
+		for _, rec := range cols {
+			for _, col := range rec {
+				if col != nil {
+					col.Release()
+				}
+			}
+		}
+	}()
+
+	arrs := make([]Interface, len(recs))
+	for i, f := range schema.Fields() {
+		if f.Type.ID() != arrow.DICTIONARY {
+			for j, rec := range recs {
+				cols[j][i] = rec.Column(i)
+				cols[j][i].Retain()
+			}
+			continue
+		}
+
+		for j, rec := range recs {
+			arrs[j] = rec.Column(i)
+		}
+		unified, err := UnifyDictionaries(mem, arrs)
+		if err != nil {
+			return nil, fmt.Errorf("arrow/array: column %q: %w", f.Name, err)
+		}
+		for j, arr := range unified {
+			cols[j][i] = arr
+		}
+	}
+
+	out := make([]Record, len(recs))
+	for j, rec := range recs {
+		out[j] = NewRecord(schema, cols[j], rec.NumRows())
+	}
+	return out, nil
+}
+
+// transposeDictionary returns the dictionary array arr, with its indices
+// remapped through transpose to the dictionary dict.
+func transposeDictionary(mem memory.Allocator, arr *Dictionary, transpose []int, dict *Data) Interface {
+	var (
+		n       = arr.Len()
+		indices = make([]int, n)
+		valid   = newBuffer(mem, bitutil.CeilByte(n)/8)
+		nulls   = 0
+	)
+	for i := range indices {
+		if arr.IsNull(i) || transpose[arr.GetValueIndex(i)] < 0 {
+			nulls++
+			continue
+		}
+		bitutil.SetBit(valid.Bytes(), i)
+		indices[i] = transpose[arr.GetValueIndex(i)]
+	}
+	if nulls == 0 {
+		valid.Release()
+		valid = nil
+	} else {
+		defer valid.Release()
+	}
+
+	buf := newDictionaryIndices(mem, arr.indices.DataType(), indices)
+	defer buf.Release()
+	data := NewDataWithDictionary(arr.DataType(), n, []*memory.Buffer{valid, buf}, nulls, 0, dict)
+	defer data.Release()
+	return MakeFromData(data)
+}
+
+func validDictionaryValueType(dt arrow.DataType) bool {
+	switch dt.(type) {
+	case *arrow.BooleanType:
+		return false
+	case *arrow.BinaryType, *arrow.StringType, *arrow.LargeBinaryType, *arrow.LargeStringType,
+		arrow.FixedWidthDataType:
+		return true
+	}
+	return false
+}
+
+// dictionaryKey returns the bytes of the i-th value of arr, which identify
+// the value in a dictionary.
+func dictionaryKey(arr Interface, i int) string {
+	switch a := arr.(type) {
+	case *String:
+		return a.Value(i)
+	case *LargeString:
+		return a.Value(i)
+	case *Binary:
+		return string(a.Value(i))
+	case *LargeBinary:
+		return string(a.Value(i))
+	case *FixedSizeBinary:
+		return string(a.Value(i))
+	}
+
+	bw := arr.DataType().(arrow.FixedWidthDataType).BitWidth() / 8
+	b := arr.Data().buffers[1].Bytes()[(arr.Data().offset+i)*bw:]
+	return string(b[:bw])
+}
+
+// newDictionaryValues returns the data of an array of type dtype holding the
+// values identified by keys, with the validity bitmap valid.
+func newDictionaryValues(mem memory.Allocator, dtype arrow.DataType, keys []string, valid *memory.Buffer, nulls int) *Data {
+	var (
+		n       = len(keys)
+		buffers []*memory.Buffer
+	)
+	fixed := func(width int) *memory.Buffer {
+		buf := newBuffer(mem, n*width)
+		for i, k := range keys {
+			copy(buf.Bytes()[i*width:(i+1)*width], k)
+		}
+		return buf
+	}
+
+	switch dt := dtype.(type) {
+	case *arrow.FixedSizeBinaryType:
+		offsets := newBuffer(mem, (n+1)*arrow.Int32SizeBytes)
+		offs := arrow.Int32Traits.CastFromBytes(offsets.Bytes())
+		for i := range offs {
+			offs[i] = int32(i * dt.ByteWidth)
+		}
+		buffers = []*memory.Buffer{valid, offsets, fixed(dt.ByteWidth)}
+
+	case arrow.FixedWidthDataType:
+		buffers = []*memory.Buffer{valid, fixed(dt.BitWidth() / 8)}
+
+	default:
+		large := dt.ID() == arrow.LARGE_BINARY || dt.ID() == arrow.LARGE_STRING
+		size := 0
+		for _, k := range keys {
+			size += len(k)
+		}
+		values := newBuffer(mem, size)
+
+		var offsets *memory.Buffer
+		if large {
+			offsets = newBuffer(mem, (n+1)*arrow.Int64SizeBytes)
+			offs := arrow.Int64Traits.CastFromBytes(offsets.Bytes())
+			pos := 0
+			for i, k := range keys {
+				offs[i] = int64(pos)
+				pos += copy(values.Bytes()[pos:], k)
+			}
+			offs[n] = int64(pos)
+		} else {
+			offsets = newBuffer(mem, (n+1)*arrow.Int32SizeBytes)
+			offs := arrow.Int32Traits.CastFromBytes(offsets.Bytes())
+			pos := 0
+			for i, k := range keys {
+				offs[i] = int32(pos)
+				pos += copy(values.Bytes()[pos:], k)
+			}
+			offs[n] = int32(pos)
+		}
+		buffers = []*memory.Buffer{valid, offsets, values}
+	}
+
+	for _, buf := range buffers[1:] {
+		defer buf.Release()
+	}
+	return NewData(dtype, n, buffers, nil, nulls, 0)
+}
+
+// newDictionaryIndices returns the buffer of the indices idx, of type dtype.
+func newDictionaryIndices(mem memory.Allocator, dtype arrow.DataType, idx []int) *memory.Buffer {
+	width := dtype.(arrow.FixedWidthDataType).BitWidth() / 8
+	buf := newBuffer(mem, len(idx)*width)
+	switch dtype.(type) {
+	case *arrow.Int8Type:
+		vs := arrow.Int8Traits.CastFromBytes(buf.Bytes())
+		for i, v := range idx {
+			vs[i] = int8(v)
+		}
+	case *arrow.Uint8Type:
+		vs := arrow.Uint8Traits.CastFromBytes(buf.Bytes())
+		for i, v := range idx {
+			vs[i] = uint8(v)
+		}
+	case *arrow.Int16Type:
+		vs := arrow.Int16Traits.CastFromBytes(buf.Bytes())
+		for i, v := range idx {
+			vs[i] = int16(v)
+		}
+	case *arrow.Uint16Type:
+		vs := arrow.Uint16Traits.CastFromBytes(buf.Bytes())
+		for i, v := range idx {
+			vs[i] = uint16(v)
+		}
+	case *arrow.Int32Type:
+		vs := arrow.Int32Traits.CastFromBytes(buf.Bytes())
+		for i, v := range idx {
+			vs[i] = int32(v)
+		}
+	case *arrow.Uint32Type:
+		vs := arrow.Uint32Traits.CastFromBytes(buf.Bytes())
+		for i, v := range idx {
+			vs[i] = uint32(v)
+		}
+	case *arrow.Int64Type:
+		vs := arrow.Int64Traits.CastFromBytes(buf.Bytes())
+		for i, v := range idx {
+			vs[i] = int64(v)
+		}
+	case *arrow.Uint64Type:
+		vs := arrow.Uint64Traits.CastFromBytes(buf.Bytes())
+		for i, v := range idx {
+			vs[i] = uint64(v)
+		}
+	}
+	return buf
+}
+
+// indexValue returns the i-th value of arr, an array of dictionary indices.
+func indexValue(arr Interface, i int) int {
+	switch a := arr.(type) {
+	case *Int8:
+		return int(a.Value(i))
+	case *Uint8:
+		return int(a.Value(i))
+	case *Int16:
+		return int(a.Value(i))
+	case *Uint16:
+		return int(a.Value(i))
+	case *Int32:
+		return int(a.Value(i))
+	case *Uint32:
+		return int(a.Value(i))
+	case *Int64:
+		return int(a.Value(i))
+	case *Uint64:
+		return int(a.Value(i))
+	}
+	panic(fmt.Errorf("arrow/array: invalid dictionary index type %s", arr.DataType().Name()))
+}
+
+// maxIndex returns the largest index of type dtype.
+func maxIndex(dtype arrow.DataType) int64 {
+	switch dtype.(type) {
+	case *arrow.Int8Type:
+		return math.MaxInt8
+	case *arrow.Uint8Type:
+		return math.MaxUint8
+	case *arrow.Int16Type:
+		return math.MaxInt16
+	case *arrow.Uint16Type:
+		return math.MaxUint16
+	case *arrow.Int32Type:
+		return math.MaxInt32
+	case *arrow.Uint32Type:
+		return math.MaxUint32
+	}
+	return math.MaxInt64
+}
+
+var (
+	_ Interface = (*Dictionary)(nil)
+	_ Builder   = (*DictionaryBuilder)(nil)
+)
@@ -0,0 +1,339 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestDictionaryBuilder(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	dt := arrow.DictionaryOf(arrow.PrimitiveTypes.Int8, arrow.BinaryTypes.String)
+	b := array.NewBuilder(pool, dt).(*array.DictionaryBuilder)
+	defer b.Release()
+
+	b.AppendString("a")
+	b.AppendString("b")
+	b.AppendNull()
+	b.AppendString("a")
+
+	arr := b.NewArray().(*array.Dictionary)
+	defer arr.Release()
+
+	if got, want := fmt.Sprint(arr), `["a" "b" (null) "a"]`; got != want {
+		t.Fatalf("got=%s, want=%s", got, want)
+	}
+	if got, want := arr.NullN(), 1; got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+	if got, want := arr.Indices().(*array.Int8).Int8Values(), []int8{0, 1, 0, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+	if got, want := fmt.Sprint(arr.Dictionary()), `["a" "b"]`; got != want {
+		t.Fatalf("got=%s, want=%s", got, want)
+	}
+
+	// the dictionary is kept across arrays.
+	b.AppendString("c")
+	b.AppendString("a")
+	next := b.NewArray().(*array.Dictionary)
+	defer next.Release()
+
+	if got, want := fmt.Sprint(next.Dictionary()), `["a" "b" "c"]`; got != want {
+		t.Fatalf("got=%s, want=%s", got, want)
+	}
+	if got, want := next.Indices().(*array.Int8).Int8Values(), []int8{2, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+
+	b.ResetFull()
+	b.AppendString("c")
+	reset := b.NewArray().(*array.Dictionary)
+	defer reset.Release()
+
+	if got, want := fmt.Sprint(reset.Dictionary()), `["c"]`; got != want {
+		t.Fatalf("got=%s, want=%s", got, want)
+	}
+
+	slice := array.NewSlice(next, 1, 2)
+	defer slice.Release()
+	if got, want := fmt.Sprint(slice), `["a"]`; got != want {
+		t.Fatalf("got=%s, want=%s", got, want)
+	}
+	if err := array.ValidateFull(slice); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDictionaryBuilderAppendArray(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	vb := array.NewInt64Builder(pool)
+	defer vb.Release()
+	vb.AppendValues([]int64{3, 1, 3, 0, 1}, []bool{true, true, true, false, true})
+	vals := vb.NewInt64Array()
+	defer vals.Release()
+
+	b := array.NewDictionaryBuilder(pool, arrow.DictionaryOf(arrow.PrimitiveTypes.Uint16, arrow.PrimitiveTypes.Int64))
+	defer b.Release()
+	b.AppendArray(vals)
+	b.AppendValueFromArray(vals, 1)
+
+	arr := b.NewArray().(*array.Dictionary)
+	defer arr.Release()
+
+	if got, want := fmt.Sprint(arr), `[3 1 3 (null) 1 1]`; got != want {
+		t.Fatalf("got=%s, want=%s", got, want)
+	}
+	if got, want := b.DictionarySize(), 2; got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+
+	b.AppendArray(arr)
+	decoded := b.NewArray()
+	defer decoded.Release()
+	if got, want := fmt.Sprint(decoded), fmt.Sprint(arr); got != want {
+		t.Fatalf("got=%s, want=%s", got, want)
+	}
+
+	if err := b.UnmarshalJSON([]byte(`[7, null, 3]`)); err != nil {
+		t.Fatal(err)
+	}
+	fromJSON := b.NewArray().(*array.Dictionary)
+	defer fromJSON.Release()
+	if got, want := fmt.Sprint(fromJSON), `[7 (null) 3]`; got != want {
+		t.Fatalf("got=%s, want=%s", got, want)
+	}
+	if got, want := fmt.Sprint(fromJSON.Dictionary()), `[3 1 7]`; got != want {
+		t.Fatalf("got=%s, want=%s", got, want)
+	}
+}
+
+func TestDictionaryBuilderSpill(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	dt := arrow.DictionaryOf(arrow.PrimitiveTypes.Int32, arrow.BinaryTypes.String)
+	b := array.NewDictionaryBuilder(pool, dt, array.WithMaxDictionarySize(2))
+	defer b.Release()
+
+	b.AppendString("a")
+	b.AppendNull()
+	b.AppendString("b")
+	b.AppendString("a")
+	if b.Spilled() {
+		t.Fatalf("builder spilled with 2 values")
+	}
+	b.AppendString("c")
+	if !b.Spilled() {
+		t.Fatalf("builder did not spill")
+	}
+
+	arr := b.NewArray()
+	defer arr.Release()
+	if got, want := arr.DataType(), arrow.BinaryTypes.String; got != want {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+	if got, want := fmt.Sprint(arr), `["a" (null) "b" "a" "c"]`; got != want {
+		t.Fatalf("got=%s, want=%s", got, want)
+	}
+
+	// the dictionary also spills when its index type overflows.
+	b8 := array.NewDictionaryBuilder(pool, arrow.DictionaryOf(arrow.PrimitiveTypes.Uint8, arrow.BinaryTypes.String))
+	defer b8.Release()
+	for i := 0; i < 256; i++ {
+		b8.AppendString(strings.Repeat("x", i))
+	}
+	if b8.Spilled() {
+		t.Fatalf("builder spilled with 256 values")
+	}
+	b8.AppendString("y")
+	if !b8.Spilled() {
+		t.Fatalf("builder did not spill")
+	}
+	plain := b8.NewArray().(*array.String)
+	defer plain.Release()
+	if got, want := plain.Len(), 257; got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+	if got, want := plain.Value(255), strings.Repeat("x", 255); got != want {
+		t.Fatalf("got=%q, want=%q", got, want)
+	}
+}
+
+func newDictionary(t *testing.T, mem memory.Allocator, dt *arrow.DictionaryType, vs []string, valid []bool) *array.Dictionary {
+	t.Helper()
+	b := array.NewDictionaryBuilder(mem, dt)
+	defer b.Release()
+	for i, v := range vs {
+		if valid != nil && !valid[i] {
+			b.AppendNull()
+			continue
+		}
+		b.AppendString(v)
+	}
+	return b.NewArray().(*array.Dictionary)
+}
+
+func TestDictionaryUnifier(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	dt := arrow.DictionaryOf(arrow.PrimitiveTypes.Int8, arrow.BinaryTypes.String)
+	a1 := newDictionary(t, pool, dt, []string{"x", "y", "x"}, nil)
+	defer a1.Release()
+	a2 := newDictionary(t, pool, dt, []string{"z", "", "x"}, []bool{true, false, true})
+	defer a2.Release()
+
+	u := array.NewDictionaryUnifier(pool, arrow.BinaryTypes.String)
+	for _, tc := range []struct {
+		arr  *array.Dictionary
+		want []int
+	}{
+		{a1, []int{0, 1}},
+		{a2, []int{2, 0}},
+	} {
+		got, err := u.Unify(tc.arr.Dictionary())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("got=%v, want=%v", got, tc.want)
+		}
+	}
+	dict := u.Dictionary()
+	defer dict.Release()
+	if got, want := fmt.Sprint(dict), `["x" "y" "z"]`; got != want {
+		t.Fatalf("got=%s, want=%s", got, want)
+	}
+
+	if _, err := u.Unify(a1.Indices()); err == nil {
+		t.Fatalf("expected an error unifying int8 values")
+	}
+
+	unified, err := array.UnifyDictionaries(pool, []array.Interface{a1, a2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, arr := range unified {
+		defer arr.Release()
+		if err := array.ValidateFull(arr); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := fmt.Sprint(arr.(*array.Dictionary).Dictionary()), `["x" "y" "z"]`; got != want {
+			t.Fatalf("got=%s, want=%s", got, want)
+		}
+		if !array.ArrayEqual(arr, []array.Interface{a1, a2}[i]) {
+			t.Fatalf("unified array %d differs: got=%v, want=%v", i, arr, []array.Interface{a1, a2}[i])
+		}
+	}
+	if got, want := unified[1].(*array.Dictionary).Indices().(*array.Int8).Int8Values(), []int8{2, 0, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+
+	cat, err := array.Concatenate(pool, a1, a2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cat.Release()
+	if got, want := fmt.Sprint(cat), `["x" "y" "x" "z" (null) "x"]`; got != want {
+		t.Fatalf("got=%s, want=%s", got, want)
+	}
+	if err := array.ValidateFull(cat); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestUnifyRecordDictionaries(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	dt := arrow.DictionaryOf(arrow.PrimitiveTypes.Int32, arrow.BinaryTypes.String)
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "tag", Type: dt, Nullable: true},
+	}, nil)
+
+	var recs []array.Record
+	for i, tags := range [][]string{{"a", "b"}, {"c", "a"}} {
+		ib := array.NewInt64Builder(pool)
+		ib.AppendValues([]int64{int64(2 * i), int64(2*i + 1)}, nil)
+		ids := ib.NewArray()
+		ib.Release()
+		tag := newDictionary(t, pool, dt, tags, nil)
+		rec := array.NewRecord(schema, []array.Interface{ids, tag}, 2)
+		ids.Release()
+		tag.Release()
+		defer rec.Release()
+		recs = append(recs, rec)
+	}
+
+	unified, err := array.UnifyRecordDictionaries(pool, recs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, rec := range unified {
+		defer rec.Release()
+		if !array.RecordEqual(rec, recs[i]) {
+			t.Fatalf("unified record %d differs", i)
+		}
+		if got, want := fmt.Sprint(rec.Column(1).(*array.Dictionary).Dictionary()), `["a" "b" "c"]`; got != want {
+			t.Fatalf("got=%s, want=%s", got, want)
+		}
+		if got, want := rec.Column(0), recs[i].Column(0); got != want {
+			t.Fatalf("non-dictionary column %d was copied", i)
+		}
+	}
+}
+
+func TestDictionaryValidate(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	ib := array.NewInt8Builder(pool)
+	defer ib.Release()
+	ib.AppendValues([]int8{0, 2}, nil)
+	indices := ib.NewArray()
+	defer indices.Release()
+
+	sb := array.NewStringBuilder(pool)
+	defer sb.Release()
+	sb.AppendValues([]string{"a", "b"}, nil)
+	dict := sb.NewArray()
+	defer dict.Release()
+
+	arr := array.NewDictionaryArray(arrow.DictionaryOf(arrow.PrimitiveTypes.Int8, arrow.BinaryTypes.String), indices, dict)
+	defer arr.Release()
+
+	if err := array.Validate(arr); err != nil {
+		t.Fatal(err)
+	}
+	err := array.ValidateFull(arr)
+	if got, want := err, "arrow/array: index 2 at index 1 out of range of the 2 dictionary values"; got == nil || got.Error() != want {
+		t.Fatalf("got=%v, want=%s", got, want)
+	}
+}
@@ -0,0 +1,328 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// RunEndEncoded represents an immutable sequence of runs of values.
+//
+// A RunEndEncoded array has no validity bitmap, and NullN always returns 0:
+// a logical slot is null when the value of its run is null, see IsNull and
+// LogicalNullN. The offset and length of the array are logical, the run ends
+// and values children are never sliced.
+type RunEndEncoded struct {
+	array
+	ends   Interface
+	values Interface
+}
+
+// NewRunEndEncodedData returns a new RunEndEncoded array value, from data.
+func NewRunEndEncodedData(data *Data) *RunEndEncoded {
+	a := &RunEndEncoded{}
+	a.refCount = 1
+	a.setData(data)
+	return a
+}
+
+// RunEnds returns the run ends of the array.
+func (a *RunEndEncoded) RunEnds() Interface { return a.ends }
+
+// Values returns the values of the runs of the array.
+func (a *RunEndEncoded) Values() Interface { return a.values }
+
+// runEnd returns the j-th run end.
+func (a *RunEndEncoded) runEnd(j int) int {
+	switch ends := a.ends.(type) {
+	case *Int16:
+		return int(ends.Value(j))
+	case *Int32:
+		return int(ends.Value(j))
+	case *Int64:
+		return int(ends.Value(j))
+	}
+	panic(fmt.Errorf("arrow/array: invalid run ends type %v", a.ends.DataType()))
+}
+
+// PhysicalIndex returns the index, in Values, of the logical slot i.
+func (a *RunEndEncoded) PhysicalIndex(i int) int {
+	if i < 0 || i >= a.data.length {
+		panic("arrow/array: index out of range")
+	}
+	i += a.data.offset
+	return sort.Search(a.ends.Len(), func(j int) bool { return a.runEnd(j) > i })
+}
+
+// PhysicalOffset returns the index, in Values, of the first logical slot.
+func (a *RunEndEncoded) PhysicalOffset() int {
+	if a.data.length == 0 {
+		return 0
+	}
+	return a.PhysicalIndex(0)
+}
+
+// PhysicalLength returns the number of runs spanned by the array.
+func (a *RunEndEncoded) PhysicalLength() int {
+	if a.data.length == 0 {
+		return 0
+	}
+	return a.PhysicalIndex(a.data.length-1) - a.PhysicalOffset() + 1
+}
+
+// IsNull returns true if the value at logical slot i is null.
+func (a *RunEndEncoded) IsNull(i int) bool { return a.values.IsNull(a.PhysicalIndex(i)) }
+
+// IsValid returns true if the value at logical slot i is not null.
+func (a *RunEndEncoded) IsValid(i int) bool { return a.values.IsValid(a.PhysicalIndex(i)) }
+
+// LogicalNullN returns the number of null logical values in the array.
+func (a *RunEndEncoded) LogicalNullN() int {
+	var (
+		beg   = a.data.offset
+		end   = beg + a.data.length
+		nulls = 0
+	)
+	for j := a.PhysicalOffset(); beg < end; j++ {
+		runEnd := a.runEnd(j)
+		if runEnd > end {
+			runEnd = end
+		}
+		if a.values.IsNull(j) {
+			nulls += runEnd - beg
+		}
+		beg = runEnd
+	}
+	return nulls
+}
+
+func (a *RunEndEncoded) String() string { return formatString(a) }
+
+func (a *RunEndEncoded) setData(data *Data) {
+	if len(data.childData) != 2 {
+		panic("arrow/array: run-end encoded arrays must have 2 children")
+	}
+	a.array.setData(data)
+	a.ends = MakeFromData(data.childData[0])
+	a.values = MakeFromData(data.childData[1])
+}
+
+// Release decreases the reference count by 1.
+// Release may be called simultaneously from multiple goroutines.
+// When the reference count goes to zero, the memory is freed.
+func (a *RunEndEncoded) Release() {
+	debug.Assert(atomic.LoadInt64(&a.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.data.Release()
+		a.ends.Release()
+		a.values.Release()
+		a.data, a.nullBitmapBytes, a.ends, a.values = nil, nil, nil, nil
+	}
+}
+
+// RunEndEncodedBuilder builds RunEndEncoded arrays.
+//
+// A run is started with Append, giving its length, and its value is then
+// appended to the ValueBuilder.
+type RunEndEncodedBuilder struct {
+	builder
+
+	dtype  *arrow.RunEndEncodedType
+	ends   []int
+	values Builder
+	max    int // maximum run end for the run ends type
+}
+
+// NewRunEndEncodedBuilder returns a builder, using the provided memory allocator.
+// The created builder will create arrays with run ends of type runEnds and
+// values of type values.
+func NewRunEndEncodedBuilder(mem memory.Allocator, runEnds, values arrow.DataType) *RunEndEncodedBuilder {
+	dtype := arrow.RunEndEncodedOf(runEnds, values)
+	b := &RunEndEncodedBuilder{
+		builder: builder{refCount: 1, mem: mem},
+		dtype:   dtype,
+		values:  NewBuilder(mem, values),
+		max:     maxRunEnd(runEnds),
+	}
+	return b
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+func (b *RunEndEncodedBuilder) Release() {
+	debug.Assert(atomic.LoadInt64(&b.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&b.refCount, -1) == 0 {
+		b.values.Release()
+		b.ends = nil
+	}
+}
+
+func (b *RunEndEncodedBuilder) addLength(n int) {
+	if n < 0 || b.length > b.max-n {
+		panic(fmt.Errorf("arrow/array: run end %d overflows %s", b.length+n, b.dtype.RunEnds().Name()))
+	}
+	b.length += n
+}
+
+// Append starts a new run of n logical values. The value of the run must
+// then be appended to the ValueBuilder.
+//
+// Append panics if n is not positive, as an empty run would repeat the end
+// of the previous one.
+func (b *RunEndEncodedBuilder) Append(n int) {
+	if n <= 0 {
+		panic(fmt.Errorf("arrow/array: invalid run length %d", n))
+	}
+	b.addLength(n)
+	b.ends = append(b.ends, b.length)
+}
+
+// ContinueRun extends the last run by n logical values.
+//
+// ContinueRun panics if no run was started.
+func (b *RunEndEncodedBuilder) ContinueRun(n int) {
+	if len(b.ends) == 0 {
+		panic("arrow/array: no run to continue")
+	}
+	b.addLength(n)
+	b.ends[len(b.ends)-1] = b.length
+}
+
+// AppendNull appends a run of a single null value.
+func (b *RunEndEncodedBuilder) AppendNull() { b.AppendNulls(1) }
+
+// AppendNulls appends a run of n null values. It appends nothing if n is zero.
+func (b *RunEndEncodedBuilder) AppendNulls(n int) {
+	if n == 0 {
+		return
+	}
+	b.Append(n)
+	b.values.AppendNull()
+}
+
+// ValueBuilder returns the builder of the values of the runs.
+func (b *RunEndEncodedBuilder) ValueBuilder() Builder { return b.values }
+
+func (b *RunEndEncodedBuilder) init(capacity int) { b.capacity = capacity }
+
+func (b *RunEndEncodedBuilder) resize(newBits int, init func(int)) { init(newBits) }
+
+// Reserve ensures there is enough space for appending n runs.
+func (b *RunEndEncodedBuilder) Reserve(n int) {
+	b.values.Reserve(n)
+}
+
+// Resize adjusts the space allocated for the values of the runs to n elements.
+func (b *RunEndEncodedBuilder) Resize(n int) {
+	b.values.Resize(n)
+}
+
+// Cap returns the total number of runs that can be stored without allocating additional memory.
+func (b *RunEndEncodedBuilder) Cap() int { return b.values.Cap() }
+
+// NewArray creates a RunEndEncoded array from the memory buffers used by the builder and resets the
+// RunEndEncodedBuilder so it can be used to build a new array.
+func (b *RunEndEncodedBuilder) NewArray() Interface {
+	return b.NewRunEndEncodedArray()
+}
+
+// NewRunEndEncodedArray creates a RunEndEncoded array from the memory buffers used by the builder and resets the
+// RunEndEncodedBuilder so it can be used to build a new array.
+func (b *RunEndEncodedBuilder) NewRunEndEncodedArray() (a *RunEndEncoded) {
+	data := b.newData()
+	a = NewRunEndEncodedData(data)
+	data.Release()
+	return
+}
+
+// newRunEnds returns an array of type dt holding the run ends ends.
+func newRunEnds(mem memory.Allocator, dt arrow.DataType, ends []int) Interface {
+	switch dt.ID() {
+	case arrow.INT16:
+		eb := NewInt16Builder(mem)
+		defer eb.Release()
+		eb.Reserve(len(ends))
+		for _, v := range ends {
+			eb.UnsafeAppend(int16(v))
+		}
+		return eb.NewArray()
+	case arrow.INT32:
+		eb := NewInt32Builder(mem)
+		defer eb.Release()
+		eb.Reserve(len(ends))
+		for _, v := range ends {
+			eb.UnsafeAppend(int32(v))
+		}
+		return eb.NewArray()
+	default:
+		eb := NewInt64Builder(mem)
+		defer eb.Release()
+		eb.Reserve(len(ends))
+		for _, v := range ends {
+			eb.UnsafeAppend(int64(v))
+		}
+		return eb.NewArray()
+	}
+}
+
+// maxRunEnd returns the largest run end representable with dt.
+func maxRunEnd(dt arrow.DataType) int {
+	switch dt.ID() {
+	case arrow.INT16:
+		return math.MaxInt16
+	case arrow.INT32:
+		return math.MaxInt32
+	}
+	return math.MaxInt64
+}
+
+func (b *RunEndEncodedBuilder) newData() (data *Data) {
+	if got, want := b.values.Len(), len(b.ends); got != want {
+		panic(fmt.Errorf("arrow/array: run-end encoded builder has %d runs but %d values", want, got))
+	}
+
+	ends := newRunEnds(b.mem, b.dtype.RunEnds(), b.ends)
+	defer ends.Release()
+	values := b.values.NewArray()
+	defer values.Release()
+
+	data = NewData(
+		b.dtype, b.length,
+		[]*memory.Buffer{nil},
+		[]*Data{ends.Data(), values.Data()},
+		0,
+		0,
+	)
+	b.ends = nil
+	b.builder.reset()
+
+	return
+}
+
+var (
+	_ Interface = (*RunEndEncoded)(nil)
+	_ Builder   = (*RunEndEncodedBuilder)(nil)
+)
@@ -0,0 +1,115 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunEndEncodedArray(t *testing.T) {
+	for _, runEnds := range []arrow.DataType{
+		arrow.PrimitiveTypes.Int16,
+		arrow.PrimitiveTypes.Int32,
+		arrow.PrimitiveTypes.Int64,
+	} {
+		t.Run(runEnds.Name(), func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			dtype := arrow.RunEndEncodedOf(runEnds, arrow.BinaryTypes.String)
+			bld := array.NewBuilder(mem, dtype).(*array.RunEndEncodedBuilder)
+			defer bld.Release()
+
+			vb := bld.ValueBuilder().(*array.StringBuilder)
+			bld.Append(3)
+			vb.Append("a")
+			bld.AppendNulls(2)
+			bld.Append(1)
+			vb.Append("b")
+			bld.ContinueRun(3)
+
+			assert.Equal(t, 9, bld.Len())
+			assert.Equal(t, 0, bld.NullN())
+
+			arr := bld.NewRunEndEncodedArray()
+			defer arr.Release()
+
+			assert.Equal(t, arrow.RUN_END_ENCODED, arr.DataType().ID())
+			assert.Equal(t, 9, arr.Len())
+			assert.Equal(t, 0, arr.NullN())
+			assert.Equal(t, 2, arr.LogicalNullN())
+			assert.Equal(t, 3, arr.RunEnds().Len())
+			assert.Equal(t, `["a" (null) "b"]`, arr.Values().(*array.String).String())
+
+			want := []int{0, 0, 0, 1, 1, 2, 2, 2, 2}
+			for i, w := range want {
+				assert.Equal(t, w, arr.PhysicalIndex(i), "slot %d", i)
+				assert.Equal(t, w == 1, arr.IsNull(i), "slot %d", i)
+			}
+			assert.Equal(t, 0, arr.PhysicalOffset())
+			assert.Equal(t, 3, arr.PhysicalLength())
+
+			slice := array.NewSlice(arr, 4, 7).(*array.RunEndEncoded)
+			defer slice.Release()
+
+			assert.Equal(t, 3, slice.Len())
+			assert.Equal(t, 0, slice.NullN())
+			assert.Equal(t, 1, slice.LogicalNullN())
+			assert.Equal(t, 1, slice.PhysicalOffset())
+			assert.Equal(t, 2, slice.PhysicalLength())
+			assert.Equal(t, 2, slice.PhysicalIndex(1))
+			assert.True(t, slice.IsNull(0))
+			assert.True(t, slice.IsValid(2))
+		})
+	}
+}
+
+func TestRunEndEncodedBuilderErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	bld := array.NewRunEndEncodedBuilder(mem, arrow.PrimitiveTypes.Int16, arrow.PrimitiveTypes.Float64)
+	defer bld.Release()
+
+	assert.Panics(t, func() { bld.ContinueRun(1) })
+	assert.Panics(t, func() { bld.Append(0) })
+	assert.Panics(t, func() { bld.Append(-1) })
+	assert.Panics(t, func() { bld.AppendNulls(-1) })
+	bld.AppendNulls(0)
+	assert.Equal(t, 0, bld.Len())
+
+	bld.Append(1 << 14)
+	bld.ValueBuilder().(*array.Float64Builder).Append(1)
+	assert.Panics(t, func() { bld.ContinueRun(1 << 15) })
+
+	bld.Append(1)
+	assert.Panics(t, func() { bld.NewArray() })
+	bld.ValueBuilder().(*array.Float64Builder).Append(2)
+
+	arr := bld.NewArray()
+	defer arr.Release()
+	assert.Equal(t, 1<<14+1, arr.Len())
+	assert.Equal(t, "[16384 16385]", fmt.Sprint(arr.(*array.RunEndEncoded).RunEnds()))
+
+	assert.Panics(t, func() { arrow.RunEndEncodedOf(arrow.PrimitiveTypes.Uint32, arrow.PrimitiveTypes.Float64) })
+}
@@ -0,0 +1,159 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// ExtensionArray is the interface implemented by the arrays of extension
+// types. All ExtensionArray implementations embed ExtensionArrayBase.
+type ExtensionArray interface {
+	Interface
+
+	// ExtensionType returns the extension type of the array.
+	ExtensionType() arrow.ExtensionType
+	// Storage returns the underlying array of the extension array.
+	Storage() Interface
+
+	base() *ExtensionArrayBase
+	setData(data *Data)
+}
+
+// ExtensionArrayBase implements the common parts of the arrays of extension
+// types. It is meant to be embedded in the array types returned by the
+// ArrayType method of an arrow.ExtensionType:
+//
+//	type UUIDArray struct {
+//		array.ExtensionArrayBase
+//	}
+type ExtensionArrayBase struct {
+	array
+	storage Interface
+}
+
+// ExtensionType returns the extension type of the array.
+func (a *ExtensionArrayBase) ExtensionType() arrow.ExtensionType {
+	return a.DataType().(arrow.ExtensionType)
+}
+
+// Storage returns the underlying array of the extension array.
+func (a *ExtensionArrayBase) Storage() Interface { return a.storage }
+
+func (a *ExtensionArrayBase) base() *ExtensionArrayBase { return a }
+
+func (a *ExtensionArrayBase) setData(data *Data) {
+	a.array.setData(data)
+	if a.storage != nil {
+		a.storage.Release()
+	}
+
+	storage := NewData(
+		a.ExtensionType().StorageType(), data.length, data.buffers, data.childData, data.nulls, data.offset,
+	)
+	a.storage = MakeFromData(storage)
+	storage.Release()
+}
+
+// Release decreases the reference count by 1.
+// Release may be called simultaneously from multiple goroutines.
+// When the reference count goes to zero, the memory is freed.
+func (a *ExtensionArrayBase) Release() {
+	debug.Assert(atomic.LoadInt64(&a.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.data.Release()
+		a.storage.Release()
+		a.data, a.nullBitmapBytes, a.storage = nil, nil, nil
+	}
+}
+
+// NewExtensionData returns a new array of the extension type of data, using
+// the array type given by the ArrayType method of the extension type.
+//
+// NewExtensionData panics if the array type does not embed ExtensionArrayBase.
+func NewExtensionData(data *Data) ExtensionArray {
+	typ := data.dtype.(arrow.ExtensionType)
+	rt := typ.ArrayType()
+	if rt.Kind() != reflect.Ptr {
+		panic(fmt.Errorf("arrow/array: invalid extension array type %v", rt))
+	}
+
+	a, ok := reflect.New(rt.Elem()).Interface().(ExtensionArray)
+	if !ok {
+		panic(fmt.Errorf("arrow/array: extension array type %v does not embed ExtensionArrayBase", rt))
+	}
+	a.base().refCount = 1
+	a.setData(data)
+	return a
+}
+
+// NewExtensionArrayWithStorage returns a new array of type dt, sharing the
+// memory of storage.
+//
+// NewExtensionArrayWithStorage panics if the type of storage is not the
+// storage type of dt.
+func NewExtensionArrayWithStorage(dt arrow.ExtensionType, storage Interface) ExtensionArray {
+	if got, want := storage.DataType().ID(), dt.StorageType().ID(); got != want {
+		panic(fmt.Errorf("arrow/array: invalid storage type %s for extension type %s (want %s)", got, dt.ExtensionName(), want))
+	}
+
+	sd := storage.Data()
+	data := NewData(dt, sd.length, sd.buffers, sd.childData, sd.nulls, sd.offset)
+	defer data.Release()
+	return NewExtensionData(data)
+}
+
+// ExtensionBuilder builds the arrays of an extension type, by appending the
+// values to the builder of its storage type.
+type ExtensionBuilder struct {
+	Builder
+	dtype arrow.ExtensionType
+}
+
+// NewExtensionBuilder returns a builder, using the provided memory allocator.
+func NewExtensionBuilder(mem memory.Allocator, dtype arrow.ExtensionType) *ExtensionBuilder {
+	return &ExtensionBuilder{Builder: NewBuilder(mem, dtype.StorageType()), dtype: dtype}
+}
+
+// StorageBuilder returns the builder of the underlying storage type.
+func (b *ExtensionBuilder) StorageBuilder() Builder { return b.Builder }
+
+// NewArray creates an extension array from the memory buffers used by the builder and resets the
+// ExtensionBuilder so it can be used to build a new array.
+func (b *ExtensionBuilder) NewArray() Interface {
+	return b.NewExtensionArray()
+}
+
+// NewExtensionArray creates an extension array from the memory buffers used by the builder and resets the
+// ExtensionBuilder so it can be used to build a new array.
+func (b *ExtensionBuilder) NewExtensionArray() ExtensionArray {
+	storage := b.Builder.NewArray()
+	defer storage.Release()
+	return NewExtensionArrayWithStorage(b.dtype, storage)
+}
+
+var (
+	_ ExtensionArray = (*ExtensionArrayBase)(nil)
+	_ Builder        = (*ExtensionBuilder)(nil)
+)
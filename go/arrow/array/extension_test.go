@@ -0,0 +1,184 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+// UUIDType is an extension type storing UUIDs as 16-byte fixed size binaries.
+type UUIDType struct {
+	arrow.ExtensionBase
+}
+
+func NewUUIDType() *UUIDType {
+	return &UUIDType{ExtensionBase: arrow.ExtensionBase{Storage: &arrow.FixedSizeBinaryType{ByteWidth: 16}}}
+}
+
+func (*UUIDType) Name() string            { return "uuid" }
+func (*UUIDType) ExtensionName() string   { return "arrow.test.uuid" }
+func (*UUIDType) ArrayType() reflect.Type { return reflect.TypeOf(&UUIDArray{}) }
+func (*UUIDType) Serialize() string       { return "" }
+
+func (*UUIDType) ExtensionEquals(other arrow.ExtensionType) bool {
+	return other.ExtensionName() == "arrow.test.uuid"
+}
+
+func (*UUIDType) Deserialize(storage arrow.DataType, data string) (arrow.ExtensionType, error) {
+	if dt, ok := storage.(*arrow.FixedSizeBinaryType); !ok || dt.ByteWidth != 16 {
+		return nil, fmt.Errorf("invalid storage type %s for uuid", storage.Name())
+	}
+	return NewUUIDType(), nil
+}
+
+type UUIDArray struct {
+	array.ExtensionArrayBase
+}
+
+func (a *UUIDArray) Value(i int) string {
+	return fmt.Sprintf("%x", a.Storage().(*array.FixedSizeBinary).Value(i))
+}
+
+// Int32Unit is a parametric extension type, storing a quantity with its unit.
+type Int32Unit struct {
+	arrow.ExtensionBase
+	Unit string
+}
+
+func (*Int32Unit) Name() string            { return "int32_unit" }
+func (*Int32Unit) ExtensionName() string   { return "arrow.test.int32_unit" }
+func (*Int32Unit) ArrayType() reflect.Type { return reflect.TypeOf(&Int32UnitArray{}) }
+func (t *Int32Unit) Serialize() string     { return strconv.Quote(t.Unit) }
+
+func (t *Int32Unit) ExtensionEquals(other arrow.ExtensionType) bool {
+	o, ok := other.(*Int32Unit)
+	return ok && o.Unit == t.Unit
+}
+
+func (*Int32Unit) Deserialize(storage arrow.DataType, data string) (arrow.ExtensionType, error) {
+	unit, err := strconv.Unquote(data)
+	if err != nil {
+		return nil, err
+	}
+	return &Int32Unit{ExtensionBase: arrow.ExtensionBase{Storage: storage}, Unit: unit}, nil
+}
+
+type Int32UnitArray struct {
+	array.ExtensionArrayBase
+}
+
+func TestExtensionArray(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	dtype := &Int32Unit{ExtensionBase: arrow.ExtensionBase{Storage: arrow.PrimitiveTypes.Int32}, Unit: "m"}
+	assert.Equal(t, arrow.EXTENSION, dtype.ID())
+
+	bld := array.NewBuilder(mem, dtype).(*array.ExtensionBuilder)
+	defer bld.Release()
+
+	bld.StorageBuilder().(*array.Int32Builder).AppendValues([]int32{1, 2, 3, 4}, []bool{true, true, false, true})
+	assert.Equal(t, 4, bld.Len())
+
+	arr := bld.NewArray().(*Int32UnitArray)
+	defer arr.Release()
+
+	assert.Equal(t, 4, arr.Len())
+	assert.Equal(t, 1, arr.NullN())
+	assert.True(t, arr.IsNull(2))
+	assert.True(t, arr.ExtensionType() == dtype)
+	assert.Equal(t, []int32{1, 2, 3, 4}, arr.Storage().(*array.Int32).Int32Values())
+
+	slice := array.NewSlice(arr, 1, 3).(*Int32UnitArray)
+	defer slice.Release()
+
+	assert.Equal(t, 2, slice.Len())
+	assert.True(t, slice.IsNull(1))
+	assert.Equal(t, []int32{2, 3}, slice.Storage().(*array.Int32).Int32Values())
+}
+
+func TestExtensionArrayWithStorage(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewFixedSizeBinaryBuilder(mem, &arrow.FixedSizeBinaryType{ByteWidth: 16})
+	defer b.Release()
+	b.Append([]byte("0123456789abcdef"))
+	b.AppendNull()
+	storage := b.NewArray()
+	defer storage.Release()
+
+	arr := array.NewExtensionArrayWithStorage(NewUUIDType(), storage)
+	defer arr.Release()
+
+	uuids, ok := arr.(*UUIDArray)
+	if !ok {
+		t.Fatalf("invalid array type %T", arr)
+	}
+	assert.Equal(t, "30313233343536373839616263646566", uuids.Value(0))
+	assert.True(t, uuids.IsNull(1))
+
+	other := array.MakeFromData(arr.Data())
+	defer other.Release()
+	assert.IsType(t, (*UUIDArray)(nil), other)
+
+	ints := array.NewInt32Builder(mem)
+	defer ints.Release()
+	wrong := ints.NewArray()
+	defer wrong.Release()
+	assert.Panics(t, func() { array.NewExtensionArrayWithStorage(NewUUIDType(), wrong) })
+}
+
+func TestExtensionTypeRegistry(t *testing.T) {
+	dtype := NewUUIDType()
+	if err := arrow.RegisterExtensionType(dtype); err != nil {
+		t.Fatal(err)
+	}
+	defer arrow.UnregisterExtensionType(dtype.ExtensionName())
+
+	if err := arrow.RegisterExtensionType(dtype); err == nil {
+		t.Fatalf("expected an error registering a type twice")
+	}
+	assert.True(t, arrow.GetExtensionType("arrow.test.uuid") == dtype)
+
+	md := arrow.ExtensionMetadata(dtype, arrow.NewMetadata([]string{"k"}, []string{"v"}))
+	assert.Equal(t, []string{arrow.ExtensionNameKey, arrow.ExtensionMetadataKey, "k"}, md.Keys())
+
+	got, err := arrow.ExtensionTypeFromMetadata(&arrow.FixedSizeBinaryType{ByteWidth: 16}, md)
+	assert.NoError(t, err)
+	assert.True(t, dtype.ExtensionEquals(got))
+
+	_, err = arrow.ExtensionTypeFromMetadata(arrow.PrimitiveTypes.Int32, md)
+	assert.Error(t, err)
+
+	got, err = arrow.ExtensionTypeFromMetadata(arrow.PrimitiveTypes.Int32, arrow.Metadata{})
+	assert.NoError(t, err)
+	assert.Nil(t, got)
+
+	assert.NoError(t, arrow.UnregisterExtensionType(dtype.ExtensionName()))
+	assert.Error(t, arrow.UnregisterExtensionType(dtype.ExtensionName()))
+	assert.Nil(t, arrow.GetExtensionType("arrow.test.uuid"))
+	assert.NoError(t, arrow.RegisterExtensionType(dtype))
+}
@@ -35,6 +35,7 @@ func NewFixedSizeBinaryData(data *Data) *FixedSizeBinary {
 
 // Value returns the fixed-size slice at index i. This value should not be mutated.
 func (a *FixedSizeBinary) Value(i int) []byte {
+	i = i + a.array.data.offset
 	return a.valueBytes[a.valueOffsets[i]:a.valueOffsets[i+1]]
 }
 
@@ -43,6 +44,8 @@ func (a *FixedSizeBinary) ValueLen(i int) int    { return int(a.valueOffsets[i+1
 func (a *FixedSizeBinary) ValueOffsets() []int32 { return a.valueOffsets }
 func (a *FixedSizeBinary) ValueBytes() []byte    { return a.valueBytes }
 
+func (a *FixedSizeBinary) String() string { return formatString(a) }
+
 func (a *FixedSizeBinary) setData(data *Data) {
 	if len(data.buffers) != 3 {
 		panic("len(data.buffers) != 3")
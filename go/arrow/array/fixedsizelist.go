@@ -0,0 +1,209 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// FixedSizeList represents an immutable sequence of array values, each
+// holding the same number of elements.
+type FixedSizeList struct {
+	array
+	n      int32
+	values Interface
+}
+
+// NewFixedSizeListData returns a new FixedSizeList array value, from data.
+func NewFixedSizeListData(data *Data) *FixedSizeList {
+	a := &FixedSizeList{}
+	a.refCount = 1
+	a.setData(data)
+	return a
+}
+
+func (a *FixedSizeList) ListValues() Interface { return a.values }
+
+func (a *FixedSizeList) String() string { return formatString(a) }
+
+func (a *FixedSizeList) setData(data *Data) {
+	a.array.setData(data)
+	a.n = data.dtype.(*arrow.FixedSizeListType).Len()
+	a.values = MakeFromData(data.childData[0])
+}
+
+// Len returns the number of elements in the array.
+func (a *FixedSizeList) Len() int { return a.array.Len() }
+
+// ValueOffsets returns the range [start, end) of the elements of the i-th
+// list in ListValues.
+func (a *FixedSizeList) ValueOffsets(i int) (start, end int64) {
+	n := int64(a.n)
+	start = int64(a.data.offset+i) * n
+	return start, start + n
+}
+
+// ValueSlice returns a zero-copy slice of the elements of the i-th list.
+// The returned array must be Release()'d after use.
+func (a *FixedSizeList) ValueSlice(i int) Interface {
+	beg, end := a.ValueOffsets(i)
+	return NewSlice(a.values, beg, end)
+}
+
+// Release decreases the reference count by 1.
+// Release may be called simultaneously from multiple goroutines.
+// When the reference count goes to zero, the memory is freed.
+func (a *FixedSizeList) Release() {
+	debug.Assert(atomic.LoadInt64(&a.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.data.Release()
+		a.values.Release()
+		a.data, a.nullBitmapBytes, a.values = nil, nil, nil
+	}
+}
+
+type FixedSizeListBuilder struct {
+	builder
+
+	n      int32          // number of elements in each list.
+	etype  arrow.DataType // data type of the list's elements.
+	values Builder        // value builder for the list's elements.
+}
+
+// NewFixedSizeListBuilder returns a builder, using the provided memory allocator.
+// The created list builder will create a list of n elements of type etype.
+func NewFixedSizeListBuilder(mem memory.Allocator, n int32, etype arrow.DataType) *FixedSizeListBuilder {
+	return &FixedSizeListBuilder{
+		builder: builder{refCount: 1, mem: mem},
+		n:       n,
+		etype:   etype,
+		values:  NewBuilder(mem, etype),
+	}
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+func (b *FixedSizeListBuilder) Release() {
+	debug.Assert(atomic.LoadInt64(&b.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&b.refCount, -1) == 0 {
+		if b.nullBitmap != nil {
+			b.nullBitmap.Release()
+			b.nullBitmap = nil
+		}
+		b.values.Release()
+	}
+}
+
+// Append appends a list slot, valid or not. The n elements of a valid
+// list must be appended to the ValueBuilder.
+func (b *FixedSizeListBuilder) Append(v bool) {
+	b.Reserve(1)
+	b.unsafeAppendBoolToBitmap(v)
+}
+
+// AppendNull appends a null list, along with its n null elements.
+func (b *FixedSizeListBuilder) AppendNull() {
+	b.Reserve(1)
+	b.unsafeAppendBoolToBitmap(false)
+	b.values.AppendNulls(int(b.n))
+}
+
+// AppendNulls appends n null lists to the builder, along with their
+// null elements.
+func (b *FixedSizeListBuilder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+	b.values.AppendNulls(n * int(b.n))
+}
+
+// AppendValues appends len(valid) list slots. The valid slice determines
+// which lists are valid (not null). The elements of all the lists, null
+// ones included, must be appended to the ValueBuilder.
+func (b *FixedSizeListBuilder) AppendValues(valid []bool) {
+	b.Reserve(len(valid))
+	b.builder.unsafeAppendBoolsToBitmap(valid, len(valid))
+}
+
+func (b *FixedSizeListBuilder) unsafeAppendBoolToBitmap(isValid bool) {
+	b.builder.UnsafeAppendBoolToBitmap(isValid)
+}
+
+// Reserve ensures there is enough space for appending n elements
+// by checking the capacity and calling Resize if necessary.
+func (b *FixedSizeListBuilder) Reserve(n int) {
+	b.builder.reserve(n, b.Resize)
+}
+
+// Resize adjusts the space allocated by b to n elements. If n is greater than b.Cap(),
+// additional memory will be allocated. If n is smaller, the allocated memory may reduced.
+func (b *FixedSizeListBuilder) Resize(n int) {
+	if n < minBuilderCapacity {
+		n = minBuilderCapacity
+	}
+
+	if b.capacity == 0 {
+		b.init(n)
+	} else {
+		b.builder.resize(n, b.builder.init)
+	}
+}
+
+func (b *FixedSizeListBuilder) ValueBuilder() Builder {
+	return b.values
+}
+
+// NewArray creates a FixedSizeList array from the memory buffers used by the builder and resets the FixedSizeListBuilder
+// so it can be used to build a new array.
+func (b *FixedSizeListBuilder) NewArray() Interface {
+	return b.NewFixedSizeListArray()
+}
+
+// NewFixedSizeListArray creates a FixedSizeList array from the memory buffers used by the builder and resets the FixedSizeListBuilder
+// so it can be used to build a new array.
+func (b *FixedSizeListBuilder) NewFixedSizeListArray() (a *FixedSizeList) {
+	data := b.newData()
+	a = NewFixedSizeListData(data)
+	data.Release()
+	return
+}
+
+func (b *FixedSizeListBuilder) newData() (data *Data) {
+	values := b.values.NewArray()
+	defer values.Release()
+
+	data = NewData(
+		arrow.FixedSizeListOf(b.n, b.etype), b.length,
+		[]*memory.Buffer{b.nullBitmap},
+		[]*Data{values.Data()},
+		b.nulls,
+		0,
+	)
+	b.reset()
+
+	return
+}
+
+var (
+	_ Interface = (*FixedSizeList)(nil)
+	_ Builder   = (*FixedSizeListBuilder)(nil)
+)
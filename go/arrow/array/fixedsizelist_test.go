@@ -0,0 +1,112 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestFixedSizeListArray(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	var (
+		vs      = []int32{0, 1, 2, 3, 4, 5, 6, 7, 8}
+		isValid = []bool{true, false, true}
+	)
+
+	lb := array.NewBuilder(pool, arrow.FixedSizeListOf(3, arrow.PrimitiveTypes.Int32)).(*array.FixedSizeListBuilder)
+	defer lb.Release()
+
+	for i := 0; i < 10; i++ {
+		vb := lb.ValueBuilder().(*array.Int32Builder)
+		vb.Reserve(len(vs))
+
+		for i, v := range isValid {
+			lb.Append(v)
+			vb.AppendValues(vs[3*i:3*i+3], nil)
+		}
+
+		arr := lb.NewArray().(*array.FixedSizeList)
+		defer arr.Release()
+
+		if got, want := arr.DataType().ID(), arrow.FIXED_SIZE_LIST; got != want {
+			t.Fatalf("got=%v, want=%v", got, want)
+		}
+
+		if got, want := arr.Len(), len(isValid); got != want {
+			t.Fatalf("got=%d, want=%d", got, want)
+		}
+
+		for i := range isValid {
+			if got, want := arr.IsValid(i), isValid[i]; got != want {
+				t.Fatalf("got[%d]=%v, want[%d]=%v", i, got, i, want)
+			}
+			beg, end := arr.ValueOffsets(i)
+			if got, want := [2]int64{beg, end}, [2]int64{int64(3 * i), int64(3*i + 3)}; got != want {
+				t.Fatalf("got[%d]=%v, want[%d]=%v", i, got, i, want)
+			}
+		}
+
+		varr := arr.ListValues().(*array.Int32)
+		if got, want := varr.Int32Values(), vs; !reflect.DeepEqual(got, want) {
+			t.Fatalf("got=%v, want=%v", got, want)
+		}
+	}
+}
+
+func TestFixedSizeListArrayValueSlice(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	lb := array.NewFixedSizeListBuilder(pool, 2, arrow.PrimitiveTypes.Float32)
+	defer lb.Release()
+	vb := lb.ValueBuilder().(*array.Float32Builder)
+
+	lb.AppendValues([]bool{true, true, true})
+	vb.AppendValues([]float32{1, 2, 3, 4, 5, 6}, nil)
+	lb.AppendNull()
+
+	arr := lb.NewFixedSizeListArray()
+	defer arr.Release()
+
+	if got, want := arr.NullN(), 1; got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+	if got, want := arr.ListValues().Len(), 8; got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+
+	slice := array.NewSlice(arr, 1, 4).(*array.FixedSizeList)
+	defer slice.Release()
+
+	for i, want := range [][]float32{{3, 4}, {5, 6}} {
+		v := slice.ValueSlice(i).(*array.Float32)
+		if got := v.Float32Values(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("got[%d]=%v, want[%d]=%v", i, got, i, want)
+		}
+		v.Release()
+	}
+	if got, want := slice.String(), "[[3 4] [5 6] (null)]"; got != want {
+		t.Fatalf("got=%q, want=%q", got, want)
+	}
+}
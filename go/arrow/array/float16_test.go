@@ -0,0 +1,71 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/float16"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFloat16Builder(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ab := array.NewFloat16Builder(mem)
+	defer ab.Release()
+
+	ab.Append(float16.New(1.5))
+	ab.AppendNull()
+	ab.AppendValues([]float16.Num{float16.New(-2), float16.New(0.1)}, nil)
+	ab.AppendFloat32(65504)
+	ab.AppendFloat32Values([]float32{3, 4, 1e6}, []bool{true, false, true})
+
+	assert.Equal(t, 8, ab.Len(), "unexpected Len()")
+	assert.Equal(t, 2, ab.NullN(), "unexpected NullN()")
+
+	a := ab.NewArray().(*array.Float16)
+	defer a.Release()
+
+	assert.Zero(t, ab.Len(), "unexpected ArrayBuilder.Len(), NewArray did not reset state")
+	assert.Zero(t, ab.Cap(), "unexpected ArrayBuilder.Cap(), NewArray did not reset state")
+
+	assert.Equal(t, arrow.FixedWidthTypes.Float16, a.DataType())
+	assert.Equal(t, 2, a.NullN(), "unexpected null count")
+	assert.Equal(t, float32(1.5), a.Value(0).Float32())
+	assert.Equal(t, float32(-2), a.Value(2).Float32())
+	assert.Equal(t, "[1.5 (null) -2 0.1 65500 3 (null) +Inf]", a.String())
+
+	slice := array.NewSlice(a, 2, 4).(*array.Float16)
+	defer slice.Release()
+
+	assert.Equal(t, []float16.Num{float16.New(-2), float16.New(0.1)}, slice.Values())
+
+	// the builder must be usable through the generic constructor, and from JSON.
+	b := array.NewBuilder(mem, arrow.FixedWidthTypes.Float16).(*array.Float16Builder)
+	defer b.Release()
+
+	assert.NoError(t, b.UnmarshalJSON([]byte(`[0.5, null, 2]`)))
+	c := b.NewArray()
+	defer c.Release()
+
+	assert.Equal(t, "[0.5 (null) 2]", c.(*array.Float16).String())
+}
@@ -0,0 +1,120 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonthIntervalBuilder(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ab := array.NewMonthIntervalBuilder(mem)
+	defer ab.Release()
+
+	ab.Append(1)
+	ab.AppendNull()
+	ab.AppendValues([]arrow.MonthInterval{-2, 3}, nil)
+
+	a := ab.NewMonthIntervalArray()
+	defer a.Release()
+
+	assert.Equal(t, arrow.FixedWidthTypes.MonthInterval, a.DataType())
+	assert.Equal(t, 1, a.NullN())
+	assert.Equal(t, "[1 (null) -2 3]", a.String())
+
+	slice := array.NewSlice(a, 2, 4).(*array.MonthInterval)
+	defer slice.Release()
+
+	assert.Equal(t, []arrow.MonthInterval{-2, 3}, slice.MonthIntervalValues())
+}
+
+func TestDayTimeIntervalBuilder(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ab := array.NewBuilder(mem, arrow.FixedWidthTypes.DayTimeInterval).(*array.DayTimeIntervalBuilder)
+	defer ab.Release()
+
+	want := []arrow.DayTimeInterval{{Days: 1, Milliseconds: 2}, {}, {Days: -3, Milliseconds: 4}}
+	for i := 0; i < 20; i++ {
+		ab.AppendValues(want, []bool{true, false, true})
+	}
+	ab.AppendNulls(2)
+
+	a := ab.NewArray().(*array.DayTimeInterval)
+	defer a.Release()
+
+	assert.Equal(t, 62, a.Len())
+	assert.Equal(t, 22, a.NullN())
+	assert.Equal(t, want[2], a.Value(59))
+
+	slice := array.NewSlice(a, 57, 60).(*array.DayTimeInterval)
+	defer slice.Release()
+
+	assert.Equal(t, "[1d2ms (null) -3d4ms]", slice.String())
+	assert.Equal(t, "-3d4ms", array.FormatValue(slice, 2))
+}
+
+func TestMonthDayNanoIntervalBuilder(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ab := array.NewMonthDayNanoIntervalBuilder(mem)
+	defer ab.Release()
+
+	v := arrow.MonthDayNanoInterval{Months: 1, Days: -2, Nanoseconds: 1 << 40}
+	ab.Append(v)
+	ab.AppendNull()
+
+	a := ab.NewMonthDayNanoIntervalArray()
+	defer a.Release()
+
+	assert.Equal(t, arrow.FixedWidthTypes.MonthDayNanoInterval, a.DataType())
+	assert.Equal(t, []arrow.MonthDayNanoInterval{v, {}}, a.MonthDayNanoIntervalValues())
+	assert.Equal(t, "[1M-2d1099511627776ns (null)]", a.String())
+
+	// intervals are rebuilt from their data, and compared by value.
+	b := array.MakeFromData(a.Data())
+	defer b.Release()
+
+	assert.IsType(t, (*array.MonthDayNanoInterval)(nil), b)
+	assert.True(t, array.ArrayEqual(a, b))
+}
+
+func TestDurationBuilder(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ab := array.NewBuilder(mem, arrow.FixedWidthTypes.Duration_ms).(*array.DurationBuilder)
+	defer ab.Release()
+
+	ab.AppendValues([]arrow.Duration{1500, -1}, []bool{true, false})
+
+	a := ab.NewDurationArray()
+	defer a.Release()
+
+	assert.Equal(t, arrow.FixedWidthTypes.Duration_ms, a.DataType())
+	assert.Equal(t, "[1500 (null)]", a.String())
+	assert.Equal(t, "DURATION", a.DataType().ID().String())
+}
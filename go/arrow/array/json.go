@@ -0,0 +1,735 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/decimal256"
+)
+
+// This file decodes JSON documents straight into builders, consuming the
+// tokens of a json.Decoder. The encoding of each type is:
+//   - null is appended as a null value, for any type;
+//   - booleans, numbers and strings map to the corresponding types;
+//   - binary values are base64-encoded strings;
+//   - decimals are numbers or strings;
+//   - dates, times and timestamps are either numbers (in the unit of the
+//     type) or strings ("2006-01-02", "15:04:05.999999999", RFC 3339);
+//   - lists are JSON arrays;
+//   - structs are JSON objects, missing fields are appended as nulls and
+//     unknown fields are ignored;
+//   - maps are JSON objects, whose keys are decoded according to the key type;
+//   - unions are [type_code, value] pairs;
+//   - run-end encoded values are appended as runs of length one.
+
+const (
+	jsonDateLayout = "2006-01-02"
+	jsonTimeLayout = "15:04:05.999999999"
+)
+
+var jsonTimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	jsonDateLayout,
+}
+
+// AppendJSON reads the next JSON value from dec and appends it to b.
+// dec should be configured with UseNumber so that integers are decoded
+// exactly.
+//
+// If AppendJSON returns an error, b may hold partially appended values.
+func AppendJSON(b Builder, dec *json.Decoder) error {
+	if err := appendJSON(b, dec); err != nil {
+		return fmt.Errorf("arrow/array: %w", err)
+	}
+	return nil
+}
+
+// unmarshalJSON appends the elements of data, a JSON array, to b.
+func unmarshalJSON(b Builder, data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	err := decodeJSONArray(dec, func() error { return appendJSON(b, dec) })
+	if err != nil {
+		return fmt.Errorf("arrow/array: %w", err)
+	}
+	return nil
+}
+
+// UnmarshalJSON appends rows to the record builder. data holds either a
+// single JSON object or a JSON array of objects, whose keys are the names
+// of the fields of the schema. Missing fields are appended as nulls and
+// unknown fields are ignored.
+func (b *RecordBuilder) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("arrow/array: %w", err)
+	}
+	switch tok {
+	case json.Delim('['):
+		for dec.More() {
+			if err := b.AppendJSON(dec); err != nil {
+				return err
+			}
+		}
+		if _, err = dec.Token(); err != nil {
+			return fmt.Errorf("arrow/array: %w", err)
+		}
+	case json.Delim('{'):
+		if err := b.appendJSONRow(dec); err != nil {
+			return fmt.Errorf("arrow/array: %w", err)
+		}
+	default:
+		return fmt.Errorf("arrow/array: expected JSON object or array, got %v", tok)
+	}
+	if err := expectJSONEnd(dec); err != nil {
+		return fmt.Errorf("arrow/array: %w", err)
+	}
+	return nil
+}
+
+// AppendJSON reads the next JSON object from dec and appends it as a row.
+// dec should be configured with UseNumber so that integers are decoded
+// exactly.
+func (b *RecordBuilder) AppendJSON(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err == nil && tok != json.Delim('{') {
+		err = fmt.Errorf("expected JSON object, got %v", tok)
+	}
+	if err == nil {
+		err = b.appendJSONRow(dec)
+	}
+	if err != nil {
+		return fmt.Errorf("arrow/array: %w", err)
+	}
+	return nil
+}
+
+func (b *RecordBuilder) appendJSONRow(dec *json.Decoder) error {
+	return appendJSONFields(dec, b.schema.Fields(), b.fields)
+}
+
+func (b *NullBuilder) UnmarshalJSON(data []byte) error                 { return unmarshalJSON(b, data) }
+func (b *BooleanBuilder) UnmarshalJSON(data []byte) error              { return unmarshalJSON(b, data) }
+func (b *BinaryBuilder) UnmarshalJSON(data []byte) error               { return unmarshalJSON(b, data) }
+func (b *LargeBinaryBuilder) UnmarshalJSON(data []byte) error          { return unmarshalJSON(b, data) }
+func (b *StringBuilder) UnmarshalJSON(data []byte) error               { return unmarshalJSON(b, data) }
+func (b *LargeStringBuilder) UnmarshalJSON(data []byte) error          { return unmarshalJSON(b, data) }
+func (b *BinaryViewBuilder) UnmarshalJSON(data []byte) error           { return unmarshalJSON(b, data) }
+func (b *StringViewBuilder) UnmarshalJSON(data []byte) error           { return unmarshalJSON(b, data) }
+func (b *FixedSizeBinaryBuilder) UnmarshalJSON(data []byte) error      { return unmarshalJSON(b, data) }
+func (b *Float16Builder) UnmarshalJSON(data []byte) error              { return unmarshalJSON(b, data) }
+func (b *Decimal256Builder) UnmarshalJSON(data []byte) error           { return unmarshalJSON(b, data) }
+func (b *MonthIntervalBuilder) UnmarshalJSON(data []byte) error        { return unmarshalJSON(b, data) }
+func (b *DayTimeIntervalBuilder) UnmarshalJSON(data []byte) error      { return unmarshalJSON(b, data) }
+func (b *MonthDayNanoIntervalBuilder) UnmarshalJSON(data []byte) error { return unmarshalJSON(b, data) }
+func (b *ListBuilder) UnmarshalJSON(data []byte) error                 { return unmarshalJSON(b, data) }
+func (b *LargeListBuilder) UnmarshalJSON(data []byte) error            { return unmarshalJSON(b, data) }
+func (b *FixedSizeListBuilder) UnmarshalJSON(data []byte) error        { return unmarshalJSON(b, data) }
+func (b *MapBuilder) UnmarshalJSON(data []byte) error                  { return unmarshalJSON(b, data) }
+func (b *StructBuilder) UnmarshalJSON(data []byte) error               { return unmarshalJSON(b, data) }
+func (b *UnionBuilder) UnmarshalJSON(data []byte) error                { return unmarshalJSON(b, data) }
+func (b *RunEndEncodedBuilder) UnmarshalJSON(data []byte) error        { return unmarshalJSON(b, data) }
+
+// decodeJSONArray reads a JSON array from dec, calling elem for each of
+// its elements, and checks that the array is the last value of dec.
+func decodeJSONArray(dec *json.Decoder, elem func() error) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok != json.Delim('[') {
+		return fmt.Errorf("expected JSON array, got %v", tok)
+	}
+	for i := 0; dec.More(); i++ {
+		if err := elem(); err != nil {
+			return fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	return expectJSONEnd(dec)
+}
+
+func expectJSONEnd(dec *json.Decoder) error {
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("unexpected data after JSON value")
+	}
+	return nil
+}
+
+// appendJSON reads the next JSON value from dec and appends it to b.
+func appendJSON(b Builder, dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	return appendJSONToken(b, tok, dec)
+}
+
+// appendJSONToken appends the JSON value starting with tok to b, reading
+// the rest of the value from dec if tok opens an array or an object.
+func appendJSONToken(b Builder, tok json.Token, dec *json.Decoder) error {
+	if tok == nil {
+		b.AppendNull()
+		return nil
+	}
+
+	switch b := b.(type) {
+	case *ExtensionBuilder:
+		return appendJSONToken(b.Builder, tok, dec)
+	case *RunEndEncodedBuilder:
+		b.Append(1)
+		return appendJSONToken(b.ValueBuilder(), tok, dec)
+	}
+
+	switch tok {
+	case json.Delim('['):
+		return appendJSONArray(b, dec)
+	case json.Delim('{'):
+		return appendJSONObject(b, dec)
+	}
+	return appendJSONScalar(b, tok)
+}
+
+func appendJSONArray(b Builder, dec *json.Decoder) error {
+	var vb Builder
+	switch b := b.(type) {
+	case *ListBuilder:
+		b.Append(true)
+		vb = b.ValueBuilder()
+	case *LargeListBuilder:
+		b.Append(true)
+		vb = b.ValueBuilder()
+	case *FixedSizeListBuilder:
+		return appendJSONFixedSizeList(b, dec)
+	case *UnionBuilder:
+		return appendJSONUnion(b, dec)
+	default:
+		return fmt.Errorf("can not append JSON array to %T", b)
+	}
+
+	for dec.More() {
+		if err := appendJSON(vb, dec); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token()
+	return err
+}
+
+func appendJSONFixedSizeList(b *FixedSizeListBuilder, dec *json.Decoder) error {
+	b.Append(true)
+	vb := b.ValueBuilder()
+	n := 0
+	for dec.More() {
+		if err := appendJSON(vb, dec); err != nil {
+			return err
+		}
+		n++
+	}
+	if n != int(b.n) {
+		return fmt.Errorf("invalid list length %d, expected %d", n, b.n)
+	}
+	_, err := dec.Token()
+	return err
+}
+
+func appendJSONUnion(b *UnionBuilder, dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	code, err := jsonInt(tok, 8)
+	if err != nil {
+		return fmt.Errorf("invalid union type code: %w", err)
+	}
+	id := b.ChildID(int8(code))
+	if id < 0 {
+		return fmt.Errorf("invalid union type code %d", code)
+	}
+	b.Append(int8(code))
+	if err := appendJSON(b.Child(id), dec); err != nil {
+		return err
+	}
+	if tok, err = dec.Token(); err != nil {
+		return err
+	}
+	if tok != json.Delim(']') {
+		return errors.New("expected [type_code, value] pair for union")
+	}
+	return nil
+}
+
+func appendJSONObject(b Builder, dec *json.Decoder) error {
+	switch b := b.(type) {
+	case *StructBuilder:
+		b.Append(true)
+		return appendJSONFields(dec, b.dtype.(*arrow.StructType).Fields(), b.fields)
+	case *MapBuilder:
+		b.Append(true)
+		kb, ib := b.KeyBuilder(), b.ItemBuilder()
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			if err := appendJSONKey(kb, tok.(string)); err != nil {
+				return err
+			}
+			if err := appendJSON(ib, dec); err != nil {
+				return err
+			}
+		}
+		_, err := dec.Token()
+		return err
+	case *DayTimeIntervalBuilder:
+		v, err := jsonInterval(dec, []string{"days", "milliseconds"}, []int{32, 32})
+		if err != nil {
+			return err
+		}
+		b.Append(arrow.DayTimeInterval{Days: int32(v[0]), Milliseconds: int32(v[1])})
+		return nil
+	case *MonthDayNanoIntervalBuilder:
+		v, err := jsonInterval(dec, []string{"months", "days", "nanoseconds"}, []int{32, 32, 64})
+		if err != nil {
+			return err
+		}
+		b.Append(arrow.MonthDayNanoInterval{Months: int32(v[0]), Days: int32(v[1]), Nanoseconds: v[2]})
+		return nil
+	}
+	return fmt.Errorf("can not append JSON object to %T", b)
+}
+
+// jsonInterval reads the members of a JSON interval object from dec, after
+// its opening delimiter. Each member must be one of the named components,
+// an integer of the matching bit size. Missing components are zero.
+func jsonInterval(dec *json.Decoder, names []string, bits []int) ([]int64, error) {
+	vals := make([]int64, len(names))
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		name := tok.(string)
+
+		i := 0
+		for i < len(names) && names[i] != name {
+			i++
+		}
+		if i == len(names) {
+			return nil, fmt.Errorf("invalid interval component %q", name)
+		}
+		if tok, err = dec.Token(); err != nil {
+			return nil, err
+		}
+		if vals[i], err = jsonInt(tok, bits[i]); err != nil {
+			return nil, fmt.Errorf("interval component %q: %w", name, err)
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	return vals, nil
+}
+
+// appendJSONFields reads the members of a JSON object from dec, after its
+// opening delimiter, and appends them to the builders of the matching fields.
+func appendJSONFields(dec *json.Decoder, fields []arrow.Field, builders []Builder) error {
+	seen := make([]bool, len(fields))
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		name := tok.(string)
+
+		i := fieldByName(fields, name)
+		if i < 0 {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+		if seen[i] {
+			return fmt.Errorf("duplicate field %q", name)
+		}
+		seen[i] = true
+		if err := appendJSON(builders[i], dec); err != nil {
+			return fmt.Errorf("field %q: %w", name, err)
+		}
+	}
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	for i, ok := range seen {
+		if !ok {
+			builders[i].AppendNull()
+		}
+	}
+	return nil
+}
+
+func fieldByName(fields []arrow.Field, name string) int {
+	for i, f := range fields {
+		if f.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// appendJSONKey appends key, the name of a JSON object member, to the key
+// builder of a map. Keys of non-string types hold the JSON encoding of
+// the key, e.g. "1" or "true".
+func appendJSONKey(b Builder, key string) error {
+	switch b.(type) {
+	case *StringBuilder, *LargeStringBuilder, *StringViewBuilder, *BinaryBuilder, *LargeBinaryBuilder, *BinaryViewBuilder, *FixedSizeBinaryBuilder:
+		return appendJSONScalar(b, key)
+	}
+	dec := json.NewDecoder(bytes.NewReader([]byte(key)))
+	dec.UseNumber()
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("invalid map key %q: %w", key, err)
+	}
+	if tok == nil {
+		return errors.New("null map key")
+	}
+	return appendJSONScalar(b, tok)
+}
+
+// appendJSONScalar appends tok, a JSON boolean, number or string, to b.
+func appendJSONScalar(b Builder, tok json.Token) error {
+	var err error
+	switch b := b.(type) {
+	case *BooleanBuilder:
+		v, ok := tok.(bool)
+		if !ok {
+			break
+		}
+		b.Append(v)
+		return nil
+	case *Int8Builder:
+		var v int64
+		if v, err = jsonInt(tok, 8); err == nil {
+			b.Append(int8(v))
+		}
+		return err
+	case *Int16Builder:
+		var v int64
+		if v, err = jsonInt(tok, 16); err == nil {
+			b.Append(int16(v))
+		}
+		return err
+	case *Int32Builder:
+		var v int64
+		if v, err = jsonInt(tok, 32); err == nil {
+			b.Append(int32(v))
+		}
+		return err
+	case *Int64Builder:
+		var v int64
+		if v, err = jsonInt(tok, 64); err == nil {
+			b.Append(v)
+		}
+		return err
+	case *Uint8Builder:
+		var v uint64
+		if v, err = jsonUint(tok, 8); err == nil {
+			b.Append(uint8(v))
+		}
+		return err
+	case *Uint16Builder:
+		var v uint64
+		if v, err = jsonUint(tok, 16); err == nil {
+			b.Append(uint16(v))
+		}
+		return err
+	case *Uint32Builder:
+		var v uint64
+		if v, err = jsonUint(tok, 32); err == nil {
+			b.Append(uint32(v))
+		}
+		return err
+	case *Uint64Builder:
+		var v uint64
+		if v, err = jsonUint(tok, 64); err == nil {
+			b.Append(v)
+		}
+		return err
+	case *Float32Builder:
+		var v float64
+		if v, err = jsonFloat(tok, 32); err == nil {
+			b.Append(float32(v))
+		}
+		return err
+	case *Float64Builder:
+		var v float64
+		if v, err = jsonFloat(tok, 64); err == nil {
+			b.Append(v)
+		}
+		return err
+	case *Float16Builder:
+		var v float64
+		if v, err = jsonFloat(tok, 32); err == nil {
+			b.AppendFloat32(float32(v))
+		}
+		return err
+	case *StringBuilder:
+		v, ok := tok.(string)
+		if !ok {
+			break
+		}
+		b.Append(v)
+		return nil
+	case *LargeStringBuilder:
+		v, ok := tok.(string)
+		if !ok {
+			break
+		}
+		b.Append(v)
+		return nil
+	case *StringViewBuilder:
+		v, ok := tok.(string)
+		if !ok {
+			break
+		}
+		b.Append(v)
+		return nil
+	case *BinaryBuilder:
+		var v []byte
+		if v, err = jsonBytes(tok); err == nil {
+			b.Append(v)
+		}
+		return err
+	case *LargeBinaryBuilder:
+		var v []byte
+		if v, err = jsonBytes(tok); err == nil {
+			b.Append(v)
+		}
+		return err
+	case *BinaryViewBuilder:
+		if b.dtype.ID() == arrow.STRING_VIEW {
+			v, ok := tok.(string)
+			if !ok {
+				break
+			}
+			b.AppendString(v)
+			return nil
+		}
+		var v []byte
+		if v, err = jsonBytes(tok); err == nil {
+			b.Append(v)
+		}
+		return err
+	case *FixedSizeBinaryBuilder:
+		var v []byte
+		if v, err = jsonBytes(tok); err != nil {
+			return err
+		}
+		if len(v) != b.dtype.ByteWidth {
+			return fmt.Errorf("invalid value length %d, want %d", len(v), b.dtype.ByteWidth)
+		}
+		b.Append(v)
+		return nil
+	case *Decimal256Builder:
+		var s string
+		switch v := tok.(type) {
+		case json.Number:
+			s = string(v)
+		case float64:
+			s = strconv.FormatFloat(v, 'f', -1, 64)
+		case string:
+			s = v
+		default:
+			return fmt.Errorf("can not append JSON value %v to %T", tok, b)
+		}
+		v, err := decimal256.FromString(s, b.dtype.Precision, b.dtype.Scale)
+		if err != nil {
+			return err
+		}
+		b.Append(v)
+		return nil
+	case *Date32Builder:
+		var v int64
+		if v, err = jsonDate(tok, arrow.DATE32); err == nil {
+			b.Append(arrow.Date32(v))
+		}
+		return err
+	case *Date64Builder:
+		var v int64
+		if v, err = jsonDate(tok, arrow.DATE64); err == nil {
+			b.Append(arrow.Date64(v))
+		}
+		return err
+	case *Time32Builder:
+		var v int64
+		if v, err = jsonTime(tok, b.dtype.Unit); err == nil {
+			b.Append(arrow.Time32(v))
+		}
+		return err
+	case *Time64Builder:
+		var v int64
+		if v, err = jsonTime(tok, b.dtype.Unit); err == nil {
+			b.Append(arrow.Time64(v))
+		}
+		return err
+	case *TimestampBuilder:
+		var v int64
+		if v, err = jsonTimestamp(tok, b.dtype); err == nil {
+			b.Append(arrow.Timestamp(v))
+		}
+		return err
+	case *DurationBuilder:
+		var v int64
+		if v, err = jsonInt(tok, 64); err == nil {
+			b.Append(arrow.Duration(v))
+		}
+		return err
+	case *MonthIntervalBuilder:
+		var v int64
+		if v, err = jsonInt(tok, 32); err == nil {
+			b.Append(arrow.MonthInterval(v))
+		}
+		return err
+	}
+	return fmt.Errorf("can not append JSON value %v to %T", tok, b)
+}
+
+func jsonNumber(tok json.Token) (string, error) {
+	switch v := tok.(type) {
+	case json.Number:
+		return string(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	}
+	return "", fmt.Errorf("expected JSON number, got %v", tok)
+}
+
+func jsonInt(tok json.Token, bits int) (int64, error) {
+	s, err := jsonNumber(tok)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(s, 10, bits)
+}
+
+func jsonUint(tok json.Token, bits int) (uint64, error) {
+	s, err := jsonNumber(tok)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(s, 10, bits)
+}
+
+// jsonFloat decodes a floating point number. The strings "NaN", "Inf" and
+// "-Inf" are accepted for the values JSON numbers can not represent.
+func jsonFloat(tok json.Token, bits int) (float64, error) {
+	switch tok {
+	case "NaN":
+		return math.NaN(), nil
+	case "Inf", "+Inf":
+		return math.Inf(1), nil
+	case "-Inf":
+		return math.Inf(-1), nil
+	}
+	s, err := jsonNumber(tok)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseFloat(s, bits)
+}
+
+func jsonBytes(tok json.Token) ([]byte, error) {
+	s, ok := tok.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected base64-encoded JSON string, got %v", tok)
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
+
+// jsonDate decodes a date, either as a number (days for date32,
+// milliseconds for date64) since the UNIX epoch or as a "YYYY-MM-DD" string.
+func jsonDate(tok json.Token, id arrow.Type) (int64, error) {
+	s, ok := tok.(string)
+	if !ok {
+		return jsonInt(tok, 64)
+	}
+	t, err := time.Parse(jsonDateLayout, s)
+	if err != nil {
+		return 0, err
+	}
+	if id == arrow.DATE32 {
+		return t.Unix() / 86400, nil
+	}
+	return t.Unix() * 1000, nil
+}
+
+// jsonTime decodes a time of day, either as a number of ticks of unit
+// since midnight or as a "HH:MM:SS[.fffffffff]" string.
+func jsonTime(tok json.Token, unit arrow.TimeUnit) (int64, error) {
+	s, ok := tok.(string)
+	if !ok {
+		return jsonInt(tok, 64)
+	}
+	t, err := time.Parse(jsonTimeLayout, s)
+	if err != nil {
+		return 0, err
+	}
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return int64(t.Sub(midnight) / unit.Multiplier()), nil
+}
+
+// jsonTimestamp decodes a timestamp, either as a number of ticks since the
+// UNIX epoch or as a string. Strings without a time zone are interpreted
+// in the time zone of dt, or UTC.
+func jsonTimestamp(tok json.Token, dt *arrow.TimestampType) (int64, error) {
+	s, ok := tok.(string)
+	if !ok {
+		return jsonInt(tok, 64)
+	}
+	loc, err := dt.Location()
+	if err != nil {
+		return 0, err
+	}
+	for _, layout := range jsonTimestampLayouts {
+		t, err := time.ParseInLocation(layout, s, loc)
+		if err == nil {
+			return int64(arrow.TimestampFromTime(t, dt.Unit)), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid timestamp %q", s)
+}
@@ -0,0 +1,216 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilderUnmarshalJSON(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		dt   arrow.DataType
+		json string
+		want string
+	}{
+		{"int8", arrow.PrimitiveTypes.Int8, `[1, null, -3]`, `[1 (null) -3]`},
+		{"uint64", arrow.PrimitiveTypes.Uint64, `[18446744073709551615]`, `[18446744073709551615]`},
+		{"float64", arrow.PrimitiveTypes.Float64, `[1.5, "NaN", "-Inf", 2]`, `[1.5 NaN -Inf 2]`},
+		{"bool", arrow.FixedWidthTypes.Boolean, `[true, false, null]`, `[true false (null)]`},
+		{"string", arrow.BinaryTypes.String, `["a", null, "b"]`, `["a" (null) "b"]`},
+		{"binary", arrow.BinaryTypes.Binary, `["aGk=", null]`, `["hi" (null)]`},
+		{"date32", arrow.PrimitiveTypes.Date32, `["1970-01-03", 5]`, `[2 5]`},
+		{"time32", arrow.FixedWidthTypes.Time32ms, `["00:00:01.5", 10]`, `[1500 10]`},
+		{"timestamp", &arrow.TimestampType{Unit: arrow.Second}, `["1970-01-01T00:01:00Z", "1970-01-01 00:00:02", 3]`, `[60 2 3]`},
+		{"duration", arrow.FixedWidthTypes.Duration_s, `[60, null, -1]`, `[60 (null) -1]`},
+		{"month_interval", arrow.FixedWidthTypes.MonthInterval, `[12, null]`, `[12 (null)]`},
+		{"day_time_interval", arrow.FixedWidthTypes.DayTimeInterval, `[{"days": 1, "milliseconds": 2}, {}, null]`, `[1d2ms 0d0ms (null)]`},
+		{"month_day_nano_interval", arrow.FixedWidthTypes.MonthDayNanoInterval, `[{"months": 1, "nanoseconds": -5}, null]`, `[1M0d-5ns (null)]`},
+		{"list", arrow.ListOf(arrow.PrimitiveTypes.Int32), `[[1, 2], null, []]`, `[[1 2] (null) []]`},
+		{"fixed_size_list", arrow.FixedSizeListOf(2, arrow.PrimitiveTypes.Int32), `[[1, 2], null, [null, 4]]`, `[[1 2] (null) [(null) 4]]`},
+		{
+			"struct",
+			arrow.StructOf(
+				arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+				arrow.Field{Name: "y", Type: arrow.ListOf(arrow.BinaryTypes.String), Nullable: true},
+			),
+			`[{"x": 1, "y": ["a"]}, {"y": [], "extra": {"z": [1]}}, null]`,
+			`[{x: 1, y: ["a"]} {x: (null), y: []} (null)]`,
+		},
+		{
+			"map",
+			arrow.MapOf(arrow.PrimitiveTypes.Int32, arrow.BinaryTypes.String),
+			`[{"1": "a", "2": null}, null]`,
+			`[{1: "a", 2: (null)} (null)]`,
+		},
+		{
+			"union",
+			arrow.DenseUnionOf([]arrow.Field{
+				{Name: "i", Type: arrow.PrimitiveTypes.Int32},
+				{Name: "s", Type: arrow.BinaryTypes.String},
+			}, []int8{2, 5}),
+			`[[2, 1], [5, "a"], null]`,
+			`[1 "a" (null)]`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			b := array.NewBuilder(mem, tc.dt)
+			defer b.Release()
+
+			if err := json.Unmarshal([]byte(tc.json), b); err != nil {
+				t.Fatal(err)
+			}
+			arr := b.NewArray()
+			defer arr.Release()
+
+			assert.Equal(t, tc.want, formatValues(arr))
+		})
+	}
+}
+
+func formatValues(arr array.Interface) string {
+	vs := make([]string, arr.Len())
+	for i := range vs {
+		vs[i] = array.FormatValue(arr, i)
+	}
+	return "[" + strings.Join(vs, " ") + "]"
+}
+
+func TestBuilderUnmarshalJSONErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	for _, tc := range []struct {
+		dt   arrow.DataType
+		json string
+		want string
+	}{
+		{arrow.PrimitiveTypes.Int8, `[1000]`, `arrow/array: element 0: strconv.ParseInt: parsing "1000": value out of range`},
+		{arrow.PrimitiveTypes.Int32, `["1"]`, `arrow/array: element 0: expected JSON number, got 1`},
+		{arrow.PrimitiveTypes.Int32, `{"a": 1}`, `arrow/array: expected JSON array, got {`},
+		{arrow.PrimitiveTypes.Int32, `[1] 2`, `arrow/array: unexpected data after JSON value`},
+		{arrow.BinaryTypes.String, `[[1]]`, `arrow/array: element 0: can not append JSON array to *array.StringBuilder`},
+		{arrow.FixedSizeListOf(2, arrow.PrimitiveTypes.Int32), `[[1, 2, 3]]`, `arrow/array: element 0: invalid list length 3, expected 2`},
+		{arrow.StructOf(arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Int32}), `[{"x": 1, "x": 2}]`, `arrow/array: element 0: duplicate field "x"`},
+	} {
+		b := array.NewBuilder(mem, tc.dt)
+		err := b.UnmarshalJSON([]byte(tc.json))
+		if assert.Error(t, err, tc.json) {
+			assert.Equal(t, tc.want, err.Error())
+		}
+		b.Release()
+	}
+}
+
+func TestRecordBuilderUnmarshalJSON(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "tags", Type: arrow.ListOf(arrow.BinaryTypes.String), Nullable: true},
+	}, nil)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	if err := json.Unmarshal([]byte(`[{"id": 1, "tags": ["a", "b"]}, {"id": 2}]`), b); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.UnmarshalJSON([]byte(`{"tags": [], "id": 3}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader([]byte(`{"id": 4} {"id": 5, "tags": null}`)))
+	dec.UseNumber()
+	for dec.More() {
+		if err := b.AppendJSON(dec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	assert.Equal(t, "[1 2 3 4 5]", rec.Column(0).(*array.Int64).String())
+	assert.Equal(t, `[["a" "b"] (null) [] (null) (null)]`, rec.Column(1).(*array.List).String())
+
+	err := b.UnmarshalJSON([]byte(`[{"id": "x"}]`))
+	assert.EqualError(t, err, `arrow/array: field "id": expected JSON number, got x`)
+}
+
+func TestAppendJSON(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewFloat64Builder(mem)
+	defer b.Release()
+
+	dec := json.NewDecoder(bytes.NewReader([]byte(`1.5 null 3`)))
+	dec.UseNumber()
+	for dec.More() {
+		if err := array.AppendJSON(b, dec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assert.EqualError(t, array.AppendJSON(b, json.NewDecoder(bytes.NewReader([]byte(`true`)))),
+		"arrow/array: expected JSON number, got true")
+
+	arr := b.NewFloat64Array()
+	defer arr.Release()
+	assert.Equal(t, "[1.5 (null) 3]", arr.String())
+}
+
+func FuzzUnmarshalJSON(f *testing.F) {
+	for i, raw := range []string{
+		`[true, null, false]`, `[1, -2, null]`, `[1, 2, null, 3]`, `["a", null, "bc"]`,
+		`["aGk=", null]`, `["a", "longer than twelve bytes", null]`, `["YWJj", null]`,
+		`[[1, 2], null, []]`, `[["a"], [null]]`, `[[1, 2], null]`, `[[{"key": "a", "value": 1}], null]`,
+		`[{"a": 1, "b": "x"}, null, {"a": null}]`, `[[2, 1], [5, "s"], null]`, `[[0, 1], [1, "s"]]`,
+		`["a", "b", null, "a"]`, `["a", "a", null, "b"]`,
+	} {
+		f.Add(byte(i), []byte(raw))
+	}
+
+	f.Fuzz(func(t *testing.T, typ byte, raw []byte) {
+		mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+		defer mem.AssertSize(t, 0)
+
+		dt := fuzzTypes[int(typ)%len(fuzzTypes)]
+		b := array.NewBuilder(mem, dt)
+		defer b.Release()
+		if err := b.UnmarshalJSON(raw); err != nil {
+			return
+		}
+		arr := b.NewArray()
+		defer arr.Release()
+		if err := array.ValidateFull(arr); err != nil {
+			t.Fatalf("invalid %s array built from %q: %v", dt.Name(), raw, err)
+		}
+	})
+}
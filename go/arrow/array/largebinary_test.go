@@ -0,0 +1,68 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLargeBinary(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewLargeBinaryBuilder(mem, arrow.BinaryTypes.LargeBinary)
+	defer b.Release()
+
+	values := [][]byte{
+		[]byte("AAA"),
+		nil,
+		[]byte("BBBB"),
+	}
+	valid := []bool{true, false, true}
+	b.AppendValues(values, valid)
+	b.Append([]byte("C"))
+
+	arr := b.NewLargeBinaryArray()
+	defer arr.Release()
+
+	assert.Equal(t, arrow.LARGE_BINARY, arr.DataType().ID())
+	assert.Equal(t, 4, arr.Len())
+	assert.Equal(t, 1, arr.NullN())
+	assert.Equal(t, []byte("AAA"), arr.Value(0))
+	assert.Equal(t, []byte{}, arr.Value(1))
+	assert.Equal(t, "BBBB", arr.ValueString(2))
+	assert.Equal(t, []int64{0, 3, 3, 7, 8}, arr.ValueOffsets())
+	assert.Equal(t, 3, arr.ValueOffset(1))
+	assert.Equal(t, 4, arr.ValueLen(2))
+	assert.Equal(t, []byte("AAABBBBC"), arr.ValueBytes())
+
+	slice := array.NewSlice(arr, 2, 4).(*array.LargeBinary)
+	defer slice.Release()
+
+	assert.Equal(t, 2, slice.Len())
+	assert.Equal(t, []byte("C"), slice.Value(1))
+	if got, want := slice.ValueOffsets(), []int64{3, 7, 8}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got=%v, want=%v", got, want)
+	}
+	assert.Equal(t, []byte("BBBBC"), slice.ValueBytes())
+}
@@ -0,0 +1,197 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// A LargeBinaryBuilder is used to build a LargeBinary array using the Append methods.
+type LargeBinaryBuilder struct {
+	builder
+
+	dtype   arrow.BinaryDataType
+	offsets *int64BufferBuilder
+	values  *byteBufferBuilder
+}
+
+func NewLargeBinaryBuilder(mem memory.Allocator, dtype arrow.BinaryDataType) *LargeBinaryBuilder {
+	b := &LargeBinaryBuilder{
+		builder: builder{refCount: 1, mem: mem},
+		dtype:   dtype,
+		offsets: newInt64BufferBuilder(mem),
+		values:  newByteBufferBuilder(mem),
+	}
+	return b
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+// Release may be called simultaneously from multiple goroutines.
+func (b *LargeBinaryBuilder) Release() {
+	debug.Assert(atomic.LoadInt64(&b.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&b.refCount, -1) == 0 {
+		if b.nullBitmap != nil {
+			b.nullBitmap.Release()
+			b.nullBitmap = nil
+		}
+		if b.offsets != nil {
+			b.offsets.Release()
+			b.offsets = nil
+		}
+		if b.values != nil {
+			b.values.Release()
+			b.values = nil
+		}
+	}
+}
+
+func (b *LargeBinaryBuilder) Append(v []byte) {
+	b.Reserve(1)
+	b.appendNextOffset()
+	b.values.Append(v)
+	b.UnsafeAppendBoolToBitmap(true)
+}
+
+func (b *LargeBinaryBuilder) AppendString(v string) {
+	b.Append([]byte(v))
+}
+
+func (b *LargeBinaryBuilder) AppendNull() {
+	b.Reserve(1)
+	b.appendNextOffset()
+	b.UnsafeAppendBoolToBitmap(false)
+}
+
+// AppendNulls appends n null values to the builder.
+func (b *LargeBinaryBuilder) AppendNulls(n int) {
+	b.Reserve(n)
+	for i := 0; i < n; i++ {
+		b.appendNextOffset()
+	}
+	b.unsafeAppendNulls(n)
+}
+
+// AppendValues will append the values in the v slice. The valid slice determines which values
+// in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
+// all values in v are appended and considered valid.
+func (b *LargeBinaryBuilder) AppendValues(v [][]byte, valid []bool) {
+	if len(v) != len(valid) && len(valid) != 0 {
+		panic("len(v) != len(valid) && len(valid) != 0")
+	}
+
+	b.Reserve(len(v))
+	for _, vv := range v {
+		b.appendNextOffset()
+		b.values.Append(vv)
+	}
+
+	b.builder.unsafeAppendBoolsToBitmap(valid, len(v))
+}
+
+// AppendStringValues will append the values in the v slice. The valid slice determines which values
+// in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
+// all values in v are appended and considered valid.
+func (b *LargeBinaryBuilder) AppendStringValues(v []string, valid []bool) {
+	if len(v) != len(valid) && len(valid) != 0 {
+		panic("len(v) != len(valid) && len(valid) != 0")
+	}
+
+	b.Reserve(len(v))
+	for _, vv := range v {
+		b.appendNextOffset()
+		b.values.Append([]byte(vv))
+	}
+
+	b.builder.unsafeAppendBoolsToBitmap(valid, len(v))
+}
+
+func (b *LargeBinaryBuilder) Value(i int) []byte {
+	offsets := b.offsets.Values()
+	start := int(offsets[i])
+	var end int
+	if i == (b.length - 1) {
+		end = b.values.Len()
+	} else {
+		end = int(offsets[i+1])
+	}
+	return b.values.Bytes()[start:end]
+}
+
+func (b *LargeBinaryBuilder) init(capacity int) {
+	b.builder.init(capacity)
+	b.offsets.resize((capacity + 1) * arrow.Int64SizeBytes)
+}
+
+// Reserve ensures there is enough space for appending n elements
+// by checking the capacity and calling Resize if necessary.
+func (b *LargeBinaryBuilder) Reserve(n int) {
+	b.builder.reserve(n, b.Resize)
+}
+
+// Resize adjusts the space allocated by b to n elements. If n is greater than b.Cap(),
+// additional memory will be allocated. If n is smaller, the allocated memory may reduced.
+func (b *LargeBinaryBuilder) Resize(n int) {
+	b.offsets.resize((n + 1) * arrow.Int64SizeBytes)
+	b.builder.resize(n, b.init)
+}
+
+// NewArray creates a LargeBinary array from the memory buffers used by the builder and resets the LargeBinaryBuilder
+// so it can be used to build a new array.
+func (b *LargeBinaryBuilder) NewArray() Interface {
+	return b.NewLargeBinaryArray()
+}
+
+// NewLargeBinaryArray creates a LargeBinary array from the memory buffers used by the builder and resets the LargeBinaryBuilder
+// so it can be used to build a new array.
+func (b *LargeBinaryBuilder) NewLargeBinaryArray() (a *LargeBinary) {
+	data := b.newData()
+	a = NewLargeBinaryData(data)
+	data.Release()
+	return
+}
+
+func (b *LargeBinaryBuilder) newData() (data *Data) {
+	b.appendNextOffset()
+	offsets, values := b.offsets.Finish(), b.values.Finish()
+	data = NewData(b.dtype, b.length, []*memory.Buffer{b.nullBitmap, offsets, values}, nil, b.nulls, 0)
+	if offsets != nil {
+		offsets.Release()
+	}
+
+	if values != nil {
+		values.Release()
+	}
+
+	b.builder.reset()
+
+	return
+}
+
+func (b *LargeBinaryBuilder) appendNextOffset() {
+	numBytes := b.values.Len()
+	b.offsets.AppendValue(int64(numBytes))
+}
+
+var (
+	_ Builder = (*LargeBinaryBuilder)(nil)
+)
@@ -0,0 +1,72 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLargeStringArray(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	var (
+		want    = []string{"hello", "世界", "", "bye"}
+		valids  = []bool{true, true, false, true}
+		offsets = []int64{0, 5, 11, 11, 14}
+	)
+
+	sb := array.NewBuilder(mem, arrow.BinaryTypes.LargeString).(*array.LargeStringBuilder)
+	defer sb.Release()
+
+	sb.Retain()
+	sb.Release()
+
+	sb.AppendValues(want[:2], nil)
+	sb.AppendNull()
+	sb.Append(want[3])
+
+	if got, want := sb.Len(), len(want); got != want {
+		t.Fatalf("invalid len: got=%d, want=%d", got, want)
+	}
+	if got, want := sb.NullN(), 1; got != want {
+		t.Fatalf("invalid nulls: got=%d, want=%d", got, want)
+	}
+
+	arr := sb.NewLargeStringArray()
+	defer arr.Release()
+
+	assert.Equal(t, arrow.LARGE_STRING, arr.DataType().ID())
+	for i := range want {
+		assert.Equal(t, valids[i], arr.IsValid(i))
+		assert.Equal(t, want[i], arr.Value(i))
+	}
+	assert.Equal(t, `["hello" "世界" (null) "bye"]`, arr.String())
+
+	data := arr.Data()
+	assert.Equal(t, offsets, arrow.Int64Traits.CastFromBytes(data.Buffers()[1].Bytes())[:len(offsets)])
+
+	slice := array.NewSlice(arr, 1, 4).(*array.LargeString)
+	defer slice.Release()
+	assert.Equal(t, "世界", slice.Value(0))
+	assert.Equal(t, "bye", slice.Value(2))
+}
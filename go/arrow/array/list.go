@@ -42,6 +42,8 @@ func NewListData(data *Data) *List {
 
 func (a *List) ListValues() Interface { return a.values }
 
+func (a *List) String() string { return formatString(a) }
+
 func (a *List) setData(data *Data) {
 	a.array.setData(data)
 	vals := data.buffers[1]
@@ -56,9 +58,17 @@ func (a *List) Len() int { return a.array.Len() }
 
 func (a *List) Offsets() []int32 { return a.offsets }
 
+// Release decreases the reference count by 1.
+// Release may be called simultaneously from multiple goroutines.
+// When the reference count goes to zero, the memory is freed.
 func (a *List) Release() {
-	a.array.Release()
-	a.values.Release()
+	debug.Assert(atomic.LoadInt64(&a.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.data.Release()
+		a.values.Release()
+		a.data, a.nullBitmapBytes, a.values = nil, nil, nil
+	}
 }
 
 type ListBuilder struct {
@@ -75,7 +85,7 @@ func NewListBuilder(mem memory.Allocator, etype arrow.DataType) *ListBuilder {
 	return &ListBuilder{
 		builder: builder{refCount: 1, mem: mem},
 		etype:   etype,
-		values:  newBuilder(mem, etype),
+		values:  NewBuilder(mem, etype),
 		offsets: NewInt32Builder(mem),
 	}
 }
@@ -90,10 +100,9 @@ func (b *ListBuilder) Release() {
 			b.nullBitmap.Release()
 			b.nullBitmap = nil
 		}
+		b.values.Release()
+		b.offsets.Release()
 	}
-
-	b.values.Release()
-	b.offsets.Release()
 }
 
 func (b *ListBuilder) appendNextOffset() {
@@ -112,6 +121,15 @@ func (b *ListBuilder) AppendNull() {
 	b.appendNextOffset()
 }
 
+// AppendNulls appends n null lists to the builder.
+func (b *ListBuilder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+	for i := 0; i < n; i++ {
+		b.appendNextOffset()
+	}
+}
+
 func (b *ListBuilder) AppendValues(offsets []int32, valid []bool) {
 	b.Reserve(len(valid))
 	b.offsets.AppendValues(offsets, nil)
@@ -154,7 +172,7 @@ func (b *ListBuilder) Resize(n int) {
 		b.init(n)
 	} else {
 		b.builder.resize(n, b.builder.init)
-		b.offsets.resize(n+1, b.offsets.init)
+		b.offsets.Resize(n + 1)
 	}
 }
 
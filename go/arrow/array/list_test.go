@@ -146,3 +146,32 @@ func TestListArrayBulkAppend(t *testing.T) {
 		t.Fatalf("got=%v, want=%v", got, want)
 	}
 }
+
+func TestListArrayGrow(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	lb := array.NewListBuilder(pool, arrow.PrimitiveTypes.Int32)
+	defer lb.Release()
+	vb := lb.ValueBuilder().(*array.Int32Builder)
+
+	// appending past the initial capacity grows the offsets.
+	const n = 100
+	for i := 0; i < n; i++ {
+		lb.Append(true)
+		vb.Append(int32(i))
+	}
+
+	arr := lb.NewArray().(*array.List)
+	defer arr.Release()
+
+	if got, want := arr.Len(), n; got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+	offsets := arr.Offsets()
+	for i := 0; i <= n; i++ {
+		if got, want := offsets[i], int32(i); got != want {
+			t.Fatalf("got[%d]=%d, want[%d]=%d", i, got, i, want)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// Map represents an immutable sequence of key/item maps.
+// A Map is stored as a List of Structs holding the keys and the items of
+// the maps.
+type Map struct {
+	*List
+	keys, items Interface
+}
+
+// NewMapData returns a new Map array value, from data.
+func NewMapData(data *Data) *Map {
+	a := &Map{List: &List{}}
+	a.refCount = 1
+	a.setData(data)
+	return a
+}
+
+// KeysSorted returns whether the keys are sorted within each map.
+func (a *Map) KeysSorted() bool { return a.DataType().(*arrow.MapType).KeysSorted }
+
+// Keys returns the keys of all the maps, which the offsets delimit.
+func (a *Map) Keys() Interface { return a.keys }
+
+// Items returns the items of all the maps, which the offsets delimit.
+func (a *Map) Items() Interface { return a.items }
+
+func (a *Map) String() string { return formatString(a) }
+
+func (a *Map) setData(data *Data) {
+	a.List.setData(data)
+	entries := a.values.(*Struct)
+	a.keys = entries.Field(0)
+	a.items = entries.Field(1)
+}
+
+// MapBuilder builds Map arrays.
+//
+// Keys and items are appended with the KeyBuilder and ItemBuilder of the
+// MapBuilder, after a call to Append; each key must have a corresponding
+// item, possibly null.
+type MapBuilder struct {
+	*ListBuilder
+
+	etype       *arrow.MapType
+	keyBuilder  Builder
+	itemBuilder Builder
+}
+
+// NewMapBuilder returns a builder, using the provided memory allocator.
+// The created map builder will create maps with keys of type keytype and
+// items of type itemtype.
+func NewMapBuilder(mem memory.Allocator, keytype, itemtype arrow.DataType, keysSorted bool) *MapBuilder {
+	etype := arrow.MapOf(keytype, itemtype)
+	etype.KeysSorted = keysSorted
+
+	lb := NewListBuilder(mem, etype.ValueType())
+	sb := lb.ValueBuilder().(*StructBuilder)
+	return &MapBuilder{
+		ListBuilder: lb,
+		etype:       etype,
+		keyBuilder:  sb.FieldBuilder(0),
+		itemBuilder: sb.FieldBuilder(1),
+	}
+}
+
+// adjustStructBuilderLen appends a valid struct entry for each key
+// appended since the last call.
+// Only the validity bitmap of the struct builder is grown: resizing the
+// StructBuilder itself would reinitialize its (already filled) field builders.
+func (b *MapBuilder) adjustStructBuilderLen() {
+	sb := b.ListBuilder.ValueBuilder().(*StructBuilder)
+	n := b.keyBuilder.Len() - sb.Len()
+	if n <= 0 {
+		return
+	}
+	sb.builder.reserve(n, func(n int) {
+		if n < minBuilderCapacity {
+			n = minBuilderCapacity
+		}
+		sb.builder.resize(n, sb.builder.init)
+	})
+	for i := 0; i < n; i++ {
+		sb.unsafeAppendBoolToBitmap(true)
+	}
+}
+
+// Append adds a new map to the array. If v is false, the map is null.
+func (b *MapBuilder) Append(v bool) {
+	b.adjustStructBuilderLen()
+	b.ListBuilder.Append(v)
+}
+
+// AppendNull adds a new null map to the array.
+func (b *MapBuilder) AppendNull() {
+	b.Append(false)
+}
+
+// AppendNulls adds n null maps to the array.
+func (b *MapBuilder) AppendNulls(n int) {
+	b.adjustStructBuilderLen()
+	b.ListBuilder.AppendNulls(n)
+}
+
+// KeyBuilder returns the builder of the keys of the maps.
+func (b *MapBuilder) KeyBuilder() Builder { return b.keyBuilder }
+
+// ItemBuilder returns the builder of the items of the maps.
+func (b *MapBuilder) ItemBuilder() Builder { return b.itemBuilder }
+
+// NewArray creates a Map array from the memory buffers used by the builder and resets the MapBuilder
+// so it can be used to build a new array.
+func (b *MapBuilder) NewArray() Interface {
+	return b.NewMapArray()
+}
+
+// NewMapArray creates a Map array from the memory buffers used by the builder and resets the MapBuilder
+// so it can be used to build a new array.
+func (b *MapBuilder) NewMapArray() (a *Map) {
+	data := b.newData()
+	a = NewMapData(data)
+	data.Release()
+	return
+}
+
+func (b *MapBuilder) newData() (data *Data) {
+	b.adjustStructBuilderLen()
+	if b.offsets.Len() != b.length+1 {
+		b.appendNextOffset()
+	}
+
+	list := b.ListBuilder.newData()
+	defer list.Release()
+
+	return NewData(b.etype, list.length, list.buffers, list.childData, list.nulls, list.offset)
+}
+
+var (
+	_ Interface = (*Map)(nil)
+	_ Builder   = (*MapBuilder)(nil)
+)
@@ -0,0 +1,89 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapArray(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	bld := array.NewMapBuilder(mem, arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int16, true)
+	defer bld.Release()
+
+	kb := bld.KeyBuilder().(*array.StringBuilder)
+	ib := bld.ItemBuilder().(*array.Int16Builder)
+
+	bld.Append(true)
+	kb.AppendValues([]string{"a", "b"}, nil)
+	ib.AppendValues([]int16{1, 2}, []bool{true, false})
+	bld.AppendNull()
+	bld.Append(true)
+	bld.Append(true)
+	kb.Append("c")
+	ib.Append(3)
+
+	assert.Equal(t, 4, bld.Len())
+	assert.Equal(t, 1, bld.NullN())
+
+	arr := bld.NewMapArray()
+	defer arr.Release()
+
+	dtype := arr.DataType().(*arrow.MapType)
+	assert.Equal(t, arrow.MAP, dtype.ID())
+	assert.Equal(t, arrow.BinaryTypes.String, dtype.KeyType())
+	assert.Equal(t, arrow.PrimitiveTypes.Int16, dtype.ItemType())
+	assert.True(t, arr.KeysSorted())
+
+	assert.Equal(t, 4, arr.Len())
+	assert.Equal(t, 1, arr.NullN())
+	assert.True(t, arr.IsNull(1))
+	assert.Equal(t, []int32{0, 2, 2, 2, 3}, arr.Offsets())
+
+	keys := arr.Keys().(*array.String)
+	items := arr.Items().(*array.Int16)
+	assert.Equal(t, 3, keys.Len())
+	assert.Equal(t, []string{"a", "b", "c"}, []string{keys.Value(0), keys.Value(1), keys.Value(2)})
+	assert.Equal(t, int16(1), items.Value(0))
+	assert.True(t, items.IsNull(1))
+	assert.Equal(t, int16(3), items.Value(2))
+
+	// round-trip through the generic constructors.
+	other := array.MakeFromData(arr.Data())
+	defer other.Release()
+	assert.Equal(t, keys.Len(), other.(*array.Map).Keys().Len())
+
+	b := array.NewBuilder(mem, arrow.MapOf(arrow.PrimitiveTypes.Int32, arrow.BinaryTypes.String))
+	defer b.Release()
+	assert.IsType(t, (*array.MapBuilder)(nil), b)
+
+	b.(*array.MapBuilder).Append(true)
+	b.(*array.MapBuilder).KeyBuilder().(*array.Int32Builder).Append(1)
+	b.(*array.MapBuilder).ItemBuilder().(*array.StringBuilder).Append("x")
+	empty := b.NewArray().(*array.Map)
+	defer empty.Release()
+
+	assert.False(t, empty.KeysSorted())
+	assert.Equal(t, []int32{0, 1}, empty.Offsets())
+}
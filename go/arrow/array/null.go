@@ -86,6 +86,11 @@ func (b *NullBuilder) AppendNull() {
 	b.builder.nulls++
 }
 
+func (b *NullBuilder) AppendNulls(n int) {
+	b.builder.length += n
+	b.builder.nulls += n
+}
+
 func (*NullBuilder) Reserve(size int) {}
 func (*NullBuilder) Resize(size int)  {}
 
@@ -38,6 +38,18 @@ func NewInt64Data(data *Data) *Int64 {
 	return a
 }
 
+// NewInt64DataFromSlice returns a new Int64 array whose values are
+// vs, without copying them. valid, if not empty, holds the validity of each
+// value and must have the same length as vs.
+//
+// The array borrows vs: vs must not be modified while the array, or any
+// array sharing its values, is in use. See memory.NewBufferBorrowed.
+func NewInt64DataFromSlice(vs []int64, valid []bool) *Int64 {
+	data := newDataFromSlice(arrow.PrimitiveTypes.Int64, arrow.Int64Traits.CastToBytes(vs), len(vs), valid)
+	defer data.Release()
+	return NewInt64Data(data)
+}
+
 func (a *Int64) Value(i int) int64    { return a.values[i] }
 func (a *Int64) Int64Values() []int64 { return a.values }
 
@@ -83,6 +95,18 @@ func NewUint64Data(data *Data) *Uint64 {
 	return a
 }
 
+// NewUint64DataFromSlice returns a new Uint64 array whose values are
+// vs, without copying them. valid, if not empty, holds the validity of each
+// value and must have the same length as vs.
+//
+// The array borrows vs: vs must not be modified while the array, or any
+// array sharing its values, is in use. See memory.NewBufferBorrowed.
+func NewUint64DataFromSlice(vs []uint64, valid []bool) *Uint64 {
+	data := newDataFromSlice(arrow.PrimitiveTypes.Uint64, arrow.Uint64Traits.CastToBytes(vs), len(vs), valid)
+	defer data.Release()
+	return NewUint64Data(data)
+}
+
 func (a *Uint64) Value(i int) uint64     { return a.values[i] }
 func (a *Uint64) Uint64Values() []uint64 { return a.values }
 
@@ -128,6 +152,18 @@ func NewFloat64Data(data *Data) *Float64 {
 	return a
 }
 
+// NewFloat64DataFromSlice returns a new Float64 array whose values are
+// vs, without copying them. valid, if not empty, holds the validity of each
+// value and must have the same length as vs.
+//
+// The array borrows vs: vs must not be modified while the array, or any
+// array sharing its values, is in use. See memory.NewBufferBorrowed.
+func NewFloat64DataFromSlice(vs []float64, valid []bool) *Float64 {
+	data := newDataFromSlice(arrow.PrimitiveTypes.Float64, arrow.Float64Traits.CastToBytes(vs), len(vs), valid)
+	defer data.Release()
+	return NewFloat64Data(data)
+}
+
 func (a *Float64) Value(i int) float64      { return a.values[i] }
 func (a *Float64) Float64Values() []float64 { return a.values }
 
@@ -173,6 +209,18 @@ func NewInt32Data(data *Data) *Int32 {
 	return a
 }
 
+// NewInt32DataFromSlice returns a new Int32 array whose values are
+// vs, without copying them. valid, if not empty, holds the validity of each
+// value and must have the same length as vs.
+//
+// The array borrows vs: vs must not be modified while the array, or any
+// array sharing its values, is in use. See memory.NewBufferBorrowed.
+func NewInt32DataFromSlice(vs []int32, valid []bool) *Int32 {
+	data := newDataFromSlice(arrow.PrimitiveTypes.Int32, arrow.Int32Traits.CastToBytes(vs), len(vs), valid)
+	defer data.Release()
+	return NewInt32Data(data)
+}
+
 func (a *Int32) Value(i int) int32    { return a.values[i] }
 func (a *Int32) Int32Values() []int32 { return a.values }
 
@@ -218,6 +266,18 @@ func NewUint32Data(data *Data) *Uint32 {
 	return a
 }
 
+// NewUint32DataFromSlice returns a new Uint32 array whose values are
+// vs, without copying them. valid, if not empty, holds the validity of each
+// value and must have the same length as vs.
+//
+// The array borrows vs: vs must not be modified while the array, or any
+// array sharing its values, is in use. See memory.NewBufferBorrowed.
+func NewUint32DataFromSlice(vs []uint32, valid []bool) *Uint32 {
+	data := newDataFromSlice(arrow.PrimitiveTypes.Uint32, arrow.Uint32Traits.CastToBytes(vs), len(vs), valid)
+	defer data.Release()
+	return NewUint32Data(data)
+}
+
 func (a *Uint32) Value(i int) uint32     { return a.values[i] }
 func (a *Uint32) Uint32Values() []uint32 { return a.values }
 
@@ -263,6 +323,18 @@ func NewFloat32Data(data *Data) *Float32 {
 	return a
 }
 
+// NewFloat32DataFromSlice returns a new Float32 array whose values are
+// vs, without copying them. valid, if not empty, holds the validity of each
+// value and must have the same length as vs.
+//
+// The array borrows vs: vs must not be modified while the array, or any
+// array sharing its values, is in use. See memory.NewBufferBorrowed.
+func NewFloat32DataFromSlice(vs []float32, valid []bool) *Float32 {
+	data := newDataFromSlice(arrow.PrimitiveTypes.Float32, arrow.Float32Traits.CastToBytes(vs), len(vs), valid)
+	defer data.Release()
+	return NewFloat32Data(data)
+}
+
 func (a *Float32) Value(i int) float32      { return a.values[i] }
 func (a *Float32) Float32Values() []float32 { return a.values }
 
@@ -308,6 +380,18 @@ func NewInt16Data(data *Data) *Int16 {
 	return a
 }
 
+// NewInt16DataFromSlice returns a new Int16 array whose values are
+// vs, without copying them. valid, if not empty, holds the validity of each
+// value and must have the same length as vs.
+//
+// The array borrows vs: vs must not be modified while the array, or any
+// array sharing its values, is in use. See memory.NewBufferBorrowed.
+func NewInt16DataFromSlice(vs []int16, valid []bool) *Int16 {
+	data := newDataFromSlice(arrow.PrimitiveTypes.Int16, arrow.Int16Traits.CastToBytes(vs), len(vs), valid)
+	defer data.Release()
+	return NewInt16Data(data)
+}
+
 func (a *Int16) Value(i int) int16    { return a.values[i] }
 func (a *Int16) Int16Values() []int16 { return a.values }
 
@@ -353,6 +437,18 @@ func NewUint16Data(data *Data) *Uint16 {
 	return a
 }
 
+// NewUint16DataFromSlice returns a new Uint16 array whose values are
+// vs, without copying them. valid, if not empty, holds the validity of each
+// value and must have the same length as vs.
+//
+// The array borrows vs: vs must not be modified while the array, or any
+// array sharing its values, is in use. See memory.NewBufferBorrowed.
+func NewUint16DataFromSlice(vs []uint16, valid []bool) *Uint16 {
+	data := newDataFromSlice(arrow.PrimitiveTypes.Uint16, arrow.Uint16Traits.CastToBytes(vs), len(vs), valid)
+	defer data.Release()
+	return NewUint16Data(data)
+}
+
 func (a *Uint16) Value(i int) uint16     { return a.values[i] }
 func (a *Uint16) Uint16Values() []uint16 { return a.values }
 
@@ -398,6 +494,18 @@ func NewInt8Data(data *Data) *Int8 {
 	return a
 }
 
+// NewInt8DataFromSlice returns a new Int8 array whose values are
+// vs, without copying them. valid, if not empty, holds the validity of each
+// value and must have the same length as vs.
+//
+// The array borrows vs: vs must not be modified while the array, or any
+// array sharing its values, is in use. See memory.NewBufferBorrowed.
+func NewInt8DataFromSlice(vs []int8, valid []bool) *Int8 {
+	data := newDataFromSlice(arrow.PrimitiveTypes.Int8, arrow.Int8Traits.CastToBytes(vs), len(vs), valid)
+	defer data.Release()
+	return NewInt8Data(data)
+}
+
 func (a *Int8) Value(i int) int8   { return a.values[i] }
 func (a *Int8) Int8Values() []int8 { return a.values }
 
@@ -443,6 +551,18 @@ func NewUint8Data(data *Data) *Uint8 {
 	return a
 }
 
+// NewUint8DataFromSlice returns a new Uint8 array whose values are
+// vs, without copying them. valid, if not empty, holds the validity of each
+// value and must have the same length as vs.
+//
+// The array borrows vs: vs must not be modified while the array, or any
+// array sharing its values, is in use. See memory.NewBufferBorrowed.
+func NewUint8DataFromSlice(vs []uint8, valid []bool) *Uint8 {
+	data := newDataFromSlice(arrow.PrimitiveTypes.Uint8, arrow.Uint8Traits.CastToBytes(vs), len(vs), valid)
+	defer data.Release()
+	return NewUint8Data(data)
+}
+
 func (a *Uint8) Value(i int) uint8    { return a.values[i] }
 func (a *Uint8) Uint8Values() []uint8 { return a.values }
 
@@ -488,6 +608,18 @@ func NewTimestampData(data *Data) *Timestamp {
 	return a
 }
 
+// NewTimestampDataFromSlice returns a new Timestamp array of type dtype
+// whose values are vs, without copying them. valid, if not empty, holds the
+// validity of each value and must have the same length as vs.
+//
+// The array borrows vs: vs must not be modified while the array, or any
+// array sharing its values, is in use. See memory.NewBufferBorrowed.
+func NewTimestampDataFromSlice(dtype *arrow.TimestampType, vs []arrow.Timestamp, valid []bool) *Timestamp {
+	data := newDataFromSlice(dtype, arrow.TimestampTraits.CastToBytes(vs), len(vs), valid)
+	defer data.Release()
+	return NewTimestampData(data)
+}
+
 func (a *Timestamp) Value(i int) arrow.Timestamp        { return a.values[i] }
 func (a *Timestamp) TimestampValues() []arrow.Timestamp { return a.values }
 
@@ -533,6 +665,18 @@ func NewTime32Data(data *Data) *Time32 {
 	return a
 }
 
+// NewTime32DataFromSlice returns a new Time32 array of type dtype
+// whose values are vs, without copying them. valid, if not empty, holds the
+// validity of each value and must have the same length as vs.
+//
+// The array borrows vs: vs must not be modified while the array, or any
+// array sharing its values, is in use. See memory.NewBufferBorrowed.
+func NewTime32DataFromSlice(dtype *arrow.Time32Type, vs []arrow.Time32, valid []bool) *Time32 {
+	data := newDataFromSlice(dtype, arrow.Time32Traits.CastToBytes(vs), len(vs), valid)
+	defer data.Release()
+	return NewTime32Data(data)
+}
+
 func (a *Time32) Value(i int) arrow.Time32     { return a.values[i] }
 func (a *Time32) Time32Values() []arrow.Time32 { return a.values }
 
@@ -578,6 +722,18 @@ func NewTime64Data(data *Data) *Time64 {
 	return a
 }
 
+// NewTime64DataFromSlice returns a new Time64 array of type dtype
+// whose values are vs, without copying them. valid, if not empty, holds the
+// validity of each value and must have the same length as vs.
+//
+// The array borrows vs: vs must not be modified while the array, or any
+// array sharing its values, is in use. See memory.NewBufferBorrowed.
+func NewTime64DataFromSlice(dtype *arrow.Time64Type, vs []arrow.Time64, valid []bool) *Time64 {
+	data := newDataFromSlice(dtype, arrow.Time64Traits.CastToBytes(vs), len(vs), valid)
+	defer data.Release()
+	return NewTime64Data(data)
+}
+
 func (a *Time64) Value(i int) arrow.Time64     { return a.values[i] }
 func (a *Time64) Time64Values() []arrow.Time64 { return a.values }
 
@@ -610,6 +766,63 @@ func (a *Time64) setData(data *Data) {
 	}
 }
 
+// A type which represents an immutable sequence of arrow.Duration values.
+type Duration struct {
+	array
+	values []arrow.Duration
+}
+
+func NewDurationData(data *Data) *Duration {
+	a := &Duration{}
+	a.refCount = 1
+	a.setData(data)
+	return a
+}
+
+// NewDurationDataFromSlice returns a new Duration array of type dtype
+// whose values are vs, without copying them. valid, if not empty, holds the
+// validity of each value and must have the same length as vs.
+//
+// The array borrows vs: vs must not be modified while the array, or any
+// array sharing its values, is in use. See memory.NewBufferBorrowed.
+func NewDurationDataFromSlice(dtype *arrow.DurationType, vs []arrow.Duration, valid []bool) *Duration {
+	data := newDataFromSlice(dtype, arrow.DurationTraits.CastToBytes(vs), len(vs), valid)
+	defer data.Release()
+	return NewDurationData(data)
+}
+
+func (a *Duration) Value(i int) arrow.Duration       { return a.values[i] }
+func (a *Duration) DurationValues() []arrow.Duration { return a.values }
+
+func (a *Duration) String() string {
+	o := new(strings.Builder)
+	o.WriteString("[")
+	for i, v := range a.values {
+		if i > 0 {
+			fmt.Fprintf(o, " ")
+		}
+		switch {
+		case a.IsNull(i):
+			o.WriteString("(null)")
+		default:
+			fmt.Fprintf(o, "%v", v)
+		}
+	}
+	o.WriteString("]")
+	return o.String()
+}
+
+func (a *Duration) setData(data *Data) {
+	a.array.setData(data)
+	vals := data.buffers[1]
+	if vals != nil {
+		a.values = arrow.DurationTraits.CastFromBytes(vals.Bytes())
+		beg := a.array.data.offset
+		end := beg + a.array.data.length
+		a.values = a.values[beg:end]
+	}
+}
+
 // A type which represents an immutable sequence of arrow.Date32 values.
 type Date32 struct {
 	array
@@ -623,6 +836,18 @@ func NewDate32Data(data *Data) *Date32 {
 	return a
 }
 
+// NewDate32DataFromSlice returns a new Date32 array whose values are
+// vs, without copying them. valid, if not empty, holds the validity of each
+// value and must have the same length as vs.
+//
+// The array borrows vs: vs must not be modified while the array, or any
+// array sharing its values, is in use. See memory.NewBufferBorrowed.
+func NewDate32DataFromSlice(vs []arrow.Date32, valid []bool) *Date32 {
+	data := newDataFromSlice(arrow.PrimitiveTypes.Date32, arrow.Date32Traits.CastToBytes(vs), len(vs), valid)
+	defer data.Release()
+	return NewDate32Data(data)
+}
+
 func (a *Date32) Value(i int) arrow.Date32     { return a.values[i] }
 func (a *Date32) Date32Values() []arrow.Date32 { return a.values }
 
@@ -668,6 +893,18 @@ func NewDate64Data(data *Data) *Date64 {
 	return a
 }
 
+// NewDate64DataFromSlice returns a new Date64 array whose values are
+// vs, without copying them. valid, if not empty, holds the validity of each
+// value and must have the same length as vs.
+//
+// The array borrows vs: vs must not be modified while the array, or any
+// array sharing its values, is in use. See memory.NewBufferBorrowed.
+func NewDate64DataFromSlice(vs []arrow.Date64, valid []bool) *Date64 {
+	data := newDataFromSlice(arrow.PrimitiveTypes.Date64, arrow.Date64Traits.CastToBytes(vs), len(vs), valid)
+	defer data.Release()
+	return NewDate64Data(data)
+}
+
 func (a *Date64) Value(i int) arrow.Date64     { return a.values[i] }
 func (a *Date64) Date64Values() []arrow.Date64 { return a.values }
 
@@ -40,6 +40,30 @@ func TestNewFloat64Data(t *testing.T) {
 	assert.Equal(t, exp, fa.Float64Values(), "unexpected Float64Values()")
 }
 
+func TestNewDataFromSlice(t *testing.T) {
+	vs := []int64{1, 2, 3, 4}
+	arr := array.NewInt64DataFromSlice(vs, []bool{true, false, true, true})
+	defer arr.Release()
+
+	assert.Equal(t, 4, arr.Len())
+	assert.Equal(t, 1, arr.NullN())
+	assert.Equal(t, "[1 (null) 3 4]", arr.String())
+	// the values are not copied.
+	assert.Equal(t, &vs[0], &arr.Int64Values()[0])
+
+	slice := array.NewSlice(arr, 2, 4)
+	defer slice.Release()
+	assert.Equal(t, "[3 4]", slice.(*array.Int64).String())
+
+	ts := array.NewTimestampDataFromSlice(&arrow.TimestampType{Unit: arrow.Second}, []arrow.Timestamp{1, 2}, nil)
+	defer ts.Release()
+	assert.Equal(t, arrow.Second, ts.DataType().(*arrow.TimestampType).Unit)
+	assert.Zero(t, ts.NullN())
+	assert.Equal(t, []arrow.Timestamp{1, 2}, ts.TimestampValues())
+
+	assert.Panics(t, func() { array.NewFloat64DataFromSlice([]float64{1}, []bool{true, false}) })
+}
+
 func TestFloat64SliceData(t *testing.T) {
 	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
 	defer pool.AssertSize(t, 0)
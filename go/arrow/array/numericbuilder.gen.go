@@ -66,6 +66,12 @@ func (b *Int64Builder) AppendNull() {
 	b.UnsafeAppendBoolToBitmap(false)
 }
 
+// AppendNulls appends n null values to the builder.
+func (b *Int64Builder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+}
+
 func (b *Int64Builder) UnsafeAppend(v int64) {
 	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	b.rawData[b.length] = v
@@ -161,6 +167,9 @@ func (b *Int64Builder) newData() (data *Data) {
 	return
 }
 
+// UnmarshalJSON appends the elements of data, a JSON array, to the builder.
+func (b *Int64Builder) UnmarshalJSON(data []byte) error { return unmarshalJSON(b, data) }
+
 type Uint64Builder struct {
 	builder
 
@@ -200,6 +209,12 @@ func (b *Uint64Builder) AppendNull() {
 	b.UnsafeAppendBoolToBitmap(false)
 }
 
+// AppendNulls appends n null values to the builder.
+func (b *Uint64Builder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+}
+
 func (b *Uint64Builder) UnsafeAppend(v uint64) {
 	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	b.rawData[b.length] = v
@@ -295,6 +310,9 @@ func (b *Uint64Builder) newData() (data *Data) {
 	return
 }
 
+// UnmarshalJSON appends the elements of data, a JSON array, to the builder.
+func (b *Uint64Builder) UnmarshalJSON(data []byte) error { return unmarshalJSON(b, data) }
+
 type Float64Builder struct {
 	builder
 
@@ -334,6 +352,12 @@ func (b *Float64Builder) AppendNull() {
 	b.UnsafeAppendBoolToBitmap(false)
 }
 
+// AppendNulls appends n null values to the builder.
+func (b *Float64Builder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+}
+
 func (b *Float64Builder) UnsafeAppend(v float64) {
 	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	b.rawData[b.length] = v
@@ -429,6 +453,9 @@ func (b *Float64Builder) newData() (data *Data) {
 	return
 }
 
+// UnmarshalJSON appends the elements of data, a JSON array, to the builder.
+func (b *Float64Builder) UnmarshalJSON(data []byte) error { return unmarshalJSON(b, data) }
+
 type Int32Builder struct {
 	builder
 
@@ -468,6 +495,12 @@ func (b *Int32Builder) AppendNull() {
 	b.UnsafeAppendBoolToBitmap(false)
 }
 
+// AppendNulls appends n null values to the builder.
+func (b *Int32Builder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+}
+
 func (b *Int32Builder) UnsafeAppend(v int32) {
 	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	b.rawData[b.length] = v
@@ -563,6 +596,9 @@ func (b *Int32Builder) newData() (data *Data) {
 	return
 }
 
+// UnmarshalJSON appends the elements of data, a JSON array, to the builder.
+func (b *Int32Builder) UnmarshalJSON(data []byte) error { return unmarshalJSON(b, data) }
+
 type Uint32Builder struct {
 	builder
 
@@ -602,6 +638,12 @@ func (b *Uint32Builder) AppendNull() {
 	b.UnsafeAppendBoolToBitmap(false)
 }
 
+// AppendNulls appends n null values to the builder.
+func (b *Uint32Builder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+}
+
 func (b *Uint32Builder) UnsafeAppend(v uint32) {
 	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	b.rawData[b.length] = v
@@ -697,6 +739,9 @@ func (b *Uint32Builder) newData() (data *Data) {
 	return
 }
 
+// UnmarshalJSON appends the elements of data, a JSON array, to the builder.
+func (b *Uint32Builder) UnmarshalJSON(data []byte) error { return unmarshalJSON(b, data) }
+
 type Float32Builder struct {
 	builder
 
@@ -736,6 +781,12 @@ func (b *Float32Builder) AppendNull() {
 	b.UnsafeAppendBoolToBitmap(false)
 }
 
+// AppendNulls appends n null values to the builder.
+func (b *Float32Builder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+}
+
 func (b *Float32Builder) UnsafeAppend(v float32) {
 	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	b.rawData[b.length] = v
@@ -831,6 +882,9 @@ func (b *Float32Builder) newData() (data *Data) {
 	return
 }
 
+// UnmarshalJSON appends the elements of data, a JSON array, to the builder.
+func (b *Float32Builder) UnmarshalJSON(data []byte) error { return unmarshalJSON(b, data) }
+
 type Int16Builder struct {
 	builder
 
@@ -870,6 +924,12 @@ func (b *Int16Builder) AppendNull() {
 	b.UnsafeAppendBoolToBitmap(false)
 }
 
+// AppendNulls appends n null values to the builder.
+func (b *Int16Builder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+}
+
 func (b *Int16Builder) UnsafeAppend(v int16) {
 	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	b.rawData[b.length] = v
@@ -965,6 +1025,9 @@ func (b *Int16Builder) newData() (data *Data) {
 	return
 }
 
+// UnmarshalJSON appends the elements of data, a JSON array, to the builder.
+func (b *Int16Builder) UnmarshalJSON(data []byte) error { return unmarshalJSON(b, data) }
+
 type Uint16Builder struct {
 	builder
 
@@ -1004,6 +1067,12 @@ func (b *Uint16Builder) AppendNull() {
 	b.UnsafeAppendBoolToBitmap(false)
 }
 
+// AppendNulls appends n null values to the builder.
+func (b *Uint16Builder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+}
+
 func (b *Uint16Builder) UnsafeAppend(v uint16) {
 	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	b.rawData[b.length] = v
@@ -1099,6 +1168,9 @@ func (b *Uint16Builder) newData() (data *Data) {
 	return
 }
 
+// UnmarshalJSON appends the elements of data, a JSON array, to the builder.
+func (b *Uint16Builder) UnmarshalJSON(data []byte) error { return unmarshalJSON(b, data) }
+
 type Int8Builder struct {
 	builder
 
@@ -1138,6 +1210,12 @@ func (b *Int8Builder) AppendNull() {
 	b.UnsafeAppendBoolToBitmap(false)
 }
 
+// AppendNulls appends n null values to the builder.
+func (b *Int8Builder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+}
+
 func (b *Int8Builder) UnsafeAppend(v int8) {
 	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	b.rawData[b.length] = v
@@ -1233,6 +1311,9 @@ func (b *Int8Builder) newData() (data *Data) {
 	return
 }
 
+// UnmarshalJSON appends the elements of data, a JSON array, to the builder.
+func (b *Int8Builder) UnmarshalJSON(data []byte) error { return unmarshalJSON(b, data) }
+
 type Uint8Builder struct {
 	builder
 
@@ -1272,6 +1353,12 @@ func (b *Uint8Builder) AppendNull() {
 	b.UnsafeAppendBoolToBitmap(false)
 }
 
+// AppendNulls appends n null values to the builder.
+func (b *Uint8Builder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+}
+
 func (b *Uint8Builder) UnsafeAppend(v uint8) {
 	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	b.rawData[b.length] = v
@@ -1367,6 +1454,9 @@ func (b *Uint8Builder) newData() (data *Data) {
 	return
 }
 
+// UnmarshalJSON appends the elements of data, a JSON array, to the builder.
+func (b *Uint8Builder) UnmarshalJSON(data []byte) error { return unmarshalJSON(b, data) }
+
 type TimestampBuilder struct {
 	builder
 
@@ -1407,6 +1497,12 @@ func (b *TimestampBuilder) AppendNull() {
 	b.UnsafeAppendBoolToBitmap(false)
 }
 
+// AppendNulls appends n null values to the builder.
+func (b *TimestampBuilder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+}
+
 func (b *TimestampBuilder) UnsafeAppend(v arrow.Timestamp) {
 	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	b.rawData[b.length] = v
@@ -1502,6 +1598,9 @@ func (b *TimestampBuilder) newData() (data *Data) {
 	return
 }
 
+// UnmarshalJSON appends the elements of data, a JSON array, to the builder.
+func (b *TimestampBuilder) UnmarshalJSON(data []byte) error { return unmarshalJSON(b, data) }
+
 type Time32Builder struct {
 	builder
 
@@ -1542,6 +1641,12 @@ func (b *Time32Builder) AppendNull() {
 	b.UnsafeAppendBoolToBitmap(false)
 }
 
+// AppendNulls appends n null values to the builder.
+func (b *Time32Builder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+}
+
 func (b *Time32Builder) UnsafeAppend(v arrow.Time32) {
 	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	b.rawData[b.length] = v
@@ -1637,6 +1742,9 @@ func (b *Time32Builder) newData() (data *Data) {
 	return
 }
 
+// UnmarshalJSON appends the elements of data, a JSON array, to the builder.
+func (b *Time32Builder) UnmarshalJSON(data []byte) error { return unmarshalJSON(b, data) }
+
 type Time64Builder struct {
 	builder
 
@@ -1677,6 +1785,12 @@ func (b *Time64Builder) AppendNull() {
 	b.UnsafeAppendBoolToBitmap(false)
 }
 
+// AppendNulls appends n null values to the builder.
+func (b *Time64Builder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+}
+
 func (b *Time64Builder) UnsafeAppend(v arrow.Time64) {
 	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	b.rawData[b.length] = v
@@ -1772,6 +1886,153 @@ func (b *Time64Builder) newData() (data *Data) {
 	return
 }
 
+// UnmarshalJSON appends the elements of data, a JSON array, to the builder.
+func (b *Time64Builder) UnmarshalJSON(data []byte) error { return unmarshalJSON(b, data) }
+
+type DurationBuilder struct {
+	builder
+
+	dtype   *arrow.DurationType
+	data    *memory.Buffer
+	rawData []arrow.Duration
+}
+
+func NewDurationBuilder(mem memory.Allocator, dtype *arrow.DurationType) *DurationBuilder {
+	return &DurationBuilder{builder: builder{refCount: 1, mem: mem}, dtype: dtype}
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+func (b *DurationBuilder) Release() {
+	debug.Assert(atomic.LoadInt64(&b.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&b.refCount, -1) == 0 {
+		if b.nullBitmap != nil {
+			b.nullBitmap.Release()
+			b.nullBitmap = nil
+		}
+		if b.data != nil {
+			b.data.Release()
+			b.data = nil
+			b.rawData = nil
+		}
+	}
+}
+
+func (b *DurationBuilder) Append(v arrow.Duration) {
+	b.Reserve(1)
+	b.UnsafeAppend(v)
+}
+
+func (b *DurationBuilder) AppendNull() {
+	b.Reserve(1)
+	b.UnsafeAppendBoolToBitmap(false)
+}
+
+// AppendNulls appends n null values to the builder.
+func (b *DurationBuilder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+}
+
+func (b *DurationBuilder) UnsafeAppend(v arrow.Duration) {
+	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
+	b.rawData[b.length] = v
+	b.length++
+}
+
+func (b *DurationBuilder) UnsafeAppendBoolToBitmap(isValid bool) {
+	if isValid {
+		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
+	} else {
+		b.nulls++
+	}
+	b.length++
+}
+
+// AppendValues will append the values in the v slice. The valid slice determines which values
+// in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
+// all values in v are appended and considered valid.
+func (b *DurationBuilder) AppendValues(v []arrow.Duration, valid []bool) {
+	if len(v) != len(valid) && len(valid) != 0 {
+		panic("len(v) != len(valid) && len(valid) != 0")
+	}
+
+	b.Reserve(len(v))
+	if len(v) > 0 {
+		arrow.DurationTraits.Copy(b.rawData[b.length:], v)
+	}
+	b.builder.unsafeAppendBoolsToBitmap(valid, len(v))
+}
+
+func (b *DurationBuilder) init(capacity int) {
+	b.builder.init(capacity)
+
+	b.data = memory.NewResizableBuffer(b.mem)
+	bytesN := arrow.DurationTraits.BytesRequired(capacity)
+	b.data.Resize(bytesN)
+	b.rawData = arrow.DurationTraits.CastFromBytes(b.data.Bytes())
+}
+
+// Reserve ensures there is enough space for appending n elements
+// by checking the capacity and calling Resize if necessary.
+func (b *DurationBuilder) Reserve(n int) {
+	b.builder.reserve(n, b.Resize)
+}
+
+// Resize adjusts the space allocated by b to n elements. If n is greater than b.Cap(),
+// additional memory will be allocated. If n is smaller, the allocated memory may reduced.
+func (b *DurationBuilder) Resize(n int) {
+	nBuilder := n
+	if n < minBuilderCapacity {
+		n = minBuilderCapacity
+	}
+
+	if b.capacity == 0 {
+		b.init(n)
+	} else {
+		b.builder.resize(nBuilder, b.init)
+		b.data.Resize(arrow.DurationTraits.BytesRequired(n))
+		b.rawData = arrow.DurationTraits.CastFromBytes(b.data.Bytes())
+	}
+}
+
+// NewArray creates a Duration array from the memory buffers used by the builder and resets the DurationBuilder
+// so it can be used to build a new array.
+func (b *DurationBuilder) NewArray() Interface {
+	return b.NewDurationArray()
+}
+
+// NewDurationArray creates a Duration array from the memory buffers used by the builder and resets the DurationBuilder
+// so it can be used to build a new array.
+func (b *DurationBuilder) NewDurationArray() (a *Duration) {
+	data := b.newData()
+	a = NewDurationData(data)
+	data.Release()
+	return
+}
+
+func (b *DurationBuilder) newData() (data *Data) {
+	bytesRequired := arrow.DurationTraits.BytesRequired(b.length)
+	if bytesRequired > 0 && bytesRequired < b.data.Len() {
+		// trim buffers
+		b.data.Resize(bytesRequired)
+	}
+	data = NewData(b.dtype, b.length, []*memory.Buffer{b.nullBitmap, b.data}, nil, b.nulls, 0)
+	b.reset()
+
+	if b.data != nil {
+		b.data.Release()
+		b.data = nil
+		b.rawData = nil
+	}
+
+	return
+}
+
+// UnmarshalJSON appends the elements of data, a JSON array, to the builder.
+func (b *DurationBuilder) UnmarshalJSON(data []byte) error { return unmarshalJSON(b, data) }
+
 type Date32Builder struct {
 	builder
 
@@ -1811,6 +2072,12 @@ func (b *Date32Builder) AppendNull() {
 	b.UnsafeAppendBoolToBitmap(false)
 }
 
+// AppendNulls appends n null values to the builder.
+func (b *Date32Builder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+}
+
 func (b *Date32Builder) UnsafeAppend(v arrow.Date32) {
 	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	b.rawData[b.length] = v
@@ -1906,6 +2173,9 @@ func (b *Date32Builder) newData() (data *Data) {
 	return
 }
 
+// UnmarshalJSON appends the elements of data, a JSON array, to the builder.
+func (b *Date32Builder) UnmarshalJSON(data []byte) error { return unmarshalJSON(b, data) }
+
 type Date64Builder struct {
 	builder
 
@@ -1945,6 +2215,12 @@ func (b *Date64Builder) AppendNull() {
 	b.UnsafeAppendBoolToBitmap(false)
 }
 
+// AppendNulls appends n null values to the builder.
+func (b *Date64Builder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+}
+
 func (b *Date64Builder) UnsafeAppend(v arrow.Date64) {
 	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	b.rawData[b.length] = v
@@ -2040,6 +2316,9 @@ func (b *Date64Builder) newData() (data *Data) {
 	return
 }
 
+// UnmarshalJSON appends the elements of data, a JSON array, to the builder.
+func (b *Date64Builder) UnmarshalJSON(data []byte) error { return unmarshalJSON(b, data) }
+
 var (
 	_ Builder = (*Int64Builder)(nil)
 	_ Builder = (*Uint64Builder)(nil)
@@ -2054,6 +2333,7 @@ var (
 	_ Builder = (*TimestampBuilder)(nil)
 	_ Builder = (*Time32Builder)(nil)
 	_ Builder = (*Time64Builder)(nil)
+	_ Builder = (*DurationBuilder)(nil)
 	_ Builder = (*Date32Builder)(nil)
 	_ Builder = (*Date64Builder)(nil)
 )
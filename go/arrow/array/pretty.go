@@ -0,0 +1,294 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/apache/arrow/go/arrow"
+)
+
+// FormatValue returns a human readable representation of the i-th element
+// of arr. Nulls are rendered as (null), strings and binary values are
+// quoted, lists as [v1 v2 ...], structs as {name: v, ...} and maps as
+// {key: item, ...}.
+func FormatValue(arr Interface, i int) string {
+	o := new(strings.Builder)
+	formatValue(o, arr, i)
+	return o.String()
+}
+
+func formatValue(o *strings.Builder, arr Interface, i int) {
+	if arr.IsNull(i) {
+		o.WriteString("(null)")
+		return
+	}
+
+	switch a := arr.(type) {
+	case *Boolean:
+		o.WriteString(strconv.FormatBool(a.Value(i)))
+	case *String:
+		o.WriteString(strconv.Quote(a.Value(i)))
+	case *LargeString:
+		o.WriteString(strconv.Quote(a.Value(i)))
+	case *StringView:
+		o.WriteString(strconv.Quote(a.Value(i)))
+	case *Binary:
+		fmt.Fprintf(o, "%q", a.Value(i))
+	case *LargeBinary:
+		fmt.Fprintf(o, "%q", a.Value(i))
+	case *BinaryView:
+		fmt.Fprintf(o, "%q", a.Value(i))
+	case *FixedSizeBinary:
+		fmt.Fprintf(o, "%q", a.Value(i))
+	case *Decimal256:
+		o.WriteString(a.ValueStr(i))
+	case *Map:
+		j := a.data.offset + i
+		o.WriteString("{")
+		for k := int(a.offsets[j]); k < int(a.offsets[j+1]); k++ {
+			if k > int(a.offsets[j]) {
+				o.WriteString(", ")
+			}
+			formatValue(o, a.keys, k)
+			o.WriteString(": ")
+			formatValue(o, a.items, k)
+		}
+		o.WriteString("}")
+	case *List:
+		j := a.data.offset + i
+		formatRange(o, a.values, int(a.offsets[j]), int(a.offsets[j+1]))
+	case *LargeList:
+		j := a.data.offset + i
+		formatRange(o, a.values, int(a.offsets[j]), int(a.offsets[j+1]))
+	case *FixedSizeList:
+		beg, end := a.ValueOffsets(i)
+		formatRange(o, a.values, int(beg), int(end))
+	case *Struct:
+		o.WriteString("{")
+		for k, f := range a.fields {
+			if k > 0 {
+				o.WriteString(", ")
+			}
+			o.WriteString(a.DataType().(*arrow.StructType).Field(k).Name)
+			o.WriteString(": ")
+			formatValue(o, f, i)
+		}
+		o.WriteString("}")
+	case *Union:
+		formatValue(o, a.Field(a.ChildID(i)), a.ValueOffset(i))
+	case *RunEndEncoded:
+		formatValue(o, a.values, a.PhysicalIndex(i))
+	case *Dictionary:
+		formatValue(o, a.dict, a.GetValueIndex(i))
+	case ExtensionArray:
+		formatValue(o, a.Storage(), i)
+	default:
+		formatNumeric(o, arr, i)
+	}
+}
+
+func formatNumeric(o *strings.Builder, arr Interface, i int) {
+	var v interface{}
+	switch a := arr.(type) {
+	case *Int8:
+		v = a.Value(i)
+	case *Int16:
+		v = a.Value(i)
+	case *Int32:
+		v = a.Value(i)
+	case *Int64:
+		v = a.Value(i)
+	case *Uint8:
+		v = a.Value(i)
+	case *Uint16:
+		v = a.Value(i)
+	case *Uint32:
+		v = a.Value(i)
+	case *Uint64:
+		v = a.Value(i)
+	case *Float32:
+		v = a.Value(i)
+	case *Float64:
+		v = a.Value(i)
+	case *Float16:
+		v = a.Value(i)
+	case *Date32:
+		v = a.Value(i)
+	case *Date64:
+		v = a.Value(i)
+	case *Time32:
+		v = a.Value(i)
+	case *Time64:
+		v = a.Value(i)
+	case *Timestamp:
+		v = a.Value(i)
+	case *Duration:
+		v = a.Value(i)
+	case *MonthInterval:
+		v = a.Value(i)
+	case *DayTimeInterval:
+		v = a.Value(i)
+	case *MonthDayNanoInterval:
+		v = a.Value(i)
+	default:
+		v = "<" + arr.DataType().Name() + ">"
+	}
+	fmt.Fprintf(o, "%v", v)
+}
+
+// formatRange writes the elements of arr in [beg, end) as a list.
+func formatRange(o *strings.Builder, arr Interface, beg, end int) {
+	o.WriteString("[")
+	for k := beg; k < end; k++ {
+		if k > beg {
+			o.WriteString(" ")
+		}
+		formatValue(o, arr, k)
+	}
+	o.WriteString("]")
+}
+
+// formatString returns the elements of arr as a list.
+func formatString(arr Interface) string {
+	o := new(strings.Builder)
+	formatRange(o, arr, 0, arr.Len())
+	return o.String()
+}
+
+// PrettyOption configures the rendering of records and tables.
+type PrettyOption func(*prettyConfig)
+
+type prettyConfig struct {
+	maxRows  int
+	maxWidth int
+}
+
+// WithMaxRows limits the rendering to the first n rows.
+// A value of 0, the default, renders all the rows.
+func WithMaxRows(n int) PrettyOption {
+	return func(cfg *prettyConfig) { cfg.maxRows = n }
+}
+
+// WithMaxWidth truncates the cells wider than n characters.
+// A value of 0, the default, does not truncate cells.
+func WithMaxWidth(n int) PrettyOption {
+	return func(cfg *prettyConfig) { cfg.maxWidth = n }
+}
+
+// RecordToString renders rec as an ASCII table, with a header holding the
+// names of the columns and a line per row.
+func RecordToString(rec Record, opts ...PrettyOption) string {
+	cols := make([][]Interface, rec.NumCols())
+	for i, col := range rec.Columns() {
+		cols[i] = []Interface{col}
+	}
+	return renderTable(rec.Schema().Fields(), cols, rec.NumRows(), opts)
+}
+
+// TableToString renders tbl as an ASCII table, with a header holding the
+// names of the columns and a line per row.
+func TableToString(tbl Table, opts ...PrettyOption) string {
+	cols := make([][]Interface, tbl.NumCols())
+	for i := range cols {
+		cols[i] = tbl.Column(i).Data().Chunks()
+	}
+	return renderTable(tbl.Schema().Fields(), cols, tbl.NumRows(), opts)
+}
+
+func renderTable(fields []arrow.Field, cols [][]Interface, nrows int64, opts []PrettyOption) string {
+	var cfg prettyConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	n := nrows
+	if cfg.maxRows > 0 && int64(cfg.maxRows) < n {
+		n = int64(cfg.maxRows)
+	}
+
+	var (
+		header = make([]string, len(fields))
+		rows   = make([][]string, n)
+		widths = make([]int, len(fields))
+	)
+	for j, f := range fields {
+		header[j] = truncate(f.Name, cfg.maxWidth)
+		widths[j] = utf8.RuneCountInString(header[j])
+	}
+	for i := range rows {
+		rows[i] = make([]string, len(fields))
+	}
+	for j, chunks := range cols {
+		var i int64
+		for _, chunk := range chunks {
+			for k := 0; k < chunk.Len() && i < n; k++ {
+				cell := truncate(FormatValue(chunk, k), cfg.maxWidth)
+				if w := utf8.RuneCountInString(cell); w > widths[j] {
+					widths[j] = w
+				}
+				rows[i][j] = cell
+				i++
+			}
+		}
+	}
+
+	o := new(strings.Builder)
+	sep := func() {
+		o.WriteString("+")
+		for _, w := range widths {
+			o.WriteString(strings.Repeat("-", w+2))
+			o.WriteString("+")
+		}
+		o.WriteString("\n")
+	}
+	line := func(cells []string) {
+		o.WriteString("|")
+		for j, cell := range cells {
+			o.WriteString(" ")
+			o.WriteString(cell)
+			o.WriteString(strings.Repeat(" ", widths[j]-utf8.RuneCountInString(cell)))
+			o.WriteString(" |")
+		}
+		o.WriteString("\n")
+	}
+
+	sep()
+	line(header)
+	sep()
+	for _, row := range rows {
+		line(row)
+	}
+	sep()
+	if n < nrows {
+		fmt.Fprintf(o, "(%d more rows)\n", nrows-n)
+	}
+	return o.String()
+}
+
+// truncate shortens s to n characters, ending with an ellipsis.
+// If n is not positive, s is returned unchanged.
+func truncate(s string, n int) string {
+	if n <= 0 || utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	r := []rune(s)
+	return string(r[:n-1]) + "…"
+}
@@ -0,0 +1,151 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPrettyRecord(mem memory.Allocator) array.Record {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "tags", Type: arrow.ListOf(arrow.PrimitiveTypes.Int64), Nullable: true},
+		{Name: "point", Type: arrow.StructOf(
+			arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Float64},
+			arrow.Field{Name: "y", Type: arrow.PrimitiveTypes.Float64},
+		)},
+		{Name: "attrs", Type: arrow.MapOf(arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int16)},
+	}, nil)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2, 3}, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues([]string{"alice", "", "bob"}, []bool{true, false, true})
+
+	lb := b.Field(2).(*array.ListBuilder)
+	vb := lb.ValueBuilder().(*array.Int64Builder)
+	lb.Append(true)
+	vb.AppendValues([]int64{1, 2}, nil)
+	lb.Append(true)
+	lb.AppendNull()
+
+	sb := b.Field(3).(*array.StructBuilder)
+	xb := sb.FieldBuilder(0).(*array.Float64Builder)
+	yb := sb.FieldBuilder(1).(*array.Float64Builder)
+	for i := 0; i < 3; i++ {
+		sb.Append(true)
+		xb.Append(float64(i))
+		yb.Append(0.5)
+	}
+
+	mb := b.Field(4).(*array.MapBuilder)
+	kb := mb.KeyBuilder().(*array.StringBuilder)
+	ib := mb.ItemBuilder().(*array.Int16Builder)
+	mb.Append(true)
+	kb.AppendValues([]string{"a", "b"}, nil)
+	ib.AppendValues([]int16{1, 2}, []bool{true, false})
+	mb.Append(true)
+	mb.Append(true)
+	kb.Append("c")
+	ib.Append(3)
+
+	return b.NewRecord()
+}
+
+func TestFormatValue(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rec := newPrettyRecord(mem)
+	defer rec.Release()
+
+	assert.Equal(t, "1", array.FormatValue(rec.Column(0), 0))
+	assert.Equal(t, `"alice"`, array.FormatValue(rec.Column(1), 0))
+	assert.Equal(t, "(null)", array.FormatValue(rec.Column(1), 1))
+	assert.Equal(t, "[1 2]", array.FormatValue(rec.Column(2), 0))
+	assert.Equal(t, "[]", array.FormatValue(rec.Column(2), 1))
+	assert.Equal(t, "{x: 1, y: 0.5}", array.FormatValue(rec.Column(3), 1))
+	assert.Equal(t, `{"a": 1, "b": (null)}`, array.FormatValue(rec.Column(4), 0))
+
+	assert.Equal(t, "[[1 2] [] (null)]", rec.Column(2).(*array.List).String())
+	assert.Equal(t, "[{x: 0, y: 0.5} {x: 1, y: 0.5} {x: 2, y: 0.5}]", rec.Column(3).(*array.Struct).String())
+	assert.Equal(t, `[{"a": 1, "b": (null)} {} {"c": 3}]`, rec.Column(4).(*array.Map).String())
+
+	slice := array.NewSlice(rec.Column(2), 1, 3)
+	defer slice.Release()
+	assert.Equal(t, "[[] (null)]", slice.(*array.List).String())
+}
+
+func TestRecordToString(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rec := newPrettyRecord(mem)
+	defer rec.Release()
+
+	want := `+----+---------+--------+----------------+-----------------------+
+| id | name    | tags   | point          | attrs                 |
++----+---------+--------+----------------+-----------------------+
+| 1  | "alice" | [1 2]  | {x: 0, y: 0.5} | {"a": 1, "b": (null)} |
+| 2  | (null)  | []     | {x: 1, y: 0.5} | {}                    |
+| 3  | "bob"   | (null) | {x: 2, y: 0.5} | {"c": 3}              |
++----+---------+--------+----------------+-----------------------+
+`
+	assert.Equal(t, want, array.RecordToString(rec))
+
+	want = `+----+--------+-------+--------+--------+
+| id | name   | tags  | point  | attrs  |
++----+--------+-------+--------+--------+
+| 1  | "alic… | [1 2] | {x: 0… | {"a":… |
++----+--------+-------+--------+--------+
+(2 more rows)
+`
+	assert.Equal(t, want, array.RecordToString(rec, array.WithMaxRows(1), array.WithMaxWidth(6)))
+}
+
+func TestTableToString(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rec := newPrettyRecord(mem)
+	defer rec.Release()
+
+	sub := array.ProjectRecord(rec, 0, 1)
+	defer sub.Release()
+
+	tbl := array.NewTableFromRecords(sub.Schema(), []array.Record{sub, sub})
+	defer tbl.Release()
+
+	want := `+----+---------+
+| id | name    |
++----+---------+
+| 1  | "alice" |
+| 2  | (null)  |
+| 3  | "bob"   |
+| 1  | "alice" |
++----+---------+
+(2 more rows)
+`
+	assert.Equal(t, want, array.TableToString(tbl, array.WithMaxRows(4)))
+}
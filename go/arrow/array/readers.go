@@ -0,0 +1,165 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+)
+
+// multiReader holds the readers combined by ConcatReaders and
+// InterleaveReaders.
+type multiReader struct {
+	refCount int64
+	rs       []RecordReader
+	cur      Record
+	err      error
+}
+
+func newMultiReader(rs []RecordReader) (multiReader, error) {
+	if len(rs) == 0 {
+		return multiReader{}, errors.New("arrow/array: no readers to combine")
+	}
+	for _, r := range rs[1:] {
+		if !r.Schema().Equal(rs[0].Schema()) {
+			return multiReader{}, errors.New("arrow/array: mismatched reader schemas")
+		}
+	}
+	for _, r := range rs {
+		r.Retain()
+	}
+	return multiReader{refCount: 1, rs: append([]RecordReader(nil), rs...)}, nil
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (m *multiReader) Retain() {
+	atomic.AddInt64(&m.refCount, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the underlying readers are released.
+// Release may be called simultaneously from multiple goroutines.
+func (m *multiReader) Release() {
+	debug.Assert(atomic.LoadInt64(&m.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&m.refCount, -1) == 0 {
+		for _, r := range m.rs {
+			r.Release()
+		}
+		m.rs, m.cur = nil, nil
+	}
+}
+
+func (m *multiReader) Schema() *arrow.Schema { return m.rs[0].Schema() }
+
+// Record returns the current record, which is owned by the reader it was
+// read from.
+func (m *multiReader) Record() Record { return m.cur }
+
+// Err returns the error of the first underlying reader that failed, if any.
+func (m *multiReader) Err() error { return m.err }
+
+// next reads the next record of the reader i into m.cur. It returns false
+// if that reader is exhausted or failed.
+func (m *multiReader) next(i int) bool {
+	if m.rs[i].Next() {
+		m.cur = m.rs[i].Record()
+		return true
+	}
+	m.err = readerErr(m.rs[i])
+	return false
+}
+
+type concatReader struct {
+	multiReader
+	i int
+}
+
+// ConcatReaders returns a reader of the records of rs, one reader after the
+// other. All the readers must have the same schema.
+// The returned reader retains rs until it is released.
+func ConcatReaders(rs ...RecordReader) (RecordReader, error) {
+	m, err := newMultiReader(rs)
+	if err != nil {
+		return nil, err
+	}
+	return &concatReader{multiReader: m}, nil
+}
+
+func (r *concatReader) Next() bool {
+	r.cur = nil
+	for r.err == nil && r.i < len(r.rs) {
+		if r.next(r.i) {
+			return true
+		}
+		r.i++
+	}
+	return false
+}
+
+type interleaveReader struct {
+	multiReader
+	i    int
+	done []bool
+	left int
+}
+
+// InterleaveReaders returns a reader taking records of rs in turn, one
+// record from each reader that is not exhausted. All the readers must have
+// the same schema.
+// The returned reader retains rs until it is released.
+func InterleaveReaders(rs ...RecordReader) (RecordReader, error) {
+	m, err := newMultiReader(rs)
+	if err != nil {
+		return nil, err
+	}
+	return &interleaveReader{multiReader: m, done: make([]bool, len(rs)), left: len(rs)}, nil
+}
+
+func (r *interleaveReader) Next() bool {
+	r.cur = nil
+	for r.err == nil && r.left > 0 {
+		i := r.i
+		r.i = (r.i + 1) % len(r.rs)
+		if r.done[i] {
+			continue
+		}
+		if r.next(i) {
+			return true
+		}
+		r.done[i] = true
+		r.left--
+	}
+	return false
+}
+
+// readerErr returns the error of rr, if it has an Err method.
+func readerErr(rr RecordReader) error {
+	if r, ok := rr.(interface{ Err() error }); ok {
+		return r.Err()
+	}
+	return nil
+}
+
+var (
+	_ RecordReader = (*concatReader)(nil)
+	_ RecordReader = (*interleaveReader)(nil)
+)
@@ -0,0 +1,134 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// int64Reader returns a reader of one record of a single int64 column for
+// each of the slices vs.
+func int64Reader(t *testing.T, mem memory.Allocator, vs ...[]int64) array.RecordReader {
+	t.Helper()
+	schema := arrow.NewSchema([]arrow.Field{{Name: "i64", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	recs := make([]array.Record, len(vs))
+	for i, v := range vs {
+		b := array.NewInt64Builder(mem)
+		for _, x := range v {
+			b.Append(x)
+		}
+		col := b.NewArray()
+		b.Release()
+		recs[i] = array.NewRecord(schema, []array.Interface{col}, int64(len(v)))
+		col.Release()
+	}
+	rr, err := array.NewRecordReader(schema, recs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rec := range recs {
+		rec.Release()
+	}
+	return rr
+}
+
+// readInt64s returns the values of the records of rr, one slice per record.
+func readInt64s(t *testing.T, rr array.RecordReader) [][]int64 {
+	t.Helper()
+	var got [][]int64
+	for rr.Next() {
+		vs := rr.Record().Column(0).(*array.Int64).Int64Values()
+		got = append(got, append([]int64{}, vs...))
+	}
+	if r, ok := rr.(interface{ Err() error }); ok && r.Err() != nil {
+		t.Fatal(r.Err())
+	}
+	return got
+}
+
+func TestConcatReaders(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	a := int64Reader(t, mem, []int64{1, 2}, []int64{3})
+	defer a.Release()
+	b := int64Reader(t, mem)
+	defer b.Release()
+	c := int64Reader(t, mem, []int64{4}, []int64{}, []int64{5, 6})
+	defer c.Release()
+
+	rr, err := array.ConcatReaders(a, b, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.Release()
+
+	got := readInt64s(t, rr)
+	want := [][]int64{{1, 2}, {3}, {4}, {}, {5, 6}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid records: got=%v, want=%v", got, want)
+	}
+}
+
+func TestInterleaveReaders(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	a := int64Reader(t, mem, []int64{1}, []int64{2}, []int64{3})
+	defer a.Release()
+	b := int64Reader(t, mem)
+	defer b.Release()
+	c := int64Reader(t, mem, []int64{10}, []int64{20})
+	defer c.Release()
+
+	rr, err := array.InterleaveReaders(a, b, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.Release()
+
+	got := readInt64s(t, rr)
+	want := [][]int64{{1}, {10}, {2}, {20}, {3}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid records: got=%v, want=%v", got, want)
+	}
+}
+
+func TestCombineReadersErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	if _, err := array.ConcatReaders(); err == nil {
+		t.Fatalf("expected an error without readers")
+	}
+
+	a := int64Reader(t, mem, []int64{1})
+	defer a.Release()
+	other, err := array.NewRecordReader(arrow.NewSchema([]arrow.Field{{Name: "f64", Type: arrow.PrimitiveTypes.Float64}}, nil), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Release()
+	if _, err := array.InterleaveReaders(a, other); err == nil {
+		t.Fatalf("expected an error with mismatched schemas")
+	}
+}
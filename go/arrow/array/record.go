@@ -175,16 +175,22 @@ func NewRecord(schema *arrow.Schema, cols []Interface, nrows int64) *simpleRecor
 }
 
 func (rec *simpleRecord) validate() error {
-	if len(rec.arrs) != len(rec.schema.Fields()) {
+	return validateColumns(rec.schema, rec.arrs, rec.rows)
+}
+
+// validateColumns checks that the columns of a record match its schema and
+// number of rows.
+func validateColumns(schema *arrow.Schema, arrs []Interface, rows int64) error {
+	if len(arrs) != len(schema.Fields()) {
 		return fmt.Errorf("arrow/array: number of columns/fields mismatch")
 	}
 
-	for i, arr := range rec.arrs {
-		f := rec.schema.Field(i)
-		if int64(arr.Len()) < rec.rows {
+	for i, arr := range arrs {
+		f := schema.Field(i)
+		if int64(arr.Len()) < rows {
 			return fmt.Errorf("arrow/array: mismatch number of rows in column %q: got=%d, want=%d",
 				f.Name,
-				arr.Len(), rec.rows,
+				arr.Len(), rows,
 			)
 		}
 		if !reflect.DeepEqual(f.Type, arr.DataType()) {
@@ -243,6 +249,97 @@ func (rec *simpleRecord) NewSlice(i, j int64) Record {
 	return NewRecord(rec.schema, arrs, j-i)
 }
 
+// SelectRecord returns a zero-copy view of rec holding only the named
+// columns, in the order given.
+// The returned record must be Release()'d after use.
+//
+// SelectRecord returns an error if a name does not match any column.
+func SelectRecord(rec Record, names ...string) (Record, error) {
+	indices, err := fieldIndices(rec.Schema(), names)
+	if err != nil {
+		return nil, err
+	}
+	return ProjectRecord(rec, indices...), nil
+}
+
+// ProjectRecord returns a zero-copy view of rec holding only the columns
+// at the given indices, in the order given.
+// The returned record must be Release()'d after use.
+//
+// ProjectRecord panics if an index is outside the valid range of columns.
+func ProjectRecord(rec Record, indices ...int) Record {
+	arrs := make([]Interface, len(indices))
+	for i, idx := range indices {
+		arrs[i] = rec.Column(idx)
+	}
+	return NewRecord(projectSchema(rec.Schema(), indices), arrs, rec.NumRows())
+}
+
+// fieldIndices returns the indices of the fields of schema with the given names.
+func fieldIndices(schema *arrow.Schema, names []string) ([]int, error) {
+	indices := make([]int, len(names))
+	for i, name := range names {
+		indices[i] = schema.FieldIndex(name)
+		if indices[i] < 0 {
+			return nil, fmt.Errorf("arrow/array: no column named %q", name)
+		}
+	}
+	return indices, nil
+}
+
+// projectSchema returns the schema made of the fields of schema at the given
+// indices. The metadata of schema is preserved.
+func projectSchema(schema *arrow.Schema, indices []int) *arrow.Schema {
+	fields := make([]arrow.Field, len(indices))
+	for i, idx := range indices {
+		fields[i] = schema.Field(idx)
+	}
+	md := schema.Metadata()
+	return arrow.NewSchema(fields, &md)
+}
+
+// HeadRecord returns a zero-copy slice of the first n rows of rec, or of all
+// its rows if it has fewer.
+// The returned record must be Release()'d after use.
+//
+// HeadRecord panics if n is negative.
+func HeadRecord(rec Record, n int64) Record {
+	i, j := headRange(rec.NumRows(), n)
+	return rec.NewSlice(i, j)
+}
+
+// TailRecord returns a zero-copy slice of the last n rows of rec, or of all
+// its rows if it has fewer.
+// The returned record must be Release()'d after use.
+//
+// TailRecord panics if n is negative.
+func TailRecord(rec Record, n int64) Record {
+	i, j := tailRange(rec.NumRows(), n)
+	return rec.NewSlice(i, j)
+}
+
+// headRange returns the range of the first n of rows rows.
+func headRange(rows, n int64) (int64, int64) {
+	if n < 0 {
+		panic(fmt.Errorf("arrow/array: negative number of rows %d", n))
+	}
+	if n > rows {
+		n = rows
+	}
+	return 0, n
+}
+
+// tailRange returns the range of the last n of rows rows.
+func tailRange(rows, n int64) (int64, int64) {
+	if n < 0 {
+		panic(fmt.Errorf("arrow/array: negative number of rows %d", n))
+	}
+	if n > rows {
+		n = rows
+	}
+	return rows - n, rows
+}
+
 // RecordBuilder eases the process of building a Record, iteratively, from
 // a known Schema.
 type RecordBuilder struct {
@@ -262,7 +359,7 @@ func NewRecordBuilder(mem memory.Allocator, schema *arrow.Schema) *RecordBuilder
 	}
 
 	for i, f := range schema.Fields() {
-		b.fields[i] = newBuilder(b.mem, f.Type)
+		b.fields[i] = NewBuilder(b.mem, f.Type)
 	}
 
 	return b
@@ -291,9 +388,40 @@ func (b *RecordBuilder) Schema() *arrow.Schema { return b.schema }
 func (b *RecordBuilder) Fields() []Builder     { return b.fields }
 func (b *RecordBuilder) Field(i int) Builder   { return b.fields[i] }
 
+// Reserve ensures there is enough space for appending size rows to the
+// builders of the fields, and to the builders of their children: the
+// fields of structs, the values of fixed size lists, and the values of the
+// other nested types, which are reserved for one value per row.
 func (b *RecordBuilder) Reserve(size int) {
 	for _, f := range b.fields {
-		f.Reserve(size)
+		reserveNested(f, size)
+	}
+}
+
+// reserveNested reserves space for n elements in b and its children.
+func reserveNested(b Builder, n int) {
+	b.Reserve(n)
+	switch b := b.(type) {
+	case *StructBuilder:
+		for i := 0; i < b.NumField(); i++ {
+			reserveNested(b.FieldBuilder(i), n)
+		}
+	case *FixedSizeListBuilder:
+		reserveNested(b.ValueBuilder(), n*int(b.n))
+	case *ListBuilder:
+		reserveNested(b.ValueBuilder(), n)
+	case *LargeListBuilder:
+		reserveNested(b.ValueBuilder(), n)
+	case *MapBuilder:
+		reserveNested(b.ValueBuilder(), n)
+	case *UnionBuilder:
+		for _, child := range b.children {
+			reserveNested(child, n)
+		}
+	case *ExtensionBuilder:
+		reserveNested(b.StorageBuilder(), n)
+	case *RunEndEncodedBuilder:
+		reserveNested(b.ValueBuilder(), n)
 	}
 }
 
@@ -328,6 +456,110 @@ func (b *RecordBuilder) NewRecord() Record {
 	return NewRecord(b.schema, cols, rows)
 }
 
+// FlushOption configures a FlushingRecordBuilder.
+type FlushOption func(*FlushingRecordBuilder)
+
+// WithFlushRows specifies the number of rows after which a
+// FlushingRecordBuilder flushes its record.
+func WithFlushRows(n int) FlushOption {
+	return func(b *FlushingRecordBuilder) { b.maxRows = n }
+}
+
+// WithFlushBytes specifies the approximate size, in bytes, after which a
+// FlushingRecordBuilder flushes its record. The size is that of the memory
+// allocated by the builders of the fields since the last flush, which grows
+// in steps as the builders are resized, and includes the reserved space.
+func WithFlushBytes(n int) FlushOption {
+	return func(b *FlushingRecordBuilder) { b.maxBytes = n }
+}
+
+// FlushingRecordBuilder is a RecordBuilder passing a record to a flush
+// function whenever a row or size threshold is reached.
+//
+// Rows are appended to the builders of the fields, as with RecordBuilder,
+// and then ended with EndRow. The builder is reused for the following rows.
+type FlushingRecordBuilder struct {
+	*RecordBuilder
+
+	mem   *countingAllocator
+	flush func(Record) error
+	rows  int
+
+	maxRows  int
+	maxBytes int
+}
+
+// NewFlushingRecordBuilder returns a builder of records of schema, using
+// mem to allocate memory. The records are passed to flush, and released on
+// its return: flush must retain the records it keeps.
+//
+// Without WithFlushRows nor WithFlushBytes, records are only flushed by Flush.
+func NewFlushingRecordBuilder(mem memory.Allocator, schema *arrow.Schema, flush func(Record) error, opts ...FlushOption) *FlushingRecordBuilder {
+	cmem := &countingAllocator{mem: mem}
+	b := &FlushingRecordBuilder{
+		RecordBuilder: NewRecordBuilder(cmem, schema),
+		mem:           cmem,
+		flush:         flush,
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Rows returns the number of rows ended since the last flush.
+func (b *FlushingRecordBuilder) Rows() int { return b.rows }
+
+// EndRow ends the row whose values were appended to the builders of the
+// fields, and flushes the record if a threshold is reached.
+// EndRow returns the error of the flush function.
+func (b *FlushingRecordBuilder) EndRow() error {
+	b.rows++
+	if (b.maxRows > 0 && b.rows >= b.maxRows) || (b.maxBytes > 0 && b.mem.size >= b.maxBytes) {
+		return b.Flush()
+	}
+	return nil
+}
+
+// Flush passes the record of the rows ended since the last flush, if any, to
+// the flush function, and returns its error.
+func (b *FlushingRecordBuilder) Flush() error {
+	if b.rows == 0 {
+		return nil
+	}
+	rec := b.NewRecord()
+	defer rec.Release()
+	return b.flush(rec)
+}
+
+// NewRecord creates a new record from the memory buffers and resets the
+// builder so it can be used to build a new record.
+func (b *FlushingRecordBuilder) NewRecord() Record {
+	b.rows = 0
+	b.mem.size = 0
+	return b.RecordBuilder.NewRecord()
+}
+
+// countingAllocator counts the bytes allocated from mem.
+type countingAllocator struct {
+	mem  memory.Allocator
+	size int
+}
+
+func (a *countingAllocator) Allocate(size int) []byte {
+	a.size += size
+	return a.mem.Allocate(size)
+}
+
+func (a *countingAllocator) Reallocate(size int, b []byte) []byte {
+	if size > len(b) {
+		a.size += size - len(b)
+	}
+	return a.mem.Reallocate(size, b)
+}
+
+func (a *countingAllocator) Free(b []byte) { a.mem.Free(b) }
+
 var (
 	_ Record       = (*simpleRecord)(nil)
 	_ RecordReader = (*simpleRecords)(nil)
@@ -388,3 +388,260 @@ func TestRecordBuilder(t *testing.T) {
 		t.Fatalf("invalid column name: got=%q, want=%q", got, want)
 	}
 }
+
+func TestRecordSelect(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	md := arrow.NewMetadata([]string{"k"}, []string{"v"})
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			arrow.Field{Name: "f1-i32", Type: arrow.PrimitiveTypes.Int32},
+			arrow.Field{Name: "f2-f64", Type: arrow.PrimitiveTypes.Float64},
+			arrow.Field{Name: "f3-str", Type: arrow.BinaryTypes.String},
+		},
+		&md,
+	)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2, 3}, nil)
+	b.Field(1).(*array.Float64Builder).AppendValues([]float64{1, 2, 3}, nil)
+	b.Field(2).(*array.StringBuilder).AppendValues([]string{"a", "b", "c"}, nil)
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	sub, err := array.SelectRecord(rec, "f3-str", "f1-i32")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Release()
+
+	if got, want := sub.NumCols(), int64(2); got != want {
+		t.Fatalf("invalid number of columns: got=%d, want=%d", got, want)
+	}
+	if got, want := sub.NumRows(), rec.NumRows(); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+	if got, want := sub.ColumnName(0), "f3-str"; got != want {
+		t.Fatalf("invalid column name: got=%q, want=%q", got, want)
+	}
+	if got, want := sub.ColumnName(1), "f1-i32"; got != want {
+		t.Fatalf("invalid column name: got=%q, want=%q", got, want)
+	}
+	if sub.Column(0) != rec.Column(2) || sub.Column(1) != rec.Column(0) {
+		t.Fatalf("selected columns should share the record arrays")
+	}
+	if !reflect.DeepEqual(sub.Schema().Metadata(), schema.Metadata()) {
+		t.Fatalf("schema metadata was not preserved")
+	}
+
+	proj := array.ProjectRecord(rec, 1)
+	defer proj.Release()
+	if got, want := proj.ColumnName(0), "f2-f64"; got != want {
+		t.Fatalf("invalid column name: got=%q, want=%q", got, want)
+	}
+
+	_, err = array.SelectRecord(rec, "f1-i32", "not-there")
+	if err == nil {
+		t.Fatalf("expected an error selecting an unknown column")
+	}
+}
+
+func TestRecordSliceNested(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	dtype := arrow.StructOf(
+		arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Int32},
+		arrow.Field{Name: "y", Type: arrow.BinaryTypes.String},
+	)
+	schema := arrow.NewSchema([]arrow.Field{{Name: "s", Type: dtype}}, nil)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	sb := b.Field(0).(*array.StructBuilder)
+	xb := sb.FieldBuilder(0).(*array.Int32Builder)
+	yb := sb.FieldBuilder(1).(*array.StringBuilder)
+	for i, v := range []string{"a", "b", "c", "d", "e"} {
+		sb.Append(true)
+		xb.Append(int32(i))
+		yb.Append(v)
+	}
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	slice := rec.NewSlice(1, 5)
+	defer slice.Release()
+
+	sub := slice.NewSlice(1, 3)
+	defer sub.Release()
+
+	arr := sub.Column(0).(*array.Struct)
+	if got, want := arr.Len(), 2; got != want {
+		t.Fatalf("invalid length: got=%d, want=%d", got, want)
+	}
+	x := arr.Field(0).(*array.Int32)
+	y := arr.Field(1).(*array.String)
+	if got, want := x.Len(), 2; got != want {
+		t.Fatalf("invalid field length: got=%d, want=%d", got, want)
+	}
+	if got, want := x.Int32Values(), []int32{2, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid field values: got=%v, want=%v", got, want)
+	}
+	if got, want := []string{y.Value(0), y.Value(1)}, []string{"c", "d"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid field values: got=%v, want=%v", got, want)
+	}
+}
+
+func TestRecordBuilderReserveNested(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "i32", Type: arrow.PrimitiveTypes.Int32},
+			{Name: "st", Type: arrow.StructOf(
+				arrow.Field{Name: "f64", Type: arrow.PrimitiveTypes.Float64},
+				arrow.Field{Name: "lst", Type: arrow.ListOf(arrow.PrimitiveTypes.Int8)},
+			)},
+			{Name: "fsl", Type: arrow.FixedSizeListOf(3, arrow.PrimitiveTypes.Int16)},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Reserve(100)
+
+	var (
+		st  = b.Field(1).(*array.StructBuilder)
+		fsl = b.Field(2).(*array.FixedSizeListBuilder)
+	)
+	for _, tc := range []struct {
+		name string
+		b    array.Builder
+		want int
+	}{
+		{"i32", b.Field(0), 100},
+		{"st", st, 100},
+		{"st.f64", st.FieldBuilder(0), 100},
+		{"st.lst", st.FieldBuilder(1), 100},
+		{"st.lst.values", st.FieldBuilder(1).(*array.ListBuilder).ValueBuilder(), 100},
+		{"fsl", fsl, 100},
+		{"fsl.values", fsl.ValueBuilder(), 300},
+	} {
+		if got := tc.b.Cap(); got < tc.want {
+			t.Errorf("%s: invalid capacity: got=%d, want>=%d", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestFlushingRecordBuilder(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "i64", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "str", Type: arrow.BinaryTypes.String},
+		},
+		nil,
+	)
+
+	for _, tc := range []struct {
+		name string
+		opts []array.FlushOption
+		rows int
+		want []int64 // number of rows of the flushed records
+	}{
+		{name: "manual", rows: 10, want: []int64{10}},
+		{name: "rows", opts: []array.FlushOption{array.WithFlushRows(4)}, rows: 10, want: []int64{4, 4, 2}},
+		{name: "rows-exact", opts: []array.FlushOption{array.WithFlushRows(5)}, rows: 10, want: []int64{5, 5}},
+		{
+			// the builders are resized for 64 rows by the 33rd row, which
+			// brings their allocations over 1KiB.
+			name: "bytes", opts: []array.FlushOption{array.WithFlushBytes(1024)}, rows: 100,
+			want: []int64{33, 33, 33, 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []int64
+			b := array.NewFlushingRecordBuilder(mem, schema, func(rec array.Record) error {
+				if !rec.Schema().Equal(schema) {
+					t.Fatalf("invalid schema: %v", rec.Schema())
+				}
+				got = append(got, rec.NumRows())
+				return nil
+			}, tc.opts...)
+			defer b.Release()
+
+			for i := 0; i < tc.rows; i++ {
+				b.Field(0).(*array.Int64Builder).Append(int64(i))
+				b.Field(1).(*array.StringBuilder).Append("x")
+				if err := b.EndRow(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := b.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			if err := b.Flush(); err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid records: got=%v, want=%v", got, tc.want)
+			}
+			if got := b.Rows(); got != 0 {
+				t.Fatalf("invalid number of pending rows: got=%d, want=0", got)
+			}
+		})
+	}
+
+	b := array.NewFlushingRecordBuilder(mem, schema, func(rec array.Record) error {
+		return fmt.Errorf("flush error")
+	}, array.WithFlushRows(1))
+	defer b.Release()
+
+	b.Field(0).(*array.Int64Builder).Append(1)
+	b.Field(1).(*array.StringBuilder).Append("x")
+	if err := b.EndRow(); err == nil || err.Error() != "flush error" {
+		t.Fatalf("invalid error: got=%v, want=flush error", err)
+	}
+}
+
+func TestRecordHeadTail(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "i64", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2, 3, 4, 5}, nil)
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	for _, tc := range []struct {
+		got  array.Record
+		want []int64
+	}{
+		{array.HeadRecord(rec, 2), []int64{1, 2}},
+		{array.HeadRecord(rec, 8), []int64{1, 2, 3, 4, 5}},
+		{array.TailRecord(rec, 2), []int64{4, 5}},
+		{array.TailRecord(rec, 0), []int64{}},
+	} {
+		defer tc.got.Release()
+		if got := tc.got.Column(0).(*array.Int64).Int64Values(); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("invalid values: got=%v, want=%v", got, tc.want)
+		}
+		if got, want := tc.got.NumRows(), int64(len(tc.want)); got != want {
+			t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+		}
+	}
+}
@@ -0,0 +1,885 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+)
+
+// SchemaOf returns the schema describing the Go struct type of v, which
+// must be a struct, a pointer to a struct, or a slice of either.
+//
+// Each exported field of the struct becomes a field of the schema, named
+// after its `arrow:"name"` tag, or after the Go field if there is no tag.
+// Fields tagged `arrow:"-"` are skipped. Pointer fields are nullable.
+//
+// Go types map to Arrow types as follows:
+//   - bool, integers and floats map to the Arrow type of the same width
+//     (int and uint map to int64 and uint64),
+//   - string maps to utf8, []byte to binary and [N]byte to fixed size binary,
+//   - time.Time maps to a timestamp with nanosecond resolution,
+//   - slices map to lists, other [N]T arrays to fixed size lists of N
+//     elements, and structs to nested structs.
+func SchemaOf(v interface{}) (*arrow.Schema, error) {
+	typ := structTypeOf(reflect.TypeOf(v))
+	if typ == nil {
+		return nil, fmt.Errorf("arrow/array: %T is not a struct type", v)
+	}
+	dt, err := dataTypeOf(typ)
+	if err != nil {
+		return nil, err
+	}
+	return arrow.NewSchema(dt.(*arrow.StructType).Fields(), nil), nil
+}
+
+// AppendStructs appends the elements of rows, a slice of structs or of
+// pointers to structs, to b, one element per row.
+//
+// The fields of the schema of b are matched by name with the fields of
+// the struct, as described by SchemaOf. Struct fields absent from the
+// schema are ignored. Nil pointers, slices and []byte values are appended
+// as nulls.
+//
+// AppendStructs returns an error if a field of the schema has no matching
+// struct field, or if a value can not be appended to its column builder.
+// The columns of b may then have different lengths, and b should be discarded.
+func AppendStructs(b *RecordBuilder, rows interface{}) error {
+	rv := reflect.ValueOf(rows)
+	if rv.Kind() != reflect.Slice || structTypeOf(rv.Type()) == nil {
+		return fmt.Errorf("arrow/array: %T is not a slice of structs", rows)
+	}
+
+	index, err := fieldIndex(b.Schema().Fields(), structTypeOf(rv.Type()))
+	if err != nil {
+		return err
+	}
+
+	b.Reserve(rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		row := rv.Index(i)
+		if row.Kind() == reflect.Ptr {
+			if row.IsNil() {
+				return fmt.Errorf("arrow/array: nil row %d", i)
+			}
+			row = row.Elem()
+		}
+		for j, fb := range b.Fields() {
+			if err := appendReflectValue(fb, row.FieldByIndex(index[j])); err != nil {
+				return fmt.Errorf("arrow/array: row %d, field %q: %v", i, b.Schema().Field(j).Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// AppendSlice appends the elements of values, a Go slice or array, to b,
+// one element per value.
+//
+// Values are converted as for the fields of AppendStructs. Nil pointers,
+// slices, maps and interfaces are appended as nulls. In addition:
+//   - list builders accept slices or arrays of element values,
+//   - struct builders accept structs, and maps keyed by field name, whose
+//     missing keys are appended as nulls,
+//   - map builders accept Go maps, whose entries are appended in key order,
+//   - date builders accept time.Time values, and time32 and time64
+//     builders accept time.Duration values.
+//
+// Slices of the Go type of the builder values, such as []int64 for an
+// Int64Builder or []string for a StringBuilder, are appended at once,
+// without reflection.
+//
+// AppendSlice returns an error if values is not a slice or an array, or if
+// an element can not be appended to b. The elements before it have then
+// been appended.
+func AppendSlice(b Builder, values interface{}) error {
+	if appendValues(b, values) {
+		return nil
+	}
+
+	rv := reflect.ValueOf(values)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return fmt.Errorf("arrow/array: %T is not a slice", values)
+	}
+	b.Reserve(rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		if err := appendReflectValue(b, rv.Index(i)); err != nil {
+			return fmt.Errorf("arrow/array: element %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// appendValues appends values to b with the AppendValues method of b, if
+// values is a slice of the Go type of the values of b.
+func appendValues(b Builder, values interface{}) bool {
+	switch vs := values.(type) {
+	case []bool:
+		if b, ok := b.(*BooleanBuilder); ok {
+			b.AppendValues(vs, nil)
+			return true
+		}
+	case []int8:
+		if b, ok := b.(*Int8Builder); ok {
+			b.AppendValues(vs, nil)
+			return true
+		}
+	case []int16:
+		if b, ok := b.(*Int16Builder); ok {
+			b.AppendValues(vs, nil)
+			return true
+		}
+	case []int32:
+		if b, ok := b.(*Int32Builder); ok {
+			b.AppendValues(vs, nil)
+			return true
+		}
+	case []int64:
+		if b, ok := b.(*Int64Builder); ok {
+			b.AppendValues(vs, nil)
+			return true
+		}
+	case []uint8:
+		if b, ok := b.(*Uint8Builder); ok {
+			b.AppendValues(vs, nil)
+			return true
+		}
+	case []uint16:
+		if b, ok := b.(*Uint16Builder); ok {
+			b.AppendValues(vs, nil)
+			return true
+		}
+	case []uint32:
+		if b, ok := b.(*Uint32Builder); ok {
+			b.AppendValues(vs, nil)
+			return true
+		}
+	case []uint64:
+		if b, ok := b.(*Uint64Builder); ok {
+			b.AppendValues(vs, nil)
+			return true
+		}
+	case []float32:
+		if b, ok := b.(*Float32Builder); ok {
+			b.AppendValues(vs, nil)
+			return true
+		}
+	case []float64:
+		if b, ok := b.(*Float64Builder); ok {
+			b.AppendValues(vs, nil)
+			return true
+		}
+	case []string:
+		switch b := b.(type) {
+		case *StringBuilder:
+			b.AppendValues(vs, nil)
+			return true
+		case *LargeStringBuilder:
+			b.AppendValues(vs, nil)
+			return true
+		case *StringViewBuilder:
+			b.AppendValues(vs, nil)
+			return true
+		}
+	}
+	return false
+}
+
+// DecodeStructs decodes the rows of rec into dst, which must be a pointer to
+// a slice of structs or of pointers to structs. The slice is replaced by one
+// holding rec.NumRows() elements.
+//
+// The columns of rec are matched by name with the fields of the struct, as
+// described by SchemaOf. Columns absent from the struct are ignored, and
+// struct fields absent from rec are left to their zero value.
+// Null values are decoded as nil pointers, slices and []byte values, or as
+// the zero value of other Go types.
+//
+// DecodeStructs returns an error if a value can not be stored in its field,
+// including if an integer overflows the Go field type.
+func DecodeStructs(rec Record, dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice || structTypeOf(rv.Elem().Type()) == nil {
+		return fmt.Errorf("arrow/array: %T is not a pointer to a slice of structs", dst)
+	}
+
+	var (
+		n      = int(rec.NumRows())
+		typ    = structTypeOf(rv.Elem().Type())
+		byName = goFieldsByName(typ)
+		slice  = reflect.MakeSlice(rv.Elem().Type(), n, n)
+	)
+	for i := 0; i < n; i++ {
+		row := slice.Index(i)
+		if row.Kind() == reflect.Ptr {
+			row.Set(reflect.New(typ))
+			row = row.Elem()
+		}
+		for j, col := range rec.Columns() {
+			idx, ok := byName[rec.ColumnName(j)]
+			if !ok {
+				continue
+			}
+			if err := decodeReflectValue(row.FieldByIndex(idx), col, i); err != nil {
+				return fmt.Errorf("arrow/array: row %d, column %q: %v", i, rec.ColumnName(j), err)
+			}
+		}
+	}
+	rv.Elem().Set(slice)
+	return nil
+}
+
+// DecodeMaps decodes the rows of rec into maps keyed by column name.
+//
+// Values are decoded as bool, sized integers, floats, string or []byte,
+// time.Time for temporal types, []interface{} for lists and
+// map[string]interface{} for structs. Null values are decoded as nil.
+//
+// DecodeMaps returns an error if rec holds a column of an unsupported type.
+func DecodeMaps(rec Record) ([]map[string]interface{}, error) {
+	rows := make([]map[string]interface{}, rec.NumRows())
+	for i := range rows {
+		rows[i] = make(map[string]interface{}, rec.NumCols())
+		for j, col := range rec.Columns() {
+			v, err := decodeValue(col, i)
+			if err != nil {
+				return nil, fmt.Errorf("arrow/array: row %d, column %q: %v", i, rec.ColumnName(j), err)
+			}
+			rows[i][rec.ColumnName(j)] = v
+		}
+	}
+	return rows, nil
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// structTypeOf returns the struct type of typ, dereferencing pointers and
+// slices, or nil if typ does not describe a struct.
+func structTypeOf(typ reflect.Type) reflect.Type {
+	if typ == nil {
+		return nil
+	}
+	if typ.Kind() == reflect.Slice {
+		typ = typ.Elem()
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct || typ == timeType {
+		return nil
+	}
+	return typ
+}
+
+// goField describes an exported field of a Go struct.
+type goField struct {
+	name  string
+	index []int
+	ftype reflect.Type
+}
+
+// goFieldsOf returns the exported fields of the struct type typ, named after
+// their arrow tag.
+func goFieldsOf(typ reflect.Type) []goField {
+	fields := make([]goField, 0, typ.NumField())
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" {
+			continue // unexported
+		}
+		name := f.Name
+		if tag, ok := f.Tag.Lookup("arrow"); ok {
+			if tag == "-" {
+				continue
+			}
+			if tag = strings.Split(tag, ",")[0]; tag != "" {
+				name = tag
+			}
+		}
+		fields = append(fields, goField{name: name, index: f.Index, ftype: f.Type})
+	}
+	return fields
+}
+
+// fieldIndex returns, for each of fields, the index of the field of the
+// struct type typ with the same name.
+func fieldIndex(fields []arrow.Field, typ reflect.Type) ([][]int, error) {
+	byName := goFieldsByName(typ)
+	index := make([][]int, len(fields))
+	for i, f := range fields {
+		idx, ok := byName[f.Name]
+		if !ok {
+			return nil, fmt.Errorf("arrow/array: no field %q in Go struct %s", f.Name, typ)
+		}
+		index[i] = idx
+	}
+	return index, nil
+}
+
+// goFieldsByName returns the indices of the exported fields of the struct
+// type typ, keyed by their name.
+func goFieldsByName(typ reflect.Type) map[string][]int {
+	byName := make(map[string][]int)
+	for _, f := range goFieldsOf(typ) {
+		byName[f.name] = f.index
+	}
+	return byName
+}
+
+// dataTypeOf returns the Arrow data type of values of the Go type typ.
+func dataTypeOf(typ reflect.Type) (arrow.DataType, error) {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	switch typ {
+	case timeType:
+		return &arrow.TimestampType{Unit: arrow.Nanosecond}, nil
+	case durationType:
+		return arrow.FixedWidthTypes.Duration_ns, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Bool:
+		return arrow.FixedWidthTypes.Boolean, nil
+	case reflect.Int8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case reflect.Int16:
+		return arrow.PrimitiveTypes.Int16, nil
+	case reflect.Int32:
+		return arrow.PrimitiveTypes.Int32, nil
+	case reflect.Int64, reflect.Int:
+		return arrow.PrimitiveTypes.Int64, nil
+	case reflect.Uint8:
+		return arrow.PrimitiveTypes.Uint8, nil
+	case reflect.Uint16:
+		return arrow.PrimitiveTypes.Uint16, nil
+	case reflect.Uint32:
+		return arrow.PrimitiveTypes.Uint32, nil
+	case reflect.Uint64, reflect.Uint:
+		return arrow.PrimitiveTypes.Uint64, nil
+	case reflect.Float32:
+		return arrow.PrimitiveTypes.Float32, nil
+	case reflect.Float64:
+		return arrow.PrimitiveTypes.Float64, nil
+	case reflect.String:
+		return arrow.BinaryTypes.String, nil
+	case reflect.Array:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return &arrow.FixedSizeBinaryType{ByteWidth: typ.Len()}, nil
+		}
+		elem, err := dataTypeOf(typ.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return arrow.FixedSizeListOf(int32(typ.Len()), elem), nil
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			return arrow.BinaryTypes.Binary, nil
+		}
+		elem, err := dataTypeOf(typ.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return arrow.ListOf(elem), nil
+	case reflect.Struct:
+		var fields []arrow.Field
+		for _, f := range goFieldsOf(typ) {
+			dt, err := dataTypeOf(f.ftype)
+			if err != nil {
+				return nil, fmt.Errorf("arrow/array: field %q: %v", f.name, err)
+			}
+			fields = append(fields, arrow.Field{Name: f.name, Type: dt, Nullable: f.ftype.Kind() == reflect.Ptr})
+		}
+		return arrow.StructOf(fields...), nil
+	}
+	return nil, fmt.Errorf("arrow/array: unsupported Go type %s", typ)
+}
+
+// appendReflectValue appends the Go value v to b.
+func appendReflectValue(b Builder, v reflect.Value) error {
+	if v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			b.AppendNull()
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map:
+		if v.IsNil() {
+			b.AppendNull()
+			return nil
+		}
+		if v.Kind() == reflect.Ptr {
+			v = v.Elem()
+		}
+	}
+
+	switch b := b.(type) {
+	case *BooleanBuilder:
+		if v.Kind() == reflect.Bool {
+			b.Append(v.Bool())
+			return nil
+		}
+	case *Int8Builder:
+		if isInt(v) {
+			b.Append(int8(v.Int()))
+			return nil
+		}
+	case *Int16Builder:
+		if isInt(v) {
+			b.Append(int16(v.Int()))
+			return nil
+		}
+	case *Int32Builder:
+		if isInt(v) {
+			b.Append(int32(v.Int()))
+			return nil
+		}
+	case *Int64Builder:
+		if isInt(v) {
+			b.Append(v.Int())
+			return nil
+		}
+	case *Uint8Builder:
+		if isUint(v) {
+			b.Append(uint8(v.Uint()))
+			return nil
+		}
+	case *Uint16Builder:
+		if isUint(v) {
+			b.Append(uint16(v.Uint()))
+			return nil
+		}
+	case *Uint32Builder:
+		if isUint(v) {
+			b.Append(uint32(v.Uint()))
+			return nil
+		}
+	case *Uint64Builder:
+		if isUint(v) {
+			b.Append(v.Uint())
+			return nil
+		}
+	case *Float32Builder:
+		if isFloat(v) {
+			b.Append(float32(v.Float()))
+			return nil
+		}
+	case *Float64Builder:
+		if isFloat(v) {
+			b.Append(v.Float())
+			return nil
+		}
+	case *StringBuilder:
+		if v.Kind() == reflect.String {
+			b.Append(v.String())
+			return nil
+		}
+	case *LargeStringBuilder:
+		if v.Kind() == reflect.String {
+			b.Append(v.String())
+			return nil
+		}
+	case *StringViewBuilder:
+		if v.Kind() == reflect.String {
+			b.Append(v.String())
+			return nil
+		}
+	case *BinaryBuilder:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			b.Append(v.Bytes())
+			return nil
+		}
+	case *LargeBinaryBuilder:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			b.Append(v.Bytes())
+			return nil
+		}
+	case *BinaryViewBuilder:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			b.Append(v.Bytes())
+			return nil
+		}
+	case *FixedSizeBinaryBuilder:
+		width := b.dtype.ByteWidth
+		if v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8 && v.Len() == width {
+			buf := make([]byte, width)
+			reflect.Copy(reflect.ValueOf(buf), v)
+			b.Append(buf)
+			return nil
+		}
+	case *TimestampBuilder:
+		if v.Type() == timeType {
+			b.AppendTime(v.Interface().(time.Time))
+			return nil
+		}
+	case *Date32Builder:
+		if v.Type() == timeType {
+			secs := v.Interface().(time.Time).Unix()
+			days := secs / 86400
+			if secs%86400 < 0 {
+				days-- // days before the epoch are rounded down.
+			}
+			b.Append(arrow.Date32(days))
+			return nil
+		}
+	case *Date64Builder:
+		if v.Type() == timeType {
+			t := v.Interface().(time.Time)
+			b.Append(arrow.Date64(t.Unix()*1000 + int64(t.Nanosecond())/int64(time.Millisecond)))
+			return nil
+		}
+	case *Time32Builder:
+		if v.Type() == durationType {
+			b.Append(arrow.Time32(time.Duration(v.Int()) / b.dtype.Unit.Multiplier()))
+			return nil
+		}
+	case *Time64Builder:
+		if v.Type() == durationType {
+			b.Append(arrow.Time64(time.Duration(v.Int()) / b.dtype.Unit.Multiplier()))
+			return nil
+		}
+	case *DurationBuilder:
+		if v.Type() == durationType {
+			b.Append(arrow.Duration(time.Duration(v.Int()) / b.dtype.Unit.Multiplier()))
+			return nil
+		}
+	case *ListBuilder:
+		if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+			b.Append(true)
+			vb := b.ValueBuilder()
+			vb.Reserve(v.Len())
+			for i := 0; i < v.Len(); i++ {
+				if err := appendReflectValue(vb, v.Index(i)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case *LargeListBuilder:
+		if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+			b.Append(true)
+			vb := b.ValueBuilder()
+			vb.Reserve(v.Len())
+			for i := 0; i < v.Len(); i++ {
+				if err := appendReflectValue(vb, v.Index(i)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case *FixedSizeListBuilder:
+		if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+			if v.Len() != int(b.n) {
+				return fmt.Errorf("invalid list length %d, expected %d", v.Len(), b.n)
+			}
+			b.Append(true)
+			vb := b.ValueBuilder()
+			vb.Reserve(v.Len())
+			for i := 0; i < v.Len(); i++ {
+				if err := appendReflectValue(vb, v.Index(i)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case *MapBuilder:
+		if v.Kind() == reflect.Map {
+			b.Append(true)
+			for _, k := range sortedMapKeys(v) {
+				if err := appendReflectValue(b.KeyBuilder(), k); err != nil {
+					return err
+				}
+				if err := appendReflectValue(b.ItemBuilder(), v.MapIndex(k)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case *StructBuilder:
+		if v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String {
+			b.Append(true)
+			for i, f := range b.dtype.(*arrow.StructType).Fields() {
+				fv := v.MapIndex(reflect.ValueOf(f.Name).Convert(v.Type().Key()))
+				if !fv.IsValid() {
+					b.FieldBuilder(i).AppendNull()
+					continue
+				}
+				if err := appendReflectValue(b.FieldBuilder(i), fv); err != nil {
+					return fmt.Errorf("field %q: %v", f.Name, err)
+				}
+			}
+			return nil
+		}
+		if v.Kind() == reflect.Struct {
+			index, err := fieldIndex(b.dtype.(*arrow.StructType).Fields(), v.Type())
+			if err != nil {
+				return err
+			}
+			b.Append(true)
+			for i, idx := range index {
+				if err := appendReflectValue(b.FieldBuilder(i), v.FieldByIndex(idx)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("can not append %s to %T", v.Type(), b)
+}
+
+// sortedMapKeys returns the keys of the map v, sorted if they are strings
+// or numbers.
+func sortedMapKeys(v reflect.Value) []reflect.Value {
+	keys := v.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		x, y := keys[i], keys[j]
+		switch {
+		case x.Kind() == reflect.String:
+			return x.String() < y.String()
+		case isInt(x):
+			return x.Int() < y.Int()
+		case isUint(x):
+			return x.Uint() < y.Uint()
+		case isFloat(x):
+			return x.Float() < y.Float()
+		}
+		return false
+	})
+	return keys
+}
+
+func isInt(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+	}
+	return false
+}
+
+func isUint(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+func isFloat(v reflect.Value) bool {
+	return v.Kind() == reflect.Float32 || v.Kind() == reflect.Float64
+}
+
+// decodeValue returns the Go value of the i-th element of arr.
+// The returned value does not share memory with arr.
+func decodeValue(arr Interface, i int) (interface{}, error) {
+	if arr.IsNull(i) {
+		return nil, nil
+	}
+
+	switch arr := arr.(type) {
+	case *Boolean:
+		return arr.Value(i), nil
+	case *Int8:
+		return arr.Value(i), nil
+	case *Int16:
+		return arr.Value(i), nil
+	case *Int32:
+		return arr.Value(i), nil
+	case *Int64:
+		return arr.Value(i), nil
+	case *Uint8:
+		return arr.Value(i), nil
+	case *Uint16:
+		return arr.Value(i), nil
+	case *Uint32:
+		return arr.Value(i), nil
+	case *Uint64:
+		return arr.Value(i), nil
+	case *Float32:
+		return arr.Value(i), nil
+	case *Float64:
+		return arr.Value(i), nil
+	case *String:
+		return string(append([]byte(nil), arr.Value(i)...)), nil
+	case *LargeString:
+		return string(append([]byte(nil), arr.Value(i)...)), nil
+	case *StringView:
+		return string(append([]byte(nil), arr.Value(i)...)), nil
+	case *Binary:
+		return append([]byte(nil), arr.Value(i)...), nil
+	case *LargeBinary:
+		return append([]byte(nil), arr.Value(i)...), nil
+	case *BinaryView:
+		return append([]byte(nil), arr.Value(i)...), nil
+	case *FixedSizeBinary:
+		return append([]byte(nil), arr.Value(i)...), nil
+	case *Date32:
+		return time.Unix(int64(arr.Value(i))*86400, 0).UTC(), nil
+	case *Date64:
+		v := int64(arr.Value(i))
+		return time.Unix(v/1000, (v%1000)*int64(time.Millisecond)).UTC(), nil
+	case *Timestamp:
+		return arr.Value(i).ToTime(arr.DataType().(*arrow.TimestampType).Unit), nil
+	case *Duration:
+		return arr.Duration(i), nil
+	case *List:
+		j := arr.Data().Offset() + i
+		beg, end := int(arr.Offsets()[j]), int(arr.Offsets()[j+1])
+		vs := make([]interface{}, 0, end-beg)
+		for k := beg; k < end; k++ {
+			v, err := decodeValue(arr.ListValues(), k)
+			if err != nil {
+				return nil, err
+			}
+			vs = append(vs, v)
+		}
+		return vs, nil
+	case *FixedSizeList:
+		beg, end := arr.ValueOffsets(i)
+		vs := make([]interface{}, 0, end-beg)
+		for k := int(beg); k < int(end); k++ {
+			v, err := decodeValue(arr.ListValues(), k)
+			if err != nil {
+				return nil, err
+			}
+			vs = append(vs, v)
+		}
+		return vs, nil
+	case *Struct:
+		fields := arr.DataType().(*arrow.StructType).Fields()
+		m := make(map[string]interface{}, len(fields))
+		for k, f := range fields {
+			v, err := decodeValue(arr.Field(k), i)
+			if err != nil {
+				return nil, err
+			}
+			m[f.Name] = v
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("unsupported data type %s", arr.DataType().Name())
+}
+
+// decodeReflectValue stores the i-th element of arr into v.
+func decodeReflectValue(v reflect.Value, arr Interface, i int) error {
+	if arr.IsNull(i) {
+		v.Set(reflect.Zero(v.Type()))
+		return nil
+	}
+	if v.Kind() == reflect.Ptr {
+		p := reflect.New(v.Type().Elem())
+		if err := decodeReflectValue(p.Elem(), arr, i); err != nil {
+			return err
+		}
+		v.Set(p)
+		return nil
+	}
+
+	switch arr := arr.(type) {
+	case *List:
+		if v.Kind() != reflect.Slice {
+			break
+		}
+		j := arr.Data().Offset() + i
+		beg, end := int(arr.Offsets()[j]), int(arr.Offsets()[j+1])
+		slice := reflect.MakeSlice(v.Type(), end-beg, end-beg)
+		for k := beg; k < end; k++ {
+			if err := decodeReflectValue(slice.Index(k-beg), arr.ListValues(), k); err != nil {
+				return err
+			}
+		}
+		v.Set(slice)
+		return nil
+	case *FixedSizeList:
+		beg, end := arr.ValueOffsets(i)
+		n := int(end - beg)
+		if v.Kind() == reflect.Slice {
+			v.Set(reflect.MakeSlice(v.Type(), n, n))
+		} else if v.Kind() != reflect.Array || v.Len() != n {
+			break
+		}
+		for k := 0; k < n; k++ {
+			if err := decodeReflectValue(v.Index(k), arr.ListValues(), int(beg)+k); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *Struct:
+		if v.Kind() != reflect.Struct || v.Type() == timeType {
+			break
+		}
+		byName := goFieldsByName(v.Type())
+		for k, f := range arr.DataType().(*arrow.StructType).Fields() {
+			idx, ok := byName[f.Name]
+			if !ok {
+				continue
+			}
+			if err := decodeReflectValue(v.FieldByIndex(idx), arr.Field(k), i); err != nil {
+				return fmt.Errorf("field %q: %v", f.Name, err)
+			}
+		}
+		return nil
+	}
+
+	x, err := decodeValue(arr, i)
+	if err != nil {
+		return err
+	}
+	xv := reflect.ValueOf(x)
+	switch {
+	case xv.Type() == v.Type():
+		v.Set(xv)
+		return nil
+	case isInt(xv) && isInt(v):
+		if !v.OverflowInt(xv.Int()) {
+			v.SetInt(xv.Int())
+			return nil
+		}
+		return fmt.Errorf("value %d overflows %s", xv.Int(), v.Type())
+	case isUint(xv) && isUint(v):
+		if !v.OverflowUint(xv.Uint()) {
+			v.SetUint(xv.Uint())
+			return nil
+		}
+		return fmt.Errorf("value %d overflows %s", xv.Uint(), v.Type())
+	case isFloat(xv) && isFloat(v):
+		v.SetFloat(xv.Float())
+		return nil
+	case xv.Kind() == reflect.String && v.Kind() == reflect.String:
+		v.SetString(xv.String())
+		return nil
+	case xv.Kind() == reflect.String && v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+		v.SetBytes([]byte(xv.String()))
+		return nil
+	case xv.Kind() == reflect.Slice && v.Kind() == reflect.String:
+		v.SetString(string(xv.Bytes()))
+		return nil
+	case xv.Kind() == reflect.Slice && v.Kind() == reflect.Array && v.Type().Elem().Kind() == reflect.Uint8 && v.Len() == xv.Len():
+		reflect.Copy(v, xv)
+		return nil
+	}
+	return fmt.Errorf("can not decode %s into %s", arr.DataType().Name(), v.Type())
+}
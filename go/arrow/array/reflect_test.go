@@ -0,0 +1,422 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+type point struct {
+	X, Y float64
+}
+
+type event struct {
+	ID       int64     `arrow:"id"`
+	Name     string    `arrow:"name"`
+	Score    *float32  `arrow:"score"`
+	Tags     []string  `arrow:"tags"`
+	Payload  []byte    `arrow:"payload"`
+	Hash     [2]byte   `arrow:"hash"`
+	When     time.Time `arrow:"when"`
+	Location point     `arrow:"location"`
+	Ignored  int       `arrow:"-"`
+	internal int
+}
+
+func TestSchemaOf(t *testing.T) {
+	schema, err := array.SchemaOf([]event{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float32, Nullable: true},
+		{Name: "tags", Type: arrow.ListOf(arrow.BinaryTypes.String)},
+		{Name: "payload", Type: arrow.BinaryTypes.Binary},
+		{Name: "hash", Type: &arrow.FixedSizeBinaryType{ByteWidth: 2}},
+		{Name: "when", Type: &arrow.TimestampType{Unit: arrow.Nanosecond}},
+		{Name: "location", Type: arrow.StructOf(
+			arrow.Field{Name: "X", Type: arrow.PrimitiveTypes.Float64},
+			arrow.Field{Name: "Y", Type: arrow.PrimitiveTypes.Float64},
+		)},
+	}, nil)
+	assert.True(t, want.Equal(schema), "got=%v\nwant=%v", schema, want)
+
+	_, err = array.SchemaOf(42)
+	assert.Error(t, err)
+
+	_, err = array.SchemaOf(struct{ C chan int }{})
+	assert.Error(t, err)
+}
+
+func TestAppendStructs(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema, err := array.SchemaOf(event{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	score := float32(0.5)
+	when := time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC)
+	rows := []*event{
+		{ID: 1, Name: "a", Score: &score, Tags: []string{"x", "y"}, Payload: []byte("p"), Hash: [2]byte{1, 2}, When: when, Location: point{1, 2}},
+		{ID: 2, Name: "b"},
+	}
+	if err := array.AppendStructs(b, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	assert.Equal(t, int64(2), rec.NumRows())
+	assert.Equal(t, []int64{1, 2}, rec.Column(0).(*array.Int64).Int64Values())
+	assert.Equal(t, `["a" "b"]`, rec.Column(1).(*array.String).String())
+
+	scores := rec.Column(2).(*array.Float32)
+	assert.Equal(t, float32(0.5), scores.Value(0))
+	assert.True(t, scores.IsNull(1))
+
+	tags := rec.Column(3).(*array.List)
+	assert.True(t, tags.IsValid(0))
+	assert.True(t, tags.IsNull(1))
+	assert.Equal(t, `["x" "y"]`, tags.ListValues().(*array.String).String())
+
+	payload := rec.Column(4).(*array.Binary)
+	assert.Equal(t, []byte("p"), payload.Value(0))
+	assert.True(t, payload.IsNull(1))
+
+	assert.Equal(t, []byte{1, 2}, rec.Column(5).(*array.FixedSizeBinary).Value(0))
+	assert.Equal(t, arrow.Timestamp(when.UnixNano()), rec.Column(6).(*array.Timestamp).Value(0))
+
+	loc := rec.Column(7).(*array.Struct)
+	assert.Equal(t, []float64{1, 0}, loc.Field(0).(*array.Float64).Float64Values())
+	assert.Equal(t, []float64{2, 0}, loc.Field(1).(*array.Float64).Float64Values())
+}
+
+func TestAppendStructsErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	err := array.AppendStructs(b, event{})
+	assert.Error(t, err)
+
+	err = array.AppendStructs(b, []struct{ Other int }{{1}})
+	assert.EqualError(t, err, `arrow/array: no field "id" in Go struct struct { Other int }`)
+
+	err = array.AppendStructs(b, []struct {
+		ID string `arrow:"id"`
+	}{{"1"}})
+	assert.EqualError(t, err, `arrow/array: row 0, field "id": can not append string to *array.Int64Builder`)
+}
+
+func TestDecodeStructs(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema, err := array.SchemaOf(event{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	score := float32(0.5)
+	when := time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC)
+	want := []event{
+		{ID: 1, Name: "a", Score: &score, Tags: []string{"x", "y"}, Payload: []byte("p"), Hash: [2]byte{1, 2}, When: when, Location: point{1, 2}},
+		{ID: 2, Name: "b", Tags: []string{}, When: time.Unix(0, 0).UTC()},
+	}
+	if err := array.AppendStructs(b, want); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	var got []event
+	if err := array.DecodeStructs(rec, &got); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, want, got)
+
+	var ptrs []*struct {
+		Name  []byte `arrow:"name"`
+		ID    int8   `arrow:"id"`
+		Score float64
+	}
+	if err := array.DecodeStructs(rec, &ptrs); err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, ptrs, 2)
+	assert.Equal(t, []byte("b"), ptrs[1].Name)
+	assert.Equal(t, int8(2), ptrs[1].ID)
+
+	var bad []struct {
+		Name int `arrow:"name"`
+	}
+	err = array.DecodeStructs(rec, &bad)
+	assert.EqualError(t, err, `arrow/array: row 0, column "name": can not decode utf8 into int`)
+
+	err = array.DecodeStructs(rec, got)
+	assert.Error(t, err)
+}
+
+func TestDecodeStructsDuration(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	type span struct {
+		Elapsed time.Duration `arrow:"elapsed"`
+	}
+	schema, err := array.SchemaOf(span{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, arrow.FixedWidthTypes.Duration_ns, schema.Field(0).Type)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	want := []span{{time.Minute}, {-time.Nanosecond}}
+	if err := array.AppendStructs(b, want); err != nil {
+		t.Fatal(err)
+	}
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	var got []span
+	if err := array.DecodeStructs(rec, &got); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestStructsFixedSizeList(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	type doc struct {
+		ID  int64      `arrow:"id"`
+		Vec [3]float32 `arrow:"vec"`
+	}
+
+	schema, err := array.SchemaOf(doc{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, arrow.FixedSizeListOf(3, arrow.PrimitiveTypes.Float32), schema.Field(1).Type)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	want := []doc{{ID: 1, Vec: [3]float32{0.5, 1, -2}}, {ID: 2}}
+	if err := array.AppendStructs(b, want); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := b.NewRecord()
+	defer rec.Release()
+	assert.Equal(t, "[[0.5 1 -2] [0 0 0]]", rec.Column(1).(*array.FixedSizeList).String())
+
+	var got []doc
+	if err := array.DecodeStructs(rec, &got); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, want, got)
+
+	var slices []struct {
+		Vec []float64 `arrow:"vec"`
+	}
+	if err := array.DecodeStructs(rec, &slices); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []float64{0.5, 1, -2}, slices[0].Vec)
+
+	var short []struct {
+		Vec [2]float32 `arrow:"vec"`
+	}
+	err = array.DecodeStructs(rec, &short)
+	assert.EqualError(t, err, `arrow/array: row 0, column "vec": can not decode fixed_size_list into [2]float32`)
+
+	lb := array.NewFixedSizeListBuilder(mem, 3, arrow.PrimitiveTypes.Float32)
+	defer lb.Release()
+	assert.EqualError(t, array.AppendSlice(lb, [][]float32{{1, 2}}), `arrow/array: element 0: invalid list length 2, expected 3`)
+}
+
+func TestDecodeStructsOverflow(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "v", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int64Builder).Append(1000)
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	var rows []struct {
+		V int8 `arrow:"v"`
+	}
+	err := array.DecodeStructs(rec, &rows)
+	assert.EqualError(t, err, `arrow/array: row 0, column "v": value 1000 overflows int8`)
+}
+
+func TestDecodeMaps(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema, err := array.SchemaOf(event{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	when := time.Date(2020, 1, 2, 3, 4, 5, 6, time.UTC)
+	rows := []event{
+		{ID: 1, Name: "a", Tags: []string{"x"}, Hash: [2]byte{1, 2}, When: when, Location: point{1, 2}},
+	}
+	if err := array.AppendStructs(b, rows); err != nil {
+		t.Fatal(err)
+	}
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	got, err := array.DecodeMaps(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []map[string]interface{}{{
+		"id":       int64(1),
+		"name":     "a",
+		"score":    nil,
+		"tags":     []interface{}{"x"},
+		"payload":  nil,
+		"hash":     []byte{1, 2},
+		"when":     when,
+		"location": map[string]interface{}{"X": 1.0, "Y": 2.0},
+	}}, got)
+}
+
+func TestAppendSlice(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ib := array.NewInt64Builder(mem)
+	defer ib.Release()
+	assert.NoError(t, array.AppendSlice(ib, []int64{1, 2}))
+	one := 3
+	assert.NoError(t, array.AppendSlice(ib, []*int{&one, nil}))
+	assert.NoError(t, array.AppendSlice(ib, []interface{}{int8(4), nil}))
+	ints := ib.NewInt64Array()
+	defer ints.Release()
+	assert.Equal(t, "[1 2 3 (null) 4 (null)]", ints.String())
+
+	sb := array.NewLargeStringBuilder(mem)
+	defer sb.Release()
+	s := "c"
+	assert.NoError(t, array.AppendSlice(sb, []string{"a", "b"}))
+	assert.NoError(t, array.AppendSlice(sb, []*string{&s, nil}))
+	strs := sb.NewLargeStringArray()
+	defer strs.Release()
+	assert.Equal(t, `["a" "b" "c" (null)]`, strs.String())
+
+	lb := array.NewListBuilder(mem, arrow.PrimitiveTypes.Int32)
+	defer lb.Release()
+	assert.NoError(t, array.AppendSlice(lb, [][]int32{{1, 2}, nil, {}}))
+	lists := lb.NewListArray()
+	defer lists.Release()
+	assert.Equal(t, "[[1 2] (null) []]", lists.String())
+
+	mb := array.NewMapBuilder(mem, arrow.BinaryTypes.String, arrow.PrimitiveTypes.Float64, false)
+	defer mb.Release()
+	assert.NoError(t, array.AppendSlice(mb, []map[string]float64{{"b": 2, "a": 1}, nil}))
+	maps := mb.NewMapArray()
+	defer maps.Release()
+	assert.Equal(t, `[{"a": 1, "b": 2} (null)]`, maps.String())
+
+	dt := arrow.StructOf(
+		arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Int16},
+		arrow.Field{Name: "y", Type: arrow.BinaryTypes.String, Nullable: true},
+	)
+	stb := array.NewStructBuilder(mem, dt)
+	defer stb.Release()
+	assert.NoError(t, array.AppendSlice(stb, []map[string]interface{}{
+		{"x": 1, "y": "a"},
+		{"x": 2},
+		nil,
+	}))
+	structs := stb.NewStructArray()
+	defer structs.Release()
+	assert.Equal(t, `[{x: 1, y: "a"} {x: 2, y: (null)} (null)]`, structs.String())
+
+	when := time.Date(1969, 12, 31, 12, 0, 0, 0, time.UTC)
+	db := array.NewDate32Builder(mem)
+	defer db.Release()
+	assert.NoError(t, array.AppendSlice(db, []time.Time{when, when.Add(24 * time.Hour)}))
+	dates := db.NewDate32Array()
+	defer dates.Release()
+	assert.Equal(t, []arrow.Date32{-1, 0}, dates.Date32Values())
+
+	tb := array.NewTime32Builder(mem, &arrow.Time32Type{Unit: arrow.Millisecond})
+	defer tb.Release()
+	assert.NoError(t, array.AppendSlice(tb, []time.Duration{1500 * time.Millisecond}))
+	times := tb.NewTime32Array()
+	defer times.Release()
+	assert.Equal(t, []arrow.Time32{1500}, times.Time32Values())
+
+	ub := array.NewDurationBuilder(mem, &arrow.DurationType{Unit: arrow.Microsecond})
+	defer ub.Release()
+	assert.NoError(t, array.AppendSlice(ub, []time.Duration{1500 * time.Millisecond}))
+	durations := ub.NewDurationArray()
+	defer durations.Release()
+	assert.Equal(t, []arrow.Duration{1500000}, durations.DurationValues())
+}
+
+func TestAppendSliceErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewInt32Builder(mem)
+	defer b.Release()
+
+	assert.EqualError(t, array.AppendSlice(b, 42), "arrow/array: int is not a slice")
+	assert.EqualError(t, array.AppendSlice(b, []interface{}{1, "a"}), "arrow/array: element 1: can not append string to *array.Int32Builder")
+	assert.Equal(t, 1, b.Len())
+}
@@ -0,0 +1,105 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"context"
+	"sync"
+)
+
+// RecordStream reads the records of a RecordReader in a separate goroutine
+// and delivers them on a channel.
+//
+// The channel is buffered: the stream reads ahead at most the capacity of the
+// channel, and then waits for the records to be received, so that a slow
+// consumer slows down the reading.
+type RecordStream struct {
+	ch     chan Record
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+	err    error
+}
+
+// NewRecordStream starts reading the records of rr, holding at most n
+// records that have not been received yet. The stream ends when rr is
+// exhausted, when ctx is done or when the stream is closed.
+//
+// The stream retains rr until it ends.
+func NewRecordStream(ctx context.Context, rr RecordReader, n int) *RecordStream {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &RecordStream{
+		ch:     make(chan Record, n),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	rr.Retain()
+	go func() {
+		defer close(s.done)
+		defer close(s.ch)
+		defer rr.Release()
+		s.err = s.run(ctx, rr)
+	}()
+	return s
+}
+
+func (s *RecordStream) run(ctx context.Context, rr RecordReader) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !rr.Next() {
+			break
+		}
+
+		rec := rr.Record()
+		rec.Retain()
+		select {
+		case s.ch <- rec:
+		case <-ctx.Done():
+			rec.Release()
+			return ctx.Err()
+		}
+	}
+	return readerErr(rr)
+}
+
+// Records returns the channel of the records of the stream, which is closed
+// when the stream ends. The received records must be Release()'d after use.
+func (s *RecordStream) Records() <-chan Record { return s.ch }
+
+// Err waits for the stream to end and returns the error that ended it: the
+// error of the context, or the error returned by the Err method of the
+// reader, if it has one. Err returns nil if the reader was exhausted.
+func (s *RecordStream) Err() error {
+	<-s.done
+	return s.err
+}
+
+// Close stops the stream, releases the records that have not been received
+// and waits for the reading goroutine to return.
+// Close may be called multiple times.
+func (s *RecordStream) Close() {
+	s.once.Do(func() {
+		s.cancel()
+		for rec := range s.ch {
+			rec.Release()
+		}
+		<-s.done
+	})
+}
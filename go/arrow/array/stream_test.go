@@ -0,0 +1,102 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func newStreamRecords(t *testing.T, mem memory.Allocator, n int) (*arrow.Schema, []array.Record) {
+	t.Helper()
+	schema := arrow.NewSchema([]arrow.Field{{Name: "i64", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	recs := make([]array.Record, n)
+	for i := range recs {
+		b := array.NewInt64Builder(mem)
+		b.AppendValues([]int64{int64(i)}, nil)
+		col := b.NewArray()
+		b.Release()
+		recs[i] = array.NewRecord(schema, []array.Interface{col}, 1)
+		col.Release()
+	}
+	return schema, recs
+}
+
+func TestRecordStream(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema, recs := newStreamRecords(t, mem, 5)
+	itr, err := array.NewRecordReader(schema, recs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rec := range recs {
+		rec.Release()
+	}
+
+	s := array.NewRecordStream(context.Background(), itr, 2)
+	itr.Release()
+	defer s.Close()
+
+	n := 0
+	for rec := range s.Records() {
+		if got, want := rec.Column(0).(*array.Int64).Value(0), int64(n); got != want {
+			t.Fatalf("invalid record %d: got=%d, want=%d", n, got, want)
+		}
+		rec.Release()
+		n++
+	}
+	if got, want := n, 5; got != want {
+		t.Fatalf("invalid number of records: got=%d, want=%d", got, want)
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRecordStreamCancel(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema, recs := newStreamRecords(t, mem, 5)
+	itr, err := array.NewRecordReader(schema, recs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rec := range recs {
+		rec.Release()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := array.NewRecordStream(ctx, itr, 0)
+	itr.Release()
+
+	rec := <-s.Records()
+	rec.Release()
+	cancel()
+
+	if got, want := s.Err(), context.Canceled; got != want {
+		t.Fatalf("invalid error: got=%v, want=%v", got, want)
+	}
+	s.Close()
+	s.Close()
+}
@@ -46,7 +46,10 @@ func NewStringData(data *Data) *String {
 }
 
 // Value returns the slice at index i. This value should not be mutated.
-func (a *String) Value(i int) string { return a.values[a.offsets[i]:a.offsets[i+1]] }
+func (a *String) Value(i int) string {
+	i = i + a.array.data.offset
+	return a.values[a.offsets[i]:a.offsets[i+1]]
+}
 
 func (a *String) String() string {
 	o := new(strings.Builder)
@@ -126,6 +129,11 @@ func (b *StringBuilder) AppendNull() {
 	b.builder.AppendNull()
 }
 
+// AppendNulls appends n null values to the builder.
+func (b *StringBuilder) AppendNulls(n int) {
+	b.builder.AppendNulls(n)
+}
+
 // AppendValues will append the values in the v slice. The valid slice determines which values
 // in v are valid (not null). The valid slice must either be empty or be equal in length to v. If empty,
 // all values in v are appended and considered valid.
@@ -94,4 +94,15 @@ func TestStringArray(t *testing.T) {
 	if got, want := arr.String(), `["hello" "世界" (null) "bye"]`; got != want {
 		t.Fatalf("got=%q, want=%q", got, want)
 	}
+
+	slice := array.NewSlice(arr, 1, 4).(*array.String)
+	defer slice.Release()
+
+	if got, want := slice.Value(0), want[1]; got != want {
+		t.Fatalf("invalid slice value: got=%q, want=%q", got, want)
+	}
+
+	if got, want := slice.String(), `["世界" (null) "bye"]`; got != want {
+		t.Fatalf("got=%q, want=%q", got, want)
+	}
 }
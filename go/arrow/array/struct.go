@@ -17,8 +17,6 @@
 package array
 
 import (
-	"fmt"
-	"strings"
 	"sync/atomic"
 
 	"github.com/apache/arrow/go/arrow"
@@ -44,36 +42,35 @@ func NewStructData(data *Data) *Struct {
 func (a *Struct) NumField() int         { return len(a.fields) }
 func (a *Struct) Field(i int) Interface { return a.fields[i] }
 
-func (a *Struct) String() string {
-	o := new(strings.Builder)
-	o.WriteString("[")
-	for i, v := range a.fields {
-		if i > 0 {
-			o.WriteString(" ")
-		}
-		switch {
-		case a.IsNull(i):
-			o.WriteString("(null)")
-		default:
-			fmt.Fprintf(o, "%v", v)
-		}
-	}
-	o.WriteString("]")
-	return o.String()
-}
+func (a *Struct) String() string { return formatString(a) }
 
 func (a *Struct) setData(data *Data) {
 	a.array.setData(data)
 	a.fields = make([]Interface, len(data.childData))
 	for i, child := range data.childData {
-		a.fields[i] = MakeFromData(child)
+		if data.offset == 0 && child.length == data.length {
+			a.fields[i] = MakeFromData(child)
+			continue
+		}
+		// fields are exposed with the same window as their parent.
+		sub := NewSliceData(child, int64(data.offset), int64(data.offset+data.length))
+		a.fields[i] = MakeFromData(sub)
+		sub.Release()
 	}
 }
 
+// Release decreases the reference count by 1.
+// Release may be called simultaneously from multiple goroutines.
+// When the reference count goes to zero, the memory is freed.
 func (a *Struct) Release() {
-	a.array.Release()
-	for _, f := range a.fields {
-		f.Release()
+	debug.Assert(atomic.LoadInt64(&a.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.data.Release()
+		for _, f := range a.fields {
+			f.Release()
+		}
+		a.data, a.nullBitmapBytes, a.fields = nil, nil, nil
 	}
 }
 
@@ -92,7 +89,7 @@ func NewStructBuilder(mem memory.Allocator, dtype *arrow.StructType) *StructBuil
 		fields:  make([]Builder, len(dtype.Fields())),
 	}
 	for i, f := range dtype.Fields() {
-		b.fields[i] = newBuilder(b.mem, f.Type)
+		b.fields[i] = NewBuilder(b.mem, f.Type)
 	}
 	return b
 }
@@ -107,10 +104,9 @@ func (b *StructBuilder) Release() {
 			b.nullBitmap.Release()
 			b.nullBitmap = nil
 		}
-	}
-
-	for _, f := range b.fields {
-		f.Release()
+		for _, f := range b.fields {
+			f.Release()
+		}
 	}
 }
 
@@ -131,6 +127,15 @@ func (b *StructBuilder) AppendValues(valids []bool) {
 
 func (b *StructBuilder) AppendNull() { b.Append(false) }
 
+// AppendNulls appends n null structs, and a null value to each field.
+func (b *StructBuilder) AppendNulls(n int) {
+	b.Reserve(n)
+	b.unsafeAppendNulls(n)
+	for _, f := range b.fields {
+		f.AppendNulls(n)
+	}
+}
+
 func (b *StructBuilder) unsafeAppend(v bool) {
 	bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
 	b.length++
@@ -170,7 +175,7 @@ func (b *StructBuilder) Resize(n int) {
 	} else {
 		b.builder.resize(n, b.builder.init)
 		for _, f := range b.fields {
-			f.resize(n, f.init)
+			f.Resize(n)
 		}
 	}
 }
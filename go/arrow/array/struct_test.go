@@ -247,3 +247,33 @@ func TestStructArrayBulkAppend(t *testing.T) {
 		}
 	}
 }
+
+func TestStructArrayGrow(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	dtype := arrow.StructOf(arrow.Field{Name: "i32", Type: arrow.PrimitiveTypes.Int32})
+	sb := array.NewStructBuilder(pool, dtype)
+	defer sb.Release()
+	fb := sb.FieldBuilder(0).(*array.Int32Builder)
+
+	// appending past the initial capacity grows the fields.
+	const n = 100
+	for i := 0; i < n; i++ {
+		sb.Append(true)
+		fb.Append(int32(i))
+	}
+
+	arr := sb.NewArray().(*array.Struct)
+	defer arr.Release()
+
+	if got, want := arr.Len(), n; got != want {
+		t.Fatalf("got=%d, want=%d", got, want)
+	}
+	vs := arr.Field(0).(*array.Int32).Int32Values()
+	for i := 0; i < n; i++ {
+		if got, want := vs[i], int32(i); got != want {
+			t.Fatalf("got[%d]=%d, want[%d]=%d", i, got, i, want)
+		}
+	}
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/apache/arrow/go/arrow"
 	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
 )
 
 // Table represents a logical sequence of chunked arrays.
@@ -200,6 +201,156 @@ func (a *Chunked) NewSlice(i, j int64) *Chunked {
 	return NewChunked(a.dtype, chunks)
 }
 
+// Rechunk returns a chunked array holding the same values, split into chunks
+// of size values, the last one possibly being shorter. The chunks consisting
+// of a slice of a single chunk of a are zero-copy slices; the others are
+// concatenated with mem.
+// The returned chunked array must be Release()'d after use.
+//
+// Rechunk panics if size is not positive.
+func (a *Chunked) Rechunk(mem memory.Allocator, size int) (*Chunked, error) {
+	if size <= 0 {
+		panic(fmt.Errorf("arrow/array: invalid chunk size %d", size))
+	}
+
+	chunks := make([]Interface, 0, (a.length+size-1)/size)
+	defer func() {
+		for _, chunk := range chunks {
+			chunk.Release()
+		}
+	}()
+
+	for beg := 0; beg < a.length; beg += size {
+		end := beg + size
+		if end > a.length {
+			end = a.length
+		}
+		chunk, err := a.combine(mem, int64(beg), int64(end))
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, chunk)
+	}
+	return NewChunked(a.dtype, chunks), nil
+}
+
+// Combine returns a chunked array holding the same values in a single chunk
+// allocated with mem, or in no chunk if it is empty. If a already has a
+// single chunk, it is not copied.
+// The returned chunked array must be Release()'d after use.
+func (a *Chunked) Combine(mem memory.Allocator) (*Chunked, error) {
+	if a.length == 0 {
+		return NewChunked(a.dtype, nil), nil
+	}
+	return a.Rechunk(mem, a.length)
+}
+
+// combine returns the values in [i, j) as a single array.
+func (a *Chunked) combine(mem memory.Allocator, i, j int64) (Interface, error) {
+	slice := a.NewSlice(i, j)
+	defer slice.Release()
+
+	if len(slice.chunks) == 1 {
+		chunk := slice.chunks[0]
+		chunk.Retain()
+		return chunk, nil
+	}
+	return Concatenate(mem, slice.chunks...)
+}
+
+// RechunkTable returns a table holding the rows of tbl, with all its
+// columns split into chunks of size rows, as Chunked.Rechunk does.
+// It is typically used to consolidate the many small chunks of a table
+// built from a stream of records.
+// The returned table must be Release()'d after use.
+//
+// RechunkTable panics if size is not positive.
+func RechunkTable(mem memory.Allocator, tbl Table, size int64) (Table, error) {
+	if size <= 0 {
+		panic(fmt.Errorf("arrow/array: invalid chunk size %d", size))
+	}
+	return rechunkTable(tbl, func(data *Chunked) (*Chunked, error) {
+		return data.Rechunk(mem, int(size))
+	})
+}
+
+// NewTableSlice returns a zero-copy slice of tbl holding its rows i to j,
+// corresponding to tbl[i:j].
+// The returned table must be Release()'d after use.
+//
+// NewTableSlice panics if the slice is outside the valid range of the table.
+// NewTableSlice panics if j < i.
+func NewTableSlice(tbl Table, i, j int64) Table {
+	if i < 0 || j < i || j > tbl.NumRows() {
+		panic(fmt.Errorf("arrow/array: table slice [%d:%d] out of range [0:%d]", i, j, tbl.NumRows()))
+	}
+	cols := make([]Column, tbl.NumCols())
+	for k := range cols {
+		cols[k] = *tbl.Column(k).NewSlice(i, j)
+	}
+	defer func() {
+		for k := range cols {
+			cols[k].Release()
+		}
+	}()
+	return NewTable(tbl.Schema(), cols, j-i)
+}
+
+// HeadTable returns a zero-copy slice of the first n rows of tbl, or of all
+// its rows if it has fewer.
+// The returned table must be Release()'d after use.
+//
+// HeadTable panics if n is negative.
+func HeadTable(tbl Table, n int64) Table {
+	i, j := headRange(tbl.NumRows(), n)
+	return NewTableSlice(tbl, i, j)
+}
+
+// TailTable returns a zero-copy slice of the last n rows of tbl, or of all
+// its rows if it has fewer.
+// The returned table must be Release()'d after use.
+//
+// TailTable panics if n is negative.
+func TailTable(tbl Table, n int64) Table {
+	i, j := tailRange(tbl.NumRows(), n)
+	return NewTableSlice(tbl, i, j)
+}
+
+// CombineTableChunks returns a table holding the rows of tbl, each column of
+// which has a single chunk (or none, for an empty table), allocated with mem.
+// Columns already made of a single chunk are not copied.
+// The returned table must be Release()'d after use.
+func CombineTableChunks(mem memory.Allocator, tbl Table) (Table, error) {
+	return rechunkTable(tbl, func(data *Chunked) (*Chunked, error) {
+		return data.Combine(mem)
+	})
+}
+
+// rechunkTable returns a table of the rows of tbl, the chunks of its columns
+// being given by rechunk.
+func rechunkTable(tbl Table, rechunk func(*Chunked) (*Chunked, error)) (Table, error) {
+	cols := make([]Column, tbl.NumCols())
+	defer func() {
+		for i := range cols {
+			if cols[i].data != nil {
+				cols[i].Release()
+			}
+		}
+	}()
+
+	for i := range cols {
+		col := tbl.Column(i)
+		data := col.data.NewSlice(0, tbl.NumRows())
+		chunks, err := rechunk(data)
+		data.Release()
+		if err != nil {
+			return nil, err
+		}
+		cols[i] = Column{field: col.field, data: chunks}
+	}
+	return NewTable(tbl.Schema(), cols, tbl.NumRows()), nil
+}
+
 // simpleTable is a basic, non-lazy in-memory table.
 type simpleTable struct {
 	refCount int64
@@ -277,6 +428,32 @@ func (tbl *simpleTable) NumRows() int64        { return tbl.rows }
 func (tbl *simpleTable) NumCols() int64        { return int64(len(tbl.cols)) }
 func (tbl *simpleTable) Column(i int) *Column  { return &tbl.cols[i] }
 
+// SelectTable returns a zero-copy view of tbl holding only the named
+// columns, in the order given.
+// The returned table must be Release()'d after use.
+//
+// SelectTable returns an error if a name does not match any column.
+func SelectTable(tbl Table, names ...string) (Table, error) {
+	indices, err := fieldIndices(tbl.Schema(), names)
+	if err != nil {
+		return nil, err
+	}
+	return ProjectTable(tbl, indices...), nil
+}
+
+// ProjectTable returns a zero-copy view of tbl holding only the columns at
+// the given indices, in the order given.
+// The returned table must be Release()'d after use.
+//
+// ProjectTable panics if an index is outside the valid range of columns.
+func ProjectTable(tbl Table, indices ...int) Table {
+	cols := make([]Column, len(indices))
+	for i, idx := range indices {
+		cols[i] = *tbl.Column(idx)
+	}
+	return NewTable(projectSchema(tbl.Schema(), indices), cols, tbl.NumRows())
+}
+
 func (tbl *simpleTable) validate() {
 	if len(tbl.cols) != len(tbl.schema.Fields()) {
 		panic(errors.New("arrow/array: table schema mismatch"))
@@ -327,21 +504,73 @@ type TableReader struct {
 	offsets []int64 // chunk offsets
 }
 
+// TableReaderOption configures a TableReader.
+type TableReaderOption func(*tableReaderConfig)
+
+type tableReaderConfig struct {
+	offset  int64
+	n       int64
+	indices []int
+}
+
+// WithRowRange restricts a TableReader to the n rows of the table starting
+// at row offset. If n is negative, or if there are fewer than n rows after
+// offset, the reader iterates up to the end of the table.
+func WithRowRange(offset, n int64) TableReaderOption {
+	return func(cfg *tableReaderConfig) {
+		cfg.offset = offset
+		cfg.n = n
+	}
+}
+
+// WithProjection restricts a TableReader to the columns of the table at
+// the given indices, in the order given, as ProjectTable does.
+func WithProjection(indices ...int) TableReaderOption {
+	return func(cfg *tableReaderConfig) { cfg.indices = indices }
+}
+
 // NewTableReader returns a new TableReader to iterate over the (possibly chunked) Table.
 // if chunkSize is <= 0, the biggest possible chunk will be selected.
-func NewTableReader(tbl Table, chunkSize int64) *TableReader {
+//
+// The records yielded by the reader are zero-copy slices of the table's
+// chunks, including when the reader is restricted with WithRowRange or
+// WithProjection.
+//
+// NewTableReader panics if the offset given to WithRowRange is outside
+// the range [0, tbl.NumRows()], or if an index given to WithProjection is
+// outside the valid range of columns.
+func NewTableReader(tbl Table, chunkSize int64, opts ...TableReaderOption) *TableReader {
+	cfg := tableReaderConfig{n: -1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	if cfg.indices != nil {
+		tbl = ProjectTable(tbl, cfg.indices...)
+	} else {
+		tbl.Retain()
+	}
+
+	rows := tbl.NumRows()
+	if cfg.offset < 0 || cfg.offset > rows {
+		tbl.Release()
+		panic(fmt.Errorf("arrow/array: table reader offset %d out of range [0, %d]", cfg.offset, rows))
+	}
+
 	ncols := tbl.NumCols()
 	tr := &TableReader{
 		refCount: 1,
 		tbl:      tbl,
-		cur:      0,
-		max:      int64(tbl.NumRows()),
+		cur:      cfg.offset,
+		max:      rows,
 		chksz:    chunkSize,
 		chunks:   make([]*Chunked, ncols),
 		slots:    make([]int, ncols),
 		offsets:  make([]int64, ncols),
 	}
-	tr.tbl.Retain()
+	if cfg.n >= 0 && cfg.n < rows-cfg.offset {
+		tr.max = cfg.offset + cfg.n
+	}
 
 	if tr.chksz <= 0 {
 		tr.chksz = math.MaxInt64
@@ -351,6 +580,18 @@ func NewTableReader(tbl Table, chunkSize int64) *TableReader {
 		col := tr.tbl.Column(i)
 		tr.chunks[i] = col.Data()
 		tr.chunks[i].Retain()
+
+		// position the column on the chunk holding the first row.
+		off := cfg.offset
+		for j, chunk := range tr.chunks[i].Chunks() {
+			if n := int64(chunk.Len()); off >= n && off > 0 {
+				off -= n
+				continue
+			}
+			tr.slots[i] = j
+			break
+		}
+		tr.offsets[i] = off
 	}
 	return tr
 }
@@ -368,7 +609,7 @@ func (tr *TableReader) Next() bool {
 	}
 
 	// determine the minimum contiguous slice across all columns
-	chunksz := imin64(tr.max, tr.chksz)
+	chunksz := imin64(tr.max-tr.cur, tr.chksz)
 	chunks := make([]Interface, len(tr.chunks))
 	for i := range chunks {
 		j := tr.slots[i]
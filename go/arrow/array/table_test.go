@@ -723,3 +723,412 @@ func TestTableReader(t *testing.T) {
 		})
 	}
 }
+
+func TestTableSelect(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			arrow.Field{Name: "f1-i32", Type: arrow.PrimitiveTypes.Int32},
+			arrow.Field{Name: "f2-f64", Type: arrow.PrimitiveTypes.Float64},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2, 3}, nil)
+	b.Field(1).(*array.Float64Builder).AppendValues([]float64{1, 2, 3}, nil)
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	tbl := array.NewTableFromRecords(schema, []array.Record{rec, rec})
+	defer tbl.Release()
+
+	sub, err := array.SelectTable(tbl, "f2-f64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Release()
+
+	if got, want := sub.NumCols(), int64(1); got != want {
+		t.Fatalf("invalid number of columns: got=%d, want=%d", got, want)
+	}
+	if got, want := sub.NumRows(), int64(6); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+	if got, want := sub.Column(0).Name(), "f2-f64"; got != want {
+		t.Fatalf("invalid column name: got=%q, want=%q", got, want)
+	}
+	if sub.Column(0).Data() != tbl.Column(1).Data() {
+		t.Fatalf("selected columns should share the table chunks")
+	}
+
+	proj := array.ProjectTable(tbl, 1, 0)
+	defer proj.Release()
+	if got, want := proj.Schema().Field(1).Name, "f1-i32"; got != want {
+		t.Fatalf("invalid column name: got=%q, want=%q", got, want)
+	}
+
+	_, err = array.SelectTable(tbl, "not-there")
+	if err == nil {
+		t.Fatalf("expected an error selecting an unknown column")
+	}
+}
+
+func TestTableReaderRange(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			arrow.Field{Name: "f1-i32", Type: arrow.PrimitiveTypes.Int32},
+			arrow.Field{Name: "f2-f64", Type: arrow.PrimitiveTypes.Float64},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	// 3 records of 4 rows: f1 holds the row number, f2 its tenth.
+	var recs []array.Record
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 4; j++ {
+			b.Field(0).(*array.Int32Builder).Append(int32(4*i + j))
+			b.Field(1).(*array.Float64Builder).Append(float64(4*i+j) / 10)
+		}
+		rec := b.NewRecord()
+		defer rec.Release()
+		recs = append(recs, rec)
+	}
+
+	tbl := array.NewTableFromRecords(schema, recs)
+	defer tbl.Release()
+
+	for _, tc := range []struct {
+		name   string
+		sz     int64
+		opts   []array.TableReaderOption
+		rows   []int64
+		first  int32
+		ncols  int
+		column string
+	}{
+		{
+			name:   "all",
+			sz:     -1,
+			rows:   []int64{4, 4, 4},
+			ncols:  2,
+			column: "f1-i32",
+		},
+		{
+			name:   "offset",
+			sz:     -1,
+			opts:   []array.TableReaderOption{array.WithRowRange(5, -1)},
+			rows:   []int64{3, 4},
+			first:  5,
+			ncols:  2,
+			column: "f1-i32",
+		},
+		{
+			name:   "page",
+			sz:     2,
+			opts:   []array.TableReaderOption{array.WithRowRange(3, 6)},
+			rows:   []int64{1, 2, 2, 1},
+			first:  3,
+			ncols:  2,
+			column: "f1-i32",
+		},
+		{
+			name:   "chunk-boundary",
+			sz:     -1,
+			opts:   []array.TableReaderOption{array.WithRowRange(4, 4)},
+			rows:   []int64{4},
+			first:  4,
+			ncols:  2,
+			column: "f1-i32",
+		},
+		{
+			name:   "past-the-end",
+			sz:     -1,
+			opts:   []array.TableReaderOption{array.WithRowRange(10, 100)},
+			rows:   []int64{2},
+			first:  10,
+			ncols:  2,
+			column: "f1-i32",
+		},
+		{
+			name:  "empty",
+			sz:    -1,
+			opts:  []array.TableReaderOption{array.WithRowRange(12, 3)},
+			ncols: 2,
+		},
+		{
+			name: "projection",
+			sz:   -1,
+			opts: []array.TableReaderOption{
+				array.WithRowRange(1, 2),
+				array.WithProjection(1),
+			},
+			rows:   []int64{2},
+			first:  1,
+			ncols:  1,
+			column: "f2-f64",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tr := array.NewTableReader(tbl, tc.sz, tc.opts...)
+			defer tr.Release()
+
+			if got, want := len(tr.Schema().Fields()), tc.ncols; got != want {
+				t.Fatalf("invalid number of fields: got=%d, want=%d", got, want)
+			}
+
+			var (
+				n    int
+				next = tc.first
+			)
+			for tr.Next() {
+				rec := tr.Record()
+				if n >= len(tc.rows) {
+					t.Fatalf("too many records: got=%d, want=%d", n+1, len(tc.rows))
+				}
+				if got, want := rec.NumRows(), tc.rows[n]; got != want {
+					t.Fatalf("invalid number of rows[%d]: got=%d, want=%d", n, got, want)
+				}
+				if got, want := int(rec.NumCols()), tc.ncols; got != want {
+					t.Fatalf("invalid number of columns[%d]: got=%d, want=%d", n, got, want)
+				}
+				if got, want := rec.ColumnName(0), tc.column; got != want {
+					t.Fatalf("invalid column name[%d]: got=%q, want=%q", n, got, want)
+				}
+				for i := 0; i < int(rec.NumRows()); i++ {
+					var got int32
+					switch col := rec.Column(0).(type) {
+					case *array.Int32:
+						got = col.Value(i)
+					case *array.Float64:
+						got = int32(col.Value(i)*10 + 0.5)
+					}
+					if got != next {
+						t.Fatalf("invalid value[%d][%d]: got=%d, want=%d", n, i, got, next)
+					}
+					next++
+				}
+				n++
+			}
+			if got, want := n, len(tc.rows); got != want {
+				t.Fatalf("invalid number of records: got=%d, want=%d", got, want)
+			}
+		})
+	}
+
+	t.Run("zero-copy", func(t *testing.T) {
+		tr := array.NewTableReader(tbl, -1, array.WithRowRange(5, 2))
+		defer tr.Release()
+
+		if !tr.Next() {
+			t.Fatalf("expected a record")
+		}
+		got := tr.Record().Column(0).Data().Buffers()[1]
+		want := recs[1].Column(0).Data().Buffers()[1]
+		if got != want {
+			t.Fatalf("records should share the table buffers")
+		}
+	})
+
+	for _, offset := range []int64{-1, 13} {
+		t.Run(fmt.Sprintf("invalid-offset=%d", offset), func(t *testing.T) {
+			defer func() {
+				if e := recover(); e == nil {
+					t.Fatalf("expected a panic")
+				}
+			}()
+			tr := array.NewTableReader(tbl, -1, array.WithRowRange(offset, 1))
+			tr.Release()
+		})
+	}
+}
+
+func TestTableRechunk(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			arrow.Field{Name: "f1-i32", Type: arrow.PrimitiveTypes.Int32},
+			arrow.Field{Name: "f2-str", Type: arrow.BinaryTypes.String, Nullable: true},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	var recs []array.Record
+	for i := 0; i < 5; i++ {
+		b.Field(0).(*array.Int32Builder).AppendValues([]int32{int32(2 * i), int32(2*i + 1)}, nil)
+		b.Field(1).(*array.StringBuilder).AppendValues([]string{fmt.Sprint(i), ""}, []bool{true, false})
+		rec := b.NewRecord()
+		defer rec.Release()
+		recs = append(recs, rec)
+	}
+
+	tbl := array.NewTableFromRecords(schema, recs)
+	defer tbl.Release()
+
+	chunkLens := func(tbl array.Table, i int) []int {
+		var lens []int
+		for _, chunk := range tbl.Column(i).Data().Chunks() {
+			lens = append(lens, chunk.Len())
+		}
+		return lens
+	}
+
+	combined, err := array.CombineTableChunks(mem, tbl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer combined.Release()
+
+	if got, want := combined.NumRows(), int64(10); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+	for i := 0; i < 2; i++ {
+		if got, want := chunkLens(combined, i), []int{10}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("invalid chunks for column %d: got=%v, want=%v", i, got, want)
+		}
+	}
+	if got, want := combined.Column(0).Data().Chunk(0).(*array.Int32).Int32Values(), []int32{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid values: got=%v, want=%v", got, want)
+	}
+	if got, want := combined.Column(1).NullN(), 5; got != want {
+		t.Fatalf("invalid null count: got=%d, want=%d", got, want)
+	}
+
+	// a column of a single chunk is not copied.
+	again, err := array.CombineTableChunks(mem, combined)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer again.Release()
+	if again.Column(0).Data().Chunk(0).Data().Buffers()[1] != combined.Column(0).Data().Chunk(0).Data().Buffers()[1] {
+		t.Fatalf("single chunks should be shared")
+	}
+
+	re, err := array.RechunkTable(mem, tbl, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer re.Release()
+
+	if got, want := chunkLens(re, 1), []int{4, 4, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid chunks: got=%v, want=%v", got, want)
+	}
+	if got, want := re.Column(1).Data().Chunk(2).(*array.String).Value(0), "4"; got != want {
+		t.Fatalf("invalid value: got=%q, want=%q", got, want)
+	}
+
+	// chunks are split without copies when they are larger than the target.
+	split, err := array.RechunkTable(mem, combined, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer split.Release()
+	if got, want := chunkLens(split, 0), []int{3, 3, 3, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid chunks: got=%v, want=%v", got, want)
+	}
+
+	// only the rows of the table are kept.
+	short := array.NewTable(schema, []array.Column{*tbl.Column(0), *tbl.Column(1)}, 3)
+	defer short.Release()
+	shortc, err := array.CombineTableChunks(mem, short)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shortc.Release()
+	if got, want := chunkLens(shortc, 0), []int{3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid chunks: got=%v, want=%v", got, want)
+	}
+
+	empty := array.NewTableFromRecords(schema, nil)
+	defer empty.Release()
+	emptyc, err := array.CombineTableChunks(mem, empty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer emptyc.Release()
+	if got := chunkLens(emptyc, 0); len(got) != 0 {
+		t.Fatalf("invalid chunks for an empty table: %v", got)
+	}
+}
+
+func TestTableHeadTail(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "i32", Type: arrow.PrimitiveTypes.Int32}}, nil)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	var recs []array.Record
+	for i := 0; i < 3; i++ {
+		b.Field(0).(*array.Int32Builder).AppendValues([]int32{int32(2 * i), int32(2*i + 1)}, nil)
+		rec := b.NewRecord()
+		defer rec.Release()
+		recs = append(recs, rec)
+	}
+
+	tbl := array.NewTableFromRecords(schema, recs)
+	defer tbl.Release()
+
+	values := func(tbl array.Table) []int32 {
+		var vs []int32
+		for _, chunk := range tbl.Column(0).Data().Chunks() {
+			vs = append(vs, chunk.(*array.Int32).Int32Values()...)
+		}
+		return vs
+	}
+
+	for _, tc := range []struct {
+		name string
+		f    func() array.Table
+		want []int32
+	}{
+		{"head", func() array.Table { return array.HeadTable(tbl, 3) }, []int32{0, 1, 2}},
+		{"head-all", func() array.Table { return array.HeadTable(tbl, 10) }, []int32{0, 1, 2, 3, 4, 5}},
+		{"head-none", func() array.Table { return array.HeadTable(tbl, 0) }, nil},
+		{"tail", func() array.Table { return array.TailTable(tbl, 3) }, []int32{3, 4, 5}},
+		{"tail-all", func() array.Table { return array.TailTable(tbl, 10) }, []int32{0, 1, 2, 3, 4, 5}},
+		{"slice", func() array.Table { return array.NewTableSlice(tbl, 1, 5) }, []int32{1, 2, 3, 4}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.f()
+			defer got.Release()
+			if got, want := got.NumRows(), int64(len(tc.want)); got != want {
+				t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+			}
+			if got := values(got); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid values: got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+
+	for _, f := range []func(){
+		func() { array.HeadTable(tbl, -1) },
+		func() { array.NewTableSlice(tbl, 2, 7) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected a panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
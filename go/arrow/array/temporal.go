@@ -0,0 +1,66 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+)
+
+// Time returns the i-th value as a time, in the time zone of the array's type
+// (UTC if it is time zone neutral).
+//
+// Time panics if the time zone of the type can not be loaded.
+func (a *Timestamp) Time(i int) time.Time {
+	dt := a.DataType().(*arrow.TimestampType)
+	loc, err := dt.Location()
+	if err != nil {
+		panic(fmt.Errorf("arrow/array: %w", err))
+	}
+	return a.values[i].ToTime(dt.Unit).In(loc)
+}
+
+// Times returns the values of the array as times, in the time zone of the
+// array's type. Null values are returned as the zero time.
+//
+// Times returns an error if the time zone of the type can not be loaded.
+func (a *Timestamp) Times() ([]time.Time, error) {
+	dt := a.DataType().(*arrow.TimestampType)
+	loc, err := dt.Location()
+	if err != nil {
+		return nil, fmt.Errorf("arrow/array: %w", err)
+	}
+	ts := make([]time.Time, a.Len())
+	for i, v := range a.values {
+		if a.IsValid(i) {
+			ts[i] = v.ToTime(dt.Unit).In(loc)
+		}
+	}
+	return ts, nil
+}
+
+// AppendTime appends t, rounded down to the unit of the builder's type.
+func (b *TimestampBuilder) AppendTime(t time.Time) {
+	b.Append(arrow.TimestampFromTime(t, b.dtype.Unit))
+}
+
+// Duration returns the i-th value as a time.Duration.
+func (a *Duration) Duration(i int) time.Duration {
+	return a.values[i].ToDuration(a.DataType().(*arrow.DurationType).Unit)
+}
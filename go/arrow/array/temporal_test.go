@@ -0,0 +1,86 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestampTime(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	dt := &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "Europe/Paris"}
+	b := array.NewTimestampBuilder(mem, dt)
+	defer b.Release()
+
+	when := time.Date(2021, 6, 1, 12, 30, 0, 1500, time.UTC)
+	b.AppendTime(when)
+	b.AppendNull()
+	b.Append(-1)
+
+	a := b.NewTimestampArray()
+	defer a.Release()
+
+	assert.Equal(t, arrow.Timestamp(1622550600000001), a.Value(0))
+	got := a.Time(0)
+	assert.Equal(t, paris, got.Location())
+	assert.True(t, when.Truncate(time.Microsecond).Equal(got), "got=%v", got)
+	assert.Equal(t, "2021-06-01T14:30:00.000001+02:00", got.Format(time.RFC3339Nano))
+
+	ts, err := a.Times()
+	assert.NoError(t, err)
+	assert.Len(t, ts, 3)
+	assert.True(t, ts[1].IsZero())
+	assert.True(t, time.Unix(0, -1000).Equal(ts[2]))
+
+	// an invalid time zone makes Time panic and Times fail.
+	bad := array.NewTimestampBuilder(mem, &arrow.TimestampType{Unit: arrow.Second, TimeZone: "Nowhere/Special"})
+	defer bad.Release()
+	bad.Append(0)
+	arr := bad.NewTimestampArray()
+	defer arr.Release()
+
+	assert.Panics(t, func() { arr.Time(0) })
+	_, err = arr.Times()
+	assert.Error(t, err)
+}
+
+func TestDurationValue(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewDurationBuilder(mem, arrow.FixedWidthTypes.Duration_us.(*arrow.DurationType))
+	defer b.Release()
+	b.Append(-2500)
+
+	a := b.NewDurationArray()
+	defer a.Release()
+
+	assert.Equal(t, -2500*time.Microsecond, a.Duration(0))
+}
@@ -0,0 +1,311 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/internal/bitutil"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// Union represents an immutable sequence of values, each of which is taken
+// from one of the union's children.
+//
+// In a sparse union, the value at slot i is the value at slot i of the
+// selected child. In a dense union, it is the value at ValueOffset(i) of the
+// selected child.
+type Union struct {
+	array
+	children  []Interface
+	typeCodes []int8
+	offsets   []int32
+}
+
+// NewUnionData returns a new Union array value, from data.
+func NewUnionData(data *Data) *Union {
+	a := &Union{}
+	a.refCount = 1
+	a.setData(data)
+	return a
+}
+
+// Mode returns the layout of the union's children.
+func (a *Union) Mode() arrow.UnionMode { return a.unionType().Mode() }
+
+func (a *Union) unionType() *arrow.UnionType { return a.data.dtype.(*arrow.UnionType) }
+
+// NumFields returns the number of children of the union.
+func (a *Union) NumFields() int { return len(a.children) }
+
+// Field returns the i-th child of the union.
+func (a *Union) Field(i int) Interface { return a.children[i] }
+
+// RawTypeCodes returns the type codes of the whole underlying buffer,
+// without taking the array offset into account.
+func (a *Union) RawTypeCodes() []int8 { return a.typeCodes }
+
+// RawValueOffsets returns the value offsets of the whole underlying buffer,
+// without taking the array offset into account. It is nil for sparse unions.
+func (a *Union) RawValueOffsets() []int32 { return a.offsets }
+
+// TypeCode returns the type code of the value at slot i.
+func (a *Union) TypeCode(i int) int8 { return a.typeCodes[a.data.offset+i] }
+
+// ChildID returns the index of the child holding the value at slot i.
+func (a *Union) ChildID(i int) int { return a.unionType().ChildID(a.TypeCode(i)) }
+
+// ValueOffset returns the index, within its child, of the value at slot i.
+func (a *Union) ValueOffset(i int) int {
+	if a.Mode() == arrow.DenseMode {
+		return int(a.offsets[a.data.offset+i])
+	}
+	return a.data.offset + i
+}
+
+func (a *Union) String() string { return formatString(a) }
+
+func (a *Union) setData(data *Data) {
+	a.array.setData(data)
+	if buf := data.buffers[1]; buf != nil {
+		a.typeCodes = arrow.Int8Traits.CastFromBytes(buf.Bytes())
+	}
+	if len(data.buffers) > 2 && data.buffers[2] != nil {
+		a.offsets = arrow.Int32Traits.CastFromBytes(data.buffers[2].Bytes())
+	}
+	a.children = make([]Interface, len(data.childData))
+	for i, child := range data.childData {
+		a.children[i] = MakeFromData(child)
+	}
+}
+
+// Release decreases the reference count by 1.
+// Release may be called simultaneously from multiple goroutines.
+// When the reference count goes to zero, the memory is freed.
+func (a *Union) Release() {
+	debug.Assert(atomic.LoadInt64(&a.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&a.refCount, -1) == 0 {
+		a.data.Release()
+		for _, c := range a.children {
+			c.Release()
+		}
+		a.data, a.nullBitmapBytes, a.children = nil, nil, nil
+	}
+}
+
+// UnionBuilder builds sparse or dense Union arrays.
+//
+// A value is appended by calling Append with its type code, then appending
+// the value itself to the corresponding child builder. For sparse unions,
+// Append fills the other children with nulls.
+type UnionBuilder struct {
+	builder
+
+	dtype    *arrow.UnionType
+	types    *Int8Builder
+	offsets  *Int32Builder // nil for sparse unions.
+	children []Builder
+}
+
+// NewUnionBuilder returns a builder, using the provided memory allocator.
+func NewUnionBuilder(mem memory.Allocator, dtype *arrow.UnionType) *UnionBuilder {
+	b := &UnionBuilder{
+		builder:  builder{refCount: 1, mem: mem},
+		dtype:    dtype,
+		types:    NewInt8Builder(mem),
+		children: make([]Builder, len(dtype.Fields())),
+	}
+	if dtype.Mode() == arrow.DenseMode {
+		b.offsets = NewInt32Builder(mem)
+	}
+	for i, f := range dtype.Fields() {
+		b.children[i] = NewBuilder(mem, f.Type)
+	}
+	return b
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+func (b *UnionBuilder) Release() {
+	debug.Assert(atomic.LoadInt64(&b.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&b.refCount, -1) == 0 {
+		if b.nullBitmap != nil {
+			b.nullBitmap.Release()
+			b.nullBitmap = nil
+		}
+		b.types.Release()
+		if b.offsets != nil {
+			b.offsets.Release()
+		}
+		for _, c := range b.children {
+			c.Release()
+		}
+	}
+}
+
+// Append adds a new slot holding a value of the child with type code code.
+// The value itself must then be appended to Child(ChildID(code)).
+//
+// Append panics if code is not a type code of the union.
+func (b *UnionBuilder) Append(code int8) {
+	id := b.dtype.ChildID(code)
+	if id < 0 {
+		panic("arrow/array: invalid union type code")
+	}
+
+	b.Reserve(1)
+	b.unsafeAppendBoolToBitmap(true)
+	b.appendSlot(code, id)
+}
+
+// AppendNull adds a new null slot to the union.
+// The slot is attributed to the first child, which receives a null value.
+func (b *UnionBuilder) AppendNull() {
+	b.Reserve(1)
+	b.unsafeAppendBoolToBitmap(false)
+	b.appendSlot(b.dtype.TypeCodes()[0], 0)
+	b.children[0].AppendNull()
+}
+
+// AppendNulls adds n null slots to the union.
+func (b *UnionBuilder) AppendNulls(n int) {
+	for i := 0; i < n; i++ {
+		b.AppendNull()
+	}
+}
+
+func (b *UnionBuilder) appendSlot(code int8, id int) {
+	b.types.Append(code)
+	if b.offsets != nil {
+		b.offsets.Append(int32(b.children[id].Len()))
+		return
+	}
+	for i, c := range b.children {
+		if i != id {
+			c.AppendNull()
+		}
+	}
+}
+
+func (b *UnionBuilder) unsafeAppendBoolToBitmap(isValid bool) {
+	if isValid {
+		bitutil.SetBit(b.nullBitmap.Bytes(), b.length)
+	} else {
+		b.nulls++
+	}
+	b.length++
+}
+
+// NumChildren returns the number of children of the union.
+func (b *UnionBuilder) NumChildren() int { return len(b.children) }
+
+// Child returns the builder of the i-th child of the union.
+func (b *UnionBuilder) Child(i int) Builder { return b.children[i] }
+
+// ChildID returns the index of the child with type code code,
+// or -1 if no child uses that code.
+func (b *UnionBuilder) ChildID(code int8) int { return b.dtype.ChildID(code) }
+
+func (b *UnionBuilder) init(capacity int) {
+	b.builder.init(capacity)
+	b.types.init(capacity)
+	if b.offsets != nil {
+		b.offsets.init(capacity)
+	}
+}
+
+// Reserve ensures there is enough space for appending n elements
+// by checking the capacity and calling Resize if necessary.
+func (b *UnionBuilder) Reserve(n int) {
+	b.builder.reserve(n, b.Resize)
+}
+
+// Resize adjusts the space allocated by b to n elements. If n is greater than b.Cap(),
+// additional memory will be allocated. If n is smaller, the allocated memory may reduced.
+func (b *UnionBuilder) Resize(n int) {
+	if n < minBuilderCapacity {
+		n = minBuilderCapacity
+	}
+
+	if b.capacity == 0 {
+		b.init(n)
+	} else {
+		b.builder.resize(n, b.builder.init)
+		b.types.Resize(n)
+		if b.offsets != nil {
+			b.offsets.Resize(n)
+		}
+	}
+}
+
+// NewArray creates a Union array from the memory buffers used by the builder and resets the UnionBuilder
+// so it can be used to build a new array.
+func (b *UnionBuilder) NewArray() Interface {
+	return b.NewUnionArray()
+}
+
+// NewUnionArray creates a Union array from the memory buffers used by the builder and resets the UnionBuilder
+// so it can be used to build a new array.
+func (b *UnionBuilder) NewUnionArray() (a *Union) {
+	data := b.newData()
+	a = NewUnionData(data)
+	data.Release()
+	return
+}
+
+func (b *UnionBuilder) newData() (data *Data) {
+	children := make([]*Data, len(b.children))
+	for i, c := range b.children {
+		arr := c.NewArray()
+		defer arr.Release()
+		children[i] = arr.Data()
+	}
+
+	types := b.types.NewInt8Array()
+	defer types.Release()
+
+	var offsets *memory.Buffer
+	if b.offsets != nil {
+		arr := b.offsets.NewInt32Array()
+		defer arr.Release()
+		offsets = arr.Data().buffers[1]
+	}
+
+	data = NewData(
+		b.dtype, b.length,
+		[]*memory.Buffer{
+			b.nullBitmap,
+			types.Data().buffers[1],
+			offsets,
+		},
+		children,
+		b.nulls,
+		0,
+	)
+	b.reset()
+
+	return
+}
+
+var (
+	_ Interface = (*Union)(nil)
+	_ Builder   = (*UnionBuilder)(nil)
+)
@@ -0,0 +1,119 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnionArray(t *testing.T) {
+	fields := []arrow.Field{
+		{Name: "i32", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+	}
+	codes := []int8{3, 7}
+
+	for _, mode := range []arrow.UnionMode{arrow.SparseMode, arrow.DenseMode} {
+		t.Run(mode.String(), func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			dtype := arrow.UnionOf(mode, fields, codes)
+			bld := array.NewBuilder(mem, dtype).(*array.UnionBuilder)
+			defer bld.Release()
+
+			ib := bld.Child(bld.ChildID(3)).(*array.Int32Builder)
+			sb := bld.Child(bld.ChildID(7)).(*array.StringBuilder)
+
+			bld.Append(3)
+			ib.Append(1)
+			bld.Append(7)
+			sb.Append("a")
+			bld.AppendNull()
+			bld.Append(3)
+			ib.Append(2)
+			bld.Append(7)
+			sb.Append("b")
+
+			assert.Equal(t, 5, bld.Len())
+			assert.Equal(t, 1, bld.NullN())
+
+			arr := bld.NewUnionArray()
+			defer arr.Release()
+
+			assert.Equal(t, mode, arr.Mode())
+			assert.Equal(t, 5, arr.Len())
+			assert.Equal(t, 1, arr.NullN())
+			assert.Equal(t, 2, arr.NumFields())
+			assert.True(t, arr.IsNull(2))
+			assert.Equal(t, []int8{3, 7, 3, 3, 7}, arr.RawTypeCodes()[:arr.Len()])
+
+			want := []interface{}{int32(1), "a", nil, int32(2), "b"}
+			check := func(t *testing.T, arr *array.Union, want []interface{}) {
+				t.Helper()
+				for i, v := range want {
+					if v == nil {
+						assert.True(t, arr.IsNull(i))
+						continue
+					}
+					child := arr.Field(arr.ChildID(i))
+					j := arr.ValueOffset(i)
+					switch child := child.(type) {
+					case *array.Int32:
+						assert.Equal(t, v, child.Value(j), "slot %d", i)
+					case *array.String:
+						assert.Equal(t, v, child.Value(j), "slot %d", i)
+					default:
+						t.Fatalf("invalid child type %T", child)
+					}
+				}
+			}
+			check(t, arr, want)
+
+			switch mode {
+			case arrow.SparseMode:
+				assert.Nil(t, arr.RawValueOffsets())
+				assert.Equal(t, 5, arr.Field(0).Len())
+				assert.Equal(t, 5, arr.Field(1).Len())
+			case arrow.DenseMode:
+				assert.Equal(t, []int32{0, 0, 1, 2, 1}, arr.RawValueOffsets()[:arr.Len()])
+				assert.Equal(t, 3, arr.Field(0).Len())
+				assert.Equal(t, 2, arr.Field(1).Len())
+			}
+
+			slice := array.NewSlice(arr, 1, 4).(*array.Union)
+			defer slice.Release()
+			check(t, slice, want[1:4])
+		})
+	}
+}
+
+func TestUnionBuilderInvalidCode(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	dtype := arrow.SparseUnionOf([]arrow.Field{{Name: "i32", Type: arrow.PrimitiveTypes.Int32}}, nil)
+	bld := array.NewUnionBuilder(mem, dtype)
+	defer bld.Release()
+
+	assert.Panics(t, func() { bld.Append(1) })
+}
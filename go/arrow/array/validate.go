@@ -0,0 +1,494 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/internal/bitutil"
+)
+
+// ValidateOption configures the validation of arrays and records.
+type ValidateOption func(*validateConfig)
+
+type validateConfig struct {
+	full bool // whether to check the values of the buffers
+	utf8 bool // whether to check that strings are valid UTF-8
+}
+
+// WithUTF8Validation sets whether ValidateFull checks that the values of
+// string arrays are valid UTF-8. The default is false.
+func WithUTF8Validation(check bool) ValidateOption {
+	return func(cfg *validateConfig) { cfg.utf8 = check }
+}
+
+// Validate checks the layout of arr in constant time, or in time
+// proportional to its number of children: the number and size of its
+// buffers, the lengths of its children, and the ranges of its first and
+// last offsets.
+//
+// Validate returns nil if arr can be accessed without going out of the
+// bounds of its buffers, assuming its offsets and type codes are valid.
+func Validate(arr Interface) error {
+	if err := validateData(arr.Data(), &validateConfig{}); err != nil {
+		return fmt.Errorf("arrow/array: %w", err)
+	}
+	return nil
+}
+
+// ValidateFull performs the checks of Validate and then checks the values
+// of the buffers of arr: offsets must be monotonic, null counts must match
+// the validity bitmaps, type codes must select a child, union offsets must
+// be within the bounds of their child, and run ends must be increasing.
+//
+// ValidateFull is meant for data coming from untrusted sources, and takes
+// time proportional to the size of arr.
+func ValidateFull(arr Interface, opts ...ValidateOption) error {
+	if err := validateData(arr.Data(), newValidateConfig(opts)); err != nil {
+		return fmt.Errorf("arrow/array: %w", err)
+	}
+	return nil
+}
+
+// ValidateRecord checks that the columns of rec match its schema and number
+// of rows, and validates each of them with ValidateFull.
+func ValidateRecord(rec Record, opts ...ValidateOption) error {
+	schema, cols := rec.Schema(), rec.Columns()
+	if err := validateColumns(schema, cols, rec.NumRows()); err != nil {
+		return err
+	}
+	cfg := newValidateConfig(opts)
+	for i, arr := range cols {
+		if err := validateData(arr.Data(), cfg); err != nil {
+			return fmt.Errorf("arrow/array: column %q: %w", schema.Field(i).Name, err)
+		}
+	}
+	return nil
+}
+
+// ValidateData performs the checks of ValidateFull on data. Unlike the
+// constructors of arrays, which may panic on malformed data, ValidateData
+// can be called before making an array from data.
+func ValidateData(data *Data, opts ...ValidateOption) error {
+	if err := validateData(data, newValidateConfig(opts)); err != nil {
+		return fmt.Errorf("arrow/array: %w", err)
+	}
+	return nil
+}
+
+func newValidateConfig(opts []ValidateOption) *validateConfig {
+	cfg := &validateConfig{full: true}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+func validateData(d *Data, cfg *validateConfig) error {
+	if d.length < 0 {
+		return fmt.Errorf("negative length %d", d.length)
+	}
+	if d.offset < 0 {
+		return fmt.Errorf("negative offset %d", d.offset)
+	}
+	if d.nulls < UnknownNullCount || d.nulls > d.length {
+		return fmt.Errorf("invalid null count %d for length %d", d.nulls, d.length)
+	}
+
+	dt := d.dtype
+	if ext, ok := dt.(arrow.ExtensionType); ok {
+		dt = ext.StorageType()
+	}
+	end := d.offset + d.length
+
+	switch dt := dt.(type) {
+	case *arrow.NullType:
+		return nil
+	case *arrow.RunEndEncodedType:
+		// run-end encoded arrays have no validity bitmap.
+		return validateRunEndEncoded(d, dt, cfg)
+	}
+	if err := validateNulls(d, cfg); err != nil {
+		return err
+	}
+
+	var err error
+	switch dt := dt.(type) {
+	case *arrow.BooleanType:
+		err = validateLayout(d, 2, 0, bitutil.CeilByte(end)/8)
+	case *arrow.FixedSizeBinaryType:
+		// array.FixedSizeBinary locates its values through an offsets buffer.
+		if err = validateLayout(d, 3, 0, 0); err == nil {
+			err = validateOffsets(d, false, len(bytesOf(d.buffers[2])), cfg)
+		}
+		if err == nil && cfg.full && d.length > 0 {
+			// null values may be empty.
+			offs := arrow.Int32Traits.CastFromBytes(d.buffers[1].Bytes())
+			bitmap := bytesOf(d.buffers[0])
+			for i := d.offset; i < end; i++ {
+				n := int(offs[i+1] - offs[i])
+				if n == 0 && bitmap != nil && bitutil.BitIsNotSet(bitmap, i) {
+					continue
+				}
+				if n != dt.ByteWidth {
+					err = fmt.Errorf("invalid length %d of value %d, expected %d", n, i-d.offset, dt.ByteWidth)
+					break
+				}
+			}
+		}
+	case arrow.FixedWidthDataType:
+		err = validateLayout(d, 2, 0, end*dt.BitWidth()/8)
+	case *arrow.BinaryType, *arrow.StringType, *arrow.LargeBinaryType, *arrow.LargeStringType:
+		large := dt.ID() == arrow.LARGE_BINARY || dt.ID() == arrow.LARGE_STRING
+		if err = validateLayout(d, 3, 0, 0); err == nil {
+			err = validateOffsets(d, large, len(bytesOf(d.buffers[2])), cfg)
+		}
+		if err == nil && cfg.utf8 && (dt.ID() == arrow.STRING || dt.ID() == arrow.LARGE_STRING) {
+			err = validateUTF8(d, large)
+		}
+	case arrow.BinaryViewDataType:
+		err = validateLayout(d, 2, 0, end*arrow.ViewSizeBytes)
+		if err == nil && (cfg.full || cfg.utf8) {
+			err = validateViews(d, cfg.utf8 && dt.ID() == arrow.STRING_VIEW)
+		}
+	case *arrow.ListType, *arrow.LargeListType, *arrow.MapType:
+		large := dt.ID() == arrow.LARGE_LIST
+		if err = validateLayout(d, 2, 1, 0); err == nil {
+			err = validateOffsets(d, large, d.childData[0].length, cfg)
+		}
+		if err == nil {
+			err = validateChild(d, 0, elemTypeOf(dt), cfg)
+		}
+	case *arrow.FixedSizeListType:
+		if err = validateLayout(d, 1, 1, 0); err == nil {
+			if n := end * int(dt.Len()); d.childData[0].length < n {
+				err = fmt.Errorf("child too short: got %d values, want at least %d", d.childData[0].length, n)
+			}
+		}
+		if err == nil {
+			err = validateChild(d, 0, dt.Elem(), cfg)
+		}
+	case *arrow.StructType:
+		if err = validateLayout(d, 1, len(dt.Fields()), 0); err == nil {
+			for k, f := range dt.Fields() {
+				if d.childData[k].length < end {
+					err = fmt.Errorf("field %q too short: got %d values, want at least %d", f.Name, d.childData[k].length, end)
+					break
+				}
+				if err = validateChild(d, k, f.Type, cfg); err != nil {
+					break
+				}
+			}
+		}
+	case *arrow.UnionType:
+		err = validateUnion(d, dt, cfg)
+	case *arrow.DictionaryType:
+		err = validateDictionary(d, dt, cfg)
+	default:
+		err = fmt.Errorf("unsupported data type %s", dt.Name())
+	}
+	return err
+}
+
+// validateLayout checks that d has nbufs buffers and nkids children, and
+// that its values buffer, if any, holds at least size bytes.
+func validateLayout(d *Data, nbufs, nkids, size int) error {
+	if len(d.buffers) < nbufs {
+		return fmt.Errorf("invalid number of buffers for %s: got=%d, want=%d", d.dtype.Name(), len(d.buffers), nbufs)
+	}
+	if len(d.childData) != nkids {
+		return fmt.Errorf("invalid number of children for %s: got=%d, want=%d", d.dtype.Name(), len(d.childData), nkids)
+	}
+	// arrays slice their values buffer, if any, even when empty.
+	if nbufs > 1 && (d.length > 0 || d.buffers[1] != nil) {
+		if n := len(bytesOf(d.buffers[1])); n < size {
+			return fmt.Errorf("values buffer too small: got %d bytes, want at least %d", n, size)
+		}
+	}
+	return nil
+}
+
+// validateNulls checks the validity bitmap of d against its null count.
+func validateNulls(d *Data, cfg *validateConfig) error {
+	// an empty validity bitmap is not an absent one: arrays read it.
+	if len(d.buffers) == 0 || d.buffers[0] == nil {
+		if d.nulls > 0 {
+			return fmt.Errorf("null count %d without validity bitmap", d.nulls)
+		}
+		return nil
+	}
+	bitmap := d.buffers[0].Bytes()
+	if n := bitutil.CeilByte(d.offset+d.length) / 8; len(bitmap) < n {
+		return fmt.Errorf("validity bitmap too small: got %d bytes, want at least %d", len(bitmap), n)
+	}
+	if cfg.full && d.nulls != UnknownNullCount {
+		if n := d.length - bitutil.CountSetBits(bitmap, d.offset, d.length); n != d.nulls {
+			return fmt.Errorf("null count %d does not match the %d nulls of the validity bitmap", d.nulls, n)
+		}
+	}
+	return nil
+}
+
+// offsetAt returns the i-th offset of the offsets buffer of d.
+func offsetAt(d *Data, large bool, i int) int64 {
+	if large {
+		return arrow.Int64Traits.CastFromBytes(d.buffers[1].Bytes())[i]
+	}
+	return int64(arrow.Int32Traits.CastFromBytes(d.buffers[1].Bytes())[i])
+}
+
+// validateOffsets checks the offsets buffer of d, whose offsets index size
+// values.
+func validateOffsets(d *Data, large bool, size int, cfg *validateConfig) error {
+	if d.length == 0 {
+		return nil
+	}
+	width := arrow.Int32SizeBytes
+	if large {
+		width = arrow.Int64SizeBytes
+	}
+	end := d.offset + d.length
+	if n := len(bytesOf(d.buffers[1])); n < (end+1)*width {
+		return fmt.Errorf("offsets buffer too small: got %d bytes, want at least %d", n, (end+1)*width)
+	}
+
+	first, last := offsetAt(d, large, d.offset), offsetAt(d, large, end)
+	if first < 0 || first > last {
+		return fmt.Errorf("invalid offsets range [%d, %d]", first, last)
+	}
+	if last > int64(size) {
+		return fmt.Errorf("offset %d out of range of the %d values", last, size)
+	}
+	if !cfg.full {
+		return nil
+	}
+	for i := d.offset; i < end; i++ {
+		if offsetAt(d, large, i+1) < offsetAt(d, large, i) {
+			return fmt.Errorf("offsets are not monotonic at index %d", i-d.offset)
+		}
+	}
+	return nil
+}
+
+func validateUTF8(d *Data, large bool) error {
+	if d.length == 0 {
+		return nil
+	}
+	values := bytesOf(d.buffers[2])
+	for i := d.offset; i < d.offset+d.length; i++ {
+		if !utf8.Valid(values[offsetAt(d, large, i):offsetAt(d, large, i+1)]) {
+			return fmt.Errorf("invalid UTF-8 data at index %d", i-d.offset)
+		}
+	}
+	return nil
+}
+
+// validateViews checks that the non-null views of d refer to ranges of its
+// data buffers, and that their values are valid UTF-8 if utf8 is set.
+func validateViews(d *Data, utf8Check bool) error {
+	var (
+		views  = bytesOf(d.buffers[1])
+		bitmap = bytesOf(d.buffers[0])
+		bufs   = viewBuffers(d)
+	)
+	for i := d.offset; i < d.offset+d.length; i++ {
+		if bitmap != nil && bitutil.BitIsNotSet(bitmap, i) {
+			continue
+		}
+		v := views[i*arrow.ViewSizeBytes : (i+1)*arrow.ViewSizeBytes]
+		n := int(int32(binary.LittleEndian.Uint32(v)))
+		if n < 0 {
+			return fmt.Errorf("invalid negative length %d of value %d", n, i-d.offset)
+		}
+		if n > viewInlineSize {
+			k := int(binary.LittleEndian.Uint32(v[8:]))
+			off := int(binary.LittleEndian.Uint32(v[12:]))
+			if k >= len(bufs) {
+				return fmt.Errorf("view of value %d refers to data buffer %d of %d", i-d.offset, k, len(bufs))
+			}
+			if off+n > len(bufs[k]) {
+				return fmt.Errorf("view of value %d out of range of the %d bytes of data buffer %d", i-d.offset, len(bufs[k]), k)
+			}
+			if !bytes.Equal(v[4:8], bufs[k][off:off+4]) {
+				return fmt.Errorf("view of value %d has a prefix not matching its value", i-d.offset)
+			}
+		}
+		if utf8Check && !utf8.Valid(viewValue(views, bufs, i)) {
+			return fmt.Errorf("invalid UTF-8 data at index %d", i-d.offset)
+		}
+	}
+	return nil
+}
+
+func elemTypeOf(dt arrow.DataType) arrow.DataType {
+	switch dt := dt.(type) {
+	case *arrow.ListType:
+		return dt.Elem()
+	case *arrow.LargeListType:
+		return dt.Elem()
+	case *arrow.MapType:
+		return dt.ValueType()
+	}
+	panic(fmt.Errorf("arrow/array: invalid list type %s", dt.Name()))
+}
+
+// validateChild checks the k-th child of d, which must be of type dt.
+func validateChild(d *Data, k int, dt arrow.DataType, cfg *validateConfig) error {
+	child := d.childData[k]
+	if child == nil {
+		return fmt.Errorf("child %d is nil", k)
+	}
+	if child.dtype.ID() != dt.ID() {
+		return fmt.Errorf("child %d has type %s, want %s", k, child.dtype.Name(), dt.Name())
+	}
+	if err := validateData(child, cfg); err != nil {
+		return fmt.Errorf("child %d: %w", k, err)
+	}
+	return nil
+}
+
+func validateUnion(d *Data, dt *arrow.UnionType, cfg *validateConfig) error {
+	nbufs := 2
+	if dt.Mode() == arrow.DenseMode {
+		nbufs = 3
+	}
+	end := d.offset + d.length
+	if err := validateLayout(d, nbufs, len(dt.Fields()), end); err != nil {
+		return err
+	}
+	if dt.Mode() == arrow.DenseMode && d.length > 0 {
+		if n := len(bytesOf(d.buffers[2])); n < end*arrow.Int32SizeBytes {
+			return fmt.Errorf("offsets buffer too small: got %d bytes, want at least %d", n, end*arrow.Int32SizeBytes)
+		}
+	}
+	for k, f := range dt.Fields() {
+		if dt.Mode() == arrow.SparseMode && d.childData[k].length < end {
+			return fmt.Errorf("field %q too short: got %d values, want at least %d", f.Name, d.childData[k].length, end)
+		}
+		if err := validateChild(d, k, f.Type, cfg); err != nil {
+			return err
+		}
+	}
+	if !cfg.full || d.length == 0 {
+		return nil
+	}
+
+	codes := arrow.Int8Traits.CastFromBytes(d.buffers[1].Bytes())
+	var offsets []int32
+	if dt.Mode() == arrow.DenseMode {
+		offsets = arrow.Int32Traits.CastFromBytes(d.buffers[2].Bytes())
+	}
+	for i := d.offset; i < end; i++ {
+		id := dt.ChildID(codes[i])
+		if id < 0 {
+			return fmt.Errorf("invalid type code %d at index %d", codes[i], i-d.offset)
+		}
+		if offsets != nil && (offsets[i] < 0 || int(offsets[i]) >= d.childData[id].length) {
+			return fmt.Errorf("offset %d at index %d out of range of field %q", offsets[i], i-d.offset, dt.Field(id).Name)
+		}
+	}
+	return nil
+}
+
+func validateDictionary(d *Data, dt *arrow.DictionaryType, cfg *validateConfig) error {
+	width := dt.IndexType().(arrow.FixedWidthDataType).BitWidth() / 8
+	if err := validateLayout(d, 2, 0, (d.offset+d.length)*width); err != nil {
+		return err
+	}
+	dict := d.dictionary
+	if dict == nil {
+		return errors.New("dictionary array without dictionary")
+	}
+	if dict.dtype.ID() != dt.ValueType().ID() {
+		return fmt.Errorf("dictionary has type %s, want %s", dict.dtype.Name(), dt.ValueType().Name())
+	}
+	if err := validateData(dict, cfg); err != nil {
+		return fmt.Errorf("dictionary: %w", err)
+	}
+	if !cfg.full {
+		return nil
+	}
+
+	idx := NewData(dt.IndexType(), d.length, d.buffers, nil, d.nulls, d.offset)
+	defer idx.Release()
+	indices := MakeFromData(idx)
+	defer indices.Release()
+	for i := 0; i < d.length; i++ {
+		if indices.IsNull(i) {
+			continue
+		}
+		if v := indexValue(indices, i); v < 0 || v >= dict.length {
+			return fmt.Errorf("index %d at index %d out of range of the %d dictionary values", v, i, dict.length)
+		}
+	}
+	return nil
+}
+
+func validateRunEndEncoded(d *Data, dt *arrow.RunEndEncodedType, cfg *validateConfig) error {
+	if len(d.childData) != 2 {
+		return fmt.Errorf("invalid number of children for %s: got=%d, want=2", dt.Name(), len(d.childData))
+	}
+	for k, f := range dt.Fields() {
+		if err := validateChild(d, k, f.Type, cfg); err != nil {
+			return err
+		}
+	}
+	ends, values := d.childData[0], d.childData[1]
+	if ends.NullN() != 0 {
+		return errors.New("run ends must not be null")
+	}
+	if values.length < ends.length {
+		return fmt.Errorf("values too short: got %d values for %d runs", values.length, ends.length)
+	}
+
+	runEnd := func(j int) int64 {
+		j += ends.offset
+		switch ends.dtype.ID() {
+		case arrow.INT16:
+			return int64(arrow.Int16Traits.CastFromBytes(ends.buffers[1].Bytes())[j])
+		case arrow.INT32:
+			return int64(arrow.Int32Traits.CastFromBytes(ends.buffers[1].Bytes())[j])
+		}
+		return arrow.Int64Traits.CastFromBytes(ends.buffers[1].Bytes())[j]
+	}
+	if d.length == 0 {
+		return nil
+	}
+	if ends.length == 0 {
+		return errors.New("no runs for a non-empty array")
+	}
+	if last, end := runEnd(ends.length-1), d.offset+d.length; last < int64(end) {
+		return fmt.Errorf("last run end %d before the end %d of the array", last, end)
+	}
+	if !cfg.full {
+		return nil
+	}
+	prev := int64(0)
+	for j := 0; j < ends.length; j++ {
+		v := runEnd(j)
+		if v <= prev {
+			return fmt.Errorf("run ends are not strictly increasing at run %d", j)
+		}
+		prev = v
+	}
+	return nil
+}
@@ -0,0 +1,345 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package array_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateFull(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	for _, tc := range []struct {
+		dt   arrow.DataType
+		json string
+	}{
+		{arrow.Null, `[null, null]`},
+		{arrow.FixedWidthTypes.Boolean, `[true, null, false]`},
+		{arrow.PrimitiveTypes.Int32, `[1, null, 3]`},
+		{arrow.BinaryTypes.String, `["a", null, "bc"]`},
+		{arrow.BinaryTypes.LargeBinary, `["aGk=", null]`},
+		{arrow.BinaryTypes.StringView, `["a", null, "a string longer than 12 bytes"]`},
+		{arrow.BinaryTypes.BinaryView, `["aGk=", null, "YSBsb25nZXIgYmluYXJ5IHZhbHVl"]`},
+		{&arrow.FixedSizeBinaryType{ByteWidth: 2}, `["aGk=", null]`},
+		{arrow.ListOf(arrow.PrimitiveTypes.Int64), `[[1, 2], null, []]`},
+		{arrow.FixedSizeListOf(2, arrow.BinaryTypes.String), `[["a", "b"], null]`},
+		{arrow.MapOf(arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int32), `[{"a": 1}, null]`},
+		{arrow.StructOf(arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Int8, Nullable: true}), `[{"x": 1}, null]`},
+		{arrow.DenseUnionOf([]arrow.Field{{Name: "i", Type: arrow.PrimitiveTypes.Int32}}, []int8{3}), `[[3, 1], [3, 2]]`},
+		{arrow.SparseUnionOf([]arrow.Field{{Name: "s", Type: arrow.BinaryTypes.String}}, nil), `[[0, "a"]]`},
+		{arrow.RunEndEncodedOf(arrow.PrimitiveTypes.Int32, arrow.BinaryTypes.String), `["a", "a", "b"]`},
+	} {
+		t.Run(tc.dt.Name(), func(t *testing.T) {
+			b := array.NewBuilder(mem, tc.dt)
+			defer b.Release()
+			if err := b.UnmarshalJSON([]byte(tc.json)); err != nil {
+				t.Fatal(err)
+			}
+			arr := b.NewArray()
+			defer arr.Release()
+
+			assert.NoError(t, array.Validate(arr))
+			assert.NoError(t, array.ValidateFull(arr, array.WithUTF8Validation(true)))
+
+			slice := array.NewSlice(arr, 1, int64(arr.Len()))
+			defer slice.Release()
+			assert.NoError(t, array.ValidateFull(slice, array.WithUTF8Validation(true)))
+		})
+	}
+}
+
+func TestValidateErrors(t *testing.T) {
+	var (
+		buf = func(b []byte) *memory.Buffer { return memory.NewBufferBytes(b) }
+		i32 = func(v ...int32) *memory.Buffer { return buf(arrow.Int32Traits.CastToBytes(v)) }
+		i8  = func(v ...int8) *memory.Buffer { return buf(arrow.Int8Traits.CastToBytes(v)) }
+
+		int32s = array.NewData(arrow.PrimitiveTypes.Int32, 2, []*memory.Buffer{nil, i32(1, 2)}, nil, 0, 0)
+		union  = arrow.DenseUnionOf([]arrow.Field{{Name: "i", Type: arrow.PrimitiveTypes.Int32}}, nil)
+		ree    = arrow.RunEndEncodedOf(arrow.PrimitiveTypes.Int32, arrow.PrimitiveTypes.Int32)
+	)
+	defer int32s.Release()
+
+	for _, tc := range []struct {
+		name string
+		data *array.Data
+		utf8 bool   // whether to validate UTF-8 strings.
+		want string // the error message.
+	}{
+		{
+			name: "short values",
+			data: array.NewData(arrow.PrimitiveTypes.Int64, 2, []*memory.Buffer{nil, buf(make([]byte, 8))}, nil, 0, 0),
+			want: "arrow/array: values buffer too small: got 8 bytes, want at least 16",
+		},
+		{
+			name: "short validity",
+			data: array.NewData(arrow.PrimitiveTypes.Int32, 2, []*memory.Buffer{buf(nil), i32(1, 2)}, nil, 1, 0),
+			want: "arrow/array: validity bitmap too small: got 0 bytes, want at least 1",
+		},
+		{
+			name: "null count",
+			data: array.NewData(arrow.PrimitiveTypes.Int32, 2, []*memory.Buffer{buf([]byte{0x3}), i32(1, 2)}, nil, 1, 0),
+			want: "arrow/array: null count 1 does not match the 0 nulls of the validity bitmap",
+		},
+		{
+			name: "offset out of range",
+			data: array.NewData(arrow.BinaryTypes.String, 2, []*memory.Buffer{nil, i32(0, 1, 5), buf([]byte("abc"))}, nil, 0, 0),
+			want: "arrow/array: offset 5 out of range of the 3 values",
+		},
+		{
+			name: "offsets not monotonic",
+			data: array.NewData(arrow.BinaryTypes.String, 2, []*memory.Buffer{nil, i32(0, 3, 2), buf([]byte("abc"))}, nil, 0, 0),
+			want: "arrow/array: offsets are not monotonic at index 1",
+		},
+		{
+			name: "invalid utf8",
+			data: array.NewData(arrow.BinaryTypes.String, 2, []*memory.Buffer{nil, i32(0, 1, 3), buf([]byte("a\xff\xfe"))}, nil, 0, 0),
+			utf8: true,
+			want: "arrow/array: invalid UTF-8 data at index 1",
+		},
+		{
+			name: "list child",
+			data: array.NewData(arrow.ListOf(arrow.PrimitiveTypes.Int32), 1, []*memory.Buffer{nil, i32(0, 3)}, []*array.Data{int32s}, 0, 0),
+			want: "arrow/array: offset 3 out of range of the 2 values",
+		},
+		{
+			name: "list child type",
+			data: array.NewData(arrow.ListOf(arrow.PrimitiveTypes.Int64), 1, []*memory.Buffer{nil, i32(0, 2)}, []*array.Data{int32s}, 0, 0),
+			want: "arrow/array: child 0 has type int32, want int64",
+		},
+		{
+			name: "fixed size list child",
+			data: array.NewData(arrow.FixedSizeListOf(2, arrow.PrimitiveTypes.Int32), 2, []*memory.Buffer{nil}, []*array.Data{int32s}, 0, 0),
+			want: "arrow/array: child too short: got 2 values, want at least 4",
+		},
+		{
+			name: "struct field",
+			data: array.NewData(arrow.StructOf(arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Int32}), 3, []*memory.Buffer{nil}, []*array.Data{int32s}, 0, 0),
+			want: `arrow/array: field "x" too short: got 2 values, want at least 3`,
+		},
+		{
+			name: "union type code",
+			data: array.NewData(union, 2, []*memory.Buffer{nil, i8(0, 1), i32(0, 1)}, []*array.Data{int32s}, 0, 0),
+			want: "arrow/array: invalid type code 1 at index 1",
+		},
+		{
+			name: "union offset",
+			data: array.NewData(union, 2, []*memory.Buffer{nil, i8(0, 0), i32(0, 2)}, []*array.Data{int32s}, 0, 0),
+			want: `arrow/array: offset 2 at index 1 out of range of field "i"`,
+		},
+		{
+			name: "run ends",
+			data: array.NewData(ree, 2, []*memory.Buffer{nil}, []*array.Data{int32s, int32s}, 0, 1),
+			want: "arrow/array: last run end 2 before the end 3 of the array",
+		},
+		{
+			name: "run ends order",
+			data: array.NewData(ree, 2, []*memory.Buffer{nil}, []*array.Data{
+				array.NewData(arrow.PrimitiveTypes.Int32, 2, []*memory.Buffer{nil, i32(2, 2)}, nil, 0, 0),
+				int32s,
+			}, 0, 0),
+			want: "arrow/array: run ends are not strictly increasing at run 1",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer tc.data.Release()
+			err := array.ValidateData(tc.data, array.WithUTF8Validation(tc.utf8))
+			assert.EqualError(t, err, tc.want)
+		})
+	}
+}
+
+func TestRecordValidate(t *testing.T) {
+	strs := array.NewData(arrow.BinaryTypes.String, 1, []*memory.Buffer{
+		nil,
+		memory.NewBufferBytes(arrow.Int32Traits.CastToBytes([]int32{0, 1})),
+		memory.NewBufferBytes([]byte{0xff}),
+	}, nil, 0, 0)
+	defer strs.Release()
+	arr := array.MakeFromData(strs)
+	defer arr.Release()
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "s", Type: arrow.BinaryTypes.String}}, nil)
+	rec := array.NewRecord(schema, []array.Interface{arr}, 1)
+	defer rec.Release()
+
+	assert.NoError(t, array.ValidateRecord(rec))
+	assert.EqualError(t, array.ValidateRecord(rec, array.WithUTF8Validation(true)), `arrow/array: column "s": invalid UTF-8 data at index 0`)
+}
+
+// fuzzTypes are the data types of the arrays built by FuzzValidateData.
+var fuzzTypes = []arrow.DataType{
+	arrow.FixedWidthTypes.Boolean,
+	arrow.PrimitiveTypes.Int8,
+	arrow.PrimitiveTypes.Int64,
+	arrow.BinaryTypes.String,
+	arrow.BinaryTypes.LargeBinary,
+	arrow.BinaryTypes.StringView,
+	&arrow.FixedSizeBinaryType{ByteWidth: 3},
+	arrow.ListOf(arrow.PrimitiveTypes.Int32),
+	arrow.LargeListOf(arrow.BinaryTypes.String),
+	arrow.FixedSizeListOf(2, arrow.PrimitiveTypes.Int16),
+	arrow.MapOf(arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int32),
+	arrow.StructOf(
+		arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		arrow.Field{Name: "b", Type: arrow.BinaryTypes.String, Nullable: true},
+	),
+	arrow.SparseUnionOf([]arrow.Field{
+		{Name: "i", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "s", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, []int8{2, 5}),
+	arrow.DenseUnionOf([]arrow.Field{
+		{Name: "i", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "s", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil),
+	arrow.DictionaryOf(arrow.PrimitiveTypes.Int8, arrow.BinaryTypes.String),
+	arrow.RunEndEncodedOf(arrow.PrimitiveTypes.Int32, arrow.BinaryTypes.String),
+}
+
+// fuzzData builds array data of type dt from the bytes of raw: the length,
+// offset and null count of the data, then its buffers, each one a length
+// followed by its bytes, then the data of its children.
+type fuzzData struct {
+	mem memory.Allocator
+	raw []byte
+}
+
+func (f *fuzzData) byte() int {
+	if len(f.raw) == 0 {
+		return 0
+	}
+	b := f.raw[0]
+	f.raw = f.raw[1:]
+	return int(b)
+}
+
+func (f *fuzzData) buffer() *memory.Buffer {
+	n := f.byte()
+	if n == 0xff {
+		return nil
+	}
+	if n > len(f.raw) {
+		n = len(f.raw)
+	}
+	buf := memory.NewResizableBuffer(f.mem)
+	buf.Resize(n)
+	copy(buf.Bytes(), f.raw[:n])
+	f.raw = f.raw[n:]
+	return buf
+}
+
+func (f *fuzzData) data(dt arrow.DataType, depth int) *array.Data {
+	length, offset, nulls := f.byte(), f.byte()%8, f.byte()-1
+
+	nbufs := 2
+	var children []arrow.DataType
+	switch dt := dt.(type) {
+	case *arrow.BinaryType, *arrow.StringType, *arrow.LargeBinaryType, *arrow.FixedSizeBinaryType:
+		nbufs = 3
+	case *arrow.StringViewType:
+		nbufs = 2 + f.byte()%3
+	case *arrow.ListType:
+		children = []arrow.DataType{dt.Elem()}
+	case *arrow.LargeListType:
+		children = []arrow.DataType{dt.Elem()}
+	case *arrow.MapType:
+		children = []arrow.DataType{dt.ValueType()}
+	case *arrow.FixedSizeListType:
+		nbufs, children = 1, []arrow.DataType{dt.Elem()}
+	case *arrow.StructType:
+		nbufs = 1
+		for _, f := range dt.Fields() {
+			children = append(children, f.Type)
+		}
+	case *arrow.UnionType:
+		nbufs = 3
+		for _, f := range dt.Fields() {
+			children = append(children, f.Type)
+		}
+	case *arrow.RunEndEncodedType:
+		nbufs, children = 1, []arrow.DataType{dt.RunEnds(), dt.Encoded()}
+	}
+
+	buffers := make([]*memory.Buffer, nbufs)
+	for i := range buffers {
+		buffers[i] = f.buffer()
+	}
+	var childData []*array.Data
+	if depth < 3 {
+		for _, ct := range children {
+			childData = append(childData, f.data(ct, depth+1))
+		}
+	}
+
+	var data *array.Data
+	if dictType, ok := dt.(*arrow.DictionaryType); ok {
+		dict := f.data(dictType.ValueType(), depth+1)
+		data = array.NewDataWithDictionary(dt, length, buffers, nulls, offset, dict)
+		dict.Release()
+	} else {
+		data = array.NewData(dt, length, buffers, childData, nulls, offset)
+	}
+	for _, buf := range buffers {
+		if buf != nil {
+			buf.Release()
+		}
+	}
+	for _, child := range childData {
+		child.Release()
+	}
+	return data
+}
+
+func FuzzValidateData(f *testing.F) {
+	for i := range fuzzTypes {
+		f.Add(byte(i), []byte{3, 0, 0, 1, 0xff, 12, 0, 0, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0})
+	}
+	f.Add(byte(3), []byte{2, 1, 1, 1, 0x5, 12, 0, 0, 0, 0, 1, 0, 0, 0, 3, 0, 0, 0, 3, 'a', 'b', 'c'})
+
+	f.Fuzz(func(t *testing.T, typ byte, raw []byte) {
+		mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+		defer mem.AssertSize(t, 0)
+
+		dt := fuzzTypes[int(typ)%len(fuzzTypes)]
+		fd := fuzzData{mem: mem, raw: raw}
+		data := fd.data(dt, 0)
+		defer data.Release()
+
+		if err := array.ValidateData(data, array.WithUTF8Validation(true)); err != nil {
+			return
+		}
+		// valid data must be usable.
+		arr := array.MakeFromData(data)
+		defer arr.Release()
+		_ = fmt.Sprint(arr)
+		if arr.Len() > 0 {
+			slice := array.NewSlice(arr, 1, int64(arr.Len()))
+			_ = fmt.Sprint(slice)
+			slice.Release()
+		}
+		if !array.ArrayEqual(arr, arr) {
+			t.Fatalf("array not equal to itself: %v", arr)
+		}
+	})
+}
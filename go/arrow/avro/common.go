@@ -0,0 +1,97 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package avro converts between Arrow records and Avro data.
+//
+// Readers and writers handle Avro object container files, and Decoder and
+// AppendDatum handle single Avro datums, as exchanged through Kafka.
+//
+// Avro records are mapped to Arrow records, as described by SchemaOf and
+// AvroSchemaOf. Unions other than the union of a type with null, decimal
+// values and recursive types are not supported.
+//
+// See https://avro.apache.org/docs/current/specification/ for a description
+// of the format.
+package avro
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// Option configures an Avro reader, writer or decoder.
+type Option func(config)
+type config interface{}
+
+// WithAllocator specifies the Arrow memory allocator used while building records.
+func WithAllocator(mem memory.Allocator) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.mem = mem
+		case *Decoder:
+			cfg.mem = mem
+		default:
+			panic(fmt.Errorf("arrow/avro: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithChunk specifies the chunk size used while reading object container
+// files.
+//
+// If n is zero or 1, no chunking will take place and the reader will create
+// one record per row.
+// If n is greater than 1, chunks of n rows will be read.
+// If n is negative, the reader will load the whole file into memory and
+// create one big record with all the rows.
+func WithChunk(n int) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.chunk = n
+		default:
+			panic(fmt.Errorf("arrow/avro: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithCodec specifies the codec used to compress the blocks of the written
+// object container files. The default is Uncompressed.
+func WithCodec(codec Codec) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			cfg.codec = codec
+		default:
+			panic(fmt.Errorf("arrow/avro: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithRecordName specifies the full name of the Avro record type written to
+// the header of object container files. The default is "Record".
+func WithRecordName(name string) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			cfg.name = name
+		default:
+			panic(fmt.Errorf("arrow/avro: unknown config type %T", cfg))
+		}
+	}
+}
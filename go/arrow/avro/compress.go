@@ -0,0 +1,113 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+
+	"github.com/apache/arrow/go/arrow/internal/snappy"
+)
+
+// Codec is a compression codec of the blocks of an object container file.
+type Codec int
+
+const (
+	Uncompressed Codec = iota
+	Deflate
+	Snappy
+)
+
+func (c Codec) String() string {
+	switch c {
+	case Uncompressed:
+		return "null"
+	case Deflate:
+		return "deflate"
+	case Snappy:
+		return "snappy"
+	}
+	return fmt.Sprintf("Codec(%d)", int(c))
+}
+
+func codecOf(name string) (Codec, error) {
+	for _, c := range []Codec{Uncompressed, Deflate, Snappy} {
+		if c.String() == name {
+			return c, nil
+		}
+	}
+	return 0, fmt.Errorf("arrow/avro: unsupported compression codec %q", name)
+}
+
+// decompress returns the uncompressed content of a block.
+// Snappy compressed blocks are followed by the big-endian CRC-32 checksum of
+// their uncompressed content.
+func decompress(codec Codec, buf []byte) ([]byte, error) {
+	switch codec {
+	case Uncompressed:
+		return buf, nil
+	case Deflate:
+		out, err := ioutil.ReadAll(flate.NewReader(bytes.NewReader(buf)))
+		if err != nil {
+			return nil, fmt.Errorf("could not decompress deflate block: %w", err)
+		}
+		return out, nil
+	case Snappy:
+		if len(buf) < 4 {
+			return nil, fmt.Errorf("invalid snappy block")
+		}
+		n := len(buf) - 4
+		out, err := snappy.Decode(buf[:n])
+		if err != nil {
+			return nil, fmt.Errorf("could not decompress snappy block: %w", err)
+		}
+		if crc32.ChecksumIEEE(out) != binary.BigEndian.Uint32(buf[n:]) {
+			return nil, fmt.Errorf("invalid snappy block checksum")
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("unsupported compression codec %v", codec)
+}
+
+// compress compresses the content of a block with codec.
+func compress(codec Codec, buf []byte) ([]byte, error) {
+	switch codec {
+	case Uncompressed:
+		return buf, nil
+	case Deflate:
+		var out bytes.Buffer
+		w, err := flate.NewWriter(&out, flate.DefaultCompression)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(buf); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return out.Bytes(), nil
+	case Snappy:
+		out := snappy.Encode(buf)
+		return binary.BigEndian.AppendUint32(out, crc32.ChecksumIEEE(buf)), nil
+	}
+	return nil, fmt.Errorf("arrow/avro: unsupported compression codec %v", codec)
+}
@@ -0,0 +1,374 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+)
+
+var errCorrupt = errors.New("corrupt datum")
+
+// datum decodes values in the Avro binary encoding.
+type datum struct {
+	buf []byte
+	err error
+}
+
+func (d *datum) long() int64 {
+	if d.err != nil {
+		return 0
+	}
+	u, n := binary.Uvarint(d.buf)
+	if n <= 0 {
+		d.err = errCorrupt
+		return 0
+	}
+	d.buf = d.buf[n:]
+	return int64(u>>1) ^ -int64(u&1)
+}
+
+func (d *datum) int() int32 {
+	v := d.long()
+	if v < math.MinInt32 || v > math.MaxInt32 {
+		d.err = errCorrupt
+		return 0
+	}
+	return int32(v)
+}
+
+func (d *datum) fixed(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(d.buf) {
+		d.err = errCorrupt
+		return nil
+	}
+	v := d.buf[:n:n]
+	d.buf = d.buf[n:]
+	return v
+}
+
+func (d *datum) bytes() []byte {
+	n := d.long()
+	if n > int64(len(d.buf)) {
+		d.err = errCorrupt
+		return nil
+	}
+	return d.fixed(int(n))
+}
+
+// blockCount returns the number of items in the next block of an array or
+// a map, whose items take at least size bytes each.
+func (d *datum) blockCount(size int) int64 {
+	n := d.long()
+	if n < 0 {
+		n = -n
+		d.long() // size of the block, in bytes
+	}
+	if size > 0 && n > int64(len(d.buf)/size) && d.err == nil {
+		d.err = errCorrupt
+	}
+	return n
+}
+
+// decodeRecord appends the fields of the Avro record decoded from d to bld.
+func decodeRecord(bld *array.RecordBuilder, root *node, d *datum) error {
+	for i, f := range root.fields {
+		if err := decodeValue(bld.Field(i), f.node, d); err != nil {
+			return fmt.Errorf("field %q: %w", f.name, err)
+		}
+	}
+	return d.err
+}
+
+func decodeValue(b array.Builder, n *node, d *datum) error {
+	if n.null >= 0 {
+		switch d.long() {
+		case n.null:
+			b.AppendNull()
+			return d.err
+		case 1 - n.null:
+		default:
+			d.err = errCorrupt
+		}
+	}
+	if d.err != nil {
+		return d.err
+	}
+
+	switch n.typ {
+	case "null":
+		b.AppendNull()
+	case "boolean":
+		v := d.fixed(1)
+		if d.err == nil {
+			b.(*array.BooleanBuilder).Append(v[0] != 0)
+		}
+	case "int":
+		v := d.int()
+		switch b := b.(type) {
+		case *array.Int32Builder:
+			b.Append(v)
+		case *array.Date32Builder:
+			b.Append(arrow.Date32(v))
+		case *array.Time32Builder:
+			b.Append(arrow.Time32(v))
+		}
+	case "long":
+		v := d.long()
+		switch b := b.(type) {
+		case *array.Int64Builder:
+			b.Append(v)
+		case *array.Time64Builder:
+			b.Append(arrow.Time64(v))
+		case *array.TimestampBuilder:
+			b.Append(arrow.Timestamp(v))
+		}
+	case "float":
+		v := d.fixed(4)
+		if d.err == nil {
+			b.(*array.Float32Builder).Append(math.Float32frombits(binary.LittleEndian.Uint32(v)))
+		}
+	case "double":
+		v := d.fixed(8)
+		if d.err == nil {
+			b.(*array.Float64Builder).Append(math.Float64frombits(binary.LittleEndian.Uint64(v)))
+		}
+	case "bytes":
+		v := d.bytes()
+		if d.err == nil {
+			b.(*array.BinaryBuilder).Append(v)
+		}
+	case "string":
+		v := d.bytes()
+		if d.err == nil {
+			b.(*array.StringBuilder).Append(string(v))
+		}
+	case "enum":
+		i := d.int()
+		if d.err == nil && (i < 0 || int(i) >= len(n.symbols)) {
+			d.err = errCorrupt
+		}
+		if d.err == nil {
+			b.(*array.StringBuilder).Append(n.symbols[i])
+		}
+	case "fixed":
+		v := d.fixed(n.size)
+		if d.err == nil {
+			b.(*array.FixedSizeBinaryBuilder).Append(v)
+		}
+	case "array":
+		b := b.(*array.ListBuilder)
+		b.Append(true)
+		vb := b.ValueBuilder()
+		size := 1
+		if n.items.typ == "null" && n.items.null < 0 {
+			size = 0
+		}
+		if err := decodeBlocks(d, size, func() error { return decodeValue(vb, n.items, d) }); err != nil {
+			return err
+		}
+	case "map":
+		b := b.(*array.MapBuilder)
+		b.Append(true)
+		kb := b.KeyBuilder().(*array.StringBuilder)
+		ib := b.ItemBuilder()
+		err := decodeBlocks(d, 1, func() error {
+			k := d.bytes()
+			if d.err != nil {
+				return d.err
+			}
+			kb.Append(string(k))
+			return decodeValue(ib, n.items, d)
+		})
+		if err != nil {
+			return err
+		}
+	case "record":
+		b := b.(*array.StructBuilder)
+		b.Append(true)
+		for i, f := range n.fields {
+			if err := decodeValue(b.FieldBuilder(i), f.node, d); err != nil {
+				return err
+			}
+		}
+	}
+	return d.err
+}
+
+// decodeBlocks calls item for each item of the blocks of an array or a map.
+func decodeBlocks(d *datum, size int, item func() error) error {
+	for {
+		n := d.blockCount(size)
+		if d.err != nil || n == 0 {
+			return d.err
+		}
+		for i := int64(0); i < n; i++ {
+			if err := item(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// AppendDatum appends the Avro binary encoding of the row-th row of rec to
+// dst and returns the extended buffer.
+// The datum is encoded with the Avro schema returned by AvroSchemaOf for the
+// schema of the record.
+//
+// AppendDatum is meant for producing single messages, as published to Kafka.
+// It does not write the framing of the Avro single-object encoding, nor that
+// of a schema registry.
+func AppendDatum(dst []byte, rec array.Record, row int) ([]byte, error) {
+	if row < 0 || int64(row) >= rec.NumRows() {
+		return dst, fmt.Errorf("arrow/avro: row %d out of range [0, %d)", row, rec.NumRows())
+	}
+	for i, f := range rec.Schema().Fields() {
+		var err error
+		dst, err = appendValue(dst, rec.Column(i), row, f.Nullable)
+		if err != nil {
+			return dst, fmt.Errorf("arrow/avro: field %q: %w", f.Name, err)
+		}
+	}
+	return dst, nil
+}
+
+func appendLong(dst []byte, v int64) []byte {
+	return binary.AppendUvarint(dst, uint64(v<<1)^uint64(v>>63))
+}
+
+func appendBytes(dst []byte, v []byte) []byte {
+	dst = appendLong(dst, int64(len(v)))
+	return append(dst, v...)
+}
+
+// appendValue appends the i-th value of arr to dst. Values of nullable
+// fields are encoded as a ["null", T] union.
+func appendValue(dst []byte, arr array.Interface, i int, nullable bool) ([]byte, error) {
+	if arr.DataType().ID() == arrow.NULL {
+		return dst, nil
+	}
+	if nullable {
+		if arr.IsNull(i) {
+			return appendLong(dst, 0), nil
+		}
+		dst = appendLong(dst, 1)
+	} else if arr.IsNull(i) {
+		return dst, fmt.Errorf("null value in non-nullable field")
+	}
+
+	switch arr := arr.(type) {
+	case *array.Boolean:
+		if arr.Value(i) {
+			return append(dst, 1), nil
+		}
+		return append(dst, 0), nil
+	case *array.Int8:
+		return appendLong(dst, int64(arr.Value(i))), nil
+	case *array.Int16:
+		return appendLong(dst, int64(arr.Value(i))), nil
+	case *array.Int32:
+		return appendLong(dst, int64(arr.Value(i))), nil
+	case *array.Int64:
+		return appendLong(dst, arr.Value(i)), nil
+	case *array.Uint8:
+		return appendLong(dst, int64(arr.Value(i))), nil
+	case *array.Uint16:
+		return appendLong(dst, int64(arr.Value(i))), nil
+	case *array.Uint32:
+		return appendLong(dst, int64(arr.Value(i))), nil
+	case *array.Float32:
+		return binary.LittleEndian.AppendUint32(dst, math.Float32bits(arr.Value(i))), nil
+	case *array.Float64:
+		return binary.LittleEndian.AppendUint64(dst, math.Float64bits(arr.Value(i))), nil
+	case *array.Binary:
+		return appendBytes(dst, arr.Value(i)), nil
+	case *array.String:
+		return appendBytes(dst, []byte(arr.Value(i))), nil
+	case *array.FixedSizeBinary:
+		return append(dst, arr.Value(i)...), nil
+	case *array.Date32:
+		return appendLong(dst, int64(arr.Value(i))), nil
+	case *array.Date64:
+		return appendLong(dst, int64(arr.Value(i))/86400000), nil
+	case *array.Time32:
+		v := int64(arr.Value(i))
+		if arr.DataType().(*arrow.Time32Type).Unit == arrow.Second {
+			v *= 1000
+		}
+		return appendLong(dst, v), nil
+	case *array.Time64:
+		return appendLong(dst, int64(arr.Value(i))), nil
+	case *array.Timestamp:
+		v := int64(arr.Value(i))
+		if arr.DataType().(*arrow.TimestampType).Unit == arrow.Second {
+			v *= 1000
+		}
+		return appendLong(dst, v), nil
+	case *array.Map:
+		j := arr.Data().Offset() + i
+		beg, end := int(arr.Offsets()[j]), int(arr.Offsets()[j+1])
+		if end > beg {
+			dst = appendLong(dst, int64(end-beg))
+			keys := arr.Keys().(*array.String)
+			for k := beg; k < end; k++ {
+				if keys.IsNull(k) {
+					return dst, fmt.Errorf("null map key")
+				}
+				dst = appendBytes(dst, []byte(keys.Value(k)))
+				var err error
+				dst, err = appendValue(dst, arr.Items(), k, true)
+				if err != nil {
+					return dst, err
+				}
+			}
+		}
+		return appendLong(dst, 0), nil
+	case *array.List:
+		j := arr.Data().Offset() + i
+		beg, end := int(arr.Offsets()[j]), int(arr.Offsets()[j+1])
+		if end > beg {
+			dst = appendLong(dst, int64(end-beg))
+			for k := beg; k < end; k++ {
+				var err error
+				dst, err = appendValue(dst, arr.ListValues(), k, true)
+				if err != nil {
+					return dst, err
+				}
+			}
+		}
+		return appendLong(dst, 0), nil
+	case *array.Struct:
+		fields := arr.DataType().(*arrow.StructType).Fields()
+		for j, f := range fields {
+			var err error
+			dst, err = appendValue(dst, arr.Field(j), i, f.Nullable)
+			if err != nil {
+				return dst, fmt.Errorf("field %q: %w", f.Name, err)
+			}
+		}
+		return dst, nil
+	}
+	return dst, fmt.Errorf("unsupported type %s", arr.DataType().Name())
+}
@@ -0,0 +1,107 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// Decoder decodes single Avro datums, such as the values of Kafka messages,
+// and appends them as rows of array.Records.
+//
+// The datums must be in the Avro binary encoding, without the framing of the
+// Avro single-object encoding or of a schema registry, which callers strip
+// before calling Decode.
+type Decoder struct {
+	schema *arrow.Schema
+	root   *node
+	bld    *array.RecordBuilder
+
+	mem memory.Allocator
+}
+
+// NewDecoder returns a decoder of the datums written with the given Avro
+// schema, which must be a record.
+// The rows are appended to records with the schema given by SchemaOf.
+func NewDecoder(avroSchema []byte, opts ...Option) (*Decoder, error) {
+	root, err := parseSchema(avroSchema)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := schemaOf(root)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Decoder{schema: schema, root: root}
+	for _, opt := range opts {
+		opt(d)
+	}
+	if d.mem == nil {
+		d.mem = memory.DefaultAllocator
+	}
+	d.bld = array.NewRecordBuilder(d.mem, schema)
+	return d, nil
+}
+
+// Release releases the memory of the rows not yet returned by NewRecord.
+func (d *Decoder) Release() {
+	if d.bld != nil {
+		d.bld.Release()
+		d.bld = nil
+	}
+}
+
+func (d *Decoder) Schema() *arrow.Schema { return d.schema }
+
+// Decode decodes datum and appends it as a new row.
+//
+// If datum is invalid, Decode returns an error and discards all the rows
+// appended since the last call to NewRecord.
+func (d *Decoder) Decode(datum []byte) error {
+	if err := d.decode(datum); err != nil {
+		// the fields may hold different numbers of values: discard them
+		// one by one.
+		for _, b := range d.bld.Fields() {
+			b.NewArray().Release()
+		}
+		return fmt.Errorf("arrow/avro: could not decode datum: %w", err)
+	}
+	return nil
+}
+
+func (d *Decoder) decode(buf []byte) error {
+	dd := datum{buf: buf}
+	if err := decodeRecord(d.bld, d.root, &dd); err != nil {
+		return err
+	}
+	if len(dd.buf) != 0 {
+		return errCorrupt
+	}
+	return nil
+}
+
+// NewRecord returns a record holding the rows decoded since the last call
+// to NewRecord.
+// The returned record must be released after use.
+func (d *Decoder) NewRecord() array.Record {
+	return d.bld.NewRecord()
+}
@@ -0,0 +1,164 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestDecoder(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	avro := `{"type": "record", "name": "R", "fields": [
+		{"name": "id", "type": "long"},
+		{"name": "name", "type": ["string", "null"]}
+	]}`
+	dec, err := NewDecoder([]byte(avro), WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dec.Release()
+
+	for _, datum := range [][]byte{
+		{0x80, 0x01, 0x00, 0x06, 'f', 'o', 'o'}, // 64, "foo"
+		{0x01, 0x02},                            // -1, null
+	} {
+		if err := dec.Decode(datum); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rec := dec.NewRecord()
+	defer rec.Release()
+	if got, want := jsonOf(t, rec), "{\"id\":64,\"name\":\"foo\"}\n{\"id\":-1,\"name\":null}\n"; got != want {
+		t.Fatalf("invalid record:\ngot= %q\nwant=%q", got, want)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		datum []byte
+		err   string
+	}{
+		{"truncated", []byte{0x80}, `arrow/avro: could not decode datum: field "id": corrupt datum`},
+		{"branch", []byte{0x02, 0x04}, `arrow/avro: could not decode datum: field "name": corrupt datum`},
+		{"string", []byte{0x02, 0x00, 0x06, 'f'}, `arrow/avro: could not decode datum: field "name": corrupt datum`},
+		{"trailing", []byte{0x02, 0x02, 0x00}, `arrow/avro: could not decode datum: corrupt datum`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := dec.Decode([]byte{0x02, 0x02}); err != nil {
+				t.Fatal(err)
+			}
+			if err := dec.Decode(tc.datum); err == nil || err.Error() != tc.err {
+				t.Fatalf("invalid error: got=%v, want=%q", err, tc.err)
+			}
+			// the rows appended before the error are discarded.
+			rec := dec.NewRecord()
+			defer rec.Release()
+			if rec.NumRows() != 0 {
+				t.Fatalf("invalid number of rows: %d", rec.NumRows())
+			}
+		})
+	}
+}
+
+func TestAppendDatum(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "i8", Type: arrow.PrimitiveTypes.Int8},
+		{Name: "u16", Type: arrow.PrimitiveTypes.Uint16, Nullable: true},
+		{Name: "day", Type: arrow.PrimitiveTypes.Date64},
+		{Name: "tod", Type: arrow.FixedWidthTypes.Time32s},
+		{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Second}},
+		{Name: "attrs", Type: arrow.MapOf(arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int64)},
+	}, nil)
+
+	bld := array.NewRecordBuilder(mem, schema)
+	defer bld.Release()
+	bld.Field(0).(*array.Int8Builder).AppendValues([]int8{-1, 2}, nil)
+	bld.Field(1).(*array.Uint16Builder).AppendValues([]uint16{65535, 0}, []bool{true, false})
+	bld.Field(2).(*array.Date64Builder).AppendValues([]arrow.Date64{86400000, -86400000}, nil)
+	bld.Field(3).(*array.Time32Builder).AppendValues([]arrow.Time32{1, 2}, nil)
+	bld.Field(4).(*array.TimestampBuilder).AppendValues([]arrow.Timestamp{3, 4}, nil)
+	mb := bld.Field(5).(*array.MapBuilder)
+	mb.Append(true)
+	mb.KeyBuilder().(*array.StringBuilder).AppendValues([]string{"a", "b"}, nil)
+	mb.ItemBuilder().(*array.Int64Builder).AppendValues([]int64{1, 0}, []bool{true, false})
+	mb.Append(true)
+	rec := bld.NewRecord()
+	defer rec.Release()
+
+	avro, err := AvroSchemaOf(schema, "R")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec, err := NewDecoder(avro, WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dec.Release()
+
+	// encode the rows of a slice, to exercise the offsets of the columns.
+	slice := rec.NewSlice(1, 2)
+	defer slice.Release()
+	var buf []byte
+	for _, row := range []struct {
+		rec array.Record
+		i   int
+	}{{rec, 0}, {slice, 0}} {
+		buf, err = AppendDatum(buf[:0], row.rec, row.i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := dec.Decode(buf); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got := dec.NewRecord()
+	defer got.Release()
+
+	if v := got.Column(0).(*array.Int32).Int32Values(); v[0] != -1 || v[1] != 2 {
+		t.Errorf("invalid i8 values: %v", v)
+	}
+	if c := got.Column(1).(*array.Int32); c.Value(0) != 65535 || !c.IsNull(1) {
+		t.Errorf("invalid u16 values: %v", c)
+	}
+	if v := got.Column(2).(*array.Date32).Date32Values(); v[0] != 1 || v[1] != -1 {
+		t.Errorf("invalid day values: %v", v)
+	}
+	if v := got.Column(3).(*array.Time32).Time32Values(); v[0] != 1000 || v[1] != 2000 {
+		t.Errorf("invalid tod values: %v", v)
+	}
+	if v := got.Column(4).(*array.Timestamp).TimestampValues(); v[0] != 3000 || v[1] != 4000 {
+		t.Errorf("invalid ts values: %v", v)
+	}
+	attrs := got.Column(5).(*array.Map)
+	items := attrs.Items().(*array.Int64)
+	if o := attrs.Offsets(); len(o) != 3 || o[1] != 2 || o[2] != 2 || items.Value(0) != 1 || !items.IsNull(1) {
+		t.Errorf("invalid attrs: offsets=%v, items=%v", o, items)
+	}
+
+	if _, err := AppendDatum(nil, rec, 2); err == nil || err.Error() != "arrow/avro: row 2 out of range [0, 2)" {
+		t.Fatalf("invalid error: %v", err)
+	}
+}
@@ -0,0 +1,298 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+var magic = []byte("Obj\x01")
+
+const (
+	syncSize = 16
+
+	// maxBlockSize bounds the size of the blocks and metadata values read
+	// from a file, so that corrupt sizes do not exhaust memory.
+	maxBlockSize = 1 << 30
+)
+
+// Reader reads an Avro object container file and creates array.Records from
+// the datums of its blocks, with the schema given by SchemaOf for the writer
+// schema stored in the header of the file.
+type Reader struct {
+	r      *bufio.Reader
+	schema *arrow.Schema
+	root   *node
+	avro   []byte
+	codec  Codec
+	sync   [syncSize]byte
+
+	refs int64
+	bld  *array.RecordBuilder
+	cur  array.Record
+	err  error
+
+	block datum // datums of the current block
+	left  int64 // number of datums left in the current block
+	nblks int   // number of blocks read
+	chunk int
+	done  bool
+
+	mem memory.Allocator
+}
+
+// NewReader returns a reader that reads the Avro object container file from r.
+//
+// NewReader reads the header of the file, and returns an error if it is not
+// a valid object container file, if its schema can not be represented as an
+// Arrow schema, or if its blocks are compressed with an unsupported codec.
+func NewReader(r io.Reader, opts ...Option) (*Reader, error) {
+	rr := &Reader{
+		r:     bufio.NewReader(r),
+		refs:  1,
+		chunk: 1,
+	}
+	for _, opt := range opts {
+		opt(rr)
+	}
+	if rr.mem == nil {
+		rr.mem = memory.DefaultAllocator
+	}
+
+	if err := rr.readHeader(); err != nil {
+		return nil, err
+	}
+	rr.bld = array.NewRecordBuilder(rr.mem, rr.schema)
+	return rr, nil
+}
+
+func (r *Reader) readHeader() error {
+	var head [4]byte
+	if _, err := io.ReadFull(r.r, head[:]); err != nil || !bytes.Equal(head[:], magic) {
+		return fmt.Errorf("arrow/avro: not an object container file")
+	}
+
+	meta := make(map[string][]byte)
+	for {
+		n, err := r.readLong()
+		if err != nil {
+			return fmt.Errorf("arrow/avro: could not read file metadata: %w", err)
+		}
+		if n == 0 {
+			break
+		}
+		if n < 0 {
+			n = -n
+			if _, err := r.readLong(); err != nil {
+				return fmt.Errorf("arrow/avro: could not read file metadata: %w", err)
+			}
+		}
+		for i := int64(0); i < n; i++ {
+			k, err := r.readBytes()
+			if err != nil {
+				return fmt.Errorf("arrow/avro: could not read file metadata: %w", err)
+			}
+			v, err := r.readBytes()
+			if err != nil {
+				return fmt.Errorf("arrow/avro: could not read file metadata: %w", err)
+			}
+			meta[string(k)] = v
+		}
+	}
+	if _, err := io.ReadFull(r.r, r.sync[:]); err != nil {
+		return fmt.Errorf("arrow/avro: could not read sync marker: %w", err)
+	}
+
+	r.avro = meta["avro.schema"]
+	if r.avro == nil {
+		return fmt.Errorf("arrow/avro: file without a schema")
+	}
+	root, err := parseSchema(r.avro)
+	if err != nil {
+		return err
+	}
+	schema, err := schemaOf(root)
+	if err != nil {
+		return err
+	}
+	r.root, r.schema = root, schema
+
+	if name, ok := meta["avro.codec"]; ok {
+		r.codec, err = codecOf(string(name))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Reader) readLong() (int64, error) {
+	u, err := binary.ReadUvarint(r.r)
+	if err != nil {
+		return 0, err
+	}
+	return int64(u>>1) ^ -int64(u&1), nil
+}
+
+func (r *Reader) readBytes() ([]byte, error) {
+	n, err := r.readLong()
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 || n > maxBlockSize {
+		return nil, errCorrupt
+	}
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r.r, buf); err != nil {
+		return nil, noEOF(err)
+	}
+	return buf, nil
+}
+
+// noEOF turns io.EOF into io.ErrUnexpectedEOF, for reads that may not
+// reach the end of the file.
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (r *Reader) Retain() {
+	atomic.AddInt64(&r.refs, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+// Release may be called simultaneously from multiple goroutines.
+func (r *Reader) Release() {
+	debug.Assert(atomic.LoadInt64(&r.refs) > 0, "too many releases")
+
+	if atomic.AddInt64(&r.refs, -1) == 0 {
+		if r.cur != nil {
+			r.cur.Release()
+			r.cur = nil
+		}
+		if r.bld != nil {
+			r.bld.Release()
+			r.bld = nil
+		}
+	}
+}
+
+// Err returns the last error encountered during the iteration over the
+// blocks of the file.
+func (r *Reader) Err() error { return r.err }
+
+func (r *Reader) Schema() *arrow.Schema { return r.schema }
+
+// AvroSchema returns the JSON representation of the writer schema stored in
+// the header of the file.
+func (r *Reader) AvroSchema() []byte { return r.avro }
+
+// Record returns the current record that has been extracted from the
+// underlying file.
+// It is valid until the next call to Next.
+func (r *Reader) Record() array.Record { return r.cur }
+
+// Next returns whether a Record could be extracted from the underlying file.
+func (r *Reader) Next() bool {
+	if r.cur != nil {
+		r.cur.Release()
+		r.cur = nil
+	}
+	if r.err != nil || r.done {
+		return false
+	}
+
+	n := 0
+	for r.chunk < 0 || n < r.chunk || n == 0 {
+		if r.left == 0 {
+			if !r.nextBlock() {
+				break
+			}
+			continue
+		}
+		if err := decodeRecord(r.bld, r.root, &r.block); err != nil {
+			r.err = fmt.Errorf("arrow/avro: block %d: %w", r.nblks-1, err)
+			return false
+		}
+		r.left--
+		n++
+		if r.left == 0 && len(r.block.buf) != 0 {
+			r.err = fmt.Errorf("arrow/avro: block %d: %w", r.nblks-1, errCorrupt)
+			return false
+		}
+	}
+	if r.err != nil || n == 0 {
+		return false
+	}
+	r.cur = r.bld.NewRecord()
+	return true
+}
+
+// nextBlock reads the next block of the file.
+func (r *Reader) nextBlock() bool {
+	n, err := r.readLong()
+	if err == io.EOF {
+		r.done = true
+		return false
+	}
+	if err == nil && n < 0 {
+		err = errCorrupt
+	}
+	var buf []byte
+	if err == nil {
+		buf, err = r.readBytes()
+	}
+	var sync [syncSize]byte
+	if err == nil {
+		_, err = io.ReadFull(r.r, sync[:])
+		err = noEOF(err)
+	}
+	if err == nil && sync != r.sync {
+		err = errors.New("invalid sync marker")
+	}
+	if err == nil {
+		buf, err = decompress(r.codec, buf)
+	}
+	if err != nil {
+		r.err = fmt.Errorf("arrow/avro: block %d: %w", r.nblks, err)
+		return false
+	}
+	r.block = datum{buf: buf}
+	r.left = n
+	r.nblks++
+	return true
+}
+
+var (
+	_ array.RecordReader = (*Reader)(nil)
+)
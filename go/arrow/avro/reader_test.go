@@ -0,0 +1,374 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/json"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func recordFromJSON(t *testing.T, mem memory.Allocator, schema *arrow.Schema, rows ...string) array.Record {
+	t.Helper()
+
+	r := json.NewReader(strings.NewReader(strings.Join(rows, "\n")), schema,
+		json.WithAllocator(mem), json.WithChunk(-1))
+	defer r.Release()
+	if !r.Next() {
+		t.Fatalf("could not read rows: %v", r.Err())
+	}
+	rec := r.Record()
+	rec.Retain()
+	return rec
+}
+
+func jsonOf(t *testing.T, rec array.Record) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := json.NewWriter(&buf, rec.Schema(), json.WithTemporalAsNumbers(true)).Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+// writeFile writes the records to an object container file.
+func writeFile(t *testing.T, schema *arrow.Schema, recs []array.Record, opts ...Option) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, schema, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rec := range recs {
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// readAll reads the records of an object container file, as JSON rows.
+func readAll(t *testing.T, mem memory.Allocator, file []byte, opts ...Option) ([]string, *arrow.Schema) {
+	t.Helper()
+
+	r, err := NewReader(bytes.NewReader(file), append(opts, WithAllocator(mem))...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	var recs []string
+	for r.Next() {
+		recs = append(recs, jsonOf(t, r.Record()))
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return recs, r.Schema()
+}
+
+var testSchema = arrow.NewSchema([]arrow.Field{
+	{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+	{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "ok", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+	{Name: "small", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+	{Name: "ratio", Type: arrow.PrimitiveTypes.Float32, Nullable: true},
+	{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	{Name: "raw", Type: arrow.BinaryTypes.Binary, Nullable: true},
+	{Name: "hash", Type: &arrow.FixedSizeBinaryType{ByteWidth: 2}, Nullable: true},
+	{Name: "day", Type: arrow.PrimitiveTypes.Date32, Nullable: true},
+	{Name: "tod", Type: arrow.FixedWidthTypes.Time32ms, Nullable: true},
+	{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, Nullable: true},
+	{Name: "tags", Type: arrow.ListOf(arrow.BinaryTypes.String), Nullable: true},
+	{Name: "address", Type: arrow.StructOf(
+		arrow.Field{Name: "city", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "zip", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+	), Nullable: true},
+}, nil)
+
+var testRows = []string{
+	`{"id": 1, "name": "alice", "ok": true, "small": -3, "ratio": 0.5, "score": 1.25, "raw": "AAE=", "hash": "AQI=", "day": 18000, "tod": 1000, "ts": 1600000000000000, "tags": ["a", null, "b"], "address": {"city": "Paris", "zip": 75001}}`,
+	`{"id": 2}`,
+	`{"id": -300, "name": "", "ok": false, "tags": [], "address": {"city": "Lyon"}}`,
+}
+
+func TestReaderWriter(t *testing.T) {
+	for _, codec := range []Codec{Uncompressed, Deflate, Snappy} {
+		t.Run(codec.String(), func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			rec1 := recordFromJSON(t, mem, testSchema, testRows[:2]...)
+			defer rec1.Release()
+			rec2 := recordFromJSON(t, mem, testSchema, testRows[2:]...)
+			defer rec2.Release()
+
+			file := writeFile(t, testSchema, []array.Record{rec1, rec2}, WithCodec(codec))
+
+			got, schema := readAll(t, mem, file, WithChunk(-1))
+			if !schema.Equal(testSchema) {
+				t.Fatalf("invalid schema:\ngot= %v\nwant=%v", schema, testSchema)
+			}
+			all := recordFromJSON(t, mem, testSchema, testRows...)
+			defer all.Release()
+			if want := []string{jsonOf(t, all)}; len(got) != 1 || got[0] != want[0] {
+				t.Fatalf("invalid records:\ngot= %q\nwant=%q", got, want)
+			}
+		})
+	}
+}
+
+func TestReaderChunk(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	rec1 := recordFromJSON(t, mem, schema, `{"id": 1}`, `{"id": 2}`, `{"id": 3}`)
+	defer rec1.Release()
+	rec2 := recordFromJSON(t, mem, schema, `{"id": 4}`, `{"id": 5}`)
+	defer rec2.Release()
+	empty := recordFromJSON(t, mem, schema, `{"id": 0}`)
+	defer empty.Release()
+	empty = empty.NewSlice(0, 0)
+	defer empty.Release()
+
+	file := writeFile(t, schema, []array.Record{rec1, empty, rec2})
+
+	for _, tc := range []struct {
+		chunk int
+		want  []string
+	}{
+		{0, []string{"{\"id\":1}\n", "{\"id\":2}\n", "{\"id\":3}\n", "{\"id\":4}\n", "{\"id\":5}\n"}},
+		{2, []string{"{\"id\":1}\n{\"id\":2}\n", "{\"id\":3}\n{\"id\":4}\n", "{\"id\":5}\n"}},
+		{-1, []string{"{\"id\":1}\n{\"id\":2}\n{\"id\":3}\n{\"id\":4}\n{\"id\":5}\n"}},
+	} {
+		got, _ := readAll(t, mem, file, WithChunk(tc.chunk))
+		if strings.Join(got, "|") != strings.Join(tc.want, "|") {
+			t.Errorf("chunk=%d: invalid records:\ngot= %q\nwant=%q", tc.chunk, got, tc.want)
+		}
+	}
+}
+
+// TestReaderFile reads a file whose header holds blocks of metadata with
+// negative counts, and whose schema holds types Avro writers produce but
+// AvroSchemaOf does not, like enums and maps.
+func TestReaderFile(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	avro := `{"type": "record", "name": "R", "fields": [
+		{"name": "color", "type": {"type": "enum", "name": "Color", "symbols": ["RED", "GREEN"]}},
+		{"name": "attrs", "type": {"type": "map", "values": "long"}}
+	]}`
+	file := append([]byte(nil), magic...)
+	file = appendLong(file, -1)
+	file = appendLong(file, int64(len("avro.schema")+len(avro)+2))
+	file = appendBytes(file, []byte("avro.schema"))
+	file = appendBytes(file, []byte(avro))
+	file = appendLong(file, 0)
+	sync := bytes.Repeat([]byte{0xab}, syncSize)
+	file = append(file, sync...)
+
+	// GREEN, {"a": 1, "b": -1} in two blocks; RED, {}
+	var data []byte
+	data = appendLong(data, 1)
+	data = appendLong(data, 1)
+	data = appendBytes(data, []byte("a"))
+	data = appendLong(data, 1)
+	data = appendLong(data, -1)
+	data = appendLong(data, 3)
+	data = appendBytes(data, []byte("b"))
+	data = appendLong(data, -1)
+	data = appendLong(data, 0)
+	data = appendLong(data, 0)
+	data = appendLong(data, 0)
+
+	file = appendLong(file, 2)
+	file = appendBytes(file, data)
+	file = append(file, sync...)
+
+	r, err := NewReader(bytes.NewReader(file), WithAllocator(mem), WithChunk(-1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	if string(r.AvroSchema()) != avro {
+		t.Fatalf("invalid avro schema: %s", r.AvroSchema())
+	}
+	if !r.Next() {
+		t.Fatalf("could not read record: %v", r.Err())
+	}
+	rec := r.Record()
+	colors := rec.Column(0).(*array.String)
+	if colors.Len() != 2 || colors.Value(0) != "GREEN" || colors.Value(1) != "RED" {
+		t.Fatalf("invalid colors: %v", colors)
+	}
+	attrs := rec.Column(1).(*array.Map)
+	if got, want := attrs.Offsets(), []int32{0, 2, 2}; !equalInt32s(got, want) {
+		t.Fatalf("invalid map offsets: got=%v, want=%v", got, want)
+	}
+	keys := attrs.Keys().(*array.String)
+	items := attrs.Items().(*array.Int64)
+	if keys.Value(0) != "a" || keys.Value(1) != "b" || items.Value(0) != 1 || items.Value(1) != -1 {
+		t.Fatalf("invalid map entries: keys=%v, items=%v", keys, items)
+	}
+	if r.Next() {
+		t.Fatalf("unexpected record")
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func equalInt32s(a, b []int32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNewReaderErrors(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	header := func(meta ...string) []byte {
+		file := append([]byte(nil), magic...)
+		file = appendLong(file, int64(len(meta)/2))
+		for _, m := range meta {
+			file = appendBytes(file, []byte(m))
+		}
+		file = appendLong(file, 0)
+		return append(file, make([]byte, syncSize)...)
+	}
+	avro, err := AvroSchemaOf(schema, "R")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		file []byte
+		err  string
+	}{
+		{"empty", nil, "arrow/avro: not an object container file"},
+		{"magic", []byte("Obj\x02"), "arrow/avro: not an object container file"},
+		{"truncated", header("avro.schema", string(avro))[:10], "arrow/avro: could not read file metadata"},
+		{"no-schema", header(), "arrow/avro: file without a schema"},
+		{"codec", header("avro.schema", string(avro), "avro.codec", "zstandard"), `arrow/avro: unsupported compression codec "zstandard"`},
+		{"schema", header("avro.schema", `"long"`), "arrow/avro: schema is not a record"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewReader(bytes.NewReader(tc.file))
+			if err == nil || !strings.HasPrefix(err.Error(), tc.err) {
+				t.Fatalf("invalid error: got=%v, want=%q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestReaderCorrupt(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "name", Type: arrow.BinaryTypes.String}}, nil)
+	rec := recordFromJSON(t, mem, schema, `{"name": "alice"}`, `{"name": "bob"}`)
+	defer rec.Release()
+	file := writeFile(t, schema, []array.Record{rec, rec}, WithCodec(Snappy))
+
+	for _, tc := range []struct {
+		name  string
+		patch func(file []byte) []byte
+		err   string
+	}{
+		{"sync", func(file []byte) []byte { file[len(file)-1] ^= 0xff; return file }, "arrow/avro: block 1: invalid sync marker"},
+		{"truncated", func(file []byte) []byte { return file[:len(file)-2] }, "arrow/avro: block 1: unexpected EOF"},
+		{"checksum", func(file []byte) []byte { file[len(file)-syncSize-1] ^= 0xff; return file }, "arrow/avro: block 1: invalid snappy block checksum"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			file := tc.patch(append([]byte(nil), file...))
+			r, err := NewReader(bytes.NewReader(file), WithAllocator(mem))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Release()
+
+			n := 0
+			for r.Next() {
+				n++
+			}
+			if n != 2 {
+				t.Fatalf("invalid number of records: got=%d, want=2", n)
+			}
+			if err := r.Err(); err == nil || err.Error() != tc.err {
+				t.Fatalf("invalid error: got=%v, want=%q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestWriterErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	if _, err := NewWriter(new(bytes.Buffer), schema, WithCodec(Codec(42))); err == nil || err.Error() != `arrow/avro: unsupported compression codec "Codec(42)"` {
+		t.Fatalf("invalid error: %v", err)
+	}
+	if _, err := NewWriter(new(bytes.Buffer), schema, WithRecordName("a-b")); err == nil || err.Error() != `arrow/avro: invalid name "a-b"` {
+		t.Fatalf("invalid error: %v", err)
+	}
+
+	w, err := NewWriter(new(bytes.Buffer), schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other := recordFromJSON(t, mem, testSchema, testRows...)
+	defer other.Release()
+	if err := w.Write(other); err != ErrMismatchFields {
+		t.Fatalf("invalid error: %v", err)
+	}
+
+	nulls := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: true}}, nil)
+	rec := recordFromJSON(t, mem, nulls, `{"id": 1}`, `{"id": null}`)
+	defer rec.Release()
+	rec = array.NewRecord(schema, rec.Columns(), rec.NumRows())
+	defer rec.Release()
+	if err := w.Write(rec); err == nil || err.Error() != `arrow/avro: field "id": null value in non-nullable field` {
+		t.Fatalf("invalid error: %v", err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(rec); err == nil || err.Error() != "arrow/avro: write to closed writer" {
+		t.Fatalf("invalid error: %v", err)
+	}
+}
@@ -0,0 +1,472 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/apache/arrow/go/arrow"
+)
+
+// node is a parsed Avro schema.
+type node struct {
+	typ     string // primitive type name, or "record", "enum", "fixed", "array" or "map"
+	logical string // logical type, if any
+	name    string // full name of named types
+	size    int    // size of fixed values
+	symbols []string
+	fields  []recordField
+	items   *node // items of arrays, values of maps
+
+	// null is the index of the null branch when the node is a
+	// ["null", T] union of its type with null, or -1.
+	null int64
+}
+
+type recordField struct {
+	name string
+	node *node
+}
+
+var primitives = map[string]bool{
+	"null":    true,
+	"boolean": true,
+	"int":     true,
+	"long":    true,
+	"float":   true,
+	"double":  true,
+	"bytes":   true,
+	"string":  true,
+}
+
+// parseSchema parses the JSON representation of an Avro schema.
+func parseSchema(schema []byte) (*node, error) {
+	var v interface{}
+	if err := json.Unmarshal(schema, &v); err != nil {
+		return nil, fmt.Errorf("arrow/avro: could not decode schema: %w", err)
+	}
+	p := parser{names: make(map[string]*node)}
+	n, err := p.parse(v, "")
+	if err != nil {
+		return nil, fmt.Errorf("arrow/avro: invalid schema: %w", err)
+	}
+	return n, nil
+}
+
+type parser struct {
+	names map[string]*node
+}
+
+func (p *parser) parse(v interface{}, namespace string) (*node, error) {
+	switch v := v.(type) {
+	case string:
+		if primitives[v] {
+			return &node{typ: v, null: -1}, nil
+		}
+		if n, ok := p.names[fullName(v, namespace)]; ok {
+			return n, nil
+		}
+		if n, ok := p.names[v]; ok {
+			return n, nil
+		}
+		return nil, fmt.Errorf("unknown type %q", v)
+
+	case []interface{}:
+		if len(v) != 2 || (v[0] != "null") == (v[1] != "null") {
+			return nil, fmt.Errorf("unsupported union %v", v)
+		}
+		null := int64(0)
+		if v[1] == "null" {
+			null = 1
+		}
+		n, err := p.parse(v[1-null], namespace)
+		if err != nil {
+			return nil, err
+		}
+		u := *n
+		u.null = null
+		return &u, nil
+
+	case map[string]interface{}:
+		return p.parseComplex(v, namespace)
+	}
+	return nil, fmt.Errorf("invalid type %v", v)
+}
+
+func (p *parser) parseComplex(v map[string]interface{}, namespace string) (*node, error) {
+	typ, ok := v["type"].(string)
+	if !ok {
+		// a type wrapped in an object, as in {"type": {"type": "array", ...}}
+		return p.parse(v["type"], namespace)
+	}
+	logical, _ := v["logicalType"].(string)
+	if primitives[typ] {
+		return &node{typ: typ, logical: logical, null: -1}, nil
+	}
+
+	n := &node{typ: typ, logical: logical, null: -1}
+	switch typ {
+	case "record", "error", "enum", "fixed":
+		if typ == "error" {
+			n.typ = "record"
+		}
+		name, _ := v["name"].(string)
+		if ns, ok := v["namespace"].(string); ok && !strings.Contains(name, ".") {
+			namespace = ns
+		}
+		if name == "" {
+			return nil, fmt.Errorf("%s without a name", typ)
+		}
+		n.name = fullName(name, namespace)
+		if i := strings.LastIndex(n.name, "."); i >= 0 {
+			namespace = n.name[:i]
+		}
+		if _, dup := p.names[n.name]; dup {
+			return nil, fmt.Errorf("duplicate type %q", n.name)
+		}
+		p.names[n.name] = n
+	}
+
+	switch n.typ {
+	case "record":
+		fields, _ := v["fields"].([]interface{})
+		for _, f := range fields {
+			f, ok := f.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid field in record %q", n.name)
+			}
+			name, _ := f["name"].(string)
+			fn, err := p.parse(f["type"], namespace)
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", name, err)
+			}
+			n.fields = append(n.fields, recordField{name: name, node: fn})
+		}
+	case "enum":
+		symbols, _ := v["symbols"].([]interface{})
+		for _, s := range symbols {
+			s, ok := s.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid symbol in enum %q", n.name)
+			}
+			n.symbols = append(n.symbols, s)
+		}
+	case "fixed":
+		size, ok := v["size"].(float64)
+		if !ok || size < 0 {
+			return nil, fmt.Errorf("invalid size of fixed %q", n.name)
+		}
+		n.size = int(size)
+	case "array", "map":
+		key := "items"
+		if n.typ == "map" {
+			key = "values"
+		}
+		items, err := p.parse(v[key], namespace)
+		if err != nil {
+			return nil, err
+		}
+		n.items = items
+	default:
+		return nil, fmt.Errorf("unknown type %q", typ)
+	}
+	return n, nil
+}
+
+func fullName(name, namespace string) string {
+	if namespace == "" || strings.Contains(name, ".") {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// SchemaOf returns the Arrow schema of the records decoded from Avro datums
+// written with the given Avro schema, which must be a record.
+//
+// Avro records are mapped to Arrow structs, arrays to lists and maps to maps
+// with string keys. Enums are mapped to strings, and unions of a type with
+// null to nullable fields of that type. Other unions, decimal values and
+// recursive types are not supported.
+func SchemaOf(avroSchema []byte) (*arrow.Schema, error) {
+	root, err := parseSchema(avroSchema)
+	if err != nil {
+		return nil, err
+	}
+	return schemaOf(root)
+}
+
+func schemaOf(root *node) (*arrow.Schema, error) {
+	if root.typ != "record" || root.null >= 0 {
+		return nil, fmt.Errorf("arrow/avro: schema is not a record")
+	}
+	fields, err := fieldsOf(root, map[string]bool{})
+	if err != nil {
+		return nil, fmt.Errorf("arrow/avro: %w", err)
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+func fieldsOf(n *node, visiting map[string]bool) ([]arrow.Field, error) {
+	if visiting[n.name] {
+		return nil, fmt.Errorf("recursive type %q", n.name)
+	}
+	visiting[n.name] = true
+	defer delete(visiting, n.name)
+
+	fields := make([]arrow.Field, len(n.fields))
+	for i, f := range n.fields {
+		dt, err := dataTypeOf(f.node, visiting)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", f.name, err)
+		}
+		fields[i] = arrow.Field{
+			Name:     f.name,
+			Type:     dt,
+			Nullable: f.node.null >= 0 || f.node.typ == "null",
+		}
+	}
+	return fields, nil
+}
+
+func dataTypeOf(n *node, visiting map[string]bool) (arrow.DataType, error) {
+	switch n.typ {
+	case "null":
+		return arrow.Null, nil
+	case "boolean":
+		return arrow.FixedWidthTypes.Boolean, nil
+	case "int":
+		switch n.logical {
+		case "date":
+			return arrow.PrimitiveTypes.Date32, nil
+		case "time-millis":
+			return arrow.FixedWidthTypes.Time32ms, nil
+		}
+		return arrow.PrimitiveTypes.Int32, nil
+	case "long":
+		switch n.logical {
+		case "time-micros":
+			return arrow.FixedWidthTypes.Time64us, nil
+		case "timestamp-millis":
+			return &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}, nil
+		case "timestamp-micros":
+			return &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, nil
+		case "timestamp-nanos":
+			return &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"}, nil
+		case "local-timestamp-millis":
+			return &arrow.TimestampType{Unit: arrow.Millisecond}, nil
+		case "local-timestamp-micros":
+			return &arrow.TimestampType{Unit: arrow.Microsecond}, nil
+		case "local-timestamp-nanos":
+			return &arrow.TimestampType{Unit: arrow.Nanosecond}, nil
+		}
+		return arrow.PrimitiveTypes.Int64, nil
+	case "float":
+		return arrow.PrimitiveTypes.Float32, nil
+	case "double":
+		return arrow.PrimitiveTypes.Float64, nil
+	case "bytes":
+		if n.logical == "decimal" {
+			return nil, fmt.Errorf("unsupported decimal type")
+		}
+		return arrow.BinaryTypes.Binary, nil
+	case "string", "enum":
+		return arrow.BinaryTypes.String, nil
+	case "fixed":
+		if n.logical == "decimal" {
+			return nil, fmt.Errorf("unsupported decimal type")
+		}
+		return &arrow.FixedSizeBinaryType{ByteWidth: n.size}, nil
+	case "array":
+		elem, err := dataTypeOf(n.items, visiting)
+		if err != nil {
+			return nil, err
+		}
+		return arrow.ListOf(elem), nil
+	case "map":
+		item, err := dataTypeOf(n.items, visiting)
+		if err != nil {
+			return nil, err
+		}
+		return arrow.MapOf(arrow.BinaryTypes.String, item), nil
+	case "record":
+		fields, err := fieldsOf(n, visiting)
+		if err != nil {
+			return nil, err
+		}
+		return arrow.StructOf(fields...), nil
+	}
+	return nil, fmt.Errorf("unsupported type %q", n.typ)
+}
+
+var validName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type avroRecord struct {
+	Type      string      `json:"type"`
+	Name      string      `json:"name"`
+	Namespace string      `json:"namespace,omitempty"`
+	Fields    []avroField `json:"fields"`
+}
+
+type avroField struct {
+	Name    string          `json:"name"`
+	Type    interface{}     `json:"type"`
+	Default json.RawMessage `json:"default,omitempty"`
+}
+
+type avroFixed struct {
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+	Size      int    `json:"size"`
+}
+
+type avroLogical struct {
+	Type        string `json:"type"`
+	LogicalType string `json:"logicalType"`
+}
+
+type avroArray struct {
+	Type  string      `json:"type"`
+	Items interface{} `json:"items"`
+}
+
+type avroMap struct {
+	Type   string      `json:"type"`
+	Values interface{} `json:"values"`
+}
+
+// AvroSchemaOf returns the JSON representation of the Avro schema of the
+// datums encoded from records with the given Arrow schema. The records are
+// encoded as Avro records with the given full name.
+//
+// Nullable fields, list items and map items are encoded as unions with null.
+// Structs are encoded as records named after their field, in the namespace
+// of their parent. Integers of up to 32 bits are encoded as Avro ints, and
+// 64-bit integers and uint32 values as Avro longs.
+// Date64 values are encoded as dates, and Time32 and Timestamp values with a
+// second unit as time-millis and timestamp-millis values.
+//
+// AvroSchemaOf returns an error if the schema holds fields of an unsupported
+// type, or if a name is not a valid Avro name.
+func AvroSchemaOf(schema *arrow.Schema, name string) ([]byte, error) {
+	rec, err := avroRecordOf(schema.Fields(), name, "")
+	if err != nil {
+		return nil, fmt.Errorf("arrow/avro: %w", err)
+	}
+	return json.Marshal(rec)
+}
+
+func avroRecordOf(fields []arrow.Field, name, namespace string) (*avroRecord, error) {
+	full := fullName(name, namespace)
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		namespace, name = name[:i], name[i+1:]
+	}
+	if !validName.MatchString(name) {
+		return nil, fmt.Errorf("invalid name %q", name)
+	}
+	rec := &avroRecord{Type: "record", Name: name, Namespace: namespace}
+	for _, f := range fields {
+		if !validName.MatchString(f.Name) {
+			return nil, fmt.Errorf("invalid field name %q", f.Name)
+		}
+		typ, err := avroTypeOf(f.Type, f.Name, full)
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", f.Name, err)
+		}
+		af := avroField{Name: f.Name, Type: typ}
+		if f.Nullable && f.Type.ID() != arrow.NULL {
+			af.Type = []interface{}{"null", typ}
+			af.Default = json.RawMessage("null")
+		}
+		rec.Fields = append(rec.Fields, af)
+	}
+	return rec, nil
+}
+
+func avroTypeOf(dt arrow.DataType, name, namespace string) (interface{}, error) {
+	switch dt := dt.(type) {
+	case *arrow.NullType:
+		return "null", nil
+	case *arrow.BooleanType:
+		return "boolean", nil
+	case *arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type, *arrow.Uint8Type, *arrow.Uint16Type:
+		return "int", nil
+	case *arrow.Int64Type, *arrow.Uint32Type:
+		return "long", nil
+	case *arrow.Float32Type:
+		return "float", nil
+	case *arrow.Float64Type:
+		return "double", nil
+	case *arrow.BinaryType:
+		return "bytes", nil
+	case *arrow.StringType:
+		return "string", nil
+	case *arrow.FixedSizeBinaryType:
+		return avroFixed{Type: "fixed", Name: name, Namespace: namespace, Size: dt.ByteWidth}, nil
+	case *arrow.Date32Type, *arrow.Date64Type:
+		return avroLogical{Type: "int", LogicalType: "date"}, nil
+	case *arrow.Time32Type:
+		return avroLogical{Type: "int", LogicalType: "time-millis"}, nil
+	case *arrow.Time64Type:
+		if dt.Unit != arrow.Microsecond {
+			break
+		}
+		return avroLogical{Type: "long", LogicalType: "time-micros"}, nil
+	case *arrow.TimestampType:
+		logical := "timestamp-"
+		if dt.TimeZone == "" {
+			logical = "local-timestamp-"
+		}
+		switch dt.Unit {
+		case arrow.Second, arrow.Millisecond:
+			logical += "millis"
+		case arrow.Microsecond:
+			logical += "micros"
+		case arrow.Nanosecond:
+			logical += "nanos"
+		}
+		return avroLogical{Type: "long", LogicalType: logical}, nil
+	case *arrow.ListType:
+		items, err := avroTypeOf(dt.Elem(), name+"_item", namespace)
+		if err != nil {
+			return nil, err
+		}
+		return avroArray{Type: "array", Items: nullable(dt.Elem(), items)}, nil
+	case *arrow.MapType:
+		if dt.KeyType().ID() != arrow.STRING {
+			return nil, fmt.Errorf("unsupported map key type %s", dt.KeyType().Name())
+		}
+		items, err := avroTypeOf(dt.ItemType(), name+"_value", namespace)
+		if err != nil {
+			return nil, err
+		}
+		return avroMap{Type: "map", Values: nullable(dt.ItemType(), items)}, nil
+	case *arrow.StructType:
+		return avroRecordOf(dt.Fields(), name, namespace)
+	}
+	return nil, fmt.Errorf("unsupported type %s", dt.Name())
+}
+
+func nullable(dt arrow.DataType, typ interface{}) interface{} {
+	if dt.ID() == arrow.NULL {
+		return typ
+	}
+	return []interface{}{"null", typ}
+}
@@ -0,0 +1,199 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+)
+
+func TestSchemaOf(t *testing.T) {
+	avro := `{
+		"type": "record", "name": "User", "namespace": "com.example",
+		"fields": [
+			{"name": "id", "type": "long"},
+			{"name": "name", "type": ["null", "string"]},
+			{"name": "score", "type": ["double", "null"]},
+			{"name": "ok", "type": "boolean"},
+			{"name": "small", "type": "int"},
+			{"name": "ratio", "type": "float"},
+			{"name": "raw", "type": "bytes"},
+			{"name": "uuid", "type": {"type": "string", "logicalType": "uuid"}},
+			{"name": "hash", "type": {"type": "fixed", "name": "MD5", "size": 16}},
+			{"name": "hash2", "type": "MD5"},
+			{"name": "color", "type": {"type": "enum", "name": "Color", "symbols": ["RED", "GREEN"]}},
+			{"name": "day", "type": {"type": "int", "logicalType": "date"}},
+			{"name": "tod", "type": {"type": "int", "logicalType": "time-millis"}},
+			{"name": "tod_us", "type": {"type": "long", "logicalType": "time-micros"}},
+			{"name": "ts", "type": {"type": "long", "logicalType": "timestamp-micros"}},
+			{"name": "local", "type": {"type": "long", "logicalType": "local-timestamp-millis"}},
+			{"name": "tags", "type": {"type": "array", "items": "string"}},
+			{"name": "attrs", "type": {"type": "map", "values": ["null", "long"]}},
+			{"name": "address", "type": ["null", {
+				"type": "record", "name": "Address",
+				"fields": [{"name": "city", "type": "string"}]
+			}]},
+			{"name": "previous", "type": {"type": "array", "items": "com.example.Address"}},
+			{"name": "nothing", "type": "null"}
+		]
+	}`
+
+	got, err := SchemaOf([]byte(avro))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := arrow.StructOf(arrow.Field{Name: "city", Type: arrow.BinaryTypes.String})
+	want := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "ok", Type: arrow.FixedWidthTypes.Boolean},
+		{Name: "small", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "ratio", Type: arrow.PrimitiveTypes.Float32},
+		{Name: "raw", Type: arrow.BinaryTypes.Binary},
+		{Name: "uuid", Type: arrow.BinaryTypes.String},
+		{Name: "hash", Type: &arrow.FixedSizeBinaryType{ByteWidth: 16}},
+		{Name: "hash2", Type: &arrow.FixedSizeBinaryType{ByteWidth: 16}},
+		{Name: "color", Type: arrow.BinaryTypes.String},
+		{Name: "day", Type: arrow.PrimitiveTypes.Date32},
+		{Name: "tod", Type: arrow.FixedWidthTypes.Time32ms},
+		{Name: "tod_us", Type: arrow.FixedWidthTypes.Time64us},
+		{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}},
+		{Name: "local", Type: &arrow.TimestampType{Unit: arrow.Millisecond}},
+		{Name: "tags", Type: arrow.ListOf(arrow.BinaryTypes.String)},
+		{Name: "attrs", Type: arrow.MapOf(arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int64)},
+		{Name: "address", Type: address, Nullable: true},
+		{Name: "previous", Type: arrow.ListOf(address)},
+		{Name: "nothing", Type: arrow.Null, Nullable: true},
+	}, nil)
+
+	if !got.Equal(want) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", got, want)
+	}
+}
+
+func TestSchemaOfErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		schema string
+		err    string
+	}{
+		{"json", `{`, "arrow/avro: could not decode schema"},
+		{"not-record", `"long"`, "arrow/avro: schema is not a record"},
+		{"unknown", `{"type": "record", "name": "R", "fields": [{"name": "a", "type": "Foo"}]}`, `arrow/avro: invalid schema: field "a": unknown type "Foo"`},
+		{"union", `{"type": "record", "name": "R", "fields": [{"name": "a", "type": ["int", "string"]}]}`, `arrow/avro: invalid schema: field "a": unsupported union`},
+		{"decimal", `{"type": "record", "name": "R", "fields": [{"name": "a", "type": {"type": "bytes", "logicalType": "decimal", "precision": 4}}]}`, `arrow/avro: field "a": unsupported decimal type`},
+		{"recursive", `{"type": "record", "name": "R", "fields": [{"name": "next", "type": ["null", "R"]}]}`, `arrow/avro: field "next": recursive type "R"`},
+		{"duplicate", `{"type": "record", "name": "R", "fields": [{"name": "a", "type": {"type": "fixed", "name": "R", "size": 1}}]}`, `arrow/avro: invalid schema: field "a": duplicate type "R"`},
+		{"no-name", `{"type": "record", "fields": []}`, "arrow/avro: invalid schema: record without a name"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := SchemaOf([]byte(tc.schema))
+			if err == nil || !strings.HasPrefix(err.Error(), tc.err) {
+				t.Fatalf("invalid error: got=%v, want=%q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestAvroSchemaOf(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "i8", Type: arrow.PrimitiveTypes.Int8},
+		{Name: "u32", Type: arrow.PrimitiveTypes.Uint32},
+		{Name: "hash", Type: &arrow.FixedSizeBinaryType{ByteWidth: 4}},
+		{Name: "day", Type: arrow.PrimitiveTypes.Date64},
+		{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Second, TimeZone: "UTC"}},
+		{Name: "tags", Type: arrow.ListOf(arrow.BinaryTypes.String)},
+		{Name: "attrs", Type: arrow.MapOf(arrow.BinaryTypes.String, arrow.PrimitiveTypes.Float64)},
+		{Name: "address", Type: arrow.StructOf(
+			arrow.Field{Name: "city", Type: arrow.BinaryTypes.String},
+			arrow.Field{Name: "code", Type: &arrow.FixedSizeBinaryType{ByteWidth: 2}, Nullable: true},
+		)},
+	}, nil)
+
+	got, err := AvroSchemaOf(schema, "com.example.User")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"record","name":"User","namespace":"com.example","fields":[` +
+		`{"name":"id","type":"long"},` +
+		`{"name":"name","type":["null","string"],"default":null},` +
+		`{"name":"i8","type":"int"},` +
+		`{"name":"u32","type":"long"},` +
+		`{"name":"hash","type":{"type":"fixed","name":"hash","namespace":"com.example.User","size":4}},` +
+		`{"name":"day","type":{"type":"int","logicalType":"date"}},` +
+		`{"name":"ts","type":{"type":"long","logicalType":"timestamp-millis"}},` +
+		`{"name":"tags","type":{"type":"array","items":["null","string"]}},` +
+		`{"name":"attrs","type":{"type":"map","values":["null","double"]}},` +
+		`{"name":"address","type":{"type":"record","name":"address","namespace":"com.example.User","fields":[` +
+		`{"name":"city","type":"string"},` +
+		`{"name":"code","type":["null",{"type":"fixed","name":"code","namespace":"com.example.User.address","size":2}],"default":null}]}}]}`
+	if string(got) != want {
+		t.Fatalf("invalid avro schema:\ngot= %s\nwant=%s", got, want)
+	}
+
+	// the Avro schema maps back to the Arrow schema, up to the widened types.
+	back, err := SchemaOf(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, dt := range []arrow.DataType{
+		arrow.PrimitiveTypes.Int64,
+		arrow.BinaryTypes.String,
+		arrow.PrimitiveTypes.Int32,
+		arrow.PrimitiveTypes.Int64,
+		&arrow.FixedSizeBinaryType{ByteWidth: 4},
+		arrow.PrimitiveTypes.Date32,
+		&arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"},
+		arrow.ListOf(arrow.BinaryTypes.String),
+		arrow.MapOf(arrow.BinaryTypes.String, arrow.PrimitiveTypes.Float64),
+		schema.Field(9).Type,
+	} {
+		f := back.Field(i)
+		if !reflect.DeepEqual(f.Type, dt) || f.Nullable != schema.Field(i).Nullable {
+			t.Errorf("field %q: got=%v (nullable=%v), want=%v", f.Name, f.Type, f.Nullable, dt)
+		}
+	}
+}
+
+func TestAvroSchemaOfErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		field arrow.Field
+		rec   string
+		err   string
+	}{
+		{"uint64", arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Uint64}, "R", `arrow/avro: field "a": unsupported type uint64`},
+		{"time64ns", arrow.Field{Name: "a", Type: arrow.FixedWidthTypes.Time64ns}, "R", `arrow/avro: field "a": unsupported type`},
+		{"map-key", arrow.Field{Name: "a", Type: arrow.MapOf(arrow.PrimitiveTypes.Int64, arrow.PrimitiveTypes.Int64)}, "R", `arrow/avro: field "a": unsupported map key type int64`},
+		{"field-name", arrow.Field{Name: "a-b", Type: arrow.PrimitiveTypes.Int64}, "R", `arrow/avro: invalid field name "a-b"`},
+		{"record-name", arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int64}, "1R", `arrow/avro: invalid name "1R"`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := AvroSchemaOf(arrow.NewSchema([]arrow.Field{tc.field}, nil), tc.rec)
+			if err == nil || !strings.HasPrefix(err.Error(), tc.err) {
+				t.Fatalf("invalid error: got=%v, want=%q", err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package avro
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+)
+
+var (
+	ErrMismatchFields = errors.New("arrow/avro: schema mismatch")
+)
+
+// Writer writes Arrow records to an Avro object container file.
+//
+// Each written record is encoded as one block of the file, with the Avro
+// schema given by AvroSchemaOf for the schema of the records.
+type Writer struct {
+	w      io.Writer
+	schema *arrow.Schema
+	sync   [syncSize]byte
+	buf    []byte
+	closed bool
+
+	codec Codec
+	name  string
+}
+
+// NewWriter returns a writer that writes array.Records with the given schema
+// to an Avro object container file, and writes the header of the file to w.
+//
+// NewWriter returns an error if the schema can not be represented as an Avro
+// schema, or if the codec is not supported.
+func NewWriter(w io.Writer, schema *arrow.Schema, opts ...Option) (*Writer, error) {
+	aw := &Writer{
+		w:      w,
+		schema: schema,
+		name:   "Record",
+	}
+	for _, opt := range opts {
+		opt(aw)
+	}
+	if _, err := codecOf(aw.codec.String()); err != nil {
+		return nil, err
+	}
+	avro, err := AvroSchemaOf(schema, aw.name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(aw.sync[:]); err != nil {
+		return nil, fmt.Errorf("arrow/avro: could not create sync marker: %w", err)
+	}
+
+	hdr := append([]byte(nil), magic...)
+	hdr = appendLong(hdr, 2)
+	hdr = appendBytes(hdr, []byte("avro.schema"))
+	hdr = appendBytes(hdr, avro)
+	hdr = appendBytes(hdr, []byte("avro.codec"))
+	hdr = appendBytes(hdr, []byte(aw.codec.String()))
+	hdr = appendLong(hdr, 0)
+	hdr = append(hdr, aw.sync[:]...)
+	if _, err := w.Write(hdr); err != nil {
+		return nil, err
+	}
+	return aw, nil
+}
+
+func (w *Writer) Schema() *arrow.Schema { return w.schema }
+
+// Write writes the rows of rec as a block of the file.
+// Empty records are not written.
+func (w *Writer) Write(rec array.Record) error {
+	if w.closed {
+		return fmt.Errorf("arrow/avro: write to closed writer")
+	}
+	if !rec.Schema().Equal(w.schema) {
+		return ErrMismatchFields
+	}
+	if rec.NumRows() == 0 {
+		return nil
+	}
+
+	data := w.buf[:0]
+	for i := 0; i < int(rec.NumRows()); i++ {
+		var err error
+		data, err = AppendDatum(data, rec, i)
+		if err != nil {
+			return err
+		}
+	}
+	w.buf = data
+
+	data, err := compress(w.codec, data)
+	if err != nil {
+		return err
+	}
+	blk := appendLong(nil, rec.NumRows())
+	blk = appendLong(blk, int64(len(data)))
+	for _, b := range [][]byte{blk, data, w.sync[:]} {
+		if _, err := w.w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close closes the writer. It does not close the underlying io.Writer.
+func (w *Writer) Close() error {
+	w.closed = true
+	return nil
+}
@@ -0,0 +1,844 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdata
+
+// #include <stdlib.h>
+// #include "arrow/c/abi.h"
+//
+// static void ArrowSchemaRelease(struct ArrowSchema* schema) { schema->release(schema); }
+// static void ArrowArrayRelease(struct ArrowArray* array) { array->release(array); }
+//
+// static int ArrowArrayStreamGetSchema(struct ArrowArrayStream* stream, struct ArrowSchema* out) { return stream->get_schema(stream, out); }
+// static int ArrowArrayStreamGetNext(struct ArrowArrayStream* stream, struct ArrowArray* out) { return stream->get_next(stream, out); }
+// static const char* ArrowArrayStreamGetLastError(struct ArrowArrayStream* stream) { return stream->get_last_error(stream); }
+// static void ArrowArrayStreamRelease(struct ArrowArrayStream* stream) { stream->release(stream); }
+import "C"
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"unsafe"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/bitutil"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// CArrowSchema is the C Data Interface description of a type (struct ArrowSchema).
+type CArrowSchema = C.struct_ArrowSchema
+
+// CArrowArray is the C Data Interface description of an array (struct ArrowArray).
+type CArrowArray = C.struct_ArrowArray
+
+// CArrowArrayStream is the C Stream Interface description of a stream of
+// arrays (struct ArrowArrayStream).
+type CArrowArrayStream = C.struct_ArrowArrayStream
+
+const (
+	flagDictionaryOrdered = C.ARROW_FLAG_DICTIONARY_ORDERED
+	flagNullable          = C.ARROW_FLAG_NULLABLE
+	flagMapKeysSorted     = C.ARROW_FLAG_MAP_KEYS_SORTED
+)
+
+// ReleaseCArrowSchema calls the release callback of schema, if it has not
+// already been released.
+func ReleaseCArrowSchema(schema *CArrowSchema) {
+	if schema.release != nil {
+		C.ArrowSchemaRelease(schema)
+	}
+}
+
+// ReleaseCArrowArray calls the release callback of arr, if it has not
+// already been released.
+func ReleaseCArrowArray(arr *CArrowArray) {
+	if arr.release != nil {
+		C.ArrowArrayRelease(arr)
+	}
+}
+
+// ImportCArrowField imports the type described by schema as an arrow.Field.
+//
+// The schema is not released: the caller remains responsible for calling
+// ReleaseCArrowSchema.
+func ImportCArrowField(schema *CArrowSchema) (arrow.Field, error) {
+	return importSchema(schema)
+}
+
+// ImportCArrowSchema imports the struct type described by schema as an
+// *arrow.Schema, the fields of the struct becoming the fields of the schema.
+//
+// The schema is not released: the caller remains responsible for calling
+// ReleaseCArrowSchema.
+func ImportCArrowSchema(schema *CArrowSchema) (*arrow.Schema, error) {
+	f, err := importSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	st, ok := f.Type.(*arrow.StructType)
+	if !ok {
+		return nil, fmt.Errorf("arrow/cdata: schema must be a struct type, got %s", f.Type.Name())
+	}
+	md := f.Metadata
+	return arrow.NewSchema(st.Fields(), &md), nil
+}
+
+// ImportCArrayWithType imports arr as an array of type dt.
+//
+// ImportCArrayWithType takes ownership of arr, which is marked as released:
+// its release callback is called once the returned array (and any array
+// sharing its memory) has been released.
+func ImportCArrayWithType(arr *CArrowArray, dt arrow.DataType) (array.Interface, error) {
+	data, err := importArray(arr, dt)
+	if err != nil {
+		return nil, err
+	}
+	defer data.Release()
+	return array.MakeFromData(data), nil
+}
+
+// ImportCArray imports arr as an array of the type described by schema.
+//
+// ImportCArray takes ownership of arr (see ImportCArrayWithType) but does
+// not release schema.
+func ImportCArray(arr *CArrowArray, schema *CArrowSchema) (arrow.Field, array.Interface, error) {
+	f, err := importSchema(schema)
+	if err != nil {
+		return f, nil, err
+	}
+	a, err := ImportCArrayWithType(arr, f.Type)
+	return f, a, err
+}
+
+// ImportCRecordBatch imports arr, a struct array described by schema, as a
+// record whose columns are the children of the struct array.
+//
+// ImportCRecordBatch takes ownership of arr (see ImportCArrayWithType) but
+// does not release schema.
+func ImportCRecordBatch(arr *CArrowArray, schema *CArrowSchema) (array.Record, error) {
+	sc, err := ImportCArrowSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	return importRecord(arr, sc)
+}
+
+// importRecord imports arr, a struct array, as a record of schema sc.
+func importRecord(arr *CArrowArray, sc *arrow.Schema) (array.Record, error) {
+	a, err := ImportCArrayWithType(arr, arrow.StructOf(sc.Fields()...))
+	if err != nil {
+		return nil, err
+	}
+	defer a.Release()
+
+	st := a.(*array.Struct)
+	if st.NullN() != 0 {
+		return nil, fmt.Errorf("arrow/cdata: record batch can not have top-level nulls")
+	}
+	cols := make([]array.Interface, st.NumField())
+	for i := range cols {
+		cols[i] = st.Field(i)
+	}
+	return array.NewRecord(sc, cols, int64(st.Len())), nil
+}
+
+// ImportCArrayStream imports stream as a reader of the records of its
+// arrays, which must be struct arrays. If schema is nil, the schema of the
+// records is the one described by the stream.
+//
+// ImportCArrayStream takes ownership of stream, which is marked as
+// released: its release callback is called once the returned reader has
+// been released.
+func ImportCArrayStream(stream *CArrowArrayStream, schema *arrow.Schema) (array.RecordReader, error) {
+	if stream.release == nil {
+		return nil, fmt.Errorf("arrow/cdata: can not import released stream")
+	}
+
+	r := &cStreamReader{refs: 1, stream: (*CArrowArrayStream)(C.malloc(C.sizeof_struct_ArrowArrayStream))}
+	*r.stream = *stream
+	stream.release = nil
+
+	if schema == nil {
+		var cschema CArrowSchema
+		if rc := C.ArrowArrayStreamGetSchema(r.stream, &cschema); rc != 0 {
+			err := r.streamErr(rc)
+			r.Release()
+			return nil, err
+		}
+		var err error
+		schema, err = ImportCArrowSchema(&cschema)
+		ReleaseCArrowSchema(&cschema)
+		if err != nil {
+			r.Release()
+			return nil, err
+		}
+	}
+	r.schema = schema
+	return r, nil
+}
+
+// cStreamReader reads the records of an imported C stream.
+type cStreamReader struct {
+	refs   int64
+	stream *CArrowArrayStream // C copy of the imported stream
+	schema *arrow.Schema
+
+	cur  array.Record
+	done bool
+	err  error
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (r *cStreamReader) Retain() {
+	atomic.AddInt64(&r.refs, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the stream is released.
+// Release may be called simultaneously from multiple goroutines.
+func (r *cStreamReader) Release() {
+	debug.Assert(atomic.LoadInt64(&r.refs) > 0, "too many releases")
+
+	if atomic.AddInt64(&r.refs, -1) == 0 {
+		if r.cur != nil {
+			r.cur.Release()
+			r.cur = nil
+		}
+		if r.stream.release != nil {
+			C.ArrowArrayStreamRelease(r.stream)
+		}
+		C.free(unsafe.Pointer(r.stream))
+		r.stream = nil
+	}
+}
+
+func (r *cStreamReader) Schema() *arrow.Schema { return r.schema }
+
+// Record returns the current record.
+// The returned record is owned by the reader and is only valid until the
+// next call to Next.
+func (r *cStreamReader) Record() array.Record { return r.cur }
+
+// Err returns the error that stopped the reader, if any.
+func (r *cStreamReader) Err() error { return r.err }
+
+// Next imports the next array of the stream. It returns false when the
+// stream has ended, or on error.
+func (r *cStreamReader) Next() bool {
+	if r.cur != nil {
+		r.cur.Release()
+		r.cur = nil
+	}
+	if r.done || r.err != nil {
+		return false
+	}
+
+	var arr CArrowArray
+	if rc := C.ArrowArrayStreamGetNext(r.stream, &arr); rc != 0 {
+		r.err = r.streamErr(rc)
+		return false
+	}
+	if arr.release == nil {
+		r.done = true
+		return false
+	}
+	r.cur, r.err = importRecord(&arr, r.schema)
+	return r.err == nil
+}
+
+// streamErr returns the error of the last operation on the stream, which
+// failed with the errno code rc.
+func (r *cStreamReader) streamErr(rc C.int) error {
+	if msg := C.ArrowArrayStreamGetLastError(r.stream); msg != nil {
+		return fmt.Errorf("arrow/cdata: %s", C.GoString(msg))
+	}
+	return fmt.Errorf("arrow/cdata: %v", syscall.Errno(rc))
+}
+
+func importSchema(schema *CArrowSchema) (arrow.Field, error) {
+	var (
+		f = arrow.Field{
+			Name:     C.GoString(schema.name),
+			Nullable: schema.flags&flagNullable != 0,
+			Metadata: decodeMetadata(schema.metadata),
+		}
+		format = C.GoString(schema.format)
+	)
+
+	children := make([]arrow.Field, int(schema.n_children))
+	for i, child := range schemaChildren(schema) {
+		var err error
+		children[i], err = importSchema(child)
+		if err != nil {
+			return f, err
+		}
+	}
+
+	dt, err := importFormat(format, children)
+	if err != nil {
+		return f, fmt.Errorf("arrow/cdata: field %q: %v", f.Name, err)
+	}
+	if mt, ok := dt.(*arrow.MapType); ok {
+		mt.KeysSorted = schema.flags&flagMapKeysSorted != 0
+	}
+	if schema.dictionary != nil {
+		switch dt.ID() {
+		case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64,
+			arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		default:
+			return f, fmt.Errorf("arrow/cdata: field %q: invalid dictionary index type %s", f.Name, dt.Name())
+		}
+		values, err := importSchema(schema.dictionary)
+		if err != nil {
+			return f, err
+		}
+		dt = arrow.DictionaryOf(dt, values.Type)
+	}
+	ext, err := arrow.ExtensionTypeFromMetadata(dt, f.Metadata)
+	if err != nil {
+		return f, fmt.Errorf("arrow/cdata: field %q: %v", f.Name, err)
+	}
+	if ext != nil {
+		dt = ext
+		f.Metadata = stripExtensionMetadata(f.Metadata)
+	}
+	f.Type = dt
+	return f, nil
+}
+
+// stripExtensionMetadata returns md without the keys describing an
+// extension type.
+func stripExtensionMetadata(md arrow.Metadata) arrow.Metadata {
+	var keys, values []string
+	for i, k := range md.Keys() {
+		if k == arrow.ExtensionNameKey || k == arrow.ExtensionMetadataKey {
+			continue
+		}
+		keys = append(keys, k)
+		values = append(values, md.Values()[i])
+	}
+	return arrow.NewMetadata(keys, values)
+}
+
+var formatToType = map[string]arrow.DataType{
+	"n":   arrow.Null,
+	"b":   arrow.FixedWidthTypes.Boolean,
+	"c":   arrow.PrimitiveTypes.Int8,
+	"C":   arrow.PrimitiveTypes.Uint8,
+	"s":   arrow.PrimitiveTypes.Int16,
+	"S":   arrow.PrimitiveTypes.Uint16,
+	"i":   arrow.PrimitiveTypes.Int32,
+	"I":   arrow.PrimitiveTypes.Uint32,
+	"l":   arrow.PrimitiveTypes.Int64,
+	"L":   arrow.PrimitiveTypes.Uint64,
+	"f":   arrow.PrimitiveTypes.Float32,
+	"e":   arrow.FixedWidthTypes.Float16,
+	"g":   arrow.PrimitiveTypes.Float64,
+	"z":   arrow.BinaryTypes.Binary,
+	"u":   arrow.BinaryTypes.String,
+	"Z":   arrow.BinaryTypes.LargeBinary,
+	"U":   arrow.BinaryTypes.LargeString,
+	"tdD": arrow.PrimitiveTypes.Date32,
+	"tdm": arrow.PrimitiveTypes.Date64,
+	"tts": arrow.FixedWidthTypes.Time32s,
+	"ttm": arrow.FixedWidthTypes.Time32ms,
+	"ttu": arrow.FixedWidthTypes.Time64us,
+	"ttn": arrow.FixedWidthTypes.Time64ns,
+	"tDs": arrow.FixedWidthTypes.Duration_s,
+	"tDm": arrow.FixedWidthTypes.Duration_ms,
+	"tDu": arrow.FixedWidthTypes.Duration_us,
+	"tDn": arrow.FixedWidthTypes.Duration_ns,
+	"tiM": arrow.FixedWidthTypes.MonthInterval,
+	"tiD": arrow.FixedWidthTypes.DayTimeInterval,
+	"tin": arrow.FixedWidthTypes.MonthDayNanoInterval,
+	"vz":  arrow.BinaryTypes.BinaryView,
+	"vu":  arrow.BinaryTypes.StringView,
+}
+
+var formatToUnit = map[byte]arrow.TimeUnit{
+	's': arrow.Second,
+	'm': arrow.Millisecond,
+	'u': arrow.Microsecond,
+	'n': arrow.Nanosecond,
+}
+
+func importFormat(format string, children []arrow.Field) (arrow.DataType, error) {
+	if dt, ok := formatToType[format]; ok {
+		return dt, nil
+	}
+
+	switch {
+	case strings.HasPrefix(format, "w:"):
+		n, err := strconv.Atoi(format[2:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid fixed size binary format %q", format)
+		}
+		return &arrow.FixedSizeBinaryType{ByteWidth: n}, nil
+	case strings.HasPrefix(format, "d:"):
+		return importDecimal(format)
+	case strings.HasPrefix(format, "ts") && len(format) >= 4 && format[3] == ':':
+		unit, ok := formatToUnit[format[2]]
+		if !ok {
+			return nil, fmt.Errorf("invalid timestamp format %q", format)
+		}
+		return &arrow.TimestampType{Unit: unit, TimeZone: format[4:]}, nil
+	case format == "+l":
+		if len(children) != 1 {
+			return nil, fmt.Errorf("list type must have exactly one child, got %d", len(children))
+		}
+		return arrow.ListOf(children[0].Type), nil
+	case format == "+L":
+		if len(children) != 1 {
+			return nil, fmt.Errorf("large list type must have exactly one child, got %d", len(children))
+		}
+		return arrow.LargeListOf(children[0].Type), nil
+	case strings.HasPrefix(format, "+w:"):
+		if len(children) != 1 {
+			return nil, fmt.Errorf("fixed size list type must have exactly one child, got %d", len(children))
+		}
+		n, err := strconv.ParseInt(format[3:], 10, 32)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid fixed size list format %q", format)
+		}
+		return arrow.FixedSizeListOf(int32(n), children[0].Type), nil
+	case format == "+s":
+		return arrow.StructOf(children...), nil
+	case format == "+m":
+		if len(children) != 1 {
+			return nil, fmt.Errorf("map type must have exactly one child, got %d", len(children))
+		}
+		st, ok := children[0].Type.(*arrow.StructType)
+		if !ok || len(st.Fields()) != 2 {
+			return nil, fmt.Errorf("map entries must be a struct with two fields, got %s", children[0].Type.Name())
+		}
+		return arrow.MapOf(st.Field(0).Type, st.Field(1).Type), nil
+	case strings.HasPrefix(format, "+us:"), strings.HasPrefix(format, "+ud:"):
+		return importUnion(format, children)
+	case format == "+r":
+		if len(children) != 2 {
+			return nil, fmt.Errorf("run-end encoded type must have exactly two children, got %d", len(children))
+		}
+		switch children[0].Type.ID() {
+		case arrow.INT16, arrow.INT32, arrow.INT64:
+		default:
+			return nil, fmt.Errorf("invalid run ends type %s", children[0].Type.Name())
+		}
+		return arrow.RunEndEncodedOf(children[0].Type, children[1].Type), nil
+	}
+	return nil, fmt.Errorf("unsupported format %q", format)
+}
+
+// importDecimal returns the decimal type described by format, of the form
+// "d:precision,scale[,bitwidth]". Only 256-bit decimals are supported.
+func importDecimal(format string) (arrow.DataType, error) {
+	parts := strings.Split(format[2:], ",")
+	if len(parts) != 2 && len(parts) != 3 {
+		return nil, fmt.Errorf("invalid decimal format %q", format)
+	}
+	prec, err1 := strconv.ParseInt(parts[0], 10, 32)
+	scale, err2 := strconv.ParseInt(parts[1], 10, 32)
+	if err1 != nil || err2 != nil {
+		return nil, fmt.Errorf("invalid decimal format %q", format)
+	}
+	if len(parts) != 3 || parts[2] != "256" {
+		return nil, fmt.Errorf("unsupported format %q", format)
+	}
+	if prec < 1 || prec > 76 {
+		return nil, fmt.Errorf("invalid decimal256 precision %d", prec)
+	}
+	return &arrow.Decimal256Type{Precision: int32(prec), Scale: int32(scale)}, nil
+}
+
+// importUnion returns the union type described by format, of the form
+// "+us:codes" or "+ud:codes", codes being the comma separated type codes of
+// children.
+func importUnion(format string, children []arrow.Field) (arrow.DataType, error) {
+	mode := arrow.SparseMode
+	if format[2] == 'd' {
+		mode = arrow.DenseMode
+	}
+
+	var codes []int8
+	if s := format[4:]; s != "" {
+		seen := make(map[int64]bool)
+		for _, c := range strings.Split(s, ",") {
+			v, err := strconv.ParseInt(c, 10, 8)
+			if err != nil || v < 0 || seen[v] {
+				return nil, fmt.Errorf("invalid union format %q", format)
+			}
+			seen[v] = true
+			codes = append(codes, int8(v))
+		}
+	}
+	if len(codes) != len(children) {
+		return nil, fmt.Errorf("union type with %d type codes must have as many children, got %d", len(codes), len(children))
+	}
+	return arrow.UnionOf(mode, children, codes), nil
+}
+
+// decodeMetadata decodes the C Data Interface binary encoding of key/value
+// metadata: an int32 number of pairs, followed by each key and value as an
+// int32 length and the corresponding bytes, all in native endianness.
+func decodeMetadata(md *C.char) arrow.Metadata {
+	if md == nil {
+		return arrow.Metadata{}
+	}
+
+	ptr := unsafe.Pointer(md)
+	next := func() int {
+		v := int(*(*int32)(ptr))
+		ptr = unsafe.Pointer(uintptr(ptr) + 4)
+		return v
+	}
+	str := func() string {
+		n := next()
+		s := C.GoStringN((*C.char)(ptr), C.int(n))
+		ptr = unsafe.Pointer(uintptr(ptr) + uintptr(n))
+		return s
+	}
+
+	n := next()
+	keys := make([]string, n)
+	vals := make([]string, n)
+	for i := 0; i < n; i++ {
+		keys[i] = str()
+		vals[i] = str()
+	}
+	return arrow.NewMetadata(keys, vals)
+}
+
+func schemaChildren(schema *CArrowSchema) []*CArrowSchema {
+	n := int(schema.n_children)
+	if n == 0 {
+		return nil
+	}
+	var children []*CArrowSchema
+	s := (*reflect.SliceHeader)(unsafe.Pointer(&children))
+	s.Data = uintptr(unsafe.Pointer(schema.children))
+	s.Len = n
+	s.Cap = n
+	return children
+}
+
+func arrayChildren(arr *CArrowArray) []*CArrowArray {
+	n := int(arr.n_children)
+	if n == 0 {
+		return nil
+	}
+	var children []*CArrowArray
+	s := (*reflect.SliceHeader)(unsafe.Pointer(&children))
+	s.Data = uintptr(unsafe.Pointer(arr.children))
+	s.Len = n
+	s.Cap = n
+	return children
+}
+
+func arrayBuffers(arr *CArrowArray) []unsafe.Pointer {
+	n := int(arr.n_buffers)
+	if n == 0 {
+		return nil
+	}
+	var bufs []unsafe.Pointer
+	s := (*reflect.SliceHeader)(unsafe.Pointer(&bufs))
+	s.Data = uintptr(unsafe.Pointer(arr.buffers))
+	s.Len = n
+	s.Cap = n
+	return bufs
+}
+
+// cReleaser releases an imported C array once all the buffers referencing
+// its memory have been released.
+//
+// cReleaser implements memory.Allocator so that it can be attached to the
+// imported buffers: freeing a buffer decrements the reference count of the
+// releaser. It can not allocate memory.
+type cReleaser struct {
+	refs int64
+	arr  *CArrowArray // C copy of the imported array
+}
+
+func (r *cReleaser) Allocate(size int) []byte {
+	panic("arrow/cdata: imported buffers are immutable")
+}
+
+func (r *cReleaser) Reallocate(size int, b []byte) []byte {
+	panic("arrow/cdata: imported buffers are immutable")
+}
+
+func (r *cReleaser) Free(b []byte) { r.release() }
+
+func (r *cReleaser) retain() { atomic.AddInt64(&r.refs, 1) }
+
+func (r *cReleaser) release() {
+	if atomic.AddInt64(&r.refs, -1) == 0 {
+		C.ArrowArrayRelease(r.arr)
+		C.free(unsafe.Pointer(r.arr))
+		r.arr = nil
+	}
+}
+
+func (r *cReleaser) buffer(ptr unsafe.Pointer, size int) *memory.Buffer {
+	if ptr == nil {
+		return nil
+	}
+	var b []byte
+	s := (*reflect.SliceHeader)(unsafe.Pointer(&b))
+	s.Data = uintptr(ptr)
+	s.Len = size
+	s.Cap = size
+	r.retain()
+	return memory.NewBufferWithAllocator(b, r)
+}
+
+// importArray moves arr to C memory and builds array data of type dt over
+// its buffers.
+func importArray(arr *CArrowArray, dt arrow.DataType) (*array.Data, error) {
+	if arr.release == nil {
+		return nil, fmt.Errorf("arrow/cdata: can not import released array")
+	}
+
+	rel := &cReleaser{refs: 1, arr: (*CArrowArray)(C.malloc(C.sizeof_struct_ArrowArray))}
+	*rel.arr = *arr
+	arr.release = nil
+	defer rel.release()
+
+	return importData(rel, rel.arr, dt)
+}
+
+func importData(rel *cReleaser, arr *CArrowArray, dt arrow.DataType) (*array.Data, error) {
+	if ext, ok := dt.(arrow.ExtensionType); ok {
+		storage, err := importData(rel, arr, ext.StorageType())
+		if err != nil {
+			return nil, err
+		}
+		defer storage.Release()
+		return array.NewData(ext, storage.Len(), storage.Buffers(), storage.Children(), storage.NullN(), storage.Offset()), nil
+	}
+
+	var (
+		offset = int(arr.offset)
+		length = int(arr.length)
+		nulls  = int(arr.null_count)
+		bufs   = arrayBuffers(arr)
+		kids   = arrayChildren(arr)
+		n      = offset + length
+
+		buffers  []*memory.Buffer
+		children []*array.Data
+	)
+
+	defer func() {
+		for _, b := range buffers {
+			if b != nil {
+				b.Release()
+			}
+		}
+		for _, c := range children {
+			c.Release()
+		}
+	}()
+
+	want := func(nbufs, nkids int) error {
+		if len(bufs) != nbufs {
+			return fmt.Errorf("arrow/cdata: invalid number of buffers for %s: got=%d, want=%d", dt.Name(), len(bufs), nbufs)
+		}
+		if len(kids) != nkids {
+			return fmt.Errorf("arrow/cdata: invalid number of children for %s: got=%d, want=%d", dt.Name(), len(kids), nkids)
+		}
+		return nil
+	}
+
+	validity := func() *memory.Buffer {
+		return rel.buffer(bufs[0], bitutil.CeilByte(n)/8)
+	}
+
+	switch dt := dt.(type) {
+	case *arrow.NullType:
+		if err := want(0, 0); err != nil {
+			return nil, err
+		}
+		buffers = []*memory.Buffer{nil}
+	case *arrow.DictionaryType:
+		if err := want(2, 0); err != nil {
+			return nil, err
+		}
+		if arr.dictionary == nil {
+			return nil, fmt.Errorf("arrow/cdata: dictionary array without dictionary")
+		}
+		width := dt.IndexType().(arrow.FixedWidthDataType).BitWidth() / 8
+		buffers = []*memory.Buffer{validity(), rel.buffer(bufs[1], n*width)}
+		dict, err := importData(rel, arr.dictionary, dt.ValueType())
+		if err != nil {
+			return nil, err
+		}
+		defer dict.Release()
+		return array.NewDataWithDictionary(dt, length, buffers, nulls, offset, dict), nil
+	case arrow.BinaryViewDataType:
+		// the data buffers are followed by a buffer of their sizes.
+		if len(bufs) < 3 || len(kids) != 0 {
+			return nil, fmt.Errorf("arrow/cdata: invalid number of buffers or children for %s: got=%d and %d, want at least 3 and 0", dt.Name(), len(bufs), len(kids))
+		}
+		nbufs := len(bufs) - 3
+		buffers = []*memory.Buffer{validity(), rel.buffer(bufs[1], n*arrow.ViewSizeBytes)}
+		if nbufs > 0 {
+			sizes := rel.buffer(bufs[len(bufs)-1], nbufs*arrow.Int64SizeBytes)
+			for i, size := range arrow.Int64Traits.CastFromBytes(sizes.Bytes()) {
+				buffers = append(buffers, rel.buffer(bufs[2+i], int(size)))
+			}
+			sizes.Release()
+		}
+	case *arrow.BooleanType:
+		if err := want(2, 0); err != nil {
+			return nil, err
+		}
+		buffers = []*memory.Buffer{validity(), rel.buffer(bufs[1], bitutil.CeilByte(n)/8)}
+	case arrow.FixedWidthDataType:
+		if err := want(2, 0); err != nil {
+			return nil, err
+		}
+		values := rel.buffer(bufs[1], n*dt.BitWidth()/8)
+		switch dt := dt.(type) {
+		case *arrow.FixedSizeBinaryType:
+			// array.FixedSizeBinary expects an offsets buffer.
+			offsets := make([]int32, n+1)
+			for i := range offsets {
+				offsets[i] = int32(i * dt.ByteWidth)
+			}
+			buffers = []*memory.Buffer{validity(), memory.NewBufferBytes(arrow.Int32Traits.CastToBytes(offsets)), values}
+		default:
+			buffers = []*memory.Buffer{validity(), values}
+		}
+	case *arrow.LargeBinaryType, *arrow.LargeStringType:
+		if err := want(3, 0); err != nil {
+			return nil, err
+		}
+		offsets := rel.buffer(bufs[1], (n+1)*arrow.Int64SizeBytes)
+		size := 0
+		if offsets != nil {
+			size = int(arrow.Int64Traits.CastFromBytes(offsets.Bytes())[n])
+		}
+		buffers = []*memory.Buffer{validity(), offsets, rel.buffer(bufs[2], size)}
+	case arrow.BinaryDataType:
+		if err := want(3, 0); err != nil {
+			return nil, err
+		}
+		offsets := rel.buffer(bufs[1], (n+1)*arrow.Int32SizeBytes)
+		size := 0
+		if offsets != nil {
+			size = int(arrow.Int32Traits.CastFromBytes(offsets.Bytes())[n])
+		}
+		buffers = []*memory.Buffer{validity(), offsets, rel.buffer(bufs[2], size)}
+	case *arrow.ListType:
+		if err := want(2, 1); err != nil {
+			return nil, err
+		}
+		offsets := rel.buffer(bufs[1], (n+1)*arrow.Int32SizeBytes)
+		buffers = []*memory.Buffer{validity(), offsets}
+		child, err := importData(rel, kids[0], dt.Elem())
+		if err != nil {
+			return nil, err
+		}
+		children = []*array.Data{child}
+	case *arrow.MapType:
+		if err := want(2, 1); err != nil {
+			return nil, err
+		}
+		offsets := rel.buffer(bufs[1], (n+1)*arrow.Int32SizeBytes)
+		buffers = []*memory.Buffer{validity(), offsets}
+		child, err := importData(rel, kids[0], dt.ValueType())
+		if err != nil {
+			return nil, err
+		}
+		children = []*array.Data{child}
+	case *arrow.UnionType:
+		// unions have no validity bitmap.
+		nbufs := 1
+		if dt.Mode() == arrow.DenseMode {
+			nbufs = 2
+		}
+		if err := want(nbufs, len(dt.Fields())); err != nil {
+			return nil, err
+		}
+		buffers = []*memory.Buffer{nil, rel.buffer(bufs[0], n), nil}
+		if nbufs == 2 {
+			buffers[2] = rel.buffer(bufs[1], n*arrow.Int32SizeBytes)
+		}
+		for i, f := range dt.Fields() {
+			child, err := importData(rel, kids[i], f.Type)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+		nulls = 0
+	case *arrow.LargeListType:
+		if err := want(2, 1); err != nil {
+			return nil, err
+		}
+		offsets := rel.buffer(bufs[1], (n+1)*arrow.Int64SizeBytes)
+		buffers = []*memory.Buffer{validity(), offsets}
+		child, err := importData(rel, kids[0], dt.Elem())
+		if err != nil {
+			return nil, err
+		}
+		children = []*array.Data{child}
+	case *arrow.FixedSizeListType:
+		if err := want(1, 1); err != nil {
+			return nil, err
+		}
+		buffers = []*memory.Buffer{validity()}
+		child, err := importData(rel, kids[0], dt.Elem())
+		if err != nil {
+			return nil, err
+		}
+		children = []*array.Data{child}
+	case *arrow.RunEndEncodedType:
+		if err := want(0, 2); err != nil {
+			return nil, err
+		}
+		buffers = []*memory.Buffer{nil}
+		for i, f := range dt.Fields() {
+			child, err := importData(rel, kids[i], f.Type)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+	case *arrow.StructType:
+		if err := want(1, len(dt.Fields())); err != nil {
+			return nil, err
+		}
+		buffers = []*memory.Buffer{validity()}
+		for i, f := range dt.Fields() {
+			child, err := importData(rel, kids[i], f.Type)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+	default:
+		return nil, fmt.Errorf("arrow/cdata: unsupported data type %s", dt.Name())
+	}
+
+	return array.NewData(dt, length, buffers, children, nulls, offset), nil
+}
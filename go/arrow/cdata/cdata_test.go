@@ -0,0 +1,780 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdata_test
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/cdata"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/json"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+var unionFields = []arrow.Field{
+	{Name: "s", Type: arrow.BinaryTypes.String, Nullable: true},
+	{Name: "i", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+}
+
+func TestSchemaRoundTrip(t *testing.T) {
+	md := arrow.NewMetadata([]string{"k1", "k2"}, []string{"v1", ""})
+	sortedMap := arrow.MapOf(arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int32)
+	sortedMap.KeysSorted = true
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "null", Type: arrow.Null, Nullable: true},
+			{Name: "bool", Type: arrow.FixedWidthTypes.Boolean},
+			{Name: "i8", Type: arrow.PrimitiveTypes.Int8},
+			{Name: "u16", Type: arrow.PrimitiveTypes.Uint16},
+			{Name: "i32", Type: arrow.PrimitiveTypes.Int32, Metadata: md},
+			{Name: "u64", Type: arrow.PrimitiveTypes.Uint64},
+			{Name: "f16", Type: arrow.FixedWidthTypes.Float16},
+			{Name: "f32", Type: arrow.PrimitiveTypes.Float32},
+			{Name: "f64", Type: arrow.PrimitiveTypes.Float64},
+			{Name: "bin", Type: arrow.BinaryTypes.Binary},
+			{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+			{Name: "fsb", Type: &arrow.FixedSizeBinaryType{ByteWidth: 3}},
+			{Name: "d32", Type: arrow.PrimitiveTypes.Date32},
+			{Name: "d64", Type: arrow.PrimitiveTypes.Date64},
+			{Name: "t32", Type: arrow.FixedWidthTypes.Time32ms},
+			{Name: "t64", Type: arrow.FixedWidthTypes.Time64ns},
+			{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "Europe/Paris"}},
+			{Name: "dur", Type: arrow.FixedWidthTypes.Duration_us},
+			{Name: "month", Type: arrow.FixedWidthTypes.MonthInterval},
+			{Name: "day-time", Type: arrow.FixedWidthTypes.DayTimeInterval},
+			{Name: "month-day-nano", Type: arrow.FixedWidthTypes.MonthDayNanoInterval},
+			{Name: "list", Type: arrow.ListOf(arrow.PrimitiveTypes.Int64)},
+			{Name: "large-bin", Type: arrow.BinaryTypes.LargeBinary},
+			{Name: "large-str", Type: arrow.BinaryTypes.LargeString, Nullable: true},
+			{Name: "large-list", Type: arrow.LargeListOf(arrow.PrimitiveTypes.Int64)},
+			{Name: "fixed-size-list", Type: arrow.FixedSizeListOf(4, arrow.PrimitiveTypes.Float32)},
+			{Name: "ree", Type: arrow.RunEndEncodedOf(arrow.PrimitiveTypes.Int32, arrow.BinaryTypes.String)},
+			{Name: "map", Type: sortedMap},
+			{Name: "sparse-union", Type: arrow.SparseUnionOf(unionFields, []int8{5, 2})},
+			{Name: "dense-union", Type: arrow.DenseUnionOf(unionFields, nil)},
+			{Name: "dec256", Type: &arrow.Decimal256Type{Precision: 60, Scale: -3}},
+			{Name: "dict", Type: arrow.DictionaryOf(arrow.PrimitiveTypes.Uint16, arrow.BinaryTypes.String), Nullable: true},
+			{Name: "bin-view", Type: arrow.BinaryTypes.BinaryView},
+			{Name: "str-view", Type: arrow.BinaryTypes.StringView},
+			{Name: "struct", Type: arrow.StructOf(
+				arrow.Field{Name: "a", Type: arrow.BinaryTypes.String, Nullable: true},
+				arrow.Field{Name: "b", Type: arrow.ListOf(arrow.PrimitiveTypes.Float64)},
+			)},
+		},
+		&md,
+	)
+
+	var cschema cdata.CArrowSchema
+	cdata.ExportArrowSchema(schema, &cschema)
+	defer cdata.ReleaseCArrowSchema(&cschema)
+
+	got, err := cdata.ImportCArrowSchema(&cschema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.Equal(schema) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", got, schema)
+	}
+	if !reflect.DeepEqual(got.Metadata(), schema.Metadata()) {
+		t.Fatalf("invalid metadata:\ngot= %v\nwant=%v", got.Metadata(), schema.Metadata())
+	}
+	if got, want := got.Field(4).Metadata, md; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid field metadata:\ngot= %v\nwant=%v", got, want)
+	}
+
+	cdata.ReleaseCArrowSchema(&cschema)
+	cdata.ReleaseCArrowSchema(&cschema) // releasing twice is a no-op.
+}
+
+func TestImportSchemaErrors(t *testing.T) {
+	var cschema cdata.CArrowSchema
+	cdata.ExportArrowField(arrow.Field{Name: "i32", Type: arrow.PrimitiveTypes.Int32}, &cschema)
+	defer cdata.ReleaseCArrowSchema(&cschema)
+
+	_, err := cdata.ImportCArrowSchema(&cschema)
+	if err == nil {
+		t.Fatalf("expected an error importing a non-struct schema")
+	}
+}
+
+func TestArrayRoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	for _, tc := range []struct {
+		name  string
+		build func() array.Interface
+	}{
+		{
+			name: "null",
+			build: func() array.Interface {
+				return array.NewNull(3)
+			},
+		},
+		{
+			name: "bool",
+			build: func() array.Interface {
+				b := array.NewBooleanBuilder(mem)
+				defer b.Release()
+				b.AppendValues([]bool{true, false, true, true}, []bool{true, true, false, true})
+				return b.NewArray()
+			},
+		},
+		{
+			name: "int64",
+			build: func() array.Interface {
+				b := array.NewInt64Builder(mem)
+				defer b.Release()
+				b.AppendValues([]int64{1, 2, 3, 4}, []bool{true, false, true, true})
+				return b.NewArray()
+			},
+		},
+		{
+			name: "float64-slice",
+			build: func() array.Interface {
+				b := array.NewFloat64Builder(mem)
+				defer b.Release()
+				b.AppendValues([]float64{1, 2, 3, 4, 5}, []bool{true, false, true, true, false})
+				arr := b.NewArray()
+				defer arr.Release()
+				return array.NewSlice(arr, 1, 4)
+			},
+		},
+		{
+			name: "string",
+			build: func() array.Interface {
+				b := array.NewStringBuilder(mem)
+				defer b.Release()
+				b.AppendValues([]string{"a", "bc", "", "def"}, []bool{true, true, false, true})
+				return b.NewArray()
+			},
+		},
+		{
+			name: "large-string",
+			build: func() array.Interface {
+				b := array.NewLargeStringBuilder(mem)
+				defer b.Release()
+				b.AppendValues([]string{"a", "bc", "", "def"}, []bool{true, true, false, true})
+				return b.NewArray()
+			},
+		},
+		{
+			name: "fixed-size-binary",
+			build: func() array.Interface {
+				b := array.NewFixedSizeBinaryBuilder(mem, &arrow.FixedSizeBinaryType{ByteWidth: 2})
+				defer b.Release()
+				b.AppendValues([][]byte{[]byte("ab"), nil, []byte("cd")}, []bool{true, false, true})
+				return b.NewArray()
+			},
+		},
+		{
+			name: "timestamp",
+			build: func() array.Interface {
+				b := array.NewTimestampBuilder(mem, &arrow.TimestampType{Unit: arrow.Second, TimeZone: "UTC"})
+				defer b.Release()
+				b.AppendValues([]arrow.Timestamp{-1, 0, 1}, nil)
+				return b.NewArray()
+			},
+		},
+		{
+			name: "list",
+			build: func() array.Interface {
+				b := array.NewListBuilder(mem, arrow.PrimitiveTypes.Int32)
+				defer b.Release()
+				vb := b.ValueBuilder().(*array.Int32Builder)
+				b.Append(true)
+				vb.AppendValues([]int32{1, 2}, nil)
+				b.AppendNull()
+				b.Append(true)
+				b.Append(true)
+				vb.AppendValues([]int32{3, 4, 5}, []bool{true, false, true})
+				return b.NewArray()
+			},
+		},
+		{
+			name: "large-list",
+			build: func() array.Interface {
+				b := array.NewLargeListBuilder(mem, arrow.BinaryTypes.LargeBinary)
+				defer b.Release()
+				vb := b.ValueBuilder().(*array.LargeBinaryBuilder)
+				b.Append(true)
+				vb.AppendValues([][]byte{[]byte("a"), nil}, []bool{true, false})
+				b.AppendNull()
+				b.Append(true)
+				vb.Append([]byte("bcd"))
+				return b.NewArray()
+			},
+		},
+		{
+			name: "fixed-size-list-slice",
+			build: func() array.Interface {
+				b := array.NewFixedSizeListBuilder(mem, 2, arrow.PrimitiveTypes.Float32)
+				defer b.Release()
+				vb := b.ValueBuilder().(*array.Float32Builder)
+				b.AppendValues([]bool{true, false, true, true})
+				vb.AppendValues([]float32{1, 2, 0, 0, 3, 4, 5, 6}, []bool{true, true, false, false, true, false, true, true})
+				arr := b.NewArray()
+				defer arr.Release()
+				return array.NewSlice(arr, 1, 4)
+			},
+		},
+		{
+			name: "struct",
+			build: func() array.Interface {
+				dtype := arrow.StructOf(
+					arrow.Field{Name: "s", Type: arrow.BinaryTypes.String},
+					arrow.Field{Name: "u8", Type: arrow.PrimitiveTypes.Uint8},
+				)
+				b := array.NewStructBuilder(mem, dtype)
+				defer b.Release()
+				sb := b.FieldBuilder(0).(*array.StringBuilder)
+				ub := b.FieldBuilder(1).(*array.Uint8Builder)
+				b.Append(true)
+				sb.Append("x")
+				ub.Append(1)
+				b.AppendNull()
+				sb.AppendNull()
+				ub.AppendNull()
+				b.Append(true)
+				sb.Append("z")
+				ub.Append(3)
+				return b.NewArray()
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			arr := tc.build()
+			defer arr.Release()
+
+			var (
+				carr    cdata.CArrowArray
+				cschema cdata.CArrowSchema
+			)
+			cdata.ExportArrowArray(arr, &carr, &cschema)
+			defer cdata.ReleaseCArrowSchema(&cschema)
+
+			f, got, err := cdata.ImportCArray(&carr, &cschema)
+			if err != nil {
+				cdata.ReleaseCArrowArray(&carr)
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			if !reflect.DeepEqual(f.Type, arr.DataType()) {
+				t.Fatalf("invalid type: got=%v, want=%v", f.Type, arr.DataType())
+			}
+			if got, want := got.NullN(), arr.NullN(); got != want {
+				t.Fatalf("invalid number of nulls: got=%d, want=%d", got, want)
+			}
+			if got, want := toJSON(t, got), toJSON(t, arr); got != want {
+				t.Fatalf("invalid array:\ngot= %s\nwant=%s", got, want)
+			}
+
+			if _, err := cdata.ImportCArrayWithType(&carr, f.Type); err == nil {
+				t.Fatalf("expected an error importing a released array")
+			}
+		})
+	}
+}
+
+func TestArrayTypesRoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	union := func(dt *arrow.UnionType) array.Interface {
+		b := array.NewUnionBuilder(mem, dt)
+		defer b.Release()
+		sb := b.Child(0).(*array.StringBuilder)
+		ib := b.Child(1).(*array.Int64Builder)
+		codes := dt.TypeCodes()
+		b.Append(codes[0])
+		sb.Append("x")
+		b.Append(codes[1])
+		ib.Append(7)
+		b.Append(codes[0])
+		sb.AppendNull()
+		b.Append(codes[1])
+		ib.Append(-1)
+		arr := b.NewArray()
+		defer arr.Release()
+		return array.NewSlice(arr, 1, 4)
+	}
+
+	for _, tc := range []struct {
+		name  string
+		build func() array.Interface
+	}{
+		{
+			name: "map",
+			build: func() array.Interface {
+				b := array.NewMapBuilder(mem, arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int32, true)
+				defer b.Release()
+				kb := b.KeyBuilder().(*array.StringBuilder)
+				ib := b.ItemBuilder().(*array.Int32Builder)
+				b.Append(true)
+				kb.AppendValues([]string{"a", "b"}, nil)
+				ib.AppendValues([]int32{1, 0}, []bool{true, false})
+				b.AppendNull()
+				b.Append(true)
+				b.Append(true)
+				kb.Append("c")
+				ib.Append(3)
+				return b.NewArray()
+			},
+		},
+		{
+			name:  "sparse-union-slice",
+			build: func() array.Interface { return union(arrow.SparseUnionOf(unionFields, []int8{5, 2})) },
+		},
+		{
+			name:  "dense-union-slice",
+			build: func() array.Interface { return union(arrow.DenseUnionOf(unionFields, []int8{1, 3})) },
+		},
+		{
+			name: "decimal256",
+			build: func() array.Interface {
+				b := array.NewDecimal256Builder(mem, &arrow.Decimal256Type{Precision: 40, Scale: 2})
+				defer b.Release()
+				b.Append(decimal256.FromI64(12345))
+				b.AppendNull()
+				b.Append(decimal256.New(1, 2, 3, 4))
+				b.Append(decimal256.FromI64(-1))
+				return b.NewArray()
+			},
+		},
+		{
+			name: "dictionary-slice",
+			build: func() array.Interface {
+				b := array.NewDictionaryBuilder(mem, arrow.DictionaryOf(arrow.PrimitiveTypes.Int16, arrow.BinaryTypes.String))
+				defer b.Release()
+				for _, s := range []string{"a", "b", "a", "", "c"} {
+					if s == "" {
+						b.AppendNull()
+						continue
+					}
+					b.AppendString(s)
+				}
+				arr := b.NewArray()
+				defer arr.Release()
+				return array.NewSlice(arr, 1, 5)
+			},
+		},
+		{
+			name: "binary-view",
+			build: func() array.Interface {
+				b := array.NewBinaryViewBuilder(mem, arrow.BinaryTypes.BinaryView)
+				defer b.Release()
+				b.SetBlockSize(20)
+				b.AppendValues([][]byte{
+					[]byte("short"), nil, []byte("a value longer than twelve bytes"), []byte("another long value"), {},
+				}, []bool{true, false, true, true, true})
+				return b.NewArray()
+			},
+		},
+		{
+			name: "string-view-slice",
+			build: func() array.Interface {
+				b := array.NewStringViewBuilder(mem)
+				defer b.Release()
+				b.AppendValues([]string{"inline", "out of line string", "", "x"}, []bool{true, true, false, true})
+				arr := b.NewArray()
+				defer arr.Release()
+				return array.NewSlice(arr, 1, 4)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			arr := tc.build()
+			defer arr.Release()
+
+			var (
+				carr    cdata.CArrowArray
+				cschema cdata.CArrowSchema
+			)
+			cdata.ExportArrowArray(arr, &carr, &cschema)
+			defer cdata.ReleaseCArrowSchema(&cschema)
+
+			f, got, err := cdata.ImportCArray(&carr, &cschema)
+			if err != nil {
+				cdata.ReleaseCArrowArray(&carr)
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			if !reflect.DeepEqual(f.Type, arr.DataType()) {
+				t.Fatalf("invalid type: got=%v, want=%v", f.Type, arr.DataType())
+			}
+			if !array.ArrayEqual(got, arr) {
+				t.Fatalf("invalid array:\ngot= %v\nwant=%v", got, arr)
+			}
+		})
+	}
+}
+
+func TestRecordBatchRoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "i32", Type: arrow.PrimitiveTypes.Int32},
+			{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2, 3, 4}, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues([]string{"a", "b", "", "d"}, []bool{true, true, false, true})
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	slice := rec.NewSlice(1, 4)
+	defer slice.Release()
+
+	var (
+		carr    cdata.CArrowArray
+		cschema cdata.CArrowSchema
+	)
+	cdata.ExportArrowRecordBatch(slice, &carr, &cschema)
+	defer cdata.ReleaseCArrowSchema(&cschema)
+
+	got, err := cdata.ImportCRecordBatch(&carr, &cschema)
+	if err != nil {
+		cdata.ReleaseCArrowArray(&carr)
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if !got.Schema().Equal(schema) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", got.Schema(), schema)
+	}
+	if got, want := got.NumRows(), slice.NumRows(); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+	for i := range got.Columns() {
+		if got, want := toJSON(t, got.Column(i)), toJSON(t, slice.Column(i)); got != want {
+			t.Fatalf("invalid column %d:\ngot= %s\nwant=%s", i, got, want)
+		}
+	}
+}
+
+func TestRecordReaderRoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "i32", Type: arrow.PrimitiveTypes.Int32},
+			{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	var recs []array.Record
+	for i, n := range []int{3, 0, 2} {
+		for j := 0; j < n; j++ {
+			b.Field(0).(*array.Int32Builder).Append(int32(10*i + j))
+			b.Field(1).(*array.StringBuilder).AppendNull()
+		}
+		rec := b.NewRecord()
+		defer rec.Release()
+		recs = append(recs, rec)
+	}
+
+	for _, tc := range []struct {
+		name   string
+		schema *arrow.Schema
+	}{
+		{name: "stream-schema"},
+		{name: "given-schema", schema: schema},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr, err := array.NewRecordReader(schema, recs)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var stream cdata.CArrowArrayStream
+			cdata.ExportRecordReader(rr, &stream)
+			rr.Release()
+
+			got, err := cdata.ImportCArrayStream(&stream, tc.schema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			if !got.Schema().Equal(schema) {
+				t.Fatalf("invalid schema:\ngot= %v\nwant=%v", got.Schema(), schema)
+			}
+			n := 0
+			for ; got.Next(); n++ {
+				if n >= len(recs) {
+					t.Fatalf("too many records")
+				}
+				if !array.RecordEqual(got.Record(), recs[n]) {
+					t.Fatalf("invalid record %d:\ngot= %v\nwant=%v", n, got.Record(), recs[n])
+				}
+			}
+			if err := got.(interface{ Err() error }).Err(); err != nil {
+				t.Fatal(err)
+			}
+			if n != len(recs) {
+				t.Fatalf("invalid number of records: got=%d, want=%d", n, len(recs))
+			}
+			if got.Next() {
+				t.Fatalf("unexpected record after the end of the stream")
+			}
+
+			if _, err := cdata.ImportCArrayStream(&stream, nil); err == nil {
+				t.Fatalf("expected an error importing a released stream")
+			}
+		})
+	}
+}
+
+// failingReader is a record reader failing after its first record.
+type failingReader struct {
+	array.RecordReader
+	n int
+}
+
+func (r *failingReader) Next() bool {
+	r.n++
+	return r.n == 1 && r.RecordReader.Next()
+}
+
+func (r *failingReader) Err() error { return fmt.Errorf("read %d times", r.n) }
+
+func TestRecordReaderErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "i64", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2}, nil)
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	rr, err := array.NewRecordReader(schema, []array.Record{rec})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fr := &failingReader{RecordReader: rr}
+
+	var stream cdata.CArrowArrayStream
+	cdata.ExportRecordReader(fr, &stream)
+	rr.Release()
+
+	got, err := cdata.ImportCArrayStream(&stream, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if !got.Next() {
+		t.Fatalf("expected a first record")
+	}
+	if got.Next() {
+		t.Fatalf("expected an error reading the second record")
+	}
+	want := "arrow/cdata: read 2 times"
+	if err := got.(interface{ Err() error }).Err(); err == nil || err.Error() != want {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%s", err, want)
+	}
+	if got.Next() {
+		t.Fatalf("unexpected record after an error")
+	}
+}
+
+func TestRunEndEncodedRoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewRunEndEncodedBuilder(mem, arrow.PrimitiveTypes.Int16, arrow.PrimitiveTypes.Float64)
+	defer b.Release()
+	vb := b.ValueBuilder().(*array.Float64Builder)
+	b.Append(3)
+	vb.Append(1.5)
+	b.AppendNulls(2)
+	b.Append(4)
+	vb.Append(-1)
+	arr := b.NewArray()
+	defer arr.Release()
+	slice := array.NewSlice(arr, 2, 8).(*array.RunEndEncoded)
+	defer slice.Release()
+
+	var (
+		carr    cdata.CArrowArray
+		cschema cdata.CArrowSchema
+	)
+	cdata.ExportArrowArray(slice, &carr, &cschema)
+	defer cdata.ReleaseCArrowSchema(&cschema)
+
+	f, got, err := cdata.ImportCArray(&carr, &cschema)
+	if err != nil {
+		cdata.ReleaseCArrowArray(&carr)
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if !reflect.DeepEqual(f.Type, slice.DataType()) {
+		t.Fatalf("invalid type: got=%v, want=%v", f.Type, slice.DataType())
+	}
+	ree := got.(*array.RunEndEncoded)
+	if got, want := ree.Len(), slice.Len(); got != want {
+		t.Fatalf("invalid length: got=%d, want=%d", got, want)
+	}
+	if got, want := ree.LogicalNullN(), 2; got != want {
+		t.Fatalf("invalid number of nulls: got=%d, want=%d", got, want)
+	}
+	values := ree.Values().(*array.Float64)
+	for i := 0; i < ree.Len(); i++ {
+		if got, want := ree.IsNull(i), slice.IsNull(i); got != want {
+			t.Fatalf("invalid validity at %d: got=%v, want=%v", i, got, want)
+		}
+		if ree.IsValid(i) {
+			if got, want := values.Value(ree.PhysicalIndex(i)), slice.Values().(*array.Float64).Value(slice.PhysicalIndex(i)); got != want {
+				t.Fatalf("invalid value at %d: got=%v, want=%v", i, got, want)
+			}
+		}
+	}
+}
+
+// uuidType is an extension type storing UUIDs as 16-byte fixed size binaries.
+type uuidType struct {
+	arrow.ExtensionBase
+}
+
+func newUUIDType() *uuidType {
+	return &uuidType{ExtensionBase: arrow.ExtensionBase{Storage: &arrow.FixedSizeBinaryType{ByteWidth: 16}}}
+}
+
+func (*uuidType) Name() string            { return "uuid" }
+func (*uuidType) ExtensionName() string   { return "arrow.test.uuid" }
+func (*uuidType) ArrayType() reflect.Type { return reflect.TypeOf(&uuidArray{}) }
+func (*uuidType) Serialize() string       { return "uuid-v4" }
+
+func (*uuidType) ExtensionEquals(other arrow.ExtensionType) bool {
+	return other.ExtensionName() == "arrow.test.uuid"
+}
+
+func (*uuidType) Deserialize(storage arrow.DataType, data string) (arrow.ExtensionType, error) {
+	if data != "uuid-v4" {
+		return nil, fmt.Errorf("invalid uuid metadata %q", data)
+	}
+	return newUUIDType(), nil
+}
+
+type uuidArray struct {
+	array.ExtensionArrayBase
+}
+
+func TestExtensionRoundTrip(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	dtype := newUUIDType()
+	if err := arrow.RegisterExtensionType(dtype); err != nil {
+		t.Fatal(err)
+	}
+	defer arrow.UnregisterExtensionType(dtype.ExtensionName())
+
+	b := array.NewExtensionBuilder(mem, dtype)
+	defer b.Release()
+	fb := b.StorageBuilder().(*array.FixedSizeBinaryBuilder)
+	fb.Append([]byte("0123456789abcdef"))
+	fb.AppendNull()
+	fb.Append([]byte("fedcba9876543210"))
+	arr := b.NewArray()
+	defer arr.Release()
+
+	md := arrow.NewMetadata([]string{"k"}, []string{"v"})
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: dtype, Nullable: true, Metadata: md}}, nil)
+	rec := array.NewRecord(schema, []array.Interface{arr}, int64(arr.Len()))
+	defer rec.Release()
+
+	var (
+		carr    cdata.CArrowArray
+		cschema cdata.CArrowSchema
+	)
+	cdata.ExportArrowRecordBatch(rec, &carr, &cschema)
+	defer cdata.ReleaseCArrowSchema(&cschema)
+
+	got, err := cdata.ImportCRecordBatch(&carr, &cschema)
+	if err != nil {
+		cdata.ReleaseCArrowArray(&carr)
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if !got.Schema().Equal(schema) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", got.Schema(), schema)
+	}
+	col, ok := got.Column(0).(*uuidArray)
+	if !ok {
+		t.Fatalf("invalid column type %T", got.Column(0))
+	}
+	if got, want := toJSON(t, col.Storage()), toJSON(t, arr.(array.ExtensionArray).Storage()); got != want {
+		t.Fatalf("invalid storage:\ngot= %s\nwant=%s", got, want)
+	}
+
+	// without the registered type, the storage type is imported along with
+	// the extension metadata.
+	arrow.UnregisterExtensionType(dtype.ExtensionName())
+	sc, err := cdata.ImportCArrowSchema(&cschema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := sc.Field(0)
+	if got, want := f.Type, dtype.StorageType(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid type: got=%v, want=%v", got, want)
+	}
+	if got, want := f.Metadata.Keys(), []string{arrow.ExtensionNameKey, arrow.ExtensionMetadataKey, "k"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid metadata: got=%v, want=%v", got, want)
+	}
+	arrow.RegisterExtensionType(dtype)
+}
+
+// toJSON returns the line-delimited JSON representation of arr.
+func toJSON(t *testing.T, arr array.Interface) string {
+	t.Helper()
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "v", Type: arr.DataType(), Nullable: true}}, nil)
+	rec := array.NewRecord(schema, []array.Interface{arr}, int64(arr.Len()))
+	defer rec.Release()
+
+	var buf bytes.Buffer
+	if err := json.NewWriter(&buf, schema).Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
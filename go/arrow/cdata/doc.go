@@ -0,0 +1,34 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cdata provides import and export of Arrow arrays, records and
+// schemas through the Arrow C Data Interface.
+//
+// The C Data Interface is a stable ABI, described by the ArrowSchema and
+// ArrowArray C structures, that allows zero-copy sharing of Arrow data
+// between runtimes living in the same process (e.g. C/C++, Python or R
+// libraries called through cgo.)
+// Record readers are shared as streams of record batches, described by the
+// ArrowArrayStream structure of the C Stream Interface.
+//
+// Importing an array moves the ownership of the C structure to Go: the
+// producer's release callback is invoked once all the Go arrays built from
+// it have been released.
+// Exporting an array, or a record reader, retains its underlying memory
+// until the consumer calls the release callback of the exported structure.
+//
+// This package requires cgo.
+package cdata
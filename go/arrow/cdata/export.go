@@ -0,0 +1,425 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdata
+
+// #include <errno.h>
+// #include <stdlib.h>
+// #include "arrow/c/abi.h"
+//
+// void releaseExportedSchema(struct ArrowSchema* schema);
+// void releaseExportedArray(struct ArrowArray* array);
+//
+// static void setSchemaRelease(struct ArrowSchema* schema) { schema->release = releaseExportedSchema; }
+// static void setArrayRelease(struct ArrowArray* array) { array->release = releaseExportedArray; }
+//
+// int getExportedStreamSchema(struct ArrowArrayStream* stream, struct ArrowSchema* out);
+// int getExportedStreamNext(struct ArrowArrayStream* stream, struct ArrowArray* out);
+// const char* getExportedStreamLastError(struct ArrowArrayStream* stream);
+// void releaseExportedStream(struct ArrowArrayStream* stream);
+//
+// static void setStreamCallbacks(struct ArrowArrayStream* stream) {
+//   stream->get_schema = getExportedStreamSchema;
+//   stream->get_next = getExportedStreamNext;
+//   stream->get_last_error = getExportedStreamLastError;
+//   stream->release = releaseExportedStream;
+// }
+import "C"
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"runtime/cgo"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// ExportArrowField exports the field f to out.
+//
+// The consumer of out is responsible for calling its release callback.
+// ExportArrowField panics if the type of f can not be exported.
+func ExportArrowField(f arrow.Field, out *CArrowSchema) {
+	exportField(f, out)
+}
+
+// ExportArrowSchema exports schema to out as a struct type whose fields are
+// the fields of the schema.
+//
+// The consumer of out is responsible for calling its release callback.
+// ExportArrowSchema panics if the type of a field can not be exported.
+func ExportArrowSchema(schema *arrow.Schema, out *CArrowSchema) {
+	exportField(arrow.Field{Type: arrow.StructOf(schema.Fields()...), Metadata: schema.Metadata()}, out)
+}
+
+// ExportArrowArray exports arr to out, without any copy of its buffers.
+// If outSchema is not nil, the type of arr is exported to outSchema.
+//
+// The array memory is retained until the consumer of out calls its release
+// callback.
+// ExportArrowArray panics if the type of arr can not be exported.
+func ExportArrowArray(arr array.Interface, out *CArrowArray, outSchema *CArrowSchema) {
+	if outSchema != nil {
+		exportField(arrow.Field{Type: arr.DataType(), Nullable: true}, outSchema)
+	}
+	exportArray(arr.Data(), out)
+}
+
+// ExportArrowRecordBatch exports rec to out as a struct array whose children
+// are the columns of the record, without any copy of its buffers.
+// If outSchema is not nil, the schema of rec is exported to outSchema.
+//
+// The record memory is retained until the consumer of out calls its release
+// callback.
+// ExportArrowRecordBatch panics if the type of a column can not be exported.
+func ExportArrowRecordBatch(rec array.Record, out *CArrowArray, outSchema *CArrowSchema) {
+	if outSchema != nil {
+		ExportArrowSchema(rec.Schema(), outSchema)
+	}
+
+	children := make([]*array.Data, rec.NumCols())
+	for i, col := range rec.Columns() {
+		children[i] = col.Data()
+	}
+	data := array.NewData(arrow.StructOf(rec.Schema().Fields()...), int(rec.NumRows()), []*memory.Buffer{nil, nil}, children, 0, 0)
+	defer data.Release()
+
+	exportArray(data, out)
+}
+
+// ExportRecordReader exports rdr to out as a stream of struct arrays, one
+// for each record of rdr (see ExportArrowRecordBatch).
+//
+// The reader is retained until the consumer of out calls its release
+// callback. Errors of the reader, and types that can not be exported, are
+// reported by the get_next and get_schema callbacks of out.
+func ExportRecordReader(rdr array.RecordReader, out *CArrowArrayStream) {
+	rdr.Retain()
+
+	h := (*cgo.Handle)(C.malloc(C.size_t(unsafe.Sizeof(cgo.Handle(0)))))
+	*h = cgo.NewHandle(&exportedStream{rdr: rdr})
+	out.private_data = unsafe.Pointer(h)
+
+	C.setStreamCallbacks(out)
+}
+
+// exportedStream is the state of an exported record reader.
+type exportedStream struct {
+	rdr array.RecordReader
+	err *C.char // description of the last error, in C memory
+}
+
+// call calls f and returns the errno code of the stream callback calling
+// it: EIO if f failed, EINVAL if it panicked.
+func (s *exportedStream) call(f func() error) (rc C.int) {
+	C.free(unsafe.Pointer(s.err))
+	s.err = nil
+
+	defer func() {
+		if e := recover(); e != nil {
+			s.err = C.CString(fmt.Sprint(e))
+			rc = C.EINVAL
+		}
+	}()
+	if err := f(); err != nil {
+		s.err = C.CString(err.Error())
+		return C.EIO
+	}
+	return 0
+}
+
+// next exports the next record of the reader to out, or marks out as
+// released at the end of the reader.
+func (s *exportedStream) next(out *CArrowArray) error {
+	if s.rdr.Next() {
+		ExportArrowRecordBatch(s.rdr.Record(), out, nil)
+		return nil
+	}
+	if r, ok := s.rdr.(interface{ Err() error }); ok && r.Err() != nil {
+		return r.Err()
+	}
+	out.release = nil
+	return nil
+}
+
+func exportFormat(dt arrow.DataType) string {
+	switch dt := dt.(type) {
+	case *arrow.FixedSizeBinaryType:
+		return "w:" + strconv.Itoa(dt.ByteWidth)
+	case *arrow.Decimal256Type:
+		return fmt.Sprintf("d:%d,%d,256", dt.Precision, dt.Scale)
+	case *arrow.Time32Type:
+		return "tt" + unitToFormat[dt.Unit]
+	case *arrow.Time64Type:
+		return "tt" + unitToFormat[dt.Unit]
+	case *arrow.TimestampType:
+		return "ts" + unitToFormat[dt.Unit] + ":" + dt.TimeZone
+	case *arrow.DurationType:
+		return "tD" + unitToFormat[dt.Unit]
+	case *arrow.MonthIntervalType:
+		return "tiM"
+	case *arrow.DayTimeIntervalType:
+		return "tiD"
+	case *arrow.MonthDayNanoIntervalType:
+		return "tin"
+	case *arrow.ListType:
+		return "+l"
+	case *arrow.LargeListType:
+		return "+L"
+	case *arrow.FixedSizeListType:
+		return "+w:" + strconv.Itoa(int(dt.Len()))
+	case *arrow.StructType:
+		return "+s"
+	case *arrow.MapType:
+		return "+m"
+	case *arrow.UnionType:
+		codes := make([]string, len(dt.TypeCodes()))
+		for i, c := range dt.TypeCodes() {
+			codes[i] = strconv.Itoa(int(c))
+		}
+		mode := "+us:"
+		if dt.Mode() == arrow.DenseMode {
+			mode = "+ud:"
+		}
+		return mode + strings.Join(codes, ",")
+	case *arrow.RunEndEncodedType:
+		return "+r"
+	case *arrow.DictionaryType:
+		return exportFormat(dt.IndexType())
+	}
+
+	for format, typ := range formatToType {
+		if typ.ID() == dt.ID() {
+			return format
+		}
+	}
+	panic(fmt.Errorf("arrow/cdata: unsupported data type %s", dt.Name()))
+}
+
+var unitToFormat = map[arrow.TimeUnit]string{
+	arrow.Second:      "s",
+	arrow.Millisecond: "m",
+	arrow.Microsecond: "u",
+	arrow.Nanosecond:  "n",
+}
+
+func exportField(f arrow.Field, out *CArrowSchema) {
+	if ext, ok := f.Type.(arrow.ExtensionType); ok {
+		f.Metadata = arrow.ExtensionMetadata(ext, f.Metadata)
+		f.Type = ext.StorageType()
+	}
+
+	var children []arrow.Field
+	switch dt := f.Type.(type) {
+	case *arrow.ListType:
+		children = []arrow.Field{{Name: "item", Type: dt.Elem(), Nullable: true}}
+	case *arrow.LargeListType:
+		children = []arrow.Field{{Name: "item", Type: dt.Elem(), Nullable: true}}
+	case *arrow.FixedSizeListType:
+		children = []arrow.Field{{Name: "item", Type: dt.Elem(), Nullable: true}}
+	case *arrow.StructType:
+		children = dt.Fields()
+	case *arrow.MapType:
+		children = []arrow.Field{{Name: "entries", Type: dt.ValueType()}}
+	case *arrow.UnionType:
+		children = dt.Fields()
+	case *arrow.RunEndEncodedType:
+		children = dt.Fields()
+	}
+
+	out.format = C.CString(exportFormat(f.Type))
+	out.name = C.CString(f.Name)
+	out.metadata = nil
+	if md := encodeMetadata(f.Metadata); md != nil {
+		out.metadata = (*C.char)(C.CBytes(md))
+	}
+	out.flags = 0
+	if f.Nullable {
+		out.flags |= flagNullable
+	}
+	if dt, ok := f.Type.(*arrow.MapType); ok && dt.KeysSorted {
+		out.flags |= flagMapKeysSorted
+	}
+	out.n_children = C.int64_t(len(children))
+	out.children = nil
+	out.dictionary = nil
+	out.private_data = nil
+
+	if len(children) > 0 {
+		out.children = (**CArrowSchema)(C.malloc(C.size_t(len(children)) * C.size_t(unsafe.Sizeof((*CArrowSchema)(nil)))))
+		block := (*CArrowSchema)(C.malloc(C.size_t(len(children)) * C.sizeof_struct_ArrowSchema))
+		kids := schemaChildren(out)
+		for i, child := range children {
+			kids[i] = (*CArrowSchema)(unsafe.Pointer(uintptr(unsafe.Pointer(block)) + uintptr(i)*C.sizeof_struct_ArrowSchema))
+			exportField(child, kids[i])
+		}
+	}
+	if dt, ok := f.Type.(*arrow.DictionaryType); ok {
+		out.dictionary = (*CArrowSchema)(C.malloc(C.sizeof_struct_ArrowSchema))
+		exportField(arrow.Field{Type: dt.ValueType(), Nullable: true}, out.dictionary)
+	}
+
+	C.setSchemaRelease(out)
+}
+
+// encodeMetadata encodes md with the binary layout described in decodeMetadata.
+func encodeMetadata(md arrow.Metadata) []byte {
+	if md.Len() == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	writeInt32 := func(v int) {
+		n := int32(v)
+		buf.Write((*[4]byte)(unsafe.Pointer(&n))[:])
+	}
+	writeInt32(md.Len())
+	for i, k := range md.Keys() {
+		v := md.Values()[i]
+		writeInt32(len(k))
+		buf.WriteString(k)
+		writeInt32(len(v))
+		buf.WriteString(v)
+	}
+	return buf.Bytes()
+}
+
+// exportedArray holds the Go memory referenced by an exported array.
+type exportedArray struct {
+	data   *array.Data
+	packed *memory.Buffer // values of a fixed size binary array, if they had to be packed
+	sizes  *memory.Buffer // sizes of the data buffers of a view array
+	pinner runtime.Pinner
+}
+
+// packFixedSizeBinary returns the values of a fixed size binary array laid
+// out contiguously, as mandated by the C data interface.
+// array.FixedSizeBinary locates its values through an offsets buffer and
+// does not store any bytes for null values appended by its builder.
+func packFixedSizeBinary(data *array.Data, width int) *memory.Buffer {
+	var (
+		n       = data.Offset() + data.Len()
+		offsets = arrow.Int32Traits.CastFromBytes(data.Buffers()[1].Bytes())
+		values  []byte
+	)
+	if b := data.Buffers()[2]; b != nil {
+		values = b.Bytes()
+	}
+
+	packed := true
+	for i := 0; i <= n && packed; i++ {
+		packed = int(offsets[i]) == i*width
+	}
+	if packed {
+		return data.Buffers()[2]
+	}
+
+	buf := make([]byte, n*width)
+	for i := 0; i < n; i++ {
+		copy(buf[i*width:(i+1)*width], values[offsets[i]:offsets[i+1]])
+	}
+	return memory.NewBufferBytes(buf)
+}
+
+func exportArray(data *array.Data, out *CArrowArray) {
+	var (
+		exp     = &exportedArray{data: data}
+		buffers = data.Buffers()
+		nulls   = data.NullN()
+	)
+	data.Retain()
+
+	dtype := data.DataType()
+	if ext, ok := dtype.(arrow.ExtensionType); ok {
+		dtype = ext.StorageType()
+	}
+
+	switch dt := dtype.(type) {
+	case *arrow.NullType:
+		buffers = nil
+	case *arrow.FixedSizeBinaryType:
+		buffers = []*memory.Buffer{buffers[0], packFixedSizeBinary(data, dt.ByteWidth)}
+		exp.packed = buffers[1]
+	case *arrow.StructType:
+		buffers = buffers[:1]
+	case *arrow.UnionType:
+		// unions have no validity bitmap: their nulls are those of their
+		// children.
+		buffers = buffers[1:2]
+		if dt.Mode() == arrow.DenseMode {
+			buffers = data.Buffers()[1:3]
+		}
+		nulls = 0
+	case arrow.BinaryViewDataType:
+		sizes := make([]int64, len(buffers)-2)
+		for i, b := range buffers[2:] {
+			if b != nil {
+				sizes[i] = int64(b.Len())
+			}
+		}
+		exp.sizes = memory.NewBufferBytes(arrow.Int64Traits.CastToBytes(sizes))
+		buffers = append(buffers[:len(buffers):len(buffers)], exp.sizes)
+	case *arrow.RunEndEncodedType:
+		buffers = nil
+	}
+
+	out.length = C.int64_t(data.Len())
+	out.null_count = C.int64_t(nulls)
+	out.offset = C.int64_t(data.Offset())
+	out.n_buffers = C.int64_t(len(buffers))
+	out.n_children = C.int64_t(len(data.Children()))
+	out.buffers = nil
+	out.children = nil
+	out.dictionary = nil
+
+	if len(buffers) > 0 {
+		out.buffers = (*unsafe.Pointer)(C.malloc(C.size_t(len(buffers)) * C.size_t(unsafe.Sizeof(unsafe.Pointer(nil)))))
+		ptrs := arrayBuffers(out)
+		for i, b := range buffers {
+			ptrs[i] = nil
+			if b != nil && b.Len() > 0 {
+				p := &b.Bytes()[0]
+				exp.pinner.Pin(p)
+				ptrs[i] = unsafe.Pointer(p)
+			}
+		}
+	}
+
+	if n := len(data.Children()); n > 0 {
+		out.children = (**CArrowArray)(C.malloc(C.size_t(n) * C.size_t(unsafe.Sizeof((*CArrowArray)(nil)))))
+		block := (*CArrowArray)(C.malloc(C.size_t(n) * C.sizeof_struct_ArrowArray))
+		kids := arrayChildren(out)
+		for i, child := range data.Children() {
+			kids[i] = (*CArrowArray)(unsafe.Pointer(uintptr(unsafe.Pointer(block)) + uintptr(i)*C.sizeof_struct_ArrowArray))
+			exportArray(child, kids[i])
+		}
+	}
+	if dict := data.Dictionary(); dict != nil {
+		out.dictionary = (*CArrowArray)(C.malloc(C.sizeof_struct_ArrowArray))
+		exportArray(dict, out.dictionary)
+	}
+
+	h := (*cgo.Handle)(C.malloc(C.size_t(unsafe.Sizeof(cgo.Handle(0)))))
+	*h = cgo.NewHandle(exp)
+	out.private_data = unsafe.Pointer(h)
+
+	C.setArrayRelease(out)
+}
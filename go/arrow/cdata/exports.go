@@ -0,0 +1,120 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cdata
+
+// #include <stdlib.h>
+// #include "arrow/c/abi.h"
+import "C"
+
+import (
+	"runtime/cgo"
+	"unsafe"
+)
+
+//export releaseExportedSchema
+func releaseExportedSchema(schema *CArrowSchema) {
+	if schema.release == nil {
+		return
+	}
+
+	C.free(unsafe.Pointer(schema.format))
+	C.free(unsafe.Pointer(schema.name))
+	C.free(unsafe.Pointer(schema.metadata))
+
+	if kids := schemaChildren(schema); len(kids) > 0 {
+		for _, child := range kids {
+			ReleaseCArrowSchema(child)
+		}
+		C.free(unsafe.Pointer(kids[0]))
+		C.free(unsafe.Pointer(schema.children))
+	}
+	if schema.dictionary != nil {
+		ReleaseCArrowSchema(schema.dictionary)
+		C.free(unsafe.Pointer(schema.dictionary))
+	}
+
+	schema.release = nil
+}
+
+func exportedStreamOf(stream *CArrowArrayStream) *exportedStream {
+	return (*cgo.Handle)(stream.private_data).Value().(*exportedStream)
+}
+
+//export getExportedStreamSchema
+func getExportedStreamSchema(stream *CArrowArrayStream, out *CArrowSchema) C.int {
+	s := exportedStreamOf(stream)
+	return s.call(func() error {
+		ExportArrowSchema(s.rdr.Schema(), out)
+		return nil
+	})
+}
+
+//export getExportedStreamNext
+func getExportedStreamNext(stream *CArrowArrayStream, out *CArrowArray) C.int {
+	s := exportedStreamOf(stream)
+	return s.call(func() error { return s.next(out) })
+}
+
+//export getExportedStreamLastError
+func getExportedStreamLastError(stream *CArrowArrayStream) *C.char {
+	return exportedStreamOf(stream).err
+}
+
+//export releaseExportedStream
+func releaseExportedStream(stream *CArrowArrayStream) {
+	if stream.release == nil {
+		return
+	}
+
+	h := (*cgo.Handle)(stream.private_data)
+	s := h.Value().(*exportedStream)
+	C.free(unsafe.Pointer(s.err))
+	s.rdr.Release()
+	h.Delete()
+	C.free(stream.private_data)
+
+	stream.release = nil
+}
+
+//export releaseExportedArray
+func releaseExportedArray(arr *CArrowArray) {
+	if arr.release == nil {
+		return
+	}
+
+	if kids := arrayChildren(arr); len(kids) > 0 {
+		for _, child := range kids {
+			ReleaseCArrowArray(child)
+		}
+		C.free(unsafe.Pointer(kids[0]))
+		C.free(unsafe.Pointer(arr.children))
+	}
+	if arr.dictionary != nil {
+		ReleaseCArrowArray(arr.dictionary)
+		C.free(unsafe.Pointer(arr.dictionary))
+	}
+	C.free(unsafe.Pointer(arr.buffers))
+
+	h := (*cgo.Handle)(arr.private_data)
+	exp := h.Value().(*exportedArray)
+	exp.pinner.Unpin()
+	exp.data.Release()
+	h.Delete()
+	C.free(arr.private_data)
+
+	arr.release = nil
+}
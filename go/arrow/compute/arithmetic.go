@@ -0,0 +1,315 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"reflect"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+type arithConfig struct {
+	allowOverflow bool
+}
+
+type arithOp int
+
+const (
+	opAdd arithOp = iota
+	opSubtract
+	opMultiply
+	opDivide
+)
+
+func (op arithOp) String() string {
+	return [...]string{"add", "subtract", "multiply", "divide"}[op]
+}
+
+// Add returns the elementwise sum of the numeric arrays a and b, which must
+// have the same type and length. An element of the output is null if any of
+// the corresponding inputs is null.
+//
+// By default, Add returns an error if an integer result overflows the data
+// type of the inputs. See WithAllowOverflow.
+func Add(mem memory.Allocator, a, b array.Interface, opts ...Option) (array.Interface, error) {
+	return arithmetic(mem, opAdd, a, b, opts)
+}
+
+// Subtract returns the elementwise difference of the numeric arrays a and b.
+// See Add for the handling of nulls and overflows.
+func Subtract(mem memory.Allocator, a, b array.Interface, opts ...Option) (array.Interface, error) {
+	return arithmetic(mem, opSubtract, a, b, opts)
+}
+
+// Multiply returns the elementwise product of the numeric arrays a and b.
+// See Add for the handling of nulls and overflows.
+func Multiply(mem memory.Allocator, a, b array.Interface, opts ...Option) (array.Interface, error) {
+	return arithmetic(mem, opMultiply, a, b, opts)
+}
+
+// Divide returns the elementwise quotient of the numeric arrays a and b.
+// See Add for the handling of nulls and overflows.
+// Integer division truncates towards zero, and returns an error when dividing
+// by zero. Floating point division follows IEEE-754.
+func Divide(mem memory.Allocator, a, b array.Interface, opts ...Option) (array.Interface, error) {
+	return arithmetic(mem, opDivide, a, b, opts)
+}
+
+// checkBinary returns an error if a and b can not be the operands of an
+// elementwise binary kernel.
+func checkBinary(a, b array.Interface) error {
+	if !reflect.DeepEqual(a.DataType(), b.DataType()) {
+		return fmt.Errorf("arrow/compute: mismatched data types %s and %s", a.DataType().Name(), b.DataType().Name())
+	}
+	if a.Len() != b.Len() {
+		return fmt.Errorf("arrow/compute: mismatched lengths %d and %d", a.Len(), b.Len())
+	}
+	return nil
+}
+
+func arithmetic(mem memory.Allocator, op arithOp, a, b array.Interface, opts []Option) (array.Interface, error) {
+	cfg := &arithConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if err := checkBinary(a, b); err != nil {
+		return nil, err
+	}
+
+	dt := a.DataType()
+	cat := categoryOf(dt)
+	switch cat {
+	case catInt, catUint, catFloat:
+	default:
+		return nil, fmt.Errorf("arrow/compute: unsupported data type %s for %s", dt.Name(), op)
+	}
+
+	bld := array.NewBuilder(mem, dt)
+	defer bld.Release()
+	bld.Reserve(a.Len())
+
+	var (
+		ga  = getter(a)
+		gb  = getter(b)
+		app = appender(bld, dt, &castConfig{allowOverflow: cfg.allowOverflow})
+	)
+	for i := 0; i < a.Len(); i++ {
+		if a.IsNull(i) || b.IsNull(i) {
+			bld.AppendNull()
+			continue
+		}
+
+		x, y := ga(i), gb(i)
+		r := value{cat: cat, dt: dt}
+		var (
+			overflow bool
+			err      error
+		)
+		switch cat {
+		case catInt:
+			r.i, overflow, err = arithInt(op, x.i, y.i)
+		case catUint:
+			r.u, overflow, err = arithUint(op, x.u, y.u)
+		case catFloat:
+			r.f = arithFloat(op, x.f, y.f)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if overflow && !cfg.allowOverflow {
+			return nil, fmt.Errorf("arrow/compute: %s(%s, %s) overflows %s", op, formatValue(x), formatValue(y), dt.Name())
+		}
+		if err := app(r); err != nil {
+			// the result does not fit in a narrower integer type.
+			return nil, fmt.Errorf("arrow/compute: %s(%s, %s) overflows %s", op, formatValue(x), formatValue(y), dt.Name())
+		}
+	}
+	return bld.NewArray(), nil
+}
+
+var errDivideByZero = errors.New("arrow/compute: integer division by zero")
+
+func arithInt(op arithOp, x, y int64) (r int64, overflow bool, err error) {
+	switch op {
+	case opAdd:
+		r = x + y
+		overflow = (x > 0 && y > 0 && r < 0) || (x < 0 && y < 0 && r >= 0)
+	case opSubtract:
+		r = x - y
+		overflow = (x >= 0 && y < 0 && r < 0) || (x < 0 && y > 0 && r >= 0)
+	case opMultiply:
+		r = x * y
+		overflow = x != 0 && (r/x != y || (x == -1 && y == math.MinInt64))
+	case opDivide:
+		if y == 0 {
+			return 0, false, errDivideByZero
+		}
+		overflow = x == math.MinInt64 && y == -1
+		if overflow {
+			return x, true, nil
+		}
+		r = x / y
+	}
+	return r, overflow, nil
+}
+
+func arithUint(op arithOp, x, y uint64) (r uint64, overflow bool, err error) {
+	switch op {
+	case opAdd:
+		var carry uint64
+		r, carry = bits.Add64(x, y, 0)
+		overflow = carry != 0
+	case opSubtract:
+		var borrow uint64
+		r, borrow = bits.Sub64(x, y, 0)
+		overflow = borrow != 0
+	case opMultiply:
+		var hi uint64
+		hi, r = bits.Mul64(x, y)
+		overflow = hi != 0
+	case opDivide:
+		if y == 0 {
+			return 0, false, errDivideByZero
+		}
+		r = x / y
+	}
+	return r, overflow, nil
+}
+
+func arithFloat(op arithOp, x, y float64) float64 {
+	switch op {
+	case opAdd:
+		return x + y
+	case opSubtract:
+		return x - y
+	case opMultiply:
+		return x * y
+	default:
+		return x / y
+	}
+}
+
+type compareOp int
+
+const (
+	opEqual compareOp = iota
+	opNotEqual
+	opLess
+	opLessEqual
+	opGreater
+	opGreaterEqual
+)
+
+// Equal returns whether the elements of a and b, which must have the same
+// type and length, are equal. An element of the output is null if any of the
+// corresponding inputs is null.
+// Numeric, boolean, string, binary and temporal arrays are supported.
+func Equal(mem memory.Allocator, a, b array.Interface) (*array.Boolean, error) {
+	return compare(mem, opEqual, a, b)
+}
+
+// NotEqual returns whether the elements of a and b are different.
+// See Equal for the handling of nulls.
+func NotEqual(mem memory.Allocator, a, b array.Interface) (*array.Boolean, error) {
+	return compare(mem, opNotEqual, a, b)
+}
+
+// Less returns whether the elements of a are less than the elements of b.
+// See Equal for the handling of nulls.
+func Less(mem memory.Allocator, a, b array.Interface) (*array.Boolean, error) {
+	return compare(mem, opLess, a, b)
+}
+
+// LessEqual returns whether the elements of a are less than or equal to the
+// elements of b. See Equal for the handling of nulls.
+func LessEqual(mem memory.Allocator, a, b array.Interface) (*array.Boolean, error) {
+	return compare(mem, opLessEqual, a, b)
+}
+
+// Greater returns whether the elements of a are greater than the elements
+// of b. See Equal for the handling of nulls.
+func Greater(mem memory.Allocator, a, b array.Interface) (*array.Boolean, error) {
+	return compare(mem, opGreater, a, b)
+}
+
+// GreaterEqual returns whether the elements of a are greater than or equal
+// to the elements of b. See Equal for the handling of nulls.
+func GreaterEqual(mem memory.Allocator, a, b array.Interface) (*array.Boolean, error) {
+	return compare(mem, opGreaterEqual, a, b)
+}
+
+func compare(mem memory.Allocator, op compareOp, a, b array.Interface) (*array.Boolean, error) {
+	if err := checkBinary(a, b); err != nil {
+		return nil, err
+	}
+
+	bld := array.NewBooleanBuilder(mem)
+	defer bld.Release()
+	bld.Reserve(a.Len())
+
+	if a.DataType().ID() == arrow.NULL {
+		for i := 0; i < a.Len(); i++ {
+			bld.AppendNull()
+		}
+		return bld.NewBooleanArray(), nil
+	}
+
+	cmp, err := valueComparer(a.DataType())
+	if err != nil {
+		return nil, err
+	}
+
+	isFloat := categoryOf(a.DataType()) == catFloat
+	ga, gb := getter(a), getter(b)
+	for i := 0; i < a.Len(); i++ {
+		if a.IsNull(i) || b.IsNull(i) {
+			bld.AppendNull()
+			continue
+		}
+
+		x, y := ga(i), gb(i)
+		if isFloat && (math.IsNaN(x.f) || math.IsNaN(y.f)) {
+			// NaN compares unequal to everything, itself included.
+			bld.Append(op == opNotEqual)
+			continue
+		}
+
+		c := cmp(x, y)
+		switch op {
+		case opEqual:
+			bld.Append(c == 0)
+		case opNotEqual:
+			bld.Append(c != 0)
+		case opLess:
+			bld.Append(c < 0)
+		case opLessEqual:
+			bld.Append(c <= 0)
+		case opGreater:
+			bld.Append(c > 0)
+		case opGreaterEqual:
+			bld.Append(c >= 0)
+		}
+	}
+	return bld.NewBooleanArray(), nil
+}
@@ -0,0 +1,286 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+type binaryKernel func(mem memory.Allocator, a, b array.Interface, opts ...compute.Option) (array.Interface, error)
+
+func TestArithmetic(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	for _, tc := range []struct {
+		name   string
+		kernel binaryKernel
+		dtype  arrow.DataType
+		a, b   []string
+		want   []string
+		opts   []compute.Option
+	}{
+		{
+			name: "add-int32", kernel: compute.Add, dtype: arrow.PrimitiveTypes.Int32,
+			a: []string{"1", "null", "-3"}, b: []string{"2", "5", "null"},
+			want: []string{"3", "null", "null"},
+		},
+		{
+			name: "add-int8-wrap", kernel: compute.Add, dtype: arrow.PrimitiveTypes.Int8,
+			a: []string{"127"}, b: []string{"1"},
+			want: []string{"-128"},
+			opts: []compute.Option{compute.WithAllowOverflow(true)},
+		},
+		{
+			name: "subtract-uint8-wrap", kernel: compute.Subtract, dtype: arrow.PrimitiveTypes.Uint8,
+			a: []string{"0"}, b: []string{"1"},
+			want: []string{"255"},
+			opts: []compute.Option{compute.WithAllowOverflow(true)},
+		},
+		{
+			name: "subtract-int64", kernel: compute.Subtract, dtype: arrow.PrimitiveTypes.Int64,
+			a: []string{"10", "-9223372036854775807"}, b: []string{"20", "1"},
+			want: []string{"-10", "-9223372036854775808"},
+		},
+		{
+			name: "multiply-uint64", kernel: compute.Multiply, dtype: arrow.PrimitiveTypes.Uint64,
+			a: []string{"4294967296", "3"}, b: []string{"4294967295", "0"},
+			want: []string{"18446744069414584320", "0"},
+		},
+		{
+			name: "divide-int16", kernel: compute.Divide, dtype: arrow.PrimitiveTypes.Int16,
+			a: []string{"7", "-7"}, b: []string{"2", "2"},
+			want: []string{"3", "-3"},
+		},
+		{
+			name: "divide-float64", kernel: compute.Divide, dtype: arrow.PrimitiveTypes.Float64,
+			a: []string{"1", "null"}, b: []string{"4", "0"},
+			want: []string{"0.25", "null"},
+		},
+		{
+			name: "multiply-float32", kernel: compute.Multiply, dtype: arrow.PrimitiveTypes.Float32,
+			a: []string{"1.5"}, b: []string{"-2"},
+			want: []string{"-3"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			a := fromJSON(t, mem, tc.dtype, tc.a...)
+			defer a.Release()
+			b := fromJSON(t, mem, tc.dtype, tc.b...)
+			defer b.Release()
+
+			got, err := tc.kernel(mem, a, b, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			want := fromJSON(t, mem, tc.dtype, tc.want...)
+			defer want.Release()
+
+			if got, want := toJSON(t, got), toJSON(t, want); got != want {
+				t.Fatalf("invalid result:\ngot= %s\nwant=%s", got, want)
+			}
+		})
+	}
+}
+
+func TestArithmeticErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	for _, tc := range []struct {
+		name   string
+		kernel binaryKernel
+		at, bt arrow.DataType
+		a, b   []string
+		err    string
+	}{
+		{
+			name: "add-int8-overflow", kernel: compute.Add,
+			at: arrow.PrimitiveTypes.Int8, a: []string{"100"},
+			bt: arrow.PrimitiveTypes.Int8, b: []string{"100"},
+			err: "arrow/compute: add(100, 100) overflows int8",
+		},
+		{
+			name: "multiply-int64-overflow", kernel: compute.Multiply,
+			at: arrow.PrimitiveTypes.Int64, a: []string{"4294967296"},
+			bt: arrow.PrimitiveTypes.Int64, b: []string{"4294967296"},
+			err: "arrow/compute: multiply(4294967296, 4294967296) overflows int64",
+		},
+		{
+			name: "subtract-uint32-overflow", kernel: compute.Subtract,
+			at: arrow.PrimitiveTypes.Uint32, a: []string{"1"},
+			bt: arrow.PrimitiveTypes.Uint32, b: []string{"2"},
+			err: "arrow/compute: subtract(1, 2) overflows uint32",
+		},
+		{
+			name: "divide-by-zero", kernel: compute.Divide,
+			at: arrow.PrimitiveTypes.Int32, a: []string{"1"},
+			bt: arrow.PrimitiveTypes.Int32, b: []string{"0"},
+			err: "arrow/compute: integer division by zero",
+		},
+		{
+			name: "mismatched-types", kernel: compute.Add,
+			at: arrow.PrimitiveTypes.Int32, a: []string{"1"},
+			bt: arrow.PrimitiveTypes.Int64, b: []string{"1"},
+			err: "arrow/compute: mismatched data types int32 and int64",
+		},
+		{
+			name: "mismatched-lengths", kernel: compute.Add,
+			at: arrow.PrimitiveTypes.Int32, a: []string{"1"},
+			bt: arrow.PrimitiveTypes.Int32, b: []string{"1", "2"},
+			err: "arrow/compute: mismatched lengths 1 and 2",
+		},
+		{
+			name: "unsupported", kernel: compute.Add,
+			at: arrow.BinaryTypes.String, a: []string{`"a"`},
+			bt: arrow.BinaryTypes.String, b: []string{`"b"`},
+			err: "arrow/compute: unsupported data type utf8 for add",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			a := fromJSON(t, mem, tc.at, tc.a...)
+			defer a.Release()
+			b := fromJSON(t, mem, tc.bt, tc.b...)
+			defer b.Release()
+
+			_, err := tc.kernel(mem, a, b)
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("invalid error:\ngot= %v\nwant=%s", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestComparison(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	kernels := []struct {
+		name string
+		fn   func(mem memory.Allocator, a, b array.Interface) (*array.Boolean, error)
+	}{
+		{"equal", compute.Equal},
+		{"not-equal", compute.NotEqual},
+		{"less", compute.Less},
+		{"less-equal", compute.LessEqual},
+		{"greater", compute.Greater},
+		{"greater-equal", compute.GreaterEqual},
+	}
+
+	for _, tc := range []struct {
+		name  string
+		dtype arrow.DataType
+		a, b  []string
+		want  []string // results of the kernels, in order, as "eq ne lt le gt ge"
+	}{
+		{
+			name: "int64", dtype: arrow.PrimitiveTypes.Int64,
+			a: []string{"1", "2", "3", "null"}, b: []string{"2", "2", "2", "1"},
+			want: []string{"F T F N", "T F T N", "T F F N", "T T F N", "F F T N", "F T T N"},
+		},
+		{
+			name: "uint8", dtype: arrow.PrimitiveTypes.Uint8,
+			a: []string{"0", "255"}, b: []string{"255", "255"},
+			want: []string{"F T", "T F", "T F", "T T", "F F", "F T"},
+		},
+		{
+			name: "float64", dtype: arrow.PrimitiveTypes.Float64,
+			a: []string{"-1.5", "0"}, b: []string{"1", "0"},
+			want: []string{"F T", "T F", "T F", "T T", "F F", "F T"},
+		},
+		{
+			name: "string", dtype: arrow.BinaryTypes.String,
+			a: []string{`"a"`, `"b"`, "null"}, b: []string{`"b"`, `"b"`, `"c"`},
+			want: []string{"F T N", "T F N", "T F N", "T T N", "F F N", "F T N"},
+		},
+		{
+			name: "bool", dtype: arrow.FixedWidthTypes.Boolean,
+			a: []string{"false", "true"}, b: []string{"true", "true"},
+			want: []string{"F T", "T F", "T F", "T T", "F F", "F T"},
+		},
+		{
+			name: "date32", dtype: arrow.PrimitiveTypes.Date32,
+			a: []string{`"2019-01-01"`}, b: []string{`"2018-01-01"`},
+			want: []string{"F", "T", "F", "F", "T", "T"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			a := fromJSON(t, mem, tc.dtype, tc.a...)
+			defer a.Release()
+			b := fromJSON(t, mem, tc.dtype, tc.b...)
+			defer b.Release()
+
+			for k, kernel := range kernels {
+				got, err := kernel.fn(mem, a, b)
+				if err != nil {
+					t.Fatalf("%s: %v", kernel.name, err)
+				}
+				defer got.Release()
+
+				res := make([]string, got.Len())
+				for i := range res {
+					switch {
+					case got.IsNull(i):
+						res[i] = "N"
+					case got.Value(i):
+						res[i] = "T"
+					default:
+						res[i] = "F"
+					}
+				}
+				if got, want := strings.Join(res, " "), tc.want[k]; got != want {
+					t.Fatalf("%s: got=%s, want=%s", kernel.name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestComparisonNaN(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewFloat64Builder(mem)
+	defer b.Release()
+
+	b.AppendValues([]float64{0, 1}, nil)
+	x := b.NewFloat64Array()
+	defer x.Release()
+
+	b.AppendValues([]float64{math.NaN(), 1}, nil)
+	y := b.NewFloat64Array()
+	defer y.Release()
+
+	eq, err := compute.Equal(mem, x, y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer eq.Release()
+
+	if eq.Value(0) || !eq.Value(1) {
+		t.Fatalf("invalid comparison with NaN: got=[%v %v]", eq.Value(0), eq.Value(1))
+	}
+}
@@ -0,0 +1,83 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// And returns the logical conjunction of a and b, which must have the same
+// length, using Kleene logic: an element of the output is false if any of
+// the inputs is false, null if any of the inputs is null, and true otherwise.
+func And(mem memory.Allocator, a, b *array.Boolean) (*array.Boolean, error) {
+	return kleene(mem, a, b, false)
+}
+
+// Or returns the logical disjunction of a and b, which must have the same
+// length, using Kleene logic: an element of the output is true if any of
+// the inputs is true, null if any of the inputs is null, and false otherwise.
+func Or(mem memory.Allocator, a, b *array.Boolean) (*array.Boolean, error) {
+	return kleene(mem, a, b, true)
+}
+
+// Not returns the logical negation of a. Null elements stay null.
+func Not(mem memory.Allocator, a *array.Boolean) (*array.Boolean, error) {
+	bld := array.NewBooleanBuilder(mem)
+	defer bld.Release()
+	bld.Reserve(a.Len())
+
+	for i := 0; i < a.Len(); i++ {
+		if a.IsNull(i) {
+			bld.AppendNull()
+			continue
+		}
+		bld.Append(!a.Value(i))
+	}
+	return bld.NewBooleanArray(), nil
+}
+
+// kleene returns the conjunction (or disjunction, if dominant is true) of a
+// and b. dominant is the value deciding the result regardless of the other
+// input, even if null.
+func kleene(mem memory.Allocator, a, b *array.Boolean, dominant bool) (*array.Boolean, error) {
+	if a.Len() != b.Len() {
+		return nil, fmt.Errorf("arrow/compute: mismatched lengths %d and %d", a.Len(), b.Len())
+	}
+
+	bld := array.NewBooleanBuilder(mem)
+	defer bld.Release()
+	bld.Reserve(a.Len())
+
+	for i := 0; i < a.Len(); i++ {
+		var (
+			x, okx = a.Value(i), a.IsValid(i)
+			y, oky = b.Value(i), b.IsValid(i)
+		)
+		switch {
+		case (okx && x == dominant) || (oky && y == dominant):
+			bld.Append(dominant)
+		case !okx || !oky:
+			bld.AppendNull()
+		default:
+			bld.Append(!dominant)
+		}
+	}
+	return bld.NewBooleanArray(), nil
+}
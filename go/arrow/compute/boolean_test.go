@@ -0,0 +1,83 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestBoolean(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	var (
+		dt = arrow.FixedWidthTypes.Boolean
+		a  = fromJSON(t, mem, dt, "true", "true", "true", "false", "false", "false", "null", "null", "null")
+		b  = fromJSON(t, mem, dt, "true", "false", "null", "true", "false", "null", "true", "false", "null")
+	)
+	defer a.Release()
+	defer b.Release()
+
+	for _, tc := range []struct {
+		name string
+		fn   func(mem memory.Allocator, a, b *array.Boolean) (*array.Boolean, error)
+		want []string
+	}{
+		{
+			name: "and", fn: compute.And,
+			want: []string{"true", "false", "null", "false", "false", "false", "null", "false", "null"},
+		},
+		{
+			name: "or", fn: compute.Or,
+			want: []string{"true", "true", "true", "true", "false", "null", "true", "null", "null"},
+		},
+		{
+			name: "not",
+			fn: func(mem memory.Allocator, a, _ *array.Boolean) (*array.Boolean, error) {
+				return compute.Not(mem, a)
+			},
+			want: []string{"false", "false", "false", "true", "true", "true", "null", "null", "null"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.fn(mem, a.(*array.Boolean), b.(*array.Boolean))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			want := fromJSON(t, mem, dt, tc.want...)
+			defer want.Release()
+
+			if got, want := toJSON(t, got), toJSON(t, want); got != want {
+				t.Fatalf("invalid result:\ngot= %s\nwant=%s", got, want)
+			}
+		})
+	}
+
+	c := fromJSON(t, mem, dt, "true")
+	defer c.Release()
+	_, err := compute.And(mem, a.(*array.Boolean), c.(*array.Boolean))
+	if want := "arrow/compute: mismatched lengths 9 and 1"; err == nil || err.Error() != want {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%s", err, want)
+	}
+}
@@ -0,0 +1,938 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/float16"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+type castConfig struct {
+	allowOverflow bool
+	allowTruncate bool
+}
+
+// Cast returns a new array holding the values of arr converted to the data
+// type to.
+//
+// The following conversions are supported:
+//   - between numeric and boolean types,
+//   - between numeric, boolean or temporal types and string,
+//   - between binary, string and fixed size binary types,
+//   - between integer and temporal types (the raw value is preserved),
+//   - between temporal types of the same kind, or between dates and timestamps,
+//   - between list types, casting their elements,
+//   - between struct types with the same number of fields, casting each field,
+//   - between dictionary types and any of the above, decoding the dictionary
+//     or dictionary encoding the values once cast to the value type,
+//   - from the null type to any of the above.
+//
+// By default, Cast returns an error if a value overflows the target type or
+// would be truncated. See WithAllowOverflow and WithAllowTruncate.
+func Cast(mem memory.Allocator, arr array.Interface, to arrow.DataType, opts ...Option) (array.Interface, error) {
+	cfg := &castConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if err := castable(arr.DataType(), to); err != nil {
+		return nil, err
+	}
+	return cast(mem, arr, to, cfg)
+}
+
+// CastRecord casts the columns of rec to the types of the fields of schema.
+func CastRecord(mem memory.Allocator, rec array.Record, schema *arrow.Schema, opts ...Option) (array.Record, error) {
+	if len(schema.Fields()) != int(rec.NumCols()) {
+		return nil, fmt.Errorf("arrow/compute: schema has %d fields, record has %d columns", len(schema.Fields()), rec.NumCols())
+	}
+
+	cols := make([]array.Interface, 0, rec.NumCols())
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+
+	for i, col := range rec.Columns() {
+		out, err := Cast(mem, col, schema.Field(i).Type, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("arrow/compute: column %d (%s): %v", i, rec.ColumnName(i), err)
+		}
+		cols = append(cols, out)
+	}
+	return array.NewRecord(schema, cols, rec.NumRows()), nil
+}
+
+// AdaptRecord adapts rec to schema: the columns of the returned record are
+// looked up by name in rec and cast to the types of the fields of schema.
+// Fields of schema absent from rec are filled with nulls, and columns of rec
+// absent from schema are dropped.
+//
+// AdaptRecord returns an error if a missing field is not nullable, or if a
+// column can not be cast to the type of its field.
+func AdaptRecord(mem memory.Allocator, rec array.Record, schema *arrow.Schema, opts ...Option) (array.Record, error) {
+	cols := make([]array.Interface, 0, len(schema.Fields()))
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+
+	src := rec.Schema()
+	for _, f := range schema.Fields() {
+		i := src.FieldIndex(f.Name)
+		if i < 0 {
+			if !f.Nullable {
+				return nil, fmt.Errorf("arrow/compute: missing non-nullable field %q", f.Name)
+			}
+			cols = append(cols, makeNulls(mem, f.Type, int(rec.NumRows())))
+			continue
+		}
+
+		out, err := Cast(mem, rec.Column(i), f.Type, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("arrow/compute: field %q: %v", f.Name, err)
+		}
+		cols = append(cols, out)
+	}
+	return array.NewRecord(schema, cols, rec.NumRows()), nil
+}
+
+// makeNulls returns an array of type dt holding n null values.
+func makeNulls(mem memory.Allocator, dt arrow.DataType, n int) array.Interface {
+	if dt.ID() == arrow.NULL {
+		return array.NewNull(n)
+	}
+
+	b := array.NewBuilder(mem, dt)
+	defer b.Release()
+
+	b.Reserve(n)
+	for i := 0; i < n; i++ {
+		b.AppendNull()
+	}
+	return b.NewArray()
+}
+
+type category int
+
+const (
+	catNull category = iota
+	catBool
+	catInt
+	catUint
+	catFloat
+	catString
+	catBinary
+	catFixedSizeBinary
+	catTemporal
+	catList
+	catStruct
+	catDictionary
+	catUnsupported
+)
+
+func categoryOf(dt arrow.DataType) category {
+	switch dt.ID() {
+	case arrow.NULL:
+		return catNull
+	case arrow.BOOL:
+		return catBool
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64:
+		return catInt
+	case arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		return catUint
+	case arrow.HALF_FLOAT, arrow.FLOAT32, arrow.FLOAT64:
+		return catFloat
+	case arrow.STRING:
+		return catString
+	case arrow.BINARY:
+		return catBinary
+	case arrow.FIXED_SIZE_BINARY:
+		return catFixedSizeBinary
+	case arrow.DATE32, arrow.DATE64, arrow.TIME32, arrow.TIME64, arrow.TIMESTAMP:
+		return catTemporal
+	case arrow.LIST:
+		return catList
+	case arrow.STRUCT:
+		return catStruct
+	case arrow.DICTIONARY:
+		return catDictionary
+	}
+	return catUnsupported
+}
+
+func isNumeric(c category) bool {
+	return c == catBool || c == catInt || c == catUint || c == catFloat
+}
+
+// castable returns an error if values of type from can not be cast to type to.
+func castable(from, to arrow.DataType) error {
+	var (
+		src = categoryOf(from)
+		dst = categoryOf(to)
+		ok  bool
+	)
+
+	switch {
+	case reflect.DeepEqual(from, to):
+		ok = true
+	case src == catUnsupported || dst == catUnsupported:
+	case src == catNull && dst != catDictionary:
+		ok = true
+	case dst == catDictionary:
+		return castable(from, to.(*arrow.DictionaryType).ValueType())
+	case src == catDictionary:
+		return castable(from.(*arrow.DictionaryType).ValueType(), to)
+	case isNumeric(src) && isNumeric(dst):
+		ok = true
+	case dst == catString:
+		ok = isNumeric(src) || src == catTemporal || src == catBinary
+	case src == catString:
+		ok = isNumeric(dst) || dst == catTemporal || dst == catBinary
+	case src == catBinary:
+		ok = dst == catFixedSizeBinary
+	case src == catFixedSizeBinary:
+		ok = dst == catBinary
+	case src == catTemporal && (dst == catInt || dst == catUint):
+		ok = true
+	case (src == catInt || src == catUint) && dst == catTemporal:
+		ok = true
+	case src == catTemporal && dst == catTemporal:
+		fk, _ := temporalOf(from)
+		tk, _ := temporalOf(to)
+		ok = fk == tk || (fk != temporalTime && tk != temporalTime)
+	case src == catList && dst == catList:
+		return castable(from.(*arrow.ListType).Elem(), to.(*arrow.ListType).Elem())
+	case src == catStruct && dst == catStruct:
+		ff := from.(*arrow.StructType).Fields()
+		tf := to.(*arrow.StructType).Fields()
+		if len(ff) != len(tf) {
+			return fmt.Errorf("arrow/compute: can not cast struct with %d fields to struct with %d fields", len(ff), len(tf))
+		}
+		for i := range ff {
+			if err := castable(ff[i].Type, tf[i].Type); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if !ok {
+		return fmt.Errorf("arrow/compute: unsupported cast from %s to %s", from.Name(), to.Name())
+	}
+	return nil
+}
+
+func cast(mem memory.Allocator, arr array.Interface, to arrow.DataType, cfg *castConfig) (array.Interface, error) {
+	if reflect.DeepEqual(arr.DataType(), to) {
+		arr.Retain()
+		return arr, nil
+	}
+
+	if arr.DataType().ID() == arrow.DICTIONARY {
+		return castDictionary(mem, arr.(*array.Dictionary), to, cfg)
+	}
+
+	data := arr.Data()
+	switch to := to.(type) {
+	case *arrow.DictionaryType:
+		return encodeDictionary(mem, arr, to, cfg)
+	case *arrow.ListType:
+		if arr.DataType().ID() != arrow.LIST {
+			break
+		}
+		elems := array.MakeFromData(data.Children()[0])
+		defer elems.Release()
+
+		values, err := cast(mem, elems, to.Elem(), cfg)
+		if err != nil {
+			return nil, err
+		}
+		defer values.Release()
+
+		out := array.NewData(to, data.Len(), data.Buffers(), []*array.Data{values.Data()}, data.NullN(), data.Offset())
+		defer out.Release()
+		return array.MakeFromData(out), nil
+
+	case *arrow.StructType:
+		if arr.DataType().ID() != arrow.STRUCT {
+			break
+		}
+		fields := make([]*array.Data, len(data.Children()))
+		for i, child := range data.Children() {
+			elems := array.MakeFromData(child)
+			values, err := cast(mem, elems, to.Field(i).Type, cfg)
+			elems.Release()
+			if err != nil {
+				for _, f := range fields[:i] {
+					f.Release()
+				}
+				return nil, err
+			}
+			fields[i] = values.Data()
+			fields[i].Retain()
+			values.Release()
+		}
+
+		out := array.NewData(to, data.Len(), data.Buffers(), fields, data.NullN(), data.Offset())
+		for _, f := range fields {
+			f.Release()
+		}
+		defer out.Release()
+		return array.MakeFromData(out), nil
+	}
+
+	bld := array.NewBuilder(mem, to)
+	defer bld.Release()
+	bld.Reserve(arr.Len())
+
+	if arr.DataType().ID() == arrow.NULL {
+		for i := 0; i < arr.Len(); i++ {
+			bld.AppendNull()
+		}
+		return bld.NewArray(), nil
+	}
+
+	get := getter(arr)
+	app := appender(bld, to, cfg)
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			bld.AppendNull()
+			continue
+		}
+		if err := app(get(i)); err != nil {
+			return nil, err
+		}
+	}
+	return bld.NewArray(), nil
+}
+
+// castDictionary decodes arr, taking its values from its dictionary, and
+// casts them to the type to.
+func castDictionary(mem memory.Allocator, arr *array.Dictionary, to arrow.DataType, cfg *castConfig) (array.Interface, error) {
+	idx, err := indicesOf(arr.Indices(), arr.Dictionary().Len())
+	if err != nil {
+		return nil, err
+	}
+	values, err := take(mem, arr.Dictionary(), idx)
+	if err != nil {
+		return nil, err
+	}
+	defer values.Release()
+	return cast(mem, values, to, cfg)
+}
+
+// encodeDictionary casts arr to the value type of to, and dictionary
+// encodes the result with indices of the index type of to.
+func encodeDictionary(mem memory.Allocator, arr array.Interface, to *arrow.DictionaryType, cfg *castConfig) (array.Interface, error) {
+	values, err := cast(mem, arr, to.ValueType(), cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer values.Release()
+
+	enc, err := DictionaryEncode(mem, values)
+	if err != nil {
+		return nil, err
+	}
+	defer enc.Release()
+
+	indices, err := cast(mem, enc.Indices(), to.IndexType(), &castConfig{})
+	if err != nil {
+		return nil, fmt.Errorf("arrow/compute: %d distinct values do not fit in %s indices", enc.Dictionary().Len(), to.IndexType().Name())
+	}
+	defer indices.Release()
+	return array.NewDictionaryArray(to, indices, enc.Dictionary()), nil
+}
+
+// value is a single element of an array, in a form independent of its type.
+type value struct {
+	cat category
+	i   int64   // catInt, catTemporal
+	u   uint64  // catUint
+	f   float64 // catFloat
+	b   bool    // catBool
+	s   []byte  // catString, catBinary, catFixedSizeBinary
+	dt  arrow.DataType
+}
+
+func getter(arr array.Interface) func(i int) value {
+	v := value{cat: categoryOf(arr.DataType()), dt: arr.DataType()}
+	switch arr := arr.(type) {
+	case *array.Boolean:
+		return func(i int) value { v.b = arr.Value(i); return v }
+	case *array.Int8:
+		return func(i int) value { v.i = int64(arr.Value(i)); return v }
+	case *array.Int16:
+		return func(i int) value { v.i = int64(arr.Value(i)); return v }
+	case *array.Int32:
+		return func(i int) value { v.i = int64(arr.Value(i)); return v }
+	case *array.Int64:
+		return func(i int) value { v.i = arr.Value(i); return v }
+	case *array.Uint8:
+		return func(i int) value { v.u = uint64(arr.Value(i)); return v }
+	case *array.Uint16:
+		return func(i int) value { v.u = uint64(arr.Value(i)); return v }
+	case *array.Uint32:
+		return func(i int) value { v.u = uint64(arr.Value(i)); return v }
+	case *array.Uint64:
+		return func(i int) value { v.u = arr.Value(i); return v }
+	case *array.Float16:
+		return func(i int) value { v.f = float64(arr.Value(i).Float32()); return v }
+	case *array.Float32:
+		return func(i int) value { v.f = float64(arr.Value(i)); return v }
+	case *array.Float64:
+		return func(i int) value { v.f = arr.Value(i); return v }
+	case *array.String:
+		return func(i int) value { v.s = []byte(arr.Value(i)); return v }
+	case *array.Binary:
+		return func(i int) value { v.s = arr.Value(i); return v }
+	case *array.FixedSizeBinary:
+		return func(i int) value { v.s = arr.Value(i); return v }
+	case *array.Date32:
+		return func(i int) value { v.i = int64(arr.Value(i)); return v }
+	case *array.Date64:
+		return func(i int) value { v.i = int64(arr.Value(i)); return v }
+	case *array.Time32:
+		return func(i int) value { v.i = int64(arr.Value(i)); return v }
+	case *array.Time64:
+		return func(i int) value { v.i = int64(arr.Value(i)); return v }
+	case *array.Timestamp:
+		return func(i int) value { v.i = int64(arr.Value(i)); return v }
+	}
+	panic(fmt.Errorf("arrow/compute: unsupported array type %T", arr))
+}
+
+func appender(bld array.Builder, to arrow.DataType, cfg *castConfig) func(v value) error {
+	switch bld := bld.(type) {
+	case *array.BooleanBuilder:
+		return func(v value) error {
+			b, err := toBool(v)
+			if err == nil {
+				bld.Append(b)
+			}
+			return err
+		}
+	case *array.Int8Builder:
+		return func(v value) error {
+			i, err := toInt(v, to, 8, cfg)
+			if err == nil {
+				bld.Append(int8(i))
+			}
+			return err
+		}
+	case *array.Int16Builder:
+		return func(v value) error {
+			i, err := toInt(v, to, 16, cfg)
+			if err == nil {
+				bld.Append(int16(i))
+			}
+			return err
+		}
+	case *array.Int32Builder:
+		return func(v value) error {
+			i, err := toInt(v, to, 32, cfg)
+			if err == nil {
+				bld.Append(int32(i))
+			}
+			return err
+		}
+	case *array.Int64Builder:
+		return func(v value) error {
+			i, err := toInt(v, to, 64, cfg)
+			if err == nil {
+				bld.Append(i)
+			}
+			return err
+		}
+	case *array.Uint8Builder:
+		return func(v value) error {
+			u, err := toUint(v, to, 8, cfg)
+			if err == nil {
+				bld.Append(uint8(u))
+			}
+			return err
+		}
+	case *array.Uint16Builder:
+		return func(v value) error {
+			u, err := toUint(v, to, 16, cfg)
+			if err == nil {
+				bld.Append(uint16(u))
+			}
+			return err
+		}
+	case *array.Uint32Builder:
+		return func(v value) error {
+			u, err := toUint(v, to, 32, cfg)
+			if err == nil {
+				bld.Append(uint32(u))
+			}
+			return err
+		}
+	case *array.Uint64Builder:
+		return func(v value) error {
+			u, err := toUint(v, to, 64, cfg)
+			if err == nil {
+				bld.Append(u)
+			}
+			return err
+		}
+	case *array.Float16Builder:
+		return func(v value) error {
+			f, err := toFloat(v, 32)
+			if err == nil {
+				bld.AppendFloat32(float32(f))
+			}
+			return err
+		}
+	case *array.Float32Builder:
+		return func(v value) error {
+			f, err := toFloat(v, 32)
+			if err == nil {
+				bld.Append(float32(f))
+			}
+			return err
+		}
+	case *array.Float64Builder:
+		return func(v value) error {
+			f, err := toFloat(v, 64)
+			if err == nil {
+				bld.Append(f)
+			}
+			return err
+		}
+	case *array.StringBuilder:
+		return func(v value) error {
+			s, err := toString(v)
+			if err == nil {
+				bld.Append(s)
+			}
+			return err
+		}
+	case *array.BinaryBuilder:
+		return func(v value) error {
+			s, err := toString(v)
+			if err == nil {
+				bld.Append([]byte(s))
+			}
+			return err
+		}
+	case *array.FixedSizeBinaryBuilder:
+		width := to.(*arrow.FixedSizeBinaryType).ByteWidth
+		return func(v value) error {
+			if len(v.s) != width {
+				return fmt.Errorf("arrow/compute: value of length %d can not be cast to %s of width %d", len(v.s), to.Name(), width)
+			}
+			bld.Append(v.s)
+			return nil
+		}
+	case *array.Date32Builder:
+		return func(v value) error {
+			i, err := toTemporal(v, to, cfg)
+			if err == nil {
+				bld.Append(arrow.Date32(i))
+			}
+			return err
+		}
+	case *array.Date64Builder:
+		return func(v value) error {
+			i, err := toTemporal(v, to, cfg)
+			if err == nil {
+				bld.Append(arrow.Date64(i))
+			}
+			return err
+		}
+	case *array.Time32Builder:
+		return func(v value) error {
+			i, err := toTemporal(v, to, cfg)
+			if err == nil {
+				bld.Append(arrow.Time32(i))
+			}
+			return err
+		}
+	case *array.Time64Builder:
+		return func(v value) error {
+			i, err := toTemporal(v, to, cfg)
+			if err == nil {
+				bld.Append(arrow.Time64(i))
+			}
+			return err
+		}
+	case *array.TimestampBuilder:
+		return func(v value) error {
+			i, err := toTemporal(v, to, cfg)
+			if err == nil {
+				bld.Append(arrow.Timestamp(i))
+			}
+			return err
+		}
+	}
+	panic(fmt.Errorf("arrow/compute: unsupported builder type %T", bld))
+}
+
+func errOverflow(v value, to arrow.DataType) error {
+	return fmt.Errorf("arrow/compute: value %s overflows %s", formatValue(v), to.Name())
+}
+
+func errTruncate(v value, to arrow.DataType) error {
+	return fmt.Errorf("arrow/compute: value %s would be truncated casting to %s", formatValue(v), to.Name())
+}
+
+func formatValue(v value) string {
+	switch v.cat {
+	case catString, catBinary, catFixedSizeBinary:
+		return strconv.Quote(string(v.s))
+	}
+	s, _ := toString(v)
+	return s
+}
+
+func toBool(v value) (bool, error) {
+	switch v.cat {
+	case catBool:
+		return v.b, nil
+	case catInt:
+		return v.i != 0, nil
+	case catUint:
+		return v.u != 0, nil
+	case catFloat:
+		return v.f != 0, nil
+	case catString:
+		b, err := strconv.ParseBool(string(v.s))
+		if err != nil {
+			return false, fmt.Errorf("arrow/compute: could not parse %q as bool", v.s)
+		}
+		return b, nil
+	}
+	panic("unreachable")
+}
+
+func toInt(v value, to arrow.DataType, bits uint, cfg *castConfig) (int64, error) {
+	var (
+		max = int64(1)<<(bits-1) - 1
+		min = -max - 1
+		i   int64
+	)
+
+	switch v.cat {
+	case catBool:
+		if v.b {
+			return 1, nil
+		}
+		return 0, nil
+	case catInt, catTemporal:
+		i = v.i
+	case catUint:
+		if v.u > uint64(math.MaxInt64) {
+			if !cfg.allowOverflow {
+				return 0, errOverflow(v, to)
+			}
+		}
+		i = int64(v.u)
+	case catFloat:
+		if math.IsNaN(v.f) || v.f < float64(min) || v.f >= -float64(min) {
+			if !cfg.allowOverflow {
+				return 0, errOverflow(v, to)
+			}
+		}
+		if v.f != math.Trunc(v.f) && !cfg.allowTruncate {
+			return 0, errTruncate(v, to)
+		}
+		i = int64(v.f)
+	case catString:
+		var err error
+		i, err = strconv.ParseInt(string(v.s), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("arrow/compute: could not parse %q as %s", v.s, to.Name())
+		}
+	default:
+		panic("unreachable")
+	}
+
+	if (i < min || i > max) && !cfg.allowOverflow {
+		return 0, errOverflow(v, to)
+	}
+	return i, nil
+}
+
+func toUint(v value, to arrow.DataType, bits uint, cfg *castConfig) (uint64, error) {
+	var (
+		max = uint64(1)<<bits - 1
+		u   uint64
+	)
+	if bits == 64 {
+		max = math.MaxUint64
+	}
+
+	switch v.cat {
+	case catBool:
+		if v.b {
+			return 1, nil
+		}
+		return 0, nil
+	case catInt, catTemporal:
+		if v.i < 0 && !cfg.allowOverflow {
+			return 0, errOverflow(v, to)
+		}
+		u = uint64(v.i)
+	case catUint:
+		u = v.u
+	case catFloat:
+		if math.IsNaN(v.f) || v.f < 0 || v.f >= 2*float64(1<<63) {
+			if !cfg.allowOverflow {
+				return 0, errOverflow(v, to)
+			}
+		}
+		if v.f != math.Trunc(v.f) && !cfg.allowTruncate {
+			return 0, errTruncate(v, to)
+		}
+		u = uint64(v.f)
+	case catString:
+		var err error
+		u, err = strconv.ParseUint(string(v.s), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("arrow/compute: could not parse %q as %s", v.s, to.Name())
+		}
+	default:
+		panic("unreachable")
+	}
+
+	if u > max && !cfg.allowOverflow {
+		return 0, errOverflow(v, to)
+	}
+	return u, nil
+}
+
+func toFloat(v value, bits int) (float64, error) {
+	switch v.cat {
+	case catBool:
+		if v.b {
+			return 1, nil
+		}
+		return 0, nil
+	case catInt:
+		return float64(v.i), nil
+	case catUint:
+		return float64(v.u), nil
+	case catFloat:
+		return v.f, nil
+	case catString:
+		f, err := strconv.ParseFloat(string(v.s), bits)
+		if err != nil {
+			return 0, fmt.Errorf("arrow/compute: could not parse %q as float%d", v.s, bits)
+		}
+		return f, nil
+	}
+	panic("unreachable")
+}
+
+func toString(v value) (string, error) {
+	switch v.cat {
+	case catBool:
+		return strconv.FormatBool(v.b), nil
+	case catInt:
+		return strconv.FormatInt(v.i, 10), nil
+	case catUint:
+		return strconv.FormatUint(v.u, 10), nil
+	case catFloat:
+		bits := 64
+		switch v.dt.ID() {
+		case arrow.HALF_FLOAT:
+			return float16.New(float32(v.f)).String(), nil
+		case arrow.FLOAT32:
+			bits = 32
+		}
+		return strconv.FormatFloat(v.f, 'g', -1, bits), nil
+	case catString, catFixedSizeBinary:
+		return string(v.s), nil
+	case catBinary:
+		if !utf8.Valid(v.s) {
+			return "", fmt.Errorf("arrow/compute: invalid UTF-8 value %q", v.s)
+		}
+		return string(v.s), nil
+	case catTemporal:
+		kind, unit := temporalOf(v.dt)
+		var t time.Time
+		if unit >= int64(time.Second) {
+			t = time.Unix(v.i*(unit/int64(time.Second)), 0).UTC()
+		} else {
+			n := int64(time.Second) / unit
+			t = time.Unix(v.i/n, (v.i%n)*unit).UTC()
+		}
+		switch kind {
+		case temporalDate:
+			return t.Format(dateLayout), nil
+		case temporalTime:
+			return t.Format(timeLayout), nil
+		default:
+			if tz := v.dt.(*arrow.TimestampType).TimeZone; tz != "" {
+				loc, err := time.LoadLocation(tz)
+				if err != nil {
+					return "", fmt.Errorf("arrow/compute: %v", err)
+				}
+				t = t.In(loc)
+			}
+			return t.Format(time.RFC3339Nano), nil
+		}
+	}
+	panic("unreachable")
+}
+
+type temporalKind int
+
+const (
+	temporalDate temporalKind = iota
+	temporalTime
+	temporalTimestamp
+)
+
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "15:04:05.999999999"
+)
+
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	dateLayout,
+}
+
+// unitNanos is the number of nanoseconds in each time unit.
+var unitNanos = [...]int64{
+	arrow.Nanosecond:  1,
+	arrow.Microsecond: 1e3,
+	arrow.Millisecond: 1e6,
+	arrow.Second:      1e9,
+}
+
+// temporalOf returns the kind of temporal type dt, and the number of
+// nanoseconds in its unit.
+func temporalOf(dt arrow.DataType) (temporalKind, int64) {
+	switch dt := dt.(type) {
+	case *arrow.Date32Type:
+		return temporalDate, 86400 * 1e9
+	case *arrow.Date64Type:
+		return temporalDate, 1e6
+	case *arrow.Time32Type:
+		return temporalTime, unitNanos[dt.Unit]
+	case *arrow.Time64Type:
+		return temporalTime, unitNanos[dt.Unit]
+	case *arrow.TimestampType:
+		return temporalTimestamp, unitNanos[dt.Unit]
+	}
+	panic(fmt.Errorf("arrow/compute: invalid temporal type %s", dt.Name()))
+}
+
+func toTemporal(v value, to arrow.DataType, cfg *castConfig) (int64, error) {
+	kind, unit := temporalOf(to)
+
+	switch v.cat {
+	case catInt, catUint:
+		return toInt(v, to, 64, cfg)
+	case catString:
+		return parseTemporal(string(v.s), kind, unit, to, cfg)
+	case catTemporal:
+		_, from := temporalOf(v.dt)
+		return rescale(v, from, unit, to, cfg)
+	}
+	panic("unreachable")
+}
+
+// rescale converts v.i from a unit of from nanoseconds to a unit of to
+// nanoseconds.
+func rescale(v value, from, to int64, dt arrow.DataType, cfg *castConfig) (int64, error) {
+	switch {
+	case from == to:
+		return v.i, nil
+	case from > to:
+		f := from / to
+		r := v.i * f
+		if r/f != v.i && !cfg.allowOverflow {
+			return 0, errOverflow(v, dt)
+		}
+		return r, nil
+	default:
+		f := to / from
+		r := v.i / f
+		if m := v.i % f; m != 0 {
+			if !cfg.allowTruncate {
+				return 0, errTruncate(v, dt)
+			}
+			if m < 0 {
+				r-- // floor, so that instants before the epoch keep their date.
+			}
+		}
+		return r, nil
+	}
+}
+
+func parseTemporal(s string, kind temporalKind, unit int64, dt arrow.DataType, cfg *castConfig) (int64, error) {
+	var (
+		t   time.Time
+		err error
+	)
+
+	switch kind {
+	case temporalDate:
+		t, err = time.Parse(dateLayout, s)
+	case temporalTime:
+		t, err = time.Parse(timeLayout, s)
+		t = t.AddDate(1970, 0, 0)
+	default:
+		loc := time.UTC
+		if tz := dt.(*arrow.TimestampType).TimeZone; tz != "" {
+			loc, err = time.LoadLocation(tz)
+			if err != nil {
+				return 0, fmt.Errorf("arrow/compute: %v", err)
+			}
+		}
+		for _, layout := range timestampLayouts {
+			t, err = time.ParseInLocation(layout, s, loc)
+			if err == nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return 0, fmt.Errorf("arrow/compute: could not parse %q as %s", s, dt.Name())
+	}
+
+	secs := value{cat: catTemporal, i: t.Unix(), dt: dt}
+	r, err := rescale(secs, int64(time.Second), unit, dt, cfg)
+	if err != nil {
+		return 0, err
+	}
+	nanos := value{cat: catTemporal, i: int64(t.Nanosecond()), dt: dt}
+	n, err := rescale(nanos, 1, unit, dt, cfg)
+	if err != nil {
+		return 0, errTruncate(value{cat: catString, s: []byte(s)}, dt)
+	}
+	return r + n, nil
+}
@@ -0,0 +1,534 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestCast(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	tsS := &arrow.TimestampType{Unit: arrow.Second}
+	tsMs := &arrow.TimestampType{Unit: arrow.Millisecond}
+
+	for _, tc := range []struct {
+		name string
+		from arrow.DataType
+		vals []string
+		to   arrow.DataType
+		want []string
+		opts []compute.Option
+	}{
+		{
+			name: "identity",
+			from: arrow.PrimitiveTypes.Int32, vals: []string{"1", "null"},
+			to: arrow.PrimitiveTypes.Int32, want: []string{"1", "null"},
+		},
+		{
+			name: "identity-large-string",
+			from: arrow.BinaryTypes.LargeString, vals: []string{`"a"`, "null"},
+			to: arrow.BinaryTypes.LargeString, want: []string{`"a"`, "null"},
+		},
+		{
+			name: "identity-list-of-large-string",
+			from: arrow.ListOf(arrow.BinaryTypes.LargeString), vals: []string{`["a"]`, "null"},
+			to: arrow.ListOf(arrow.BinaryTypes.LargeString), want: []string{`["a"]`, "null"},
+		},
+		{
+			name: "null-to-string",
+			from: arrow.Null, vals: []string{"null", "null"},
+			to: arrow.BinaryTypes.String, want: []string{"null", "null"},
+		},
+		{
+			name: "int8-to-int64",
+			from: arrow.PrimitiveTypes.Int8, vals: []string{"-128", "null", "127"},
+			to: arrow.PrimitiveTypes.Int64, want: []string{"-128", "null", "127"},
+		},
+		{
+			name: "int64-to-uint8",
+			from: arrow.PrimitiveTypes.Int64, vals: []string{"0", "255"},
+			to: arrow.PrimitiveTypes.Uint8, want: []string{"0", "255"},
+		},
+		{
+			name: "int64-to-int8-overflow",
+			from: arrow.PrimitiveTypes.Int64, vals: []string{"1", "257"},
+			to: arrow.PrimitiveTypes.Int8, want: []string{"1", "1"},
+			opts: []compute.Option{compute.WithAllowOverflow(true)},
+		},
+		{
+			name: "uint64-to-int64",
+			from: arrow.PrimitiveTypes.Uint64, vals: []string{"9223372036854775807"},
+			to: arrow.PrimitiveTypes.Int64, want: []string{"9223372036854775807"},
+		},
+		{
+			name: "float64-to-int32",
+			from: arrow.PrimitiveTypes.Float64, vals: []string{"-2", "3"},
+			to: arrow.PrimitiveTypes.Int32, want: []string{"-2", "3"},
+		},
+		{
+			name: "float64-to-int32-truncate",
+			from: arrow.PrimitiveTypes.Float64, vals: []string{"-2.5", "3.9"},
+			to: arrow.PrimitiveTypes.Int32, want: []string{"-2", "3"},
+			opts: []compute.Option{compute.WithAllowTruncate(true)},
+		},
+		{
+			name: "int32-to-float32",
+			from: arrow.PrimitiveTypes.Int32, vals: []string{"-2", "null"},
+			to: arrow.PrimitiveTypes.Float32, want: []string{"-2", "null"},
+		},
+		{
+			name: "bool-to-uint16",
+			from: arrow.FixedWidthTypes.Boolean, vals: []string{"true", "false"},
+			to: arrow.PrimitiveTypes.Uint16, want: []string{"1", "0"},
+		},
+		{
+			name: "float32-to-bool",
+			from: arrow.PrimitiveTypes.Float32, vals: []string{"0", "0.5"},
+			to: arrow.FixedWidthTypes.Boolean, want: []string{"false", "true"},
+		},
+		{
+			name: "string-to-int16",
+			from: arrow.BinaryTypes.String, vals: []string{`"-12"`, "null"},
+			to: arrow.PrimitiveTypes.Int16, want: []string{"-12", "null"},
+		},
+		{
+			name: "string-to-float64",
+			from: arrow.BinaryTypes.String, vals: []string{`"1.5e3"`, `"-0.25"`},
+			to: arrow.PrimitiveTypes.Float64, want: []string{"1500", "-0.25"},
+		},
+		{
+			name: "float32-to-string",
+			from: arrow.PrimitiveTypes.Float32, vals: []string{"0.1", "null"},
+			to: arrow.BinaryTypes.String, want: []string{`"0.1"`, "null"},
+		},
+		{
+			name: "float32-to-float16",
+			from: arrow.PrimitiveTypes.Float32, vals: []string{"0.1", "null", "65519"},
+			to: arrow.FixedWidthTypes.Float16, want: []string{"0.1", "null", "65504"},
+		},
+		{
+			name: "float16-to-float64",
+			from: arrow.FixedWidthTypes.Float16, vals: []string{"0.1", "-2"},
+			to: arrow.PrimitiveTypes.Float64, want: []string{"0.0999755859375", "-2"},
+		},
+		{
+			name: "int32-to-float16",
+			from: arrow.PrimitiveTypes.Int32, vals: []string{"2049", "2051"},
+			to: arrow.FixedWidthTypes.Float16, want: []string{"2048", "2052"},
+		},
+		{
+			name: "float16-to-string",
+			from: arrow.FixedWidthTypes.Float16, vals: []string{"0.1", "null"},
+			to: arrow.BinaryTypes.String, want: []string{`"0.1"`, "null"},
+		},
+		{
+			name: "string-to-float16",
+			from: arrow.BinaryTypes.String, vals: []string{`"1.5e3"`},
+			to: arrow.FixedWidthTypes.Float16, want: []string{"1500"},
+		},
+		{
+			name: "uint64-to-string",
+			from: arrow.PrimitiveTypes.Uint64, vals: []string{"18446744073709551615"},
+			to: arrow.BinaryTypes.String, want: []string{`"18446744073709551615"`},
+		},
+		{
+			name: "string-to-binary",
+			from: arrow.BinaryTypes.String, vals: []string{`"abc"`},
+			to: arrow.BinaryTypes.Binary, want: []string{`"YWJj"`},
+		},
+		{
+			name: "binary-to-fixed-size-binary",
+			from: arrow.BinaryTypes.Binary, vals: []string{`"YWJj"`, "null"},
+			to: &arrow.FixedSizeBinaryType{ByteWidth: 3}, want: []string{`"YWJj"`, "null"},
+		},
+		{
+			name: "timestamp-s-to-ms",
+			from: tsS, vals: []string{`"2019-01-01T00:00:01Z"`},
+			to: tsMs, want: []string{`"2019-01-01T00:00:01Z"`},
+		},
+		{
+			name: "timestamp-ms-to-s-truncate",
+			from: tsMs, vals: []string{`"1969-12-31T23:59:59.5Z"`},
+			to: tsS, want: []string{`"1969-12-31T23:59:59Z"`},
+			opts: []compute.Option{compute.WithAllowTruncate(true)},
+		},
+		{
+			name: "timestamp-to-date32",
+			from: tsS, vals: []string{`"1969-12-31T12:00:00Z"`, `"2019-03-04T05:06:07Z"`},
+			to: arrow.PrimitiveTypes.Date32, want: []string{`"1969-12-31"`, `"2019-03-04"`},
+			opts: []compute.Option{compute.WithAllowTruncate(true)},
+		},
+		{
+			name: "date32-to-date64",
+			from: arrow.PrimitiveTypes.Date32, vals: []string{`"2019-03-04"`},
+			to: arrow.PrimitiveTypes.Date64, want: []string{`"2019-03-04"`},
+		},
+		{
+			name: "date32-to-timestamp",
+			from: arrow.PrimitiveTypes.Date32, vals: []string{`"2019-03-04"`},
+			to: tsS, want: []string{`"2019-03-04T00:00:00Z"`},
+		},
+		{
+			name: "time32-to-time64",
+			from: arrow.FixedWidthTypes.Time32s, vals: []string{`"12:34:56"`},
+			to: arrow.FixedWidthTypes.Time64ns, want: []string{`"12:34:56"`},
+		},
+		{
+			name: "int64-to-timestamp",
+			from: arrow.PrimitiveTypes.Int64, vals: []string{"86400"},
+			to: tsS, want: []string{`"1970-01-02T00:00:00Z"`},
+		},
+		{
+			name: "timestamp-to-int64",
+			from: tsMs, vals: []string{`"1970-01-01T00:00:01Z"`},
+			to: arrow.PrimitiveTypes.Int64, want: []string{"1000"},
+		},
+		{
+			name: "string-to-timestamp",
+			from: arrow.BinaryTypes.String, vals: []string{`"2019-03-04 05:06:07.5"`, `"2019-03-04T05:06:07+01:00"`},
+			to: tsMs, want: []string{`"2019-03-04T05:06:07.5Z"`, `"2019-03-04T04:06:07Z"`},
+		},
+		{
+			name: "timestamp-to-string",
+			from: &arrow.TimestampType{Unit: arrow.Second, TimeZone: "Europe/Paris"}, vals: []string{`"2019-03-04T05:06:07Z"`},
+			to: arrow.BinaryTypes.String, want: []string{`"2019-03-04T06:06:07+01:00"`},
+		},
+		{
+			name: "date64-to-string",
+			from: arrow.PrimitiveTypes.Date64, vals: []string{`"1969-01-02"`},
+			to: arrow.BinaryTypes.String, want: []string{`"1969-01-02"`},
+		},
+		{
+			name: "list",
+			from: arrow.ListOf(arrow.PrimitiveTypes.Int32), vals: []string{"[1,null]", "null", "[]"},
+			to: arrow.ListOf(arrow.BinaryTypes.String), want: []string{`["1",null]`, "null", "[]"},
+		},
+		{
+			name: "struct",
+			from: arrow.StructOf(arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int32, Nullable: true}),
+			vals: []string{`{"a":1}`, "null"},
+			to:   arrow.StructOf(arrow.Field{Name: "b", Type: arrow.PrimitiveTypes.Float64, Nullable: true}),
+			want: []string{`{"b":1}`, "null"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			arr := fromJSON(t, mem, tc.from, tc.vals...)
+			defer arr.Release()
+
+			got, err := compute.Cast(mem, arr, tc.to, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			want := fromJSON(t, mem, tc.to, tc.want...)
+			defer want.Release()
+
+			if got, want := toJSON(t, got), toJSON(t, want); got != want {
+				t.Fatalf("invalid cast:\ngot= %s\nwant=%s", got, want)
+			}
+		})
+	}
+}
+
+func TestCastErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	for _, tc := range []struct {
+		name string
+		from arrow.DataType
+		vals []string
+		to   arrow.DataType
+		err  string
+	}{
+		{
+			name: "overflow",
+			from: arrow.PrimitiveTypes.Int32, vals: []string{"128"},
+			to:  arrow.PrimitiveTypes.Int8,
+			err: "arrow/compute: value 128 overflows int8",
+		},
+		{
+			name: "negative-to-unsigned",
+			from: arrow.PrimitiveTypes.Int32, vals: []string{"-1"},
+			to:  arrow.PrimitiveTypes.Uint32,
+			err: "arrow/compute: value -1 overflows uint32",
+		},
+		{
+			name: "float-overflow",
+			from: arrow.PrimitiveTypes.Float64, vals: []string{"1e20"},
+			to:  arrow.PrimitiveTypes.Int64,
+			err: "arrow/compute: value 1e+20 overflows int64",
+		},
+		{
+			name: "float-truncate",
+			from: arrow.PrimitiveTypes.Float64, vals: []string{"1.5"},
+			to:  arrow.PrimitiveTypes.Int64,
+			err: "arrow/compute: value 1.5 would be truncated casting to int64",
+		},
+		{
+			name: "timestamp-truncate",
+			from: &arrow.TimestampType{Unit: arrow.Millisecond}, vals: []string{"1500"},
+			to:  &arrow.TimestampType{Unit: arrow.Second},
+			err: "arrow/compute: value 1970-01-01T00:00:01.5Z would be truncated casting to timestamp",
+		},
+		{
+			name: "parse",
+			from: arrow.BinaryTypes.String, vals: []string{`"x"`},
+			to:  arrow.PrimitiveTypes.Int32,
+			err: `arrow/compute: could not parse "x" as int32`,
+		},
+		{
+			name: "fixed-size-binary-width",
+			from: arrow.BinaryTypes.Binary, vals: []string{`"YWI="`},
+			to:  &arrow.FixedSizeBinaryType{ByteWidth: 3},
+			err: "arrow/compute: value of length 2 can not be cast to fixed_size_binary of width 3",
+		},
+		{
+			name: "unsupported",
+			from: arrow.FixedWidthTypes.Time32s, vals: []string{`"00:00:01"`},
+			to:  arrow.PrimitiveTypes.Date32,
+			err: "arrow/compute: unsupported cast from time32 to date32",
+		},
+		{
+			name: "unsupported-list",
+			from: arrow.ListOf(arrow.PrimitiveTypes.Int32), vals: []string{"[1]"},
+			to:  arrow.ListOf(arrow.ListOf(arrow.PrimitiveTypes.Int32)),
+			err: "arrow/compute: unsupported cast from int32 to list",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			arr := fromJSON(t, mem, tc.from, tc.vals...)
+			defer arr.Release()
+
+			_, err := compute.Cast(mem, arr, tc.to)
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("invalid error:\ngot= %v\nwant=%s", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestCastDictionary(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	dict := func(index arrow.DataType, values arrow.DataType, idx, vals []string) array.Interface {
+		indices := fromJSON(t, mem, index, idx...)
+		defer indices.Release()
+		dict := fromJSON(t, mem, values, vals...)
+		defer dict.Release()
+		return array.NewDictionaryArray(arrow.DictionaryOf(index, values), indices, dict)
+	}
+	i8, u8, i16 := arrow.PrimitiveTypes.Int8, arrow.PrimitiveTypes.Uint8, arrow.PrimitiveTypes.Int16
+	str, i32, i64 := arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int32, arrow.PrimitiveTypes.Int64
+
+	for _, tc := range []struct {
+		name string
+		arr  func() array.Interface
+		to   arrow.DataType
+		want []string // values, or indices of dictionaries
+		dict []string // dictionary of dictionaries
+	}{
+		{
+			name: "dictionary-to-string",
+			arr:  func() array.Interface { return dict(i8, str, []string{"1", "null", "0", "1"}, []string{`"a"`, `"b"`}) },
+			to:   str, want: []string{`"b"`, "null", `"a"`, `"b"`},
+		},
+		{
+			name: "dictionary-to-int64",
+			arr:  func() array.Interface { return dict(u8, str, []string{"1", "0"}, []string{`"7"`, `"-2"`}) },
+			to:   i64, want: []string{"-2", "7"},
+		},
+		{
+			name: "int32-to-dictionary",
+			arr:  func() array.Interface { return fromJSON(t, mem, i32, "3", "null", "3", "5") },
+			to:   arrow.DictionaryOf(i16, str), want: []string{"0", "null", "0", "1"}, dict: []string{`"3"`, `"5"`},
+		},
+		{
+			name: "dictionary-to-dictionary",
+			arr:  func() array.Interface { return dict(i8, str, []string{"1", "1", "0"}, []string{`"1"`, `"2"`}) },
+			to:   arrow.DictionaryOf(u8, i64), want: []string{"0", "0", "1"}, dict: []string{"2", "1"},
+		},
+		{
+			name: "null-to-dictionary",
+			arr:  func() array.Interface { return array.NewNull(2) },
+			to:   arrow.DictionaryOf(i8, str), want: []string{"null", "null"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			arr := tc.arr()
+			defer arr.Release()
+
+			got, err := compute.Cast(mem, arr, tc.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			values := got
+			if dt, ok := tc.to.(*arrow.DictionaryType); ok {
+				d := got.(*array.Dictionary)
+				if !reflect.DeepEqual(d.DataType(), dt) {
+					t.Fatalf("invalid type: got=%v, want=%v", d.DataType(), dt)
+				}
+				switch {
+				case len(tc.dict) == 0:
+					if n := d.Dictionary().Len(); n != 0 {
+						t.Fatalf("invalid dictionary length: got=%d, want=0", n)
+					}
+				default:
+					want := fromJSON(t, mem, dt.ValueType(), tc.dict...)
+					defer want.Release()
+					if got, want := toJSON(t, d.Dictionary()), toJSON(t, want); got != want {
+						t.Fatalf("invalid dictionary:\ngot= %s\nwant=%s", got, want)
+					}
+				}
+				values = d.Indices()
+			}
+			want := fromJSON(t, mem, values.DataType(), tc.want...)
+			defer want.Release()
+			if got, want := toJSON(t, values), toJSON(t, want); got != want {
+				t.Fatalf("invalid cast:\ngot= %s\nwant=%s", got, want)
+			}
+		})
+	}
+
+	t.Run("too-many-values", func(t *testing.T) {
+		vals := make([]string, 300)
+		for i := range vals {
+			vals[i] = strconv.Itoa(i)
+		}
+		arr := fromJSON(t, mem, i32, vals...)
+		defer arr.Release()
+
+		_, err := compute.Cast(mem, arr, arrow.DictionaryOf(i8, i32))
+		if want := "arrow/compute: 300 distinct values do not fit in int8 indices"; err == nil || err.Error() != want {
+			t.Fatalf("invalid error:\ngot= %v\nwant=%s", err, want)
+		}
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		arr := dict(i8, arrow.FixedWidthTypes.Time32s, []string{"0"}, []string{`"00:00:01"`})
+		defer arr.Release()
+
+		_, err := compute.Cast(mem, arr, arrow.PrimitiveTypes.Date32)
+		if want := "arrow/compute: unsupported cast from time32 to date32"; err == nil || err.Error() != want {
+			t.Fatalf("invalid error:\ngot= %v\nwant=%s", err, want)
+		}
+	})
+}
+
+func TestCastRecord(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "a", Type: arrow.BinaryTypes.String}}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.StringBuilder).AppendValues([]string{"1", "2"}, nil)
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	to := arrow.NewSchema([]arrow.Field{{Name: "a", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	got, err := compute.CastRecord(mem, rec, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if !got.Schema().Equal(to) {
+		t.Fatalf("invalid schema: got=%v, want=%v", got.Schema(), to)
+	}
+	if got, want := got.Column(0).(*array.Int64).Int64Values(), []int64{1, 2}; got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("invalid values: got=%v, want=%v", got, want)
+	}
+
+	_, err = compute.CastRecord(mem, rec, arrow.NewSchema([]arrow.Field{{Name: "a", Type: arrow.FixedWidthTypes.Boolean}}, nil))
+	if got, want := err, `arrow/compute: column 0 (a): arrow/compute: could not parse "2" as bool`; got == nil || got.Error() != want {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%s", got, want)
+	}
+}
+
+func TestAdaptRecord(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "a", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "b", Type: arrow.BinaryTypes.String},
+		{Name: "dropped", Type: arrow.FixedWidthTypes.Boolean},
+	}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2}, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues([]string{"x", "y"}, nil)
+	b.Field(2).(*array.BooleanBuilder).AppendValues([]bool{true, false}, nil)
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	to := arrow.NewSchema([]arrow.Field{
+		{Name: "b", Type: arrow.BinaryTypes.String},
+		{Name: "c", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "a", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "d", Type: arrow.ListOf(arrow.PrimitiveTypes.Int8), Nullable: true},
+	}, nil)
+	got, err := compute.AdaptRecord(mem, rec, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if !got.Schema().Equal(to) {
+		t.Fatalf("invalid schema: got=%v, want=%v", got.Schema(), to)
+	}
+	if got, want := got.NumRows(), int64(2); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+	if got.Column(0) != rec.Column(1) {
+		t.Fatalf("column of the same type should be reused")
+	}
+	for _, i := range []int{1, 3} {
+		if col := got.Column(i); col.Len() != 2 || col.NullN() != 2 {
+			t.Fatalf("column %d: invalid missing column: len=%d, nulls=%d", i, col.Len(), col.NullN())
+		}
+	}
+	if got, want := got.Column(2).(*array.Int64).Int64Values(), []int64{1, 2}; got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("invalid values: got=%v, want=%v", got, want)
+	}
+
+	_, err = compute.AdaptRecord(mem, rec, arrow.NewSchema([]arrow.Field{{Name: "z", Type: arrow.PrimitiveTypes.Int64}}, nil))
+	if got, want := err, `arrow/compute: missing non-nullable field "z"`; got == nil || got.Error() != want {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%s", got, want)
+	}
+
+	_, err = compute.AdaptRecord(mem, rec, arrow.NewSchema([]arrow.Field{{Name: "b", Type: arrow.PrimitiveTypes.Int64}}, nil))
+	if got, want := err, `arrow/compute: field "b": arrow/compute: could not parse "x" as int64`; got == nil || got.Error() != want {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%s", got, want)
+	}
+}
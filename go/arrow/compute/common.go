@@ -0,0 +1,55 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"fmt"
+)
+
+// Option configures a compute kernel.
+type Option func(config)
+type config interface{}
+
+// WithAllowOverflow specifies whether Cast and the arithmetic kernels may
+// silently wrap integer values that do not fit in the target type, instead
+// of returning an error.
+func WithAllowOverflow(v bool) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *castConfig:
+			cfg.allowOverflow = v
+		case *arithConfig:
+			cfg.allowOverflow = v
+		default:
+			panic(fmt.Errorf("arrow/compute: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithAllowTruncate specifies whether Cast may silently drop the fractional
+// part of floating point values cast to integers, or the sub-unit part of
+// temporal values cast to a coarser unit, instead of returning an error.
+func WithAllowTruncate(v bool) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *castConfig:
+			cfg.allowTruncate = v
+		default:
+			panic(fmt.Errorf("arrow/compute: unknown config type %T", cfg))
+		}
+	}
+}
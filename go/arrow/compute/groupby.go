@@ -0,0 +1,343 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/bits"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// AggregateFunc is a function aggregating the values of a group.
+type AggregateFunc int
+
+const (
+	Count AggregateFunc = iota // number of non-null values, as an int64
+	Sum                        // sum of the values, as an int64, uint64 or float64
+	Min                        // minimum value, of the type of the values
+	Max                        // maximum value, of the type of the values
+	Mean                       // arithmetic mean of the values, as a float64
+)
+
+func (f AggregateFunc) String() string {
+	switch f {
+	case Count:
+		return "count"
+	case Sum:
+		return "sum"
+	case Min:
+		return "min"
+	case Max:
+		return "max"
+	case Mean:
+		return "mean"
+	}
+	return fmt.Sprintf("AggregateFunc(%d)", int(f))
+}
+
+// Aggregate describes an aggregation computed by GroupBy.
+type Aggregate struct {
+	Func   AggregateFunc // aggregation function
+	Column string        // name of the aggregated column
+	Name   string        // name of the output column, "<func>_<column>" if empty
+}
+
+// GroupBy groups the rows of rec by the values of the key columns and
+// computes the aggregations aggs over each group.
+//
+// The returned record holds the key columns followed by one column per
+// aggregation, with one row per group. Groups appear in the order of their
+// first row in rec. Null key values form their own group.
+// Null values are ignored by all aggregations: Sum, Min, Max and Mean
+// return null for groups without any valid value.
+// GroupBy returns an error if the integer sum of a group overflows.
+//
+// Floating-point keys equal to 0 or -0 form a single group, and so do NaN
+// keys.
+func GroupBy(mem memory.Allocator, rec array.Record, keys []string, aggs []Aggregate) (array.Record, error) {
+	keyCols := make([]int, len(keys))
+	for i, key := range keys {
+		keyCols[i] = rec.Schema().FieldIndex(key)
+		if keyCols[i] < 0 {
+			return nil, fmt.Errorf("arrow/compute: no column named %q", key)
+		}
+	}
+
+	groups, first, err := groupRows(rec, keyCols)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		fields = make([]arrow.Field, 0, len(keys)+len(aggs))
+		cols   = make([]array.Interface, 0, len(keys)+len(aggs))
+	)
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+
+	for _, i := range keyCols {
+		col, err := take(mem, rec.Column(i), first)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, rec.Schema().Field(i))
+		cols = append(cols, col)
+	}
+
+	for _, agg := range aggs {
+		i := rec.Schema().FieldIndex(agg.Column)
+		if i < 0 {
+			return nil, fmt.Errorf("arrow/compute: no column named %q", agg.Column)
+		}
+		col, err := aggregate(mem, agg.Func, rec.Column(i), groups, len(first))
+		if err != nil {
+			return nil, fmt.Errorf("arrow/compute: %s of column %q: %v", agg.Func, agg.Column, err)
+		}
+		name := agg.Name
+		if name == "" {
+			name = agg.Func.String() + "_" + agg.Column
+		}
+		fields = append(fields, arrow.Field{Name: name, Type: col.DataType(), Nullable: agg.Func != Count})
+		cols = append(cols, col)
+	}
+
+	return array.NewRecord(arrow.NewSchema(fields, nil), cols, int64(len(first))), nil
+}
+
+// groupRows returns the group of each row of rec, and the first row of each
+// group.
+func groupRows(rec array.Record, keyCols []int) (groups []int, first []int, err error) {
+	encoders := make([]func(buf []byte, i int) []byte, len(keyCols))
+	for k, i := range keyCols {
+		encoders[k], err = keyEncoder(rec.Column(i), "grouping")
+		if err != nil {
+			return nil, nil, fmt.Errorf("arrow/compute: key column %q: %v", rec.ColumnName(i), err)
+		}
+	}
+
+	var (
+		n   = int(rec.NumRows())
+		ids = make(map[string]int)
+		buf []byte
+	)
+	groups = make([]int, n)
+	for row := 0; row < n; row++ {
+		buf = buf[:0]
+		for _, enc := range encoders {
+			buf = enc(buf, row)
+		}
+		id, ok := ids[string(buf)]
+		if !ok {
+			id = len(first)
+			ids[string(buf)] = id
+			first = append(first, row)
+		}
+		groups[row] = id
+	}
+	return groups, first, nil
+}
+
+// keyEncoder returns a function appending an unambiguous binary encoding of
+// the element i of arr to buf. The name of the kernel is used in errors.
+func keyEncoder(arr array.Interface, kernel string) (func(buf []byte, i int) []byte, error) {
+	var enc func(buf []byte, v value) []byte
+	switch categoryOf(arr.DataType()) {
+	case catNull:
+		return func(buf []byte, i int) []byte { return append(buf, 0) }, nil
+	case catBool:
+		enc = func(buf []byte, v value) []byte {
+			if v.b {
+				return append(buf, 1)
+			}
+			return append(buf, 0)
+		}
+	case catInt, catTemporal:
+		enc = func(buf []byte, v value) []byte {
+			var b [8]byte
+			binary.LittleEndian.PutUint64(b[:], uint64(v.i))
+			return append(buf, b[:]...)
+		}
+	case catUint:
+		enc = func(buf []byte, v value) []byte {
+			var b [8]byte
+			binary.LittleEndian.PutUint64(b[:], v.u)
+			return append(buf, b[:]...)
+		}
+	case catFloat:
+		// -0 and 0 are equal, and so are all the NaNs.
+		nan := math.Float64bits(math.NaN())
+		enc = func(buf []byte, v value) []byte {
+			var b [8]byte
+			switch {
+			case v.f == 0:
+				binary.LittleEndian.PutUint64(b[:], 0)
+			case math.IsNaN(v.f):
+				binary.LittleEndian.PutUint64(b[:], nan)
+			default:
+				binary.LittleEndian.PutUint64(b[:], math.Float64bits(v.f))
+			}
+			return append(buf, b[:]...)
+		}
+	case catString, catBinary, catFixedSizeBinary:
+		enc = func(buf []byte, v value) []byte {
+			var b [binary.MaxVarintLen64]byte
+			buf = append(buf, b[:binary.PutUvarint(b[:], uint64(len(v.s)))]...)
+			return append(buf, v.s...)
+		}
+	default:
+		return nil, fmt.Errorf("arrow/compute: unsupported data type %s for %s", arr.DataType().Name(), kernel)
+	}
+
+	get := getter(arr)
+	return func(buf []byte, i int) []byte {
+		if arr.IsNull(i) {
+			return append(buf, 0)
+		}
+		return enc(append(buf, 1), get(i))
+	}, nil
+}
+
+func aggregate(mem memory.Allocator, f AggregateFunc, arr array.Interface, groups []int, n int) (array.Interface, error) {
+	var (
+		cat   = categoryOf(arr.DataType())
+		count = make([]int64, n)
+	)
+	for row, g := range groups {
+		if arr.IsValid(row) {
+			count[g]++
+		}
+	}
+
+	switch f {
+	case Count:
+		bld := array.NewInt64Builder(mem)
+		defer bld.Release()
+		bld.AppendValues(count, nil)
+		return bld.NewArray(), nil
+
+	case Min, Max:
+		if cat == catNull {
+			return array.NewNull(n), nil
+		}
+		cmp, err := valueComparer(arr.DataType())
+		if err != nil {
+			return nil, err
+		}
+		sign := 1
+		if f == Max {
+			sign = -1
+		}
+
+		get := getter(arr)
+		best := make([]int, n)
+		for i := range best {
+			best[i] = -1
+		}
+		for row, g := range groups {
+			if arr.IsNull(row) {
+				continue
+			}
+			if best[g] < 0 || sign*cmp(get(row), get(best[g])) < 0 {
+				best[g] = row
+			}
+		}
+		return take(mem, arr, best)
+
+	case Sum, Mean:
+		if !isNumeric(cat) && cat != catNull {
+			return nil, fmt.Errorf("arrow/compute: unsupported data type %s", arr.DataType().Name())
+		}
+
+		var (
+			ints     = make([]int64, n)
+			uints    = make([]uint64, n)
+			floats   = make([]float64, n)
+			overflow bool
+		)
+		if cat != catNull {
+			get := getter(arr)
+			for row, g := range groups {
+				if arr.IsNull(row) {
+					continue
+				}
+				v := get(row)
+				switch cat {
+				case catBool:
+					if v.b {
+						ints[g]++
+						floats[g]++
+					}
+				case catInt:
+					sum := ints[g] + v.i
+					overflow = overflow || (v.i > 0 && sum < ints[g]) || (v.i < 0 && sum > ints[g])
+					ints[g] = sum
+					floats[g] += float64(v.i)
+				case catUint:
+					var carry uint64
+					uints[g], carry = bits.Add64(uints[g], v.u, 0)
+					overflow = overflow || carry != 0
+					floats[g] += float64(v.u)
+				case catFloat:
+					floats[g] += v.f
+				}
+			}
+		}
+
+		valid := make([]bool, n)
+		for g := range valid {
+			valid[g] = count[g] > 0
+		}
+
+		switch {
+		case f == Sum && overflow && cat == catInt:
+			return nil, fmt.Errorf("arrow/compute: int64 overflow")
+		case f == Sum && overflow:
+			return nil, fmt.Errorf("arrow/compute: uint64 overflow")
+		case f == Mean:
+			for g := range floats {
+				if count[g] > 0 {
+					floats[g] /= float64(count[g])
+				}
+			}
+		case cat == catBool || cat == catInt || cat == catNull:
+			bld := array.NewInt64Builder(mem)
+			defer bld.Release()
+			bld.AppendValues(ints, valid)
+			return bld.NewArray(), nil
+		case cat == catUint:
+			bld := array.NewUint64Builder(mem)
+			defer bld.Release()
+			bld.AppendValues(uints, valid)
+			return bld.NewArray(), nil
+		}
+
+		bld := array.NewFloat64Builder(mem)
+		defer bld.Release()
+		bld.AppendValues(floats, valid)
+		return bld.NewArray(), nil
+	}
+
+	return nil, fmt.Errorf("arrow/compute: invalid aggregate function %v", f)
+}
@@ -0,0 +1,262 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestGroupBy(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "k1", Type: arrow.BinaryTypes.String, Nullable: true},
+			{Name: "k2", Type: arrow.PrimitiveTypes.Int32},
+			{Name: "i64", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+			{Name: "u8", Type: arrow.PrimitiveTypes.Uint8},
+			{Name: "f64", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+			{Name: "str", Type: arrow.BinaryTypes.String},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.StringBuilder).AppendValues([]string{"a", "b", "a", "", "a", "b"}, []bool{true, true, true, false, true, true})
+	b.Field(1).(*array.Int32Builder).AppendValues([]int32{1, 1, 1, 1, 2, 1}, nil)
+	b.Field(2).(*array.Int64Builder).AppendValues([]int64{1, 2, 3, 4, 5, 6}, []bool{true, true, true, true, true, false})
+	b.Field(3).(*array.Uint8Builder).AppendValues([]uint8{10, 20, 30, 40, 50, 60}, nil)
+	b.Field(4).(*array.Float64Builder).AppendValues([]float64{1, 2, 4, 0, 0, 0}, []bool{true, true, true, false, false, false})
+	b.Field(5).(*array.StringBuilder).AppendValues([]string{"z", "y", "x", "w", "v", "u"}, nil)
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	got, err := compute.GroupBy(mem, rec, []string{"k1", "k2"}, []compute.Aggregate{
+		{Func: compute.Count, Column: "i64"},
+		{Func: compute.Sum, Column: "i64"},
+		{Func: compute.Sum, Column: "u8", Name: "total"},
+		{Func: compute.Mean, Column: "f64"},
+		{Func: compute.Min, Column: "str"},
+		{Func: compute.Max, Column: "f64"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	want := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "k1", Type: arrow.BinaryTypes.String, Nullable: true},
+			{Name: "k2", Type: arrow.PrimitiveTypes.Int32},
+			{Name: "count_i64", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "sum_i64", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+			{Name: "total", Type: arrow.PrimitiveTypes.Uint64, Nullable: true},
+			{Name: "mean_f64", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+			{Name: "min_str", Type: arrow.BinaryTypes.String, Nullable: true},
+			{Name: "max_f64", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		},
+		nil,
+	)
+	if !got.Schema().Equal(want) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", got.Schema(), want)
+	}
+
+	for i, want := range []string{
+		`{"v":"a"}
+{"v":"b"}
+{"v":null}
+{"v":"a"}
+`,
+		`{"v":1}
+{"v":1}
+{"v":1}
+{"v":2}
+`,
+		`{"v":2}
+{"v":1}
+{"v":1}
+{"v":1}
+`,
+		`{"v":4}
+{"v":2}
+{"v":4}
+{"v":5}
+`,
+		`{"v":40}
+{"v":80}
+{"v":40}
+{"v":50}
+`,
+		`{"v":2.5}
+{"v":2}
+{"v":null}
+{"v":null}
+`,
+		`{"v":"x"}
+{"v":"u"}
+{"v":"w"}
+{"v":"v"}
+`,
+		`{"v":4}
+{"v":2}
+{"v":null}
+{"v":null}
+`,
+	} {
+		if got := toJSON(t, got.Column(i)); got != want {
+			t.Fatalf("invalid column %d:\ngot= %s\nwant=%s", i, got, want)
+		}
+	}
+}
+
+func TestGroupByFloatKeys(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "k", Type: arrow.PrimitiveTypes.Float64},
+			{Name: "v", Type: arrow.PrimitiveTypes.Int64},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Float64Builder).AppendValues([]float64{
+		0, math.Copysign(0, -1), math.NaN(), math.Float64frombits(0x7ff8000000000001), 1, -math.NaN(),
+	}, nil)
+	b.Field(1).(*array.Int64Builder).AppendValues([]int64{1, 2, 3, 4, 5, 6}, nil)
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	got, err := compute.GroupBy(mem, rec, []string{"k"}, []compute.Aggregate{{Func: compute.Sum, Column: "v"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if got, want := toJSON(t, got.Column(1)), "{\"v\":3}\n{\"v\":13}\n{\"v\":5}\n"; got != want {
+		t.Fatalf("invalid sums:\ngot= %s\nwant=%s", got, want)
+	}
+}
+
+func TestGroupBySumOverflow(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "k", Type: arrow.PrimitiveTypes.Int32},
+			{Name: "i", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "u", Type: arrow.PrimitiveTypes.Uint64},
+			{Name: "n", Type: arrow.PrimitiveTypes.Int64},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 1, 2}, nil)
+	b.Field(1).(*array.Int64Builder).AppendValues([]int64{math.MaxInt64, 1, math.MaxInt64}, nil)
+	b.Field(2).(*array.Uint64Builder).AppendValues([]uint64{math.MaxUint64, 1, 0}, nil)
+	b.Field(3).(*array.Int64Builder).AppendValues([]int64{math.MinInt64, -1, 1}, nil)
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	for col, want := range map[string]string{
+		"i": `arrow/compute: sum of column "i": arrow/compute: int64 overflow`,
+		"u": `arrow/compute: sum of column "u": arrow/compute: uint64 overflow`,
+		"n": `arrow/compute: sum of column "n": arrow/compute: int64 overflow`,
+	} {
+		_, err := compute.GroupBy(mem, rec, []string{"k"}, []compute.Aggregate{{Func: compute.Sum, Column: col}})
+		if err == nil || err.Error() != want {
+			t.Fatalf("invalid error:\ngot= %v\nwant=%s", err, want)
+		}
+	}
+
+	// the mean is computed on floats, and does not overflow.
+	got, err := compute.GroupBy(mem, rec, []string{"k"}, []compute.Aggregate{{Func: compute.Mean, Column: "u"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got.Release()
+}
+
+func TestGroupByErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "k", Type: arrow.PrimitiveTypes.Int32},
+			{Name: "s", Type: arrow.BinaryTypes.String},
+			{Name: "l", Type: arrow.ListOf(arrow.PrimitiveTypes.Int32)},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	for _, tc := range []struct {
+		keys []string
+		aggs []compute.Aggregate
+		err  string
+	}{
+		{
+			keys: []string{"x"},
+			err:  `arrow/compute: no column named "x"`,
+		},
+		{
+			keys: []string{"k"},
+			aggs: []compute.Aggregate{{Func: compute.Sum, Column: "x"}},
+			err:  `arrow/compute: no column named "x"`,
+		},
+		{
+			keys: []string{"k"},
+			aggs: []compute.Aggregate{{Func: compute.Sum, Column: "s"}},
+			err:  `arrow/compute: sum of column "s": arrow/compute: unsupported data type utf8`,
+		},
+		{
+			keys: []string{"l"},
+			err:  `arrow/compute: key column "l": arrow/compute: unsupported data type list for grouping`,
+		},
+	} {
+		_, err := compute.GroupBy(mem, rec, tc.keys, tc.aggs)
+		if err == nil || err.Error() != tc.err {
+			t.Fatalf("invalid error:\ngot= %v\nwant=%s", err, tc.err)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// Unique returns the distinct values of arr, in the order of their first
+// occurrence. If arr has nulls, the result holds a single null.
+func Unique(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+	_, first, err := hashRows(arr, "unique")
+	if err != nil {
+		return nil, err
+	}
+	return take(mem, arr, first)
+}
+
+// ValueCounts returns a struct array with a "values" field holding the
+// distinct values of arr, in the order of their first occurrence, and a
+// "counts" field holding their number of occurrences as int64.
+// Nulls are counted as a single value.
+func ValueCounts(mem memory.Allocator, arr array.Interface) (*array.Struct, error) {
+	ids, first, err := hashRows(arr, "value_counts")
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := take(mem, arr, first)
+	if err != nil {
+		return nil, err
+	}
+	defer values.Release()
+
+	counts := make([]int64, len(first))
+	for _, id := range ids {
+		counts[id]++
+	}
+	bld := array.NewInt64Builder(mem)
+	defer bld.Release()
+	bld.AppendValues(counts, nil)
+	cnts := bld.NewArray()
+	defer cnts.Release()
+
+	dt := arrow.StructOf(
+		arrow.Field{Name: "values", Type: arr.DataType(), Nullable: true},
+		arrow.Field{Name: "counts", Type: arrow.PrimitiveTypes.Int64},
+	)
+	data := array.NewData(dt, len(first), []*memory.Buffer{nil}, []*array.Data{values.Data(), cnts.Data()}, 0, 0)
+	defer data.Release()
+	return array.NewStructData(data), nil
+}
+
+// DictionaryEncode returns the dictionary array, with int32 indices, whose
+// dictionary holds the distinct valid values of arr in the order of their
+// first occurrence. Nulls of arr are null indices.
+func DictionaryEncode(mem memory.Allocator, arr array.Interface) (*array.Dictionary, error) {
+	ids, first, err := hashRows(arr, "dictionary_encode")
+	if err != nil {
+		return nil, err
+	}
+
+	// drop the null value from the dictionary, and renumber the values
+	// following it.
+	transpose := make([]int, len(first))
+	dict := first[:0:0]
+	for id, row := range first {
+		if arr.IsNull(row) {
+			transpose[id] = -1
+			continue
+		}
+		transpose[id] = len(dict)
+		dict = append(dict, row)
+	}
+
+	values, err := take(mem, arr, dict)
+	if err != nil {
+		return nil, err
+	}
+	defer values.Release()
+
+	bld := array.NewInt32Builder(mem)
+	defer bld.Release()
+	bld.Reserve(len(ids))
+	for _, id := range ids {
+		if idx := transpose[id]; idx >= 0 {
+			bld.UnsafeAppend(int32(idx))
+		} else {
+			bld.UnsafeAppendBoolToBitmap(false)
+		}
+	}
+	indices := bld.NewArray()
+	defer indices.Release()
+
+	dt := arrow.DictionaryOf(arrow.PrimitiveTypes.Int32, arr.DataType())
+	return array.NewDictionaryArray(dt, indices, values), nil
+}
+
+// hashRows returns the identifier of the distinct value of each element of
+// arr, and the first element of each distinct value.
+func hashRows(arr array.Interface, kernel string) (ids []int, first []int, err error) {
+	enc, err := keyEncoder(arr, kernel)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		n    = arr.Len()
+		seen = make(map[string]int)
+		buf  []byte
+	)
+	ids = make([]int, n)
+	for i := 0; i < n; i++ {
+		buf = enc(buf[:0], i)
+		id, ok := seen[string(buf)]
+		if !ok {
+			id = len(first)
+			seen[string(buf)] = id
+			first = append(first, i)
+		}
+		ids[i] = id
+	}
+	return ids, first, nil
+}
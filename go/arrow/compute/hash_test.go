@@ -0,0 +1,124 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestHashKernels(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	for _, tc := range []struct {
+		name    string
+		dtype   arrow.DataType
+		in      []string
+		unique  []string
+		counts  string
+		indices string
+		dict    string
+	}{
+		{
+			name:    "int64",
+			dtype:   arrow.PrimitiveTypes.Int64,
+			in:      []string{"3", "1", "null", "3", "2", "1", "null", "3"},
+			unique:  []string{"3", "1", "null", "2"},
+			counts:  `[{values: 3, counts: 3} {values: 1, counts: 2} {values: (null), counts: 2} {values: 2, counts: 1}]`,
+			indices: "[0 1 (null) 0 2 1 (null) 0]",
+			dict:    "[3 1 2]",
+		},
+		{
+			name:    "string",
+			dtype:   arrow.BinaryTypes.String,
+			in:      []string{`"b"`, `"a"`, `"b"`, `""`},
+			unique:  []string{`"b"`, `"a"`, `""`},
+			counts:  `[{values: "b", counts: 2} {values: "a", counts: 1} {values: "", counts: 1}]`,
+			indices: "[0 1 0 2]",
+			dict:    `["b" "a" ""]`,
+		},
+		{
+			name:    "float64",
+			dtype:   arrow.PrimitiveTypes.Float64,
+			in:      []string{"null", "1.5", "1.5"},
+			unique:  []string{"null", "1.5"},
+			counts:  `[{values: (null), counts: 1} {values: 1.5, counts: 2}]`,
+			indices: "[(null) 0 0]",
+			dict:    "[1.5]",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			arr := fromJSON(t, mem, tc.dtype, tc.in...)
+			defer arr.Release()
+
+			uniq, err := compute.Unique(mem, arr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer uniq.Release()
+
+			want := fromJSON(t, mem, tc.dtype, tc.unique...)
+			defer want.Release()
+			if got, want := toJSON(t, uniq), toJSON(t, want); got != want {
+				t.Fatalf("invalid unique values:\ngot= %s\nwant=%s", got, want)
+			}
+
+			counts, err := compute.ValueCounts(mem, arr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer counts.Release()
+			if got := fmt.Sprint(counts); got != tc.counts {
+				t.Fatalf("invalid value counts:\ngot= %s\nwant=%s", got, tc.counts)
+			}
+
+			dict, err := compute.DictionaryEncode(mem, arr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer dict.Release()
+			if got := fmt.Sprint(dict.Indices()); got != tc.indices {
+				t.Fatalf("invalid indices:\ngot= %s\nwant=%s", got, tc.indices)
+			}
+			if got := fmt.Sprint(dict.Dictionary()); got != tc.dict {
+				t.Fatalf("invalid dictionary:\ngot= %s\nwant=%s", got, tc.dict)
+			}
+			if got, want := dict.Len(), arr.Len(); got != want {
+				t.Fatalf("invalid length: got=%d, want=%d", got, want)
+			}
+		})
+	}
+}
+
+func TestHashKernelsErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	dt := arrow.ListOf(arrow.PrimitiveTypes.Int32)
+	arr := fromJSON(t, mem, dt, "[1]")
+	defer arr.Release()
+
+	_, err := compute.Unique(mem, arr)
+	if want := "arrow/compute: unsupported data type list for unique"; err == nil || err.Error() != want {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%s", err, want)
+	}
+}
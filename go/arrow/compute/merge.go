@@ -0,0 +1,319 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"container/heap"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// mergeReader merges the rows of readers sorted on the same keys.
+type mergeReader struct {
+	refs int64
+	mem  memory.Allocator
+	keys []mergeKey
+	rs   []array.RecordReader
+
+	heap    mergeHeap      // cursors of the readers with rows left to merge
+	pending []*mergeCursor // cursors whose record is exhausted
+
+	cur array.Record
+	err error
+}
+
+// mergeKey is a sort key of a mergeReader.
+type mergeKey struct {
+	col          int                  // index of the column
+	cmp          func(a, b value) int // nil for null columns
+	order, nulls int
+}
+
+// mergeCursor is the position of a mergeReader in the current record of one
+// of its readers.
+type mergeCursor struct {
+	src   int // index of the reader
+	rec   array.Record
+	cols  []array.Interface // key columns of rec
+	gets  []func(i int) value
+	start int // first row of rec not merged by the previous records
+	pos   int // first row of rec not merged yet
+}
+
+// mergeHeap is a min-heap of cursors, ordered by their current rows.
+type mergeHeap struct {
+	keys []mergeKey
+	cs   []*mergeCursor
+}
+
+func (h *mergeHeap) Len() int           { return len(h.cs) }
+func (h *mergeHeap) Swap(i, j int)      { h.cs[i], h.cs[j] = h.cs[j], h.cs[i] }
+func (h *mergeHeap) Push(x interface{}) { h.cs = append(h.cs, x.(*mergeCursor)) }
+func (h *mergeHeap) Pop() (x interface{}) {
+	n := len(h.cs) - 1
+	x, h.cs = h.cs[n], h.cs[:n]
+	return x
+}
+
+// Less orders equal rows by the indices of their readers.
+func (h *mergeHeap) Less(i, j int) bool {
+	a, b := h.cs[i], h.cs[j]
+	for k, key := range h.keys {
+		an, bn := a.cols[k].IsNull(a.pos), b.cols[k].IsNull(b.pos)
+		c := 0
+		switch {
+		case an && bn:
+		case an:
+			c = key.nulls
+		case bn:
+			c = -key.nulls
+		default:
+			c = key.order * key.cmp(a.gets[k](a.pos), b.gets[k](b.pos))
+		}
+		if c != 0 {
+			return c < 0
+		}
+	}
+	return a.src < b.src
+}
+
+// MergeSortedBy returns a reader of the rows of the records of rs, whose
+// rows must each be sorted on keys, merged into a single stream of rows
+// sorted on keys. Equal rows are read in the order of the readers in rs.
+// All the readers must have the same schema.
+//
+// A record of the returned reader holds the rows up to the end of a record
+// of one of the readers: the readers are read as the rows are merged.
+// The returned reader retains rs until it is released.
+func MergeSortedBy(mem memory.Allocator, keys []SortKey, rs ...array.RecordReader) (array.RecordReader, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("arrow/compute: no sort keys")
+	}
+	if len(rs) == 0 {
+		return nil, fmt.Errorf("arrow/compute: no readers to merge")
+	}
+	schema := rs[0].Schema()
+	for _, r := range rs[1:] {
+		if !r.Schema().Equal(schema) {
+			return nil, fmt.Errorf("arrow/compute: mismatched reader schemas")
+		}
+	}
+	mkeys := make([]mergeKey, len(keys))
+	for k, key := range keys {
+		idx := schema.FieldIndex(key.Name)
+		if idx < 0 {
+			return nil, fmt.Errorf("arrow/compute: no column named %q", key.Name)
+		}
+		mkeys[k] = mergeKey{col: idx, order: 1, nulls: 1}
+		if key.Order == Descending {
+			mkeys[k].order = -1
+		}
+		if key.Nulls == NullsAtStart {
+			mkeys[k].nulls = -1
+		}
+		if dt := schema.Field(idx).Type; categoryOf(dt) != catNull {
+			cmp, err := valueComparer(dt)
+			if err != nil {
+				return nil, fmt.Errorf("arrow/compute: column %q: %v", key.Name, err)
+			}
+			mkeys[k].cmp = cmp
+		}
+	}
+
+	r := &mergeReader{
+		refs: 1,
+		mem:  mem,
+		keys: mkeys,
+		rs:   append([]array.RecordReader(nil), rs...),
+		heap: mergeHeap{keys: mkeys, cs: make([]*mergeCursor, 0, len(rs))},
+	}
+	for i, rr := range rs {
+		rr.Retain()
+		r.pending = append(r.pending, &mergeCursor{src: i})
+	}
+	return r, nil
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (r *mergeReader) Retain() {
+	atomic.AddInt64(&r.refs, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+// Release may be called simultaneously from multiple goroutines.
+func (r *mergeReader) Release() {
+	debug.Assert(atomic.LoadInt64(&r.refs) > 0, "too many releases")
+
+	if atomic.AddInt64(&r.refs, -1) == 0 {
+		if r.cur != nil {
+			r.cur.Release()
+			r.cur = nil
+		}
+		for _, rr := range r.rs {
+			rr.Release()
+		}
+		r.rs, r.heap.cs, r.pending = nil, nil, nil
+	}
+}
+
+func (r *mergeReader) Schema() *arrow.Schema { return r.rs[0].Schema() }
+func (r *mergeReader) Record() array.Record  { return r.cur }
+
+// Err returns the error of the first reader that failed, if any.
+func (r *mergeReader) Err() error { return r.err }
+
+func (r *mergeReader) Next() bool {
+	if r.cur != nil {
+		r.cur.Release()
+		r.cur = nil
+	}
+	if r.err != nil || !r.refill() {
+		return false
+	}
+	if r.heap.Len() == 0 {
+		return false
+	}
+
+	// merge the rows of the cursors until the record of one of them is
+	// exhausted.
+	var order []*mergeCursor // cursor of each merged row
+	for _, c := range r.heap.cs {
+		c.start = c.pos
+	}
+	if r.heap.Len() == 1 {
+		c := r.heap.cs[0]
+		c.pos = int(c.rec.NumRows())
+	} else {
+		for {
+			c := r.heap.cs[0]
+			order = append(order, c)
+			c.pos++
+			if c.pos == int(c.rec.NumRows()) {
+				break
+			}
+			heap.Fix(&r.heap, 0)
+		}
+	}
+
+	// the exhausted cursor is at the top of the heap.
+	if c := r.heap.cs[0]; c.pos == int(c.rec.NumRows()) {
+		heap.Pop(&r.heap)
+		r.pending = append(r.pending, c)
+	}
+
+	var err error
+	r.cur, err = r.merged(order)
+	if err != nil {
+		r.err = err
+		return false
+	}
+	return true
+}
+
+// refill moves the pending cursors to the next non-empty record of their
+// reader, and back into the heap. It returns false if a reader failed.
+func (r *mergeReader) refill() bool {
+	for _, c := range r.pending {
+		rr := r.rs[c.src]
+		for {
+			if !rr.Next() {
+				c.rec = nil
+				if r.err = readerErr(rr); r.err != nil {
+					return false
+				}
+				break
+			}
+			if rec := rr.Record(); rec.NumRows() > 0 {
+				c.rec, c.pos = rec, 0
+				break
+			}
+		}
+		if c.rec == nil {
+			continue
+		}
+		c.cols = c.cols[:0]
+		c.gets = c.gets[:0]
+		for _, key := range r.keys {
+			col := c.rec.Column(key.col)
+			var get func(i int) value
+			if key.cmp != nil {
+				get = getter(col)
+			}
+			c.cols = append(c.cols, col)
+			c.gets = append(c.gets, get)
+		}
+		heap.Push(&r.heap, c)
+	}
+	r.pending = r.pending[:0]
+	return true
+}
+
+// merged returns the record of the rows merged by Next: the rows of the
+// cursors, from their start to their position, in the given order. A nil
+// order means a single cursor contributed rows.
+func (r *mergeReader) merged(order []*mergeCursor) (array.Record, error) {
+	var srcs []*mergeCursor
+	for _, c := range append(r.heap.cs, r.pending...) {
+		if c.rec != nil && c.pos > c.start {
+			srcs = append(srcs, c)
+		}
+	}
+	if len(srcs) == 1 {
+		c := srcs[0]
+		return c.rec.NewSlice(int64(c.start), int64(c.pos)), nil
+	}
+
+	// only the merged rows are concatenated, to be interleaved by take.
+	slices := make([]array.Record, len(srcs))
+	base := make(map[*mergeCursor]int, len(srcs))
+	n := 0
+	for k, c := range srcs {
+		slices[k] = c.rec.NewSlice(int64(c.start), int64(c.pos))
+		base[c] = n - c.start
+		n += c.pos - c.start
+	}
+	defer func() {
+		for _, s := range slices {
+			s.Release()
+		}
+	}()
+	cat, err := array.ConcatenateRecords(r.mem, slices...)
+	if err != nil {
+		return nil, fmt.Errorf("arrow/compute: %v", err)
+	}
+	defer cat.Release()
+
+	idx := make([]int, len(order))
+	next := make(map[*mergeCursor]int, len(srcs))
+	for c, b := range base {
+		next[c] = b + c.start
+	}
+	for i, c := range order {
+		idx[i] = next[c]
+		next[c]++
+	}
+	return takeRecord(r.mem, cat, idx)
+}
+
+var _ array.RecordReader = (*mergeReader)(nil)
@@ -0,0 +1,173 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestMergeSortedBy(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "k", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "src", Type: arrow.BinaryTypes.String},
+	}, nil)
+	reader := func(src string, keys ...[]int64) array.RecordReader {
+		b := array.NewRecordBuilder(mem, schema)
+		defer b.Release()
+		var recs []array.Record
+		for _, ks := range keys {
+			for _, k := range ks {
+				if k < 0 {
+					b.Field(0).AppendNull()
+				} else {
+					b.Field(0).(*array.Int64Builder).Append(k)
+				}
+				b.Field(1).(*array.StringBuilder).Append(src)
+			}
+			rec := b.NewRecord()
+			defer rec.Release()
+			recs = append(recs, rec)
+		}
+		rr, err := array.NewRecordReader(schema, recs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rr
+	}
+
+	a := reader("a", []int64{1, 3, 5}, []int64{}, []int64{7, -1})
+	defer a.Release()
+	b := reader("b", []int64{1, 2}, []int64{6, 8, 9})
+	defer b.Release()
+	c := reader("c")
+	defer c.Release()
+
+	rr, err := compute.MergeSortedBy(mem, []compute.SortKey{{Name: "k"}}, a, b, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.Release()
+
+	var got []string
+	for rr.Next() {
+		rec := rr.Record()
+		ks, srcs := rec.Column(0).(*array.Int64), rec.Column(1).(*array.String)
+		for i := 0; i < ks.Len(); i++ {
+			k := "null"
+			if ks.IsValid(i) {
+				k = strconv.FormatInt(ks.Value(i), 10)
+			}
+			got = append(got, srcs.Value(i)+k)
+		}
+	}
+	if err := rr.(interface{ Err() error }).Err(); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"a1", "b1", "b2", "a3", "a5", "b6", "a7", "b8", "b9", "anull"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid merge:\ngot= %v\nwant=%v", got, want)
+	}
+
+	if _, err := compute.MergeSortedBy(mem, []compute.SortKey{{Name: "nope"}}, a); err == nil {
+		t.Fatalf("expected an error with a missing key column")
+	}
+}
+
+func TestMergeSortedByMany(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "k", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "row", Type: arrow.BinaryTypes.String},
+	}, nil)
+
+	type row struct {
+		k   int64
+		row string
+	}
+	var (
+		rng  = rand.New(rand.NewSource(1))
+		rs   []array.RecordReader
+		want []row
+	)
+	for src := 0; src < 7; src++ {
+		b := array.NewRecordBuilder(mem, schema)
+		var (
+			recs []array.Record
+			k    int64
+		)
+		for i, n := 0, rng.Intn(6); i < n; i++ {
+			for j, m := 0, rng.Intn(20); j < m; j++ {
+				k -= int64(rng.Intn(3))
+				r := row{k: k, row: fmt.Sprintf("%d/%d", src, len(want))}
+				b.Field(0).(*array.Int64Builder).Append(r.k)
+				b.Field(1).(*array.StringBuilder).Append(r.row)
+				want = append(want, r)
+			}
+			recs = append(recs, b.NewRecord())
+		}
+		b.Release()
+		rr, err := array.NewRecordReader(schema, recs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, rec := range recs {
+			rec.Release()
+		}
+		defer rr.Release()
+		rs = append(rs, rr)
+	}
+	sort.SliceStable(want, func(i, j int) bool { return want[i].k > want[j].k })
+
+	rr, err := compute.MergeSortedBy(mem, []compute.SortKey{{Name: "k", Order: compute.Descending}}, rs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rr.Release()
+
+	var got []row
+	for rr.Next() {
+		rec := rr.Record()
+		if rec.NumRows() == 0 {
+			t.Fatalf("unexpected empty record")
+		}
+		ks, rows := rec.Column(0).(*array.Int64), rec.Column(1).(*array.String)
+		for i := 0; i < ks.Len(); i++ {
+			got = append(got, row{k: ks.Value(i), row: rows.Value(i)})
+		}
+	}
+	if err := rr.(interface{ Err() error }).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid merge:\ngot= %v\nwant=%v", got, want)
+	}
+}
@@ -0,0 +1,126 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// SampleRecord returns a record made of n rows of rec drawn at random,
+// without replacement, in the order they appear in rec. All the rows are
+// returned if rec has fewer than n rows. The same seed draws the same rows.
+// The returned record must be Release()'d after use.
+//
+// SampleRecord panics if n is negative.
+func SampleRecord(mem memory.Allocator, rec array.Record, n int, seed int64) (array.Record, error) {
+	return takeRecord(mem, rec, sampleIndices(int(rec.NumRows()), n, seed))
+}
+
+// SampleTable returns a table made of n rows of tbl drawn at random, as
+// SampleRecord does. Each column of the result has one chunk for each
+// chunk of tbl holding sampled rows.
+// The returned table must be Release()'d after use.
+//
+// SampleTable panics if n is negative.
+func SampleTable(mem memory.Allocator, tbl array.Table, n int, seed int64) (array.Table, error) {
+	idx := sampleIndices(int(tbl.NumRows()), n, seed)
+
+	cols := make([]array.Column, 0, tbl.NumCols())
+	defer func() {
+		for i := range cols {
+			cols[i].Release()
+		}
+	}()
+
+	for i := 0; i < int(tbl.NumCols()); i++ {
+		col := tbl.Column(i)
+		data, err := takeChunked(mem, col.Data(), idx)
+		if err != nil {
+			return nil, fmt.Errorf("arrow/compute: column %d (%s): %v", i, col.Name(), err)
+		}
+		cols = append(cols, *array.NewColumn(col.Field(), data))
+		data.Release()
+	}
+	return array.NewTable(tbl.Schema(), cols, int64(len(idx))), nil
+}
+
+// sampleIndices returns n distinct positions out of rows, drawn at random
+// with Floyd's algorithm, in increasing order.
+func sampleIndices(rows, n int, seed int64) []int {
+	if n < 0 {
+		panic(fmt.Errorf("arrow/compute: negative sample size %d", n))
+	}
+	if n >= rows {
+		idx := make([]int, rows)
+		for i := range idx {
+			idx[i] = i
+		}
+		return idx
+	}
+
+	var (
+		rnd  = rand.New(rand.NewSource(seed))
+		seen = make(map[int]bool, n)
+		idx  = make([]int, 0, n)
+	)
+	for j := rows - n; j < rows; j++ {
+		i := rnd.Intn(j + 1)
+		if seen[i] {
+			i = j
+		}
+		seen[i] = true
+		idx = append(idx, i)
+	}
+	sort.Ints(idx)
+	return idx
+}
+
+// takeChunked returns the elements of data at the positions idx, which
+// must be in increasing order, as a chunked array with one chunk for each
+// chunk of data holding some of the positions.
+func takeChunked(mem memory.Allocator, data *array.Chunked, idx []int) (*array.Chunked, error) {
+	chunks := make([]array.Interface, 0, len(data.Chunks()))
+	defer func() {
+		for _, c := range chunks {
+			c.Release()
+		}
+	}()
+
+	off := 0
+	for _, c := range data.Chunks() {
+		var local []int
+		for len(idx) > 0 && idx[0] < off+c.Len() {
+			local = append(local, idx[0]-off)
+			idx = idx[1:]
+		}
+		off += c.Len()
+		if len(local) == 0 {
+			continue
+		}
+		out, err := take(mem, c, local)
+		if err != nil {
+			return nil, err
+		}
+		chunks = append(chunks, out)
+	}
+	return array.NewChunked(data.DataType(), chunks), nil
+}
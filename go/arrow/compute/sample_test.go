@@ -0,0 +1,108 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestSampleRecord(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "i64", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	var recs []array.Record
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 25; j++ {
+			b.Field(0).(*array.Int64Builder).Append(int64(25*i + j))
+		}
+		rec := b.NewRecord()
+		defer rec.Release()
+		recs = append(recs, rec)
+	}
+	rec, err := array.ConcatenateRecords(mem, recs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rec.Release()
+
+	sample := func(seed int64) []int64 {
+		out, err := compute.SampleRecord(mem, rec, 10, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer out.Release()
+		return append([]int64(nil), out.Column(0).(*array.Int64).Int64Values()...)
+	}
+
+	got := sample(42)
+	if len(got) != 10 {
+		t.Fatalf("invalid sample size: got=%d, want=10", len(got))
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i] <= got[i-1] {
+			t.Fatalf("sampled rows should be distinct and in order: %v", got)
+		}
+	}
+	if again := sample(42); !reflect.DeepEqual(got, again) {
+		t.Fatalf("the same seed should draw the same rows: %v, %v", got, again)
+	}
+	if other := sample(7); reflect.DeepEqual(got, other) {
+		t.Fatalf("different seeds should draw different rows: %v", got)
+	}
+
+	all, err := compute.SampleRecord(mem, rec, 1000, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer all.Release()
+	if !array.RecordEqual(all, rec) {
+		t.Fatalf("a sample larger than the record should hold all its rows")
+	}
+
+	// a table made of the same rows draws the same sample.
+	tbl := array.NewTableFromRecords(schema, recs)
+	defer tbl.Release()
+	sampled, err := compute.SampleTable(mem, tbl, 10, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sampled.Release()
+
+	if got, want := sampled.NumRows(), int64(10); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+	var vs []int64
+	for _, chunk := range sampled.Column(0).Data().Chunks() {
+		vs = append(vs, chunk.(*array.Int64).Int64Values()...)
+	}
+	if !reflect.DeepEqual(vs, got) {
+		t.Fatalf("invalid table sample: got=%v, want=%v", vs, got)
+	}
+	if n := len(sampled.Column(0).Data().Chunks()); n > len(recs) {
+		t.Fatalf("too many chunks: %d", n)
+	}
+}
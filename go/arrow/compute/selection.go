@@ -0,0 +1,578 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/bitutil"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// Take returns an array made of the elements of arr at the positions given
+// by indices, in order.
+// indices must be an array of integers. A null index results in a null
+// element in the output.
+//
+// Take returns an error if an index is out of the bounds of arr.
+func Take(mem memory.Allocator, arr array.Interface, indices array.Interface) (array.Interface, error) {
+	idx, err := indicesOf(indices, arr.Len())
+	if err != nil {
+		return nil, err
+	}
+	return take(mem, arr, idx)
+}
+
+// Filter returns an array made of the elements of arr for which the
+// corresponding element of mask is true. Null elements of mask are
+// considered false.
+//
+// Filter returns an error if arr and mask have different lengths.
+func Filter(mem memory.Allocator, arr array.Interface, mask *array.Boolean) (array.Interface, error) {
+	idx, err := maskOf(mask, arr.Len())
+	if err != nil {
+		return nil, err
+	}
+	return take(mem, arr, idx)
+}
+
+// TakeRecord applies Take with indices to all the columns of rec.
+func TakeRecord(mem memory.Allocator, rec array.Record, indices array.Interface) (array.Record, error) {
+	idx, err := indicesOf(indices, int(rec.NumRows()))
+	if err != nil {
+		return nil, err
+	}
+	return takeRecord(mem, rec, idx)
+}
+
+// FilterRecord applies Filter with mask to all the columns of rec.
+func FilterRecord(mem memory.Allocator, rec array.Record, mask *array.Boolean) (array.Record, error) {
+	idx, err := maskOf(mask, int(rec.NumRows()))
+	if err != nil {
+		return nil, err
+	}
+	return takeRecord(mem, rec, idx)
+}
+
+func take(mem memory.Allocator, arr array.Interface, idx []int) (array.Interface, error) {
+	data, err := takeData(mem, arr.Data(), idx)
+	if err != nil {
+		return nil, err
+	}
+	defer data.Release()
+	return array.MakeFromData(data), nil
+}
+
+func takeRecord(mem memory.Allocator, rec array.Record, idx []int) (array.Record, error) {
+	cols := make([]array.Interface, 0, rec.NumCols())
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+
+	for i, col := range rec.Columns() {
+		out, err := take(mem, col, idx)
+		if err != nil {
+			return nil, fmt.Errorf("arrow/compute: column %d (%s): %v", i, rec.ColumnName(i), err)
+		}
+		cols = append(cols, out)
+	}
+	return array.NewRecord(rec.Schema(), cols, int64(len(idx))), nil
+}
+
+// indicesOf converts an array of integer indices into a slice of positions
+// within [0, n), null indices being represented as -1.
+func indicesOf(indices array.Interface, n int) ([]int, error) {
+	var value func(i int) int64
+	switch indices := indices.(type) {
+	case *array.Int8:
+		value = func(i int) int64 { return int64(indices.Value(i)) }
+	case *array.Int16:
+		value = func(i int) int64 { return int64(indices.Value(i)) }
+	case *array.Int32:
+		value = func(i int) int64 { return int64(indices.Value(i)) }
+	case *array.Int64:
+		value = func(i int) int64 { return indices.Value(i) }
+	case *array.Uint8:
+		value = func(i int) int64 { return int64(indices.Value(i)) }
+	case *array.Uint16:
+		value = func(i int) int64 { return int64(indices.Value(i)) }
+	case *array.Uint32:
+		value = func(i int) int64 { return int64(indices.Value(i)) }
+	case *array.Uint64:
+		value = func(i int) int64 {
+			v := indices.Value(i)
+			if v > uint64(n) {
+				return int64(n) // out of bounds, without overflowing.
+			}
+			return int64(v)
+		}
+	default:
+		return nil, fmt.Errorf("arrow/compute: invalid indices type %s", indices.DataType().Name())
+	}
+
+	idx := make([]int, indices.Len())
+	for i := range idx {
+		if indices.IsNull(i) {
+			idx[i] = -1
+			continue
+		}
+		v := value(i)
+		if v < 0 || v >= int64(n) {
+			return nil, fmt.Errorf("arrow/compute: index at position %d out of bounds [0, %d)", i, n)
+		}
+		idx[i] = int(v)
+	}
+	return idx, nil
+}
+
+// maskOf returns the positions of the true elements of mask.
+func maskOf(mask *array.Boolean, n int) ([]int, error) {
+	if mask.Len() != n {
+		return nil, fmt.Errorf("arrow/compute: mask length mismatch (got=%d, want=%d)", mask.Len(), n)
+	}
+
+	idx := make([]int, 0, n)
+	for i := 0; i < n; i++ {
+		if mask.IsValid(i) && mask.Value(i) {
+			idx = append(idx, i)
+		}
+	}
+	return idx, nil
+}
+
+// newBuffer returns a zeroed buffer of the given size allocated from mem.
+func newBuffer(mem memory.Allocator, size int) *memory.Buffer {
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(size)
+	memory.Set(buf.Bytes(), 0)
+	return buf
+}
+
+// takeData returns the elements of data at the positions idx, relative to
+// the offset of data. Negative positions result in null elements.
+func takeData(mem memory.Allocator, data *array.Data, idx []int) (*array.Data, error) {
+	var (
+		n        = len(idx)
+		offset   = data.Offset()
+		bufs     = data.Buffers()
+		nulls    = 0
+		buffers  []*memory.Buffer
+		children []*array.Data
+	)
+
+	defer func() {
+		for _, b := range buffers {
+			if b != nil {
+				b.Release()
+			}
+		}
+		for _, c := range children {
+			c.Release()
+		}
+	}()
+
+	switch dt := data.DataType().(type) {
+	case *arrow.NullType:
+		return array.NewData(dt, n, []*memory.Buffer{nil}, nil, n, 0), nil
+	case arrow.ExtensionType:
+		return takeExtension(mem, data, dt, idx)
+	case *arrow.RunEndEncodedType:
+		// run-end encoded arrays have no validity bitmap.
+		return takeRunEndEncoded(mem, data, dt, idx)
+	}
+
+	var srcValid []byte
+	if bufs[0] != nil {
+		srcValid = bufs[0].Bytes()
+	}
+	valid := func(i int) bool {
+		return i >= 0 && (srcValid == nil || bitutil.BitIsSet(srcValid, offset+i))
+	}
+
+	bitmap := newBuffer(mem, bitutil.CeilByte(n)/8)
+	for k, i := range idx {
+		if valid(i) {
+			bitutil.SetBit(bitmap.Bytes(), k)
+		} else {
+			nulls++
+		}
+	}
+	buffers = append(buffers, bitmap)
+
+	switch dt := data.DataType().(type) {
+	case *arrow.BooleanType:
+		src := bufs[1].Bytes()
+		values := newBuffer(mem, bitutil.CeilByte(n)/8)
+		for k, i := range idx {
+			if valid(i) && bitutil.BitIsSet(src, offset+i) {
+				bitutil.SetBit(values.Bytes(), k)
+			}
+		}
+		buffers = append(buffers, values)
+
+	case *arrow.FixedSizeBinaryType:
+		var (
+			w       = dt.ByteWidth
+			src     = bytesOf(bufs[2])
+			srcOffs = arrow.Int32Traits.CastFromBytes(bufs[1].Bytes())
+			offsets = newBuffer(mem, (n+1)*arrow.Int32SizeBytes)
+			values  = newBuffer(mem, n*w)
+			offs    = arrow.Int32Traits.CastFromBytes(offsets.Bytes())
+			dst     = values.Bytes()
+		)
+		for k, i := range idx {
+			offs[k] = int32(k * w)
+			if valid(i) {
+				copy(dst[k*w:(k+1)*w], src[srcOffs[offset+i]:srcOffs[offset+i+1]])
+			}
+		}
+		offs[n] = int32(n * w)
+		buffers = append(buffers, offsets, values)
+
+	case *arrow.DictionaryType:
+		// the indices are copied and the dictionary shared.
+		var (
+			w       = dt.IndexType().(arrow.FixedWidthDataType).BitWidth() / 8
+			src     = bytesOf(bufs[1])
+			indices = newBuffer(mem, n*w)
+			dst     = indices.Bytes()
+		)
+		for k, i := range idx {
+			if valid(i) {
+				j := offset + i
+				copy(dst[k*w:(k+1)*w], src[j*w:(j+1)*w])
+			}
+		}
+		buffers = append(buffers, indices)
+		return array.NewDataWithDictionary(dt, n, buffers, nulls, 0, data.Dictionary()), nil
+
+	case arrow.FixedWidthDataType:
+		var (
+			w      = dt.BitWidth() / 8
+			src    = bytesOf(bufs[1])
+			values = newBuffer(mem, n*w)
+			dst    = values.Bytes()
+		)
+		for k, i := range idx {
+			if valid(i) {
+				j := offset + i
+				copy(dst[k*w:(k+1)*w], src[j*w:(j+1)*w])
+			}
+		}
+		buffers = append(buffers, values)
+
+	case arrow.BinaryViewDataType:
+		// the views are copied and the data buffers shared.
+		var (
+			src   = bytesOf(bufs[1])
+			views = newBuffer(mem, n*arrow.ViewSizeBytes)
+			dst   = views.Bytes()
+			w     = arrow.ViewSizeBytes
+		)
+		for k, i := range idx {
+			if valid(i) {
+				j := offset + i
+				copy(dst[k*w:(k+1)*w], src[j*w:(j+1)*w])
+			}
+		}
+		buffers = append(buffers, views)
+		for _, b := range bufs[2:] {
+			if b != nil {
+				b.Retain()
+			}
+			buffers = append(buffers, b)
+		}
+
+	case *arrow.LargeStringType, *arrow.LargeBinaryType:
+		var (
+			src     = bytesOf(bufs[2])
+			srcOffs = arrow.Int64Traits.CastFromBytes(bufs[1].Bytes())
+			offsets = newBuffer(mem, (n+1)*arrow.Int64SizeBytes)
+			offs    = arrow.Int64Traits.CastFromBytes(offsets.Bytes())
+			size    = int64(0)
+		)
+		for k, i := range idx {
+			offs[k] = size
+			if valid(i) {
+				size += srcOffs[offset+i+1] - srcOffs[offset+i]
+			}
+		}
+		offs[n] = size
+
+		values := newBuffer(mem, int(size))
+		dst := values.Bytes()
+		for k, i := range idx {
+			if valid(i) {
+				copy(dst[offs[k]:offs[k+1]], src[srcOffs[offset+i]:srcOffs[offset+i+1]])
+			}
+		}
+		buffers = append(buffers, offsets, values)
+
+	case arrow.BinaryDataType:
+		var (
+			src     = bytesOf(bufs[2])
+			srcOffs = arrow.Int32Traits.CastFromBytes(bufs[1].Bytes())
+			offsets = newBuffer(mem, (n+1)*arrow.Int32SizeBytes)
+			offs    = arrow.Int32Traits.CastFromBytes(offsets.Bytes())
+			size    = 0
+		)
+		for k, i := range idx {
+			offs[k] = int32(size)
+			if valid(i) {
+				size += int(srcOffs[offset+i+1] - srcOffs[offset+i])
+			}
+		}
+		offs[n] = int32(size)
+
+		values := newBuffer(mem, size)
+		dst := values.Bytes()
+		for k, i := range idx {
+			if valid(i) {
+				copy(dst[offs[k]:offs[k+1]], src[srcOffs[offset+i]:srcOffs[offset+i+1]])
+			}
+		}
+		buffers = append(buffers, offsets, values)
+
+	case *arrow.ListType, *arrow.MapType:
+		var (
+			srcOffs  = arrow.Int32Traits.CastFromBytes(bufs[1].Bytes())
+			offsets  = newBuffer(mem, (n+1)*arrow.Int32SizeBytes)
+			offs     = arrow.Int32Traits.CastFromBytes(offsets.Bytes())
+			childIdx []int
+		)
+		for k, i := range idx {
+			offs[k] = int32(len(childIdx))
+			if valid(i) {
+				for j := srcOffs[offset+i]; j < srcOffs[offset+i+1]; j++ {
+					childIdx = append(childIdx, int(j))
+				}
+			}
+		}
+		offs[n] = int32(len(childIdx))
+		buffers = append(buffers, offsets)
+
+		child, err := takeData(mem, data.Children()[0], childIdx)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+
+	case *arrow.LargeListType:
+		var (
+			srcOffs  = arrow.Int64Traits.CastFromBytes(bufs[1].Bytes())
+			offsets  = newBuffer(mem, (n+1)*arrow.Int64SizeBytes)
+			offs     = arrow.Int64Traits.CastFromBytes(offsets.Bytes())
+			childIdx []int
+		)
+		for k, i := range idx {
+			offs[k] = int64(len(childIdx))
+			if valid(i) {
+				for j := srcOffs[offset+i]; j < srcOffs[offset+i+1]; j++ {
+					childIdx = append(childIdx, int(j))
+				}
+			}
+		}
+		offs[n] = int64(len(childIdx))
+		buffers = append(buffers, offsets)
+
+		child, err := takeData(mem, data.Children()[0], childIdx)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+
+	case *arrow.FixedSizeListType:
+		// fixed size list children are not sliced along with their parent.
+		var (
+			size     = int(dt.Len())
+			childIdx = make([]int, 0, n*size)
+		)
+		for _, i := range idx {
+			for j := 0; j < size; j++ {
+				if valid(i) {
+					childIdx = append(childIdx, (offset+i)*size+j)
+				} else {
+					childIdx = append(childIdx, -1)
+				}
+			}
+		}
+
+		child, err := takeData(mem, data.Children()[0], childIdx)
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+
+	case *arrow.UnionType:
+		// null elements are made of a null value of the first field.
+		// union children are not sliced along with their parent.
+		var (
+			srcCodes = arrow.Int8Traits.CastFromBytes(bytesOf(bufs[1]))
+			codes    = newBuffer(mem, n)
+			dst      = arrow.Int8Traits.CastFromBytes(codes.Bytes())
+			childIdx = make([][]int, len(dt.Fields()))
+		)
+		if n > 0 && len(childIdx) == 0 {
+			return nil, fmt.Errorf("arrow/compute: cannot take elements of a union without fields")
+		}
+		buffers = append(buffers, codes)
+
+		switch dt.Mode() {
+		case arrow.SparseMode:
+			for j := range childIdx {
+				childIdx[j] = make([]int, n)
+			}
+			for k, i := range idx {
+				dst[k] = dt.TypeCodes()[0]
+				pos := -1
+				if valid(i) {
+					dst[k] = srcCodes[offset+i]
+					pos = offset + i
+				}
+				for j := range childIdx {
+					childIdx[j][k] = pos
+				}
+			}
+			buffers = append(buffers, nil)
+
+		case arrow.DenseMode:
+			var (
+				srcOffs = arrow.Int32Traits.CastFromBytes(bytesOf(bufs[2]))
+				offsets = newBuffer(mem, n*arrow.Int32SizeBytes)
+				offs    = arrow.Int32Traits.CastFromBytes(offsets.Bytes())
+			)
+			for k, i := range idx {
+				code, pos := dt.TypeCodes()[0], -1
+				if valid(i) {
+					code, pos = srcCodes[offset+i], int(srcOffs[offset+i])
+				}
+				j := dt.ChildID(code)
+				dst[k] = code
+				offs[k] = int32(len(childIdx[j]))
+				childIdx[j] = append(childIdx[j], pos)
+			}
+			buffers = append(buffers, offsets)
+		}
+
+		for j, c := range data.Children() {
+			child, err := takeData(mem, c, childIdx[j])
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+
+	case *arrow.StructType:
+		// struct children are not sliced along with their parent.
+		childIdx := make([]int, n)
+		for k, i := range idx {
+			childIdx[k] = -1
+			if i >= 0 {
+				childIdx[k] = offset + i
+			}
+		}
+		buffers = append(buffers, nil)
+		for _, c := range data.Children() {
+			child, err := takeData(mem, c, childIdx)
+			if err != nil {
+				return nil, err
+			}
+			children = append(children, child)
+		}
+
+	default:
+		return nil, fmt.Errorf("arrow/compute: unsupported data type %s", dt.Name())
+	}
+
+	return array.NewData(data.DataType(), n, buffers, children, nulls, 0), nil
+}
+
+// takeExtension returns the elements of data, an extension array, at the
+// positions idx, taken from its storage.
+func takeExtension(mem memory.Allocator, data *array.Data, dt arrow.ExtensionType, idx []int) (*array.Data, error) {
+	storage := array.NewData(dt.StorageType(), data.Len(), data.Buffers(), data.Children(), data.NullN(), data.Offset())
+	defer storage.Release()
+
+	out, err := takeData(mem, storage, idx)
+	if err != nil {
+		return nil, err
+	}
+	defer out.Release()
+	return array.NewData(dt, out.Len(), out.Buffers(), out.Children(), out.NullN(), 0), nil
+}
+
+// takeRunEndEncoded returns the elements of data, a run-end encoded array,
+// at the positions idx. Consecutive positions within the same run of data
+// result in a single run.
+func takeRunEndEncoded(mem memory.Allocator, data *array.Data, dt *arrow.RunEndEncodedType, idx []int) (*array.Data, error) {
+	n := len(idx)
+	width := dt.RunEnds().(arrow.FixedWidthDataType).BitWidth() / 8
+	if limit := int64(1)<<(8*width-1) - 1; int64(n) > limit {
+		return nil, fmt.Errorf("arrow/compute: %d values do not fit in %s run ends", n, dt.RunEnds().Name())
+	}
+
+	arr := array.NewRunEndEncodedData(data)
+	defer arr.Release()
+
+	var ends, values []int
+	for k, i := range idx {
+		j := -1
+		if i >= 0 {
+			j = arr.PhysicalIndex(i)
+		}
+		if len(values) > 0 && values[len(values)-1] == j {
+			ends[len(ends)-1] = k + 1
+			continue
+		}
+		ends = append(ends, k+1)
+		values = append(values, j)
+	}
+
+	buf := newBuffer(mem, len(ends)*width)
+	defer buf.Release()
+	for j, end := range ends {
+		switch dt.RunEnds().ID() {
+		case arrow.INT16:
+			arrow.Int16Traits.CastFromBytes(buf.Bytes())[j] = int16(end)
+		case arrow.INT32:
+			arrow.Int32Traits.CastFromBytes(buf.Bytes())[j] = int32(end)
+		default:
+			arrow.Int64Traits.CastFromBytes(buf.Bytes())[j] = int64(end)
+		}
+	}
+	runEnds := array.NewData(dt.RunEnds(), len(ends), []*memory.Buffer{nil, buf}, nil, 0, 0)
+	defer runEnds.Release()
+
+	vals, err := takeData(mem, data.Children()[1], values)
+	if err != nil {
+		return nil, err
+	}
+	defer vals.Release()
+
+	return array.NewData(dt, n, []*memory.Buffer{nil}, []*array.Data{runEnds, vals}, 0, 0), nil
+}
+
+func bytesOf(buf *memory.Buffer) []byte {
+	if buf == nil {
+		return nil
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,584 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/json"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// toJSON returns the line-delimited JSON representation of arr.
+func toJSON(t *testing.T, arr array.Interface) string {
+	t.Helper()
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "v", Type: arr.DataType(), Nullable: true}}, nil)
+	rec := array.NewRecord(schema, []array.Interface{arr}, int64(arr.Len()))
+	defer rec.Release()
+
+	var buf bytes.Buffer
+	if err := json.NewWriter(&buf, schema).Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+// fromJSON returns the array of type dt described by the JSON values vs.
+func fromJSON(t *testing.T, mem memory.Allocator, dt arrow.DataType, vs ...string) array.Interface {
+	t.Helper()
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "v", Type: dt, Nullable: true}}, nil)
+	rows := make([]string, len(vs))
+	for i, v := range vs {
+		rows[i] = `{"v":` + v + `}`
+	}
+
+	r := json.NewReader(strings.NewReader(strings.Join(rows, "\n")), schema, json.WithAllocator(mem), json.WithChunk(-1))
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("could not read array: %v", r.Err())
+	}
+	arr := r.Record().Column(0)
+	arr.Retain()
+	return arr
+}
+
+func TestTakeFilter(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	for _, tc := range []struct {
+		name   string
+		dtype  arrow.DataType
+		values []string
+		take   []string // expected values for indices [3, null, 0, 3]
+		filter []string // expected values for mask [true, false, null, true, ...]
+	}{
+		{
+			name:   "null",
+			dtype:  arrow.Null,
+			values: []string{"null", "null", "null", "null"},
+			take:   []string{"null", "null", "null", "null"},
+			filter: []string{"null", "null"},
+		},
+		{
+			name:   "bool",
+			dtype:  arrow.FixedWidthTypes.Boolean,
+			values: []string{"true", "false", "null", "true"},
+			take:   []string{"true", "null", "true", "true"},
+			filter: []string{"true", "true"},
+		},
+		{
+			name:   "int32",
+			dtype:  arrow.PrimitiveTypes.Int32,
+			values: []string{"1", "2", "null", "4"},
+			take:   []string{"4", "null", "1", "4"},
+			filter: []string{"1", "4"},
+		},
+		{
+			name:   "float64",
+			dtype:  arrow.PrimitiveTypes.Float64,
+			values: []string{"1.5", "null", "3", "-4.5"},
+			take:   []string{"-4.5", "null", "1.5", "-4.5"},
+			filter: []string{"1.5", "-4.5"},
+		},
+		{
+			name:   "timestamp",
+			dtype:  &arrow.TimestampType{Unit: arrow.Second},
+			values: []string{`"1970-01-01T00:00:01Z"`, "null", "null", `"1970-01-01T00:00:04Z"`},
+			take:   []string{`"1970-01-01T00:00:04Z"`, "null", `"1970-01-01T00:00:01Z"`, `"1970-01-01T00:00:04Z"`},
+			filter: []string{`"1970-01-01T00:00:01Z"`, `"1970-01-01T00:00:04Z"`},
+		},
+		{
+			name:   "string",
+			dtype:  arrow.BinaryTypes.String,
+			values: []string{`"a"`, `"bc"`, "null", `"def"`},
+			take:   []string{`"def"`, "null", `"a"`, `"def"`},
+			filter: []string{`"a"`, `"def"`},
+		},
+		{
+			name:   "large-string",
+			dtype:  arrow.BinaryTypes.LargeString,
+			values: []string{`"a"`, `"bc"`, "null", `"def"`},
+			take:   []string{`"def"`, "null", `"a"`, `"def"`},
+			filter: []string{`"a"`, `"def"`},
+		},
+		{
+			name:   "large-binary",
+			dtype:  arrow.BinaryTypes.LargeBinary,
+			values: []string{`"AQ=="`, "null", `"AwM="`, `"BAQE"`},
+			take:   []string{`"BAQE"`, "null", `"AQ=="`, `"BAQE"`},
+			filter: []string{`"AQ=="`, `"BAQE"`},
+		},
+		{
+			name:   "string-view",
+			dtype:  arrow.BinaryTypes.StringView,
+			values: []string{`"a"`, `"a string longer than 12 bytes"`, "null", `"another long string"`},
+			take:   []string{`"another long string"`, "null", `"a"`, `"another long string"`},
+			filter: []string{`"a"`, `"another long string"`},
+		},
+		{
+			name:   "fixed-size-binary",
+			dtype:  &arrow.FixedSizeBinaryType{ByteWidth: 1},
+			values: []string{`"AQ=="`, "null", `"Aw=="`, `"BA=="`},
+			take:   []string{`"BA=="`, "null", `"AQ=="`, `"BA=="`},
+			filter: []string{`"AQ=="`, `"BA=="`},
+		},
+		{
+			name:   "list",
+			dtype:  arrow.ListOf(arrow.PrimitiveTypes.Int64),
+			values: []string{"[1,2]", "null", "[]", "[3,null]"},
+			take:   []string{"[3,null]", "null", "[1,2]", "[3,null]"},
+			filter: []string{"[1,2]", "[3,null]"},
+		},
+		{
+			name:   "large-list",
+			dtype:  arrow.LargeListOf(arrow.PrimitiveTypes.Int64),
+			values: []string{"[1,2]", "null", "[]", "[3,null]"},
+			take:   []string{"[3,null]", "null", "[1,2]", "[3,null]"},
+			filter: []string{"[1,2]", "[3,null]"},
+		},
+		{
+			name: "struct",
+			dtype: arrow.StructOf(
+				arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int8, Nullable: true},
+				arrow.Field{Name: "b", Type: arrow.BinaryTypes.String, Nullable: true},
+			),
+			values: []string{`{"a":1,"b":"x"}`, "null", `{"a":null,"b":"y"}`, `{"a":4,"b":null}`},
+			take:   []string{`{"a":4,"b":null}`, "null", `{"a":1,"b":"x"}`, `{"a":4,"b":null}`},
+			filter: []string{`{"a":1,"b":"x"}`, `{"a":4,"b":null}`},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			arr := fromJSON(t, mem, tc.dtype, tc.values...)
+			defer arr.Release()
+
+			indices := fromJSON(t, mem, arrow.PrimitiveTypes.Int64, "3", "null", "0", "3")
+			defer indices.Release()
+
+			got, err := compute.Take(mem, arr, indices)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+			if err := array.ValidateFull(got); err != nil {
+				t.Fatalf("invalid take: %v", err)
+			}
+
+			want := fromJSON(t, mem, tc.dtype, tc.take...)
+			defer want.Release()
+
+			if got, want := toJSON(t, got), toJSON(t, want); got != want {
+				t.Fatalf("invalid take:\ngot= %s\nwant=%s", got, want)
+			}
+
+			mask := fromJSON(t, mem, arrow.FixedWidthTypes.Boolean, "true", "false", "null", "true")
+			defer mask.Release()
+
+			got, err = compute.Filter(mem, arr, mask.(*array.Boolean))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+			if err := array.ValidateFull(got); err != nil {
+				t.Fatalf("invalid filter: %v", err)
+			}
+
+			want = fromJSON(t, mem, tc.dtype, tc.filter...)
+			defer want.Release()
+
+			if got, want := toJSON(t, got), toJSON(t, want); got != want {
+				t.Fatalf("invalid filter:\ngot= %s\nwant=%s", got, want)
+			}
+
+			// a slice of the input.
+			slice := array.NewSlice(arr, 2, 4)
+			defer slice.Release()
+
+			indices = fromJSON(t, mem, arrow.PrimitiveTypes.Uint8, "1", "0")
+			defer indices.Release()
+
+			got, err = compute.Take(mem, slice, indices)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+			if err := array.ValidateFull(got); err != nil {
+				t.Fatalf("invalid take of slice: %v", err)
+			}
+
+			want = fromJSON(t, mem, tc.dtype, tc.values[3], tc.values[2])
+			defer want.Release()
+
+			if got, want := toJSON(t, got), toJSON(t, want); got != want {
+				t.Fatalf("invalid take of slice:\ngot= %s\nwant=%s", got, want)
+			}
+
+			// a filter of the slice.
+			mask = fromJSON(t, mem, arrow.FixedWidthTypes.Boolean, "false", "true")
+			defer mask.Release()
+
+			got, err = compute.Filter(mem, slice, mask.(*array.Boolean))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+			if err := array.ValidateFull(got); err != nil {
+				t.Fatalf("invalid filter of slice: %v", err)
+			}
+
+			want = fromJSON(t, mem, tc.dtype, tc.values[3])
+			defer want.Release()
+
+			if got, want := toJSON(t, got), toJSON(t, want); got != want {
+				t.Fatalf("invalid filter of slice:\ngot= %s\nwant=%s", got, want)
+			}
+		})
+	}
+}
+
+// idType is an extension type storing identifiers as int32 values.
+type idType struct {
+	arrow.ExtensionBase
+}
+
+func (*idType) Name() string            { return "id" }
+func (*idType) ExtensionName() string   { return "arrow.test.id" }
+func (*idType) ArrayType() reflect.Type { return reflect.TypeOf(&idArray{}) }
+func (*idType) Serialize() string       { return "" }
+
+func (*idType) ExtensionEquals(other arrow.ExtensionType) bool {
+	return other.ExtensionName() == "arrow.test.id"
+}
+
+func (*idType) Deserialize(storage arrow.DataType, data string) (arrow.ExtensionType, error) {
+	return &idType{arrow.ExtensionBase{Storage: storage}}, nil
+}
+
+type idArray struct {
+	array.ExtensionArrayBase
+}
+
+// checkTake checks that got holds the elements of arr at the positions idx,
+// negative positions being nulls.
+func checkTake(t *testing.T, got, arr array.Interface, idx []int) {
+	t.Helper()
+
+	if err := array.ValidateFull(got); err != nil {
+		t.Fatalf("invalid output: %v", err)
+	}
+	if got.Len() != len(idx) {
+		t.Fatalf("invalid length: got=%d, want=%d", got.Len(), len(idx))
+	}
+	for k, i := range idx {
+		if i < 0 {
+			if !got.IsNull(k) {
+				t.Fatalf("element %d: got a valid value, want null", k)
+			}
+			continue
+		}
+		g := array.NewSlice(got, int64(k), int64(k+1))
+		w := array.NewSlice(arr, int64(i), int64(i+1))
+		eq := array.ArrayEqual(g, w)
+		g.Release()
+		w.Release()
+		if !eq {
+			t.Fatalf("element %d: got=%v, want element %d of %v", k, got, i, arr)
+		}
+	}
+}
+
+func TestTakeFilterTypes(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	fields := []arrow.Field{
+		{Name: "s", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "i", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	}
+	union := func(dt *arrow.UnionType) array.Interface {
+		b := array.NewUnionBuilder(mem, dt)
+		defer b.Release()
+		sb := b.Child(0).(*array.StringBuilder)
+		ib := b.Child(1).(*array.Int64Builder)
+		codes := dt.TypeCodes()
+		b.Append(codes[1])
+		ib.Append(7)
+		b.Append(codes[0])
+		sb.Append("x")
+		b.AppendNull()
+		b.Append(codes[1])
+		ib.Append(-1)
+		b.Append(codes[0])
+		sb.Append("y")
+		return b.NewArray()
+	}
+
+	for _, tc := range []struct {
+		name  string
+		build func() array.Interface
+	}{
+		{
+			name: "dictionary",
+			build: func() array.Interface {
+				b := array.NewDictionaryBuilder(mem, arrow.DictionaryOf(arrow.PrimitiveTypes.Int16, arrow.BinaryTypes.String))
+				defer b.Release()
+				for _, s := range []string{"a", "b", "", "a", "c"} {
+					if s == "" {
+						b.AppendNull()
+						continue
+					}
+					b.AppendString(s)
+				}
+				return b.NewArray()
+			},
+		},
+		{
+			name: "run-end-encoded",
+			build: func() array.Interface {
+				b := array.NewRunEndEncodedBuilder(mem, arrow.PrimitiveTypes.Int16, arrow.BinaryTypes.String)
+				defer b.Release()
+				vb := b.ValueBuilder().(*array.StringBuilder)
+				b.Append(2)
+				vb.Append("a")
+				b.AppendNull()
+				b.Append(2)
+				vb.Append("b")
+				return b.NewArray()
+			},
+		},
+		{
+			name:  "sparse-union",
+			build: func() array.Interface { return union(arrow.SparseUnionOf(fields, []int8{5, 2})) },
+		},
+		{
+			name:  "dense-union",
+			build: func() array.Interface { return union(arrow.DenseUnionOf(fields, []int8{1, 3})) },
+		},
+		{
+			name: "map",
+			build: func() array.Interface {
+				b := array.NewMapBuilder(mem, arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int32, false)
+				defer b.Release()
+				kb := b.KeyBuilder().(*array.StringBuilder)
+				ib := b.ItemBuilder().(*array.Int32Builder)
+				b.Append(true)
+				kb.AppendValues([]string{"a", "b"}, nil)
+				ib.AppendValues([]int32{1, 0}, []bool{true, false})
+				b.Append(true)
+				b.AppendNull()
+				b.Append(true)
+				kb.Append("c")
+				ib.Append(3)
+				b.Append(true)
+				kb.Append("d")
+				ib.Append(4)
+				return b.NewArray()
+			},
+		},
+		{
+			name: "fixed-size-list",
+			build: func() array.Interface {
+				b := array.NewFixedSizeListBuilder(mem, 2, arrow.PrimitiveTypes.Int32)
+				defer b.Release()
+				vb := b.ValueBuilder().(*array.Int32Builder)
+				b.AppendValues([]bool{true, true})
+				vb.AppendValues([]int32{1, 2, 3, 0}, []bool{true, true, true, false})
+				b.AppendNull()
+				b.AppendValues([]bool{true, true})
+				vb.AppendValues([]int32{5, 6, 7, 8}, nil)
+				return b.NewArray()
+			},
+		},
+		{
+			name: "extension",
+			build: func() array.Interface {
+				b := array.NewInt32Builder(mem)
+				defer b.Release()
+				b.AppendValues([]int32{1, 2, 0, 4, 5}, []bool{true, true, false, true, true})
+				storage := b.NewArray()
+				defer storage.Release()
+				return array.NewExtensionArrayWithStorage(&idType{arrow.ExtensionBase{Storage: arrow.PrimitiveTypes.Int32}}, storage)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			arr := tc.build()
+			defer arr.Release()
+
+			indices := fromJSON(t, mem, arrow.PrimitiveTypes.Int64, "3", "null", "0", "2", "3", "4")
+			defer indices.Release()
+
+			got, err := compute.Take(mem, arr, indices)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+			checkTake(t, got, arr, []int{3, -1, 0, 2, 3, 4})
+
+			mask := fromJSON(t, mem, arrow.FixedWidthTypes.Boolean, "true", "false", "null", "true", "true")
+			defer mask.Release()
+
+			got, err = compute.Filter(mem, arr, mask.(*array.Boolean))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+			checkTake(t, got, arr, []int{0, 3, 4})
+
+			// a slice of the input.
+			slice := array.NewSlice(arr, 1, 5)
+			defer slice.Release()
+
+			indices = fromJSON(t, mem, arrow.PrimitiveTypes.Uint8, "3", "1", "null", "0")
+			defer indices.Release()
+
+			got, err = compute.Take(mem, slice, indices)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+			checkTake(t, got, slice, []int{3, 1, -1, 0})
+
+			mask = fromJSON(t, mem, arrow.FixedWidthTypes.Boolean, "false", "true", "true", "true")
+			defer mask.Release()
+
+			got, err = compute.Filter(mem, slice, mask.(*array.Boolean))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+			checkTake(t, got, slice, []int{1, 2, 3})
+		})
+	}
+}
+
+func TestTakeFilterRecord(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "i64", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2, 3}, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues([]string{"a", "", "c"}, []bool{true, false, true})
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	indices := fromJSON(t, mem, arrow.PrimitiveTypes.Int32, "2", "1")
+	defer indices.Release()
+
+	got, err := compute.TakeRecord(mem, rec, indices)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if got, want := got.NumRows(), int64(2); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+	if got, want := got.Column(0).(*array.Int64).Int64Values(), []int64{3, 2}; got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("invalid column 0: got=%v, want=%v", got, want)
+	}
+	if got := got.Column(1).(*array.String); got.Value(0) != "c" || !got.IsNull(1) {
+		t.Fatalf("invalid column 1: got=%v", got)
+	}
+
+	mask := fromJSON(t, mem, arrow.FixedWidthTypes.Boolean, "false", "true", "true")
+	defer mask.Release()
+
+	filtered, err := compute.FilterRecord(mem, rec, mask.(*array.Boolean))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer filtered.Release()
+
+	if got, want := filtered.NumRows(), int64(2); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+	if got, want := filtered.Column(0).(*array.Int64).Int64Values(), []int64{2, 3}; got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("invalid column 0: got=%v, want=%v", got, want)
+	}
+}
+
+func TestTakeFilterErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	arr := fromJSON(t, mem, arrow.PrimitiveTypes.Int32, "1", "2")
+	defer arr.Release()
+
+	for _, tc := range []struct {
+		name    string
+		indices array.Interface
+		err     string
+	}{
+		{
+			name:    "out-of-bounds",
+			indices: fromJSON(t, mem, arrow.PrimitiveTypes.Int64, "0", "2"),
+			err:     "arrow/compute: index at position 1 out of bounds [0, 2)",
+		},
+		{
+			name:    "negative",
+			indices: fromJSON(t, mem, arrow.PrimitiveTypes.Int8, "-1"),
+			err:     "arrow/compute: index at position 0 out of bounds [0, 2)",
+		},
+		{
+			name:    "overflow",
+			indices: fromJSON(t, mem, arrow.PrimitiveTypes.Uint64, "18446744073709551615"),
+			err:     "arrow/compute: index at position 0 out of bounds [0, 2)",
+		},
+		{
+			name:    "invalid-type",
+			indices: fromJSON(t, mem, arrow.PrimitiveTypes.Float64, "0"),
+			err:     "arrow/compute: invalid indices type float64",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer tc.indices.Release()
+			_, err := compute.Take(mem, arr, tc.indices)
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("invalid error: got=%v, want=%s", err, tc.err)
+			}
+		})
+	}
+
+	mask := fromJSON(t, mem, arrow.FixedWidthTypes.Boolean, "true")
+	defer mask.Release()
+
+	_, err := compute.Filter(mem, arr, mask.(*array.Boolean))
+	if got, want := err, "arrow/compute: mask length mismatch (got=1, want=2)"; got == nil || got.Error() != want {
+		t.Fatalf("invalid error: got=%v, want=%s", got, want)
+	}
+}
@@ -0,0 +1,239 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// SortOrder specifies the order in which values are sorted.
+type SortOrder int
+
+const (
+	Ascending SortOrder = iota
+	Descending
+)
+
+// NullPlacement specifies where null values are placed in a sorted output.
+type NullPlacement int
+
+const (
+	NullsAtEnd NullPlacement = iota
+	NullsAtStart
+)
+
+// SortKey describes one of the columns a record is sorted by.
+type SortKey struct {
+	Name  string        // name of the column
+	Order SortOrder     // order of the sort
+	Nulls NullPlacement // placement of the null values
+}
+
+type sortConfig struct {
+	order SortOrder
+	nulls NullPlacement
+}
+
+// WithSortOrder specifies the order used by SortIndices.
+// The default is Ascending.
+func WithSortOrder(order SortOrder) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *sortConfig:
+			cfg.order = order
+		default:
+			panic(fmt.Errorf("arrow/compute: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithNullPlacement specifies where SortIndices places null values.
+// The default is NullsAtEnd.
+func WithNullPlacement(nulls NullPlacement) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *sortConfig:
+			cfg.nulls = nulls
+		default:
+			panic(fmt.Errorf("arrow/compute: unknown config type %T", cfg))
+		}
+	}
+}
+
+// SortIndices returns the indices that would sort arr, suitable for Take.
+// The sort is stable: equal values keep their relative order.
+// NaN values are considered greater than any other floating point value.
+func SortIndices(mem memory.Allocator, arr array.Interface, opts ...Option) (*array.Uint64, error) {
+	cfg := &sortConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	cmp, err := comparer(arr, SortKey{Order: cfg.order, Nulls: cfg.nulls})
+	if err != nil {
+		return nil, err
+	}
+	return sortIndices(mem, arr.Len(), []func(i, j int) int{cmp}), nil
+}
+
+// SortRecord returns the indices that would sort rec, suitable for
+// TakeRecord. Rows are ordered by the first key, then ties are broken by the
+// following keys.
+// The sort is stable: equal rows keep their relative order.
+func SortRecord(mem memory.Allocator, rec array.Record, keys []SortKey) (*array.Uint64, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("arrow/compute: no sort keys")
+	}
+
+	cmps := make([]func(i, j int) int, len(keys))
+	for k, key := range keys {
+		idx := rec.Schema().FieldIndex(key.Name)
+		if idx < 0 {
+			return nil, fmt.Errorf("arrow/compute: no column named %q", key.Name)
+		}
+		cmp, err := comparer(rec.Column(idx), key)
+		if err != nil {
+			return nil, fmt.Errorf("arrow/compute: column %q: %v", key.Name, err)
+		}
+		cmps[k] = cmp
+	}
+	return sortIndices(mem, int(rec.NumRows()), cmps), nil
+}
+
+func sortIndices(mem memory.Allocator, n int, cmps []func(i, j int) int) *array.Uint64 {
+	idx := make([]uint64, n)
+	for i := range idx {
+		idx[i] = uint64(i)
+	}
+
+	sort.SliceStable(idx, func(i, j int) bool {
+		for _, cmp := range cmps {
+			if c := cmp(int(idx[i]), int(idx[j])); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+
+	bld := array.NewUint64Builder(mem)
+	defer bld.Release()
+	bld.AppendValues(idx, nil)
+	return bld.NewUint64Array()
+}
+
+// comparer returns a function comparing the elements i and j of arr
+// according to key.
+func comparer(arr array.Interface, key SortKey) (func(i, j int) int, error) {
+	if categoryOf(arr.DataType()) == catNull {
+		return func(i, j int) int { return 0 }, nil
+	}
+
+	cmp, err := valueComparer(arr.DataType())
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		get   = getter(arr)
+		order = 1
+		nulls = 1
+	)
+	if key.Order == Descending {
+		order = -1
+	}
+	if key.Nulls == NullsAtStart {
+		nulls = -1
+	}
+
+	return func(i, j int) int {
+		in, jn := arr.IsNull(i), arr.IsNull(j)
+		switch {
+		case in && jn:
+			return 0
+		case in:
+			return nulls
+		case jn:
+			return -nulls
+		}
+		return order * cmp(get(i), get(j))
+	}, nil
+}
+
+// valueComparer returns a function comparing two values of type dt.
+func valueComparer(dt arrow.DataType) (func(a, b value) int, error) {
+	switch categoryOf(dt) {
+	case catBool:
+		return func(a, b value) int {
+			switch {
+			case a.b == b.b:
+				return 0
+			case b.b:
+				return -1
+			}
+			return +1
+		}, nil
+	case catInt, catTemporal:
+		return func(a, b value) int {
+			switch {
+			case a.i < b.i:
+				return -1
+			case a.i > b.i:
+				return +1
+			}
+			return 0
+		}, nil
+	case catUint:
+		return func(a, b value) int {
+			switch {
+			case a.u < b.u:
+				return -1
+			case a.u > b.u:
+				return +1
+			}
+			return 0
+		}, nil
+	case catFloat:
+		return func(a, b value) int {
+			an, bn := math.IsNaN(a.f), math.IsNaN(b.f)
+			switch {
+			case an || bn:
+				switch {
+				case an && bn:
+					return 0
+				case an:
+					return +1
+				}
+				return -1
+			case a.f < b.f:
+				return -1
+			case a.f > b.f:
+				return +1
+			}
+			return 0
+		}, nil
+	case catString, catBinary, catFixedSizeBinary:
+		return func(a, b value) int { return bytes.Compare(a.s, b.s) }, nil
+	}
+	return nil, fmt.Errorf("arrow/compute: unsupported data type %s for comparison", dt.Name())
+}
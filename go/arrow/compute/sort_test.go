@@ -0,0 +1,195 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestSortIndices(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	for _, tc := range []struct {
+		name  string
+		dtype arrow.DataType
+		vals  []string
+		opts  []compute.Option
+		want  []uint64
+	}{
+		{
+			name:  "int32",
+			dtype: arrow.PrimitiveTypes.Int32,
+			vals:  []string{"3", "null", "-1", "3", "0"},
+			want:  []uint64{2, 4, 0, 3, 1},
+		},
+		{
+			name:  "int32-desc",
+			dtype: arrow.PrimitiveTypes.Int32,
+			vals:  []string{"3", "null", "-1", "3", "0"},
+			opts:  []compute.Option{compute.WithSortOrder(compute.Descending)},
+			want:  []uint64{0, 3, 4, 2, 1},
+		},
+		{
+			name:  "int32-nulls-first",
+			dtype: arrow.PrimitiveTypes.Int32,
+			vals:  []string{"3", "null", "-1", "3", "0"},
+			opts:  []compute.Option{compute.WithNullPlacement(compute.NullsAtStart)},
+			want:  []uint64{1, 2, 4, 0, 3},
+		},
+		{
+			name:  "uint64",
+			dtype: arrow.PrimitiveTypes.Uint64,
+			vals:  []string{"18446744073709551615", "0", "1"},
+			want:  []uint64{1, 2, 0},
+		},
+		{
+			name:  "float64",
+			dtype: arrow.PrimitiveTypes.Float64,
+			vals:  []string{"1.5", "-2", "null", "0"},
+			want:  []uint64{1, 3, 0, 2},
+		},
+		{
+			name:  "bool",
+			dtype: arrow.FixedWidthTypes.Boolean,
+			vals:  []string{"true", "false", "null", "false"},
+			want:  []uint64{1, 3, 0, 2},
+		},
+		{
+			name:  "string",
+			dtype: arrow.BinaryTypes.String,
+			vals:  []string{`"b"`, `"ab"`, `""`, `"a"`},
+			opts:  []compute.Option{compute.WithSortOrder(compute.Descending)},
+			want:  []uint64{0, 1, 3, 2},
+		},
+		{
+			name:  "timestamp",
+			dtype: &arrow.TimestampType{Unit: arrow.Second},
+			vals:  []string{`"2019-01-01T00:00:00Z"`, `"1969-01-01T00:00:00Z"`},
+			want:  []uint64{1, 0},
+		},
+		{
+			name:  "null",
+			dtype: arrow.Null,
+			vals:  []string{"null", "null"},
+			want:  []uint64{0, 1},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			arr := fromJSON(t, mem, tc.dtype, tc.vals...)
+			defer arr.Release()
+
+			got, err := compute.SortIndices(mem, arr, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			if got := got.Uint64Values(); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid indices: got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSortIndicesNaN(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	b := array.NewFloat64Builder(mem)
+	defer b.Release()
+
+	b.AppendValues([]float64{math.NaN(), 1, 0}, nil)
+	b.AppendNull()
+	arr := b.NewFloat64Array()
+	defer arr.Release()
+
+	got, err := compute.SortIndices(mem, arr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if got, want := got.Uint64Values(), []uint64{2, 1, 0, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid indices: got=%v, want=%v", got, want)
+	}
+}
+
+func TestSortRecord(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "k", Type: arrow.BinaryTypes.String, Nullable: true},
+			{Name: "v", Type: arrow.PrimitiveTypes.Int64},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.StringBuilder).AppendValues([]string{"b", "a", "", "b", "a"}, []bool{true, true, false, true, true})
+	b.Field(1).(*array.Int64Builder).AppendValues([]int64{1, 2, 3, 4, 5}, nil)
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	idx, err := compute.SortRecord(mem, rec, []compute.SortKey{
+		{Name: "k", Nulls: compute.NullsAtStart},
+		{Name: "v", Order: compute.Descending},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer idx.Release()
+
+	if got, want := idx.Uint64Values(), []uint64{2, 4, 1, 3, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid indices: got=%v, want=%v", got, want)
+	}
+
+	sorted, err := compute.TakeRecord(mem, rec, idx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sorted.Release()
+
+	if got, want := sorted.Column(1).(*array.Int64).Int64Values(), []int64{3, 5, 2, 4, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid sorted record: got=%v, want=%v", got, want)
+	}
+
+	for _, tc := range []struct {
+		keys []compute.SortKey
+		err  string
+	}{
+		{keys: nil, err: "arrow/compute: no sort keys"},
+		{keys: []compute.SortKey{{Name: "x"}}, err: `arrow/compute: no column named "x"`},
+	} {
+		_, err := compute.SortRecord(mem, rec, tc.keys)
+		if err == nil || err.Error() != tc.err {
+			t.Fatalf("invalid error: got=%v, want=%s", err, tc.err)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/bitutil"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// The string kernels operate on String and LargeString arrays. They read the
+// values in place and write their results directly to the buffers of the
+// output; null values stay null.
+
+type matchConfig struct {
+	ignoreCase bool
+}
+
+// WithIgnoreCase specifies whether MatchSubstring compares the strings and the
+// pattern ignoring their case. The default is false.
+func WithIgnoreCase(v bool) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *matchConfig:
+			cfg.ignoreCase = v
+		default:
+			panic(fmt.Errorf("arrow/compute: unknown config type %T", cfg))
+		}
+	}
+}
+
+// Upper returns the strings of arr converted to upper case.
+func Upper(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+	return mapStrings(mem, arr, "upper", func(dst []byte, s string) []byte {
+		return appendMapped(dst, s, 'a', 'z', unicode.ToUpper)
+	})
+}
+
+// Lower returns the strings of arr converted to lower case.
+func Lower(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+	return mapStrings(mem, arr, "lower", func(dst []byte, s string) []byte {
+		return appendMapped(dst, s, 'A', 'Z', unicode.ToLower)
+	})
+}
+
+// TrimSpace returns the strings of arr without their leading and trailing
+// white space, as defined by Unicode.
+func TrimSpace(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+	return mapStrings(mem, arr, "trim_space", func(dst []byte, s string) []byte {
+		return append(dst, strings.TrimSpace(s)...)
+	})
+}
+
+// Trim returns the strings of arr without their leading and trailing
+// characters contained in cutset.
+func Trim(mem memory.Allocator, arr array.Interface, cutset string) (array.Interface, error) {
+	return mapStrings(mem, arr, "trim", func(dst []byte, s string) []byte {
+		return append(dst, strings.Trim(s, cutset)...)
+	})
+}
+
+// MatchSubstring returns whether each string of arr contains pattern.
+// With WithIgnoreCase, the strings and pattern are compared after their
+// conversion to lower case.
+func MatchSubstring(mem memory.Allocator, arr array.Interface, pattern string, opts ...Option) (*array.Boolean, error) {
+	cfg := &matchConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	match := func(s string) bool { return strings.Contains(s, pattern) }
+	if cfg.ignoreCase {
+		var (
+			lower = []byte(strings.ToLower(pattern))
+			buf   []byte
+		)
+		match = func(s string) bool {
+			buf = appendMapped(buf[:0], s, 'A', 'Z', unicode.ToLower)
+			return bytes.Contains(buf, lower)
+		}
+	}
+	return matchStrings(mem, arr, "match_substring", match)
+}
+
+// MatchRegex returns whether each string of arr contains a match of re.
+func MatchRegex(mem memory.Allocator, arr array.Interface, re *regexp.Regexp) (*array.Boolean, error) {
+	return matchStrings(mem, arr, "match_regex", re.MatchString)
+}
+
+// ExtractRegex returns a struct array with one field per named group of re,
+// holding the text each group matched in the first match of re in the
+// strings of arr. The fields have the type of arr. An element of the output is
+// null if its string is null or does not match re.
+//
+// ExtractRegex returns an error if re has no groups, or has unnamed groups.
+func ExtractRegex(mem memory.Allocator, arr array.Interface, re *regexp.Regexp) (*array.Struct, error) {
+	strs, err := stringsOf(arr, "extract_regex")
+	if err != nil {
+		return nil, err
+	}
+
+	names := re.SubexpNames()[1:]
+	if len(names) == 0 {
+		return nil, fmt.Errorf("arrow/compute: extract_regex pattern %q has no groups", re.String())
+	}
+	fields := make([]arrow.Field, len(names))
+	for k, name := range names {
+		if name == "" {
+			return nil, fmt.Errorf("arrow/compute: extract_regex pattern %q has an unnamed group", re.String())
+		}
+		fields[k] = arrow.Field{Name: name, Type: arr.DataType(), Nullable: true}
+	}
+
+	type stringBuilder interface {
+		array.Builder
+		Append(string)
+	}
+	var (
+		n     = strs.Len()
+		blds  = make([]stringBuilder, len(names))
+		valid = newBuffer(mem, bitutil.CeilByte(n)/8)
+		nulls = 0
+	)
+	defer valid.Release()
+	for k := range blds {
+		blds[k] = array.NewBuilder(mem, arr.DataType()).(stringBuilder)
+		defer blds[k].Release()
+		blds[k].Reserve(n)
+	}
+
+	for i := 0; i < n; i++ {
+		var m []int
+		if strs.IsValid(i) {
+			m = re.FindStringSubmatchIndex(strs.Value(i))
+		}
+		if m == nil {
+			nulls++
+			for _, b := range blds {
+				b.AppendNull()
+			}
+			continue
+		}
+
+		bitutil.SetBit(valid.Bytes(), i)
+		s := strs.Value(i)
+		for k, b := range blds {
+			beg, end := m[2*k+2], m[2*k+3]
+			if beg < 0 {
+				// the group did not participate in the match.
+				b.Append("")
+				continue
+			}
+			b.Append(s[beg:end])
+		}
+	}
+
+	children := make([]*array.Data, len(blds))
+	for k, b := range blds {
+		child := b.NewArray()
+		defer child.Release()
+		children[k] = child.Data()
+	}
+	data := array.NewData(arrow.StructOf(fields...), n, []*memory.Buffer{valid}, children, nulls, 0)
+	defer data.Release()
+	return array.NewStructData(data), nil
+}
+
+// stringArray is implemented by String and LargeString arrays.
+type stringArray interface {
+	array.Interface
+	Value(i int) string
+}
+
+func stringsOf(arr array.Interface, kernel string) (stringArray, error) {
+	switch arr := arr.(type) {
+	case *array.String:
+		return arr, nil
+	case *array.LargeString:
+		return arr, nil
+	}
+	return nil, fmt.Errorf("arrow/compute: unsupported data type %s for %s", arr.DataType().Name(), kernel)
+}
+
+// mapStrings returns the array of the type of arr holding the results of fn,
+// which appends the transformation of s to dst, for each string of arr.
+func mapStrings(mem memory.Allocator, arr array.Interface, kernel string, fn func(dst []byte, s string) []byte) (array.Interface, error) {
+	strs, err := stringsOf(arr, kernel)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		n    = strs.Len()
+		size = 0
+	)
+	for i := 0; i < n; i++ {
+		size += len(strs.Value(i))
+	}
+
+	var (
+		values = make([]byte, 0, size)
+		ends   = make([]int, n)
+	)
+	for i := 0; i < n; i++ {
+		if strs.IsValid(i) {
+			values = fn(values, strs.Value(i))
+		}
+		ends[i] = len(values)
+	}
+
+	large := arr.DataType().ID() == arrow.LARGE_STRING
+	if !large && len(values) > math.MaxInt32 {
+		return nil, fmt.Errorf("arrow/compute: %s result of %d bytes overflows %s offsets", kernel, len(values), arr.DataType().Name())
+	}
+
+	var offsets *memory.Buffer
+	if large {
+		offsets = newBuffer(mem, (n+1)*arrow.Int64SizeBytes)
+		offs := arrow.Int64Traits.CastFromBytes(offsets.Bytes())
+		for i, end := range ends {
+			offs[i+1] = int64(end)
+		}
+	} else {
+		offsets = newBuffer(mem, (n+1)*arrow.Int32SizeBytes)
+		offs := arrow.Int32Traits.CastFromBytes(offsets.Bytes())
+		for i, end := range ends {
+			offs[i+1] = int32(end)
+		}
+	}
+	defer offsets.Release()
+
+	data := newBuffer(mem, len(values))
+	defer data.Release()
+	copy(data.Bytes(), values)
+
+	validity := copyValidity(mem, arr)
+	if validity != nil {
+		defer validity.Release()
+	}
+
+	out := array.NewData(arr.DataType(), n, []*memory.Buffer{validity, offsets, data}, nil, arr.NullN(), 0)
+	defer out.Release()
+	return array.MakeFromData(out), nil
+}
+
+// matchStrings returns the boolean array of the results of match for each
+// string of arr.
+func matchStrings(mem memory.Allocator, arr array.Interface, kernel string, match func(s string) bool) (*array.Boolean, error) {
+	strs, err := stringsOf(arr, kernel)
+	if err != nil {
+		return nil, err
+	}
+
+	n := strs.Len()
+	values := newBuffer(mem, bitutil.CeilByte(n)/8)
+	defer values.Release()
+	for i := 0; i < n; i++ {
+		if strs.IsValid(i) && match(strs.Value(i)) {
+			bitutil.SetBit(values.Bytes(), i)
+		}
+	}
+
+	validity := copyValidity(mem, arr)
+	if validity != nil {
+		defer validity.Release()
+	}
+
+	out := array.NewData(arrow.FixedWidthTypes.Boolean, n, []*memory.Buffer{validity, values}, nil, arr.NullN(), 0)
+	defer out.Release()
+	return array.NewBooleanData(out), nil
+}
+
+// copyValidity returns the validity bitmap of arr, without its offset, or nil
+// if arr has no nulls.
+func copyValidity(mem memory.Allocator, arr array.Interface) *memory.Buffer {
+	if arr.NullN() == 0 {
+		return nil
+	}
+	buf := newBuffer(mem, bitutil.CeilByte(arr.Len())/8)
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsValid(i) {
+			bitutil.SetBit(buf.Bytes(), i)
+		}
+	}
+	return buf
+}
+
+// appendMapped appends s to dst with its runes mapped by fn. ASCII runes are
+// mapped by shifting the bytes between lo and hi, and invalid UTF-8 bytes are
+// copied as is.
+func appendMapped(dst []byte, s string, lo, hi byte, fn func(rune) rune) []byte {
+	const shift = 'a' - 'A'
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			if lo <= c && c <= hi {
+				if lo == 'a' {
+					c -= shift
+				} else {
+					c += shift
+				}
+			}
+			dst = append(dst, c)
+			i++
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, c)
+			i++
+			continue
+		}
+		dst = utf8.AppendRune(dst, fn(r))
+		i += size
+	}
+	return dst
+}
@@ -0,0 +1,234 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func TestStringTransforms(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	for _, tc := range []struct {
+		name   string
+		kernel func(mem memory.Allocator, arr array.Interface) (array.Interface, error)
+		in     []string
+		want   []string
+	}{
+		{
+			name: "upper", kernel: compute.Upper,
+			in:   []string{`"hello, World"`, "null", `"straße ǆ"`, `""`},
+			want: []string{`"HELLO, WORLD"`, "null", `"STRAßE Ǆ"`, `""`},
+		},
+		{
+			name: "lower", kernel: compute.Lower,
+			in:   []string{`"Hello, WORLD"`, `"ÀÉÎ"`, "null"},
+			want: []string{`"hello, world"`, `"àéî"`, "null"},
+		},
+		{
+			name: "trim-space", kernel: compute.TrimSpace,
+			in:   []string{`"  a b \t"`, "null", `" c\n"`, `"   "`},
+			want: []string{`"a b"`, "null", `"c"`, `""`},
+		},
+		{
+			name: "trim", kernel: func(mem memory.Allocator, arr array.Interface) (array.Interface, error) {
+				return compute.Trim(mem, arr, "-_")
+			},
+			in:   []string{`"--a-b__"`, "null", `"-_-"`},
+			want: []string{`"a-b"`, "null", `""`},
+		},
+	} {
+		for _, dt := range []arrow.DataType{arrow.BinaryTypes.String, arrow.BinaryTypes.LargeString} {
+			t.Run(tc.name+"-"+dt.Name(), func(t *testing.T) {
+				in := fromJSON(t, mem, dt, tc.in...)
+				defer in.Release()
+
+				got, err := tc.kernel(mem, in)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer got.Release()
+
+				want := fromJSON(t, mem, dt, tc.want...)
+				defer want.Release()
+
+				if got, want := toJSON(t, got), toJSON(t, want); got != want {
+					t.Fatalf("invalid result:\ngot= %s\nwant=%s", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestStringMatch(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	in := []string{`"Arrow"`, "null", `"sparrow"`, `"ARROWS"`, `"go"`, `""`}
+	for _, tc := range []struct {
+		name   string
+		kernel func(mem memory.Allocator, arr array.Interface) (*array.Boolean, error)
+		want   []string
+	}{
+		{
+			name: "substring",
+			kernel: func(mem memory.Allocator, arr array.Interface) (*array.Boolean, error) {
+				return compute.MatchSubstring(mem, arr, "rrow")
+			},
+			want: []string{"true", "null", "true", "false", "false", "false"},
+		},
+		{
+			name: "substring-ignore-case",
+			kernel: func(mem memory.Allocator, arr array.Interface) (*array.Boolean, error) {
+				return compute.MatchSubstring(mem, arr, "rRoW", compute.WithIgnoreCase(true))
+			},
+			want: []string{"true", "null", "true", "true", "false", "false"},
+		},
+		{
+			name: "substring-empty",
+			kernel: func(mem memory.Allocator, arr array.Interface) (*array.Boolean, error) {
+				return compute.MatchSubstring(mem, arr, "")
+			},
+			want: []string{"true", "null", "true", "true", "true", "true"},
+		},
+		{
+			name: "regex",
+			kernel: func(mem memory.Allocator, arr array.Interface) (*array.Boolean, error) {
+				return compute.MatchRegex(mem, arr, regexp.MustCompile(`^[A-Z][a-z]+$`))
+			},
+			want: []string{"true", "null", "false", "false", "false", "false"},
+		},
+	} {
+		for _, dt := range []arrow.DataType{arrow.BinaryTypes.String, arrow.BinaryTypes.LargeString} {
+			t.Run(tc.name+"-"+dt.Name(), func(t *testing.T) {
+				arr := fromJSON(t, mem, dt, in...)
+				defer arr.Release()
+
+				got, err := tc.kernel(mem, arr)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer got.Release()
+
+				want := fromJSON(t, mem, arrow.FixedWidthTypes.Boolean, tc.want...)
+				defer want.Release()
+
+				if got, want := toJSON(t, got), toJSON(t, want); got != want {
+					t.Fatalf("invalid result:\ngot= %s\nwant=%s", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestExtractRegex(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	arr := fromJSON(t, mem, arrow.BinaryTypes.String,
+		`"key=a"`, "null", `"no match"`, `"key=b;x"`, `"key="`,
+	)
+	defer arr.Release()
+
+	re := regexp.MustCompile(`(?P<key>key)=(?P<value>[a-z]*)(?P<rest>;.*)?`)
+	got, err := compute.ExtractRegex(mem, arr, re)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	dt := arrow.StructOf(
+		arrow.Field{Name: "key", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "value", Type: arrow.BinaryTypes.String, Nullable: true},
+		arrow.Field{Name: "rest", Type: arrow.BinaryTypes.String, Nullable: true},
+	)
+	want := fromJSON(t, mem, dt,
+		`{"key":"key","value":"a","rest":""}`,
+		"null",
+		"null",
+		`{"key":"key","value":"b","rest":";x"}`,
+		`{"key":"key","value":"","rest":""}`,
+	)
+	defer want.Release()
+
+	if got, want := toJSON(t, got), toJSON(t, want); got != want {
+		t.Fatalf("invalid result:\ngot= %s\nwant=%s", got, want)
+	}
+}
+
+func TestStringErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	str := fromJSON(t, mem, arrow.BinaryTypes.String, `"a"`)
+	defer str.Release()
+	i32 := fromJSON(t, mem, arrow.PrimitiveTypes.Int32, "1")
+	defer i32.Release()
+
+	for _, tc := range []struct {
+		name string
+		fn   func() error
+		err  string
+	}{
+		{
+			name: "upper-int32",
+			fn: func() error {
+				_, err := compute.Upper(mem, i32)
+				return err
+			},
+			err: "arrow/compute: unsupported data type int32 for upper",
+		},
+		{
+			name: "match-substring-int32",
+			fn: func() error {
+				_, err := compute.MatchSubstring(mem, i32, "a")
+				return err
+			},
+			err: "arrow/compute: unsupported data type int32 for match_substring",
+		},
+		{
+			name: "extract-no-groups",
+			fn: func() error {
+				_, err := compute.ExtractRegex(mem, str, regexp.MustCompile(`a`))
+				return err
+			},
+			err: `arrow/compute: extract_regex pattern "a" has no groups`,
+		},
+		{
+			name: "extract-unnamed-group",
+			fn: func() error {
+				_, err := compute.ExtractRegex(mem, str, regexp.MustCompile(`(?P<x>a)(b)?`))
+				return err
+			},
+			err: `arrow/compute: extract_regex pattern "(?P<x>a)(b)?" has an unnamed group`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.fn()
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("invalid error:\ngot= %v\nwant=%s", err, tc.err)
+			}
+		})
+	}
+}
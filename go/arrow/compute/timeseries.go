@@ -0,0 +1,784 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// WindowReader splits the records of a reader, sorted on a timestamp
+// column, into time windows.
+//
+// Windows span size and start at the multiples of step since the epoch,
+// in the unit of the timestamps: windows are tumbling when step equals
+// size, and sliding, one row appearing in several windows, when step is
+// smaller than size. Empty windows are skipped.
+type WindowReader struct {
+	refs int64
+	mem  memory.Allocator
+	rr   array.RecordReader
+	key  sortedKey
+
+	size, step int64
+
+	buf   recordBuffer // buffered rows, not before start
+	ts    []int64      // keys of the buffered rows
+	start int64        // start of the next window
+	begun bool
+	eof   bool
+
+	cur          array.Record
+	cstart, cend arrow.Timestamp
+	err          error
+}
+
+// NewWindowReader returns a reader of the windows of size size, starting
+// every step, of the records of rr. The rows of rr must be sorted on key,
+// a timestamp column without nulls.
+// The returned reader retains rr until it is released.
+func NewWindowReader(mem memory.Allocator, rr array.RecordReader, key string, size, step time.Duration) (*WindowReader, error) {
+	k, err := newSortedKey(rr.Schema(), key)
+	if err != nil {
+		return nil, err
+	}
+	unit := rr.Schema().Field(k.col).Type.(*arrow.TimestampType).Unit
+	sz, err := ticks("window size", size, unit)
+	if err != nil {
+		return nil, err
+	}
+	st, err := ticks("window step", step, unit)
+	if err != nil {
+		return nil, err
+	}
+
+	rr.Retain()
+	return &WindowReader{
+		refs: 1,
+		mem:  mem,
+		rr:   rr,
+		key:  k,
+		size: sz,
+		step: st,
+		buf:  recordBuffer{schema: rr.Schema()},
+	}, nil
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (r *WindowReader) Retain() {
+	atomic.AddInt64(&r.refs, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+// Release may be called simultaneously from multiple goroutines.
+func (r *WindowReader) Release() {
+	debug.Assert(atomic.LoadInt64(&r.refs) > 0, "too many releases")
+
+	if atomic.AddInt64(&r.refs, -1) == 0 {
+		if r.cur != nil {
+			r.cur.Release()
+			r.cur = nil
+		}
+		r.buf.release()
+		r.rr.Release()
+	}
+}
+
+func (r *WindowReader) Schema() *arrow.Schema { return r.rr.Schema() }
+
+// Record returns the rows of the current window.
+// The returned record is owned by the reader and is only valid until the
+// next call to Next.
+func (r *WindowReader) Record() array.Record { return r.cur }
+
+// Window returns the bounds of the current window: it holds the rows whose
+// key is in [start, end).
+func (r *WindowReader) Window() (start, end arrow.Timestamp) { return r.cstart, r.cend }
+
+// Err returns the error that stopped the reader, if any.
+func (r *WindowReader) Err() error { return r.err }
+
+// Next moves to the next non-empty window. It returns false when the
+// records of the underlying reader are exhausted, or on error.
+func (r *WindowReader) Next() bool {
+	if r.cur != nil {
+		r.cur.Release()
+		r.cur = nil
+	}
+
+	// drop the rows before the next window, skipping empty windows.
+	for {
+		for len(r.ts) == 0 {
+			if !r.read() {
+				return false
+			}
+		}
+		if first := r.firstWindow(r.ts[0]); !r.begun || first > r.start {
+			r.start, r.begun = first, true
+		}
+		n := sort.Search(len(r.ts), func(i int) bool { return r.ts[i] >= r.start })
+		if n == 0 {
+			break
+		}
+		r.drop(n)
+	}
+
+	end := r.start + r.size
+	for r.ts[len(r.ts)-1] < end && r.read() {
+	}
+	if r.err != nil {
+		return false
+	}
+
+	n := sort.Search(len(r.ts), func(i int) bool { return r.ts[i] >= end })
+	cur, err := r.buf.slice(r.mem, 0, n)
+	if err != nil {
+		r.err = err
+		return false
+	}
+	r.cur = cur
+	r.cstart, r.cend = arrow.Timestamp(r.start), arrow.Timestamp(end)
+
+	r.start += r.step
+	return true
+}
+
+// firstWindow returns the start of the first window holding t.
+func (r *WindowReader) firstWindow(t int64) int64 {
+	v := t - r.size
+	k := v / r.step
+	if v%r.step != 0 && v < 0 {
+		k--
+	}
+	return (k + 1) * r.step
+}
+
+// read appends the next record of the underlying reader to the buffer.
+// It returns false when the reader is exhausted, or on error.
+func (r *WindowReader) read() bool {
+	if r.eof || r.err != nil {
+		return false
+	}
+	if !r.rr.Next() {
+		r.eof, r.err = true, readerErr(r.rr)
+		return false
+	}
+
+	rec := r.rr.Record()
+	ts, err := r.key.values(rec)
+	if err != nil {
+		r.err = err
+		return false
+	}
+	if len(ts) == 0 {
+		return true
+	}
+
+	r.buf.push(rec)
+	r.ts = append(r.ts, ts...)
+	return true
+}
+
+// drop removes the first n buffered rows.
+func (r *WindowReader) drop(n int) {
+	if n == 0 {
+		return
+	}
+	r.buf.drop(n)
+	r.ts = r.ts[:copy(r.ts, r.ts[n:])]
+}
+
+// WithTolerance specifies the largest distance between the keys of the rows
+// matched by AsOfJoin. By default, rows are matched however far apart
+// their keys are.
+func WithTolerance(d time.Duration) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *asOfConfig:
+			cfg.tolerance, cfg.bounded = d, true
+		default:
+			panic(fmt.Errorf("arrow/compute: unknown config type %T", cfg))
+		}
+	}
+}
+
+type asOfConfig struct {
+	tolerance time.Duration
+	bounded   bool
+}
+
+// AsOfJoinReader joins each row of a left reader with the last row of a
+// right reader whose key is not after the key of the left row.
+type AsOfJoinReader struct {
+	refs        int64
+	mem         memory.Allocator
+	left, right array.RecordReader
+	schema      *arrow.Schema
+
+	lkey, rkey sortedKey
+	lby, rby   []int
+	cols       []int // right columns of the output
+	tolerance  int64 // negative if unbounded
+
+	buf  recordBuffer   // right rows, on the columns cols
+	ts   []int64        // keys of the right rows
+	keys []string       // encoded by values of the right rows
+	pos  int            // first right row after the key of the last left row
+	last map[string]int // last right row before pos, by by values
+	eof  bool
+
+	cur array.Record
+	err error
+}
+
+// NewAsOfJoinReader returns a reader of the as-of join of left and right on
+// the timestamp column on: each row of left is joined with the last row of
+// right whose key is not after its own and, if by is not empty, whose by
+// columns are equal to its own. Null by values are equal to each other.
+//
+// The records of the reader hold the rows of the records of left, one
+// record for each, followed by the columns of right other than on and by.
+// These columns are null for rows of left without a matching row of right.
+//
+// The rows of left and right must be sorted on on, which must have the same
+// type on both sides and no nulls. Only the right rows that may still be
+// matched are kept in memory.
+// The returned reader retains left and right until it is released.
+func NewAsOfJoinReader(mem memory.Allocator, left, right array.RecordReader, on string, by []string, opts ...Option) (*AsOfJoinReader, error) {
+	var cfg asOfConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	ls, rs := left.Schema(), right.Schema()
+	lkey, err := newSortedKey(ls, on)
+	if err != nil {
+		return nil, fmt.Errorf("%v on the left", err)
+	}
+	rkey, err := newSortedKey(rs, on)
+	if err != nil {
+		return nil, fmt.Errorf("%v on the right", err)
+	}
+	lt, rt := ls.Field(lkey.col).Type, rs.Field(rkey.col).Type
+	if !reflect.DeepEqual(lt, rt) {
+		return nil, fmt.Errorf("arrow/compute: key column %q is %s on the left and %s on the right", on, lt.Name(), rt.Name())
+	}
+
+	keyed := map[int]bool{rkey.col: true}
+	lby, rby := make([]int, len(by)), make([]int, len(by))
+	for i, name := range by {
+		lby[i], rby[i] = ls.FieldIndex(name), rs.FieldIndex(name)
+		if lby[i] < 0 || rby[i] < 0 {
+			return nil, fmt.Errorf("arrow/compute: no column named %q on both sides", name)
+		}
+		lt, rt := ls.Field(lby[i]).Type, rs.Field(rby[i]).Type
+		if !reflect.DeepEqual(lt, rt) {
+			return nil, fmt.Errorf("arrow/compute: key column %q is %s on the left and %s on the right", name, lt.Name(), rt.Name())
+		}
+		keyed[rby[i]] = true
+	}
+
+	var (
+		tolerance = int64(-1)
+		fields    = append([]arrow.Field(nil), ls.Fields()...)
+		bufFields []arrow.Field
+		cols      []int
+	)
+	switch {
+	case !cfg.bounded:
+	case cfg.tolerance < 0:
+		return nil, fmt.Errorf("arrow/compute: negative tolerance %v", cfg.tolerance)
+	case cfg.tolerance == 0:
+		tolerance = 0
+	default:
+		tolerance, err = ticks("tolerance", cfg.tolerance, lt.(*arrow.TimestampType).Unit)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for i, f := range rs.Fields() {
+		if keyed[i] {
+			continue
+		}
+		if ls.HasField(f.Name) {
+			return nil, fmt.Errorf("arrow/compute: column %q is on both sides of the join", f.Name)
+		}
+		bufFields = append(bufFields, f)
+		f.Nullable = true
+		fields = append(fields, f)
+		cols = append(cols, i)
+	}
+
+	meta := ls.Metadata()
+	left.Retain()
+	right.Retain()
+	return &AsOfJoinReader{
+		refs:      1,
+		mem:       mem,
+		left:      left,
+		right:     right,
+		schema:    arrow.NewSchema(fields, &meta),
+		lkey:      lkey,
+		rkey:      rkey,
+		lby:       lby,
+		rby:       rby,
+		cols:      cols,
+		tolerance: tolerance,
+		buf:       recordBuffer{schema: arrow.NewSchema(bufFields, nil)},
+		last:      make(map[string]int),
+	}, nil
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (r *AsOfJoinReader) Retain() {
+	atomic.AddInt64(&r.refs, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+// Release may be called simultaneously from multiple goroutines.
+func (r *AsOfJoinReader) Release() {
+	debug.Assert(atomic.LoadInt64(&r.refs) > 0, "too many releases")
+
+	if atomic.AddInt64(&r.refs, -1) == 0 {
+		if r.cur != nil {
+			r.cur.Release()
+			r.cur = nil
+		}
+		r.buf.release()
+		r.left.Release()
+		r.right.Release()
+	}
+}
+
+func (r *AsOfJoinReader) Schema() *arrow.Schema { return r.schema }
+
+// Record returns the current record.
+// The returned record is owned by the reader and is only valid until the
+// next call to Next.
+func (r *AsOfJoinReader) Record() array.Record { return r.cur }
+
+// Err returns the error that stopped the reader, if any.
+func (r *AsOfJoinReader) Err() error { return r.err }
+
+// Next joins the next record of the left reader. It returns false when the
+// records of the left reader are exhausted, or on error.
+func (r *AsOfJoinReader) Next() bool {
+	if r.cur != nil {
+		r.cur.Release()
+		r.cur = nil
+	}
+	if r.err != nil {
+		return false
+	}
+	if !r.left.Next() {
+		r.err = readerErr(r.left)
+		return false
+	}
+
+	rec := r.left.Record()
+	ts, err := r.lkey.values(rec)
+	if err != nil {
+		r.err = err
+		return false
+	}
+	encoders, err := byEncoders(rec, r.lby)
+	if err != nil {
+		r.err = err
+		return false
+	}
+
+	var (
+		idx = make([]int, len(ts))
+		buf []byte
+	)
+	for i, t := range ts {
+		if !r.advance(t) {
+			return false
+		}
+		buf = buf[:0]
+		for _, enc := range encoders {
+			buf = enc(buf, i)
+		}
+		j, ok := r.last[string(buf)]
+		if !ok || (r.tolerance >= 0 && t-r.ts[j] > r.tolerance) {
+			j = -1
+		}
+		idx[i] = j
+	}
+
+	cols := make([]array.Interface, 0, len(r.schema.Fields()))
+	defer func() {
+		for _, col := range cols {
+			col.Release()
+		}
+	}()
+	for _, col := range rec.Columns() {
+		col.Retain()
+		cols = append(cols, col)
+	}
+	matched, err := r.buf.take(r.mem, idx)
+	if err != nil {
+		r.err = err
+		return false
+	}
+	for _, col := range matched.Columns() {
+		col.Retain()
+		cols = append(cols, col)
+	}
+	matched.Release()
+	r.cur = array.NewRecord(r.schema, cols, rec.NumRows())
+
+	if len(ts) > 0 {
+		r.compact(ts[len(ts)-1])
+	}
+	return r.err == nil
+}
+
+// advance records the last right row of each by value up to the key t.
+// It returns false on error.
+func (r *AsOfJoinReader) advance(t int64) bool {
+	for {
+		if r.pos == len(r.ts) && !r.read() {
+			return r.err == nil
+		}
+		if r.ts[r.pos] > t {
+			return true
+		}
+		r.last[r.keys[r.pos]] = r.pos
+		r.pos++
+	}
+}
+
+// read appends the next non-empty record of the right reader to the
+// buffer. It returns false when the reader is exhausted, or on error.
+func (r *AsOfJoinReader) read() bool {
+	for !r.eof {
+		if !r.right.Next() {
+			r.eof, r.err = true, readerErr(r.right)
+			return false
+		}
+
+		rec := r.right.Record()
+		ts, err := r.rkey.values(rec)
+		if err != nil {
+			r.err = err
+			return false
+		}
+		if len(ts) == 0 {
+			continue
+		}
+		encoders, err := byEncoders(rec, r.rby)
+		if err != nil {
+			r.err = err
+			return false
+		}
+		var buf []byte
+		for i := range ts {
+			buf = buf[:0]
+			for _, enc := range encoders {
+				buf = enc(buf, i)
+			}
+			r.keys = append(r.keys, string(buf))
+		}
+
+		cols := make([]array.Interface, len(r.cols))
+		for i, j := range r.cols {
+			cols[i] = rec.Column(j)
+		}
+		proj := array.NewRecord(r.buf.schema, cols, rec.NumRows())
+		r.buf.push(proj)
+		proj.Release()
+		r.ts = append(r.ts, ts...)
+		return true
+	}
+	return false
+}
+
+// compact removes the buffered right rows that can no longer be matched by
+// left rows with keys from t, once they make up most of the buffer.
+func (r *AsOfJoinReader) compact(t int64) {
+	keep := make([]int, 0, len(r.last)+len(r.ts)-r.pos)
+	for k, j := range r.last {
+		if r.tolerance >= 0 && t-r.ts[j] > r.tolerance {
+			delete(r.last, k)
+			continue
+		}
+		keep = append(keep, j)
+	}
+	sort.Ints(keep)
+	for j := r.pos; j < len(r.ts); j++ {
+		keep = append(keep, j)
+	}
+	if 2*len(keep) > len(r.ts) {
+		return
+	}
+
+	if err := r.buf.keep(r.mem, keep); err != nil {
+		r.err = err
+		return
+	}
+
+	for k, j := range r.last {
+		r.last[k] = sort.SearchInts(keep, j)
+	}
+	ts, keys := make([]int64, len(keep)), make([]string, len(keep))
+	for i, j := range keep {
+		ts[i], keys[i] = r.ts[j], r.keys[j]
+	}
+	r.pos -= len(r.ts) - len(keep)
+	r.ts, r.keys = ts, keys
+}
+
+// recordBuffer is a list of records, whose rows are numbered as if they
+// were concatenated. Rows are only copied when they are read from several
+// records.
+type recordBuffer struct {
+	schema *arrow.Schema
+	recs   []array.Record
+	offs   []int // first row of each record
+	n      int
+}
+
+// push appends the rows of rec, which must not be empty, to the buffer.
+func (b *recordBuffer) push(rec array.Record) {
+	rec.Retain()
+	b.recs = append(b.recs, rec)
+	b.offs = append(b.offs, b.n)
+	b.n += int(rec.NumRows())
+}
+
+// drop removes the first n rows of the buffer.
+func (b *recordBuffer) drop(n int) {
+	k := sort.Search(len(b.recs), func(k int) bool { return b.offs[k]+int(b.recs[k].NumRows()) > n })
+	for _, rec := range b.recs[:k] {
+		rec.Release()
+	}
+	recs := b.recs[:copy(b.recs, b.recs[k:])]
+	if len(recs) > 0 && n > b.offs[k] {
+		rec := recs[0].NewSlice(int64(n-b.offs[k]), recs[0].NumRows())
+		recs[0].Release()
+		recs[0] = rec
+	}
+	b.recs = recs
+	b.reindex()
+}
+
+// keep removes the rows of the buffer not in idx, which must be sorted.
+func (b *recordBuffer) keep(mem memory.Allocator, idx []int) error {
+	recs := make([]array.Record, 0, len(b.recs))
+	for k, rec := range b.recs {
+		var local []int
+		for len(idx) > 0 && idx[0] < b.offs[k]+int(rec.NumRows()) {
+			local = append(local, idx[0]-b.offs[k])
+			idx = idx[1:]
+		}
+		switch len(local) {
+		case 0:
+			rec.Release()
+		case int(rec.NumRows()):
+			recs = append(recs, rec)
+		default:
+			out, err := takeRecord(mem, rec, local)
+			if err != nil {
+				for _, rec := range recs {
+					rec.Release()
+				}
+				return err
+			}
+			rec.Release()
+			recs = append(recs, out)
+		}
+	}
+	b.recs = recs
+	b.reindex()
+	return nil
+}
+
+func (b *recordBuffer) reindex() {
+	b.offs, b.n = b.offs[:0], 0
+	for _, rec := range b.recs {
+		b.offs = append(b.offs, b.n)
+		b.n += int(rec.NumRows())
+	}
+}
+
+// slice returns the rows [i, j) of the buffer.
+func (b *recordBuffer) slice(mem memory.Allocator, i, j int) (array.Record, error) {
+	if len(b.recs) == 0 {
+		bld := array.NewRecordBuilder(mem, b.schema)
+		defer bld.Release()
+		return bld.NewRecord(), nil
+	}
+	k := sort.Search(len(b.recs), func(k int) bool { return b.offs[k]+int(b.recs[k].NumRows()) > i })
+	if k == len(b.recs) {
+		k--
+	}
+	var parts []array.Record
+	defer func() {
+		for _, part := range parts {
+			part.Release()
+		}
+	}()
+	for ; k < len(b.recs) && (len(parts) == 0 || b.offs[k] < j); k++ {
+		lo, hi := i-b.offs[k], j-b.offs[k]
+		if lo < 0 {
+			lo = 0
+		}
+		if n := int(b.recs[k].NumRows()); hi > n {
+			hi = n
+		}
+		parts = append(parts, b.recs[k].NewSlice(int64(lo), int64(hi)))
+	}
+	if len(parts) == 1 {
+		rec := parts[0]
+		parts = nil
+		return rec, nil
+	}
+	rec, err := array.ConcatenateRecords(mem, parts...)
+	if err != nil {
+		return nil, fmt.Errorf("arrow/compute: %v", err)
+	}
+	return rec, nil
+}
+
+// take returns the rows idx of the buffer, null for the indices -1.
+func (b *recordBuffer) take(mem memory.Allocator, idx []int) (array.Record, error) {
+	lo, hi := b.n, 0
+	for _, j := range idx {
+		if j < 0 {
+			continue
+		}
+		if j < lo {
+			lo = j
+		}
+		if j >= hi {
+			hi = j + 1
+		}
+	}
+	if lo >= hi {
+		lo, hi = 0, 0
+	}
+	rows, err := b.slice(mem, lo, hi)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Release()
+
+	local := make([]int, len(idx))
+	for i, j := range idx {
+		local[i] = j
+		if j >= 0 {
+			local[i] -= lo
+		}
+	}
+	return takeRecord(mem, rows, local)
+}
+
+func (b *recordBuffer) release() {
+	for _, rec := range b.recs {
+		rec.Release()
+	}
+	b.recs, b.offs, b.n = nil, nil, 0
+}
+
+// byEncoders returns the key encoders of the columns cols of rec.
+func byEncoders(rec array.Record, cols []int) ([]func(buf []byte, i int) []byte, error) {
+	encoders := make([]func(buf []byte, i int) []byte, len(cols))
+	for k, i := range cols {
+		enc, err := keyEncoder(rec.Column(i), "as-of join")
+		if err != nil {
+			return nil, fmt.Errorf("arrow/compute: key column %q: %v", rec.ColumnName(i), err)
+		}
+		encoders[k] = enc
+	}
+	return encoders, nil
+}
+
+// sortedKey checks the values of a timestamp column of the records of a
+// reader, which must be sorted and not null.
+type sortedKey struct {
+	name string
+	col  int
+	last int64
+	seen bool
+}
+
+func newSortedKey(schema *arrow.Schema, name string) (sortedKey, error) {
+	col := schema.FieldIndex(name)
+	if col < 0 {
+		return sortedKey{}, fmt.Errorf("arrow/compute: no column named %q", name)
+	}
+	if dt := schema.Field(col).Type; dt.ID() != arrow.TIMESTAMP {
+		return sortedKey{}, fmt.Errorf("arrow/compute: key column %q is %s, not a timestamp", name, dt.Name())
+	}
+	return sortedKey{name: name, col: col}, nil
+}
+
+// values returns the keys of rec.
+func (k *sortedKey) values(rec array.Record) ([]int64, error) {
+	col, ok := rec.Column(k.col).(*array.Timestamp)
+	if !ok || rec.ColumnName(k.col) != k.name {
+		return nil, fmt.Errorf("arrow/compute: record does not match the schema of the reader")
+	}
+	if col.NullN() > 0 {
+		return nil, fmt.Errorf("arrow/compute: null value in key column %q", k.name)
+	}
+	ts := make([]int64, col.Len())
+	for i, v := range col.TimestampValues() {
+		if k.seen && int64(v) < k.last {
+			return nil, fmt.Errorf("arrow/compute: key column %q is not sorted", k.name)
+		}
+		ts[i], k.last, k.seen = int64(v), int64(v), true
+	}
+	return ts, nil
+}
+
+// ticks converts d, which must be positive, to a number of ticks of unit.
+func ticks(what string, d time.Duration, unit arrow.TimeUnit) (int64, error) {
+	if d <= 0 {
+		return 0, fmt.Errorf("arrow/compute: %s %v is not positive", what, d)
+	}
+	if d%unit.Multiplier() != 0 {
+		return 0, fmt.Errorf("arrow/compute: %s %v is not a whole number of %s", what, d, unit)
+	}
+	return int64(d / unit.Multiplier()), nil
+}
+
+// readerErr returns the error of rr, if it has an Err method.
+func readerErr(rr array.RecordReader) error {
+	if r, ok := rr.(interface{ Err() error }); ok {
+		return r.Err()
+	}
+	return nil
+}
+
+var (
+	_ array.RecordReader = (*WindowReader)(nil)
+	_ array.RecordReader = (*AsOfJoinReader)(nil)
+)
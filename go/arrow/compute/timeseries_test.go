@@ -0,0 +1,363 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compute_test
+
+import (
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+var tsType = &arrow.TimestampType{Unit: arrow.Second}
+
+// tsReader returns a reader of records of timestamps ts, symbols sym and
+// row numbers, in the columns "ts", symCol and valCol, split in records
+// after the rows at positions cuts.
+func tsReader(t *testing.T, mem memory.Allocator, symCol, valCol string, ts []int64, sym []string, cuts ...int) array.RecordReader {
+	t.Helper()
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "ts", Type: tsType},
+		{Name: symCol, Type: arrow.BinaryTypes.String},
+		{Name: valCol, Type: arrow.PrimitiveTypes.Int64},
+	}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	var recs []array.Record
+	cuts = append(cuts, len(ts))
+	for i := range ts {
+		b.Field(0).(*array.TimestampBuilder).Append(arrow.Timestamp(ts[i]))
+		b.Field(1).(*array.StringBuilder).Append(sym[i])
+		b.Field(2).(*array.Int64Builder).Append(int64(i))
+		if i+1 == cuts[0] {
+			recs = append(recs, b.NewRecord())
+			cuts = cuts[1:]
+		}
+	}
+	rr, err := array.NewRecordReader(schema, recs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rec := range recs {
+		rec.Release()
+	}
+	return rr
+}
+
+func TestWindowReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ts := []int64{-3, 0, 1, 4, 5, 5, 12, 13, 14, 30}
+	sym := make([]string, len(ts))
+
+	for _, tc := range []struct {
+		name       string
+		size, step time.Duration
+		want       []string // start, end: rows
+	}{
+		{
+			name: "tumbling", size: 5 * time.Second, step: 5 * time.Second,
+			want: []string{"-5,0:0", "0,5:1 2 3", "5,10:4 5", "10,15:6 7 8", "30,35:9"},
+		},
+		{
+			name: "sliding", size: 10 * time.Second, step: 5 * time.Second,
+			want: []string{"-10,0:0", "-5,5:0 1 2 3", "0,10:1 2 3 4 5", "5,15:4 5 6 7 8", "10,20:6 7 8", "25,35:9", "30,40:9"},
+		},
+		{
+			name: "hopping", size: 2 * time.Second, step: 5 * time.Second,
+			want: []string{"0,2:1 2", "5,7:4 5", "30,32:9"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := tsReader(t, mem, "sym", "v", ts, sym, 1, 5, 6)
+			defer rr.Release()
+			w, err := compute.NewWindowReader(mem, rr, "ts", tc.size, tc.step)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer w.Release()
+
+			var got []string
+			for w.Next() {
+				start, end := w.Window()
+				var rows []string
+				for _, v := range w.Record().Column(2).(*array.Int64).Int64Values() {
+					rows = append(rows, strconv.FormatInt(v, 10))
+				}
+				got = append(got, strconv.FormatInt(int64(start), 10)+","+strconv.FormatInt(int64(end), 10)+":"+strings.Join(rows, " "))
+			}
+			if err := w.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid windows:\ngot= %q\nwant=%q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWindowReaderErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rr := tsReader(t, mem, "sym", "v", []int64{1, 2}, []string{"a", "b"})
+	defer rr.Release()
+	for _, tc := range []struct {
+		key        string
+		size, step time.Duration
+		want       string
+	}{
+		{"nope", time.Second, time.Second, `no column named "nope"`},
+		{"sym", time.Second, time.Second, `key column "sym" is utf8, not a timestamp`},
+		{"ts", 0, time.Second, "window size 0s is not positive"},
+		{"ts", time.Second, time.Millisecond, "window step 1ms is not a whole number of s"},
+	} {
+		_, err := compute.NewWindowReader(mem, rr, tc.key, tc.size, tc.step)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("invalid error: got=%v, want=%q", err, tc.want)
+		}
+	}
+
+	unsorted := tsReader(t, mem, "sym", "v", []int64{5, 6, 4}, []string{"a", "b", "c"}, 2)
+	defer unsorted.Release()
+	w, err := compute.NewWindowReader(mem, unsorted, "ts", time.Second, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Release()
+	for w.Next() {
+	}
+	if err, want := w.Err(), `key column "ts" is not sorted`; err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("invalid error: got=%v, want=%q", err, want)
+	}
+}
+
+func TestAsOfJoin(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	for _, tc := range []struct {
+		name      string
+		rightSym  string
+		noRight   bool // no right rows
+		by        []string
+		opts      []compute.Option
+		want      []int64 // right row of each left row, -1 if none
+		wantNames []string
+	}{
+		{
+			name:      "nearest",
+			rightSym:  "rsym",
+			want:      []int64{-1, 1, 2, 2, 3, 6},
+			wantNames: []string{"ts", "sym", "l", "rsym", "r"},
+		},
+		{
+			name:      "by",
+			by:        []string{"sym"},
+			want:      []int64{-1, 1, 2, 1, 3, 6},
+			wantNames: []string{"ts", "sym", "l", "r"},
+		},
+		{
+			name:      "tolerance",
+			by:        []string{"sym"},
+			opts:      []compute.Option{compute.WithTolerance(2 * time.Second)},
+			want:      []int64{-1, 1, 2, -1, 3, -1},
+			wantNames: []string{"ts", "sym", "l", "r"},
+		},
+		{
+			name:      "no right rows",
+			noRight:   true,
+			by:        []string{"sym"},
+			want:      []int64{-1, -1, -1, -1, -1, -1},
+			wantNames: []string{"ts", "sym", "l", "r"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			left := tsReader(t, mem, "sym", "l",
+				[]int64{0, 2, 5, 5, 9, 20},
+				[]string{"a", "a", "b", "a", "a", "b"}, 2, 3)
+			defer left.Release()
+			if tc.rightSym == "" {
+				tc.rightSym = "sym"
+			}
+			rts, rsym := []int64{1, 2, 4, 7, 10, 10, 12}, []string{"a", "a", "b", "a", "a", "a", "b"}
+			if tc.noRight {
+				rts, rsym = nil, nil
+			}
+			right := tsReader(t, mem, tc.rightSym, "r", rts, rsym, 1, 3)
+			defer right.Release()
+
+			j, err := compute.NewAsOfJoinReader(mem, left, right, "ts", tc.by, tc.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer j.Release()
+
+			var (
+				got   []int64
+				nrecs int
+			)
+			for j.Next() {
+				rec := j.Record()
+				nrecs++
+				r := rec.Column(rec.Schema().FieldIndex("r")).(*array.Int64)
+				for i := 0; i < r.Len(); i++ {
+					if r.IsNull(i) {
+						got = append(got, -1)
+						continue
+					}
+					got = append(got, r.Value(i))
+				}
+			}
+			if err := j.Err(); err != nil {
+				t.Fatal(err)
+			}
+			if nrecs != 3 {
+				t.Fatalf("invalid number of records: got=%d, want=3", nrecs)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid matches: got=%v, want=%v", got, tc.want)
+			}
+			var names []string
+			for _, f := range j.Schema().Fields() {
+				names = append(names, f.Name)
+			}
+			if !reflect.DeepEqual(names, tc.wantNames) {
+				t.Fatalf("invalid columns: got=%v, want=%v", names, tc.wantNames)
+			}
+			if f := j.Schema().Field(len(names) - 1); !f.Nullable {
+				t.Fatalf("right columns should be nullable")
+			}
+		})
+	}
+}
+
+func TestAsOfJoinRandom(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	var (
+		rnd  = rand.New(rand.NewSource(1))
+		syms = []string{"a", "b", "c", "d"}
+	)
+	gen := func(n int) ([]int64, []string, []int) {
+		ts, sym := make([]int64, n), make([]string, n)
+		var (
+			t    int64
+			cuts []int
+		)
+		for i := range ts {
+			t += int64(rnd.Intn(3))
+			ts[i], sym[i] = t, syms[rnd.Intn(len(syms))]
+			if rnd.Intn(10) == 0 {
+				cuts = append(cuts, i+1)
+			}
+		}
+		return ts, sym, cuts
+	}
+
+	lts, lsym, lcuts := gen(500)
+	rts, rsym, rcuts := gen(800)
+	for _, tolerance := range []int64{3, -1} {
+		left := tsReader(t, mem, "sym", "l", lts, lsym, lcuts...)
+		right := tsReader(t, mem, "sym", "r", rts, rsym, rcuts...)
+		var opts []compute.Option
+		if tolerance >= 0 {
+			opts = append(opts, compute.WithTolerance(time.Duration(tolerance)*time.Second))
+		}
+		j, err := compute.NewAsOfJoinReader(mem, left, right, "ts", []string{"sym"}, opts...)
+		left.Release()
+		right.Release()
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkAsOfJoin(t, j, lts, lsym, rts, rsym, tolerance)
+		j.Release()
+	}
+}
+
+// checkAsOfJoin checks the matches of j against a scan of the right rows.
+func checkAsOfJoin(t *testing.T, j *compute.AsOfJoinReader, lts []int64, lsym []string, rts []int64, rsym []string, tolerance int64) {
+	t.Helper()
+
+	row := 0
+	for j.Next() {
+		r := j.Record().Column(3).(*array.Int64)
+		for i := 0; i < r.Len(); i, row = i+1, row+1 {
+			want := int64(-1)
+			for k := range rts {
+				d := lts[row] - rts[k]
+				if d >= 0 && (tolerance < 0 || d <= tolerance) && rsym[k] == lsym[row] {
+					want = int64(k)
+				}
+			}
+			got := int64(-1)
+			if r.IsValid(i) {
+				got = r.Value(i)
+			}
+			if got != want {
+				t.Fatalf("tolerance %d: row %d: invalid match: got=%d, want=%d", tolerance, row, got, want)
+			}
+		}
+	}
+	if err := j.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if row != len(lts) {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", row, len(lts))
+	}
+}
+
+func TestAsOfJoinErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	left := tsReader(t, mem, "sym", "l", []int64{1}, []string{"a"})
+	defer left.Release()
+	right := tsReader(t, mem, "sym", "r", []int64{1}, []string{"a"})
+	defer right.Release()
+
+	for _, tc := range []struct {
+		name  string
+		right array.RecordReader
+		on    string
+		by    []string
+		opts  []compute.Option
+		want  string
+	}{
+		{"missing key", right, "nope", nil, nil, `no column named "nope" on the left`},
+		{"missing by", right, "ts", []string{"nope"}, nil, `no column named "nope" on both sides`},
+		{"duplicate column", left, "ts", []string{"sym"}, nil, `column "l" is on both sides of the join`},
+		{"negative tolerance", right, "ts", nil, []compute.Option{compute.WithTolerance(-time.Second)}, "negative tolerance -1s"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := compute.NewAsOfJoinReader(mem, left, tc.right, tc.on, tc.by, tc.opts...)
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("invalid error: got=%v, want=%q", err, tc.want)
+			}
+		})
+	}
+}
@@ -19,8 +19,10 @@
 package csv
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/apache/arrow/go/arrow"
 	"github.com/apache/arrow/go/arrow/memory"
@@ -28,8 +30,50 @@ import (
 
 var (
 	ErrMismatchFields = errors.New("arrow/csv: number of records mismatch")
+	ErrMismatchHeader = errors.New("arrow/csv: header mismatch")
 )
 
+// ErrorPolicy specifies how a Reader handles malformed rows.
+type ErrorPolicy int
+
+const (
+	// FailOnError stops the reading at the first malformed row, whose
+	// error is returned by Reader.Err. This is the default policy.
+	FailOnError ErrorPolicy = iota
+	// SkipRow skips the malformed rows.
+	SkipRow
+	// NullFill appends null values in place of the values that can not be
+	// parsed. Missing fields are null-filled and extra fields are ignored.
+	// Rows that are syntactically invalid CSV are skipped.
+	NullFill
+)
+
+// RowError describes a malformed row, or a value that could not be parsed.
+type RowError struct {
+	File   string // name of the file, for readers created by NewReaderFS
+	Line   int    // line of the row in the CSV file, starting at 1
+	Column int    // index of the field, or -1 if the error concerns the whole row
+	Field  string // name of the field, if Column is not negative
+	Err    error
+}
+
+func (e *RowError) Error() string {
+	msg := strings.TrimPrefix(e.Err.Error(), "arrow/csv: ")
+	file := ""
+	if e.File != "" {
+		file = e.File + ": "
+	}
+	if e.Column < 0 {
+		return fmt.Sprintf("arrow/csv: %sline %d: %s", file, e.Line, msg)
+	}
+	return fmt.Sprintf("arrow/csv: %sline %d, column %d (%s): %s", file, e.Line, e.Column, e.Field, msg)
+}
+
+func (e *RowError) Unwrap() error { return e.Err }
+
+// utf8BOM is the UTF-8 encoding of the byte order mark.
+const utf8BOM = "\ufeff"
+
 // Option configures a CSV reader/writer.
 type Option func(config)
 type config interface{}
@@ -90,6 +134,49 @@ func WithChunk(n int) Option {
 	}
 }
 
+// WithContext specifies the context of the reading of CSV files. Once ctx
+// is done, the reader stops before the next row or the next read of the
+// underlying io.Reader, and Reader.Err returns the error of ctx.
+func WithContext(ctx context.Context) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.ctx = ctx
+		default:
+			panic(fmt.Errorf("arrow/csv: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithHeader specifies whether CSV files start with a header row, holding
+// the names of the fields. The header of each file read is checked against
+// the names of the fields of the schema, in order: a mismatch stops the
+// reading, whatever the error policy.
+// The default value is false.
+func WithHeader(header bool) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.header = header
+		default:
+			panic(fmt.Errorf("arrow/csv: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithErrorPolicy specifies how malformed rows are handled while reading CSV files.
+// Whatever the policy, the malformed rows and values are reported by Reader.RowErrors.
+func WithErrorPolicy(p ErrorPolicy) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.policy = p
+		default:
+			panic(fmt.Errorf("arrow/csv: unknown config type %T", cfg))
+		}
+	}
+}
+
 // WithCRLF specifies the line terminator used while writing CSV files.
 // If useCRLF is true, \r\n is used as the line terminator, otherwise \n is used.
 // The default value is false.
@@ -104,16 +191,89 @@ func WithCRLF(useCRLF bool) Option {
 	}
 }
 
+// WithQuoteStrings specifies whether the non-null values of string columns
+// are always quoted while writing CSV files, as spreadsheets expect to tell
+// text from numbers. Null values are written as empty, unquoted fields, so
+// they can be told apart from empty strings.
+// Otherwise, fields are only quoted when they must be.
+// The default value is false.
+func WithQuoteStrings(quote bool) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			cfg.quote = quote
+		default:
+			panic(fmt.Errorf("arrow/csv: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithBOM specifies whether a UTF-8 byte order mark is written at the start
+// of CSV files, as Excel requires to detect the encoding.
+// The mark is written along with the first record.
+// The default value is false.
+//
+// Readers always skip a byte order mark at the start of their input.
+func WithBOM(bom bool) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			cfg.bom = bom
+		default:
+			panic(fmt.Errorf("arrow/csv: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithColumnParser specifies a custom parser for the CSV values of the field
+// named name, e.g. to handle booleans written as "Y"/"N", decimal numbers
+// with a comma separator or timestamps given as milliseconds since the epoch.
+//
+// The value returned by parse is appended to the column as by array.AppendSlice:
+// nil is a null value, and values must be of the Go type corresponding to the
+// field's data type (time.Time for timestamps and dates, for example).
+// Fields with a custom parser may be of any data type supported by
+// array.AppendSlice.
+func WithColumnParser(name string, parse func(string) (interface{}, error)) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			if cfg.parsers == nil {
+				cfg.parsers = make(map[string]func(string) (interface{}, error))
+			}
+			cfg.parsers[name] = parse
+		default:
+			panic(fmt.Errorf("arrow/csv: unknown config type %T", cfg))
+		}
+	}
+}
+
 func validate(schema *arrow.Schema) {
 	for i, f := range schema.Fields() {
-		switch ft := f.Type.(type) {
+		validateField(i, f)
+	}
+}
+
+func validateField(i int, f arrow.Field) {
+	switch ft := f.Type.(type) {
+	case *arrow.BooleanType:
+	case *arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type, *arrow.Int64Type:
+	case *arrow.Uint8Type, *arrow.Uint16Type, *arrow.Uint32Type, *arrow.Uint64Type:
+	case *arrow.Float16Type, *arrow.Float32Type, *arrow.Float64Type:
+	case *arrow.StringType:
+	case *arrow.DurationType, *arrow.MonthIntervalType:
+	case *arrow.DayTimeIntervalType, *arrow.MonthDayNanoIntervalType:
+	case *arrow.FixedSizeBinaryType:
+	case *arrow.FixedSizeListType:
+		switch ft.Elem().(type) {
 		case *arrow.BooleanType:
 		case *arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type, *arrow.Int64Type:
 		case *arrow.Uint8Type, *arrow.Uint16Type, *arrow.Uint32Type, *arrow.Uint64Type:
-		case *arrow.Float32Type, *arrow.Float64Type:
-		case *arrow.StringType:
+		case *arrow.Float16Type, *arrow.Float32Type, *arrow.Float64Type:
 		default:
-			panic(fmt.Errorf("arrow/csv: field %d (%s) has invalid data type %T", i, f.Name, ft))
+			panic(fmt.Errorf("arrow/csv: field %d (%s) has invalid list element type %T", i, f.Name, ft.Elem()))
 		}
+	default:
+		panic(fmt.Errorf("arrow/csv: field %d (%s) has invalid data type %T", i, f.Name, ft))
 	}
 }
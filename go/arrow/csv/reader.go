@@ -17,9 +17,16 @@
 package csv
 
 import (
+	"bufio"
+	"context"
+	"encoding/base64"
 	"encoding/csv"
+	"errors"
+	"fmt"
 	"io"
+	"io/fs"
 	"strconv"
+	"strings"
 	"sync/atomic"
 
 	"github.com/apache/arrow/go/arrow"
@@ -43,22 +50,147 @@ type Reader struct {
 	next  func() bool
 
 	mem memory.Allocator
+
+	parsers map[string]func(string) (interface{}, error) // custom parsers, by field name
+	fparse  []func(string) (interface{}, error)          // custom parsers, by field index
+
+	policy  ErrorPolicy
+	cells   []cell // parsed values of the current row
+	rowErrs []*RowError
+
+	ctx context.Context
+
+	header     bool          // whether each input starts with a header
+	needHeader bool          // whether the header of the current input is still to be read
+	srcs       []source      // inputs left to read
+	file       string        // name of the current input, if any
+	closer     io.ReadCloser // current input
+}
+
+// source is an input of a reader.
+type source struct {
+	name string
+	open func() (io.ReadCloser, error)
+}
+
+// bomReader drops the byte order mark at the start of its input, if any.
+type bomReader struct {
+	r       *bufio.Reader
+	started bool
+}
+
+func (br *bomReader) Read(p []byte) (int, error) {
+	if !br.started {
+		br.started = true
+		if b, err := br.r.Peek(len(utf8BOM)); err == nil && string(b) == utf8BOM {
+			br.r.Discard(len(utf8BOM))
+		}
+	}
+	return br.r.Read(p)
+}
+
+// ctxReader fails the reads of r once the context of the CSV reader is done.
+type ctxReader struct {
+	r  io.Reader
+	rr *Reader
+}
+
+func (cr *ctxReader) Read(p []byte) (int, error) {
+	if err := cr.rr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
+
+// cell holds the parsed value of a CSV field.
+type cell struct {
+	b    bool
+	i    int64
+	u    uint64
+	f    float64
+	v    interface{} // value returned by a custom parser, parsed interval, binary or list elements
+	null bool
 }
 
 // NewReader returns a reader that reads from the CSV file and creates
 // array.Records from the given schema.
 //
 // NewReader panics if the given schema contains fields that have types that are not
-// primitive types.
+// primitive types, unless a custom parser was provided for them with WithColumnParser.
+// NewReader panics if a custom parser is provided for a field that is not in the schema.
 func NewReader(r io.Reader, schema *arrow.Schema, opts ...Option) *Reader {
-	validate(schema)
+	return NewMultiReader([]io.Reader{r}, schema, opts...)
+}
+
+// NewMultiReader returns a reader that reads the CSV files rs one after the
+// other, as a single stream of records of the given schema. A record may hold
+// rows of several files.
+//
+// NewMultiReader panics in the same cases as NewReader.
+func NewMultiReader(rs []io.Reader, schema *arrow.Schema, opts ...Option) *Reader {
+	srcs := make([]source, len(rs))
+	for i, r := range rs {
+		r := r
+		srcs[i].open = func() (io.ReadCloser, error) { return io.NopCloser(r), nil }
+	}
+	return newReader(srcs, schema, opts)
+}
 
-	rr := &Reader{r: csv.NewReader(r), schema: schema, refs: 1, chunk: 1}
-	rr.r.ReuseRecord = true
+// NewReaderFS returns a reader that reads the files of fsys whose names match
+// pattern, as reported by fs.Glob, in lexical order. The files are read as a
+// single stream of records of the given schema, as by NewMultiReader.
+// Each file is opened once the previous one has been read, and closed once read
+// or when the reader is released.
+// Row errors report the name of the file the malformed row belongs to.
+//
+// NewReaderFS returns an error if the pattern is malformed, if no file matches
+// it or if the first file can not be opened. It panics in the same cases as
+// NewReader.
+func NewReaderFS(fsys fs.FS, pattern string, schema *arrow.Schema, opts ...Option) (*Reader, error) {
+	names, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("arrow/csv: no file matches %q", pattern)
+	}
+
+	srcs := make([]source, len(names))
+	for i, name := range names {
+		name := name
+		srcs[i] = source{name: name, open: func() (io.ReadCloser, error) { return fsys.Open(name) }}
+	}
+	rr := newReader(srcs, schema, opts)
+	if rr.err != nil {
+		err := rr.err
+		rr.Release()
+		return nil, err
+	}
+	return rr, nil
+}
+
+func newReader(srcs []source, schema *arrow.Schema, opts []Option) *Reader {
+	rr := &Reader{schema: schema, refs: 1, chunk: 1, ctx: context.Background(), srcs: srcs}
+	rr.r = rr.newCSVReader(nil) // holds the options, until the first input is opened.
 	for _, opt := range opts {
 		opt(rr)
 	}
 
+	rr.cells = make([]cell, len(schema.Fields()))
+	rr.fparse = make([]func(string) (interface{}, error), len(schema.Fields()))
+	for i, f := range schema.Fields() {
+		if parse, ok := rr.parsers[f.Name]; ok {
+			rr.fparse[i] = parse
+			continue
+		}
+		validateField(i, f)
+	}
+	for name := range rr.parsers {
+		if !schema.HasField(name) {
+			panic(fmt.Errorf("arrow/csv: column parser for unknown field %q", name))
+		}
+	}
+
 	if rr.mem == nil {
 		rr.mem = memory.DefaultAllocator
 	}
@@ -73,9 +205,56 @@ func NewReader(r io.Reader, schema *arrow.Schema, opts ...Option) *Reader {
 	default:
 		rr.next = rr.next1
 	}
+
+	if !rr.nextInput() {
+		rr.done = true
+	}
 	return rr
 }
 
+// newCSVReader returns a CSV reader of in, with the settings of the current one.
+func (r *Reader) newCSVReader(in io.Reader) *csv.Reader {
+	cr := csv.NewReader(&bomReader{r: bufio.NewReader(&ctxReader{r: in, rr: r})})
+	cr.ReuseRecord = true
+	cr.FieldsPerRecord = -1 // the number of fields is checked against the schema.
+	if r.r != nil {
+		cr.Comma = r.r.Comma
+		cr.Comment = r.r.Comment
+	}
+	return cr
+}
+
+// nextInput closes the current input and opens the next one.
+// It returns false once all the inputs have been read, or if the next one
+// could not be opened.
+func (r *Reader) nextInput() bool {
+	r.closeInput()
+	if len(r.srcs) == 0 {
+		return false
+	}
+
+	src := r.srcs[0]
+	r.srcs = r.srcs[1:]
+	in, err := src.open()
+	if err != nil {
+		r.done = true
+		r.err = err
+		return false
+	}
+	r.file = src.name
+	r.closer = in
+	r.r = r.newCSVReader(in)
+	r.needHeader = r.header
+	return true
+}
+
+func (r *Reader) closeInput() {
+	if r.closer != nil {
+		r.closer.Close()
+		r.closer = nil
+	}
+}
+
 // Err returns the last error encountered during the iteration over the
 // underlying CSV file.
 func (r *Reader) Err() error { return r.err }
@@ -89,8 +268,9 @@ func (r *Reader) Record() array.Record { return r.cur }
 
 // Next returns whether a Record could be extracted from the underlying CSV file.
 //
-// Next panics if the number of records extracted from a CSV row does not match
-// the number of fields of the associated schema.
+// Malformed rows, whose number of fields does not match the schema or whose
+// values can not be parsed, are handled according to the error policy of the
+// reader (see WithErrorPolicy).
 func (r *Reader) Next() bool {
 	if r.cur != nil {
 		r.cur.Release()
@@ -104,229 +284,412 @@ func (r *Reader) Next() bool {
 	return r.next()
 }
 
+// RowErrors returns the malformed rows and values encountered so far.
+// With the FailOnError policy, it holds at most the error returned by Err.
+func (r *Reader) RowErrors() []*RowError { return r.rowErrs }
+
 // next1 reads one row from the CSV file and creates a single Record
 // from that row.
 func (r *Reader) next1() bool {
-	var recs []string
-	recs, r.err = r.r.Read()
-	if r.err != nil {
-		r.done = true
-		if r.err == io.EOF {
-			r.err = nil
+	for !r.done {
+		if r.readRow() {
+			r.cur = r.bld.NewRecord()
+			return true
 		}
-		return false
 	}
-
-	r.validate(recs)
-	r.read(recs)
-	r.cur = r.bld.NewRecord()
-
-	return true
+	return false
 }
 
 // nextall reads the whole CSV file into memory and creates one single
 // Record from all the CSV rows.
+// With the FailOnError policy, no Record is created if an error occurred.
 func (r *Reader) nextall() bool {
-	defer func() {
-		r.done = true
-	}()
-
-	var (
-		recs [][]string
-	)
-
-	recs, r.err = r.r.ReadAll()
-	if r.err != nil {
-		return false
-	}
-
-	for _, rec := range recs {
-		r.validate(rec)
-		r.read(rec)
+	n := 0
+	for !r.done {
+		if r.readRow() {
+			n++
+		}
 	}
 	r.cur = r.bld.NewRecord()
 
+	if r.err != nil && (n == 0 || r.policy == FailOnError) {
+		r.cur.Release()
+		r.cur = nil
+		return false
+	}
 	return true
 }
 
 // nextn reads n rows from the CSV file, where n is the chunk size, and creates
 // a Record from these rows.
 func (r *Reader) nextn() bool {
-	var (
-		recs []string
-		n    = 0
-	)
-
-	for i := 0; i < r.chunk && !r.done; i++ {
-		recs, r.err = r.r.Read()
-		if r.err != nil {
-			r.done = true
-			break
+	n := 0
+	for n < r.chunk && !r.done {
+		if r.readRow() {
+			n++
 		}
+	}
+
+	r.cur = r.bld.NewRecord()
+	return n > 0
+}
 
-		r.validate(recs)
-		r.read(recs)
-		n++
+// readRow reads the next row of the CSV file and appends it to the record
+// builder, according to the error policy. It returns whether a row was
+// appended.
+func (r *Reader) readRow() bool {
+	if err := r.ctx.Err(); err != nil {
+		r.done = true
+		r.err = err
+		return false
 	}
 
-	if r.err != nil {
+	if r.needHeader {
+		r.needHeader = false
+		if !r.readHeader() {
+			return false
+		}
+	}
+
+	recs, err := r.r.Read()
+	if err != nil {
+		if err == io.EOF && r.nextInput() {
+			return false
+		}
+		var perr *csv.ParseError
+		if errors.As(err, &perr) && r.policy != FailOnError {
+			r.report(perr.Line, -1, perr.Err)
+			return false
+		}
 		r.done = true
-		if r.err == io.EOF {
-			r.err = nil
+		if err != io.EOF {
+			r.err = err
 		}
+		return false
 	}
 
-	r.cur = r.bld.NewRecord()
-	return n > 0
+	line, _ := r.r.FieldPos(0)
+	if !r.parse(line, recs) && r.policy != NullFill {
+		return false
+	}
+	r.append(recs)
+	return true
 }
 
-func (r *Reader) validate(recs []string) {
-	if r.err != nil {
-		return
+// readHeader reads the header of the current input and checks that it holds
+// the names of the fields of the schema. A mismatch stops the reading,
+// whatever the error policy. readHeader returns false if the input is empty
+// or if the reading stopped.
+func (r *Reader) readHeader() bool {
+	recs, err := r.r.Read()
+	switch {
+	case err == io.EOF:
+		return false
+	case err != nil:
+		r.done = true
+		r.err = err
+		return false
 	}
 
-	if len(recs) != len(r.schema.Fields()) {
-		r.err = ErrMismatchFields
-		return
+	ok := len(recs) == len(r.schema.Fields())
+	names := make([]string, len(r.schema.Fields()))
+	for i, f := range r.schema.Fields() {
+		names[i] = f.Name
+		ok = ok && recs[i] == f.Name
 	}
+	if !ok {
+		line, _ := r.r.FieldPos(0)
+		e := &RowError{
+			File: r.file, Line: line, Column: -1,
+			Err: fmt.Errorf("%w: got %q, want %q", ErrMismatchHeader, recs, names),
+		}
+		r.rowErrs = append(r.rowErrs, e)
+		r.err = e
+		r.done = true
+		return false
+	}
+	return true
 }
 
-func (r *Reader) read(recs []string) {
-	for i, str := range recs {
-		switch r.schema.Field(i).Type.(type) {
-		case *arrow.BooleanType:
-			var v bool
-			switch str {
-			case "false", "False", "0":
-				v = false
-			case "true", "True", "1":
-				v = true
-			}
-			r.bld.Field(i).(*array.BooleanBuilder).Append(v)
-		case *arrow.Int8Type:
-			v := r.readI8(str)
-			r.bld.Field(i).(*array.Int8Builder).Append(v)
-		case *arrow.Int16Type:
-			v := r.readI16(str)
-			r.bld.Field(i).(*array.Int16Builder).Append(v)
-		case *arrow.Int32Type:
-			v := r.readI32(str)
-			r.bld.Field(i).(*array.Int32Builder).Append(v)
-		case *arrow.Int64Type:
-			v := r.readI64(str)
-			r.bld.Field(i).(*array.Int64Builder).Append(v)
-		case *arrow.Uint8Type:
-			v := r.readU8(str)
-			r.bld.Field(i).(*array.Uint8Builder).Append(v)
-		case *arrow.Uint16Type:
-			v := r.readU16(str)
-			r.bld.Field(i).(*array.Uint16Builder).Append(v)
-		case *arrow.Uint32Type:
-			v := r.readU32(str)
-			r.bld.Field(i).(*array.Uint32Builder).Append(v)
-		case *arrow.Uint64Type:
-			v := r.readU64(str)
-			r.bld.Field(i).(*array.Uint64Builder).Append(v)
-		case *arrow.Float32Type:
-			v := r.readF32(str)
-			r.bld.Field(i).(*array.Float32Builder).Append(v)
-		case *arrow.Float64Type:
-			v := r.readF64(str)
-			r.bld.Field(i).(*array.Float64Builder).Append(v)
-		case *arrow.StringType:
-			r.bld.Field(i).(*array.StringBuilder).Append(str)
+// parse parses the fields of a CSV row into r.cells, and reports the
+// malformed ones. It returns whether all the fields could be parsed.
+func (r *Reader) parse(line int, recs []string) bool {
+	ok := true
+	if len(recs) != len(r.cells) {
+		r.report(line, -1, ErrMismatchFields)
+		ok = false
+	}
+
+	for i := range r.cells {
+		c := &r.cells[i]
+		*c = cell{}
+		if i >= len(recs) {
+			c.null = true
+			continue
+		}
+
+		str := recs[i]
+		var err error
+		if parse := r.fparse[i]; parse != nil {
+			c.v, err = parse(str)
+		} else {
+			err = parseCell(c, r.schema.Field(i).Type, str)
+		}
+		if err != nil {
+			r.report(line, i, err)
+			c.null = true
+			ok = false
 		}
 	}
+	return ok
 }
 
-func (r *Reader) readI8(str string) int8 {
-	v, err := strconv.ParseInt(str, 10, 8)
-	if err != nil && r.err == nil {
-		r.err = err
-		return 0
+// append appends the values parsed into r.cells to the record builder.
+func (r *Reader) append(recs []string) {
+	for i := range r.cells {
+		c := &r.cells[i]
+		if c.null {
+			r.bld.Field(i).AppendNull()
+			continue
+		}
+		if r.fparse[i] != nil {
+			r.appendCustom(i, c.v)
+			continue
+		}
+		appendCell(r.bld.Field(i), c, recs[i])
 	}
-	return int8(v)
 }
 
-func (r *Reader) readI16(str string) int16 {
-	v, err := strconv.ParseInt(str, 10, 16)
-	if err != nil && r.err == nil {
-		r.err = err
-		return 0
+// appendCell appends the value parsed into c to b. str is the text of the
+// value, appended as is to string builders.
+func appendCell(b array.Builder, c *cell, str string) {
+	if c.null {
+		b.AppendNull()
+		return
+	}
+	switch b := b.(type) {
+	case *array.BooleanBuilder:
+		b.Append(c.b)
+	case *array.Int8Builder:
+		b.Append(int8(c.i))
+	case *array.Int16Builder:
+		b.Append(int16(c.i))
+	case *array.Int32Builder:
+		b.Append(int32(c.i))
+	case *array.Int64Builder:
+		b.Append(c.i)
+	case *array.Uint8Builder:
+		b.Append(uint8(c.u))
+	case *array.Uint16Builder:
+		b.Append(uint16(c.u))
+	case *array.Uint32Builder:
+		b.Append(uint32(c.u))
+	case *array.Uint64Builder:
+		b.Append(c.u)
+	case *array.Float16Builder:
+		b.AppendFloat32(float32(c.f))
+	case *array.Float32Builder:
+		b.Append(float32(c.f))
+	case *array.Float64Builder:
+		b.Append(c.f)
+	case *array.StringBuilder:
+		b.Append(str)
+	case *array.DurationBuilder:
+		b.Append(arrow.Duration(c.i))
+	case *array.MonthIntervalBuilder:
+		b.Append(arrow.MonthInterval(c.i))
+	case *array.DayTimeIntervalBuilder:
+		b.Append(c.v.(arrow.DayTimeInterval))
+	case *array.MonthDayNanoIntervalBuilder:
+		b.Append(c.v.(arrow.MonthDayNanoInterval))
+	case *array.FixedSizeBinaryBuilder:
+		b.Append(c.v.([]byte))
+	case *array.FixedSizeListBuilder:
+		b.Append(true)
+		elems := c.v.([]cell)
+		for k := range elems {
+			appendCell(b.ValueBuilder(), &elems[k], "")
+		}
 	}
-	return int16(v)
 }
 
-func (r *Reader) readI32(str string) int32 {
-	v, err := strconv.ParseInt(str, 10, 32)
-	if err != nil && r.err == nil {
-		r.err = err
-		return 0
+// parseCell parses str, a value of type dt, into c.
+func parseCell(c *cell, dt arrow.DataType, str string) error {
+	var err error
+	switch dt := dt.(type) {
+	case *arrow.BooleanType:
+		switch str {
+		case "false", "False", "0":
+			c.b = false
+		case "true", "True", "1":
+			c.b = true
+		}
+	case *arrow.Int8Type:
+		c.i, err = strconv.ParseInt(str, 10, 8)
+	case *arrow.Int16Type:
+		c.i, err = strconv.ParseInt(str, 10, 16)
+	case *arrow.Int32Type:
+		c.i, err = strconv.ParseInt(str, 10, 32)
+	case *arrow.Int64Type:
+		c.i, err = strconv.ParseInt(str, 10, 64)
+	case *arrow.Uint8Type:
+		c.u, err = strconv.ParseUint(str, 10, 8)
+	case *arrow.Uint16Type:
+		c.u, err = strconv.ParseUint(str, 10, 16)
+	case *arrow.Uint32Type:
+		c.u, err = strconv.ParseUint(str, 10, 32)
+	case *arrow.Uint64Type:
+		c.u, err = strconv.ParseUint(str, 10, 64)
+	case *arrow.Float16Type, *arrow.Float32Type:
+		c.f, err = strconv.ParseFloat(str, 32)
+	case *arrow.Float64Type:
+		c.f, err = strconv.ParseFloat(str, 64)
+	case *arrow.DurationType:
+		c.i, err = strconv.ParseInt(str, 10, 64)
+	case *arrow.MonthIntervalType:
+		c.i, err = strconv.ParseInt(str, 10, 32)
+	case *arrow.DayTimeIntervalType:
+		c.v, err = parseDayTimeInterval(str)
+	case *arrow.MonthDayNanoIntervalType:
+		c.v, err = parseMonthDayNanoInterval(str)
+	case *arrow.FixedSizeBinaryType:
+		if str == "" {
+			c.null = true
+			break
+		}
+		var v []byte
+		v, err = base64.StdEncoding.DecodeString(str)
+		if err == nil && len(v) != dt.ByteWidth {
+			err = fmt.Errorf("invalid fixed size binary length %d, expected %d", len(v), dt.ByteWidth)
+		}
+		c.v = v
+	case *arrow.FixedSizeListType:
+		if str == "" {
+			c.null = true
+			break
+		}
+		c.v, err = parseFixedSizeList(str, dt)
 	}
-	return int32(v)
+	return err
 }
 
-func (r *Reader) readI64(str string) int64 {
-	v, err := strconv.ParseInt(str, 10, 64)
-	if err != nil && r.err == nil {
-		r.err = err
-		return 0
+// parseFixedSizeList parses a list written as "[v1,v2,...]", whose null
+// elements are written as "null". An empty field is a null list.
+func parseFixedSizeList(s string, dt *arrow.FixedSizeListType) ([]cell, error) {
+	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ']' {
+		return nil, fmt.Errorf("invalid fixed size list %q", s)
+	}
+	var elems []string
+	if s = strings.TrimSpace(s[1 : len(s)-1]); s != "" {
+		elems = strings.Split(s, ",")
+	}
+	if len(elems) != int(dt.Len()) {
+		return nil, fmt.Errorf("invalid list length %d, expected %d", len(elems), dt.Len())
+	}
+	cells := make([]cell, len(elems))
+	for k, e := range elems {
+		e = strings.TrimSpace(e)
+		if e == "null" {
+			cells[k].null = true
+			continue
+		}
+		if err := parseCell(&cells[k], dt.Elem(), e); err != nil {
+			return nil, err
+		}
 	}
-	return int64(v)
+	return cells, nil
 }
 
-func (r *Reader) readU8(str string) uint8 {
-	v, err := strconv.ParseUint(str, 10, 8)
-	if err != nil && r.err == nil {
-		r.err = err
-		return 0
+// parseDayTimeInterval parses a day-time interval written as
+// "<days>d<milliseconds>ms", e.g. "1d500ms". Either component may be omitted.
+func parseDayTimeInterval(s string) (arrow.DayTimeInterval, error) {
+	v, err := parseInterval(s, []string{"d", "ms"}, []int{32, 32})
+	if err != nil {
+		return arrow.DayTimeInterval{}, err
 	}
-	return uint8(v)
+	return arrow.DayTimeInterval{Days: int32(v[0]), Milliseconds: int32(v[1])}, nil
 }
 
-func (r *Reader) readU16(str string) uint16 {
-	v, err := strconv.ParseUint(str, 10, 16)
-	if err != nil && r.err == nil {
-		r.err = err
-		return 0
+// parseMonthDayNanoInterval parses a month-day-nano interval written as
+// "<months>M<days>d<nanoseconds>ns", e.g. "1M2d3ns". Any component may be
+// omitted.
+func parseMonthDayNanoInterval(s string) (arrow.MonthDayNanoInterval, error) {
+	v, err := parseInterval(s, []string{"M", "d", "ns"}, []int{32, 32, 64})
+	if err != nil {
+		return arrow.MonthDayNanoInterval{}, err
 	}
-	return uint16(v)
+	return arrow.MonthDayNanoInterval{Months: int32(v[0]), Days: int32(v[1]), Nanoseconds: v[2]}, nil
 }
 
-func (r *Reader) readU32(str string) uint32 {
-	v, err := strconv.ParseUint(str, 10, 32)
-	if err != nil && r.err == nil {
-		r.err = err
-		return 0
+// parseInterval parses the components of an interval, each written as an
+// integer of the given bit size followed by its unit, in the order of units.
+func parseInterval(s string, units []string, bits []int) ([]int64, error) {
+	if s == "" {
+		return nil, fmt.Errorf("invalid interval %q", s)
 	}
-	return uint32(v)
-}
+	vals := make([]int64, len(units))
+	next := 0 // index of the next unit allowed
+	for rest := s; rest != ""; {
+		n := 0
+		if rest[0] == '-' || rest[0] == '+' {
+			n++
+		}
+		for n < len(rest) && '0' <= rest[n] && rest[n] <= '9' {
+			n++
+		}
+		u := n
+		for u < len(rest) && (rest[u] < '0' || '9' < rest[u]) && rest[u] != '-' && rest[u] != '+' {
+			u++
+		}
 
-func (r *Reader) readU64(str string) uint64 {
-	v, err := strconv.ParseUint(str, 10, 64)
-	if err != nil && r.err == nil {
-		r.err = err
-		return 0
+		k := next
+		for k < len(units) && units[k] != rest[n:u] {
+			k++
+		}
+		if k == len(units) {
+			return nil, fmt.Errorf("invalid interval %q", s)
+		}
+		v, err := strconv.ParseInt(rest[:n], 10, bits[k])
+		if err != nil {
+			return nil, err
+		}
+		vals[k] = v
+		next = k + 1
+		rest = rest[u:]
 	}
-	return uint64(v)
+	return vals, nil
 }
 
-func (r *Reader) readF32(str string) float32 {
-	v, err := strconv.ParseFloat(str, 32)
-	if err != nil && r.err == nil {
-		r.err = err
-		return 0
+// appendCustom appends v, the value returned by the custom parser of field i,
+// to the builder of that field. Values of the wrong type are appended as nulls.
+func (r *Reader) appendCustom(i int, v interface{}) {
+	b := r.bld.Field(i)
+	n := b.Len()
+	err := array.AppendSlice(b, []interface{}{v})
+	if b.Len() == n {
+		b.AppendNull()
+	}
+	if err != nil {
+		line, _ := r.r.FieldPos(0)
+		r.report(line, i, err)
 	}
-	return float32(v)
 }
 
-func (r *Reader) readF64(str string) float64 {
-	v, err := strconv.ParseFloat(str, 64)
-	if err != nil && r.err == nil {
-		r.err = err
-		return 0
+// report records a malformed row (if col is negative) or value.
+// With the FailOnError policy, the first one becomes the error of the reader.
+func (r *Reader) report(line, col int, err error) {
+	e := &RowError{File: r.file, Line: line, Column: col, Err: err}
+	if col >= 0 {
+		e.Field = r.schema.Field(col).Name
+	}
+	if r.policy == FailOnError {
+		if r.err != nil {
+			return
+		}
+		r.err = e
+		r.done = true
 	}
-	return float64(v)
+	r.rowErrs = append(r.rowErrs, e)
 }
 
 // Retain increases the reference count by 1.
@@ -345,6 +708,7 @@ func (r *Reader) Release() {
 		if r.cur != nil {
 			r.cur.Release()
 		}
+		r.closeInput()
 	}
 }
 
@@ -18,11 +18,19 @@ package csv_test
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"strconv"
+	"strings"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
 	"github.com/apache/arrow/go/arrow/csv"
 	"github.com/apache/arrow/go/arrow/memory"
 )
@@ -563,3 +571,458 @@ func benchRead(b *testing.B, raw []byte, rows, cols, chunks int) {
 		}
 	}
 }
+
+func TestCSVReaderWithColumnParser(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	raw := `Y;1,5;1000
+N;-2,25;0
+?;3;1500
+`
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "ok", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+			{Name: "f64", Type: arrow.PrimitiveTypes.Float64},
+			{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Millisecond}},
+		},
+		nil,
+	)
+
+	yesNo := func(s string) (interface{}, error) {
+		switch s {
+		case "Y":
+			return true, nil
+		case "N":
+			return false, nil
+		}
+		return nil, nil
+	}
+	decimalComma := func(s string) (interface{}, error) {
+		return strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+	}
+	epochMillis := func(s string) (interface{}, error) {
+		ms, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return time.Unix(0, ms*int64(time.Millisecond)), nil
+	}
+
+	r := csv.NewReader(bytes.NewReader([]byte(raw)), schema,
+		csv.WithAllocator(mem), csv.WithComma(';'), csv.WithChunk(-1),
+		csv.WithColumnParser("ok", yesNo),
+		csv.WithColumnParser("f64", decimalComma),
+		csv.WithColumnParser("ts", epochMillis),
+	)
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("could not read record: %v", r.Err())
+	}
+	rec := r.Record()
+	for i, want := range []string{"[true false (null)]", "[1.5 -2.25 3]", "[1000 0 1500]"} {
+		vs := make([]string, rec.Column(i).Len())
+		for j := range vs {
+			vs[j] = array.FormatValue(rec.Column(i), j)
+		}
+		if got := "[" + strings.Join(vs, " ") + "]"; got != want {
+			t.Fatalf("invalid column %q: got=%s, want=%s", rec.ColumnName(i), got, want)
+		}
+	}
+	if r.Err() != nil {
+		t.Fatalf("unexpected error: %v", r.Err())
+	}
+
+	// parser errors are reported as errors of the reader.
+	r = csv.NewReader(bytes.NewReader([]byte("Y;x;1\n")), schema,
+		csv.WithAllocator(mem), csv.WithComma(';'),
+		csv.WithColumnParser("ok", yesNo),
+		csv.WithColumnParser("f64", decimalComma),
+		csv.WithColumnParser("ts", epochMillis),
+	)
+	defer r.Release()
+	r.Next()
+	want := `arrow/csv: line 1, column 1 (f64): strconv.ParseFloat: parsing "x": invalid syntax`
+	if err := r.Err(); err == nil || err.Error() != want {
+		t.Fatalf("invalid error: got=%v, want=%s", err, want)
+	}
+
+	func() {
+		defer func() {
+			e := recover()
+			if e == nil {
+				t.Fatalf("expected a panic for an unknown field")
+			}
+		}()
+		csv.NewReader(bytes.NewReader(nil), schema, csv.WithColumnParser("nope", yesNo))
+	}()
+}
+
+func TestCSVReaderErrorPolicy(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	raw := `1;1.5;a
+x;2.5;b
+3;3.5
+4;y;d;extra
+5;"z"z;e
+6;6.5;f
+`
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "i64", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+			{Name: "f64", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+			{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+		},
+		nil,
+	)
+
+	for _, tc := range []struct {
+		name   string
+		policy csv.ErrorPolicy
+		want   string
+		errs   []string
+		err    string
+	}{
+		{
+			name:   "fail",
+			policy: csv.FailOnError,
+			want:   "",
+			errs:   []string{`arrow/csv: line 2, column 0 (i64): strconv.ParseInt: parsing "x": invalid syntax`},
+			err:    `arrow/csv: line 2, column 0 (i64): strconv.ParseInt: parsing "x": invalid syntax`,
+		},
+		{
+			name:   "skip",
+			policy: csv.SkipRow,
+			want: `rec[0]["i64"]: [1 6]
+rec[1]["f64"]: [1.5 6.5]
+rec[2]["str"]: ["a" "f"]
+`,
+			errs: []string{
+				`arrow/csv: line 2, column 0 (i64): strconv.ParseInt: parsing "x": invalid syntax`,
+				`arrow/csv: line 3: number of records mismatch`,
+				`arrow/csv: line 4: number of records mismatch`,
+				`arrow/csv: line 4, column 1 (f64): strconv.ParseFloat: parsing "y": invalid syntax`,
+				`arrow/csv: line 5: extraneous or missing " in quoted-field`,
+			},
+		},
+		{
+			name:   "null-fill",
+			policy: csv.NullFill,
+			want: `rec[0]["i64"]: [1 (null) 3 4 6]
+rec[1]["f64"]: [1.5 2.5 3.5 (null) 6.5]
+rec[2]["str"]: ["a" "b" (null) "d" "f"]
+`,
+			errs: []string{
+				`arrow/csv: line 2, column 0 (i64): strconv.ParseInt: parsing "x": invalid syntax`,
+				`arrow/csv: line 3: number of records mismatch`,
+				`arrow/csv: line 4: number of records mismatch`,
+				`arrow/csv: line 4, column 1 (f64): strconv.ParseFloat: parsing "y": invalid syntax`,
+				`arrow/csv: line 5: extraneous or missing " in quoted-field`,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := csv.NewReader(bytes.NewReader([]byte(raw)), schema,
+				csv.WithAllocator(mem), csv.WithComma(';'), csv.WithChunk(-1),
+				csv.WithErrorPolicy(tc.policy),
+			)
+			defer r.Release()
+
+			out := new(bytes.Buffer)
+			for r.Next() {
+				rec := r.Record()
+				for i, col := range rec.Columns() {
+					fmt.Fprintf(out, "rec[%d][%q]: %v\n", i, rec.ColumnName(i), col)
+				}
+			}
+			if got := out.String(); got != tc.want {
+				t.Fatalf("invalid output:\ngot= %s\nwant=%s\n", got, tc.want)
+			}
+
+			var errs []string
+			for _, e := range r.RowErrors() {
+				errs = append(errs, e.Error())
+			}
+			if got, want := strings.Join(errs, "\n"), strings.Join(tc.errs, "\n"); got != want {
+				t.Fatalf("invalid row errors:\ngot= %s\nwant=%s\n", got, want)
+			}
+
+			switch err := r.Err(); {
+			case tc.err == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tc.err != "" && (err == nil || err.Error() != tc.err):
+				t.Fatalf("invalid error: got=%v, want=%s", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestCSVReaderIntervals(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	raw := `1500;3;1d500ms;1M2d3ns
+-2;-1;-1d;5d
+`
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "dur", Type: arrow.FixedWidthTypes.Duration_ms},
+			{Name: "m", Type: arrow.FixedWidthTypes.MonthInterval},
+			{Name: "dt", Type: arrow.FixedWidthTypes.DayTimeInterval},
+			{Name: "mdn", Type: arrow.FixedWidthTypes.MonthDayNanoInterval},
+		},
+		nil,
+	)
+
+	r := csv.NewReader(bytes.NewReader([]byte(raw)), schema,
+		csv.WithAllocator(mem), csv.WithComma(';'), csv.WithChunk(-1),
+	)
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("could not read record: %v", r.Err())
+	}
+	rec := r.Record()
+	for i, want := range []string{"[1500 -2]", "[3 -1]", "[1d500ms -1d0ms]", "[1M2d3ns 0M5d0ns]"} {
+		if got := fmt.Sprint(rec.Column(i)); got != want {
+			t.Fatalf("invalid column %q: got=%s, want=%s", rec.ColumnName(i), got, want)
+		}
+	}
+
+	// written intervals are read back.
+	out := new(bytes.Buffer)
+	if err := csv.NewWriter(out, schema, csv.WithComma(';')).Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := out.String(), "1500;3;1d500ms;1M2d3ns\n-2;-1;-1d0ms;0M5d0ns\n"; got != want {
+		t.Fatalf("invalid output:\ngot=%q\nwant=%q", got, want)
+	}
+
+	for _, tc := range []struct {
+		raw  string
+		want string
+	}{
+		{"1;1;1ms1d;0ns", `arrow/csv: line 1, column 2 (dt): invalid interval "1ms1d"`},
+		{"1;1;1d;3h", `arrow/csv: line 1, column 3 (mdn): invalid interval "3h"`},
+		{"1;1;d;0ns", `arrow/csv: line 1, column 2 (dt): strconv.ParseInt: parsing "": invalid syntax`},
+	} {
+		r := csv.NewReader(strings.NewReader(tc.raw), schema, csv.WithAllocator(mem), csv.WithComma(';'))
+		r.Next()
+		if err := r.Err(); err == nil || err.Error() != tc.want {
+			t.Errorf("%q: invalid error: got=%v, want=%s", tc.raw, err, tc.want)
+		}
+		r.Release()
+	}
+}
+
+func TestCSVReaderFloat16(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	raw := "0.1\n-2.5e2\n65519\n1e6\n"
+	schema := arrow.NewSchema(
+		[]arrow.Field{{Name: "f16", Type: arrow.FixedWidthTypes.Float16}},
+		nil,
+	)
+
+	r := csv.NewReader(strings.NewReader(raw), schema, csv.WithAllocator(mem), csv.WithChunk(-1))
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("could not read record: %v", r.Err())
+	}
+	rec := r.Record()
+	if got, want := fmt.Sprint(rec.Column(0)), "[0.1 -250 65500 +Inf]"; got != want {
+		t.Fatalf("invalid column: got=%s, want=%s", got, want)
+	}
+
+	out := new(bytes.Buffer)
+	if err := csv.NewWriter(out, schema).Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := out.String(), "0.1\n-250\n65500\n+Inf\n"; got != want {
+		t.Fatalf("invalid output:\ngot=%q\nwant=%q", got, want)
+	}
+}
+
+func TestCSVReaderFixedSize(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	raw := `YWI=,"[1,2.5,-3]"
+,
+Y2Q=,"[ 4, null, 6 ]"
+`
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "fsb", Type: &arrow.FixedSizeBinaryType{ByteWidth: 2}, Nullable: true},
+			{Name: "vec", Type: arrow.FixedSizeListOf(3, arrow.PrimitiveTypes.Float32), Nullable: true},
+		},
+		nil,
+	)
+
+	r := csv.NewReader(strings.NewReader(raw), schema, csv.WithAllocator(mem), csv.WithChunk(-1))
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("could not read record: %v", r.Err())
+	}
+	rec := r.Record()
+	if got, want := fmt.Sprint(rec.Column(0)), `["ab" (null) "cd"]`; got != want {
+		t.Fatalf("invalid column 0: got=%s, want=%s", got, want)
+	}
+	if got, want := fmt.Sprint(rec.Column(1)), "[[1 2.5 -3] (null) [4 (null) 6]]"; got != want {
+		t.Fatalf("invalid column 1: got=%s, want=%s", got, want)
+	}
+
+	out := new(bytes.Buffer)
+	if err := csv.NewWriter(out, schema).Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := out.String(), "YWI=,\"[1,2.5,-3]\"\n,\nY2Q=,\"[4,null,6]\"\n"; got != want {
+		t.Fatalf("invalid output:\ngot=%q\nwant=%q", got, want)
+	}
+
+	for _, tc := range []struct {
+		raw  string
+		want string
+	}{
+		{`YWJj,"[1,2,3]"`, `arrow/csv: line 1, column 0 (fsb): invalid fixed size binary length 3, expected 2`},
+		{`YWI=,"[1,2]"`, `arrow/csv: line 1, column 1 (vec): invalid list length 2, expected 3`},
+		{`YWI=,"1,2,3"`, `arrow/csv: line 1, column 1 (vec): invalid fixed size list "1,2,3"`},
+		{`YWI=,"[1,x,3]"`, `arrow/csv: line 1, column 1 (vec): strconv.ParseFloat: parsing "x": invalid syntax`},
+	} {
+		r := csv.NewReader(strings.NewReader(tc.raw), schema, csv.WithAllocator(mem))
+		if r.Next() {
+			t.Fatalf("%s: unexpected record", tc.raw)
+		}
+		if err := r.Err(); err == nil || err.Error() != tc.want {
+			t.Fatalf("%s: got error %v, want %s", tc.raw, err, tc.want)
+		}
+		r.Release()
+	}
+}
+
+func TestCSVReaderWithContext(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "i64", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := csv.NewReader(strings.NewReader("1\n2\n3\n4\n"), schema, csv.WithAllocator(mem), csv.WithChunk(2), csv.WithContext(ctx))
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("could not read record: %v", r.Err())
+	}
+	if got, want := r.Record().NumRows(), int64(2); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+
+	cancel()
+	if r.Next() {
+		t.Fatalf("unexpected record after cancellation")
+	}
+	if got, want := r.Err(), context.Canceled; got != want {
+		t.Fatalf("invalid error: got=%v, want=%v", got, want)
+	}
+}
+
+func TestCSVReaderMulti(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "i64", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "str", Type: arrow.BinaryTypes.String},
+		},
+		nil,
+	)
+
+	fsys := fstest.MapFS{
+		"data/part-0000.csv": {Data: []byte("i64;str\n1;a\n2;b\n")},
+		"data/part-0001.csv": {Data: []byte("\ufeffi64;str\n3;c")}, // no trailing newline
+		"data/part-0002.csv": {Data: []byte("")},
+		"data/part-0003.csv": {Data: []byte("i64;str\n4;d\n5;e\n")},
+		"data/README":        {Data: []byte("not a csv file")},
+	}
+
+	read := func(r *csv.Reader) string {
+		out := new(bytes.Buffer)
+		for r.Next() {
+			rec := r.Record()
+			fmt.Fprintf(out, "%v %v\n", rec.Column(0), rec.Column(1))
+		}
+		return out.String()
+	}
+
+	r, err := csv.NewReaderFS(fsys, "data/part-*.csv", schema,
+		csv.WithAllocator(mem), csv.WithComma(';'), csv.WithHeader(true), csv.WithChunk(2),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := read(r)
+	if err := r.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r.Release()
+	if want := "[1 2] [\"a\" \"b\"]\n[3 4] [\"c\" \"d\"]\n[5] [\"e\"]\n"; got != want {
+		t.Fatalf("invalid output:\ngot= %s\nwant=%s\n", got, want)
+	}
+
+	r = csv.NewMultiReader(
+		[]io.Reader{strings.NewReader("1;a\n"), strings.NewReader("2;b\n3;c\n")}, schema,
+		csv.WithAllocator(mem), csv.WithComma(';'), csv.WithChunk(-1),
+	)
+	if got, want := read(r), "[1 2 3] [\"a\" \"b\" \"c\"]\n"; got != want {
+		t.Fatalf("invalid output:\ngot= %s\nwant=%s\n", got, want)
+	}
+	r.Release()
+
+	// the headers of all the files must match the schema.
+	fsys["data/part-0002.csv"] = &fstest.MapFile{Data: []byte("i64;string\n6;f\n")}
+	r, err = csv.NewReaderFS(fsys, "data/part-*.csv", schema,
+		csv.WithAllocator(mem), csv.WithComma(';'), csv.WithHeader(true), csv.WithErrorPolicy(csv.SkipRow),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	read(r)
+	want := `arrow/csv: data/part-0002.csv: line 1: header mismatch: got ["i64" "string"], want ["i64" "str"]`
+	if err := r.Err(); err == nil || err.Error() != want {
+		t.Fatalf("invalid error: got=%v, want=%s", err, want)
+	}
+	if !errors.Is(r.Err(), csv.ErrMismatchHeader) {
+		t.Fatalf("error should be a header mismatch: %v", r.Err())
+	}
+	r.Release()
+
+	// row errors report the file of the row.
+	fsys["data/part-0002.csv"] = &fstest.MapFile{Data: []byte("i64;str\nx;f\n")}
+	r, err = csv.NewReaderFS(fsys, "data/part-*.csv", schema,
+		csv.WithAllocator(mem), csv.WithComma(';'), csv.WithHeader(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	read(r)
+	want = `arrow/csv: data/part-0002.csv: line 2, column 0 (i64): strconv.ParseInt: parsing "x": invalid syntax`
+	if err := r.Err(); err == nil || err.Error() != want {
+		t.Fatalf("invalid error: got=%v, want=%s", err, want)
+	}
+	r.Release()
+
+	if _, err := csv.NewReaderFS(fsys, "data/*.tsv", schema); err == nil {
+		t.Fatalf("expected an error for a pattern without match")
+	}
+	if _, err := csv.NewReaderFS(fsys, "data/[", schema); err == nil {
+		t.Fatalf("expected an error for a malformed pattern")
+	}
+}
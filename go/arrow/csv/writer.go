@@ -17,9 +17,14 @@
 package csv
 
 import (
+	"bufio"
+	"encoding/base64"
 	"encoding/csv"
 	"fmt"
 	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/apache/arrow/go/arrow"
 	"github.com/apache/arrow/go/arrow/array"
@@ -28,7 +33,12 @@ import (
 // Writer wraps encoding/csv.Writer and writes array.Record based on a schema.
 type Writer struct {
 	w      *csv.Writer
+	out    *bufio.Writer
 	schema *arrow.Schema
+
+	quote   bool // whether to quote the values of string columns
+	bom     bool // whether to start the output with a byte order mark
+	started bool // whether a record has been written
 }
 
 // NewWriter returns a writer that writes array.Records to the CSV file
@@ -39,7 +49,8 @@ type Writer struct {
 func NewWriter(w io.Writer, schema *arrow.Schema, opts ...Option) *Writer {
 	validate(schema)
 
-	ww := &Writer{w: csv.NewWriter(w), schema: schema}
+	out := bufio.NewWriter(w)
+	ww := &Writer{w: csv.NewWriter(out), out: out, schema: schema}
 	for _, opt := range opts {
 		opt(ww)
 	}
@@ -107,6 +118,11 @@ func (w *Writer) Write(record array.Record) error {
 			for i := 0; i < arr.Len(); i++ {
 				recs[i][j] = fmt.Sprintf("%v", arr.Value(i))
 			}
+		case *arrow.Float16Type:
+			arr := col.(*array.Float16)
+			for i := 0; i < arr.Len(); i++ {
+				recs[i][j] = arr.Value(i).String()
+			}
 		case *arrow.Float32Type:
 			arr := col.(*array.Float32)
 			for i := 0; i < arr.Len(); i++ {
@@ -122,8 +138,165 @@ func (w *Writer) Write(record array.Record) error {
 			for i := 0; i < arr.Len(); i++ {
 				recs[i][j] = fmt.Sprintf("%v", arr.Value(i))
 			}
+		case *arrow.DurationType:
+			arr := col.(*array.Duration)
+			for i := 0; i < arr.Len(); i++ {
+				recs[i][j] = fmt.Sprintf("%v", arr.Value(i))
+			}
+		case *arrow.MonthIntervalType:
+			arr := col.(*array.MonthInterval)
+			for i := 0; i < arr.Len(); i++ {
+				recs[i][j] = fmt.Sprintf("%v", arr.Value(i))
+			}
+		case *arrow.DayTimeIntervalType:
+			arr := col.(*array.DayTimeInterval)
+			for i := 0; i < arr.Len(); i++ {
+				recs[i][j] = arr.Value(i).String()
+			}
+		case *arrow.MonthDayNanoIntervalType:
+			arr := col.(*array.MonthDayNanoInterval)
+			for i := 0; i < arr.Len(); i++ {
+				recs[i][j] = arr.Value(i).String()
+			}
+		case *arrow.FixedSizeBinaryType:
+			arr := col.(*array.FixedSizeBinary)
+			for i := 0; i < arr.Len(); i++ {
+				if arr.IsValid(i) {
+					recs[i][j] = base64.StdEncoding.EncodeToString(arr.Value(i))
+				}
+			}
+		case *arrow.FixedSizeListType:
+			arr := col.(*array.FixedSizeList)
+			for i := 0; i < arr.Len(); i++ {
+				if arr.IsValid(i) {
+					recs[i][j] = formatFixedSizeList(arr, i)
+				}
+			}
+		}
+	}
+
+	if w.bom && !w.started {
+		if _, err := w.out.WriteString(utf8BOM); err != nil {
+			return err
+		}
+	}
+	w.started = true
+
+	if !w.quote {
+		return w.w.WriteAll(recs)
+	}
+
+	// encoding/csv only quotes the fields that need it: rows are written
+	// by hand, following the same rules for the fields of other columns.
+	strs := make([]bool, record.NumCols())
+	for j, col := range record.Columns() {
+		_, strs[j] = col.DataType().(*arrow.StringType)
+	}
+	for i, row := range recs {
+		for j, field := range row {
+			if j > 0 {
+				w.out.WriteRune(w.w.Comma)
+			}
+			if strs[j] && record.Column(j).IsValid(i) || w.fieldNeedsQuotes(field) {
+				w.writeQuoted(field)
+				continue
+			}
+			w.out.WriteString(field)
+		}
+		if w.w.UseCRLF {
+			w.out.WriteString("\r\n")
+		} else {
+			w.out.WriteByte('\n')
+		}
+	}
+	return w.out.Flush()
+}
+
+// formatFixedSizeList formats the i-th list of arr as "[v1,v2,...]", writing
+// null elements as "null".
+func formatFixedSizeList(arr *array.FixedSizeList, i int) string {
+	var (
+		o        strings.Builder
+		beg, end = arr.ValueOffsets(i)
+		values   = arr.ListValues()
+	)
+	o.WriteByte('[')
+	for k := int(beg); k < int(end); k++ {
+		if k > int(beg) {
+			o.WriteByte(',')
+		}
+		if values.IsNull(k) {
+			o.WriteString("null")
+			continue
+		}
+		switch values := values.(type) {
+		case *array.Boolean:
+			fmt.Fprintf(&o, "%v", values.Value(k))
+		case *array.Int8:
+			fmt.Fprintf(&o, "%v", values.Value(k))
+		case *array.Int16:
+			fmt.Fprintf(&o, "%v", values.Value(k))
+		case *array.Int32:
+			fmt.Fprintf(&o, "%v", values.Value(k))
+		case *array.Int64:
+			fmt.Fprintf(&o, "%v", values.Value(k))
+		case *array.Uint8:
+			fmt.Fprintf(&o, "%v", values.Value(k))
+		case *array.Uint16:
+			fmt.Fprintf(&o, "%v", values.Value(k))
+		case *array.Uint32:
+			fmt.Fprintf(&o, "%v", values.Value(k))
+		case *array.Uint64:
+			fmt.Fprintf(&o, "%v", values.Value(k))
+		case *array.Float16:
+			o.WriteString(values.Value(k).String())
+		case *array.Float32:
+			fmt.Fprintf(&o, "%v", values.Value(k))
+		case *array.Float64:
+			fmt.Fprintf(&o, "%v", values.Value(k))
 		}
 	}
+	o.WriteByte(']')
+	return o.String()
+}
 
-	return w.w.WriteAll(recs)
+// fieldNeedsQuotes reports whether field must be quoted, as encoding/csv
+// does.
+func (w *Writer) fieldNeedsQuotes(field string) bool {
+	switch {
+	case field == "":
+		return false
+	case field == `\.`:
+		return true
+	case strings.ContainsRune(field, w.w.Comma), strings.ContainsAny(field, "\"\r\n"):
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(field)
+	return unicode.IsSpace(r)
+}
+
+// writeQuoted writes field between double quotes, doubling the quotes it
+// holds and honoring UseCRLF for the line breaks it holds, as encoding/csv
+// does.
+func (w *Writer) writeQuoted(field string) {
+	w.out.WriteByte('"')
+	for _, r := range field {
+		switch r {
+		case '"':
+			w.out.WriteString(`""`)
+		case '\r':
+			if !w.w.UseCRLF {
+				w.out.WriteByte('\r')
+			}
+		case '\n':
+			if w.w.UseCRLF {
+				w.out.WriteString("\r\n")
+			} else {
+				w.out.WriteByte('\n')
+			}
+		default:
+			w.out.WriteRune(r)
+		}
+	}
+	w.out.WriteByte('"')
 }
@@ -160,3 +160,99 @@ true;1;1;1;1;2;2;2;2;0.2;0.2;str-2
 		t.Fatalf("invalid output:\ngot=%s\nwant=%s\n", got, want)
 	}
 }
+
+func TestCSVWriterOptions(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "i64", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(pool, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2, 3, 4, 5}, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues(
+		[]string{"a", "", "", `say "hi"; ok`, " b\nc"},
+		[]bool{true, true, false, true, true},
+	)
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	for _, tc := range []struct {
+		name  string
+		comma rune
+		opts  []csv.Option
+		want  string
+	}{
+		{
+			name:  "default",
+			comma: ',',
+			want:  "1,a\n2,\n3,\n4,\"say \"\"hi\"\"; ok\"\n5,\" b\nc\"\n",
+		},
+		{
+			name:  "bom",
+			comma: ',',
+			opts:  []csv.Option{csv.WithBOM(true)},
+			want:  "\ufeff1,a\n2,\n3,\n4,\"say \"\"hi\"\"; ok\"\n5,\" b\nc\"\n",
+		},
+		{
+			name:  "quote",
+			comma: ';',
+			opts:  []csv.Option{csv.WithComma(';'), csv.WithQuoteStrings(true)},
+			want:  "1;\"a\"\n2;\"\"\n3;\n4;\"say \"\"hi\"\"; ok\"\n5;\" b\nc\"\n",
+		},
+		{
+			name:  "excel",
+			comma: ';',
+			opts: []csv.Option{
+				csv.WithComma(';'), csv.WithQuoteStrings(true),
+				csv.WithCRLF(true), csv.WithBOM(true),
+			},
+			want: "\ufeff1;\"a\"\r\n2;\"\"\r\n3;\r\n4;\"say \"\"hi\"\"; ok\"\r\n5;\" b\r\nc\"\r\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := new(bytes.Buffer)
+			w := csv.NewWriter(f, schema, tc.opts...)
+			if err := w.Write(rec); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := f.String(), tc.want; got != want {
+				t.Fatalf("invalid output:\ngot= %q\nwant=%q\n", got, want)
+			}
+
+			// the byte order mark only starts the output.
+			if err := w.Write(rec); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := f.String(), tc.want+strings.TrimPrefix(tc.want, "\ufeff"); got != want {
+				t.Fatalf("invalid output:\ngot= %q\nwant=%q\n", got, want)
+			}
+
+			// the output reads back, with the byte order mark skipped.
+			r := csv.NewReader(strings.NewReader(tc.want), schema,
+				csv.WithAllocator(pool), csv.WithComma(tc.comma), csv.WithChunk(-1),
+			)
+			defer r.Release()
+
+			if !r.Next() {
+				t.Fatalf("expected a record: %v", r.Err())
+			}
+			got := r.Record()
+			if got, want := got.Column(0).(*array.Int64).Int64Values(), []int64{1, 2, 3, 4, 5}; fmt.Sprint(got) != fmt.Sprint(want) {
+				t.Fatalf("invalid i64 values: got=%v, want=%v", got, want)
+			}
+			str := got.Column(1).(*array.String)
+			if got, want := str.Value(3), `say "hi"; ok`; got != want {
+				t.Fatalf("invalid str value: got=%q, want=%q", got, want)
+			}
+		})
+	}
+}
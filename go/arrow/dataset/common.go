@@ -0,0 +1,113 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataset
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/expr"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// Option configures the discovery of a dataset, or a scanner.
+type Option func(config)
+type config interface{}
+
+// WithGlob specifies the pattern, in the syntax of path.Match, that the
+// names of the files of a dataset must match, such as "*.parquet".
+// By default, all the files are part of the dataset.
+func WithGlob(pattern string) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Dataset:
+			cfg.glob = pattern
+		default:
+			panic(fmt.Errorf("arrow/dataset: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithHivePartitioning specifies that the directories of the files named
+// "key=value" hold the values of partition keys. The fields of schema, which
+// may be nil, give the types of the keys, parsed from their values as by
+// compute.Cast. The other keys are int64 if all their values are integers,
+// and utf8 otherwise.
+func WithHivePartitioning(schema *arrow.Schema) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Dataset:
+			cfg.hive = true
+			cfg.partSchema = schema
+		default:
+			panic(fmt.Errorf("arrow/dataset: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithSchema specifies the schema of the files of a dataset, instead of
+// merging the schemas inspected from each file.
+// Columns of the schema missing from a file are read as nulls.
+func WithSchema(schema *arrow.Schema) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Dataset:
+			cfg.fileSchema = schema
+		default:
+			panic(fmt.Errorf("arrow/dataset: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithAllocator specifies the Arrow memory allocator used while building records.
+func WithAllocator(mem memory.Allocator) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Scanner:
+			cfg.mem = mem
+		default:
+			panic(fmt.Errorf("arrow/dataset: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithColumns specifies the names of the columns of the records of a
+// scanner, in order. By default, all the columns of the dataset are read.
+func WithColumns(names ...string) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Scanner:
+			cfg.names = names
+		default:
+			panic(fmt.Errorf("arrow/dataset: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithFilter specifies the predicate selecting the rows of the records of a
+// scanner. The columns referenced by the filter need not be selected by
+// WithColumns.
+func WithFilter(e expr.Expr) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Scanner:
+			cfg.filter = e
+		default:
+			panic(fmt.Errorf("arrow/dataset: unknown config type %T", cfg))
+		}
+	}
+}
@@ -0,0 +1,334 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dataset reads collections of files as a single source of records.
+//
+// A Dataset is discovered from the files of a directory tree, whose
+// directories may hold hive-style partitions such as "year=2021/month=03".
+// The schemas of the files are merged, and the partition keys become
+// columns of the dataset.
+// A Scanner reads the records of a dataset, selecting their columns and
+// filtering their rows with an expression. The filter is also used to skip
+// partitions, and the Parquet row groups whose statistics prove that none of
+// their rows can match.
+//
+// The Parquet and CSV formats are supported.
+package dataset
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+)
+
+// NullPartition is the directory value of a null partition key.
+const NullPartition = "__HIVE_DEFAULT_PARTITION__"
+
+// Dataset is a collection of files of the same format, read as records of a
+// single schema.
+type Dataset struct {
+	fsys   fs.FS
+	format Format
+	schema *arrow.Schema // schema of the files, then of the partition keys
+	parts  *arrow.Schema // schema of the partition keys
+	frags  []fragment
+
+	// discovery options.
+	glob       string
+	hive       bool
+	partSchema *arrow.Schema
+	fileSchema *arrow.Schema
+}
+
+// fragment is a file of a dataset.
+type fragment struct {
+	path  string
+	parts map[string]scalar.Scalar // values of the partition keys
+}
+
+// Discover returns the dataset of the files of format under the directory
+// root of fsys.
+//
+// Files and directories whose name starts with "." or "_" are ignored.
+// See WithGlob, WithHivePartitioning and WithSchema for the discovery options.
+//
+// Discover returns an error if no file is found and no schema is given, if a
+// partition value cannot be parsed, or if the schemas of the files have
+// fields with the same name and incompatible types.
+func Discover(fsys fs.FS, root string, format Format, opts ...Option) (*Dataset, error) {
+	d := &Dataset{fsys: fsys, format: format}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	var paths []string
+	err := fs.WalkDir(fsys, root, func(p string, e fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if p != root && hidden(e.Name()) {
+			if e.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if e.IsDir() {
+			return nil
+		}
+		if d.glob != "" {
+			ok, err := path.Match(d.glob, e.Name())
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+		}
+		paths = append(paths, p)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("arrow/dataset: could not list files of %q: %w", root, err)
+	}
+
+	parts, err := d.partitions(root, paths)
+	if err != nil {
+		return nil, err
+	}
+
+	d.frags = make([]fragment, len(paths))
+	for i, p := range paths {
+		d.frags[i] = fragment{path: p, parts: make(map[string]scalar.Scalar, len(parts.Fields()))}
+	}
+	if err := d.parsePartitions(root, parts); err != nil {
+		return nil, err
+	}
+
+	files := d.fileSchema
+	if files == nil {
+		if len(paths) == 0 {
+			return nil, fmt.Errorf("arrow/dataset: no files found in %q", root)
+		}
+		files, err = d.inspect()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fields := append([]arrow.Field(nil), files.Fields()...)
+	for _, f := range parts.Fields() {
+		if files.HasField(f.Name) {
+			return nil, fmt.Errorf("arrow/dataset: partition key %q is also a column of the files", f.Name)
+		}
+		fields = append(fields, f)
+	}
+	md := files.Metadata()
+	d.schema = arrow.NewSchema(fields, &md)
+	d.parts = parts
+	return d, nil
+}
+
+// Schema returns the schema of the dataset: the merged fields of the files,
+// followed by the partition keys.
+func (d *Dataset) Schema() *arrow.Schema { return d.schema }
+
+// Files returns the paths of the files of the dataset, in lexical order.
+func (d *Dataset) Files() []string {
+	paths := make([]string, len(d.frags))
+	for i, f := range d.frags {
+		paths[i] = f.path
+	}
+	return paths
+}
+
+// isPartition returns whether name is a partition key of d.
+func (d *Dataset) isPartition(name string) bool { return d.parts.HasField(name) }
+
+func hidden(name string) bool {
+	return strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+// hivePairs returns the key=value pairs of the directories of p, relative to
+// root, with their values unescaped.
+func hivePairs(root, p string) ([][2]string, error) {
+	dir := path.Dir(p)
+	if root != "." {
+		dir = strings.TrimPrefix(strings.TrimPrefix(dir, root), "/")
+	}
+
+	var pairs [][2]string
+	for _, seg := range strings.Split(dir, "/") {
+		i := strings.Index(seg, "=")
+		if i <= 0 {
+			continue
+		}
+		v, err := url.PathUnescape(seg[i+1:])
+		if err != nil {
+			return nil, fmt.Errorf("arrow/dataset: invalid partition directory %q: %w", seg, err)
+		}
+		pairs = append(pairs, [2]string{seg[:i], v})
+	}
+	return pairs, nil
+}
+
+// partitions returns the schema of the partition keys of the files.
+// The keys without a type given by WithHivePartitioning are int64 if all
+// their values are integers, and utf8 otherwise.
+func (d *Dataset) partitions(root string, paths []string) (*arrow.Schema, error) {
+	if !d.hive {
+		return arrow.NewSchema(nil, nil), nil
+	}
+
+	var (
+		keys []string
+		ints = make(map[string]bool)
+	)
+	for _, p := range paths {
+		pairs, err := hivePairs(root, p)
+		if err != nil {
+			return nil, err
+		}
+		for _, kv := range pairs {
+			isInt, seen := ints[kv[0]]
+			if !seen {
+				keys = append(keys, kv[0])
+				isInt = true
+			}
+			if kv[1] != NullPartition {
+				_, err := strconv.ParseInt(kv[1], 10, 64)
+				isInt = isInt && err == nil
+			}
+			ints[kv[0]] = isInt
+		}
+	}
+
+	fields := make([]arrow.Field, len(keys))
+	for i, k := range keys {
+		fields[i] = arrow.Field{Name: k, Type: arrow.BinaryTypes.String, Nullable: true}
+		switch {
+		case d.partSchema != nil && d.partSchema.HasField(k):
+			f, _ := d.partSchema.FieldByName(k)
+			fields[i].Type = f.Type
+		case ints[k]:
+			fields[i].Type = arrow.PrimitiveTypes.Int64
+		}
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+// parsePartitions sets the values of the partition keys of the fragments.
+// Keys missing from the directories of a file are null.
+func (d *Dataset) parsePartitions(root string, parts *arrow.Schema) error {
+	if !d.hive {
+		return nil
+	}
+	for i := range d.frags {
+		frag := &d.frags[i]
+		pairs, err := hivePairs(root, frag.path)
+		if err != nil {
+			return err
+		}
+		for _, f := range parts.Fields() {
+			frag.parts[f.Name] = scalar.MakeNullScalar(f.Type)
+		}
+		for _, kv := range pairs {
+			if kv[1] == NullPartition {
+				continue
+			}
+			f, _ := parts.FieldByName(kv[0])
+			v, err := parseValue(kv[1], f.Type)
+			if err != nil {
+				return fmt.Errorf("arrow/dataset: invalid value %q for partition key %q of %q: %w", kv[1], kv[0], frag.path, err)
+			}
+			frag.parts[kv[0]] = v
+		}
+	}
+	return nil
+}
+
+// parseValue returns the scalar of type dt parsed from s.
+func parseValue(s string, dt arrow.DataType) (scalar.Scalar, error) {
+	bld := array.NewStringBuilder(memory.DefaultAllocator)
+	defer bld.Release()
+	bld.Append(s)
+	str := bld.NewArray()
+	defer str.Release()
+
+	arr, err := compute.Cast(memory.DefaultAllocator, str, dt)
+	if err != nil {
+		return nil, err
+	}
+	defer arr.Release()
+	return scalar.GetScalar(arr, 0)
+}
+
+// inspect returns the merged schema of the files.
+func (d *Dataset) inspect() (*arrow.Schema, error) {
+	var (
+		fields []arrow.Field
+		index  = make(map[string]int)
+		md     arrow.Metadata
+	)
+	for i, frag := range d.frags {
+		schema, err := d.format.Inspect(d.fsys, frag.path)
+		if err != nil {
+			return nil, fmt.Errorf("arrow/dataset: could not inspect %q: %w", frag.path, err)
+		}
+		if i == 0 {
+			md = schema.Metadata()
+		}
+
+		for _, f := range schema.Fields() {
+			j, ok := index[f.Name]
+			if !ok {
+				// fields missing from the previous files are null there.
+				f.Nullable = f.Nullable || i > 0
+				index[f.Name] = len(fields)
+				fields = append(fields, f)
+				continue
+			}
+
+			cur := &fields[j]
+			switch {
+			case reflect.DeepEqual(cur.Type, f.Type):
+			case cur.Type.ID() == arrow.NULL:
+				cur.Type = f.Type
+			case f.Type.ID() == arrow.NULL:
+			default:
+				return nil, fmt.Errorf("arrow/dataset: field %q of %q has type %s, expected %s", f.Name, frag.path, f.Type.Name(), cur.Type.Name())
+			}
+			cur.Nullable = cur.Nullable || f.Nullable || f.Type.ID() == arrow.NULL
+		}
+
+		// fields missing from this file are null there.
+		for j := range fields {
+			if !schema.HasField(fields[j].Name) {
+				fields[j].Nullable = true
+			}
+		}
+	}
+	return arrow.NewSchema(fields, &md), nil
+}
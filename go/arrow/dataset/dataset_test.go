@@ -0,0 +1,408 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataset
+
+import (
+	"bytes"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/csv"
+	"github.com/apache/arrow/go/arrow/expr"
+	"github.com/apache/arrow/go/arrow/json"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/parquet"
+	"github.com/apache/arrow/go/arrow/scalar"
+)
+
+// parquetFile returns the Parquet file of the records of schema read from
+// line-delimited JSON rows.
+func parquetFile(t *testing.T, schema *arrow.Schema, rows []string, opts ...parquet.Option) *fstest.MapFile {
+	t.Helper()
+
+	r := json.NewReader(strings.NewReader(strings.Join(rows, "\n")), schema, json.WithChunk(-1))
+	defer r.Release()
+	if !r.Next() {
+		t.Fatalf("could not read rows: %v", r.Err())
+	}
+
+	var buf bytes.Buffer
+	w, err := parquet.NewWriter(&buf, schema, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(r.Record()); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &fstest.MapFile{Data: buf.Bytes()}
+}
+
+// scanAll returns the rows of the records of s, as line-delimited JSON.
+func scanAll(t *testing.T, s *Scanner) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := json.NewWriter(&buf, s.Schema())
+	for s.Next() {
+		if err := w.Write(s.Record()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+// countingFormat counts the files scanned by a format.
+type countingFormat struct {
+	Format
+	scanned []string
+}
+
+func (f *countingFormat) Scan(fsys fs.FS, path string, scan FileScan) (RecordReader, error) {
+	f.scanned = append(f.scanned, path)
+	return f.Format.Scan(fsys, path, scan)
+}
+
+func newTestFS(t *testing.T) fstest.MapFS {
+	v1 := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "amount", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	v2 := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "note", Type: arrow.BinaryTypes.String},
+		{Name: "amount", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+
+	return fstest.MapFS{
+		"sales/region=eu/year=2020/part-0.parquet": parquetFile(t, v1, []string{
+			`{"id": 1, "amount": 10.5}`,
+			`{"id": 2, "amount": null}`,
+		}),
+		"sales/region=eu/year=2021/part-0.parquet": parquetFile(t, v2, []string{
+			`{"id": 3, "note": "a", "amount": 1}`,
+			`{"id": 4, "note": "b", "amount": 2}`,
+			`{"id": 5, "note": "c", "amount": 3}`,
+			`{"id": 6, "note": "d", "amount": 4}`,
+		}, parquet.WithRowGroupSize(2)),
+		"sales/region=us%2Fwest/year=2021/part-0.parquet": parquetFile(t, v1, []string{
+			`{"id": 7, "amount": 100}`,
+		}),
+		"sales/region=__HIVE_DEFAULT_PARTITION__/year=2021/part-0.parquet": parquetFile(t, v1, []string{
+			`{"id": 8, "amount": 5}`,
+		}),
+		"sales/region=eu/year=2021/_SUCCESS":            &fstest.MapFile{},
+		"sales/region=eu/.tmp/part-1.parquet":           &fstest.MapFile{Data: []byte("garbage")},
+		"sales/region=eu/year=2020/README.md":           &fstest.MapFile{Data: []byte("not a data file")},
+		"other/year=1999/part-0.parquet":                &fstest.MapFile{Data: []byte("garbage")},
+		"sales/region=eu/year=2020/_metadata/x.parquet": &fstest.MapFile{Data: []byte("garbage")},
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	fsys := newTestFS(t)
+
+	d, err := Discover(fsys, "sales", ParquetFormat{}, WithGlob("*.parquet"), WithHivePartitioning(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"sales/region=__HIVE_DEFAULT_PARTITION__/year=2021/part-0.parquet",
+		"sales/region=eu/year=2020/part-0.parquet",
+		"sales/region=eu/year=2021/part-0.parquet",
+		"sales/region=us%2Fwest/year=2021/part-0.parquet",
+	}
+	if got := d.Files(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid files:\ngot= %q\nwant=%q", got, want)
+	}
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "amount", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "note", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "region", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "year", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+	}, nil)
+	if got := d.Schema(); !got.Equal(schema) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", got, schema)
+	}
+
+	// typed partition keys.
+	d, err = Discover(fsys, "sales", ParquetFormat{}, WithGlob("*.parquet"), WithHivePartitioning(
+		arrow.NewSchema([]arrow.Field{{Name: "year", Type: arrow.PrimitiveTypes.Int16}}, nil),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f, _ := d.Schema().FieldByName("year"); f.Type.ID() != arrow.INT16 {
+		t.Fatalf("invalid partition type: got=%s, want=int16", f.Type.Name())
+	}
+}
+
+func TestScanner(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	fsys := newTestFS(t)
+	format := &countingFormat{Format: ParquetFormat{}}
+	d, err := Discover(fsys, "sales", format, WithGlob("*.parquet"), WithHivePartitioning(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		opts    []Option
+		want    []string
+		scanned int
+	}{
+		{
+			name: "all",
+			want: []string{
+				`{"id":8,"amount":5,"note":null,"region":null,"year":2021}`,
+				`{"id":1,"amount":10.5,"note":null,"region":"eu","year":2020}`,
+				`{"id":2,"amount":null,"note":null,"region":"eu","year":2020}`,
+				`{"id":3,"amount":1,"note":"a","region":"eu","year":2021}`,
+				`{"id":4,"amount":2,"note":"b","region":"eu","year":2021}`,
+				`{"id":5,"amount":3,"note":"c","region":"eu","year":2021}`,
+				`{"id":6,"amount":4,"note":"d","region":"eu","year":2021}`,
+				`{"id":7,"amount":100,"note":null,"region":"us/west","year":2021}`,
+			},
+			scanned: 4,
+		},
+		{
+			name: "projection",
+			opts: []Option{WithColumns("year", "id")},
+			want: []string{
+				`{"year":2021,"id":8}`,
+				`{"year":2020,"id":1}`,
+				`{"year":2020,"id":2}`,
+				`{"year":2021,"id":3}`,
+				`{"year":2021,"id":4}`,
+				`{"year":2021,"id":5}`,
+				`{"year":2021,"id":6}`,
+				`{"year":2021,"id":7}`,
+			},
+			scanned: 4,
+		},
+		{
+			name: "partition-filter",
+			opts: []Option{
+				WithColumns("id", "note"),
+				WithFilter(expr.And(
+					expr.Equal(expr.Field("region"), expr.Lit(scalar.NewStringScalar("eu"))),
+					expr.Greater(expr.Field("year"), expr.Lit(scalar.NewInt64Scalar(2020))),
+					expr.GreaterEqual(expr.Field("amount"), expr.Lit(scalar.NewFloat64Scalar(3))),
+				)),
+			},
+			want: []string{
+				`{"id":5,"note":"c"}`,
+				`{"id":6,"note":"d"}`,
+			},
+			scanned: 1,
+		},
+		{
+			name: "column-filter",
+			opts: []Option{
+				WithColumns("id"),
+				WithFilter(expr.Or(
+					expr.Equal(expr.Field("note"), expr.Lit(scalar.NewStringScalar("b"))),
+					expr.Less(expr.Field("amount"), expr.Lit(scalar.NewFloat64Scalar(6))),
+				)),
+			},
+			want: []string{
+				`{"id":8}`,
+				`{"id":3}`,
+				`{"id":4}`,
+				`{"id":5}`,
+				`{"id":6}`,
+			},
+			scanned: 4,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			format.scanned = nil
+
+			s, err := d.NewScanner(append([]Option{WithAllocator(mem)}, tc.opts...)...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer s.Release()
+
+			want := strings.Join(tc.want, "\n") + "\n"
+			if got := scanAll(t, s); got != want {
+				t.Fatalf("invalid rows:\ngot=\n%s\nwant=\n%s", got, want)
+			}
+			if got := len(format.scanned); got != tc.scanned {
+				t.Fatalf("invalid number of scanned files: got=%d, want=%d (%q)", got, tc.scanned, format.scanned)
+			}
+		})
+	}
+}
+
+func TestScannerCSV(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	fsys := fstest.MapFS{
+		"logs/day=1/a.csv": &fstest.MapFile{Data: []byte("1;x\n2;y\n")},
+		"logs/day=2/b.csv": &fstest.MapFile{Data: []byte("3;z\n")},
+	}
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "n", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "s", Type: arrow.BinaryTypes.String},
+	}, nil)
+
+	format := CSVFormat{Schema: schema, Options: []csv.Option{csv.WithComma(';')}}
+	d, err := Discover(fsys, "logs", format, WithHivePartitioning(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := d.NewScanner(WithAllocator(mem), WithFilter(expr.NotEqual(expr.Field("n"), expr.Lit(scalar.NewInt64Scalar(2)))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Release()
+
+	want := `{"n":1,"s":"x","day":1}` + "\n" + `{"n":3,"s":"z","day":2}` + "\n"
+	if got := scanAll(t, s); got != want {
+		t.Fatalf("invalid rows:\ngot=\n%s\nwant=\n%s", got, want)
+	}
+}
+
+func TestMayMatch(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+	rg := parquet.RowGroup{
+		NumRows: 2,
+		Columns: []parquet.ColumnStatistics{
+			{Name: "id", HasMinMax: true, Min: int64(4), Max: int64(5)},
+			{Name: "name", HasMinMax: true, Min: "carol", Max: "dave"},
+			{Name: "score", HasNullCount: true, NullCount: 2},
+		},
+	}
+
+	var (
+		id    = expr.Field("id")
+		name  = expr.Field("name")
+		score = expr.Field("score")
+		i64   = func(v int64) expr.Expr { return expr.Lit(scalar.NewInt64Scalar(v)) }
+		i32   = func(v int32) expr.Expr { return expr.Lit(scalar.NewInt32Scalar(v)) }
+		str   = func(v string) expr.Expr { return expr.Lit(scalar.NewStringScalar(v)) }
+	)
+	for _, tc := range []struct {
+		pred expr.Expr
+		want bool
+	}{
+		{expr.Equal(id, i64(4)), true},
+		{expr.Equal(id, i64(6)), false},
+		{expr.Equal(id, i32(3)), false},
+		{expr.Less(id, i64(4)), false},
+		{expr.LessEqual(id, i64(4)), true},
+		{expr.Greater(id, i64(5)), false},
+		{expr.GreaterEqual(id, i64(5)), true},
+		{expr.Less(i64(5), id), false},
+		{expr.Greater(i64(5), id), true},
+		{expr.NotEqual(id, i64(4)), true},
+		{expr.Equal(name, str("bob")), false},
+		{expr.Equal(name, str("coco")), true},
+		{expr.Greater(score, expr.Lit(scalar.NewFloat64Scalar(0))), false},
+		{expr.Equal(id, str("x")), true},
+		{expr.Equal(id, expr.Field("name")), true},
+		{expr.And(expr.Equal(id, i64(4)), expr.Equal(name, str("bob"))), false},
+		{expr.Or(expr.Equal(id, i64(6)), expr.Equal(name, str("bob"))), true},
+	} {
+		t.Run(tc.pred.String(), func(t *testing.T) {
+			if got := mayMatch(mem, schema, rg, conjuncts(tc.pred)); got != tc.want {
+				t.Fatalf("invalid match: got=%v, want=%v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDatasetErrors(t *testing.T) {
+	fsys := newTestFS(t)
+
+	for _, tc := range []struct {
+		name string
+		root string
+		opts []Option
+		err  string
+	}{
+		{
+			name: "no-files",
+			root: "sales",
+			opts: []Option{WithGlob("*.orc")},
+			err:  `arrow/dataset: no files found in "sales"`,
+		},
+		{
+			name: "missing-root",
+			root: "missing",
+			err:  `arrow/dataset: could not list files of "missing": open missing: file does not exist`,
+		},
+		{
+			name: "invalid-file",
+			root: "other",
+			err:  `arrow/dataset: could not inspect "other/year=1999/part-0.parquet": arrow/parquet: file too small (7 bytes)`,
+		},
+		{
+			name: "invalid-partition",
+			root: "sales",
+			opts: []Option{
+				WithGlob("*.parquet"),
+				WithHivePartitioning(arrow.NewSchema([]arrow.Field{{Name: "region", Type: arrow.PrimitiveTypes.Int32}}, nil)),
+			},
+			err: `arrow/dataset: invalid value "eu" for partition key "region" of "sales/region=eu/year=2020/part-0.parquet": `,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Discover(fsys, tc.root, ParquetFormat{}, tc.opts...)
+			if err == nil || !strings.HasPrefix(err.Error(), tc.err) {
+				t.Fatalf("invalid error:\ngot= %v\nwant=%s", err, tc.err)
+			}
+		})
+	}
+
+	d, err := Discover(fsys, "sales", ParquetFormat{}, WithGlob("*.parquet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = d.NewScanner(WithColumns("id", "region"))
+	if want := `arrow/dataset: no column named "region"`; err == nil || err.Error() != want {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%s", err, want)
+	}
+	_, err = d.NewScanner(WithFilter(expr.Equal(expr.Field("x"), expr.Field("id"))))
+	if want := `arrow/dataset: filter references unknown column "x"`; err == nil || err.Error() != want {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%s", err, want)
+	}
+}
@@ -0,0 +1,312 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataset
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/fs"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/csv"
+	"github.com/apache/arrow/go/arrow/expr"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/parquet"
+)
+
+// Format reads the files of a dataset.
+type Format interface {
+	// Inspect returns the schema of the file at path.
+	Inspect(fsys fs.FS, path string) (*arrow.Schema, error)
+
+	// Scan returns a reader of the records of the file at path, holding at
+	// least the columns of the file selected by scan.
+	Scan(fsys fs.FS, path string, scan FileScan) (RecordReader, error)
+}
+
+// FileScan describes the records requested from a file.
+type FileScan struct {
+	// Columns are the names of the columns to read. Names missing from the
+	// file are ignored.
+	Columns []string
+
+	// Filter, if not nil, is the predicate of the scan. Formats may use it
+	// to skip data without any matching row; the rows they return are
+	// filtered by the scanner.
+	Filter expr.Expr
+
+	Mem memory.Allocator
+}
+
+// RecordReader reads the records of a file.
+type RecordReader interface {
+	array.RecordReader
+
+	// Err returns the last error encountered while reading the file.
+	Err() error
+}
+
+// CSVFormat reads CSV files of a given schema, without header.
+type CSVFormat struct {
+	Schema  *arrow.Schema
+	Options []csv.Option // options of the reader, such as csv.WithComma
+}
+
+// Inspect returns the schema of f.
+func (f CSVFormat) Inspect(fsys fs.FS, path string) (*arrow.Schema, error) {
+	return f.Schema, nil
+}
+
+// Scan returns a reader of all the columns of the file.
+func (f CSVFormat) Scan(fsys fs.FS, path string, scan FileScan) (RecordReader, error) {
+	file, err := fsys.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	opts := append([]csv.Option{csv.WithAllocator(scan.Mem), csv.WithChunk(1024)}, f.Options...)
+	return &fileReader{RecordReader: csv.NewReader(file, f.Schema, opts...), file: file}, nil
+}
+
+// ParquetFormat reads Parquet files.
+//
+// Comparisons of a column with a literal in the top-level conjunction of the
+// filter of a scan are checked against the statistics of the row groups;
+// the row groups where no row can match are skipped.
+type ParquetFormat struct{}
+
+// Inspect returns the schema of the Parquet file at path.
+func (ParquetFormat) Inspect(fsys fs.FS, path string) (*arrow.Schema, error) {
+	pf, file, err := openParquet(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return pf.Schema(), nil
+}
+
+// Scan returns a reader of the selected columns of the Parquet file at path.
+func (ParquetFormat) Scan(fsys fs.FS, path string, scan FileScan) (RecordReader, error) {
+	pf, file, err := openParquet(fsys, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cols []string
+	for _, name := range scan.Columns {
+		if pf.Schema().HasField(name) {
+			cols = append(cols, name)
+		}
+	}
+	opts := []parquet.Option{parquet.WithAllocator(scan.Mem), parquet.WithColumns(cols...)}
+	if scan.Filter != nil {
+		schema := pf.Schema()
+		preds := conjuncts(scan.Filter)
+		opts = append(opts, parquet.WithRowGroupFilter(func(rg parquet.RowGroup) bool {
+			return mayMatch(scan.Mem, schema, rg, preds)
+		}))
+	}
+
+	r, err := pf.NewReader(opts...)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &fileReader{RecordReader: r, file: file}, nil
+}
+
+func openParquet(fsys fs.FS, path string) (*parquet.File, fs.File, error) {
+	file, err := fsys.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	fi, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	r, ok := file.(io.ReaderAt)
+	if !ok {
+		buf, err := io.ReadAll(file)
+		if err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+		r = bytes.NewReader(buf)
+	}
+
+	pf, err := parquet.Open(r, fi.Size())
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	return pf, file, nil
+}
+
+// fileReader is a reader closing its file when released.
+type fileReader struct {
+	RecordReader
+	file fs.File
+}
+
+func (r *fileReader) Release() {
+	r.RecordReader.Release()
+	if r.file != nil {
+		r.file.Close()
+		r.file = nil
+	}
+}
+
+// conjuncts returns the operands of the top-level conjunction of e.
+func conjuncts(e expr.Expr) []expr.Expr {
+	c, ok := e.(*expr.Call)
+	if !ok || c.Op != expr.OpAnd {
+		return []expr.Expr{e}
+	}
+	var out []expr.Expr
+	for _, arg := range c.Args {
+		out = append(out, conjuncts(arg)...)
+	}
+	return out
+}
+
+// mayMatch returns false if the statistics of rg prove that one of preds is
+// not true for any row of rg.
+func mayMatch(mem memory.Allocator, schema *arrow.Schema, rg parquet.RowGroup, preds []expr.Expr) bool {
+	for _, pred := range preds {
+		c, ok := pred.(*expr.Call)
+		if !ok || len(c.Args) != 2 {
+			continue
+		}
+
+		op := c.Op
+		ref, okr := c.Args[0].(*expr.FieldRef)
+		lit, okl := c.Args[1].(*expr.Literal)
+		if !okr || !okl {
+			// literal op field.
+			ref, okr = c.Args[1].(*expr.FieldRef)
+			lit, okl = c.Args[0].(*expr.Literal)
+			switch op {
+			case expr.OpLess:
+				op = expr.OpGreater
+			case expr.OpLessEqual:
+				op = expr.OpGreaterEqual
+			case expr.OpGreater:
+				op = expr.OpLess
+			case expr.OpGreaterEqual:
+				op = expr.OpLessEqual
+			}
+		}
+		if !okr || !okl {
+			continue
+		}
+
+		i := schema.FieldIndex(ref.Name)
+		if i < 0 {
+			continue
+		}
+		st := rg.Columns[i]
+		if st.HasNullCount && st.NullCount == rg.NumRows {
+			// comparisons with nulls are never true.
+			switch op {
+			case expr.OpEqual, expr.OpNotEqual, expr.OpLess, expr.OpLessEqual, expr.OpGreater, expr.OpGreaterEqual:
+				return false
+			}
+			continue
+		}
+		if !st.HasMinMax {
+			continue
+		}
+
+		// check the predicate against the bounds of the values: the minimum
+		// for "<" and "<=", the maximum for ">" and ">=", and both for "==".
+		var checks []expr.Expr
+		switch op {
+		case expr.OpLess, expr.OpLessEqual:
+			checks = []expr.Expr{&expr.Call{Op: op, Args: []expr.Expr{expr.Field("min"), lit}}}
+		case expr.OpGreater, expr.OpGreaterEqual:
+			checks = []expr.Expr{&expr.Call{Op: op, Args: []expr.Expr{expr.Field("max"), lit}}}
+		case expr.OpEqual:
+			checks = []expr.Expr{
+				expr.LessEqual(expr.Field("min"), lit),
+				expr.GreaterEqual(expr.Field("max"), lit),
+			}
+		default:
+			continue
+		}
+		bounds := boundsRecord(mem, schema.Field(i).Type, st.Min, st.Max)
+		if bounds == nil {
+			continue
+		}
+		match := true
+		for _, chk := range checks {
+			if isFalse(mem, chk, bounds) {
+				match = false
+				break
+			}
+		}
+		bounds.Release()
+		if !match {
+			return false
+		}
+	}
+	return true
+}
+
+// boundsRecord returns the record of one row whose "min" and "max" columns,
+// of type dt, hold min and max, or nil if they cannot be converted to dt.
+func boundsRecord(mem memory.Allocator, dt arrow.DataType, min, max interface{}) array.Record {
+	cols := make([]array.Interface, 2)
+	for i, v := range []interface{}{min, max} {
+		raw, err := json.Marshal([]interface{}{v})
+		if err != nil {
+			return nil
+		}
+		bld := array.NewBuilder(mem, dt)
+		err = bld.UnmarshalJSON(raw)
+		if err == nil {
+			cols[i] = bld.NewArray()
+			defer cols[i].Release()
+		}
+		bld.Release()
+		if err != nil {
+			return nil
+		}
+	}
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "min", Type: dt}, {Name: "max", Type: dt}}, nil)
+	return array.NewRecord(schema, cols, 1)
+}
+
+// isFalse returns whether e evaluates to false, and not to true or null, on
+// the single row of rec. Evaluation errors are not false.
+func isFalse(mem memory.Allocator, e expr.Expr, rec array.Record) bool {
+	v, err := expr.Eval(mem, e, rec)
+	if err != nil {
+		return false
+	}
+	defer v.Release()
+	b, ok := v.(*array.Boolean)
+	return ok && b.IsValid(0) && !b.Value(0)
+}
+
+var (
+	_ Format = CSVFormat{}
+	_ Format = ParquetFormat{}
+)
@@ -0,0 +1,331 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dataset
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/expr"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// Scanner reads the files of a dataset, one after the other, and creates
+// records of the selected columns holding the rows matching its filter.
+// Files whose partition values do not match the filter are skipped.
+type Scanner struct {
+	d      *Dataset
+	schema *arrow.Schema // schema of the records
+	needed *arrow.Schema // schema of the columns read, in the projection or the filter
+	names  []string
+	filter expr.Expr
+	preds  []expr.Expr // conjuncts of the filter on partition keys only
+
+	frag int
+	rr   RecordReader
+
+	refs int64
+	cur  array.Record
+	err  error
+
+	mem memory.Allocator
+}
+
+// NewScanner returns a scanner of the dataset.
+//
+// NewScanner returns an error if a column selected with WithColumns, or
+// referenced by the filter, is not a column of the dataset.
+func (d *Dataset) NewScanner(opts ...Option) (*Scanner, error) {
+	s := &Scanner{d: d, refs: 1}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if s.mem == nil {
+		s.mem = memory.DefaultAllocator
+	}
+
+	names := s.names
+	if names == nil {
+		for _, f := range d.schema.Fields() {
+			names = append(names, f.Name)
+		}
+	}
+	var (
+		fields = make([]arrow.Field, 0, len(names))
+		needed []arrow.Field
+		seen   = make(map[string]bool)
+	)
+	for _, name := range names {
+		f, ok := d.schema.FieldByName(name)
+		if !ok {
+			return nil, fmt.Errorf("arrow/dataset: no column named %q", name)
+		}
+		fields = append(fields, f)
+		if !seen[name] {
+			seen[name] = true
+			needed = append(needed, f)
+		}
+	}
+	md := d.schema.Metadata()
+	s.schema = arrow.NewSchema(fields, &md)
+	s.names = names
+
+	if s.filter != nil {
+		for _, name := range expr.Fields(s.filter) {
+			f, ok := d.schema.FieldByName(name)
+			if !ok {
+				return nil, fmt.Errorf("arrow/dataset: filter references unknown column %q", name)
+			}
+			if !seen[name] {
+				seen[name] = true
+				needed = append(needed, f)
+			}
+		}
+
+	preds:
+		for _, pred := range conjuncts(s.filter) {
+			for _, name := range expr.Fields(pred) {
+				if !d.isPartition(name) {
+					continue preds
+				}
+			}
+			s.preds = append(s.preds, pred)
+		}
+	}
+	s.needed = arrow.NewSchema(needed, nil)
+	return s, nil
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (s *Scanner) Retain() {
+	atomic.AddInt64(&s.refs, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+// Release may be called simultaneously from multiple goroutines.
+func (s *Scanner) Release() {
+	debug.Assert(atomic.LoadInt64(&s.refs) > 0, "too many releases")
+
+	if atomic.AddInt64(&s.refs, -1) == 0 {
+		if s.cur != nil {
+			s.cur.Release()
+			s.cur = nil
+		}
+		if s.rr != nil {
+			s.rr.Release()
+			s.rr = nil
+		}
+	}
+}
+
+// Err returns the last error encountered while scanning the dataset.
+func (s *Scanner) Err() error { return s.err }
+
+// Schema returns the schema of the records of the scanner.
+func (s *Scanner) Schema() *arrow.Schema { return s.schema }
+
+// Record returns the current record of the scanner.
+// The returned record is owned by the scanner, and is only valid until the
+// next call to Next.
+func (s *Scanner) Record() array.Record { return s.cur }
+
+// Next returns whether a record could be read. Records without any
+// matching row are skipped.
+func (s *Scanner) Next() bool {
+	if s.cur != nil {
+		s.cur.Release()
+		s.cur = nil
+	}
+
+	for s.err == nil {
+		if s.rr == nil && !s.open() {
+			return false
+		}
+		if !s.rr.Next() {
+			if err := s.rr.Err(); err != nil {
+				s.err = fmt.Errorf("arrow/dataset: could not read %q: %w", s.d.frags[s.frag-1].path, err)
+			}
+			s.rr.Release()
+			s.rr = nil
+			continue
+		}
+
+		rec, err := s.conform(&s.d.frags[s.frag-1], s.rr.Record())
+		if err != nil {
+			s.err = fmt.Errorf("arrow/dataset: %q: %w", s.d.frags[s.frag-1].path, err)
+			return false
+		}
+		if rec.NumRows() == 0 {
+			rec.Release()
+			continue
+		}
+		s.cur = rec
+		return true
+	}
+	return false
+}
+
+// open opens the next file whose partition may match the filter, and returns
+// false at the end of the dataset or on error.
+func (s *Scanner) open() bool {
+	for ; s.frag < len(s.d.frags); s.frag++ {
+		frag := &s.d.frags[s.frag]
+		ok, err := s.mayMatch(frag)
+		if err != nil {
+			s.err = fmt.Errorf("arrow/dataset: %q: %w", frag.path, err)
+			return false
+		}
+		if !ok {
+			continue
+		}
+
+		s.frag++
+		cols := make([]string, 0, len(s.needed.Fields()))
+		for _, f := range s.needed.Fields() {
+			if !s.d.isPartition(f.Name) {
+				cols = append(cols, f.Name)
+			}
+		}
+		s.rr, err = s.d.format.Scan(s.d.fsys, frag.path, FileScan{Columns: cols, Filter: s.filter, Mem: s.mem})
+		if err != nil {
+			s.err = fmt.Errorf("arrow/dataset: could not scan %q: %w", frag.path, err)
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// mayMatch returns false if the partition values of frag do not match the
+// filter.
+func (s *Scanner) mayMatch(frag *fragment) (bool, error) {
+	if len(s.preds) == 0 {
+		return true, nil
+	}
+
+	rec, err := s.partitionColumns(frag, nil, 1)
+	if err != nil {
+		return false, err
+	}
+	defer rec.Release()
+
+	for _, pred := range s.preds {
+		v, err := expr.Eval(s.mem, pred, rec)
+		if err != nil {
+			return false, err
+		}
+		b, ok := v.(*array.Boolean)
+		match := ok && b.IsValid(0) && b.Value(0)
+		v.Release()
+		if !match {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// partitionColumns returns the record of n rows of the partition values of
+// frag, restricted to the keys in fields if not nil.
+func (s *Scanner) partitionColumns(frag *fragment, fields []arrow.Field, n int64) (array.Record, error) {
+	if fields == nil {
+		fields = s.d.parts.Fields()
+	}
+
+	empty := array.NewRecord(arrow.NewSchema(nil, nil), nil, n)
+	defer empty.Release()
+
+	cols := make([]array.Interface, len(fields))
+	for i, f := range fields {
+		col, err := expr.Eval(s.mem, expr.Lit(frag.parts[f.Name]), empty)
+		if err != nil {
+			return nil, err
+		}
+		defer col.Release()
+		cols[i] = col
+	}
+	return array.NewRecord(arrow.NewSchema(fields, nil), cols, n), nil
+}
+
+// conform returns the record of the selected columns of the rows of rec
+// matching the filter.
+func (s *Scanner) conform(frag *fragment, rec array.Record) (array.Record, error) {
+	n := rec.NumRows()
+	cols := make([]array.Interface, len(s.needed.Fields()))
+	defer func() {
+		for _, col := range cols {
+			if col != nil {
+				col.Release()
+			}
+		}
+	}()
+
+	for i, f := range s.needed.Fields() {
+		switch j := rec.Schema().FieldIndex(f.Name); {
+		case s.d.isPartition(f.Name):
+			parts, err := s.partitionColumns(frag, []arrow.Field{f}, n)
+			if err != nil {
+				return nil, err
+			}
+			cols[i] = parts.Column(0)
+			cols[i].Retain()
+			parts.Release()
+		case j < 0:
+			bld := array.NewBuilder(s.mem, f.Type)
+			bld.AppendNulls(int(n))
+			cols[i] = bld.NewArray()
+			bld.Release()
+		case !reflect.DeepEqual(rec.Column(j).DataType(), f.Type):
+			col, err := compute.Cast(s.mem, rec.Column(j), f.Type)
+			if err != nil {
+				return nil, fmt.Errorf("column %q: %w", f.Name, err)
+			}
+			cols[i] = col
+		default:
+			cols[i] = rec.Column(j)
+			cols[i].Retain()
+		}
+	}
+
+	var out array.Record = array.NewRecord(s.needed, cols, n)
+	if s.filter != nil {
+		filtered, err := expr.Filter(s.mem, out, s.filter)
+		out.Release()
+		if err != nil {
+			return nil, err
+		}
+		out = filtered
+	}
+	defer out.Release()
+
+	proj := make([]array.Interface, len(s.names))
+	for i, name := range s.names {
+		proj[i] = out.Column(out.Schema().FieldIndex(name))
+	}
+	return array.NewRecord(s.schema, proj, out.NumRows()), nil
+}
+
+var (
+	_ array.RecordReader = (*Scanner)(nil)
+)
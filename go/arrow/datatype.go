@@ -89,7 +89,9 @@ const (
 	// nanoseconds since midnight
 	TIME64
 
-	// INTERVAL is YEAR_MONTH or DAY_TIME interval in SQL style
+	// INTERVAL is a calendar interval in SQL style: a number of months
+	// (MonthIntervalType), of days and milliseconds (DayTimeIntervalType)
+	// or of months, days and nanoseconds (MonthDayNanoIntervalType)
 	INTERVAL
 
 	// DECIMAL is a precision- and scale-based decimal type. Storage type depends on the
@@ -110,6 +112,44 @@ const (
 
 	// MAP is a repeated struct logical type
 	MAP
+
+	// DECIMAL256 is a precision- and scale-based decimal type, stored as a
+	// 256-bit signed integer.
+	DECIMAL256
+
+	// LARGE_STRING is a UTF8 variable-length string with 64-bit offsets
+	LARGE_STRING
+
+	// LARGE_BINARY is a variable-length byte sequence with 64-bit offsets
+	LARGE_BINARY
+
+	// LARGE_LIST is a list of some logical data type, with 64-bit offsets
+	LARGE_LIST
+
+	// EXTENSION is a user-defined logical type, stored as another data type
+	EXTENSION
+
+	// RUN_END_ENCODED is a sequence of runs of values, each run being
+	// stored as its end index and its value
+	RUN_END_ENCODED
+
+	// DURATION is a signed 64-bit integer, representing an elapsed time
+	// in a given time unit
+	DURATION
+
+	// FIXED_SIZE_LIST is a list of some logical data type, each list
+	// holding the same number of values
+	FIXED_SIZE_LIST
+
+	// STRING_VIEW is a UTF8 variable-length string, stored as a view
+	// that either inlines the string or refers to one of several data
+	// buffers
+	STRING_VIEW
+
+	// BINARY_VIEW is a variable-length byte sequence, stored as a view
+	// that either inlines the bytes or refers to one of several data
+	// buffers
+	BINARY_VIEW
 )
 
 // DataType is the representation of an Arrow type.
@@ -131,3 +171,10 @@ type BinaryDataType interface {
 	DataType
 	binary()
 }
+
+// BinaryViewDataType is a BinaryDataType whose values are stored as views
+// rather than through an offsets buffer.
+type BinaryViewDataType interface {
+	BinaryDataType
+	view()
+}
@@ -28,12 +28,59 @@ func (t *StringType) ID() Type     { return STRING }
 func (t *StringType) Name() string { return "utf8" }
 func (t *StringType) binary()      {}
 
+// LargeBinaryType is a variable-length byte sequence type, using 64-bit
+// offsets so an array can hold more than 2GB of data.
+type LargeBinaryType struct{}
+
+func (t *LargeBinaryType) ID() Type     { return LARGE_BINARY }
+func (t *LargeBinaryType) Name() string { return "large_binary" }
+func (t *LargeBinaryType) binary()      {}
+
+// LargeStringType is a UTF8 variable-length string type, using 64-bit
+// offsets so an array can hold more than 2GB of data.
+type LargeStringType struct{}
+
+func (t *LargeStringType) ID() Type     { return LARGE_STRING }
+func (t *LargeStringType) Name() string { return "large_utf8" }
+func (t *LargeStringType) binary()      {}
+
+// ViewSizeBytes is the size of a view, the element of the views buffer of
+// binary and string view arrays.
+const ViewSizeBytes = 16
+
+// BinaryViewType is a variable-length byte sequence type. Each value is
+// stored as a view, which holds values of up to 12 bytes inline and
+// otherwise refers to a range of one of the array's data buffers.
+type BinaryViewType struct{}
+
+func (t *BinaryViewType) ID() Type     { return BINARY_VIEW }
+func (t *BinaryViewType) Name() string { return "binary_view" }
+func (t *BinaryViewType) binary()      {}
+func (t *BinaryViewType) view()        {}
+
+// StringViewType is a UTF8 variable-length string type, with the same
+// layout as BinaryViewType.
+type StringViewType struct{}
+
+func (t *StringViewType) ID() Type     { return STRING_VIEW }
+func (t *StringViewType) Name() string { return "utf8_view" }
+func (t *StringViewType) binary()      {}
+func (t *StringViewType) view()        {}
+
 var (
 	BinaryTypes = struct {
-		Binary BinaryDataType
-		String BinaryDataType
+		Binary      BinaryDataType
+		String      BinaryDataType
+		LargeBinary BinaryDataType
+		LargeString BinaryDataType
+		BinaryView  BinaryViewDataType
+		StringView  BinaryViewDataType
 	}{
-		Binary: &BinaryType{},
-		String: &StringType{},
+		Binary:      &BinaryType{},
+		String:      &StringType{},
+		LargeBinary: &LargeBinaryType{},
+		LargeString: &LargeStringType{},
+		BinaryView:  &BinaryViewType{},
+		StringView:  &StringViewType{},
 	}
 )
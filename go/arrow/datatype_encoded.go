@@ -0,0 +1,101 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import "fmt"
+
+// RunEndEncodedType describes an encoded type in which consecutive equal
+// values are stored once, as runs. Each run is stored as the logical index
+// one past its last element, its run end, and its value.
+type RunEndEncodedType struct {
+	runEnds DataType
+	values  DataType
+}
+
+// RunEndEncodedOf returns the run-end encoded type with run ends of type
+// runEnds and values of type values.
+//
+// RunEndEncodedOf panics if runEnds is not a signed 16, 32 or 64 bit
+// integer type, or if values is nil.
+func RunEndEncodedOf(runEnds, values DataType) *RunEndEncodedType {
+	switch runEnds.(type) {
+	case *Int16Type, *Int32Type, *Int64Type:
+	default:
+		panic(fmt.Errorf("arrow: invalid run ends type %v", runEnds))
+	}
+	if values == nil {
+		panic("arrow: nil DataType")
+	}
+	return &RunEndEncodedType{runEnds: runEnds, values: values}
+}
+
+func (*RunEndEncodedType) ID() Type     { return RUN_END_ENCODED }
+func (*RunEndEncodedType) Name() string { return "run_end_encoded" }
+
+// RunEnds returns the data type of the run ends.
+func (t *RunEndEncodedType) RunEnds() DataType { return t.runEnds }
+
+// Encoded returns the data type of the values of the runs.
+func (t *RunEndEncodedType) Encoded() DataType { return t.values }
+
+// Fields returns the fields of the two children of run-end encoded arrays.
+func (t *RunEndEncodedType) Fields() []Field {
+	return []Field{
+		{Name: "run_ends", Type: t.runEnds},
+		{Name: "values", Type: t.values, Nullable: true},
+	}
+}
+
+// DictionaryType describes an encoded type in which each array slot holds
+// the index of its value in a dictionary of values. The indices are stored
+// in the array itself and the dictionary as a separate array.
+type DictionaryType struct {
+	index   DataType
+	value   DataType
+	Ordered bool // whether the order of the dictionary values is meaningful
+}
+
+// DictionaryOf returns the dictionary type with indices of type index and
+// values of type value.
+//
+// DictionaryOf panics if index is not an integer type, or if value is nil.
+func DictionaryOf(index, value DataType) *DictionaryType {
+	switch index.(type) {
+	case *Int8Type, *Int16Type, *Int32Type, *Int64Type,
+		*Uint8Type, *Uint16Type, *Uint32Type, *Uint64Type:
+	default:
+		panic(fmt.Errorf("arrow: invalid dictionary index type %v", index))
+	}
+	if value == nil {
+		panic("arrow: nil DataType")
+	}
+	return &DictionaryType{index: index, value: value}
+}
+
+func (*DictionaryType) ID() Type     { return DICTIONARY }
+func (*DictionaryType) Name() string { return "dictionary" }
+
+// IndexType returns the data type of the indices.
+func (t *DictionaryType) IndexType() DataType { return t.index }
+
+// ValueType returns the data type of the dictionary values.
+func (t *DictionaryType) ValueType() DataType { return t.value }
+
+var (
+	_ DataType = (*RunEndEncodedType)(nil)
+	_ DataType = (*DictionaryType)(nil)
+)
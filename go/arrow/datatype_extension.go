@@ -0,0 +1,149 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+const (
+	// ExtensionNameKey is the field metadata key holding the name of an
+	// extension type.
+	ExtensionNameKey = "ARROW:extension:name"
+	// ExtensionMetadataKey is the field metadata key holding the serialized
+	// parameters of an extension type.
+	ExtensionMetadataKey = "ARROW:extension:metadata"
+)
+
+// ExtensionType is the interface implemented by user-defined logical types.
+//
+// An extension type is stored as another data type, its storage type, and
+// is identified by its name when transported, together with its serialized
+// parameters.
+// Implementations should embed ExtensionBase.
+type ExtensionType interface {
+	DataType
+
+	// ArrayType returns the type of the array wrapping the storage of this
+	// extension type. It must be a pointer to a struct embedding
+	// array.ExtensionArrayBase.
+	ArrayType() reflect.Type
+	// ExtensionName returns the unique name of the extension type.
+	ExtensionName() string
+	// StorageType returns the underlying data type of the extension type.
+	StorageType() DataType
+	// ExtensionEquals returns whether other is the same extension type,
+	// with the same parameters.
+	ExtensionEquals(other ExtensionType) bool
+	// Serialize returns the parameters of the extension type, as stored in
+	// the ExtensionMetadataKey metadata.
+	Serialize() string
+	// Deserialize creates a new extension type from its storage type and
+	// its serialized parameters.
+	Deserialize(storage DataType, data string) (ExtensionType, error)
+}
+
+// ExtensionBase provides the common implementation of the methods of an
+// ExtensionType that only depend on its storage type.
+type ExtensionBase struct {
+	Storage DataType // the underlying data type
+}
+
+func (*ExtensionBase) ID() Type { return EXTENSION }
+
+// StorageType returns the underlying data type of the extension type.
+func (e *ExtensionBase) StorageType() DataType { return e.Storage }
+
+var extTypes = struct {
+	sync.RWMutex
+	m map[string]ExtensionType
+}{m: make(map[string]ExtensionType)}
+
+// RegisterExtensionType registers typ so it can be recognized when reading
+// fields carrying its name in their metadata.
+//
+// RegisterExtensionType returns an error if an extension type with the same
+// name is already registered.
+func RegisterExtensionType(typ ExtensionType) error {
+	name := typ.ExtensionName()
+	extTypes.Lock()
+	defer extTypes.Unlock()
+	if _, dup := extTypes.m[name]; dup {
+		return fmt.Errorf("arrow: extension type %q already registered", name)
+	}
+	extTypes.m[name] = typ
+	return nil
+}
+
+// UnregisterExtensionType removes the extension type with the given name
+// from the registry.
+//
+// UnregisterExtensionType returns an error if no such type is registered.
+func UnregisterExtensionType(name string) error {
+	extTypes.Lock()
+	defer extTypes.Unlock()
+	if _, ok := extTypes.m[name]; !ok {
+		return fmt.Errorf("arrow: extension type %q not registered", name)
+	}
+	delete(extTypes.m, name)
+	return nil
+}
+
+// GetExtensionType returns the registered extension type with the given
+// name, or nil if there is none.
+func GetExtensionType(name string) ExtensionType {
+	extTypes.RLock()
+	defer extTypes.RUnlock()
+	return extTypes.m[name]
+}
+
+// ExtensionTypeFromMetadata returns the extension type described by md,
+// with the given storage type.
+// It returns nil if md does not name an extension type, or names one that
+// is not registered.
+func ExtensionTypeFromMetadata(storage DataType, md Metadata) (ExtensionType, error) {
+	i := md.FindKey(ExtensionNameKey)
+	if i < 0 {
+		return nil, nil
+	}
+	typ := GetExtensionType(md.Values()[i])
+	if typ == nil {
+		return nil, nil
+	}
+
+	data := ""
+	if j := md.FindKey(ExtensionMetadataKey); j >= 0 {
+		data = md.Values()[j]
+	}
+	return typ.Deserialize(storage, data)
+}
+
+// ExtensionMetadata returns the field metadata describing typ, merged with md.
+func ExtensionMetadata(typ ExtensionType, md Metadata) Metadata {
+	keys := []string{ExtensionNameKey, ExtensionMetadataKey}
+	values := []string{typ.ExtensionName(), typ.Serialize()}
+	for i, k := range md.Keys() {
+		if k == ExtensionNameKey || k == ExtensionMetadataKey {
+			continue
+		}
+		keys = append(keys, k)
+		values = append(values, md.Values()[i])
+	}
+	return NewMetadata(keys, values)
+}
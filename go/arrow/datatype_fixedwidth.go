@@ -16,6 +16,12 @@
 
 package arrow
 
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
 type BooleanType struct{}
 
 func (t *BooleanType) ID() Type     { return BOOL }
@@ -24,6 +30,13 @@ func (t *BooleanType) Name() string { return "bool" }
 // BitWidth returns the number of bits required to store a single element of this data type in memory.
 func (t *BooleanType) BitWidth() int { return 1 }
 
+// Float16Type represents an IEEE 754 half-precision floating point number.
+type Float16Type struct{}
+
+func (*Float16Type) ID() Type      { return HALF_FLOAT }
+func (*Float16Type) Name() string  { return "float16" }
+func (*Float16Type) BitWidth() int { return 16 }
+
 type FixedSizeBinaryType struct {
 	ByteWidth int
 }
@@ -32,15 +45,54 @@ func (*FixedSizeBinaryType) ID() Type        { return FIXED_SIZE_BINARY }
 func (*FixedSizeBinaryType) Name() string    { return "fixed_size_binary" }
 func (t *FixedSizeBinaryType) BitWidth() int { return 8 * t.ByteWidth }
 
+// Decimal256Type represents a fixed-precision decimal number, stored as a
+// 256-bit signed integer holding the unscaled value.
+type Decimal256Type struct {
+	Precision int32 // total number of decimal digits, from 1 to 76
+	Scale     int32 // number of digits after the decimal point
+}
+
+func (*Decimal256Type) ID() Type      { return DECIMAL256 }
+func (*Decimal256Type) Name() string  { return "decimal256" }
+func (*Decimal256Type) BitWidth() int { return 256 }
+
 type (
-	Timestamp int64
-	Time32    int32
-	Time64    int64
-	TimeUnit  int
-	Date32    int32
-	Date64    int64
+	Timestamp     int64
+	Time32        int32
+	Time64        int64
+	TimeUnit      int
+	Date32        int32
+	Date64        int64
+	Duration      int64
+	MonthInterval int32
 )
 
+// DayTimeInterval is an interval of a number of days and milliseconds.
+type DayTimeInterval struct {
+	Days         int32 `json:"days"`
+	Milliseconds int32 `json:"milliseconds"`
+}
+
+// String returns the interval as "<days>d<milliseconds>ms", e.g. "1d500ms".
+func (v DayTimeInterval) String() string {
+	return fmt.Sprintf("%dd%dms", v.Days, v.Milliseconds)
+}
+
+// MonthDayNanoInterval is an interval of a number of months, days and
+// nanoseconds. The three components are independent: a month is not a
+// fixed number of days, nor a day a fixed number of nanoseconds.
+type MonthDayNanoInterval struct {
+	Months      int32 `json:"months"`
+	Days        int32 `json:"days"`
+	Nanoseconds int64 `json:"nanoseconds"`
+}
+
+// String returns the interval as "<months>M<days>d<nanoseconds>ns",
+// e.g. "1M2d3ns".
+func (v MonthDayNanoInterval) String() string {
+	return fmt.Sprintf("%dM%dd%dns", v.Months, v.Days, v.Nanoseconds)
+}
+
 const (
 	Nanosecond TimeUnit = iota
 	Microsecond
@@ -50,6 +102,59 @@ const (
 
 func (u TimeUnit) String() string { return [...]string{"ns", "us", "ms", "s"}[uint(u)&3] }
 
+// Multiplier returns the duration of a single tick of the time unit.
+func (u TimeUnit) Multiplier() time.Duration {
+	return [...]time.Duration{time.Nanosecond, time.Microsecond, time.Millisecond, time.Second}[uint(u)&3]
+}
+
+// ConvertTimeUnit converts v, a number of ticks of unit from, to a number of
+// ticks of unit to. Conversions to a coarser unit round towards negative
+// infinity, so that the result is the tick holding v.
+func ConvertTimeUnit(v int64, from, to TimeUnit) int64 {
+	fd, td := int64(from.Multiplier()), int64(to.Multiplier())
+	if fd >= td {
+		return v * (fd / td)
+	}
+	n := td / fd
+	q := v / n
+	if v%n < 0 {
+		q--
+	}
+	return q
+}
+
+// ToTime returns the time of t, a number of ticks of unit since the UNIX
+// epoch, in UTC.
+func (t Timestamp) ToTime(unit TimeUnit) time.Time {
+	d := int64(unit.Multiplier())
+	perSec := int64(time.Second) / d
+	sec, rem := int64(t)/perSec, int64(t)%perSec
+	if rem < 0 {
+		sec--
+		rem += perSec
+	}
+	return time.Unix(sec, rem*d).UTC()
+}
+
+// Convert converts t from a number of ticks of unit from to a number of
+// ticks of unit to, as ConvertTimeUnit does.
+func (t Timestamp) Convert(from, to TimeUnit) Timestamp {
+	return Timestamp(ConvertTimeUnit(int64(t), from, to))
+}
+
+// TimestampFromTime returns the timestamp of v as a number of ticks of unit
+// since the UNIX epoch, rounded down to a whole tick.
+// Nanosecond timestamps only cover the years 1678 to 2262.
+func TimestampFromTime(v time.Time, unit TimeUnit) Timestamp {
+	d := int64(unit.Multiplier())
+	return Timestamp(v.Unix()*(int64(time.Second)/d) + int64(v.Nanosecond())/d)
+}
+
+// ToDuration returns d, a number of ticks of unit, as a time.Duration.
+func (d Duration) ToDuration(unit TimeUnit) time.Duration {
+	return time.Duration(d) * unit.Multiplier()
+}
+
 // TimestampType is encoded as a 64-bit signed integer since the UNIX epoch (2017-01-01T00:00:00Z).
 // The zero-value is a nanosecond and time zone neutral. Time zone neutral can be
 // considered UTC without having "UTC" as a time zone.
@@ -64,6 +169,26 @@ func (*TimestampType) Name() string { return "timestamp" }
 // BitWidth returns the number of bits required to store a single element of this data type in memory.
 func (*TimestampType) BitWidth() int { return 64 }
 
+// locations caches the time zones loaded by TimestampType.Location.
+var locations sync.Map // map[string]*time.Location
+
+// Location returns the time zone of the type, UTC if it is time zone neutral.
+// Location returns an error if TimeZone is not a valid time zone name.
+func (t *TimestampType) Location() (*time.Location, error) {
+	if t.TimeZone == "" {
+		return time.UTC, nil
+	}
+	if loc, ok := locations.Load(t.TimeZone); ok {
+		return loc.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(t.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(t.TimeZone, loc)
+	return loc, nil
+}
+
 // Time32Type is encoded as a 32-bit signed integer, representing either seconds or milliseconds since midnight.
 type Time32Type struct {
 	Unit TimeUnit
@@ -82,20 +207,75 @@ func (*Time64Type) ID() Type      { return TIME64 }
 func (*Time64Type) Name() string  { return "time64" }
 func (*Time64Type) BitWidth() int { return 64 }
 
+// DurationType is encoded as a 64-bit signed integer, representing an elapsed
+// time in the unit of the type.
+type DurationType struct {
+	Unit TimeUnit
+}
+
+func (*DurationType) ID() Type      { return DURATION }
+func (*DurationType) Name() string  { return "duration" }
+func (*DurationType) BitWidth() int { return 64 }
+
+// MonthIntervalType is encoded as a 32-bit signed integer, representing a
+// number of months.
+type MonthIntervalType struct{}
+
+func (*MonthIntervalType) ID() Type      { return INTERVAL }
+func (*MonthIntervalType) Name() string  { return "month_interval" }
+func (*MonthIntervalType) BitWidth() int { return 32 }
+
+// DayTimeIntervalType is encoded as a pair of 32-bit signed integers,
+// representing a number of days and of milliseconds.
+type DayTimeIntervalType struct{}
+
+func (*DayTimeIntervalType) ID() Type      { return INTERVAL }
+func (*DayTimeIntervalType) Name() string  { return "day_time_interval" }
+func (*DayTimeIntervalType) BitWidth() int { return 64 }
+
+// MonthDayNanoIntervalType is encoded as two 32-bit signed integers and a
+// 64-bit signed integer, representing a number of months, days and nanoseconds.
+type MonthDayNanoIntervalType struct{}
+
+func (*MonthDayNanoIntervalType) ID() Type      { return INTERVAL }
+func (*MonthDayNanoIntervalType) Name() string  { return "month_day_nano_interval" }
+func (*MonthDayNanoIntervalType) BitWidth() int { return 128 }
+
 var (
 	FixedWidthTypes = struct {
-		Boolean  FixedWidthDataType
-		Time32s  FixedWidthDataType
-		Time32ms FixedWidthDataType
-		Time64us FixedWidthDataType
-		Time64ns FixedWidthDataType
+		Boolean              FixedWidthDataType
+		Float16              FixedWidthDataType
+		Time32s              FixedWidthDataType
+		Time32ms             FixedWidthDataType
+		Time64us             FixedWidthDataType
+		Time64ns             FixedWidthDataType
+		Duration_s           FixedWidthDataType
+		Duration_ms          FixedWidthDataType
+		Duration_us          FixedWidthDataType
+		Duration_ns          FixedWidthDataType
+		MonthInterval        FixedWidthDataType
+		DayTimeInterval      FixedWidthDataType
+		MonthDayNanoInterval FixedWidthDataType
 	}{
-		Boolean:  &BooleanType{},
-		Time32s:  &Time32Type{Unit: Second},
-		Time32ms: &Time32Type{Unit: Millisecond},
-		Time64us: &Time64Type{Unit: Microsecond},
-		Time64ns: &Time64Type{Unit: Nanosecond},
+		Boolean:              &BooleanType{},
+		Float16:              &Float16Type{},
+		Time32s:              &Time32Type{Unit: Second},
+		Time32ms:             &Time32Type{Unit: Millisecond},
+		Time64us:             &Time64Type{Unit: Microsecond},
+		Time64ns:             &Time64Type{Unit: Nanosecond},
+		Duration_s:           &DurationType{Unit: Second},
+		Duration_ms:          &DurationType{Unit: Millisecond},
+		Duration_us:          &DurationType{Unit: Microsecond},
+		Duration_ns:          &DurationType{Unit: Nanosecond},
+		MonthInterval:        &MonthIntervalType{},
+		DayTimeInterval:      &DayTimeIntervalType{},
+		MonthDayNanoInterval: &MonthDayNanoIntervalType{},
 	}
 
+	_ FixedWidthDataType = (*Float16Type)(nil)
 	_ FixedWidthDataType = (*FixedSizeBinaryType)(nil)
+	_ FixedWidthDataType = (*DurationType)(nil)
+	_ FixedWidthDataType = (*MonthIntervalType)(nil)
+	_ FixedWidthDataType = (*DayTimeIntervalType)(nil)
+	_ FixedWidthDataType = (*MonthDayNanoIntervalType)(nil)
 )
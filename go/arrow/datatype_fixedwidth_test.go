@@ -18,6 +18,7 @@ package arrow_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/apache/arrow/go/arrow"
 	"github.com/stretchr/testify/assert"
@@ -40,3 +41,48 @@ func TestTimeUnit_String(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertTimeUnit(t *testing.T) {
+	tests := []struct {
+		v        int64
+		from, to arrow.TimeUnit
+		exp      int64
+	}{
+		{1500, arrow.Millisecond, arrow.Microsecond, 1500000},
+		{1500, arrow.Millisecond, arrow.Second, 1},
+		{-1500, arrow.Millisecond, arrow.Second, -2},
+		{-1000, arrow.Microsecond, arrow.Millisecond, -1},
+		{3, arrow.Second, arrow.Nanosecond, 3000000000},
+		{7, arrow.Microsecond, arrow.Microsecond, 7},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.exp, arrow.ConvertTimeUnit(test.v, test.from, test.to), "%d%s to %s", test.v, test.from, test.to)
+		assert.Equal(t, arrow.Timestamp(test.exp), arrow.Timestamp(test.v).Convert(test.from, test.to))
+	}
+}
+
+func TestTimestampToTime(t *testing.T) {
+	when := time.Date(1969, 12, 31, 23, 59, 58, 500e6, time.UTC)
+	for _, unit := range []arrow.TimeUnit{arrow.Millisecond, arrow.Microsecond, arrow.Nanosecond} {
+		ts := arrow.TimestampFromTime(when, unit)
+		assert.Equal(t, when, ts.ToTime(unit), "unit %s", unit)
+	}
+	assert.Equal(t, arrow.Timestamp(-1500), arrow.TimestampFromTime(when, arrow.Millisecond))
+	assert.Equal(t, arrow.Timestamp(-2), arrow.TimestampFromTime(when, arrow.Second))
+	assert.Equal(t, time.Unix(-2, 0).UTC(), arrow.Timestamp(-2).ToTime(arrow.Second))
+
+	assert.Equal(t, 1500*time.Millisecond, arrow.Duration(1500).ToDuration(arrow.Millisecond))
+}
+
+func TestTimestampTypeLocation(t *testing.T) {
+	loc, err := (&arrow.TimestampType{}).Location()
+	assert.NoError(t, err)
+	assert.Equal(t, time.UTC, loc)
+
+	loc, err = (&arrow.TimestampType{TimeZone: "Europe/Paris"}).Location()
+	assert.NoError(t, err)
+	assert.Equal(t, "Europe/Paris", loc.String())
+
+	_, err = (&arrow.TimestampType{TimeZone: "Nowhere/Special"}).Location()
+	assert.Error(t, err)
+}
@@ -44,6 +44,59 @@ func (*ListType) Name() string { return "list" }
 // Elem returns the ListType's element type.
 func (t *ListType) Elem() DataType { return t.elem }
 
+// LargeListType describes a nested type in which each array slot contains
+// a variable-size sequence of values, all having the same relative type.
+// LargeListType uses 64-bit offsets.
+type LargeListType struct {
+	elem DataType // DataType of the list's elements
+}
+
+// LargeListOf returns the large list type with element type t.
+//
+// LargeListOf panics if t is nil or invalid.
+func LargeListOf(t DataType) *LargeListType {
+	if t == nil {
+		panic("arrow: nil DataType")
+	}
+	return &LargeListType{elem: t}
+}
+
+func (*LargeListType) ID() Type     { return LARGE_LIST }
+func (*LargeListType) Name() string { return "large_list" }
+
+// Elem returns the LargeListType's element type.
+func (t *LargeListType) Elem() DataType { return t.elem }
+
+// FixedSizeListType describes a nested type in which each array slot
+// contains a sequence of n values, all having the same relative type.
+type FixedSizeListType struct {
+	n    int32    // number of elements in each list
+	elem DataType // DataType of the list's elements
+}
+
+// FixedSizeListOf returns the list type of n elements of type t.
+// For example, if t represents int32, FixedSizeListOf(3, t) represents [3]int32.
+//
+// FixedSizeListOf panics if n is negative, or if t is nil or invalid.
+func FixedSizeListOf(n int32, t DataType) *FixedSizeListType {
+	if n < 0 {
+		panic(fmt.Errorf("arrow: negative fixed size list length %d", n))
+	}
+	if t == nil {
+		panic("arrow: nil DataType")
+	}
+	return &FixedSizeListType{n: n, elem: t}
+}
+
+func (*FixedSizeListType) ID() Type     { return FIXED_SIZE_LIST }
+func (*FixedSizeListType) Name() string { return "fixed_size_list" }
+
+// Elem returns the FixedSizeListType's element type.
+func (t *FixedSizeListType) Elem() DataType { return t.elem }
+
+// Len returns the number of elements in each list.
+func (t *FixedSizeListType) Len() int32 { return t.n }
+
 // StructType describes a nested type parameterized by an ordered sequence
 // of relative types, called its fields.
 type StructType struct {
@@ -99,6 +152,149 @@ func (t *StructType) FieldByName(name string) (Field, bool) {
 	return t.fields[i], true
 }
 
+// MapType describes a nested type in which each array slot contains a
+// variable-size sequence of key/item pairs.
+// Map arrays are stored as lists of structs, with a key and a value
+// field.
+type MapType struct {
+	value      *ListType // list<entries: struct<key, value>>
+	KeysSorted bool      // whether the keys are sorted within each map
+}
+
+// MapOf returns the map type with key type key and item type item.
+//
+// MapOf panics if key or item are nil.
+func MapOf(key, item DataType) *MapType {
+	if key == nil || item == nil {
+		panic("arrow: nil key or item type for MapType")
+	}
+	return &MapType{value: ListOf(StructOf(
+		Field{Name: "key", Type: key},
+		Field{Name: "value", Type: item, Nullable: true},
+	))}
+}
+
+func (*MapType) ID() Type     { return MAP }
+func (*MapType) Name() string { return "map" }
+
+// KeyType returns the data type of the keys of the map.
+func (t *MapType) KeyType() DataType { return t.ValueType().Field(0).Type }
+
+// ItemType returns the data type of the items of the map.
+func (t *MapType) ItemType() DataType { return t.ValueType().Field(1).Type }
+
+// ValueType returns the struct type of the key/item pairs of the map.
+func (t *MapType) ValueType() *StructType { return t.value.Elem().(*StructType) }
+
+// UnionMode describes how the children of a union are laid out.
+type UnionMode int8
+
+const (
+	// SparseMode unions have children of the same length as the union.
+	SparseMode UnionMode = iota
+	// DenseMode unions carry an offset into the child selected by each slot.
+	DenseMode
+)
+
+func (m UnionMode) String() string {
+	switch m {
+	case SparseMode:
+		return "sparse"
+	case DenseMode:
+		return "dense"
+	}
+	return fmt.Sprintf("UnionMode(%d)", int8(m))
+}
+
+// UnionType describes a nested type in which each array slot holds a value
+// of one of its child types, selected by a type code.
+type UnionType struct {
+	mode      UnionMode
+	fields    []Field
+	typeCodes []int8
+	childIDs  [128]int // type code -> child index, -1 if unused
+}
+
+// UnionOf returns the union type with the given mode, fields and type codes.
+// If codes is nil, the type codes are the indices of the fields.
+//
+// UnionOf panics if the number of codes does not match the number of fields,
+// or if a code is negative or duplicated.
+func UnionOf(mode UnionMode, fs []Field, codes []int8) *UnionType {
+	if codes == nil {
+		codes = make([]int8, len(fs))
+		for i := range codes {
+			codes[i] = int8(i)
+		}
+	}
+	if len(codes) != len(fs) {
+		panic(fmt.Errorf("arrow: union with %d fields and %d type codes", len(fs), len(codes)))
+	}
+
+	t := &UnionType{
+		mode:      mode,
+		fields:    make([]Field, len(fs)),
+		typeCodes: make([]int8, len(codes)),
+	}
+	for i := range t.childIDs {
+		t.childIDs[i] = -1
+	}
+	for i, f := range fs {
+		if f.Type == nil {
+			panic("arrow: field with nil DataType")
+		}
+		c := codes[i]
+		if c < 0 {
+			panic(fmt.Errorf("arrow: invalid union type code %d", c))
+		}
+		if t.childIDs[c] != -1 {
+			panic(fmt.Errorf("arrow: duplicate union type code %d", c))
+		}
+		t.fields[i] = Field{
+			Name:     f.Name,
+			Type:     f.Type,
+			Nullable: f.Nullable,
+			Metadata: f.Metadata.clone(),
+		}
+		t.typeCodes[i] = c
+		t.childIDs[c] = i
+	}
+
+	return t
+}
+
+// SparseUnionOf returns the sparse union type with fields fs and type codes codes.
+func SparseUnionOf(fs []Field, codes []int8) *UnionType { return UnionOf(SparseMode, fs, codes) }
+
+// DenseUnionOf returns the dense union type with fields fs and type codes codes.
+func DenseUnionOf(fs []Field, codes []int8) *UnionType { return UnionOf(DenseMode, fs, codes) }
+
+func (*UnionType) ID() Type { return UNION }
+func (t *UnionType) Name() string {
+	if t.mode == DenseMode {
+		return "dense_union"
+	}
+	return "sparse_union"
+}
+
+// Mode returns the layout of the union's children.
+func (t *UnionType) Mode() UnionMode { return t.mode }
+
+func (t *UnionType) Fields() []Field   { return t.fields }
+func (t *UnionType) Field(i int) Field { return t.fields[i] }
+
+// TypeCodes returns the type codes of the union's children, in field order.
+func (t *UnionType) TypeCodes() []int8 { return t.typeCodes }
+
+// ChildID returns the index of the child with type code code,
+// or -1 if no child uses that code.
+func (t *UnionType) ChildID(code int8) int {
+	if code < 0 {
+		return -1
+	}
+	return t.childIDs[code]
+}
+
 type Field struct {
 	Name     string   // Field name
 	Type     DataType // The field's data type
@@ -114,5 +310,9 @@ func (f Field) Equal(o Field) bool {
 
 var (
 	_ DataType = (*ListType)(nil)
+	_ DataType = (*LargeListType)(nil)
+	_ DataType = (*FixedSizeListType)(nil)
 	_ DataType = (*StructType)(nil)
+	_ DataType = (*MapType)(nil)
+	_ DataType = (*UnionType)(nil)
 )
@@ -291,3 +291,60 @@ func TestFieldEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestUnionOf(t *testing.T) {
+	fields := []Field{
+		{Name: "i32", Type: PrimitiveTypes.Int32, Nullable: true},
+		{Name: "f64", Type: PrimitiveTypes.Float64, Nullable: true},
+	}
+
+	for _, tc := range []struct {
+		mode  UnionMode
+		codes []int8
+		name  string
+		want  []int8
+	}{
+		{mode: SparseMode, name: "sparse_union", want: []int8{0, 1}},
+		{mode: DenseMode, codes: []int8{5, 2}, name: "dense_union", want: []int8{5, 2}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dt := UnionOf(tc.mode, fields, tc.codes)
+			if got, want := dt.ID(), UNION; got != want {
+				t.Fatalf("got=%v, want=%v", got, want)
+			}
+			if got, want := dt.Name(), tc.name; got != want {
+				t.Fatalf("got=%q, want=%q", got, want)
+			}
+			if got, want := dt.Mode(), tc.mode; got != want {
+				t.Fatalf("got=%v, want=%v", got, want)
+			}
+			if got, want := dt.TypeCodes(), tc.want; !reflect.DeepEqual(got, want) {
+				t.Fatalf("got=%v, want=%v", got, want)
+			}
+			if !reflect.DeepEqual(dt.Fields(), fields) {
+				t.Fatalf("got=%v, want=%v", dt.Fields(), fields)
+			}
+			for i, c := range tc.want {
+				if got, want := dt.ChildID(c), i; got != want {
+					t.Fatalf("child id of code %d: got=%d, want=%d", c, got, want)
+				}
+			}
+			if got, want := dt.ChildID(42), -1; got != want {
+				t.Fatalf("got=%d, want=%d", got, want)
+			}
+		})
+	}
+
+	for _, codes := range [][]int8{{0}, {1, 1}, {-1, 0}} {
+		t.Run("invalid", func(t *testing.T) {
+			defer func() {
+				e := recover()
+				if e == nil {
+					t.Fatalf("test should have panicked but did not")
+				}
+			}()
+
+			_ = UnionOf(SparseMode, fields, codes)
+		})
+	}
+}
@@ -0,0 +1,386 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package decimal128 provides a 128-bit signed integer type, used to store
+// the unscaled values of the Arrow decimal128 type, and the arithmetic on
+// these values.
+//
+// A decimal value is a Num along with a scale, the number of its fractional
+// digits, which is held by the data type of the column rather than by the
+// Num itself. Add and Sub expect Nums of the same scale, which callers align
+// with Rescale; AddScaled and SubScaled align their operands themselves. The
+// scale of a product is the sum of the scales of its operands, and the scale
+// of a quotient the difference of the scales of the dividend and the divisor.
+package decimal128
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// MaxPrecision is the maximum number of decimal digits a Num can hold.
+const MaxPrecision = 38
+
+var (
+	two128 = new(big.Int).Lsh(big.NewInt(1), 128)
+	ten    = big.NewInt(10)
+)
+
+var errDivByZero = errors.New("decimal128: division by zero")
+
+// pow10s holds the powers of ten that fit in a Num.
+var pow10s = func() (p [MaxPrecision + 1]Num) {
+	p[0] = FromU64(1)
+	for i := 1; i < len(p); i++ {
+		p[i] = p[i-1].Mul(FromU64(10))
+	}
+	return p
+}()
+
+// Num is a 128-bit signed integer, stored as its low and high 64-bit words
+// in two's complement.
+type Num struct {
+	lo uint64
+	hi int64
+}
+
+// New returns a Num from its high and low 64-bit words.
+func New(hi int64, lo uint64) Num {
+	return Num{lo: lo, hi: hi}
+}
+
+// FromU64 returns a Num holding v.
+func FromU64(v uint64) Num {
+	return Num{lo: v}
+}
+
+// FromI64 returns a Num holding v.
+func FromI64(v int64) Num {
+	if v >= 0 {
+		return FromU64(uint64(v))
+	}
+	return Num{lo: uint64(v), hi: -1}
+}
+
+// FromBigInt returns a Num holding v, truncated to its 128 least
+// significant bits in two's complement.
+func FromBigInt(v *big.Int) Num {
+	u := new(big.Int).Set(v)
+	if u.Sign() < 0 {
+		u.Add(u, two128)
+	}
+	mask := new(big.Int).SetUint64(^uint64(0))
+	lo := new(big.Int).And(u, mask).Uint64()
+	hi := new(big.Int).And(u.Rsh(u, 64), mask).Uint64()
+	return Num{lo: lo, hi: int64(hi)}
+}
+
+// FromString parses s, a decimal number possibly holding a fractional part
+// and an exponent, as a Num with the given precision and scale.
+// FromString returns an error if s is not a valid number, if it has more
+// fractional digits than scale, or if the result does not fit in prec digits.
+func FromString(s string, prec, scale int32) (Num, error) {
+	if prec <= 0 || prec > MaxPrecision {
+		return Num{}, fmt.Errorf("decimal128: invalid precision %d", prec)
+	}
+
+	// big.Rat also parses fractions such as "1/2".
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || strings.Contains(s, "/") {
+		return Num{}, fmt.Errorf("decimal128: invalid number %q", s)
+	}
+	if scale >= 0 {
+		r.Mul(r, new(big.Rat).SetInt(pow10(scale)))
+	} else {
+		r.Quo(r, new(big.Rat).SetInt(pow10(-scale)))
+	}
+	if !r.IsInt() {
+		return Num{}, fmt.Errorf("decimal128: %q has more than %d fractional digits", s, scale)
+	}
+
+	v := r.Num()
+	if new(big.Int).Abs(v).Cmp(pow10(prec)) >= 0 {
+		return Num{}, fmt.Errorf("decimal128: %q does not fit in precision %d", s, prec)
+	}
+	return FromBigInt(v), nil
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(n)), nil)
+}
+
+// LowBits returns the low 64 bits of n.
+func (n Num) LowBits() uint64 { return n.lo }
+
+// HighBits returns the high 64 bits of n.
+func (n Num) HighBits() int64 { return n.hi }
+
+// Sign returns -1, 0 or +1 depending on the sign of n.
+func (n Num) Sign() int {
+	switch {
+	case n.hi < 0:
+		return -1
+	case n == Num{}:
+		return 0
+	}
+	return +1
+}
+
+// Negate returns -n. The negation of the minimum value is itself.
+func (n Num) Negate() Num {
+	lo, borrow := bits.Sub64(0, n.lo, 0)
+	return Num{lo: lo, hi: -n.hi - int64(borrow)}
+}
+
+// Abs returns the absolute value of n. The absolute value of the minimum
+// value is itself.
+func (n Num) Abs() Num {
+	if n.hi < 0 {
+		return n.Negate()
+	}
+	return n
+}
+
+// Add returns n+o. Like the sum of Go integers, it wraps around on overflow.
+func (n Num) Add(o Num) Num {
+	lo, carry := bits.Add64(n.lo, o.lo, 0)
+	return Num{lo: lo, hi: n.hi + o.hi + int64(carry)}
+}
+
+// Sub returns n-o. Like the difference of Go integers, it wraps around on
+// overflow.
+func (n Num) Sub(o Num) Num {
+	lo, borrow := bits.Sub64(n.lo, o.lo, 0)
+	return Num{lo: lo, hi: n.hi - o.hi - int64(borrow)}
+}
+
+// Mul returns n*o. Like the product of Go integers, it wraps around on
+// overflow.
+func (n Num) Mul(o Num) Num {
+	hi, lo := bits.Mul64(n.lo, o.lo)
+	hi += n.lo*uint64(o.hi) + uint64(n.hi)*o.lo
+	return Num{lo: lo, hi: int64(hi)}
+}
+
+// Div returns the quotient n/o, truncated toward zero, and the remainder
+// n-o*(n/o), which has the sign of n. The quotient of the minimum value by -1
+// is itself.
+//
+// Div panics if o is zero.
+func (n Num) Div(o Num) (quo, rem Num) {
+	if o == (Num{}) {
+		panic(errDivByZero)
+	}
+	quo, rem = divUnsigned(n.Abs(), o.Abs())
+	if (n.hi < 0) != (o.hi < 0) {
+		quo = quo.Negate()
+	}
+	if n.hi < 0 {
+		rem = rem.Negate()
+	}
+	return quo, rem
+}
+
+// divUnsigned returns the quotient and remainder of n by o, both taken as
+// unsigned 128-bit integers. o must not be zero.
+func divUnsigned(n, o Num) (quo, rem Num) {
+	nhi, ohi := uint64(n.hi), uint64(o.hi)
+	if ohi == 0 {
+		qhi, r := nhi/o.lo, nhi%o.lo
+		qlo, r := bits.Div64(r, n.lo, o.lo)
+		return Num{lo: qlo, hi: int64(qhi)}, Num{lo: r}
+	}
+
+	// The quotient fits in 64 bits. It is estimated by dividing n/2 by the
+	// 64 leading bits of o, which is off by at most one (Hacker's Delight,
+	// section 9-5).
+	s := uint(bits.LeadingZeros64(ohi))
+	v := ohi<<s | o.lo>>(64-s)
+	q, _ := bits.Div64(nhi>>1, nhi<<63|n.lo>>1, v)
+	q >>= 63 - s
+	if q != 0 {
+		q--
+	}
+	rem = n.Sub(o.Mul(FromU64(q)))
+	if cmpUnsigned(rem, o) >= 0 {
+		q++
+		rem = rem.Sub(o)
+	}
+	return FromU64(q), rem
+}
+
+// cmpUnsigned compares n and o taken as unsigned 128-bit integers.
+func cmpUnsigned(n, o Num) int {
+	switch a, b := uint64(n.hi), uint64(o.hi); {
+	case a != b:
+		if a < b {
+			return -1
+		}
+		return +1
+	case n.lo < o.lo:
+		return -1
+	case n.lo > o.lo:
+		return +1
+	}
+	return 0
+}
+
+// Rescale returns n, an unscaled value of scale from, as an unscaled value of
+// scale to. Rescale returns an error if the result does not fit in a Num, or
+// if reducing the scale would drop non-zero digits.
+func (n Num) Rescale(from, to int32) (Num, error) {
+	switch {
+	case from == to || n == (Num{}):
+		return n, nil
+	case to > from:
+		d := int(to - from)
+		if d < len(pow10s) && n != MinValue() {
+			limit, _ := divUnsigned(MaxValue(), pow10s[d])
+			if cmpUnsigned(n.Abs(), limit) <= 0 {
+				return n.Mul(pow10s[d]), nil
+			}
+		}
+		return Num{}, fmt.Errorf("decimal128: rescaling %s from scale %d to %d overflows", n.ToString(from), from, to)
+	default:
+		d := int(from - to)
+		if d < len(pow10s) {
+			quo, rem := n.Div(pow10s[d])
+			if rem == (Num{}) {
+				return quo, nil
+			}
+		}
+		return Num{}, fmt.Errorf("decimal128: rescaling %s from scale %d to %d loses digits", n.ToString(from), from, to)
+	}
+}
+
+// AddScaled returns the sum of n, an unscaled value of the given scale, and
+// o, an unscaled value of scale oscale, along with the scale of the sum, the
+// larger of the two. AddScaled returns an error if an operand can not be
+// rescaled or if the sum does not fit in a Num.
+func (n Num) AddScaled(scale int32, o Num, oscale int32) (Num, int32, error) {
+	n, o, scale, err := align(n, scale, o, oscale)
+	if err != nil {
+		return Num{}, 0, err
+	}
+	sum := n.Add(o)
+	if (n.hi < 0) == (o.hi < 0) && (sum.hi < 0) != (n.hi < 0) {
+		return Num{}, 0, fmt.Errorf("decimal128: %s + %s overflows", n.ToString(scale), o.ToString(scale))
+	}
+	return sum, scale, nil
+}
+
+// SubScaled returns the difference of n, an unscaled value of the given
+// scale, and o, an unscaled value of scale oscale, along with the scale of
+// the difference, the larger of the two. SubScaled returns an error if an
+// operand can not be rescaled or if the difference does not fit in a Num.
+func (n Num) SubScaled(scale int32, o Num, oscale int32) (Num, int32, error) {
+	n, o, scale, err := align(n, scale, o, oscale)
+	if err != nil {
+		return Num{}, 0, err
+	}
+	diff := n.Sub(o)
+	if (n.hi < 0) != (o.hi < 0) && (diff.hi < 0) != (n.hi < 0) {
+		return Num{}, 0, fmt.Errorf("decimal128: %s - %s overflows", n.ToString(scale), o.ToString(scale))
+	}
+	return diff, scale, nil
+}
+
+// align rescales the operand of the smaller scale to the larger one.
+func align(n Num, scale int32, o Num, oscale int32) (Num, Num, int32, error) {
+	var err error
+	switch {
+	case scale < oscale:
+		n, err = n.Rescale(scale, oscale)
+		scale = oscale
+	case oscale < scale:
+		o, err = o.Rescale(oscale, scale)
+	}
+	return n, o, scale, err
+}
+
+// Cmp compares n and o and returns -1, 0 or +1 if n is respectively less
+// than, equal to or greater than o.
+func (n Num) Cmp(o Num) int {
+	switch {
+	case n.hi < o.hi:
+		return -1
+	case n.hi > o.hi:
+		return +1
+	case n.lo < o.lo:
+		return -1
+	case n.lo > o.lo:
+		return +1
+	}
+	return 0
+}
+
+// Less returns whether n is less than o.
+func (n Num) Less(o Num) bool { return n.Cmp(o) < 0 }
+
+// LessEqual returns whether n is less than or equal to o.
+func (n Num) LessEqual(o Num) bool { return n.Cmp(o) <= 0 }
+
+// Greater returns whether n is greater than o.
+func (n Num) Greater(o Num) bool { return n.Cmp(o) > 0 }
+
+// GreaterEqual returns whether n is greater than or equal to o.
+func (n Num) GreaterEqual(o Num) bool { return n.Cmp(o) >= 0 }
+
+// BigInt returns the value of n as a big.Int.
+func (n Num) BigInt() *big.Int {
+	v := new(big.Int).SetUint64(uint64(n.hi))
+	v.Lsh(v, 64)
+	v.Or(v, new(big.Int).SetUint64(n.lo))
+	if n.hi < 0 {
+		v.Sub(v, two128)
+	}
+	return v
+}
+
+// FitsInPrecision returns whether n has at most prec decimal digits.
+func (n Num) FitsInPrecision(prec int32) bool {
+	return new(big.Int).Abs(n.BigInt()).Cmp(pow10(prec)) < 0
+}
+
+// ToString returns the decimal representation of n, interpreted as an
+// unscaled value with scale fractional digits.
+func (n Num) ToString(scale int32) string {
+	s := n.BigInt().String()
+	if scale <= 0 {
+		return s + strings.Repeat("0", int(-scale))
+	}
+
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if len(s) <= int(scale) {
+		s = strings.Repeat("0", int(scale)-len(s)+1) + s
+	}
+	s = s[:len(s)-int(scale)] + "." + s[len(s)-int(scale):]
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// MaxValue returns the maximum value a Num can hold.
+func MaxValue() Num { return Num{lo: ^uint64(0), hi: math.MaxInt64} }
+
+// MinValue returns the minimum value a Num can hold.
+func MinValue() Num { return Num{hi: math.MinInt64} }
@@ -0,0 +1,262 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decimal128_test
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNum(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		n    decimal128.Num
+		want string
+		sign int
+	}{
+		{"zero", decimal128.FromU64(0), "0", 0},
+		{"one", decimal128.FromI64(1), "1", +1},
+		{"minus-one", decimal128.FromI64(-1), "-1", -1},
+		{"u64", decimal128.FromU64(1<<64 - 1), "18446744073709551615", +1},
+		{"word", decimal128.New(1, 0), "18446744073709551616", +1},
+		{"max", decimal128.MaxValue(), "170141183460469231731687303715884105727", +1},
+		{"min", decimal128.MinValue(), "-170141183460469231731687303715884105728", -1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.n.BigInt().String())
+			assert.Equal(t, tc.sign, tc.n.Sign())
+
+			v, _ := new(big.Int).SetString(tc.want, 10)
+			assert.Equal(t, tc.n, decimal128.FromBigInt(v))
+
+			if tc.name != "min" {
+				assert.Equal(t, new(big.Int).Neg(v), tc.n.Negate().BigInt())
+				assert.Equal(t, new(big.Int).Abs(v), tc.n.Abs().BigInt())
+			}
+		})
+	}
+
+	assert.Equal(t, decimal128.MinValue(), decimal128.MinValue().Negate())
+	n := decimal128.New(-2, 3)
+	assert.Equal(t, int64(-2), n.HighBits())
+	assert.Equal(t, uint64(3), n.LowBits())
+}
+
+func TestNumArithmetic(t *testing.T) {
+	two128 := new(big.Int).Lsh(big.NewInt(1), 128)
+	wrap := func(v *big.Int) *big.Int {
+		v.Mod(v, two128)
+		if v.Cmp(decimal128.MaxValue().BigInt()) > 0 {
+			v.Sub(v, two128)
+		}
+		return v
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	num := func() decimal128.Num {
+		switch rnd.Intn(4) {
+		case 0:
+			return decimal128.FromI64(rnd.Int63n(2000) - 1000)
+		case 1:
+			return decimal128.FromI64(rnd.Int63() - rnd.Int63())
+		}
+		return decimal128.New(rnd.Int63()-rnd.Int63(), rnd.Uint64())
+	}
+
+	for i := 0; i < 1000; i++ {
+		a, b := num(), num()
+		x, y := a.BigInt(), b.BigInt()
+
+		assert.Equal(t, wrap(new(big.Int).Add(x, y)).String(), a.Add(b).BigInt().String(), "%v + %v", x, y)
+		assert.Equal(t, wrap(new(big.Int).Sub(x, y)).String(), a.Sub(b).BigInt().String(), "%v - %v", x, y)
+		assert.Equal(t, wrap(new(big.Int).Mul(x, y)).String(), a.Mul(b).BigInt().String(), "%v * %v", x, y)
+		if b.Sign() != 0 {
+			q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+			quo, rem := a.Div(b)
+			assert.Equal(t, q.String(), quo.BigInt().String(), "%v / %v", x, y)
+			assert.Equal(t, r.String(), rem.BigInt().String(), "%v %% %v", x, y)
+		}
+		assert.Equal(t, x.Cmp(y), a.Cmp(b), "cmp(%v, %v)", x, y)
+	}
+
+	assert.Equal(t, decimal128.MinValue(), decimal128.MaxValue().Add(decimal128.FromI64(1)))
+	quo, _ := decimal128.MinValue().Div(decimal128.FromI64(-1))
+	assert.Equal(t, decimal128.MinValue(), quo)
+	assert.Panics(t, func() { decimal128.FromI64(1).Div(decimal128.Num{}) })
+}
+
+func TestNumCmp(t *testing.T) {
+	nums := []decimal128.Num{
+		decimal128.MinValue(),
+		decimal128.New(-1, 0),
+		decimal128.FromI64(-1),
+		decimal128.FromI64(0),
+		decimal128.FromU64(1),
+		decimal128.New(0, 1<<63),
+		decimal128.New(1, 0),
+		decimal128.MaxValue(),
+	}
+	for i := range nums {
+		for j := range nums {
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = +1
+			}
+			assert.Equal(t, want, nums[i].Cmp(nums[j]), "cmp(%d, %d)", i, j)
+			assert.Equal(t, want < 0, nums[i].Less(nums[j]), "less(%d, %d)", i, j)
+			assert.Equal(t, want <= 0, nums[i].LessEqual(nums[j]), "less-equal(%d, %d)", i, j)
+			assert.Equal(t, want > 0, nums[i].Greater(nums[j]), "greater(%d, %d)", i, j)
+			assert.Equal(t, want >= 0, nums[i].GreaterEqual(nums[j]), "greater-equal(%d, %d)", i, j)
+		}
+	}
+}
+
+func TestNumRescale(t *testing.T) {
+	for _, tc := range []struct {
+		s        string
+		from, to int32
+		want     string
+		err      string
+	}{
+		{s: "1.5", from: 1, to: 3, want: "1.500"},
+		{s: "-1.500", from: 3, to: 1, want: "-1.5"},
+		{s: "120", from: 0, to: -1, want: "120"},
+		{s: "2.25", from: 2, to: 2, want: "2.25"},
+		{s: "1.25", from: 2, to: 1, err: "decimal128: rescaling 1.25 from scale 2 to 1 loses digits"},
+		{s: "1", from: 0, to: 39, err: "decimal128: rescaling 1 from scale 0 to 39 overflows"},
+		{s: "0", from: 0, to: 39, want: "0.000000000000000000000000000000000000000"},
+		{s: "17014118346046923173168730371588410572", from: 0, to: 2, err: "decimal128: rescaling 17014118346046923173168730371588410572 from scale 0 to 2 overflows"},
+		{s: "17014118346046923173168730371588410572", from: 0, to: 1, want: "17014118346046923173168730371588410572.0"},
+		{s: "-17014118346046923173168730371588410572", from: 0, to: 1, want: "-17014118346046923173168730371588410572.0"},
+		{s: "-1.0000000000000000000000000000000000000", from: 37, to: 0, want: "-1"},
+	} {
+		t.Run(tc.s, func(t *testing.T) {
+			n, err := decimal128.FromString(tc.s, 38, tc.from)
+			assert.NoError(t, err)
+			n, err = n.Rescale(tc.from, tc.to)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, n.ToString(tc.to))
+		})
+	}
+}
+
+func TestNumDecimalArithmetic(t *testing.T) {
+	// 12.50 * 0.3 = 3.750, and 3.750 / 0.3 = 12.50
+	a, _ := decimal128.FromString("12.50", 10, 2)
+	b, _ := decimal128.FromString("0.3", 10, 1)
+	prod := a.Mul(b)
+	assert.Equal(t, "3.750", prod.ToString(3))
+
+	dividend, err := prod.Rescale(3, 3)
+	assert.NoError(t, err)
+	quo, rem := dividend.Div(b)
+	assert.Equal(t, "12.50", quo.ToString(2))
+	assert.Equal(t, 0, rem.Sign())
+
+	// 12.50 + 0.3 = 12.80, at the larger scale.
+	c, err := b.Rescale(1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "12.80", a.Add(c).ToString(2))
+	assert.Equal(t, "-12.20", c.Sub(a).ToString(2))
+
+	// AddScaled and SubScaled align the scales themselves.
+	sum, scale, err := b.AddScaled(1, a, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "12.80", sum.ToString(scale))
+	diff, scale, err := b.SubScaled(1, a, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "-12.20", diff.ToString(scale))
+
+	_, _, err = decimal128.MaxValue().AddScaled(0, decimal128.FromI64(1), 0)
+	assert.EqualError(t, err, "decimal128: 170141183460469231731687303715884105727 + 1 overflows")
+	_, _, err = decimal128.MinValue().SubScaled(0, decimal128.FromI64(1), 0)
+	assert.EqualError(t, err, "decimal128: -170141183460469231731687303715884105728 - 1 overflows")
+	_, _, err = decimal128.MaxValue().AddScaled(0, decimal128.FromI64(1), 1)
+	assert.EqualError(t, err, "decimal128: rescaling 170141183460469231731687303715884105727 from scale 0 to 1 overflows")
+}
+
+func TestNumRescaleRandom(t *testing.T) {
+	max := decimal128.MaxValue().BigInt()
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		n := decimal128.New(rnd.Int63()>>uint(rnd.Intn(64))-rnd.Int63()>>uint(rnd.Intn(64)), rnd.Uint64())
+		from, to := int32(rnd.Intn(40)), int32(rnd.Intn(40))
+
+		v := n.BigInt()
+		ok := true
+		if to > from {
+			v.Mul(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(to-from)), nil))
+			ok = new(big.Int).Abs(v).Cmp(max) <= 0
+		} else {
+			var rem big.Int
+			v.QuoRem(v, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(from-to)), nil), &rem)
+			ok = rem.Sign() == 0
+		}
+
+		got, err := n.Rescale(from, to)
+		if !ok {
+			assert.Error(t, err, "rescale(%v, %d, %d)", n.BigInt(), from, to)
+			continue
+		}
+		assert.NoError(t, err, "rescale(%v, %d, %d)", n.BigInt(), from, to)
+		assert.Equal(t, v.String(), got.BigInt().String(), "rescale(%v, %d, %d)", n.BigInt(), from, to)
+	}
+}
+
+func TestNumString(t *testing.T) {
+	for _, tc := range []struct {
+		s     string
+		prec  int32
+		scale int32
+		want  string
+		err   string
+	}{
+		{s: "123.45", prec: 5, scale: 2, want: "123.45"},
+		{s: "-0.5", prec: 5, scale: 3, want: "-0.500"},
+		{s: "0.001", prec: 3, scale: 3, want: "0.001"},
+		{s: "1e3", prec: 10, scale: 0, want: "1000"},
+		{s: "120", prec: 3, scale: -1, want: "120"},
+		{s: "99999999999999999999999999999999999999", prec: 38, scale: 0, want: "99999999999999999999999999999999999999"},
+		{s: "1.5", prec: 5, scale: 0, err: `decimal128: "1.5" has more than 0 fractional digits`},
+		{s: "1000", prec: 3, scale: 0, err: `decimal128: "1000" does not fit in precision 3`},
+		{s: "x", prec: 3, scale: 0, err: `decimal128: invalid number "x"`},
+		{s: "1/2", prec: 3, scale: 1, err: `decimal128: invalid number "1/2"`},
+		{s: "1", prec: 39, scale: 0, err: "decimal128: invalid precision 39"},
+	} {
+		t.Run(tc.s, func(t *testing.T) {
+			n, err := decimal128.FromString(tc.s, tc.prec, tc.scale)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, n.ToString(tc.scale))
+			assert.True(t, n.FitsInPrecision(tc.prec))
+		})
+	}
+}
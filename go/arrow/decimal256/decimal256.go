@@ -0,0 +1,475 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package decimal256 provides a 256-bit signed integer type, used to store
+// the unscaled values of the Arrow decimal256 type.
+//
+// Its arithmetic follows the one of package decimal128: Add and Sub expect
+// Nums of the same scale, which callers align with Rescale, while AddScaled
+// and SubScaled align their operands themselves.
+package decimal256
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+	"strings"
+)
+
+// MaxPrecision is the maximum number of decimal digits a Num can hold.
+const MaxPrecision = 76
+
+var (
+	two256 = new(big.Int).Lsh(big.NewInt(1), 256)
+	ten    = big.NewInt(10)
+)
+
+var errDivByZero = errors.New("decimal256: division by zero")
+
+// pow10s holds the powers of ten that fit in a Num.
+var pow10s = func() (p [MaxPrecision + 1]Num) {
+	p[0] = FromU64(1)
+	for i := 1; i < len(p); i++ {
+		p[i] = p[i-1].Mul(FromU64(10))
+	}
+	return p
+}()
+
+// Num is a 256-bit signed integer, stored as four 64-bit words in
+// two's complement, least significant word first.
+type Num struct {
+	arr [4]uint64
+}
+
+// New returns a Num from its four 64-bit words, most significant first.
+func New(x1, x2, x3, x4 uint64) Num {
+	return Num{arr: [4]uint64{x4, x3, x2, x1}}
+}
+
+// FromU64 returns a Num holding v.
+func FromU64(v uint64) Num {
+	return Num{arr: [4]uint64{v, 0, 0, 0}}
+}
+
+// FromI64 returns a Num holding v.
+func FromI64(v int64) Num {
+	if v >= 0 {
+		return FromU64(uint64(v))
+	}
+	ext := ^uint64(0)
+	return Num{arr: [4]uint64{uint64(v), ext, ext, ext}}
+}
+
+// FromBigInt returns a Num holding v, truncated to its 256 least
+// significant bits in two's complement.
+func FromBigInt(v *big.Int) Num {
+	u := new(big.Int).Set(v)
+	if u.Sign() < 0 {
+		u.Add(u, two256)
+	}
+
+	var n Num
+	words := new(big.Int)
+	mask := new(big.Int).SetUint64(^uint64(0))
+	for i := range n.arr {
+		n.arr[i] = words.And(u, mask).Uint64()
+		u.Rsh(u, 64)
+	}
+	return n
+}
+
+// FromString parses s, a decimal number possibly holding a fractional part
+// and an exponent, as a Num with the given precision and scale.
+// FromString returns an error if s is not a valid number, if it has more
+// fractional digits than scale, or if the result does not fit in prec digits.
+func FromString(s string, prec, scale int32) (Num, error) {
+	if prec <= 0 || prec > MaxPrecision {
+		return Num{}, fmt.Errorf("decimal256: invalid precision %d", prec)
+	}
+
+	// big.Rat also parses fractions such as "1/2".
+	r, ok := new(big.Rat).SetString(s)
+	if !ok || strings.Contains(s, "/") {
+		return Num{}, fmt.Errorf("decimal256: invalid number %q", s)
+	}
+	if scale >= 0 {
+		r.Mul(r, new(big.Rat).SetInt(pow10(scale)))
+	} else {
+		r.Quo(r, new(big.Rat).SetInt(pow10(-scale)))
+	}
+	if !r.IsInt() {
+		return Num{}, fmt.Errorf("decimal256: %q has more than %d fractional digits", s, scale)
+	}
+
+	v := r.Num()
+	if new(big.Int).Abs(v).Cmp(pow10(prec)) >= 0 {
+		return Num{}, fmt.Errorf("decimal256: %q does not fit in precision %d", s, prec)
+	}
+	return FromBigInt(v), nil
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(n)), nil)
+}
+
+// Array returns the four 64-bit words of n, least significant first.
+func (n Num) Array() [4]uint64 { return n.arr }
+
+// Sign returns -1, 0 or +1 depending on the sign of n.
+func (n Num) Sign() int {
+	switch {
+	case int64(n.arr[3]) < 0:
+		return -1
+	case n.arr == [4]uint64{}:
+		return 0
+	}
+	return +1
+}
+
+// Negate returns -n. The negation of the minimum value is itself.
+func (n Num) Negate() Num {
+	var (
+		out   Num
+		carry = uint64(1)
+	)
+	for i, w := range n.arr {
+		out.arr[i] = ^w + carry
+		if out.arr[i] != 0 {
+			carry = 0
+		}
+	}
+	return out
+}
+
+// Abs returns the absolute value of n. The absolute value of the minimum
+// value is itself.
+func (n Num) Abs() Num {
+	if n.Sign() < 0 {
+		return n.Negate()
+	}
+	return n
+}
+
+// Add returns n+o. Like the sum of Go integers, it wraps around on overflow.
+func (n Num) Add(o Num) Num {
+	var (
+		out   Num
+		carry uint64
+	)
+	for i := range n.arr {
+		out.arr[i], carry = bits.Add64(n.arr[i], o.arr[i], carry)
+	}
+	return out
+}
+
+// Sub returns n-o. Like the difference of Go integers, it wraps around on
+// overflow.
+func (n Num) Sub(o Num) Num {
+	var (
+		out    Num
+		borrow uint64
+	)
+	for i := range n.arr {
+		out.arr[i], borrow = bits.Sub64(n.arr[i], o.arr[i], borrow)
+	}
+	return out
+}
+
+// Mul returns n*o. Like the product of Go integers, it wraps around on
+// overflow.
+func (n Num) Mul(o Num) Num {
+	var out Num
+	for i := range n.arr {
+		var carry uint64
+		for j := 0; i+j < len(out.arr); j++ {
+			hi, lo := bits.Mul64(n.arr[i], o.arr[j])
+			var c uint64
+			lo, c = bits.Add64(lo, out.arr[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			out.arr[i+j], carry = lo, hi+c
+		}
+	}
+	return out
+}
+
+// Div returns the quotient n/o, truncated toward zero, and the remainder
+// n-o*(n/o), which has the sign of n. The quotient of the minimum value by -1
+// is itself.
+//
+// Div panics if o is zero.
+func (n Num) Div(o Num) (quo, rem Num) {
+	if o == (Num{}) {
+		panic(errDivByZero)
+	}
+	quo, rem = divUnsigned(n.Abs(), o.Abs())
+	if (n.Sign() < 0) != (o.Sign() < 0) {
+		quo = quo.Negate()
+	}
+	if n.Sign() < 0 {
+		rem = rem.Negate()
+	}
+	return quo, rem
+}
+
+// divUnsigned returns the quotient and remainder of n by o, both taken as
+// unsigned 256-bit integers. o must not be zero.
+//
+// It is the long division of Knuth, The Art of Computer Programming, vol. 2,
+// section 4.3.1, algorithm D, on 64-bit words.
+func divUnsigned(n, o Num) (quo, rem Num) {
+	l := len(o.arr)
+	for o.arr[l-1] == 0 {
+		l--
+	}
+
+	if l == 1 {
+		var r uint64
+		for i := len(n.arr) - 1; i >= 0; i-- {
+			quo.arr[i], r = bits.Div64(r, n.arr[i], o.arr[0])
+		}
+		return quo, FromU64(r)
+	}
+
+	// Normalize the operands so that the top bit of the divisor is set.
+	s := uint(bits.LeadingZeros64(o.arr[l-1]))
+	u, v := shl(n.arr, s), shl(o.arr, s)
+	vt, vn := v[l-1], v[l-2]
+
+	for j := len(n.arr) - l; j >= 0; j-- {
+		// Estimate the quotient word from the top words, then correct the
+		// estimate until it is at most one too large.
+		qhat, rhat, overflow := ^uint64(0), uint64(0), false
+		if u[j+l] < vt {
+			qhat, rhat = bits.Div64(u[j+l], u[j+l-1], vt)
+		} else {
+			var c uint64
+			rhat, c = bits.Add64(u[j+l-1], vt, 0)
+			overflow = c != 0
+		}
+		for !overflow {
+			hi, lo := bits.Mul64(qhat, vn)
+			if hi < rhat || hi == rhat && lo <= u[j+l-2] {
+				break
+			}
+			qhat--
+			var c uint64
+			rhat, c = bits.Add64(rhat, vt, 0)
+			overflow = c != 0
+		}
+
+		// Subtract qhat*v from u, adding v back if qhat was too large.
+		var carry, borrow uint64
+		for i := 0; i < l; i++ {
+			hi, lo := bits.Mul64(qhat, v[i])
+			var c uint64
+			lo, c = bits.Add64(lo, carry, 0)
+			carry = hi + c
+			u[j+i], borrow = bits.Sub64(u[j+i], lo, borrow)
+		}
+		u[j+l], borrow = bits.Sub64(u[j+l], carry, borrow)
+		if borrow != 0 {
+			qhat--
+			var c uint64
+			for i := 0; i < l; i++ {
+				u[j+i], c = bits.Add64(u[j+i], v[i], c)
+			}
+			u[j+l] += c
+		}
+		quo.arr[j] = qhat
+	}
+
+	for i := 0; i < l; i++ {
+		rem.arr[i] = u[i]>>s | u[i+1]<<(64-s)
+	}
+	return quo, rem
+}
+
+// shl returns the words of a shifted left by s < 64 bits, with an extra
+// word for the shifted-out bits.
+func shl(a [4]uint64, s uint) (out [5]uint64) {
+	for i := len(a) - 1; i >= 0; i-- {
+		out[i+1] |= a[i] >> (64 - s)
+		out[i] = a[i] << s
+	}
+	return out
+}
+
+// cmpUnsigned compares n and o taken as unsigned 256-bit integers.
+func cmpUnsigned(n, o Num) int {
+	for i := len(n.arr) - 1; i >= 0; i-- {
+		if a, b := n.arr[i], o.arr[i]; a != b {
+			if a < b {
+				return -1
+			}
+			return +1
+		}
+	}
+	return 0
+}
+
+// Rescale returns n, an unscaled value of scale from, as an unscaled value of
+// scale to. Rescale returns an error if the result does not fit in a Num, or
+// if reducing the scale would drop non-zero digits.
+func (n Num) Rescale(from, to int32) (Num, error) {
+	switch {
+	case from == to || n == (Num{}):
+		return n, nil
+	case to > from:
+		d := int(to - from)
+		if d < len(pow10s) && n != MinValue() {
+			limit, _ := divUnsigned(MaxValue(), pow10s[d])
+			if cmpUnsigned(n.Abs(), limit) <= 0 {
+				return n.Mul(pow10s[d]), nil
+			}
+		}
+		return Num{}, fmt.Errorf("decimal256: rescaling %s from scale %d to %d overflows", n.ToString(from), from, to)
+	default:
+		d := int(from - to)
+		if d < len(pow10s) {
+			quo, rem := n.Div(pow10s[d])
+			if rem == (Num{}) {
+				return quo, nil
+			}
+		}
+		return Num{}, fmt.Errorf("decimal256: rescaling %s from scale %d to %d loses digits", n.ToString(from), from, to)
+	}
+}
+
+// AddScaled returns the sum of n, an unscaled value of the given scale, and
+// o, an unscaled value of scale oscale, along with the scale of the sum, the
+// larger of the two. AddScaled returns an error if an operand can not be
+// rescaled or if the sum does not fit in a Num.
+func (n Num) AddScaled(scale int32, o Num, oscale int32) (Num, int32, error) {
+	n, o, scale, err := align(n, scale, o, oscale)
+	if err != nil {
+		return Num{}, 0, err
+	}
+	sum := n.Add(o)
+	if (n.Sign() < 0) == (o.Sign() < 0) && (sum.Sign() < 0) != (n.Sign() < 0) {
+		return Num{}, 0, fmt.Errorf("decimal256: %s + %s overflows", n.ToString(scale), o.ToString(scale))
+	}
+	return sum, scale, nil
+}
+
+// SubScaled returns the difference of n, an unscaled value of the given
+// scale, and o, an unscaled value of scale oscale, along with the scale of
+// the difference, the larger of the two. SubScaled returns an error if an
+// operand can not be rescaled or if the difference does not fit in a Num.
+func (n Num) SubScaled(scale int32, o Num, oscale int32) (Num, int32, error) {
+	n, o, scale, err := align(n, scale, o, oscale)
+	if err != nil {
+		return Num{}, 0, err
+	}
+	diff := n.Sub(o)
+	if (n.Sign() < 0) != (o.Sign() < 0) && (diff.Sign() < 0) != (n.Sign() < 0) {
+		return Num{}, 0, fmt.Errorf("decimal256: %s - %s overflows", n.ToString(scale), o.ToString(scale))
+	}
+	return diff, scale, nil
+}
+
+// align rescales the operand of the smaller scale to the larger one.
+func align(n Num, scale int32, o Num, oscale int32) (Num, Num, int32, error) {
+	var err error
+	switch {
+	case scale < oscale:
+		n, err = n.Rescale(scale, oscale)
+		scale = oscale
+	case oscale < scale:
+		o, err = o.Rescale(oscale, scale)
+	}
+	return n, o, scale, err
+}
+
+// Cmp compares n and o and returns -1, 0 or +1 if n is respectively less
+// than, equal to or greater than o.
+func (n Num) Cmp(o Num) int {
+	if a, b := int64(n.arr[3]), int64(o.arr[3]); a != b {
+		if a < b {
+			return -1
+		}
+		return +1
+	}
+	for i := 2; i >= 0; i-- {
+		if a, b := n.arr[i], o.arr[i]; a != b {
+			if a < b {
+				return -1
+			}
+			return +1
+		}
+	}
+	return 0
+}
+
+// Less returns whether n is less than o.
+func (n Num) Less(o Num) bool { return n.Cmp(o) < 0 }
+
+// LessEqual returns whether n is less than or equal to o.
+func (n Num) LessEqual(o Num) bool { return n.Cmp(o) <= 0 }
+
+// Greater returns whether n is greater than o.
+func (n Num) Greater(o Num) bool { return n.Cmp(o) > 0 }
+
+// GreaterEqual returns whether n is greater than or equal to o.
+func (n Num) GreaterEqual(o Num) bool { return n.Cmp(o) >= 0 }
+
+// BigInt returns the value of n as a big.Int.
+func (n Num) BigInt() *big.Int {
+	v := new(big.Int)
+	for i := len(n.arr) - 1; i >= 0; i-- {
+		v.Lsh(v, 64)
+		v.Or(v, new(big.Int).SetUint64(n.arr[i]))
+	}
+	if n.Sign() < 0 {
+		v.Sub(v, two256)
+	}
+	return v
+}
+
+// FitsInPrecision returns whether n has at most prec decimal digits.
+func (n Num) FitsInPrecision(prec int32) bool {
+	return new(big.Int).Abs(n.BigInt()).Cmp(pow10(prec)) < 0
+}
+
+// ToString returns the decimal representation of n, interpreted as an
+// unscaled value with scale fractional digits.
+func (n Num) ToString(scale int32) string {
+	s := n.BigInt().String()
+	if scale <= 0 {
+		return s + strings.Repeat("0", int(-scale))
+	}
+
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if len(s) <= int(scale) {
+		s = strings.Repeat("0", int(scale)-len(s)+1) + s
+	}
+	s = s[:len(s)-int(scale)] + "." + s[len(s)-int(scale):]
+	if neg {
+		s = "-" + s
+	}
+	return s
+}
+
+// MaxValue returns the maximum value a Num can hold.
+func MaxValue() Num {
+	return Num{arr: [4]uint64{^uint64(0), ^uint64(0), ^uint64(0), math.MaxInt64}}
+}
+
+// MinValue returns the minimum value a Num can hold.
+func MinValue() Num { return Num{arr: [4]uint64{0, 0, 0, 1 << 63}} }
@@ -0,0 +1,234 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package decimal256_test
+
+import (
+	"math/big"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNum(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		n    decimal256.Num
+		want string
+		sign int
+	}{
+		{"zero", decimal256.FromU64(0), "0", 0},
+		{"one", decimal256.FromI64(1), "1", +1},
+		{"minus-one", decimal256.FromI64(-1), "-1", -1},
+		{"u64", decimal256.FromU64(1<<64 - 1), "18446744073709551615", +1},
+		{"word", decimal256.New(0, 0, 1, 0), "18446744073709551616", +1},
+		{"max", decimal256.MaxValue(), "57896044618658097711785492504343953926634992332820282019728792003956564819967", +1},
+		{"min", decimal256.MinValue(), "-57896044618658097711785492504343953926634992332820282019728792003956564819968", -1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.n.BigInt().String())
+			assert.Equal(t, tc.sign, tc.n.Sign())
+
+			v, _ := new(big.Int).SetString(tc.want, 10)
+			assert.Equal(t, tc.n, decimal256.FromBigInt(v))
+
+			if tc.name != "min" {
+				assert.Equal(t, new(big.Int).Neg(v), tc.n.Negate().BigInt())
+				assert.Equal(t, new(big.Int).Abs(v), tc.n.Abs().BigInt())
+			}
+		})
+	}
+
+	assert.Equal(t, decimal256.MinValue(), decimal256.MinValue().Negate())
+	assert.Equal(t, [4]uint64{4, 3, 2, 1}, decimal256.New(1, 2, 3, 4).Array())
+}
+
+func TestNumCmp(t *testing.T) {
+	nums := []decimal256.Num{
+		decimal256.MinValue(),
+		decimal256.New(^uint64(0), 0, 0, 0),
+		decimal256.FromI64(-1),
+		decimal256.FromI64(0),
+		decimal256.FromU64(1),
+		decimal256.New(0, 0, 1, 0),
+		decimal256.MaxValue(),
+	}
+	for i := range nums {
+		for j := range nums {
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = +1
+			}
+			assert.Equal(t, want, nums[i].Cmp(nums[j]), "cmp(%d, %d)", i, j)
+			assert.Equal(t, want < 0, nums[i].Less(nums[j]), "less(%d, %d)", i, j)
+			assert.Equal(t, want <= 0, nums[i].LessEqual(nums[j]), "less-equal(%d, %d)", i, j)
+			assert.Equal(t, want > 0, nums[i].Greater(nums[j]), "greater(%d, %d)", i, j)
+			assert.Equal(t, want >= 0, nums[i].GreaterEqual(nums[j]), "greater-equal(%d, %d)", i, j)
+		}
+	}
+}
+
+func TestNumArithmetic(t *testing.T) {
+	two256 := new(big.Int).Lsh(big.NewInt(1), 256)
+	wrap := func(v *big.Int) *big.Int {
+		v.Mod(v, two256)
+		if v.Cmp(decimal256.MaxValue().BigInt()) > 0 {
+			v.Sub(v, two256)
+		}
+		return v
+	}
+
+	// num returns a Num of 1 to 4 significant words, some of them saturated,
+	// to go through every path of the long division.
+	rnd := rand.New(rand.NewSource(1))
+	num := func() decimal256.Num {
+		var w [4]uint64
+		for i := rnd.Intn(len(w)); i >= 0; i-- {
+			switch rnd.Intn(4) {
+			case 0:
+				w[i] = ^uint64(0)
+			case 1:
+				w[i] = rnd.Uint64() >> uint(rnd.Intn(64))
+			default:
+				w[i] = rnd.Uint64()
+			}
+		}
+		n := decimal256.New(w[3], w[2], w[1], w[0])
+		if rnd.Intn(2) == 0 {
+			n = n.Negate()
+		}
+		return n
+	}
+
+	for i := 0; i < 5000; i++ {
+		a, b := num(), num()
+		x, y := a.BigInt(), b.BigInt()
+
+		assert.Equal(t, wrap(new(big.Int).Add(x, y)).String(), a.Add(b).BigInt().String(), "%v + %v", x, y)
+		assert.Equal(t, wrap(new(big.Int).Sub(x, y)).String(), a.Sub(b).BigInt().String(), "%v - %v", x, y)
+		assert.Equal(t, wrap(new(big.Int).Mul(x, y)).String(), a.Mul(b).BigInt().String(), "%v * %v", x, y)
+		if b.Sign() != 0 && a != decimal256.MinValue() {
+			q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+			quo, rem := a.Div(b)
+			assert.Equal(t, q.String(), quo.BigInt().String(), "%v / %v", x, y)
+			assert.Equal(t, r.String(), rem.BigInt().String(), "%v %% %v", x, y)
+		}
+	}
+
+	// The estimated quotient word of these operands is one too large.
+	a := decimal256.New(0x7fffffffffffffff, 0x0000000000000001, 0x7fffffffffffffff, 0x0000000100000000)
+	b := decimal256.New(0, 0x8000000000000000, 0x0000000000000001, 0xffffffffffffffff)
+	quo, rem := a.Div(b)
+	assert.Equal(t, "18446744073709551613", quo.BigInt().String())
+	assert.Equal(t, "3138550867693340381747753528143363976430170882962685427709", rem.BigInt().String())
+
+	assert.Equal(t, decimal256.MinValue(), decimal256.MaxValue().Add(decimal256.FromI64(1)))
+	quo, _ = decimal256.MinValue().Div(decimal256.FromI64(-1))
+	assert.Equal(t, decimal256.MinValue(), quo)
+	assert.Panics(t, func() { decimal256.FromI64(1).Div(decimal256.Num{}) })
+}
+
+func TestNumRescale(t *testing.T) {
+	for _, tc := range []struct {
+		s        string
+		from, to int32
+		want     string
+		err      string
+	}{
+		{s: "1.5", from: 1, to: 3, want: "1.500"},
+		{s: "-1.500", from: 3, to: 1, want: "-1.5"},
+		{s: "120", from: 0, to: -1, want: "120"},
+		{s: "2.25", from: 2, to: 2, want: "2.25"},
+		{s: "0", from: 0, to: 77, want: "0." + strings.Repeat("0", 77)},
+		{s: "-1." + strings.Repeat("0", 75), from: 75, to: 0, want: "-1"},
+		{s: "5" + strings.Repeat("0", 75), from: 0, to: 1, want: "5" + strings.Repeat("0", 75) + ".0"},
+		{s: "1.25", from: 2, to: 1, err: "decimal256: rescaling 1.25 from scale 2 to 1 loses digits"},
+		{s: "6" + strings.Repeat("0", 75), from: 0, to: 1, err: "decimal256: rescaling 6" + strings.Repeat("0", 75) + " from scale 0 to 1 overflows"},
+		{s: "1", from: 0, to: 77, err: "decimal256: rescaling 1 from scale 0 to 77 overflows"},
+	} {
+		t.Run(tc.s, func(t *testing.T) {
+			n, err := decimal256.FromString(tc.s, decimal256.MaxPrecision, tc.from)
+			assert.NoError(t, err)
+			n, err = n.Rescale(tc.from, tc.to)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, n.ToString(tc.to))
+		})
+	}
+}
+
+func TestNumDecimalArithmetic(t *testing.T) {
+	a, _ := decimal256.FromString("12.50", 10, 2)
+	b, _ := decimal256.FromString("0.3", 10, 1)
+	prod := a.Mul(b)
+	assert.Equal(t, "3.750", prod.ToString(3))
+	quo, rem := prod.Div(b)
+	assert.Equal(t, "12.50", quo.ToString(2))
+	assert.Equal(t, 0, rem.Sign())
+
+	sum, scale, err := b.AddScaled(1, a, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "12.80", sum.ToString(scale))
+	diff, scale, err := b.SubScaled(1, a, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "-12.20", diff.ToString(scale))
+
+	_, _, err = decimal256.MaxValue().AddScaled(0, decimal256.FromI64(1), 0)
+	assert.EqualError(t, err, "decimal256: "+decimal256.MaxValue().ToString(0)+" + 1 overflows")
+	_, _, err = decimal256.MinValue().SubScaled(0, decimal256.FromI64(1), 0)
+	assert.EqualError(t, err, "decimal256: "+decimal256.MinValue().ToString(0)+" - 1 overflows")
+}
+
+func TestNumString(t *testing.T) {
+	for _, tc := range []struct {
+		s     string
+		prec  int32
+		scale int32
+		want  string
+		err   string
+	}{
+		{s: "123.45", prec: 5, scale: 2, want: "123.45"},
+		{s: "-0.5", prec: 5, scale: 3, want: "-0.500"},
+		{s: "0.001", prec: 3, scale: 3, want: "0.001"},
+		{s: "1e3", prec: 10, scale: 0, want: "1000"},
+		{s: "120", prec: 3, scale: -1, want: "120"},
+		{s: "1.5", prec: 5, scale: 0, err: `decimal256: "1.5" has more than 0 fractional digits`},
+		{s: "1000", prec: 3, scale: 0, err: `decimal256: "1000" does not fit in precision 3`},
+		{s: "x", prec: 3, scale: 0, err: `decimal256: invalid number "x"`},
+		{s: "1/2", prec: 3, scale: 1, err: `decimal256: invalid number "1/2"`},
+		{s: "1", prec: 77, scale: 0, err: "decimal256: invalid precision 77"},
+	} {
+		t.Run(tc.s, func(t *testing.T) {
+			n, err := decimal256.FromString(tc.s, tc.prec, tc.scale)
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, n.ToString(tc.scale))
+			assert.True(t, n.FitsInPrecision(tc.prec))
+		})
+	}
+}
@@ -0,0 +1,440 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package expr provides expressions over the columns of records, such as the
+// predicates filtering the rows of a scan.
+//
+// Expressions are trees of field references, literals and calls to the
+// comparison and logical operators. They are evaluated with the kernels of
+// the compute package, and can be serialized to JSON.
+package expr
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/compute"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+)
+
+// Expr is an expression evaluated against the rows of a record.
+// Expressions are *FieldRef, *Literal or *Call values.
+type Expr interface {
+	fmt.Stringer
+	json.Marshaler
+
+	eval(mem memory.Allocator, rec array.Record) (array.Interface, error)
+}
+
+// Op is the operator of a call.
+type Op int
+
+const (
+	OpEqual Op = iota
+	OpNotEqual
+	OpLess
+	OpLessEqual
+	OpGreater
+	OpGreaterEqual
+	OpAnd
+	OpOr
+	OpNot
+)
+
+var opNames = [...]string{
+	OpEqual:        "equal",
+	OpNotEqual:     "not_equal",
+	OpLess:         "less",
+	OpLessEqual:    "less_equal",
+	OpGreater:      "greater",
+	OpGreaterEqual: "greater_equal",
+	OpAnd:          "and",
+	OpOr:           "or",
+	OpNot:          "not",
+}
+
+var opSymbols = [...]string{
+	OpEqual:        "==",
+	OpNotEqual:     "!=",
+	OpLess:         "<",
+	OpLessEqual:    "<=",
+	OpGreater:      ">",
+	OpGreaterEqual: ">=",
+	OpAnd:          "and",
+	OpOr:           "or",
+	OpNot:          "not",
+}
+
+func (op Op) String() string {
+	if op < 0 || int(op) >= len(opNames) {
+		return fmt.Sprintf("Op(%d)", int(op))
+	}
+	return opNames[op]
+}
+
+// FieldRef refers to the column of a record with a given name.
+type FieldRef struct {
+	Name string
+}
+
+// Literal is a constant value, of the same type for all the rows.
+type Literal struct {
+	Value scalar.Scalar
+}
+
+// Call applies an operator to its arguments.
+type Call struct {
+	Op   Op
+	Args []Expr
+}
+
+// Field returns the reference to the column named name.
+func Field(name string) *FieldRef { return &FieldRef{Name: name} }
+
+// Lit returns the literal of value v.
+func Lit(v scalar.Scalar) *Literal { return &Literal{Value: v} }
+
+// Equal returns the expression comparing a and b for equality.
+func Equal(a, b Expr) *Call { return &Call{Op: OpEqual, Args: []Expr{a, b}} }
+
+// NotEqual returns the expression comparing a and b for inequality.
+func NotEqual(a, b Expr) *Call { return &Call{Op: OpNotEqual, Args: []Expr{a, b}} }
+
+// Less returns the expression a < b.
+func Less(a, b Expr) *Call { return &Call{Op: OpLess, Args: []Expr{a, b}} }
+
+// LessEqual returns the expression a <= b.
+func LessEqual(a, b Expr) *Call { return &Call{Op: OpLessEqual, Args: []Expr{a, b}} }
+
+// Greater returns the expression a > b.
+func Greater(a, b Expr) *Call { return &Call{Op: OpGreater, Args: []Expr{a, b}} }
+
+// GreaterEqual returns the expression a >= b.
+func GreaterEqual(a, b Expr) *Call { return &Call{Op: OpGreaterEqual, Args: []Expr{a, b}} }
+
+// And returns the conjunction of args.
+func And(args ...Expr) *Call { return &Call{Op: OpAnd, Args: args} }
+
+// Or returns the disjunction of args.
+func Or(args ...Expr) *Call { return &Call{Op: OpOr, Args: args} }
+
+// Not returns the negation of a.
+func Not(a Expr) *Call { return &Call{Op: OpNot, Args: []Expr{a}} }
+
+func (f *FieldRef) String() string { return f.Name }
+func (l *Literal) String() string  { return l.Value.String() }
+
+func (c *Call) String() string {
+	if c.Op < 0 || int(c.Op) >= len(opSymbols) {
+		return fmt.Sprintf("%v(%d args)", c.Op, len(c.Args))
+	}
+	if c.Op == OpNot && len(c.Args) == 1 {
+		return "not " + c.Args[0].String()
+	}
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		args[i] = arg.String()
+	}
+	return "(" + strings.Join(args, " "+opSymbols[c.Op]+" ") + ")"
+}
+
+// Eval evaluates e against the rows of rec, and returns an array of
+// rec.NumRows() elements that must be released after use.
+//
+// Comparisons follow the compute kernels: their result is null if any of
+// their operands is null. A literal compared to a value of another type is
+// first cast to that type. And and Or use Kleene logic.
+func Eval(mem memory.Allocator, e Expr, rec array.Record) (array.Interface, error) {
+	return e.eval(mem, rec)
+}
+
+// Filter returns the rows of rec for which the predicate e is true.
+//
+// Filter returns an error if e does not evaluate to a boolean array.
+func Filter(mem memory.Allocator, rec array.Record, e Expr) (array.Record, error) {
+	mask, err := evalBool(mem, e, rec)
+	if err != nil {
+		return nil, err
+	}
+	defer mask.Release()
+	return compute.FilterRecord(mem, rec, mask)
+}
+
+// Fields returns the names of the fields referenced by e, in the order of
+// their first reference.
+func Fields(e Expr) []string {
+	var (
+		names []string
+		seen  = make(map[string]bool)
+		walk  func(e Expr)
+	)
+	walk = func(e Expr) {
+		switch e := e.(type) {
+		case *FieldRef:
+			if !seen[e.Name] {
+				seen[e.Name] = true
+				names = append(names, e.Name)
+			}
+		case *Call:
+			for _, arg := range e.Args {
+				walk(arg)
+			}
+		}
+	}
+	walk(e)
+	return names
+}
+
+func (f *FieldRef) eval(mem memory.Allocator, rec array.Record) (array.Interface, error) {
+	i := rec.Schema().FieldIndex(f.Name)
+	if i < 0 {
+		return nil, fmt.Errorf("arrow/expr: unknown field %q", f.Name)
+	}
+	col := rec.Column(i)
+	col.Retain()
+	return col, nil
+}
+
+func (l *Literal) eval(mem memory.Allocator, rec array.Record) (array.Interface, error) {
+	return broadcast(mem, l.Value, int(rec.NumRows()))
+}
+
+func (c *Call) eval(mem memory.Allocator, rec array.Record) (array.Interface, error) {
+	switch c.Op {
+	case OpEqual, OpNotEqual, OpLess, OpLessEqual, OpGreater, OpGreaterEqual:
+		if len(c.Args) != 2 {
+			return nil, fmt.Errorf("arrow/expr: %v with %d arguments, expected 2", c.Op, len(c.Args))
+		}
+		return c.compare(mem, rec)
+
+	case OpAnd, OpOr:
+		if len(c.Args) == 0 {
+			return nil, fmt.Errorf("arrow/expr: %v without arguments", c.Op)
+		}
+		kernel := compute.And
+		if c.Op == OpOr {
+			kernel = compute.Or
+		}
+		acc, err := evalBool(mem, c.Args[0], rec)
+		if err != nil {
+			return nil, err
+		}
+		for _, arg := range c.Args[1:] {
+			v, err := evalBool(mem, arg, rec)
+			if err != nil {
+				acc.Release()
+				return nil, err
+			}
+			res, err := kernel(mem, acc, v)
+			acc.Release()
+			v.Release()
+			if err != nil {
+				return nil, err
+			}
+			acc = res
+		}
+		return acc, nil
+
+	case OpNot:
+		if len(c.Args) != 1 {
+			return nil, fmt.Errorf("arrow/expr: not with %d arguments, expected 1", len(c.Args))
+		}
+		v, err := evalBool(mem, c.Args[0], rec)
+		if err != nil {
+			return nil, err
+		}
+		defer v.Release()
+		return compute.Not(mem, v)
+	}
+	return nil, fmt.Errorf("arrow/expr: invalid operator %v", c.Op)
+}
+
+func (c *Call) compare(mem memory.Allocator, rec array.Record) (array.Interface, error) {
+	a, err := c.Args[0].eval(mem, rec)
+	if err != nil {
+		return nil, err
+	}
+	defer a.Release()
+	b, err := c.Args[1].eval(mem, rec)
+	if err != nil {
+		return nil, err
+	}
+	defer b.Release()
+
+	if !reflect.DeepEqual(a.DataType(), b.DataType()) {
+		// cast the literal operand, if any, to the type of the other one.
+		_, alit := c.Args[0].(*Literal)
+		_, blit := c.Args[1].(*Literal)
+		switch {
+		case blit:
+			b, err = compute.Cast(mem, b, a.DataType())
+			if err != nil {
+				return nil, fmt.Errorf("arrow/expr: %v: %w", c, err)
+			}
+			defer b.Release()
+		case alit:
+			a, err = compute.Cast(mem, a, b.DataType())
+			if err != nil {
+				return nil, fmt.Errorf("arrow/expr: %v: %w", c, err)
+			}
+			defer a.Release()
+		}
+	}
+
+	var kernel func(mem memory.Allocator, a, b array.Interface) (*array.Boolean, error)
+	switch c.Op {
+	case OpEqual:
+		kernel = compute.Equal
+	case OpNotEqual:
+		kernel = compute.NotEqual
+	case OpLess:
+		kernel = compute.Less
+	case OpLessEqual:
+		kernel = compute.LessEqual
+	case OpGreater:
+		kernel = compute.Greater
+	case OpGreaterEqual:
+		kernel = compute.GreaterEqual
+	}
+	res, err := kernel(mem, a, b)
+	if err != nil {
+		return nil, fmt.Errorf("arrow/expr: %v: %w", c, err)
+	}
+	return res, nil
+}
+
+// evalBool evaluates e, which must have a boolean result.
+func evalBool(mem memory.Allocator, e Expr, rec array.Record) (*array.Boolean, error) {
+	v, err := e.eval(mem, rec)
+	if err != nil {
+		return nil, err
+	}
+	res, ok := v.(*array.Boolean)
+	if !ok {
+		v.Release()
+		return nil, fmt.Errorf("arrow/expr: %v of type %s, expected bool", e, v.DataType().Name())
+	}
+	return res, nil
+}
+
+// broadcast returns the array of n elements equal to s.
+func broadcast(mem memory.Allocator, s scalar.Scalar, n int) (array.Interface, error) {
+	if !supported(s.DataType()) {
+		return nil, fmt.Errorf("arrow/expr: unsupported literal of type %s", s.DataType().Name())
+	}
+
+	bld := array.NewBuilder(mem, s.DataType())
+	defer bld.Release()
+	if !s.IsValid() {
+		bld.AppendNulls(n)
+		return bld.NewArray(), nil
+	}
+
+	var app func()
+	switch s := s.(type) {
+	case *scalar.Boolean:
+		app = func() { bld.(*array.BooleanBuilder).Append(s.Value) }
+	case *scalar.Int8:
+		app = func() { bld.(*array.Int8Builder).Append(s.Value) }
+	case *scalar.Int16:
+		app = func() { bld.(*array.Int16Builder).Append(s.Value) }
+	case *scalar.Int32:
+		app = func() { bld.(*array.Int32Builder).Append(s.Value) }
+	case *scalar.Int64:
+		app = func() { bld.(*array.Int64Builder).Append(s.Value) }
+	case *scalar.Uint8:
+		app = func() { bld.(*array.Uint8Builder).Append(s.Value) }
+	case *scalar.Uint16:
+		app = func() { bld.(*array.Uint16Builder).Append(s.Value) }
+	case *scalar.Uint32:
+		app = func() { bld.(*array.Uint32Builder).Append(s.Value) }
+	case *scalar.Uint64:
+		app = func() { bld.(*array.Uint64Builder).Append(s.Value) }
+	case *scalar.Float32:
+		app = func() { bld.(*array.Float32Builder).Append(s.Value) }
+	case *scalar.Float64:
+		app = func() { bld.(*array.Float64Builder).Append(s.Value) }
+	case *scalar.String:
+		app = func() { bld.(*array.StringBuilder).Append(s.Value) }
+	case *scalar.LargeString:
+		app = func() { bld.(*array.LargeStringBuilder).Append(s.Value) }
+	case *scalar.Binary:
+		app = func() { bld.(*array.BinaryBuilder).Append(s.Value) }
+	case *scalar.LargeBinary:
+		app = func() { bld.(*array.LargeBinaryBuilder).Append(s.Value) }
+	case *scalar.Date32:
+		app = func() { bld.(*array.Date32Builder).Append(s.Value) }
+	case *scalar.Date64:
+		app = func() { bld.(*array.Date64Builder).Append(s.Value) }
+	case *scalar.Timestamp:
+		app = func() { bld.(*array.TimestampBuilder).Append(s.Value) }
+	}
+
+	bld.Reserve(n)
+	for i := 0; i < n; i++ {
+		app()
+	}
+	return bld.NewArray(), nil
+}
+
+// supported returns whether literals of type dt are supported.
+func supported(dt arrow.DataType) bool {
+	if dt.ID() == arrow.TIMESTAMP {
+		return true
+	}
+	lt, ok := literalTypes[dt.Name()]
+	return ok && lt.ID() == dt.ID()
+}
+
+// literalTypes are the types of the supported literals, except timestamps,
+// by name.
+var literalTypes = map[string]arrow.DataType{}
+
+func init() {
+	for _, dt := range []arrow.DataType{
+		arrow.Null,
+		arrow.FixedWidthTypes.Boolean,
+		arrow.PrimitiveTypes.Int8,
+		arrow.PrimitiveTypes.Int16,
+		arrow.PrimitiveTypes.Int32,
+		arrow.PrimitiveTypes.Int64,
+		arrow.PrimitiveTypes.Uint8,
+		arrow.PrimitiveTypes.Uint16,
+		arrow.PrimitiveTypes.Uint32,
+		arrow.PrimitiveTypes.Uint64,
+		arrow.PrimitiveTypes.Float32,
+		arrow.PrimitiveTypes.Float64,
+		arrow.PrimitiveTypes.Date32,
+		arrow.PrimitiveTypes.Date64,
+		arrow.BinaryTypes.String,
+		arrow.BinaryTypes.LargeString,
+		arrow.BinaryTypes.Binary,
+		arrow.BinaryTypes.LargeBinary,
+	} {
+		literalTypes[dt.Name()] = dt
+	}
+}
+
+var (
+	_ Expr = (*FieldRef)(nil)
+	_ Expr = (*Literal)(nil)
+	_ Expr = (*Call)(nil)
+)
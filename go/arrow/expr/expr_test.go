@@ -0,0 +1,248 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expr_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/expr"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+)
+
+func makeRecord(mem memory.Allocator) array.Record {
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "i32", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+			{Name: "str", Type: arrow.BinaryTypes.String},
+			{Name: "f64", Type: arrow.PrimitiveTypes.Float64},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2, 3, 4, 5}, []bool{true, true, false, true, true})
+	b.Field(1).(*array.StringBuilder).AppendValues([]string{"a", "b", "a", "c", "a"}, nil)
+	b.Field(2).(*array.Float64Builder).AppendValues([]float64{0.5, 1.5, 2.5, 3.5, 4.5}, nil)
+	return b.NewRecord()
+}
+
+func TestEval(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rec := makeRecord(mem)
+	defer rec.Release()
+
+	for _, tc := range []struct {
+		expr expr.Expr
+		str  string
+		want string
+	}{
+		{
+			expr: expr.Field("i32"),
+			str:  "i32",
+			want: "[1 2 (null) 4 5]",
+		},
+		{
+			expr: expr.Lit(scalar.NewStringScalar("x")),
+			str:  `"x"`,
+			want: `["x" "x" "x" "x" "x"]`,
+		},
+		{
+			expr: expr.Greater(expr.Field("i32"), expr.Lit(scalar.NewInt64Scalar(2))),
+			str:  "(i32 > 2)",
+			want: "[false false (null) true true]",
+		},
+		{
+			expr: expr.LessEqual(expr.Lit(scalar.NewFloat64Scalar(2.5)), expr.Field("f64")),
+			str:  "(2.5 <= f64)",
+			want: "[false false true true true]",
+		},
+		{
+			expr: expr.And(
+				expr.Equal(expr.Field("str"), expr.Lit(scalar.NewStringScalar("a"))),
+				expr.GreaterEqual(expr.Field("i32"), expr.Lit(scalar.NewInt32Scalar(2))),
+			),
+			str:  `((str == "a") and (i32 >= 2))`,
+			want: "[false false (null) false true]",
+		},
+		{
+			expr: expr.Or(
+				expr.NotEqual(expr.Field("str"), expr.Lit(scalar.NewStringScalar("a"))),
+				expr.Less(expr.Field("i32"), expr.Lit(scalar.NewInt32Scalar(2))),
+				expr.Equal(expr.Field("f64"), expr.Lit(scalar.NewFloat64Scalar(2.5))),
+			),
+			str:  `((str != "a") or (i32 < 2) or (f64 == 2.5))`,
+			want: "[true true true true false]",
+		},
+		{
+			expr: expr.Not(expr.Equal(expr.Field("i32"), expr.Lit(scalar.MakeNullScalar(arrow.PrimitiveTypes.Int32)))),
+			str:  "not (i32 == (null))",
+			want: "[(null) (null) (null) (null) (null)]",
+		},
+	} {
+		t.Run(tc.str, func(t *testing.T) {
+			if got := tc.expr.String(); got != tc.str {
+				t.Fatalf("invalid string:\ngot= %s\nwant=%s", got, tc.str)
+			}
+
+			got, err := expr.Eval(mem, tc.expr, rec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+
+			if got := fmt.Sprint(got); got != tc.want {
+				t.Fatalf("invalid result:\ngot= %s\nwant=%s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFilter(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rec := makeRecord(mem)
+	defer rec.Release()
+
+	pred := expr.And(
+		expr.Equal(expr.Field("str"), expr.Lit(scalar.NewStringScalar("a"))),
+		expr.Not(expr.Less(expr.Field("f64"), expr.Lit(scalar.NewInt64Scalar(2)))),
+	)
+	if got, want := expr.Fields(pred), []string{"str", "f64"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid fields: got=%q, want=%q", got, want)
+	}
+
+	got, err := expr.Filter(mem, rec, pred)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if got, want := got.NumRows(), int64(2); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+	for i, want := range []string{"[(null) 5]", `["a" "a"]`, "[2.5 4.5]"} {
+		if got := fmt.Sprint(got.Column(i)); got != want {
+			t.Fatalf("invalid column %d: got=%s, want=%s", i, got, want)
+		}
+	}
+}
+
+func TestEvalErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rec := makeRecord(mem)
+	defer rec.Release()
+
+	for _, tc := range []struct {
+		expr expr.Expr
+		err  string
+	}{
+		{
+			expr: expr.Field("missing"),
+			err:  `arrow/expr: unknown field "missing"`,
+		},
+		{
+			expr: expr.Not(expr.Field("i32")),
+			err:  "arrow/expr: i32 of type int32, expected bool",
+		},
+		{
+			expr: expr.Equal(expr.Field("i32"), expr.Field("f64")),
+			err:  "arrow/expr: (i32 == f64): arrow/compute: mismatched data types int32 and float64",
+		},
+		{
+			expr: expr.And(),
+			err:  "arrow/expr: and without arguments",
+		},
+		{
+			expr: &expr.Call{Op: expr.OpLess, Args: []expr.Expr{expr.Field("i32")}},
+			err:  "arrow/expr: less with 1 arguments, expected 2",
+		},
+	} {
+		t.Run(tc.err, func(t *testing.T) {
+			_, err := expr.Eval(mem, tc.expr, rec)
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("invalid error:\ngot= %v\nwant=%s", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestJSON(t *testing.T) {
+	ts := &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "UTC"}
+	e := expr.Or(
+		expr.And(
+			expr.Equal(expr.Field("a"), expr.Lit(scalar.NewInt8Scalar(-3))),
+			expr.Greater(expr.Field("b"), expr.Lit(scalar.NewTimestampScalar(1600000000000, ts))),
+		),
+		expr.Not(expr.Equal(expr.Field("c"), expr.Lit(scalar.NewBinaryScalar([]byte("xyz"))))),
+		expr.NotEqual(expr.Field("d"), expr.Lit(scalar.MakeNullScalar(arrow.BinaryTypes.String))),
+		expr.Less(expr.Field("e"), expr.Lit(scalar.NewUint64Scalar(18446744073709551615))),
+		expr.Less(expr.Field("f"), expr.Lit(scalar.NewFloat32Scalar(1.5))),
+	)
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `{"call":"or","args":[` +
+		`{"call":"and","args":[` +
+		`{"call":"equal","args":[{"field":"a"},{"literal":-3,"type":"int8"}]},` +
+		`{"call":"greater","args":[{"field":"b"},{"literal":1600000000000,"type":"timestamp","unit":"ms","timezone":"UTC"}]}]},` +
+		`{"call":"not","args":[{"call":"equal","args":[{"field":"c"},{"literal":"eHl6","type":"binary"}]}]},` +
+		`{"call":"not_equal","args":[{"field":"d"},{"literal":null,"type":"utf8"}]},` +
+		`{"call":"less","args":[{"field":"e"},{"literal":18446744073709551615,"type":"uint64"}]},` +
+		`{"call":"less","args":[{"field":"f"},{"literal":1.5,"type":"float32"}]}]}`
+	if got := string(data); got != want {
+		t.Fatalf("invalid JSON:\ngot= %s\nwant=%s", got, want)
+	}
+
+	got, err := expr.Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, e) {
+		t.Fatalf("invalid round-trip:\ngot= %v\nwant=%v", got, e)
+	}
+
+	for _, tc := range []struct {
+		data string
+		err  string
+	}{
+		{`{"call":"xor","args":[]}`, `arrow/expr: unknown operator "xor"`},
+		{`{"literal":1,"type":"list"}`, "arrow/expr: unsupported literal of type list"},
+		{`{"literal":300,"type":"uint8"}`, "arrow/expr: could not decode literal of type uint8: value 300 overflows uint8"},
+		{`{"literal":1,"type":"timestamp","unit":"h"}`, `arrow/expr: invalid timestamp unit "h"`},
+		{`{}`, "arrow/expr: invalid expression {}"},
+	} {
+		_, err := expr.Unmarshal([]byte(tc.data))
+		if err == nil || err.Error() != tc.err {
+			t.Fatalf("invalid error for %s:\ngot= %v\nwant=%s", tc.data, err, tc.err)
+		}
+	}
+}
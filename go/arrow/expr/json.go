@@ -0,0 +1,273 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expr
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/scalar"
+)
+
+// Expressions are serialized as JSON objects of one of the forms:
+//
+//	{"field": "name"}
+//	{"literal": 42, "type": "int64"}
+//	{"literal": 1600000000, "type": "timestamp", "unit": "s", "timezone": "UTC"}
+//	{"call": "equal", "args": [...]}
+//
+// Literals are null, or booleans, numbers, strings or, for binary types,
+// base64 strings. Dates and timestamps are numbers in units of their type.
+type jsonExpr struct {
+	Field    *string         `json:"field,omitempty"`
+	Literal  json.RawMessage `json:"literal,omitempty"`
+	Type     string          `json:"type,omitempty"`
+	Unit     string          `json:"unit,omitempty"`
+	TimeZone string          `json:"timezone,omitempty"`
+	Call     string          `json:"call,omitempty"`
+	Args     []Expr          `json:"args,omitempty"`
+}
+
+func (f *FieldRef) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonExpr{Field: &f.Name})
+}
+
+func (l *Literal) MarshalJSON() ([]byte, error) {
+	dt := l.Value.DataType()
+	if !supported(dt) {
+		return nil, fmt.Errorf("arrow/expr: unsupported literal of type %s", dt.Name())
+	}
+
+	var v interface{}
+	if l.Value.IsValid() {
+		switch s := l.Value.(type) {
+		case *scalar.Boolean:
+			v = s.Value
+		case *scalar.Int8:
+			v = s.Value
+		case *scalar.Int16:
+			v = s.Value
+		case *scalar.Int32:
+			v = s.Value
+		case *scalar.Int64:
+			v = s.Value
+		case *scalar.Uint8:
+			v = s.Value
+		case *scalar.Uint16:
+			v = s.Value
+		case *scalar.Uint32:
+			v = s.Value
+		case *scalar.Uint64:
+			v = s.Value
+		case *scalar.Float32:
+			v = s.Value
+		case *scalar.Float64:
+			v = s.Value
+		case *scalar.String:
+			v = s.Value
+		case *scalar.LargeString:
+			v = s.Value
+		case *scalar.Binary:
+			v = s.Value
+		case *scalar.LargeBinary:
+			v = s.Value
+		case *scalar.Date32:
+			v = s.Value
+		case *scalar.Date64:
+			v = s.Value
+		case *scalar.Timestamp:
+			v = s.Value
+		}
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("arrow/expr: could not encode literal %v: %w", l.Value, err)
+	}
+
+	out := jsonExpr{Literal: raw, Type: dt.Name()}
+	if dt, ok := dt.(*arrow.TimestampType); ok {
+		out.Unit = dt.Unit.String()
+		out.TimeZone = dt.TimeZone
+	}
+	return json.Marshal(out)
+}
+
+func (c *Call) MarshalJSON() ([]byte, error) {
+	if c.Op < 0 || int(c.Op) >= len(opNames) {
+		return nil, fmt.Errorf("arrow/expr: invalid operator %v", c.Op)
+	}
+	args := c.Args
+	if args == nil {
+		args = []Expr{}
+	}
+	return json.Marshal(jsonExpr{Call: c.Op.String(), Args: args})
+}
+
+// Unmarshal returns the expression serialized in data.
+func Unmarshal(data []byte) (Expr, error) {
+	var raw struct {
+		jsonExpr
+		Args []json.RawMessage `json:"args,omitempty"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("arrow/expr: could not decode expression: %w", err)
+	}
+
+	switch {
+	case raw.Field != nil:
+		return Field(*raw.Field), nil
+
+	case raw.Type != "":
+		dt, err := literalType(raw.Type, raw.Unit, raw.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+		v, err := literalValue(dt, raw.Literal)
+		if err != nil {
+			return nil, fmt.Errorf("arrow/expr: could not decode literal of type %s: %w", raw.Type, err)
+		}
+		return Lit(v), nil
+
+	case raw.Call != "":
+		op := Op(-1)
+		for i, name := range opNames {
+			if name == raw.Call {
+				op = Op(i)
+				break
+			}
+		}
+		if op < 0 {
+			return nil, fmt.Errorf("arrow/expr: unknown operator %q", raw.Call)
+		}
+		args := make([]Expr, len(raw.Args))
+		for i, arg := range raw.Args {
+			e, err := Unmarshal(arg)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = e
+		}
+		return &Call{Op: op, Args: args}, nil
+	}
+	return nil, fmt.Errorf("arrow/expr: invalid expression %s", data)
+}
+
+func literalType(name, unit, tz string) (arrow.DataType, error) {
+	if name == "timestamp" {
+		for u := arrow.Nanosecond; u <= arrow.Second; u++ {
+			if u.String() == unit {
+				return &arrow.TimestampType{Unit: u, TimeZone: tz}, nil
+			}
+		}
+		return nil, fmt.Errorf("arrow/expr: invalid timestamp unit %q", unit)
+	}
+	dt, ok := literalTypes[name]
+	if !ok {
+		return nil, fmt.Errorf("arrow/expr: unsupported literal of type %s", name)
+	}
+	return dt, nil
+}
+
+func literalValue(dt arrow.DataType, raw json.RawMessage) (scalar.Scalar, error) {
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return scalar.MakeNullScalar(dt), nil
+	}
+
+	var (
+		i   int64
+		u   uint64
+		f   float64
+		err error
+	)
+	switch dt.ID() {
+	case arrow.NULL:
+		return nil, fmt.Errorf("invalid value %s", raw)
+	case arrow.BOOL:
+		var v bool
+		err = json.Unmarshal(raw, &v)
+		return scalar.NewBooleanScalar(v), err
+	case arrow.STRING, arrow.LARGE_STRING:
+		var v string
+		if err = json.Unmarshal(raw, &v); err != nil {
+			return nil, err
+		}
+		if dt.ID() == arrow.LARGE_STRING {
+			return scalar.NewLargeStringScalar(v), nil
+		}
+		return scalar.NewStringScalar(v), nil
+	case arrow.BINARY, arrow.LARGE_BINARY:
+		var v []byte
+		if err = json.Unmarshal(raw, &v); err != nil {
+			return nil, err
+		}
+		if dt.ID() == arrow.LARGE_BINARY {
+			return scalar.NewLargeBinaryScalar(v), nil
+		}
+		return scalar.NewBinaryScalar(v), nil
+	case arrow.FLOAT32, arrow.FLOAT64:
+		if err = json.Unmarshal(raw, &f); err != nil {
+			return nil, err
+		}
+	case arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		if err = json.Unmarshal(raw, &u); err != nil {
+			return nil, err
+		}
+	default:
+		if err = json.Unmarshal(raw, &i); err != nil {
+			return nil, err
+		}
+	}
+
+	overflow := func(ok bool) error {
+		if !ok {
+			return fmt.Errorf("value %s overflows %s", raw, dt.Name())
+		}
+		return nil
+	}
+	switch dt.ID() {
+	case arrow.FLOAT32:
+		return scalar.NewFloat32Scalar(float32(f)), overflow(math.Abs(f) <= math.MaxFloat32)
+	case arrow.FLOAT64:
+		return scalar.NewFloat64Scalar(f), nil
+	case arrow.UINT8:
+		return scalar.NewUint8Scalar(uint8(u)), overflow(u <= math.MaxUint8)
+	case arrow.UINT16:
+		return scalar.NewUint16Scalar(uint16(u)), overflow(u <= math.MaxUint16)
+	case arrow.UINT32:
+		return scalar.NewUint32Scalar(uint32(u)), overflow(u <= math.MaxUint32)
+	case arrow.UINT64:
+		return scalar.NewUint64Scalar(u), nil
+	case arrow.INT8:
+		return scalar.NewInt8Scalar(int8(i)), overflow(math.MinInt8 <= i && i <= math.MaxInt8)
+	case arrow.INT16:
+		return scalar.NewInt16Scalar(int16(i)), overflow(math.MinInt16 <= i && i <= math.MaxInt16)
+	case arrow.INT32:
+		return scalar.NewInt32Scalar(int32(i)), overflow(math.MinInt32 <= i && i <= math.MaxInt32)
+	case arrow.INT64:
+		return scalar.NewInt64Scalar(i), nil
+	case arrow.DATE32:
+		return scalar.NewDate32Scalar(arrow.Date32(i)), overflow(math.MinInt32 <= i && i <= math.MaxInt32)
+	case arrow.DATE64:
+		return scalar.NewDate64Scalar(arrow.Date64(i)), nil
+	case arrow.TIMESTAMP:
+		return scalar.NewTimestampScalar(arrow.Timestamp(i), dt.(*arrow.TimestampType)), nil
+	}
+	return nil, fmt.Errorf("unsupported data type %s", dt.Name())
+}
@@ -0,0 +1,124 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fixedwidth writes records as fixed-width text, each column of a
+// row taking the same number of characters on every line.
+package fixedwidth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrMismatchFields = errors.New("arrow/fixedwidth: schema mismatch")
+	ErrTooWide        = errors.New("arrow/fixedwidth: value wider than its column")
+)
+
+// FieldError describes a value that could not be written.
+type FieldError struct {
+	Row    int    // index of the row among the rows written, or -1 for the header
+	Column int    // index of the column
+	Field  string // name of the field
+	Err    error
+}
+
+func (e *FieldError) Error() string {
+	msg := strings.TrimPrefix(e.Err.Error(), "arrow/fixedwidth: ")
+	if e.Row < 0 {
+		return fmt.Sprintf("arrow/fixedwidth: header, column %d (%s): %s", e.Column, e.Field, msg)
+	}
+	return fmt.Sprintf("arrow/fixedwidth: row %d, column %d (%s): %s", e.Row, e.Column, e.Field, msg)
+}
+
+func (e *FieldError) Unwrap() error { return e.Err }
+
+// Align specifies how the values are placed within their column.
+type Align int
+
+const (
+	// AlignDefault right-aligns numbers and left-aligns the other values.
+	AlignDefault Align = iota
+	AlignLeft
+	AlignRight
+)
+
+// Column describes the layout of a column of a fixed-width file.
+type Column struct {
+	Width int   // width of the column, in characters
+	Align Align // alignment of the values within the column
+}
+
+// Option configures a fixed-width writer.
+type Option func(config)
+type config interface{}
+
+// WithHeader specifies whether to write a header line, holding the names
+// of the fields, before the first row.
+// The default value is false.
+func WithHeader(header bool) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			cfg.header = header
+		default:
+			panic(fmt.Errorf("arrow/fixedwidth: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithNullValue specifies the text written for null values.
+// The default value is the empty string, which leaves the column blank.
+func WithNullValue(null string) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			cfg.null = null
+		default:
+			panic(fmt.Errorf("arrow/fixedwidth: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithTruncate specifies whether values wider than their column are cut to
+// its width, instead of failing the write with a FieldError wrapping
+// ErrTooWide. Right-aligned values lose their first characters, other
+// values their last ones.
+// The default value is false.
+func WithTruncate(truncate bool) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			cfg.truncate = truncate
+		default:
+			panic(fmt.Errorf("arrow/fixedwidth: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithCRLF specifies whether lines end with \r\n instead of \n.
+// The default value is false.
+func WithCRLF(useCRLF bool) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			cfg.crlf = useCRLF
+		default:
+			panic(fmt.Errorf("arrow/fixedwidth: unknown config type %T", cfg))
+		}
+	}
+}
@@ -0,0 +1,274 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fixedwidth
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+)
+
+// formatFunc returns the text of the i-th value of arr.
+type formatFunc func(arr array.Interface, i int) string
+
+// Writer writes array.Records as fixed-width text, one line per row, based
+// on a schema and the layout of its columns.
+type Writer struct {
+	out     *bufio.Writer
+	schema  *arrow.Schema
+	layout  []Column
+	formats []formatFunc
+	right   []bool // whether each column is right-aligned
+
+	header   bool
+	null     string
+	truncate bool
+	crlf     bool
+
+	rows int // rows written, for the errors
+	line []byte
+}
+
+// NewWriter returns a writer of the records with the given schema to w,
+// with one column of layout for each field of the schema.
+//
+// NewWriter panics if the layout does not match the schema, if a width is
+// not positive, or if the schema contains fields of unsupported types:
+// booleans, numbers, decimals, strings, dates, times, timestamps and
+// durations are supported.
+func NewWriter(w io.Writer, schema *arrow.Schema, layout []Column, opts ...Option) *Writer {
+	if len(layout) != len(schema.Fields()) {
+		panic(fmt.Errorf("arrow/fixedwidth: layout of %d columns for %d fields", len(layout), len(schema.Fields())))
+	}
+
+	ww := &Writer{
+		out:     bufio.NewWriter(w),
+		schema:  schema,
+		layout:  append([]Column(nil), layout...),
+		formats: make([]formatFunc, len(layout)),
+		right:   make([]bool, len(layout)),
+	}
+	for i, f := range schema.Fields() {
+		col := layout[i]
+		if col.Width <= 0 {
+			panic(fmt.Errorf("arrow/fixedwidth: field %d (%s) has invalid width %d", i, f.Name, col.Width))
+		}
+		if dt, ok := f.Type.(*arrow.TimestampType); ok {
+			if _, err := dt.Location(); err != nil {
+				panic(fmt.Errorf("arrow/fixedwidth: field %d (%s): %w", i, f.Name, err))
+			}
+		}
+		format, numeric := formatFuncOf(f.Type)
+		if format == nil {
+			panic(fmt.Errorf("arrow/fixedwidth: field %d (%s) has invalid data type %T", i, f.Name, f.Type))
+		}
+		ww.formats[i] = format
+		ww.right[i] = col.Align == AlignRight || col.Align == AlignDefault && numeric
+	}
+	for _, opt := range opts {
+		opt(ww)
+	}
+
+	return ww
+}
+
+func (w *Writer) Schema() *arrow.Schema { return w.schema }
+
+// Write writes the rows of record, starting with the header line if it is
+// the first record written and the writer was configured WithHeader.
+func (w *Writer) Write(record array.Record) error {
+	if !record.Schema().Equal(w.schema) {
+		return ErrMismatchFields
+	}
+
+	if w.header {
+		w.header = false
+		w.line = w.line[:0]
+		for j, f := range w.schema.Fields() {
+			var err error
+			if w.line, err = w.appendField(w.line, j, f.Name); err != nil {
+				return &FieldError{Row: -1, Column: j, Field: f.Name, Err: err}
+			}
+		}
+		w.writeLine()
+	}
+
+	cols := record.Columns()
+	for i := 0; i < int(record.NumRows()); i++ {
+		w.line = w.line[:0]
+		for j, col := range cols {
+			v := w.null
+			if col.IsValid(i) {
+				v = w.formats[j](col, i)
+			}
+			var err error
+			if w.line, err = w.appendField(w.line, j, v); err != nil {
+				return &FieldError{Row: w.rows + i, Column: j, Field: w.schema.Field(j).Name, Err: err}
+			}
+		}
+		w.writeLine()
+	}
+	w.rows += int(record.NumRows())
+	return w.out.Flush()
+}
+
+// appendField appends v, padded to the width of the column j.
+func (w *Writer) appendField(line []byte, j int, v string) ([]byte, error) {
+	if strings.ContainsAny(v, "\r\n") {
+		return nil, fmt.Errorf("arrow/fixedwidth: value %q holds a line break", v)
+	}
+
+	width := w.layout[j].Width
+	n := utf8.RuneCountInString(v)
+	if n > width {
+		if !w.truncate {
+			return nil, fmt.Errorf("%w: %q is longer than %d characters", ErrTooWide, v, width)
+		}
+		if w.right[j] {
+			for ; n > width; n-- {
+				_, size := utf8.DecodeRuneInString(v)
+				v = v[size:]
+			}
+		} else {
+			for ; n > width; n-- {
+				_, size := utf8.DecodeLastRuneInString(v)
+				v = v[:len(v)-size]
+			}
+		}
+	}
+
+	pad := width - n
+	if w.right[j] {
+		line = appendSpaces(line, pad)
+		return append(line, v...), nil
+	}
+	line = append(line, v...)
+	return appendSpaces(line, pad), nil
+}
+
+func (w *Writer) writeLine() {
+	w.out.Write(w.line)
+	if w.crlf {
+		w.out.WriteString("\r\n")
+	} else {
+		w.out.WriteByte('\n')
+	}
+}
+
+func appendSpaces(line []byte, n int) []byte {
+	for ; n > 0; n-- {
+		line = append(line, ' ')
+	}
+	return line
+}
+
+// formatFuncOf returns the function formatting the values of type dt and
+// whether they are numbers, or nil if dt is not supported.
+func formatFuncOf(dt arrow.DataType) (formatFunc, bool) {
+	switch dt := dt.(type) {
+	case *arrow.BooleanType:
+		return func(arr array.Interface, i int) string { return strconv.FormatBool(arr.(*array.Boolean).Value(i)) }, false
+	case *arrow.Int8Type:
+		return func(arr array.Interface, i int) string {
+			return strconv.FormatInt(int64(arr.(*array.Int8).Value(i)), 10)
+		}, true
+	case *arrow.Int16Type:
+		return func(arr array.Interface, i int) string {
+			return strconv.FormatInt(int64(arr.(*array.Int16).Value(i)), 10)
+		}, true
+	case *arrow.Int32Type:
+		return func(arr array.Interface, i int) string {
+			return strconv.FormatInt(int64(arr.(*array.Int32).Value(i)), 10)
+		}, true
+	case *arrow.Int64Type:
+		return func(arr array.Interface, i int) string { return strconv.FormatInt(arr.(*array.Int64).Value(i), 10) }, true
+	case *arrow.Uint8Type:
+		return func(arr array.Interface, i int) string {
+			return strconv.FormatUint(uint64(arr.(*array.Uint8).Value(i)), 10)
+		}, true
+	case *arrow.Uint16Type:
+		return func(arr array.Interface, i int) string {
+			return strconv.FormatUint(uint64(arr.(*array.Uint16).Value(i)), 10)
+		}, true
+	case *arrow.Uint32Type:
+		return func(arr array.Interface, i int) string {
+			return strconv.FormatUint(uint64(arr.(*array.Uint32).Value(i)), 10)
+		}, true
+	case *arrow.Uint64Type:
+		return func(arr array.Interface, i int) string { return strconv.FormatUint(arr.(*array.Uint64).Value(i), 10) }, true
+	case *arrow.Float16Type:
+		return func(arr array.Interface, i int) string { return arr.(*array.Float16).Value(i).String() }, true
+	case *arrow.Float32Type:
+		return func(arr array.Interface, i int) string {
+			return strconv.FormatFloat(float64(arr.(*array.Float32).Value(i)), 'g', -1, 32)
+		}, true
+	case *arrow.Float64Type:
+		return func(arr array.Interface, i int) string {
+			return strconv.FormatFloat(arr.(*array.Float64).Value(i), 'g', -1, 64)
+		}, true
+	case *arrow.Decimal256Type:
+		return func(arr array.Interface, i int) string { return arr.(*array.Decimal256).ValueStr(i) }, true
+	case *arrow.StringType:
+		return func(arr array.Interface, i int) string { return arr.(*array.String).Value(i) }, false
+	case *arrow.LargeStringType:
+		return func(arr array.Interface, i int) string { return arr.(*array.LargeString).Value(i) }, false
+	case *arrow.Date32Type:
+		return func(arr array.Interface, i int) string {
+			return time.Unix(int64(arr.(*array.Date32).Value(i))*24*3600, 0).UTC().Format("2006-01-02")
+		}, false
+	case *arrow.Date64Type:
+		return func(arr array.Interface, i int) string {
+			return time.UnixMilli(int64(arr.(*array.Date64).Value(i))).UTC().Format("2006-01-02")
+		}, false
+	case *arrow.TimestampType:
+		layout := "2006-01-02T15:04:05" + fraction(dt.Unit)
+		if dt.TimeZone != "" {
+			layout += "Z07:00"
+		}
+		return func(arr array.Interface, i int) string { return arr.(*array.Timestamp).Time(i).Format(layout) }, false
+	case *arrow.Time32Type:
+		return timeFormat(dt.Unit, func(arr array.Interface, i int) int64 { return int64(arr.(*array.Time32).Value(i)) }), false
+	case *arrow.Time64Type:
+		return timeFormat(dt.Unit, func(arr array.Interface, i int) int64 { return int64(arr.(*array.Time64).Value(i)) }), false
+	case *arrow.DurationType:
+		return func(arr array.Interface, i int) string {
+			return strconv.FormatInt(int64(arr.(*array.Duration).Value(i)), 10)
+		}, true
+	}
+	return nil, false
+}
+
+// fraction returns the layout of the fractional seconds of unit, so that
+// all the values of a column have the same width.
+func fraction(unit arrow.TimeUnit) string {
+	return [...]string{".000000000", ".000000", ".000", ""}[unit&3]
+}
+
+func timeFormat(unit arrow.TimeUnit, value func(arr array.Interface, i int) int64) formatFunc {
+	layout := "15:04:05" + fraction(unit)
+	return func(arr array.Interface, i int) string {
+		d := time.Duration(value(arr, i)) * unit.Multiplier()
+		return time.Unix(0, int64(d)).UTC().Format(layout)
+	}
+}
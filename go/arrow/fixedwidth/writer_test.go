@@ -0,0 +1,144 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fixedwidth_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/fixedwidth"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func newRecord(t *testing.T, mem memory.Allocator) array.Record {
+	t.Helper()
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "price", Type: arrow.PrimitiveTypes.Float64},
+		{Name: "day", Type: arrow.PrimitiveTypes.Date32},
+		{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Millisecond}},
+		{Name: "ok", Type: arrow.FixedWidthTypes.Boolean},
+	}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 12345}, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues([]string{"héllo", ""}, []bool{true, false})
+	b.Field(2).(*array.Float64Builder).AppendValues([]float64{1.5, -20}, nil)
+	b.Field(3).(*array.Date32Builder).AppendValues([]arrow.Date32{0, 19723}, nil)
+	b.Field(4).(*array.TimestampBuilder).AppendValues([]arrow.Timestamp{1500, 1704067200000}, nil)
+	b.Field(5).(*array.BooleanBuilder).AppendValues([]bool{true, false}, nil)
+	return b.NewRecord()
+}
+
+var layout = []fixedwidth.Column{
+	{Width: 6},
+	{Width: 8},
+	{Width: 7},
+	{Width: 11},
+	{Width: 24, Align: fixedwidth.AlignRight},
+	{Width: 5, Align: fixedwidth.AlignRight},
+}
+
+func TestWriter(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rec := newRecord(t, mem)
+	defer rec.Release()
+
+	var out bytes.Buffer
+	w := fixedwidth.NewWriter(&out, rec.Schema(), layout, fixedwidth.WithHeader(true), fixedwidth.WithNullValue("-"))
+	for i := 0; i < 2; i++ {
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := `    idname      priceday                              ts   ok
+     1héllo       1.51970-01-01  1970-01-01T00:00:01.500 true
+ 12345-           -202024-01-01  2024-01-01T00:00:00.000false
+     1héllo       1.51970-01-01  1970-01-01T00:00:01.500 true
+ 12345-           -202024-01-01  2024-01-01T00:00:00.000false
+`
+	if got := out.String(); got != want {
+		t.Fatalf("invalid output:\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriterTooWide(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	rec := newRecord(t, mem)
+	defer rec.Release()
+
+	narrow := append([]fixedwidth.Column(nil), layout...)
+	narrow[0].Width = 3
+	narrow[1] = fixedwidth.Column{Width: 3, Align: fixedwidth.AlignLeft}
+
+	err := fixedwidth.NewWriter(new(bytes.Buffer), rec.Schema(), narrow).Write(rec)
+	var ferr *fixedwidth.FieldError
+	if !errors.As(err, &ferr) || !errors.Is(err, fixedwidth.ErrTooWide) {
+		t.Fatalf("invalid error: %v", err)
+	}
+	if ferr.Row != 0 || ferr.Column != 1 {
+		t.Fatalf("invalid error row and column: %d, %d", ferr.Row, ferr.Column)
+	}
+	if got, want := err.Error(), `arrow/fixedwidth: row 0, column 1 (name): value wider than its column: "héllo" is longer than 3 characters`; got != want {
+		t.Fatalf("invalid error message:\ngot= %s\nwant=%s", got, want)
+	}
+
+	var out bytes.Buffer
+	w := fixedwidth.NewWriter(&out, rec.Schema(), narrow, fixedwidth.WithTruncate(true), fixedwidth.WithCRLF(true))
+	if err := w.Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(out.String(), "\r\n")
+	if got, want := lines[1][:6], "345   "; got != want {
+		t.Fatalf("invalid truncated values: got=%q, want=%q", got, want)
+	}
+	if got, want := lines[0][:7], "  1hél"; got != want {
+		t.Fatalf("invalid truncated values: got=%q, want=%q", got, want)
+	}
+}
+
+func TestWriterInvalidLayout(t *testing.T) {
+	schema := arrow.NewSchema([]arrow.Field{{Name: "l", Type: arrow.ListOf(arrow.PrimitiveTypes.Int64)}}, nil)
+	for _, tc := range []struct {
+		name   string
+		layout []fixedwidth.Column
+	}{
+		{"missing column", nil},
+		{"zero width", []fixedwidth.Column{{Width: 0}}},
+		{"unsupported type", []fixedwidth.Column{{Width: 4}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("expected a panic")
+				}
+			}()
+			fixedwidth.NewWriter(new(bytes.Buffer), schema, tc.layout)
+		})
+	}
+}
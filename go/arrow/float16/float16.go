@@ -0,0 +1,134 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package float16 provides the IEEE 754 half-precision floating point type,
+// used to store the values of the Arrow float16 type.
+package float16
+
+import (
+	"math"
+	"strconv"
+)
+
+// Num is an IEEE 754 half-precision floating point number: 1 sign bit,
+// 5 exponent bits and 10 mantissa bits.
+type Num struct {
+	bits uint16
+}
+
+// New returns the half-precision number nearest to f, rounding ties to even.
+// Values too large for a Num become infinities, and NaN stays NaN.
+func New(f float32) Num {
+	b := math.Float32bits(f)
+	sign := uint16(b>>16) & 0x8000
+	exp := int32(b>>23) & 0xff
+	mant := b & 0x7fffff
+
+	if exp == 0xff {
+		if mant != 0 {
+			return Num{bits: sign | 0x7e00}
+		}
+		return Num{bits: sign | 0x7c00}
+	}
+
+	e := exp - 127 + 15
+	switch {
+	case e >= 0x1f:
+		return Num{bits: sign | 0x7c00}
+	case e <= 0:
+		// subnormal: the value is h*2⁻²⁴, with the implicit bit of f.
+		if e < -10 {
+			return Num{bits: sign}
+		}
+		return Num{bits: sign | uint16(roundShift(mant|0x800000, uint32(14-e)))}
+	}
+	// a carry out of the mantissa correctly bumps the exponent, up to
+	// infinity.
+	return Num{bits: sign | uint16(uint32(e)<<10+roundShift(mant, 13))}
+}
+
+// roundShift returns v>>s, rounded to nearest, ties to even.
+func roundShift(v, s uint32) uint32 {
+	h := v >> s
+	rem := v & (1<<s - 1)
+	half := uint32(1) << (s - 1)
+	if rem > half || (rem == half && h&1 == 1) {
+		h++
+	}
+	return h
+}
+
+// FromBits returns the Num with the IEEE 754 binary representation b.
+func FromBits(b uint16) Num { return Num{bits: b} }
+
+// Float32 returns the value of f as a float32. The conversion is exact.
+func (f Num) Float32() float32 {
+	sign := uint32(f.bits&0x8000) << 16
+	exp := uint32(f.bits>>10) & 0x1f
+	mant := uint32(f.bits & 0x3ff)
+
+	switch exp {
+	case 0x1f:
+		return math.Float32frombits(sign | 0x7f800000 | mant<<13)
+	case 0:
+		if mant == 0 {
+			return math.Float32frombits(sign)
+		}
+		// subnormal: normalize the mantissa.
+		e := uint32(127 - 15 + 1)
+		for mant&0x400 == 0 {
+			mant <<= 1
+			e--
+		}
+		return math.Float32frombits(sign | e<<23 | (mant&0x3ff)<<13)
+	}
+	return math.Float32frombits(sign | (exp+127-15)<<23 | mant<<13)
+}
+
+// Uint16 returns the IEEE 754 binary representation of f.
+func (f Num) Uint16() uint16 { return f.bits }
+
+// IsNaN reports whether f is a NaN value.
+func (f Num) IsNaN() bool { return f.bits&0x7c00 == 0x7c00 && f.bits&0x3ff != 0 }
+
+// IsInf reports whether f is an infinity, according to sign:
+// if sign > 0, whether f is positive infinity; if sign < 0, whether f is
+// negative infinity; if sign == 0, whether f is either infinity.
+func (f Num) IsInf(sign int) bool {
+	switch {
+	case sign > 0:
+		return f.bits == 0x7c00
+	case sign < 0:
+		return f.bits == 0xfc00
+	}
+	return f.bits&0x7fff == 0x7c00
+}
+
+// String returns the shortest decimal representation that reads back as f.
+func (f Num) String() string {
+	if f.IsNaN() {
+		return "NaN"
+	}
+	v := float64(f.Float32())
+	for prec := 1; prec < 5; prec++ {
+		p, err := strconv.ParseFloat(strconv.FormatFloat(v, 'g', prec, 32), 32)
+		if err == nil && New(float32(p)) == f {
+			return strconv.FormatFloat(p, 'g', -1, 32)
+		}
+	}
+	// 5 significant digits always identify a half-precision number.
+	return strconv.FormatFloat(v, 'g', 5, 32)
+}
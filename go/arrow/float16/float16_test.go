@@ -0,0 +1,107 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package float16_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/float16"
+)
+
+func TestNew(t *testing.T) {
+	for _, tc := range []struct {
+		f    float32
+		bits uint16
+	}{
+		{0, 0x0000},
+		{float32(math.Copysign(0, -1)), 0x8000},
+		{1, 0x3c00},
+		{-2, 0xc000},
+		{0.5, 0x3800},
+		{65504, 0x7bff},         // largest normal
+		{65520, 0x7c00},         // rounds up to infinity
+		{1e6, 0x7c00},           // overflow
+		{6.1035156e-05, 0x0400}, // smallest normal
+		{5.9604645e-08, 0x0001}, // smallest subnormal
+		{2.9802322e-08, 0x0000}, // half the smallest subnormal, ties to even
+		{4.4703484e-08, 0x0001}, // above half the smallest subnormal
+		{1e-9, 0x0000},          // underflow
+		{1.0009766, 0x3c01},
+		{1.0004883, 0x3c00}, // tie, rounds to even
+		{1.0014648, 0x3c02}, // tie, rounds to even
+		{float32(math.Inf(+1)), 0x7c00},
+		{float32(math.Inf(-1)), 0xfc00},
+	} {
+		if got := float16.New(tc.f).Uint16(); got != tc.bits {
+			t.Errorf("New(%v): got=%#04x, want=%#04x", tc.f, got, tc.bits)
+		}
+	}
+
+	if nan := float16.New(float32(math.NaN())); !nan.IsNaN() {
+		t.Errorf("New(NaN): got=%#04x, want a NaN", nan.Uint16())
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	// every half-precision number converts exactly to float32 and back.
+	for b := 0; b <= math.MaxUint16; b++ {
+		f := float16.FromBits(uint16(b))
+		if f.IsNaN() {
+			if !float16.New(f.Float32()).IsNaN() {
+				t.Fatalf("%#04x: NaN did not round trip", b)
+			}
+			continue
+		}
+		if got := float16.New(f.Float32()); got != f {
+			t.Fatalf("%#04x: got=%#04x after round trip through %v", b, got.Uint16(), f.Float32())
+		}
+	}
+}
+
+func TestIsInf(t *testing.T) {
+	pos, neg := float16.FromBits(0x7c00), float16.FromBits(0xfc00)
+	if !pos.IsInf(1) || pos.IsInf(-1) || !pos.IsInf(0) {
+		t.Errorf("invalid IsInf for +Inf")
+	}
+	if neg.IsInf(1) || !neg.IsInf(-1) || !neg.IsInf(0) {
+		t.Errorf("invalid IsInf for -Inf")
+	}
+	if one := float16.New(1); one.IsInf(0) || one.IsNaN() {
+		t.Errorf("invalid IsInf/IsNaN for 1")
+	}
+}
+
+func TestString(t *testing.T) {
+	for _, tc := range []struct {
+		f    float16.Num
+		want string
+	}{
+		{float16.New(0.1), "0.1"},
+		{float16.New(1.5), "1.5"},
+		{float16.New(-3), "-3"},
+		{float16.New(65504), "65500"},
+		{float16.New(1.0009766), "1.001"},
+		{float16.FromBits(0x0001), "6e-08"},
+		{float16.FromBits(0x7c00), "+Inf"},
+		{float16.FromBits(0x7e00), "NaN"},
+	} {
+		if got := tc.f.String(); got != tc.want {
+			t.Errorf("%#04x: got=%q, want=%q", tc.f.Uint16(), got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,283 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitutil
+
+import (
+	"encoding/binary"
+)
+
+// BitmapReader reads the bits of a bitmap one at a time, starting at an
+// arbitrary bit offset.
+type BitmapReader struct {
+	bitmap []byte
+	pos    int // position of the current bit, relative to the start of the reader
+	len    int
+
+	cur    byte // byte holding the current bit
+	byteOf int  // index in bitmap of the next byte to load
+	bitOf  int  // index of the current bit in cur
+}
+
+// NewBitmapReader returns a reader over the length bits of bitmap starting
+// at bit offset.
+func NewBitmapReader(bitmap []byte, offset, length int) *BitmapReader {
+	r := &BitmapReader{bitmap: bitmap, len: length, byteOf: offset / 8, bitOf: offset % 8}
+	if length > 0 {
+		r.cur = bitmap[r.byteOf]
+	}
+	return r
+}
+
+// Set returns whether the current bit is set.
+func (r *BitmapReader) Set() bool { return r.cur&BitMask[r.bitOf] != 0 }
+
+// NotSet returns whether the current bit is not set.
+func (r *BitmapReader) NotSet() bool { return r.cur&BitMask[r.bitOf] == 0 }
+
+// Next moves the reader to the next bit.
+func (r *BitmapReader) Next() {
+	r.bitOf++
+	r.pos++
+	if r.bitOf == 8 {
+		r.bitOf = 0
+		r.byteOf++
+		if r.pos < r.len {
+			r.cur = r.bitmap[r.byteOf]
+		}
+	}
+}
+
+// Pos returns the position of the current bit, relative to the offset of the reader.
+func (r *BitmapReader) Pos() int { return r.pos }
+
+// Len returns the number of bits of the reader.
+func (r *BitmapReader) Len() int { return r.len }
+
+// BitmapWriter writes the bits of a bitmap one at a time, starting at an
+// arbitrary bit offset. The bits are buffered a byte at a time, so Finish
+// must be called once all the bits have been written.
+type BitmapWriter struct {
+	bitmap []byte
+	pos    int
+	len    int
+
+	cur    byte
+	mask   byte
+	byteOf int
+}
+
+// NewBitmapWriter returns a writer of the length bits of bitmap starting
+// at bit offset. Bits outside of that range are left untouched.
+func NewBitmapWriter(bitmap []byte, offset, length int) *BitmapWriter {
+	w := &BitmapWriter{bitmap: bitmap, len: length, byteOf: offset / 8, mask: BitMask[offset%8]}
+	if length > 0 {
+		w.cur = bitmap[w.byteOf]
+	}
+	return w
+}
+
+// Set sets the current bit.
+func (w *BitmapWriter) Set() { w.cur |= w.mask }
+
+// Clear clears the current bit.
+func (w *BitmapWriter) Clear() { w.cur &^= w.mask }
+
+// Next moves the writer to the next bit.
+func (w *BitmapWriter) Next() {
+	w.mask <<= 1
+	w.pos++
+	if w.mask == 0 {
+		w.mask = 1
+		w.bitmap[w.byteOf] = w.cur
+		w.byteOf++
+		if w.pos < w.len {
+			w.cur = w.bitmap[w.byteOf]
+		}
+	}
+}
+
+// Pos returns the position of the current bit, relative to the offset of the writer.
+func (w *BitmapWriter) Pos() int { return w.pos }
+
+// Finish flushes the last, partially written, byte to the bitmap.
+func (w *BitmapWriter) Finish() {
+	if w.len > 0 && (w.mask != 1 || w.pos < w.len) {
+		w.bitmap[w.byteOf] = w.cur
+	}
+}
+
+// BitmapWordReader reads a bitmap 64 bits at a time, starting at an
+// arbitrary bit offset. The Words full words are read with NextWord and
+// the remaining bits with NextTrailingByte.
+type BitmapWordReader struct {
+	bitmap   []byte // bitmap, starting at the byte holding the first bit
+	shift    uint   // offset of the first bit in bitmap[0]
+	pos      int    // index of the next byte to read
+	words    int
+	nbytes   int // number of trailing bytes
+	trailing int // number of trailing bits left to read
+}
+
+// NewBitmapWordReader returns a word reader over the length bits of bitmap
+// starting at bit offset.
+func NewBitmapWordReader(bitmap []byte, offset, length int) *BitmapWordReader {
+	return &BitmapWordReader{
+		bitmap:   bitmap[offset/8:],
+		shift:    uint(offset % 8),
+		words:    length / uint64SizeBits,
+		nbytes:   (length%uint64SizeBits + 7) / 8,
+		trailing: length % uint64SizeBits,
+	}
+}
+
+// Words returns the number of full words of the reader.
+func (r *BitmapWordReader) Words() int { return r.words }
+
+// TrailingBytes returns the number of bytes, possibly partial, holding the
+// bits after the full words.
+func (r *BitmapWordReader) TrailingBytes() int { return r.nbytes }
+
+// NextWord returns the next 64 bits of the bitmap.
+func (r *BitmapWordReader) NextWord() uint64 {
+	w := binary.LittleEndian.Uint64(r.bitmap[r.pos:])
+	if r.shift != 0 {
+		w = w>>r.shift | uint64(r.bitmap[r.pos+8])<<(64-r.shift)
+	}
+	r.pos += 8
+	return w
+}
+
+// NextTrailingByte returns the next trailing bits, as a byte, and the
+// number of valid bits in that byte.
+func (r *BitmapWordReader) NextTrailingByte() (b byte, validBits int) {
+	validBits = r.trailing
+	if validBits > 8 {
+		validBits = 8
+	}
+	r.trailing -= validBits
+
+	b = r.bitmap[r.pos] >> r.shift
+	if r.shift != 0 && int(8-r.shift) < validBits {
+		b |= r.bitmap[r.pos+1] << (8 - r.shift)
+	}
+	r.pos++
+	return b & byte(1<<uint(validBits)-1), validBits
+}
+
+// BitmapWordWriter writes a bitmap 64 bits at a time, starting at an
+// arbitrary bit offset. Bits outside of the written range are left untouched.
+type BitmapWordWriter struct {
+	bitmap []byte
+	shift  uint
+	pos    int // index of the next byte to write
+	bit    int // index of the next bit to write by PutNextTrailingByte
+}
+
+// NewBitmapWordWriter returns a word writer of the bits of bitmap starting
+// at bit offset.
+func NewBitmapWordWriter(bitmap []byte, offset int) *BitmapWordWriter {
+	return &BitmapWordWriter{bitmap: bitmap[offset/8:], shift: uint(offset % 8), bit: int(offset % 8)}
+}
+
+// PutNextWord writes the next 64 bits of the bitmap.
+func (w *BitmapWordWriter) PutNextWord(word uint64) {
+	buf := w.bitmap[w.pos:]
+	if w.shift == 0 {
+		binary.LittleEndian.PutUint64(buf, word)
+	} else {
+		low := byte(1)<<w.shift - 1
+		first := buf[0] & low
+		binary.LittleEndian.PutUint64(buf, word<<w.shift)
+		buf[0] |= first
+		buf[8] = buf[8]&^low | byte(word>>(64-w.shift))
+	}
+	w.pos += 8
+	w.bit += uint64SizeBits
+}
+
+// PutNextTrailingByte writes the validBits low bits of b.
+func (w *BitmapWordWriter) PutNextTrailingByte(b byte, validBits int) {
+	for i := 0; i < validBits; i++ {
+		SetBitTo(w.bitmap, w.bit, b&BitMask[i] != 0)
+		w.bit++
+	}
+	w.pos++
+}
+
+// BitmapAnd writes the bitwise AND of the length bits of left and right,
+// starting at their respective bit offsets, to out starting at outOffset.
+func BitmapAnd(left []byte, lOffset int, right []byte, rOffset int, out []byte, outOffset, length int) {
+	bitmapOp(left, lOffset, right, rOffset, out, outOffset, length, func(a, b uint64) uint64 { return a & b })
+}
+
+// BitmapOr writes the bitwise OR of the length bits of left and right,
+// starting at their respective bit offsets, to out starting at outOffset.
+func BitmapOr(left []byte, lOffset int, right []byte, rOffset int, out []byte, outOffset, length int) {
+	bitmapOp(left, lOffset, right, rOffset, out, outOffset, length, func(a, b uint64) uint64 { return a | b })
+}
+
+// BitmapXor writes the bitwise XOR of the length bits of left and right,
+// starting at their respective bit offsets, to out starting at outOffset.
+func BitmapXor(left []byte, lOffset int, right []byte, rOffset int, out []byte, outOffset, length int) {
+	bitmapOp(left, lOffset, right, rOffset, out, outOffset, length, func(a, b uint64) uint64 { return a ^ b })
+}
+
+// BitmapAndNot writes the bitwise AND NOT of the length bits of left and right,
+// starting at their respective bit offsets, to out starting at outOffset.
+func BitmapAndNot(left []byte, lOffset int, right []byte, rOffset int, out []byte, outOffset, length int) {
+	bitmapOp(left, lOffset, right, rOffset, out, outOffset, length, func(a, b uint64) uint64 { return a &^ b })
+}
+
+func bitmapOp(left []byte, lOffset int, right []byte, rOffset int, out []byte, outOffset, length int, op func(a, b uint64) uint64) {
+	if length <= 0 {
+		return
+	}
+	if lOffset%8 == 0 && rOffset%8 == 0 && outOffset%8 == 0 {
+		alignedBitmapOp(left[lOffset/8:], right[rOffset/8:], out[outOffset/8:], length, op)
+		return
+	}
+
+	lr := NewBitmapWordReader(left, lOffset, length)
+	rr := NewBitmapWordReader(right, rOffset, length)
+	w := NewBitmapWordWriter(out, outOffset)
+	for i := 0; i < lr.Words(); i++ {
+		w.PutNextWord(op(lr.NextWord(), rr.NextWord()))
+	}
+	for i := 0; i < lr.TrailingBytes(); i++ {
+		l, n := lr.NextTrailingByte()
+		r, _ := rr.NextTrailingByte()
+		w.PutNextTrailingByte(byte(op(uint64(l), uint64(r))), n)
+	}
+}
+
+// alignedBitmapOp applies op to bitmaps starting on byte boundaries.
+func alignedBitmapOp(left, right, out []byte, length int, op func(a, b uint64) uint64) {
+	nbytes := length / 8
+	i := 0
+	for ; i+uint64SizeBytes <= nbytes; i += uint64SizeBytes {
+		v := op(binary.LittleEndian.Uint64(left[i:]), binary.LittleEndian.Uint64(right[i:]))
+		binary.LittleEndian.PutUint64(out[i:], v)
+	}
+	for ; i < nbytes; i++ {
+		out[i] = byte(op(uint64(left[i]), uint64(right[i])))
+	}
+	if tail := uint(length % 8); tail != 0 {
+		mask := byte(1)<<tail - 1
+		v := byte(op(uint64(left[i]), uint64(right[i])))
+		out[i] = out[i]&^mask | v&mask
+	}
+}
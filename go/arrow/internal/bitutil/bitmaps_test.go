@@ -0,0 +1,193 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bitutil_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/internal/bitutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func randomBitmap(r *rand.Rand, nbits int) []byte {
+	buf := make([]byte, bitutil.CeilByte(nbits)/8)
+	r.Read(buf)
+	return buf
+}
+
+func TestBitmapReaderWriter(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	src := randomBitmap(r, 300)
+
+	for _, offset := range []int{0, 3, 8, 13} {
+		for _, length := range []int{0, 1, 7, 8, 9, 64, 100, 200} {
+			dst := randomBitmap(r, 300)
+			orig := append([]byte(nil), dst...)
+
+			br := bitutil.NewBitmapReader(src, offset, length)
+			bw := bitutil.NewBitmapWriter(dst, offset+1, length)
+			for i := 0; i < length; i++ {
+				assert.Equal(t, i, br.Pos())
+				if br.Set() {
+					bw.Set()
+				} else {
+					bw.Clear()
+				}
+				br.Next()
+				bw.Next()
+			}
+			bw.Finish()
+
+			for i := 0; i < 300; i++ {
+				want := bitutil.BitIsSet(orig, i)
+				if i > offset && i <= offset+length {
+					want = bitutil.BitIsSet(src, i-1)
+				}
+				if got := bitutil.BitIsSet(dst, i); got != want {
+					t.Fatalf("offset=%d, length=%d: invalid bit %d: got=%v, want=%v", offset, length, i, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestBitmapWordReaderWriter(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	src := randomBitmap(r, 1024)
+
+	for _, roff := range []int{0, 5, 64} {
+		for _, woff := range []int{0, 3, 8} {
+			for _, length := range []int{0, 5, 63, 64, 65, 130, 700} {
+				dst := randomBitmap(r, 1024)
+				orig := append([]byte(nil), dst...)
+
+				wr := bitutil.NewBitmapWordReader(src, roff, length)
+				ww := bitutil.NewBitmapWordWriter(dst, woff)
+				for i := 0; i < wr.Words(); i++ {
+					ww.PutNextWord(wr.NextWord())
+				}
+				for i := 0; i < wr.TrailingBytes(); i++ {
+					ww.PutNextTrailingByte(wr.NextTrailingByte())
+				}
+
+				for i := 0; i < 1024; i++ {
+					want := bitutil.BitIsSet(orig, i)
+					if i >= woff && i < woff+length {
+						want = bitutil.BitIsSet(src, i-woff+roff)
+					}
+					if got := bitutil.BitIsSet(dst, i); got != want {
+						t.Fatalf("roff=%d, woff=%d, length=%d: invalid bit %d: got=%v, want=%v", roff, woff, length, i, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestBitmapOps(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	left, right := randomBitmap(r, 1024), randomBitmap(r, 1024)
+
+	for _, tc := range []struct {
+		name string
+		fn   func(left []byte, lOffset int, right []byte, rOffset int, out []byte, outOffset, length int)
+		op   func(a, b bool) bool
+	}{
+		{"and", bitutil.BitmapAnd, func(a, b bool) bool { return a && b }},
+		{"or", bitutil.BitmapOr, func(a, b bool) bool { return a || b }},
+		{"xor", bitutil.BitmapXor, func(a, b bool) bool { return a != b }},
+		{"and-not", bitutil.BitmapAndNot, func(a, b bool) bool { return a && !b }},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, offsets := range [][3]int{{0, 0, 0}, {8, 16, 64}, {1, 0, 0}, {0, 7, 3}, {5, 9, 13}} {
+				for _, length := range []int{1, 7, 8, 63, 64, 100, 500} {
+					out := randomBitmap(r, 1024)
+					orig := append([]byte(nil), out...)
+					lo, ro, oo := offsets[0], offsets[1], offsets[2]
+					tc.fn(left, lo, right, ro, out, oo, length)
+
+					for i := 0; i < 1024; i++ {
+						want := bitutil.BitIsSet(orig, i)
+						if i >= oo && i < oo+length {
+							want = tc.op(bitutil.BitIsSet(left, i-oo+lo), bitutil.BitIsSet(right, i-oo+ro))
+						}
+						if got := bitutil.BitIsSet(out, i); got != want {
+							t.Fatalf("offsets=%v, length=%d: invalid bit %d: got=%v, want=%v", offsets, length, i, got, want)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCountSetBitsRanges(t *testing.T) {
+	r := rand.New(rand.NewSource(0))
+	buf := randomBitmap(r, 1024)
+
+	for offset := 0; offset < 70; offset += 3 {
+		for _, n := range []int{0, 1, 9, 63, 64, 65, 200, 700} {
+			want := 0
+			for i := offset; i < offset+n; i++ {
+				if bitutil.BitIsSet(buf, i) {
+					want++
+				}
+			}
+			assert.Equal(t, want, bitutil.CountSetBits(buf, offset, n), "offset=%d, n=%d", offset, n)
+		}
+	}
+}
+
+func benchmarkBitmapAnd(b *testing.B, offset int) {
+	const n = 1 << 16
+	r := rand.New(rand.NewSource(0))
+	left, right, out := randomBitmap(r, n+64), randomBitmap(r, n+64), make([]byte, n/8+8)
+	b.SetBytes(n / 8)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		bitutil.BitmapAnd(left, offset, right, 0, out, 0, n)
+	}
+}
+
+func BenchmarkBitmapAnd_Aligned(b *testing.B)   { benchmarkBitmapAnd(b, 0) }
+func BenchmarkBitmapAnd_Unaligned(b *testing.B) { benchmarkBitmapAnd(b, 3) }
+
+func TestSetBitsTo(t *testing.T) {
+	for _, val := range []bool{true, false} {
+		for offset := 0; offset < 20; offset++ {
+			for _, n := range []int{0, 1, 5, 8, 9, 30} {
+				buf := make([]byte, 8)
+				if !val {
+					for i := range buf {
+						buf[i] = 0xff
+					}
+				}
+				bitutil.SetBitsTo(buf, offset, n, val)
+				for i := 0; i < 64; i++ {
+					want := !val
+					if i >= offset && i < offset+n {
+						want = val
+					}
+					if got := bitutil.BitIsSet(buf, i); got != want {
+						t.Fatalf("val=%v, offset=%d, n=%d: invalid bit %d", val, offset, n, i)
+					}
+				}
+			}
+		}
+	}
+}
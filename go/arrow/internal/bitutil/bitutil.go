@@ -54,6 +54,31 @@ func SetBitTo(buf []byte, i int, val bool) {
 	}
 }
 
+// SetBitsTo sets the length bits of buf starting at offset to val.
+func SetBitsTo(buf []byte, offset, length int, val bool) {
+	if length <= 0 {
+		return
+	}
+	var fill byte
+	if val {
+		fill = 0xff
+	}
+
+	beg, end := offset, offset+length
+	// leading bits, up to the first byte boundary.
+	for ; beg < end && beg%8 != 0; beg++ {
+		SetBitTo(buf, beg, val)
+	}
+	// whole bytes.
+	for i := beg / 8; i < end/8; i++ {
+		buf[i] = fill
+	}
+	// trailing bits.
+	for i := max(beg, end&^7); i < end; i++ {
+		SetBitTo(buf, i, val)
+	}
+}
+
 // CountSetBits counts the number of 1's in buf up to n bits.
 func CountSetBits(buf []byte, offset, n int) int {
 	if offset > 0 {
@@ -106,11 +131,14 @@ func countSetBitsWithOffset(buf []byte, offset, n int) int {
 		}
 	}
 
-	// FIXME: use a fallback to bits.OnesCount8
-	// before counting the tail bits.
-
 	tail := beg + init + nU64*uint64SizeBits
-	for i := tail; i < end; i++ {
+	nU8 := (end - tail) / 8
+	begU8 = tail / 8
+	for _, v := range buf[begU8 : begU8+nU8] {
+		count += bits.OnesCount8(v)
+	}
+
+	for i := tail + nU8*8; i < end; i++ {
 		if BitIsSet(buf, i) {
 			count++
 		}
@@ -130,6 +158,13 @@ func min(a, b int) int {
 	return b
 }
 
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
 const (
 	uint64SizeBytes = int(unsafe.Sizeof(uint64(0)))
 	uint64SizeBits  = uint64SizeBytes * 8
@@ -18,6 +18,10 @@
 
 package debug
 
+// AssertEnabled reports whether the assert build tag is set. It guards
+// checks too costly to run when assertions are disabled.
+const AssertEnabled = false
+
 // Assert will panic with msg if cond is false.
 //
 // msg must be a string, func() string or fmt.Stringer.
@@ -0,0 +1,177 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package snappy implements the block format of the snappy compression
+// format, as used by columnar file formats.
+//
+// See https://github.com/google/snappy/blob/master/format_description.txt
+package snappy
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// ErrCorrupt is returned when decoding invalid snappy data.
+var ErrCorrupt = errors.New("snappy: corrupt input")
+
+// DecodedLen returns the length of the decoded block, as declared by its header.
+func DecodedLen(src []byte) (int, error) {
+	size, n := binary.Uvarint(src)
+	if n <= 0 || size > 1<<32 {
+		return 0, ErrCorrupt
+	}
+	return int(size), nil
+}
+
+// Decode decodes a block in the snappy format.
+func Decode(src []byte) ([]byte, error) {
+	size, n := binary.Uvarint(src)
+	if n <= 0 || size > 1<<32 {
+		return nil, ErrCorrupt
+	}
+	src = src[n:]
+	dst := make([]byte, 0, size)
+
+	for len(src) > 0 {
+		tag := src[0]
+		var length, offset int
+		switch tag & 0x03 {
+		case 0x00: // literal
+			length = int(tag>>2) + 1
+			src = src[1:]
+			if length > 60 {
+				nb := length - 60
+				if len(src) < nb {
+					return nil, ErrCorrupt
+				}
+				length = 0
+				for i := 0; i < nb; i++ {
+					length |= int(src[i]) << (8 * uint(i))
+				}
+				length++
+				src = src[nb:]
+			}
+			if length <= 0 || length > len(src) {
+				return nil, ErrCorrupt
+			}
+			dst = append(dst, src[:length]...)
+			src = src[length:]
+			continue
+		case 0x01: // copy with 1-byte offset
+			if len(src) < 2 {
+				return nil, ErrCorrupt
+			}
+			length = int(tag>>2&0x07) + 4
+			offset = int(tag>>5)<<8 | int(src[1])
+			src = src[2:]
+		case 0x02: // copy with 2-byte offset
+			if len(src) < 3 {
+				return nil, ErrCorrupt
+			}
+			length = int(tag>>2) + 1
+			offset = int(binary.LittleEndian.Uint16(src[1:]))
+			src = src[3:]
+		case 0x03: // copy with 4-byte offset
+			if len(src) < 5 {
+				return nil, ErrCorrupt
+			}
+			length = int(tag>>2) + 1
+			offset = int(binary.LittleEndian.Uint32(src[1:]))
+			src = src[5:]
+		}
+		if offset <= 0 || offset > len(dst) {
+			return nil, ErrCorrupt
+		}
+		// copies may overlap their output.
+		for i := 0; i < length; i++ {
+			dst = append(dst, dst[len(dst)-offset])
+		}
+	}
+
+	if uint64(len(dst)) != size {
+		return nil, ErrCorrupt
+	}
+	return dst, nil
+}
+
+// Encode encodes src as a block in the snappy format.
+// Matches are searched with a hash table of the previous 4-byte sequences.
+func Encode(src []byte) []byte {
+	const (
+		minMatch  = 4
+		maxOffset = 1<<16 - 1 // offsets of 2-byte copies
+		tableBits = 14
+	)
+
+	dst := binary.AppendUvarint(make([]byte, 0, len(src)+len(src)/6+binary.MaxVarintLen64), uint64(len(src)))
+
+	var table [1 << tableBits]int32 // positions of the sequences, plus one
+	lit := 0
+	for i := 0; i+minMatch <= len(src); {
+		u := binary.LittleEndian.Uint32(src[i:])
+		h := (u * 0x1e35a7bd) >> (32 - tableBits)
+		cand := int(table[h]) - 1
+		table[h] = int32(i + 1)
+		if cand < 0 || i-cand > maxOffset || binary.LittleEndian.Uint32(src[cand:]) != u {
+			i++
+			continue
+		}
+
+		n := minMatch
+		for i+n < len(src) && src[cand+n] == src[i+n] {
+			n++
+		}
+		dst = emitLiteral(dst, src[lit:i])
+		dst = emitCopy(dst, i-cand, n)
+		i += n
+		lit = i
+	}
+	return emitLiteral(dst, src[lit:])
+}
+
+func emitLiteral(dst, lit []byte) []byte {
+	for len(lit) > 0 {
+		n := len(lit)
+		if n > 1<<16 {
+			n = 1 << 16
+		}
+		switch {
+		case n <= 60:
+			dst = append(dst, byte(n-1)<<2)
+		case n <= 1<<8:
+			dst = append(dst, 60<<2, byte(n-1))
+		default:
+			dst = append(dst, 61<<2, byte(n-1), byte((n-1)>>8))
+		}
+		dst = append(dst, lit[:n]...)
+		lit = lit[n:]
+	}
+	return dst
+}
+
+// emitCopy appends copies with 2-byte offsets, of up to 64 bytes each.
+func emitCopy(dst []byte, offset, length int) []byte {
+	for length > 0 {
+		n := length
+		if n > 64 {
+			n = 64
+		}
+		dst = append(dst, byte(n-1)<<2|0x02, byte(offset), byte(offset>>8))
+		length -= n
+	}
+	return dst
+}
@@ -0,0 +1,44 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snappy
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	for _, src := range [][]byte{
+		nil,
+		[]byte("a"),
+		[]byte(strings.Repeat("abcd", 100)),
+		[]byte(strings.Repeat("parquet arrow ", 10000) + "end"),
+		bytes.Repeat([]byte{0}, 1<<17),
+	} {
+		buf := Encode(src)
+		got, err := Decode(buf)
+		assert.NoError(t, err)
+		assert.Equal(t, len(src), len(got))
+		assert.True(t, bytes.Equal(src, got))
+		if len(src) > 100 {
+			assert.True(t, len(buf) < len(src)/2, "%d bytes compressed to %d", len(src), len(buf))
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package json reads line-delimited JSON files and presents the extracted
+// data as records, also writes data as records into line-delimited JSON files.
+package json
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+const (
+	// defaultInferRows is the number of rows used to infer the schema
+	// when no schema is provided to NewReader.
+	defaultInferRows = 1000
+)
+
+// Option configures a JSON reader/writer.
+type Option func(config)
+type config interface{}
+
+// WithAllocator specifies the Arrow memory allocator used while building records.
+func WithAllocator(mem memory.Allocator) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.mem = mem
+		default:
+			panic(fmt.Errorf("arrow/json: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithChunk specifies the chunk size used while parsing JSON files.
+//
+// If n is zero or 1, no chunking will take place and the reader will create
+// one record per row.
+// If n is greater than 1, chunks of n rows will be read.
+// If n is negative, the reader will load the whole JSON file into memory and
+// create one big record with all the rows.
+func WithChunk(n int) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.chunk = n
+		default:
+			panic(fmt.Errorf("arrow/json: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithInferRows specifies the number of rows used to infer the schema when
+// no schema is provided to NewReader.
+// If n is negative, the whole JSON file is loaded into memory to infer the schema.
+func WithInferRows(n int) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.infer = n
+		default:
+			panic(fmt.Errorf("arrow/json: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithTimestampLayout specifies the layout, as defined by the time package,
+// used to format timestamps while writing JSON files.
+// The default layout is time.RFC3339Nano.
+func WithTimestampLayout(layout string) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			cfg.tsLayout = layout
+		default:
+			panic(fmt.Errorf("arrow/json: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithTemporalAsNumbers specifies whether dates, times and timestamps are
+// written as their underlying integer values instead of formatted strings.
+// The default value is false.
+func WithTemporalAsNumbers(v bool) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			cfg.rawTemporal = v
+		default:
+			panic(fmt.Errorf("arrow/json: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithOmitNulls specifies whether null values are omitted from the written
+// JSON objects instead of being written as JSON null values.
+// The default value is false.
+func WithOmitNulls(v bool) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			cfg.omitNulls = v
+		default:
+			panic(fmt.Errorf("arrow/json: unknown config type %T", cfg))
+		}
+	}
+}
+
+func validate(schema *arrow.Schema) {
+	for i, f := range schema.Fields() {
+		if !validType(f.Type) {
+			panic(fmt.Errorf("arrow/json: field %d (%s) has invalid data type %T", i, f.Name, f.Type))
+		}
+	}
+}
+
+func validType(dt arrow.DataType) bool {
+	switch dt := dt.(type) {
+	case *arrow.NullType, *arrow.BooleanType:
+	case *arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type, *arrow.Int64Type:
+	case *arrow.Uint8Type, *arrow.Uint16Type, *arrow.Uint32Type, *arrow.Uint64Type:
+	case *arrow.Float16Type, *arrow.Float32Type, *arrow.Float64Type:
+	case *arrow.StringType, *arrow.BinaryType, *arrow.FixedSizeBinaryType:
+	case *arrow.LargeStringType, *arrow.LargeBinaryType:
+	case *arrow.StringViewType, *arrow.BinaryViewType:
+	case *arrow.Date32Type, *arrow.Date64Type, *arrow.TimestampType:
+	case *arrow.Time32Type, *arrow.Time64Type, *arrow.DurationType:
+	case *arrow.MonthIntervalType, *arrow.DayTimeIntervalType, *arrow.MonthDayNanoIntervalType:
+	case *arrow.ListType:
+		return validType(dt.Elem())
+	case *arrow.LargeListType:
+		return validType(dt.Elem())
+	case *arrow.FixedSizeListType:
+		return validType(dt.Elem())
+	case *arrow.StructType:
+		for _, f := range dt.Fields() {
+			if !validType(f.Type) {
+				return false
+			}
+		}
+	default:
+		return false
+	}
+	return true
+}
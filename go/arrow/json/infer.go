@@ -0,0 +1,216 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/apache/arrow/go/arrow"
+)
+
+// InferSchema reads up to n rows of line-delimited JSON from r and returns
+// the schema describing them.
+// If n is negative, all the rows are read.
+//
+// JSON numbers are inferred as int64 unless a fractional part or an exponent
+// is seen, in which case float64 is used. Objects are inferred as structs,
+// arrays as lists and values that were only ever seen as null as the null type.
+// The order of the fields follows the order in which keys were first seen.
+func InferSchema(r io.Reader, n int) (*arrow.Schema, error) {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	var root inferNode
+	root.kind = inferStruct
+	for i := 0; n < 0 || i < n; i++ {
+		var raw json.RawMessage
+		err := dec.Decode(&raw)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := root.inferRow(raw); err != nil {
+			return nil, err
+		}
+	}
+	return root.schema(), nil
+}
+
+type inferKind int
+
+const (
+	inferNull inferKind = iota
+	inferBool
+	inferInt
+	inferFloat
+	inferString
+	inferList
+	inferStruct
+)
+
+func (k inferKind) String() string {
+	return [...]string{"null", "bool", "int", "float", "string", "list", "struct"}[k]
+}
+
+// inferNode accumulates the type information gathered for a JSON value.
+type inferNode struct {
+	kind   inferKind
+	elem   *inferNode            // element of a list
+	names  []string              // struct keys, in order of appearance
+	fields map[string]*inferNode // struct fields
+}
+
+// inferRow merges the type information of a single row into node.
+func (node *inferNode) inferRow(raw json.RawMessage) error {
+	dec := json.NewDecoder(strings.NewReader(string(raw)))
+	dec.UseNumber()
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if tok != json.Delim('{') {
+		return fmt.Errorf("arrow/json: invalid row %q: not a JSON object", raw)
+	}
+	return node.inferObject(dec, "")
+}
+
+func (node *inferNode) infer(dec *json.Decoder, name string) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch tok := tok.(type) {
+	case nil:
+		return nil
+	case bool:
+		return node.merge(inferBool, name)
+	case string:
+		return node.merge(inferString, name)
+	case json.Number:
+		if strings.ContainsAny(string(tok), ".eE") {
+			return node.merge(inferFloat, name)
+		}
+		return node.merge(inferInt, name)
+	case json.Delim:
+		switch tok {
+		case '[':
+			if err := node.merge(inferList, name); err != nil {
+				return err
+			}
+			if node.elem == nil {
+				node.elem = &inferNode{}
+			}
+			for dec.More() {
+				if err := node.elem.infer(dec, name); err != nil {
+					return err
+				}
+			}
+			_, err = dec.Token() // consume ']'
+			return err
+		case '{':
+			if err := node.merge(inferStruct, name); err != nil {
+				return err
+			}
+			return node.inferObject(dec, name)
+		}
+	}
+	return fmt.Errorf("arrow/json: unexpected token %v", tok)
+}
+
+// inferObject infers the fields of an object whose opening delimiter has
+// already been consumed.
+func (node *inferNode) inferObject(dec *json.Decoder, name string) error {
+	if node.fields == nil {
+		node.fields = make(map[string]*inferNode)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := tok.(string)
+		child, ok := node.fields[key]
+		if !ok {
+			child = &inferNode{}
+			node.fields[key] = child
+			node.names = append(node.names, key)
+		}
+		sub := key
+		if name != "" {
+			sub = name + "." + key
+		}
+		if err := child.infer(dec, sub); err != nil {
+			return err
+		}
+	}
+	_, err := dec.Token() // consume '}'
+	return err
+}
+
+func (node *inferNode) merge(kind inferKind, name string) error {
+	switch {
+	case node.kind == kind:
+	case node.kind == inferNull:
+		node.kind = kind
+	case node.kind == inferInt && kind == inferFloat:
+		node.kind = inferFloat
+	case node.kind == inferFloat && kind == inferInt:
+	default:
+		return fmt.Errorf("arrow/json: field %q has conflicting types %v and %v", name, node.kind, kind)
+	}
+	return nil
+}
+
+func (node *inferNode) dataType() arrow.DataType {
+	switch node.kind {
+	case inferBool:
+		return arrow.FixedWidthTypes.Boolean
+	case inferInt:
+		return arrow.PrimitiveTypes.Int64
+	case inferFloat:
+		return arrow.PrimitiveTypes.Float64
+	case inferString:
+		return arrow.BinaryTypes.String
+	case inferList:
+		return arrow.ListOf(node.elem.dataType())
+	case inferStruct:
+		return arrow.StructOf(node.fieldList()...)
+	default:
+		return arrow.Null
+	}
+}
+
+func (node *inferNode) fieldList() []arrow.Field {
+	fields := make([]arrow.Field, len(node.names))
+	for i, name := range node.names {
+		fields[i] = arrow.Field{Name: name, Type: node.fields[name].dataType(), Nullable: true}
+	}
+	return fields
+}
+
+func (node *inferNode) schema() *arrow.Schema {
+	return arrow.NewSchema(node.fieldList(), nil)
+}
@@ -0,0 +1,584 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// Reader decodes line-delimited JSON and creates array.Records from a schema.
+//
+// Each row must be a JSON object. Keys of the object are matched against the
+// names of the schema fields; missing keys and JSON null values are appended
+// as nulls and unknown keys are ignored.
+type Reader struct {
+	dec    *json.Decoder
+	schema *arrow.Schema
+
+	refs int64
+	bld  *array.RecordBuilder
+	cur  array.Record
+	err  error
+
+	chunk int
+	infer int
+	done  bool
+	next  func() bool
+
+	// rows decoded while inferring the schema, not yet appended to a record.
+	pending []map[string]interface{}
+
+	mem memory.Allocator
+}
+
+// NewReader returns a reader that reads from the line-delimited JSON file and
+// creates array.Records from the given schema.
+//
+// If schema is nil, the schema is inferred from the first rows of the file.
+// (see WithInferRows and InferSchema.)
+//
+// NewReader panics if the given schema contains fields that have types that are
+// not supported.
+func NewReader(r io.Reader, schema *arrow.Schema, opts ...Option) *Reader {
+	if schema != nil {
+		validate(schema)
+	}
+
+	rr := &Reader{
+		dec:    json.NewDecoder(r),
+		schema: schema,
+		refs:   1,
+		chunk:  1,
+		infer:  defaultInferRows,
+	}
+	rr.dec.UseNumber()
+	for _, opt := range opts {
+		opt(rr)
+	}
+
+	if rr.mem == nil {
+		rr.mem = memory.DefaultAllocator
+	}
+
+	if rr.schema == nil {
+		rr.err = rr.inferSchema()
+		if rr.err != nil {
+			rr.done = true
+			return rr
+		}
+	}
+
+	rr.bld = array.NewRecordBuilder(rr.mem, rr.schema)
+
+	switch {
+	case rr.chunk < 0:
+		rr.next = rr.nextall
+	case rr.chunk > 1:
+		rr.next = rr.nextn
+	default:
+		rr.next = rr.next1
+	}
+	return rr
+}
+
+// inferSchema decodes the first rows of the file, infers the schema from them
+// and keeps the decoded rows around so they are part of the first records.
+func (r *Reader) inferSchema() error {
+	var root inferNode
+	root.kind = inferStruct
+	for i := 0; r.infer < 0 || i < r.infer; i++ {
+		var raw json.RawMessage
+		err := r.dec.Decode(&raw)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := root.inferRow(raw); err != nil {
+			return err
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		var row map[string]interface{}
+		if err := dec.Decode(&row); err != nil {
+			return err
+		}
+		r.pending = append(r.pending, row)
+	}
+	r.schema = root.schema()
+	return nil
+}
+
+// Err returns the last error encountered during the iteration over the
+// underlying JSON file.
+func (r *Reader) Err() error { return r.err }
+
+func (r *Reader) Schema() *arrow.Schema { return r.schema }
+
+// Record returns the current record that has been extracted from the
+// underlying JSON file.
+// It is valid until the next call to Next.
+func (r *Reader) Record() array.Record { return r.cur }
+
+// Next returns whether a Record could be extracted from the underlying JSON file.
+func (r *Reader) Next() bool {
+	if r.cur != nil {
+		r.cur.Release()
+		r.cur = nil
+	}
+
+	if r.err != nil || r.done {
+		return false
+	}
+
+	return r.next()
+}
+
+// readRow decodes the next row of the JSON file.
+func (r *Reader) readRow() (map[string]interface{}, error) {
+	if len(r.pending) > 0 {
+		row := r.pending[0]
+		r.pending = r.pending[1:]
+		return row, nil
+	}
+
+	var row map[string]interface{}
+	err := r.dec.Decode(&row)
+	return row, err
+}
+
+// next1 reads one row from the JSON file and creates a single Record
+// from that row.
+func (r *Reader) next1() bool {
+	var row map[string]interface{}
+	row, r.err = r.readRow()
+	if r.err != nil {
+		r.done = true
+		if r.err == io.EOF {
+			r.err = nil
+		}
+		return false
+	}
+
+	r.err = r.read(row)
+	if r.err != nil {
+		r.done = true
+		return false
+	}
+	r.cur = r.bld.NewRecord()
+
+	return true
+}
+
+// nextall reads the whole JSON file into memory and creates one single
+// Record from all the JSON rows.
+func (r *Reader) nextall() bool {
+	defer func() {
+		r.done = true
+	}()
+
+	n := 0
+	for {
+		row, err := r.readRow()
+		if err != nil {
+			if err != io.EOF {
+				r.err = err
+			}
+			break
+		}
+
+		r.err = r.read(row)
+		if r.err != nil {
+			return false
+		}
+		n++
+	}
+
+	if r.err != nil {
+		return false
+	}
+
+	r.cur = r.bld.NewRecord()
+	return n > 0
+}
+
+// nextn reads n rows from the JSON file, where n is the chunk size, and creates
+// a Record from these rows.
+func (r *Reader) nextn() bool {
+	var (
+		row map[string]interface{}
+		n   = 0
+	)
+
+	for i := 0; i < r.chunk && !r.done; i++ {
+		row, r.err = r.readRow()
+		if r.err != nil {
+			r.done = true
+			break
+		}
+
+		r.err = r.read(row)
+		if r.err != nil {
+			r.done = true
+			return false
+		}
+		n++
+	}
+
+	if r.err != nil {
+		r.done = true
+		if r.err == io.EOF {
+			r.err = nil
+		}
+	}
+
+	if r.err != nil || n == 0 {
+		return false
+	}
+
+	r.cur = r.bld.NewRecord()
+	return true
+}
+
+func (r *Reader) read(row map[string]interface{}) error {
+	for i, f := range r.schema.Fields() {
+		err := appendValue(r.bld.Field(i), f.Type, row[f.Name])
+		if err != nil {
+			return fmt.Errorf("arrow/json: field %q: %v", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// appendValue appends the decoded JSON value v to the builder b of type dt.
+func appendValue(b array.Builder, dt arrow.DataType, v interface{}) error {
+	if v == nil {
+		b.AppendNull()
+		return nil
+	}
+
+	switch dt := dt.(type) {
+	case *arrow.NullType:
+		return invalidValue(v, dt)
+	case *arrow.BooleanType:
+		v, ok := v.(bool)
+		if !ok {
+			return invalidValue(v, dt)
+		}
+		b.(*array.BooleanBuilder).Append(v)
+	case *arrow.Int8Type:
+		n, err := parseInt(v, 8)
+		if err != nil {
+			return err
+		}
+		b.(*array.Int8Builder).Append(int8(n))
+	case *arrow.Int16Type:
+		n, err := parseInt(v, 16)
+		if err != nil {
+			return err
+		}
+		b.(*array.Int16Builder).Append(int16(n))
+	case *arrow.Int32Type:
+		n, err := parseInt(v, 32)
+		if err != nil {
+			return err
+		}
+		b.(*array.Int32Builder).Append(int32(n))
+	case *arrow.Int64Type:
+		n, err := parseInt(v, 64)
+		if err != nil {
+			return err
+		}
+		b.(*array.Int64Builder).Append(n)
+	case *arrow.Uint8Type:
+		n, err := parseUint(v, 8)
+		if err != nil {
+			return err
+		}
+		b.(*array.Uint8Builder).Append(uint8(n))
+	case *arrow.Uint16Type:
+		n, err := parseUint(v, 16)
+		if err != nil {
+			return err
+		}
+		b.(*array.Uint16Builder).Append(uint16(n))
+	case *arrow.Uint32Type:
+		n, err := parseUint(v, 32)
+		if err != nil {
+			return err
+		}
+		b.(*array.Uint32Builder).Append(uint32(n))
+	case *arrow.Uint64Type:
+		n, err := parseUint(v, 64)
+		if err != nil {
+			return err
+		}
+		b.(*array.Uint64Builder).Append(n)
+	case *arrow.Float16Type:
+		f, err := parseFloat(v, 32)
+		if err != nil {
+			return err
+		}
+		b.(*array.Float16Builder).AppendFloat32(float32(f))
+	case *arrow.Float32Type:
+		f, err := parseFloat(v, 32)
+		if err != nil {
+			return err
+		}
+		b.(*array.Float32Builder).Append(float32(f))
+	case *arrow.Float64Type:
+		f, err := parseFloat(v, 64)
+		if err != nil {
+			return err
+		}
+		b.(*array.Float64Builder).Append(f)
+	case *arrow.StringType:
+		s, ok := v.(string)
+		if !ok {
+			return invalidValue(v, dt)
+		}
+		b.(*array.StringBuilder).Append(s)
+	case *arrow.BinaryType:
+		raw, err := parseBytes(v, dt)
+		if err != nil {
+			return err
+		}
+		b.(*array.BinaryBuilder).Append(raw)
+	case *arrow.LargeStringType:
+		s, ok := v.(string)
+		if !ok {
+			return invalidValue(v, dt)
+		}
+		b.(*array.LargeStringBuilder).Append(s)
+	case *arrow.LargeBinaryType:
+		raw, err := parseBytes(v, dt)
+		if err != nil {
+			return err
+		}
+		b.(*array.LargeBinaryBuilder).Append(raw)
+	case *arrow.StringViewType:
+		s, ok := v.(string)
+		if !ok {
+			return invalidValue(v, dt)
+		}
+		b.(*array.StringViewBuilder).Append(s)
+	case *arrow.BinaryViewType:
+		raw, err := parseBytes(v, dt)
+		if err != nil {
+			return err
+		}
+		b.(*array.BinaryViewBuilder).Append(raw)
+	case *arrow.FixedSizeBinaryType:
+		raw, err := parseBytes(v, dt)
+		if err != nil {
+			return err
+		}
+		if len(raw) != dt.ByteWidth {
+			return fmt.Errorf("invalid value %v for type %s: got %d bytes, want %d", v, dt.Name(), len(raw), dt.ByteWidth)
+		}
+		b.(*array.FixedSizeBinaryBuilder).Append(raw)
+	case *arrow.Date32Type:
+		n, err := parseDate(v, dt)
+		if err != nil {
+			return err
+		}
+		b.(*array.Date32Builder).Append(arrow.Date32(n))
+	case *arrow.Date64Type:
+		n, err := parseDate(v, dt)
+		if err != nil {
+			return err
+		}
+		b.(*array.Date64Builder).Append(arrow.Date64(n))
+	case *arrow.TimestampType:
+		n, err := parseTimestamp(v, dt)
+		if err != nil {
+			return err
+		}
+		b.(*array.TimestampBuilder).Append(arrow.Timestamp(n))
+	case *arrow.Time32Type:
+		n, err := parseTime(v, dt, dt.Unit)
+		if err != nil {
+			return err
+		}
+		b.(*array.Time32Builder).Append(arrow.Time32(n))
+	case *arrow.Time64Type:
+		n, err := parseTime(v, dt, dt.Unit)
+		if err != nil {
+			return err
+		}
+		b.(*array.Time64Builder).Append(arrow.Time64(n))
+	case *arrow.DurationType:
+		n, err := parseDuration(v, dt)
+		if err != nil {
+			return err
+		}
+		b.(*array.DurationBuilder).Append(arrow.Duration(n))
+	case *arrow.MonthIntervalType:
+		n, err := parseInt(v, 32)
+		if err != nil {
+			return err
+		}
+		b.(*array.MonthIntervalBuilder).Append(arrow.MonthInterval(n))
+	case *arrow.DayTimeIntervalType:
+		c, err := parseInterval(v, dt, []string{"days", "milliseconds"}, []int{32, 32})
+		if err != nil {
+			return err
+		}
+		b.(*array.DayTimeIntervalBuilder).Append(arrow.DayTimeInterval{Days: int32(c[0]), Milliseconds: int32(c[1])})
+	case *arrow.MonthDayNanoIntervalType:
+		c, err := parseInterval(v, dt, []string{"months", "days", "nanoseconds"}, []int{32, 32, 64})
+		if err != nil {
+			return err
+		}
+		b.(*array.MonthDayNanoIntervalBuilder).Append(arrow.MonthDayNanoInterval{Months: int32(c[0]), Days: int32(c[1]), Nanoseconds: c[2]})
+	case *arrow.ListType:
+		vs, ok := v.([]interface{})
+		if !ok {
+			return invalidValue(v, dt)
+		}
+		lb := b.(*array.ListBuilder)
+		lb.Append(true)
+		vb := lb.ValueBuilder()
+		for _, e := range vs {
+			if err := appendValue(vb, dt.Elem(), e); err != nil {
+				return err
+			}
+		}
+	case *arrow.LargeListType:
+		vs, ok := v.([]interface{})
+		if !ok {
+			return invalidValue(v, dt)
+		}
+		lb := b.(*array.LargeListBuilder)
+		lb.Append(true)
+		vb := lb.ValueBuilder()
+		for _, e := range vs {
+			if err := appendValue(vb, dt.Elem(), e); err != nil {
+				return err
+			}
+		}
+	case *arrow.FixedSizeListType:
+		vs, ok := v.([]interface{})
+		if !ok {
+			return invalidValue(v, dt)
+		}
+		if len(vs) != int(dt.Len()) {
+			return fmt.Errorf("invalid list length %d for %s of %d elements", len(vs), dt.Name(), dt.Len())
+		}
+		lb := b.(*array.FixedSizeListBuilder)
+		lb.Append(true)
+		vb := lb.ValueBuilder()
+		for _, e := range vs {
+			if err := appendValue(vb, dt.Elem(), e); err != nil {
+				return err
+			}
+		}
+	case *arrow.StructType:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return invalidValue(v, dt)
+		}
+		sb := b.(*array.StructBuilder)
+		sb.Append(true)
+		for i, f := range dt.Fields() {
+			if err := appendValue(sb.FieldBuilder(i), f.Type, obj[f.Name]); err != nil {
+				return fmt.Errorf("field %q: %v", f.Name, err)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported data type %s", dt.Name())
+	}
+	return nil
+}
+
+func invalidValue(v interface{}, dt arrow.DataType) error {
+	return fmt.Errorf("invalid value %v (%T) for type %s", v, v, dt.Name())
+}
+
+func parseInt(v interface{}, bitSize int) (int64, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("invalid value %v (%T) for type int%d", v, v, bitSize)
+	}
+	return strconv.ParseInt(string(n), 10, bitSize)
+}
+
+func parseUint(v interface{}, bitSize int) (uint64, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("invalid value %v (%T) for type uint%d", v, v, bitSize)
+	}
+	return strconv.ParseUint(string(n), 10, bitSize)
+}
+
+func parseFloat(v interface{}, bitSize int) (float64, error) {
+	n, ok := v.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("invalid value %v (%T) for type float%d", v, v, bitSize)
+	}
+	return strconv.ParseFloat(string(n), bitSize)
+}
+
+// parseBytes decodes a base64 encoded JSON string, following the convention
+// of encoding/json for []byte values.
+func parseBytes(v interface{}, dt arrow.DataType) ([]byte, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, invalidValue(v, dt)
+	}
+	return base64.StdEncoding.DecodeString(s)
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (r *Reader) Retain() {
+	atomic.AddInt64(&r.refs, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+// Release may be called simultaneously from multiple goroutines.
+func (r *Reader) Release() {
+	debug.Assert(atomic.LoadInt64(&r.refs) > 0, "too many releases")
+
+	if atomic.AddInt64(&r.refs, -1) == 0 {
+		if r.cur != nil {
+			r.cur.Release()
+		}
+		if r.bld != nil {
+			r.bld.Release()
+		}
+		r.pending = nil
+	}
+}
+
+var (
+	_ array.RecordReader = (*Reader)(nil)
+)
@@ -0,0 +1,659 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json_test
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/json"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func Example() {
+	f := strings.NewReader(`{"i64": 0, "f64": 0.5, "str": "str-0"}
+{"i64": 1, "f64": 1.5, "str": "str-1"}
+{"i64": 2, "f64": 2.5}
+{"i64": 3, "f64": 3.5, "str": "str-3"}
+`)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "i64", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "f64", Type: arrow.PrimitiveTypes.Float64},
+			{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+		},
+		nil,
+	)
+	r := json.NewReader(f, schema, json.WithChunk(2))
+	defer r.Release()
+
+	n := 0
+	for r.Next() {
+		rec := r.Record()
+		for i, col := range rec.Columns() {
+			fmt.Printf("rec[%d][%q]: %v\n", n, rec.ColumnName(i), col)
+		}
+		n++
+	}
+
+	// Output:
+	// rec[0]["i64"]: [0 1]
+	// rec[0]["f64"]: [0.5 1.5]
+	// rec[0]["str"]: ["str-0" "str-1"]
+	// rec[1]["i64"]: [2 3]
+	// rec[1]["f64"]: [2.5 3.5]
+	// rec[1]["str"]: [(null) "str-3"]
+}
+
+func TestJSONReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	raw, err := ioutil.ReadFile("testdata/types.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "bool", Type: arrow.FixedWidthTypes.Boolean},
+			{Name: "i8", Type: arrow.PrimitiveTypes.Int8},
+			{Name: "i16", Type: arrow.PrimitiveTypes.Int16},
+			{Name: "i32", Type: arrow.PrimitiveTypes.Int32},
+			{Name: "i64", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "u8", Type: arrow.PrimitiveTypes.Uint8},
+			{Name: "u16", Type: arrow.PrimitiveTypes.Uint16},
+			{Name: "u32", Type: arrow.PrimitiveTypes.Uint32},
+			{Name: "u64", Type: arrow.PrimitiveTypes.Uint64},
+			{Name: "f32", Type: arrow.PrimitiveTypes.Float32},
+			{Name: "f64", Type: arrow.PrimitiveTypes.Float64},
+			{Name: "str", Type: arrow.BinaryTypes.String},
+		},
+		nil,
+	)
+	r := json.NewReader(bytes.NewReader(raw), schema, json.WithAllocator(mem))
+	defer r.Release()
+
+	r.Retain()
+	r.Release()
+
+	if got, want := r.Schema(), schema; !got.Equal(want) {
+		t.Fatalf("invalid schema: got=%v, want=%v", got, want)
+	}
+
+	out := new(bytes.Buffer)
+
+	n := 0
+	for r.Next() {
+		rec := r.Record()
+		for i, col := range rec.Columns() {
+			fmt.Fprintf(out, "rec[%d][%q]: %v\n", i, rec.ColumnName(i), col)
+		}
+		n++
+	}
+
+	if r.Err() != nil {
+		t.Fatalf("unexpected error: %v", r.Err())
+	}
+
+	if got, want := n, 4; got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+
+	want := `rec[0]["bool"]: [true]
+rec[1]["i8"]: [-1]
+rec[2]["i16"]: [-1]
+rec[3]["i32"]: [-1]
+rec[4]["i64"]: [-1]
+rec[5]["u8"]: [1]
+rec[6]["u16"]: [1]
+rec[7]["u32"]: [1]
+rec[8]["u64"]: [1]
+rec[9]["f32"]: [1.1]
+rec[10]["f64"]: [1.1]
+rec[11]["str"]: ["str-1"]
+rec[0]["bool"]: [false]
+rec[1]["i8"]: [-2]
+rec[2]["i16"]: [-2]
+rec[3]["i32"]: [-2]
+rec[4]["i64"]: [-2]
+rec[5]["u8"]: [2]
+rec[6]["u16"]: [2]
+rec[7]["u32"]: [2]
+rec[8]["u64"]: [2]
+rec[9]["f32"]: [2.2]
+rec[10]["f64"]: [2.2]
+rec[11]["str"]: ["str-2"]
+rec[0]["bool"]: [(null)]
+rec[1]["i8"]: [(null)]
+rec[2]["i16"]: [(null)]
+rec[3]["i32"]: [(null)]
+rec[4]["i64"]: [(null)]
+rec[5]["u8"]: [(null)]
+rec[6]["u16"]: [(null)]
+rec[7]["u32"]: [(null)]
+rec[8]["u64"]: [(null)]
+rec[9]["f32"]: [(null)]
+rec[10]["f64"]: [(null)]
+rec[11]["str"]: [(null)]
+rec[0]["bool"]: [(null)]
+rec[1]["i8"]: [(null)]
+rec[2]["i16"]: [(null)]
+rec[3]["i32"]: [(null)]
+rec[4]["i64"]: [(null)]
+rec[5]["u8"]: [(null)]
+rec[6]["u16"]: [(null)]
+rec[7]["u32"]: [(null)]
+rec[8]["u64"]: [(null)]
+rec[9]["f32"]: [(null)]
+rec[10]["f64"]: [(null)]
+rec[11]["str"]: [(null)]
+`
+
+	if got, want := out.String(), want; got != want {
+		t.Fatalf("invalid output:\ngot= %s\nwant=%s\n", got, want)
+	}
+}
+
+func TestJSONReaderWithChunk(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	raw, err := ioutil.ReadFile("testdata/types.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "i64", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "str", Type: arrow.BinaryTypes.String},
+		},
+		nil,
+	)
+
+	for _, tc := range []struct {
+		name  string
+		opts  []json.Option
+		rows  []int64
+		recs  int
+		nulls int
+	}{
+		{name: "chunk=0", opts: []json.Option{json.WithChunk(0)}, rows: []int64{1, 1, 1, 1}},
+		{name: "chunk=1", opts: []json.Option{json.WithChunk(1)}, rows: []int64{1, 1, 1, 1}},
+		{name: "chunk=3", opts: []json.Option{json.WithChunk(3)}, rows: []int64{3, 1}},
+		{name: "chunk=4", opts: []json.Option{json.WithChunk(4)}, rows: []int64{4}},
+		{name: "chunk=10", opts: []json.Option{json.WithChunk(10)}, rows: []int64{4}},
+		{name: "chunk=-1", opts: []json.Option{json.WithChunk(-1)}, rows: []int64{4}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := json.NewReader(bytes.NewReader(raw), schema, append(tc.opts, json.WithAllocator(mem))...)
+			defer r.Release()
+
+			var rows []int64
+			for r.Next() {
+				rows = append(rows, r.Record().NumRows())
+			}
+			if err := r.Err(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got, want := fmt.Sprint(rows), fmt.Sprint(tc.rows); got != want {
+				t.Fatalf("invalid rows per record: got=%s, want=%s", got, want)
+			}
+		})
+	}
+}
+
+func TestJSONReaderNested(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	f := strings.NewReader(`{"id": 1, "tags": ["a", "b"], "pos": {"x": 1.5, "y": 2}}
+{"id": 2, "tags": [], "pos": null}
+{"id": 3, "tags": null, "pos": {"x": -1}}
+`)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+			{Name: "tags", Type: arrow.ListOf(arrow.BinaryTypes.String), Nullable: true},
+			{Name: "pos", Type: arrow.StructOf(
+				arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Float64},
+				arrow.Field{Name: "y", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+			), Nullable: true},
+		},
+		nil,
+	)
+
+	r := json.NewReader(f, schema, json.WithAllocator(mem), json.WithChunk(-1))
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("could not read record: %v", r.Err())
+	}
+	rec := r.Record()
+	if got, want := rec.NumRows(), int64(3); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+
+	ids := rec.Column(0).(*array.Int32)
+	if got, want := ids.Int32Values(), []int32{1, 2, 3}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("invalid ids: got=%v, want=%v", got, want)
+	}
+
+	tags := rec.Column(1).(*array.List)
+	if got, want := tags.Offsets(), []int32{0, 2, 2, 2}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("invalid list offsets: got=%v, want=%v", got, want)
+	}
+	if !tags.IsNull(2) || tags.IsNull(1) {
+		t.Fatalf("invalid list validity")
+	}
+	if got, want := tags.ListValues().(*array.String).String(), `["a" "b"]`; got != want {
+		t.Fatalf("invalid list values: got=%s, want=%s", got, want)
+	}
+
+	pos := rec.Column(2).(*array.Struct)
+	if !pos.IsNull(1) || pos.IsNull(0) || pos.IsNull(2) {
+		t.Fatalf("invalid struct validity")
+	}
+	if got, want := pos.Field(0).(*array.Float64).String(), "[1.5 (null) -1]"; got != want {
+		t.Fatalf("invalid struct field x: got=%s, want=%s", got, want)
+	}
+	if got, want := pos.Field(1).(*array.Int64).String(), "[2 (null) (null)]"; got != want {
+		t.Fatalf("invalid struct field y: got=%s, want=%s", got, want)
+	}
+
+	if r.Next() {
+		t.Fatalf("expected a single record")
+	}
+}
+
+func TestJSONReaderLargeTypes(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	const data = `{"str":"a","bin":"Ymlu","list":[1,2]}
+{"str":null,"bin":"","list":null}
+{"str":"c","bin":"/w==","list":[]}
+`
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "str", Type: arrow.BinaryTypes.LargeString, Nullable: true},
+			{Name: "bin", Type: arrow.BinaryTypes.LargeBinary},
+			{Name: "list", Type: arrow.LargeListOf(arrow.PrimitiveTypes.Int64), Nullable: true},
+		},
+		nil,
+	)
+
+	r := json.NewReader(strings.NewReader(data), schema, json.WithAllocator(mem), json.WithChunk(-1))
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("could not read record: %v", r.Err())
+	}
+	rec := r.Record()
+
+	if got, want := rec.Column(0).(*array.LargeString).String(), `["a" (null) "c"]`; got != want {
+		t.Fatalf("invalid strings: got=%s, want=%s", got, want)
+	}
+	if got, want := rec.Column(2).(*array.LargeList).Offsets(), []int64{0, 2, 2, 2}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("invalid list offsets: got=%v, want=%v", got, want)
+	}
+
+	buf := new(strings.Builder)
+	if err := json.NewWriter(buf, schema).Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); got != data {
+		t.Fatalf("invalid round-trip:\ngot= %s\nwant=%s", got, data)
+	}
+}
+
+func TestJSONReaderTemporal(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	f := strings.NewReader(`{"ts": "2018-12-31T23:59:59.5Z", "d32": "1970-01-11", "d64": 86400000, "t32": "01:00:01"}
+{"ts": 1500, "d32": 1, "d64": "1970-01-03", "t32": 3601}
+`)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Millisecond}},
+			{Name: "d32", Type: arrow.PrimitiveTypes.Date32},
+			{Name: "d64", Type: arrow.PrimitiveTypes.Date64},
+			{Name: "t32", Type: arrow.FixedWidthTypes.Time32s},
+		},
+		nil,
+	)
+
+	r := json.NewReader(f, schema, json.WithAllocator(mem), json.WithChunk(-1))
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("could not read record: %v", r.Err())
+	}
+	rec := r.Record()
+
+	for i, want := range []string{
+		"[1546300799500 1500]",
+		"[10 1]",
+		"[86400000 172800000]",
+		"[3601 3601]",
+	} {
+		if got := fmt.Sprint(rec.Column(i)); got != want {
+			t.Errorf("invalid column %q: got=%s, want=%s", rec.ColumnName(i), got, want)
+		}
+	}
+}
+
+func TestJSONReaderErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "i8", Type: arrow.PrimitiveTypes.Int8},
+			{Name: "str", Type: arrow.BinaryTypes.String},
+		},
+		nil,
+	)
+
+	for _, tc := range []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{
+			name: "overflow",
+			raw:  `{"i8": 1024, "str": "a"}`,
+			want: `arrow/json: field "i8": strconv.ParseInt: parsing "1024": value out of range`,
+		},
+		{
+			name: "type",
+			raw:  `{"i8": 1, "str": 2}`,
+			want: `arrow/json: field "str": invalid value 2 (json.Number) for type utf8`,
+		},
+		{
+			name: "syntax",
+			raw:  `{"i8": 1, "str": "a"`,
+			want: `unexpected EOF`,
+		},
+		{
+			name: "not-an-object",
+			raw:  `[1, "a"]`,
+			want: `json: cannot unmarshal array into Go value of type map[string]interface {}`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := json.NewReader(strings.NewReader(tc.raw), schema, json.WithAllocator(mem))
+			defer r.Release()
+
+			if r.Next() {
+				t.Fatalf("expected an error")
+			}
+			if r.Err() == nil {
+				t.Fatalf("expected a non-nil error")
+			}
+			if got, want := r.Err().Error(), tc.want; got != want {
+				t.Fatalf("invalid error:\ngot= %s\nwant=%s", got, want)
+			}
+		})
+	}
+}
+
+func TestInferSchema(t *testing.T) {
+	const raw = `{"a": 1, "b": "x", "c": [1, 2], "d": {"e": true}}
+{"a": 2.5, "f": null, "c": [], "d": {"g": "y"}}
+{"a": null, "b": null, "c": [3.5]}
+`
+
+	schema, err := json.InferSchema(strings.NewReader(raw), -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "a", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+			{Name: "b", Type: arrow.BinaryTypes.String, Nullable: true},
+			{Name: "c", Type: arrow.ListOf(arrow.PrimitiveTypes.Float64), Nullable: true},
+			{Name: "d", Type: arrow.StructOf(
+				arrow.Field{Name: "e", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+				arrow.Field{Name: "g", Type: arrow.BinaryTypes.String, Nullable: true},
+			), Nullable: true},
+			{Name: "f", Type: arrow.Null, Nullable: true},
+		},
+		nil,
+	)
+
+	if !schema.Equal(want) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", schema, want)
+	}
+
+	_, err = json.InferSchema(strings.NewReader(`{"a": 1}
+{"a": "x"}`), 1)
+	if err != nil {
+		t.Fatalf("inference should stop after the first row: %v", err)
+	}
+
+	_, err = json.InferSchema(strings.NewReader(`{"a": {"b": 1}}
+{"a": {"b": "x"}}`), -1)
+	if got, want := fmt.Sprint(err), `arrow/json: field "a.b" has conflicting types int and string`; got != want {
+		t.Fatalf("invalid error: got=%s, want=%s", got, want)
+	}
+}
+
+func TestJSONReaderInferSchema(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	f := strings.NewReader(`{"a": 1, "b": "x"}
+{"a": 2}
+{"a": 3, "b": "z"}
+`)
+
+	r := json.NewReader(f, nil, json.WithAllocator(mem), json.WithInferRows(2), json.WithChunk(2))
+	defer r.Release()
+
+	want := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "a", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+			{Name: "b", Type: arrow.BinaryTypes.String, Nullable: true},
+		},
+		nil,
+	)
+	if got := r.Schema(); !got.Equal(want) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", got, want)
+	}
+
+	var out []string
+	for r.Next() {
+		rec := r.Record()
+		out = append(out, fmt.Sprintf("%v %v", rec.Column(0), rec.Column(1)))
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := strings.Join(out, "\n"), "[1 2] [\"x\" (null)]\n[3] [\"z\"]"; got != want {
+		t.Fatalf("invalid records:\ngot= %s\nwant=%s", got, want)
+	}
+}
+
+func TestJSONReaderIntervals(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	f := strings.NewReader(`{"dur": 1500, "m": 3, "dt": {"days": 1, "milliseconds": 500}, "mdn": {"months": 1, "days": 2, "nanoseconds": 3}}
+{"dur": "1m30s", "m": -1, "dt": {"days": -1}, "mdn": {"days": 5}}
+`)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "dur", Type: arrow.FixedWidthTypes.Duration_ms},
+			{Name: "m", Type: arrow.FixedWidthTypes.MonthInterval},
+			{Name: "dt", Type: arrow.FixedWidthTypes.DayTimeInterval},
+			{Name: "mdn", Type: arrow.FixedWidthTypes.MonthDayNanoInterval},
+		},
+		nil,
+	)
+
+	r := json.NewReader(f, schema, json.WithAllocator(mem), json.WithChunk(-1))
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("could not read record: %v", r.Err())
+	}
+	rec := r.Record()
+
+	for i, want := range []string{
+		"[1500 90000]",
+		"[3 -1]",
+		"[1d500ms -1d0ms]",
+		"[1M2d3ns 0M5d0ns]",
+	} {
+		if got := fmt.Sprint(rec.Column(i)); got != want {
+			t.Errorf("invalid column %q: got=%s, want=%s", rec.ColumnName(i), got, want)
+		}
+	}
+
+	out := new(bytes.Buffer)
+	if err := json.NewWriter(out, schema).Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"dur":1500,"m":3,"dt":{"days":1,"milliseconds":500},"mdn":{"months":1,"days":2,"nanoseconds":3}}
+{"dur":90000,"m":-1,"dt":{"days":-1,"milliseconds":0},"mdn":{"months":0,"days":5,"nanoseconds":0}}
+`
+	if got := out.String(); got != want {
+		t.Errorf("invalid output:\ngot=%s\nwant=%s", got, want)
+	}
+
+	r = json.NewReader(strings.NewReader(`{"dt": {"hours": 1}}`), schema, json.WithAllocator(mem))
+	defer r.Release()
+	if r.Next() {
+		t.Fatalf("expected an error for an invalid interval component")
+	}
+	if err := r.Err(); err == nil || !strings.Contains(err.Error(), `invalid component "hours"`) {
+		t.Fatalf("invalid error: %v", err)
+	}
+}
+
+func TestJSONReaderFloat16(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	f := strings.NewReader(`{"f16": 0.1}
+{"f16": null}
+{"f16": -2.5e2}
+{"f16": 65519}
+`)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{{Name: "f16", Type: arrow.FixedWidthTypes.Float16, Nullable: true}},
+		nil,
+	)
+
+	r := json.NewReader(f, schema, json.WithAllocator(mem), json.WithChunk(-1))
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("could not read record: %v", r.Err())
+	}
+	rec := r.Record()
+	if got, want := fmt.Sprint(rec.Column(0)), "[0.1 (null) -250 65500]"; got != want {
+		t.Fatalf("invalid column: got=%s, want=%s", got, want)
+	}
+
+	out := new(bytes.Buffer)
+	if err := json.NewWriter(out, schema).Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"f16":0.1}
+{"f16":null}
+{"f16":-250}
+{"f16":65500}
+`
+	if got := out.String(); got != want {
+		t.Errorf("invalid output:\ngot=%s\nwant=%s", got, want)
+	}
+
+	r = json.NewReader(strings.NewReader(`{"f16": 1e6}`), schema, json.WithAllocator(mem))
+	defer r.Release()
+	if !r.Next() {
+		t.Fatalf("could not read record: %v", r.Err())
+	}
+	err := json.NewWriter(new(bytes.Buffer), schema).Write(r.Record())
+	if got, want := fmt.Sprint(err), `arrow/json: field "f16": unsupported value +Inf`; got != want {
+		t.Fatalf("invalid error: got=%s, want=%s", got, want)
+	}
+}
+
+func TestJSONReaderFixedSizeList(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	f := strings.NewReader(`{"vec": [0.5, -1, 2]}
+{"vec": null}
+{"vec": [null, 4, 5]}
+`)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{{Name: "vec", Type: arrow.FixedSizeListOf(3, arrow.PrimitiveTypes.Float32), Nullable: true}},
+		nil,
+	)
+
+	r := json.NewReader(f, schema, json.WithAllocator(mem), json.WithChunk(-1))
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("could not read record: %v", r.Err())
+	}
+	rec := r.Record()
+	if got, want := fmt.Sprint(rec.Column(0)), "[[0.5 -1 2] (null) [(null) 4 5]]"; got != want {
+		t.Fatalf("invalid column: got=%s, want=%s", got, want)
+	}
+
+	out := new(bytes.Buffer)
+	if err := json.NewWriter(out, schema).Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"vec":[0.5,-1,2]}
+{"vec":null}
+{"vec":[null,4,5]}
+`
+	if got := out.String(); got != want {
+		t.Errorf("invalid output:\ngot=%s\nwant=%s", got, want)
+	}
+
+	r = json.NewReader(strings.NewReader(`{"vec": [1, 2]}`), schema, json.WithAllocator(mem))
+	defer r.Release()
+	if r.Next() {
+		t.Fatalf("unexpected record")
+	}
+	if got, want := fmt.Sprint(r.Err()), "invalid list length 2 for fixed_size_list of 3 elements"; !strings.Contains(got, want) {
+		t.Fatalf("invalid error: got=%s, want=%s", got, want)
+	}
+}
@@ -0,0 +1,151 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+)
+
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "15:04:05.999999999"
+)
+
+// timestampLayouts are the layouts accepted when parsing timestamps from
+// JSON strings. Layouts without a time zone are interpreted as UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	dateLayout,
+}
+
+// parseTimestamp parses a timestamp given either as a number of ticks since
+// the UNIX epoch or as a string.
+func parseTimestamp(v interface{}, dt *arrow.TimestampType) (int64, error) {
+	switch v := v.(type) {
+	case json.Number:
+		return strconv.ParseInt(string(v), 10, 64)
+	case string:
+		loc, err := dt.Location()
+		if err != nil {
+			return 0, err
+		}
+		for _, layout := range timestampLayouts {
+			t, err := time.ParseInLocation(layout, v, loc)
+			if err == nil {
+				return int64(arrow.TimestampFromTime(t, dt.Unit)), nil
+			}
+		}
+		return 0, fmt.Errorf("invalid timestamp %q", v)
+	}
+	return 0, invalidValue(v, dt)
+}
+
+// parseDate parses a date given either as a number (days for date32,
+// milliseconds for date64) since the UNIX epoch or as a "YYYY-MM-DD" string.
+func parseDate(v interface{}, dt arrow.DataType) (int64, error) {
+	switch v := v.(type) {
+	case json.Number:
+		return strconv.ParseInt(string(v), 10, 64)
+	case string:
+		t, err := time.Parse(dateLayout, v)
+		if err != nil {
+			return 0, err
+		}
+		if dt.ID() == arrow.DATE32 {
+			return t.Unix() / 86400, nil
+		}
+		return int64(arrow.TimestampFromTime(t, arrow.Millisecond)), nil
+	}
+	return 0, invalidValue(v, dt)
+}
+
+// parseTime parses a time of day given either as a number of ticks since
+// midnight or as a "HH:MM:SS[.fffffffff]" string.
+func parseTime(v interface{}, dt arrow.DataType, unit arrow.TimeUnit) (int64, error) {
+	switch v := v.(type) {
+	case json.Number:
+		return strconv.ParseInt(string(v), 10, 64)
+	case string:
+		t, err := time.Parse(timeLayout, v)
+		if err != nil {
+			return 0, err
+		}
+		midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return int64(t.Sub(midnight) / unit.Multiplier()), nil
+	}
+	return 0, invalidValue(v, dt)
+}
+
+// formatTime formats v, a number of ticks of unit since midnight, as a time of day.
+func formatTime(v int64, unit arrow.TimeUnit) string {
+	return time.Unix(0, 0).UTC().Add(time.Duration(v) * unit.Multiplier()).Format(timeLayout)
+}
+
+// parseDuration parses a duration given either as a number of ticks or as a
+// string accepted by time.ParseDuration, such as "1h30m".
+func parseDuration(v interface{}, dt *arrow.DurationType) (int64, error) {
+	switch v := v.(type) {
+	case json.Number:
+		return strconv.ParseInt(string(v), 10, 64)
+	case string:
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, err
+		}
+		return int64(d / dt.Unit.Multiplier()), nil
+	}
+	return 0, invalidValue(v, dt)
+}
+
+// parseInterval parses an interval given as an object holding its named
+// components, each an integer of the matching bit size. Missing components
+// are zero.
+func parseInterval(v interface{}, dt arrow.DataType, names []string, bits []int) ([]int64, error) {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, invalidValue(v, dt)
+	}
+	vals := make([]int64, len(names))
+	for k := range obj {
+		i := 0
+		for i < len(names) && names[i] != k {
+			i++
+		}
+		if i == len(names) {
+			return nil, fmt.Errorf("invalid component %q for type %s", k, dt.Name())
+		}
+	}
+	for i, name := range names {
+		c, ok := obj[name]
+		if !ok {
+			continue
+		}
+		n, err := parseInt(c, bits[i])
+		if err != nil {
+			return nil, fmt.Errorf("component %q: %v", name, err)
+		}
+		vals[i] = n
+	}
+	return vals, nil
+}
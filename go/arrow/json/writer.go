@@ -0,0 +1,303 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+)
+
+var (
+	ErrMismatchFields = errors.New("arrow/json: schema mismatch")
+)
+
+// Writer writes array.Records as line-delimited JSON, one JSON object per row,
+// based on a schema.
+type Writer struct {
+	w      io.Writer
+	schema *arrow.Schema
+	buf    []byte
+
+	tsLayout    string
+	rawTemporal bool
+	omitNulls   bool
+}
+
+// NewWriter returns a writer that writes array.Records to the line-delimited
+// JSON file with the given schema.
+//
+// NewWriter panics if the given schema contains fields that have types that are
+// not supported.
+func NewWriter(w io.Writer, schema *arrow.Schema, opts ...Option) *Writer {
+	validate(schema)
+
+	ww := &Writer{w: w, schema: schema, tsLayout: time.RFC3339Nano}
+	for _, opt := range opts {
+		opt(ww)
+	}
+
+	return ww
+}
+
+func (w *Writer) Schema() *arrow.Schema { return w.schema }
+
+// Write writes a single Record as one JSON object per row.
+func (w *Writer) Write(record array.Record) error {
+	if !record.Schema().Equal(w.schema) {
+		return ErrMismatchFields
+	}
+
+	var err error
+	w.buf = w.buf[:0]
+	for i := 0; i < int(record.NumRows()); i++ {
+		w.buf = append(w.buf, '{')
+		n := 0
+		for j, col := range record.Columns() {
+			if w.omitNulls && col.IsNull(i) {
+				continue
+			}
+			if n > 0 {
+				w.buf = append(w.buf, ',')
+			}
+			w.buf = appendString(w.buf, w.schema.Field(j).Name)
+			w.buf = append(w.buf, ':')
+			w.buf, err = w.appendValue(w.buf, col, i)
+			if err != nil {
+				return fmt.Errorf("arrow/json: field %q: %v", w.schema.Field(j).Name, err)
+			}
+			n++
+		}
+		w.buf = append(w.buf, '}', '\n')
+	}
+
+	_, err = w.w.Write(w.buf)
+	return err
+}
+
+// appendValue appends the JSON encoding of the i-th value of arr to buf.
+func (w *Writer) appendValue(buf []byte, arr array.Interface, i int) ([]byte, error) {
+	if arr.IsNull(i) {
+		return append(buf, "null"...), nil
+	}
+
+	switch arr := arr.(type) {
+	case *array.Null:
+		return append(buf, "null"...), nil
+	case *array.Boolean:
+		return strconv.AppendBool(buf, arr.Value(i)), nil
+	case *array.Int8:
+		return strconv.AppendInt(buf, int64(arr.Value(i)), 10), nil
+	case *array.Int16:
+		return strconv.AppendInt(buf, int64(arr.Value(i)), 10), nil
+	case *array.Int32:
+		return strconv.AppendInt(buf, int64(arr.Value(i)), 10), nil
+	case *array.Int64:
+		return strconv.AppendInt(buf, arr.Value(i), 10), nil
+	case *array.Uint8:
+		return strconv.AppendUint(buf, uint64(arr.Value(i)), 10), nil
+	case *array.Uint16:
+		return strconv.AppendUint(buf, uint64(arr.Value(i)), 10), nil
+	case *array.Uint32:
+		return strconv.AppendUint(buf, uint64(arr.Value(i)), 10), nil
+	case *array.Uint64:
+		return strconv.AppendUint(buf, arr.Value(i), 10), nil
+	case *array.Float16:
+		if v := arr.Value(i); v.IsNaN() || v.IsInf(0) {
+			return buf, fmt.Errorf("unsupported value %v", v)
+		}
+		return append(buf, arr.Value(i).String()...), nil
+	case *array.Float32:
+		return appendFloat(buf, float64(arr.Value(i)), 32)
+	case *array.Float64:
+		return appendFloat(buf, arr.Value(i), 64)
+	case *array.String:
+		return appendString(buf, arr.Value(i)), nil
+	case *array.Binary:
+		return appendString(buf, base64.StdEncoding.EncodeToString(arr.Value(i))), nil
+	case *array.LargeString:
+		return appendString(buf, arr.Value(i)), nil
+	case *array.LargeBinary:
+		return appendString(buf, base64.StdEncoding.EncodeToString(arr.Value(i))), nil
+	case *array.StringView:
+		return appendString(buf, arr.Value(i)), nil
+	case *array.BinaryView:
+		return appendString(buf, base64.StdEncoding.EncodeToString(arr.Value(i))), nil
+	case *array.FixedSizeBinary:
+		return appendString(buf, base64.StdEncoding.EncodeToString(arr.Value(i))), nil
+	case *array.Date32:
+		v := int64(arr.Value(i))
+		if w.rawTemporal {
+			return strconv.AppendInt(buf, v, 10), nil
+		}
+		t := time.Unix(v*86400, 0).UTC()
+		return appendString(buf, t.Format(dateLayout)), nil
+	case *array.Date64:
+		v := int64(arr.Value(i))
+		if w.rawTemporal {
+			return strconv.AppendInt(buf, v, 10), nil
+		}
+		t := arrow.Timestamp(v).ToTime(arrow.Millisecond)
+		return appendString(buf, t.Format(dateLayout)), nil
+	case *array.Timestamp:
+		v := int64(arr.Value(i))
+		if w.rawTemporal {
+			return strconv.AppendInt(buf, v, 10), nil
+		}
+		dt := arr.DataType().(*arrow.TimestampType)
+		loc, err := dt.Location()
+		if err != nil {
+			return buf, err
+		}
+		t := arr.Value(i).ToTime(dt.Unit).In(loc)
+		return appendString(buf, t.Format(w.tsLayout)), nil
+	case *array.Time32:
+		v := int64(arr.Value(i))
+		if w.rawTemporal {
+			return strconv.AppendInt(buf, v, 10), nil
+		}
+		unit := arr.DataType().(*arrow.Time32Type).Unit
+		return appendString(buf, formatTime(v, unit)), nil
+	case *array.Time64:
+		v := int64(arr.Value(i))
+		if w.rawTemporal {
+			return strconv.AppendInt(buf, v, 10), nil
+		}
+		unit := arr.DataType().(*arrow.Time64Type).Unit
+		return appendString(buf, formatTime(v, unit)), nil
+	case *array.Duration:
+		return strconv.AppendInt(buf, int64(arr.Value(i)), 10), nil
+	case *array.MonthInterval:
+		return strconv.AppendInt(buf, int64(arr.Value(i)), 10), nil
+	case *array.DayTimeInterval:
+		v := arr.Value(i)
+		buf = append(buf, `{"days":`...)
+		buf = strconv.AppendInt(buf, int64(v.Days), 10)
+		buf = append(buf, `,"milliseconds":`...)
+		buf = strconv.AppendInt(buf, int64(v.Milliseconds), 10)
+		return append(buf, '}'), nil
+	case *array.MonthDayNanoInterval:
+		v := arr.Value(i)
+		buf = append(buf, `{"months":`...)
+		buf = strconv.AppendInt(buf, int64(v.Months), 10)
+		buf = append(buf, `,"days":`...)
+		buf = strconv.AppendInt(buf, int64(v.Days), 10)
+		buf = append(buf, `,"nanoseconds":`...)
+		buf = strconv.AppendInt(buf, v.Nanoseconds, 10)
+		return append(buf, '}'), nil
+	case *array.List:
+		var (
+			err     error
+			offsets = arr.Offsets()
+			j       = arr.Data().Offset() + i
+			values  = arr.ListValues()
+		)
+		buf = append(buf, '[')
+		for k := int(offsets[j]); k < int(offsets[j+1]); k++ {
+			if k > int(offsets[j]) {
+				buf = append(buf, ',')
+			}
+			buf, err = w.appendValue(buf, values, k)
+			if err != nil {
+				return buf, err
+			}
+		}
+		return append(buf, ']'), nil
+	case *array.LargeList:
+		var (
+			err     error
+			offsets = arr.Offsets()
+			j       = arr.Data().Offset() + i
+			values  = arr.ListValues()
+		)
+		buf = append(buf, '[')
+		for k := int(offsets[j]); k < int(offsets[j+1]); k++ {
+			if k > int(offsets[j]) {
+				buf = append(buf, ',')
+			}
+			buf, err = w.appendValue(buf, values, k)
+			if err != nil {
+				return buf, err
+			}
+		}
+		return append(buf, ']'), nil
+	case *array.FixedSizeList:
+		var (
+			err      error
+			beg, end = arr.ValueOffsets(i)
+			values   = arr.ListValues()
+		)
+		buf = append(buf, '[')
+		for k := int(beg); k < int(end); k++ {
+			if k > int(beg) {
+				buf = append(buf, ',')
+			}
+			buf, err = w.appendValue(buf, values, k)
+			if err != nil {
+				return buf, err
+			}
+		}
+		return append(buf, ']'), nil
+	case *array.Struct:
+		var (
+			err   error
+			dt    = arr.DataType().(*arrow.StructType)
+			nvals = 0
+		)
+		buf = append(buf, '{')
+		for k, f := range dt.Fields() {
+			field := arr.Field(k)
+			if w.omitNulls && field.IsNull(i) {
+				continue
+			}
+			if nvals > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendString(buf, f.Name)
+			buf = append(buf, ':')
+			buf, err = w.appendValue(buf, field, i)
+			if err != nil {
+				return buf, fmt.Errorf("field %q: %v", f.Name, err)
+			}
+			nvals++
+		}
+		return append(buf, '}'), nil
+	}
+	return buf, fmt.Errorf("unsupported data type %s", arr.DataType().Name())
+}
+
+// appendFloat appends the JSON encoding of f to buf.
+// NaN and infinite values can not be represented in JSON and yield an error.
+func appendFloat(buf []byte, f float64, bitSize int) ([]byte, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return buf, fmt.Errorf("unsupported value %v", f)
+	}
+	return strconv.AppendFloat(buf, f, 'g', -1, bitSize), nil
+}
+
+func appendString(buf []byte, s string) []byte {
+	raw, _ := json.Marshal(s) // marshaling a string can not fail.
+	return append(buf, raw...)
+}
@@ -0,0 +1,226 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json_test
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/json"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func Example_writer() {
+	pool := memory.NewGoAllocator()
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "i64", Type: arrow.PrimitiveTypes.Int64},
+			{Name: "tags", Type: arrow.ListOf(arrow.BinaryTypes.String), Nullable: true},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(pool, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2, 3}, nil)
+	lb := b.Field(1).(*array.ListBuilder)
+	vb := lb.ValueBuilder().(*array.StringBuilder)
+	lb.Append(true)
+	vb.AppendValues([]string{"a", "b"}, nil)
+	lb.AppendNull()
+	lb.Append(true)
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	w := json.NewWriter(os.Stdout, schema)
+	err := w.Write(rec)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Output:
+	// {"i64":1,"tags":["a","b"]}
+	// {"i64":2,"tags":null}
+	// {"i64":3,"tags":[]}
+}
+
+func TestJSONWriter(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	schema := arrow.NewSchema(
+		[]arrow.Field{
+			{Name: "bool", Type: arrow.FixedWidthTypes.Boolean},
+			{Name: "i8", Type: arrow.PrimitiveTypes.Int8},
+			{Name: "u64", Type: arrow.PrimitiveTypes.Uint64},
+			{Name: "f32", Type: arrow.PrimitiveTypes.Float32},
+			{Name: "f64", Type: arrow.PrimitiveTypes.Float64},
+			{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+			{Name: "bin", Type: arrow.BinaryTypes.Binary},
+			{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Millisecond}},
+			{Name: "d32", Type: arrow.PrimitiveTypes.Date32},
+			{Name: "t64", Type: arrow.FixedWidthTypes.Time64us},
+			{Name: "pos", Type: arrow.StructOf(
+				arrow.Field{Name: "x", Type: arrow.PrimitiveTypes.Int32},
+				arrow.Field{Name: "y", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+			), Nullable: true},
+		},
+		nil,
+	)
+
+	b := array.NewRecordBuilder(pool, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.BooleanBuilder).AppendValues([]bool{true, false, true}, nil)
+	b.Field(1).(*array.Int8Builder).AppendValues([]int8{-1, 0, 1}, nil)
+	b.Field(2).(*array.Uint64Builder).AppendValues([]uint64{0, 1, 1 << 63}, nil)
+	b.Field(3).(*array.Float32Builder).AppendValues([]float32{0.0, 0.1, 0.2}, nil)
+	b.Field(4).(*array.Float64Builder).AppendValues([]float64{0.0, 0.1, 1e21}, nil)
+	b.Field(5).(*array.StringBuilder).AppendValues([]string{"str-0", "", `"quoted"`}, []bool{true, false, true})
+	b.Field(6).(*array.BinaryBuilder).AppendValues([][]byte{[]byte("bin"), nil, {0xff}}, nil)
+	b.Field(7).(*array.TimestampBuilder).AppendValues([]arrow.Timestamp{0, 1546300799500, -1}, nil)
+	b.Field(8).(*array.Date32Builder).AppendValues([]arrow.Date32{0, 1, -1}, nil)
+	b.Field(9).(*array.Time64Builder).AppendValues([]arrow.Time64{0, 3600e6 + 1, 86399e6}, nil)
+	sb := b.Field(10).(*array.StructBuilder)
+	sb.Append(true)
+	sb.FieldBuilder(0).(*array.Int32Builder).Append(1)
+	sb.FieldBuilder(1).(*array.Int32Builder).AppendNull()
+	sb.AppendNull()
+	sb.Append(true)
+	sb.FieldBuilder(0).(*array.Int32Builder).Append(3)
+	sb.FieldBuilder(1).(*array.Int32Builder).Append(4)
+
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	for _, tc := range []struct {
+		name string
+		opts []json.Option
+		want string
+	}{
+		{
+			name: "default",
+			want: `{"bool":true,"i8":-1,"u64":0,"f32":0,"f64":0,"str":"str-0","bin":"Ymlu","ts":"1970-01-01T00:00:00Z","d32":"1970-01-01","t64":"00:00:00","pos":{"x":1,"y":null}}
+{"bool":false,"i8":0,"u64":1,"f32":0.1,"f64":0.1,"str":null,"bin":"","ts":"2018-12-31T23:59:59.5Z","d32":"1970-01-02","t64":"01:00:00.000001","pos":null}
+{"bool":true,"i8":1,"u64":9223372036854775808,"f32":0.2,"f64":1e+21,"str":"\"quoted\"","bin":"/w==","ts":"1969-12-31T23:59:59.999Z","d32":"1969-12-31","t64":"23:59:59","pos":{"x":3,"y":4}}
+`,
+		},
+		{
+			name: "omit-nulls",
+			opts: []json.Option{json.WithOmitNulls(true), json.WithTemporalAsNumbers(true)},
+			want: `{"bool":true,"i8":-1,"u64":0,"f32":0,"f64":0,"str":"str-0","bin":"Ymlu","ts":0,"d32":0,"t64":0,"pos":{"x":1}}
+{"bool":false,"i8":0,"u64":1,"f32":0.1,"f64":0.1,"bin":"","ts":1546300799500,"d32":1,"t64":3600000001}
+{"bool":true,"i8":1,"u64":9223372036854775808,"f32":0.2,"f64":1e+21,"str":"\"quoted\"","bin":"/w==","ts":-1,"d32":-1,"t64":86399000000,"pos":{"x":3,"y":4}}
+`,
+		},
+		{
+			name: "timestamp-layout",
+			opts: []json.Option{json.WithTimestampLayout("2006-01-02 15:04:05.000")},
+			want: `"ts":"1969-12-31 23:59:59.999"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := new(bytes.Buffer)
+			w := json.NewWriter(f, schema, tc.opts...)
+			err := w.Write(rec)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got, want := f.String(), tc.want; !strings.Contains(got, want) {
+				t.Fatalf("invalid output:\ngot=%s\nwant=%s\n", got, want)
+			}
+		})
+	}
+
+	// round-trip through the reader.
+	f := new(bytes.Buffer)
+	err := json.NewWriter(f, schema).Write(rec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := f.String()
+	r := json.NewReader(f, schema, json.WithAllocator(pool), json.WithChunk(-1))
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("could not read back record: %v", r.Err())
+	}
+
+	f = new(bytes.Buffer)
+	err = json.NewWriter(f, schema).Write(r.Record())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.String(); got != want {
+		t.Fatalf("invalid round-trip:\ngot= %s\nwant=%s\n", got, want)
+	}
+
+	// a slice of the record.
+	slice := rec.NewSlice(1, 3)
+	defer slice.Release()
+
+	f.Reset()
+	err = json.NewWriter(f, schema, json.WithOmitNulls(true)).Write(slice)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := f.String(), `{"bool":false,"i8":0,"u64":1,"f32":0.1,"f64":0.1,"bin":"","ts":"2018-12-31T23:59:59.5Z","d32":"1970-01-02","t64":"01:00:00.000001"}
+{"bool":true,"i8":1,"u64":9223372036854775808,"f32":0.2,"f64":1e+21,"str":"\"quoted\"","bin":"/w==","ts":"1969-12-31T23:59:59.999Z","d32":"1969-12-31","t64":"23:59:59","pos":{"x":3,"y":4}}
+`; got != want {
+		t.Fatalf("invalid output:\ngot=%s\nwant=%s\n", got, want)
+	}
+}
+
+func TestJSONWriterErrors(t *testing.T) {
+	pool := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer pool.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "f64", Type: arrow.PrimitiveTypes.Float64}}, nil)
+	b := array.NewRecordBuilder(pool, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Float64Builder).AppendValues([]float64{1, 0}, nil)
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	other := arrow.NewSchema([]arrow.Field{{Name: "i64", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	if err := json.NewWriter(new(bytes.Buffer), other).Write(rec); err != json.ErrMismatchFields {
+		t.Fatalf("invalid error: got=%v, want=%v", err, json.ErrMismatchFields)
+	}
+
+	b.Field(0).(*array.Float64Builder).AppendValues([]float64{1, 0}, nil)
+	b.Field(0).(*array.Float64Builder).Append(math.NaN())
+	nans := b.NewRecord()
+	defer nans.Release()
+
+	err := json.NewWriter(new(bytes.Buffer), schema).Write(nans)
+	if got, want := fmt.Sprint(err), `arrow/json: field "f64": unsupported value NaN`; got != want {
+		t.Fatalf("invalid error: got=%s, want=%s", got, want)
+	}
+}
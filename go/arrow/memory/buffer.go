@@ -17,6 +17,8 @@
 package memory
 
 import (
+	"fmt"
+	"hash/crc32"
 	"sync/atomic"
 
 	"github.com/apache/arrow/go/arrow/internal/debug"
@@ -35,6 +37,36 @@ func NewBufferBytes(data []byte) *Buffer {
 	return &Buffer{refCount: 0, buf: data, length: len(data)}
 }
 
+// NewBufferOwned creates a fixed-size, reference counted buffer from data,
+// without copying it. The buffer takes ownership of data: the caller must
+// neither read nor modify data once it has been handed over. data is left
+// to the garbage collector once the reference count drops to zero.
+func NewBufferOwned(data []byte) *Buffer {
+	return &Buffer{refCount: 1, buf: data, length: len(data), mem: ownedAllocator{}}
+}
+
+// NewBufferBorrowed creates a fixed-size, reference counted buffer from
+// data, without copying it. The caller keeps ownership of data, which the
+// buffer never frees, and must not modify it until the reference count of
+// the buffer, and of every array built on it, drops to zero.
+//
+// When built with the assert tag, the content of data is checksummed and
+// the last Release panics if data was modified while borrowed.
+func NewBufferBorrowed(data []byte) *Buffer {
+	mem := &borrowedAllocator{}
+	if debug.AssertEnabled {
+		mem.sum = crc32.ChecksumIEEE(data)
+	}
+	return &Buffer{refCount: 1, buf: data, length: len(data), mem: mem}
+}
+
+// NewBufferWithAllocator creates a fixed-size buffer from the specified data.
+// The data is handed back to mem via Free once the reference count of the
+// buffer drops to zero.
+func NewBufferWithAllocator(data []byte, mem Allocator) *Buffer {
+	return &Buffer{refCount: 1, buf: data, length: len(data), mem: mem}
+}
+
 // NewBuffer creates a mutable, resizable buffer with an Allocator for managing memory.
 func NewResizableBuffer(mem Allocator) *Buffer {
 	return &Buffer{refCount: 1, mutable: true, mem: mem}
@@ -69,6 +101,31 @@ func (b *Buffer) Mutable() bool { return b.mutable }
 func (b *Buffer) Len() int      { return b.length }
 func (b *Buffer) Cap() int      { return len(b.buf) }
 
+// IsAligned returns whether the memory of the buffer is aligned on n bytes,
+// as required by SIMD instructions.
+func (b *Buffer) IsAligned(n int) bool { return IsAligned(b.buf, n) }
+
+// EnsureAligned returns buf, with an additional reference, if its memory is
+// aligned on n bytes. Otherwise, it returns a new buffer allocated from mem,
+// holding a copy of the content of buf.
+// The returned buffer must be released after use.
+//
+// EnsureAligned panics if n is not a power of two, or if n is greater than 64,
+// the alignment guaranteed by the allocators of this package.
+func EnsureAligned(mem Allocator, buf *Buffer, n int) *Buffer {
+	if n > alignment {
+		panic(fmt.Errorf("arrow/memory: invalid alignment %d", n))
+	}
+	if buf.IsAligned(n) {
+		buf.Retain()
+		return buf
+	}
+	out := NewResizableBuffer(mem)
+	out.Resize(buf.Len())
+	copy(out.Bytes(), buf.Bytes())
+	return out
+}
+
 func (b *Buffer) Reserve(capacity int) {
 	if capacity > len(b.buf) {
 		newCap := roundUpToMultipleOf64(capacity)
@@ -19,6 +19,7 @@ package memory_test
 import (
 	"testing"
 
+	"github.com/apache/arrow/go/arrow/internal/debug"
 	"github.com/apache/arrow/go/arrow/memory"
 	"github.com/stretchr/testify/assert"
 )
@@ -43,3 +44,87 @@ func TestNewResizableBuffer(t *testing.T) {
 	assert.Nil(t, buf.Bytes())
 	assert.Zero(t, buf.Len())
 }
+
+func TestNewBufferWithAllocator(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	data := mem.Allocate(10)
+	buf := memory.NewBufferWithAllocator(data, mem)
+	buf.Retain() // refCount == 2
+
+	assert.Equal(t, 10, buf.Len())
+	assert.False(t, buf.Mutable())
+
+	buf.Release() // refCount == 1
+	assert.NotNil(t, buf.Bytes())
+	mem.AssertSize(t, 10)
+
+	buf.Release() // refCount == 0
+	assert.Nil(t, buf.Bytes())
+}
+
+func TestNewBufferOwned(t *testing.T) {
+	buf := memory.NewBufferOwned([]byte("hello"))
+	buf.Retain() // refCount == 2
+
+	assert.Equal(t, 5, buf.Len())
+	assert.False(t, buf.Mutable())
+	assert.Panics(t, func() { buf.Resize(100) })
+
+	buf.Release() // refCount == 1
+	assert.Equal(t, []byte("hello"), buf.Bytes())
+
+	buf.Release() // refCount == 0
+	assert.Nil(t, buf.Bytes())
+}
+
+func TestNewBufferBorrowed(t *testing.T) {
+	data := []byte("hello")
+	buf := memory.NewBufferBorrowed(data)
+	buf.Retain() // refCount == 2
+
+	assert.Equal(t, 5, buf.Len())
+	assert.Equal(t, &data[0], &buf.Bytes()[0])
+
+	buf.Release() // refCount == 1
+	buf.Release() // refCount == 0
+	assert.Nil(t, buf.Bytes())
+	assert.Equal(t, []byte("hello"), data)
+
+	if !debug.AssertEnabled {
+		return
+	}
+	buf = memory.NewBufferBorrowed(data)
+	data[0] = 'j'
+	assert.PanicsWithValue(t, "arrow/memory: borrowed buffer modified while in use", buf.Release)
+}
+
+func TestBufferAlignment(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	buf := memory.NewResizableBuffer(mem)
+	defer buf.Release()
+	buf.Resize(100)
+	for i := range buf.Bytes() {
+		buf.Bytes()[i] = byte(i)
+	}
+	assert.True(t, buf.IsAligned(64))
+	assert.True(t, memory.IsAligned(nil, 64))
+	assert.Panics(t, func() { buf.IsAligned(3) })
+
+	same := memory.EnsureAligned(mem, buf, 64)
+	assert.Equal(t, buf, same)
+	same.Release()
+
+	unaligned := memory.NewBufferBytes(buf.Bytes()[1:])
+	assert.False(t, unaligned.IsAligned(64))
+	assert.True(t, unaligned.IsAligned(1))
+	aligned := memory.EnsureAligned(mem, unaligned, 64)
+	defer aligned.Release()
+	assert.True(t, aligned.IsAligned(64))
+	assert.Equal(t, buf.Bytes()[1:], aligned.Bytes())
+
+	assert.Panics(t, func() { memory.EnsureAligned(mem, buf, 128) })
+}
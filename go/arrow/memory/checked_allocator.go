@@ -16,56 +16,203 @@
 
 package memory
 
+import (
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"unsafe"
+)
+
+const (
+	maxStackDepth = 32 // number of frames recorded for each allocation
+	maxReported   = 10 // number of outstanding allocations reported by AssertSize
+)
+
+// CheckedAllocator wraps an Allocator and keeps track of the allocated
+// memory, and of the stack trace of each outstanding allocation, so that
+// tests can find the origin of leaked buffers and of buffers freed twice.
+//
+// CheckedAllocator is safe for concurrent use.
 type CheckedAllocator struct {
 	mem  Allocator
 	base int
 	sz   int
+
+	mu     sync.Mutex
+	seq    uint64
+	allocs map[uintptr]allocation // outstanding allocations, by address
+}
+
+type allocation struct {
+	size int
+	seq  uint64 // order of the allocation
+	pcs  []uintptr
 }
 
 func NewCheckedAllocator(mem Allocator) *CheckedAllocator {
-	return &CheckedAllocator{mem: mem}
+	return &CheckedAllocator{mem: mem, allocs: make(map[uintptr]allocation)}
 }
 
 func (a *CheckedAllocator) Allocate(size int) []byte {
+	b := a.mem.Allocate(size)
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	a.sz += size
-	return a.mem.Allocate(size)
+	a.track(b)
+	return b
 }
 
 func (a *CheckedAllocator) Reallocate(size int, b []byte) []byte {
+	a.untrack(b)
+	out := a.mem.Reallocate(size, b)
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	a.sz += size - len(b)
-	return a.mem.Reallocate(size, b)
+	a.track(out)
+	return out
 }
 
+// Free frees b. Free panics if b is not an outstanding allocation of the
+// allocator, that is if it has already been freed or was allocated elsewhere.
 func (a *CheckedAllocator) Free(b []byte) {
+	a.untrack(b)
+	a.mu.Lock()
 	a.sz -= len(b)
+	a.mu.Unlock()
 	a.mem.Free(b)
 }
 
+// track records the allocation of b, with the stack trace of the caller of
+// the allocator. Empty buffers are not tracked.
+func (a *CheckedAllocator) track(b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(3, pcs)
+	a.seq++
+	a.allocs[uintptr(unsafe.Pointer(&b[0]))] = allocation{size: len(b), seq: a.seq, pcs: pcs[:n]}
+}
+
+// untrack removes the record of the allocation of b. It panics if b is not
+// an outstanding allocation.
+func (a *CheckedAllocator) untrack(b []byte) {
+	if len(b) == 0 {
+		return
+	}
+	addr := uintptr(unsafe.Pointer(&b[0]))
+	a.mu.Lock()
+	_, ok := a.allocs[addr]
+	delete(a.allocs, addr)
+	a.mu.Unlock()
+	if !ok {
+		panic(fmt.Errorf("arrow/memory: free of a buffer of %d bytes that is not allocated (double free?)", len(b)))
+	}
+}
+
+// CurrentAlloc returns the number of bytes currently allocated.
+func (a *CheckedAllocator) CurrentAlloc() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.sz
+}
+
+// Leak describes an outstanding allocation of a CheckedAllocator.
+type Leak struct {
+	Size  int    // size of the allocation, in bytes
+	Stack string // stack trace of the allocation
+}
+
+// Leaks returns the allocations that have not been freed, in the order
+// they were made.
+func (a *CheckedAllocator) Leaks() []Leak {
+	return a.leaksSince(0)
+}
+
+func (a *CheckedAllocator) leaksSince(seq uint64) []Leak {
+	a.mu.Lock()
+	allocs := make([]allocation, 0, len(a.allocs))
+	for _, v := range a.allocs {
+		if v.seq > seq {
+			allocs = append(allocs, v)
+		}
+	}
+	a.mu.Unlock()
+
+	sort.Slice(allocs, func(i, j int) bool { return allocs[i].seq < allocs[j].seq })
+	leaks := make([]Leak, len(allocs))
+	for i, v := range allocs {
+		leaks[i] = Leak{Size: v.size, Stack: stackOf(v.pcs)}
+	}
+	return leaks
+}
+
+func stackOf(pcs []uintptr) string {
+	var sb strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		f, more := frames.Next()
+		fmt.Fprintf(&sb, "%s\n\t%s:%d\n", f.Function, f.File, f.Line)
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
+
+// report formats the outstanding allocations for an error message.
+func report(leaks []Leak) string {
+	if len(leaks) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("\noutstanding allocations:")
+	for i, l := range leaks {
+		if i == maxReported {
+			fmt.Fprintf(&sb, "\n... and %d more", len(leaks)-i)
+			break
+		}
+		fmt.Fprintf(&sb, "\n%d bytes allocated at:\n%s", l.Size, l.Stack)
+	}
+	return sb.String()
+}
+
 type TestingT interface {
 	Errorf(format string, args ...interface{})
 	Helper()
 }
 
+// AssertSize checks that sz bytes are currently allocated. Otherwise, it
+// reports an error listing the outstanding allocations, with their stack
+// traces.
 func (a *CheckedAllocator) AssertSize(t TestingT, sz int) {
-	if a.sz != sz {
+	if got := a.CurrentAlloc(); got != sz {
 		t.Helper()
-		t.Errorf("invalid memory size exp=%d, got=%d", sz, a.sz)
+		t.Errorf("invalid memory size exp=%d, got=%d%s", sz, got, report(a.Leaks()))
 	}
 }
 
 type CheckedAllocatorScope struct {
 	alloc *CheckedAllocator
 	sz    int
+	seq   uint64
 }
 
 func NewCheckedAllocatorScope(alloc *CheckedAllocator) *CheckedAllocatorScope {
-	return &CheckedAllocatorScope{alloc: alloc, sz: alloc.sz}
+	alloc.mu.Lock()
+	defer alloc.mu.Unlock()
+	return &CheckedAllocatorScope{alloc: alloc, sz: alloc.sz, seq: alloc.seq}
 }
 
+// CheckSize checks that the allocated memory has the size it had when the
+// scope was created. Otherwise, it reports an error listing the allocations
+// made since then that have not been freed, with their stack traces.
 func (c *CheckedAllocatorScope) CheckSize(t TestingT) {
-	if c.sz != c.alloc.sz {
+	if got := c.alloc.CurrentAlloc(); got != c.sz {
 		t.Helper()
-		t.Errorf("invalid memory size exp=%d, got=%d", c.sz, c.alloc.sz)
+		t.Errorf("invalid memory size exp=%d, got=%d%s", c.sz, got, report(c.alloc.leaksSince(c.seq)))
 	}
 }
 
@@ -0,0 +1,101 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+type testingT struct {
+	errs []string
+}
+
+func (t *testingT) Errorf(format string, args ...interface{}) {
+	t.errs = append(t.errs, fmt.Sprintf(format, args...))
+}
+
+func (t *testingT) Helper() {}
+
+func leak(mem memory.Allocator) *memory.Buffer {
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(100)
+	return buf
+}
+
+func TestCheckedAllocator(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+
+	b1 := mem.Allocate(10)
+	b2 := leak(mem)
+	assert.Equal(t, 10+128, mem.CurrentAlloc())
+
+	leaks := mem.Leaks()
+	if assert.Len(t, leaks, 2) {
+		assert.Equal(t, 10, leaks[0].Size)
+		assert.Contains(t, leaks[0].Stack, "memory_test.TestCheckedAllocator")
+		assert.Equal(t, 128, leaks[1].Size)
+		assert.Contains(t, leaks[1].Stack, "memory_test.leak")
+		assert.Contains(t, leaks[1].Stack, "checked_allocator_test.go")
+	}
+
+	var tt testingT
+	mem.AssertSize(&tt, 0)
+	if assert.Len(t, tt.errs, 1) {
+		assert.True(t, strings.HasPrefix(tt.errs[0], "invalid memory size exp=0, got=138\noutstanding allocations:\n10 bytes allocated at:\n"), tt.errs[0])
+		assert.Contains(t, tt.errs[0], "\n128 bytes allocated at:\n")
+	}
+
+	b1 = mem.Reallocate(20, b1)
+	scope := memory.NewCheckedAllocatorScope(mem)
+	b3 := mem.Allocate(5)
+	tt.errs = nil
+	scope.CheckSize(&tt)
+	if assert.Len(t, tt.errs, 1) {
+		assert.True(t, strings.HasPrefix(tt.errs[0], "invalid memory size exp=148, got=153\noutstanding allocations:\n5 bytes allocated at:\n"), tt.errs[0])
+		assert.NotContains(t, tt.errs[0], "128 bytes")
+	}
+
+	mem.Free(b3)
+	mem.Free(b1)
+	b2.Release()
+	mem.AssertSize(t, 0)
+	assert.Len(t, mem.Leaks(), 0)
+}
+
+func TestCheckedAllocatorDoubleFree(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	b := mem.Allocate(10)
+	mem.Free(b)
+	func() {
+		defer func() {
+			e := recover()
+			if err, ok := e.(error); !ok || err.Error() != "arrow/memory: free of a buffer of 10 bytes that is not allocated (double free?)" {
+				t.Fatalf("invalid panic: %v", e)
+			}
+		}()
+		mem.Free(b)
+	}()
+
+	// empty buffers are not tracked.
+	mem.Free(mem.Allocate(0))
+	mem.AssertSize(t, 0)
+}
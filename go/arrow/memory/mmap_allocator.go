@@ -0,0 +1,131 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"sync"
+	"unsafe"
+)
+
+// Advice is a hint about how the memory of a mapping will be accessed,
+// given to the operating system with madvise. Advice is only given on Linux.
+type Advice int
+
+const (
+	AdviceNormal     Advice = iota // no particular access pattern
+	AdviceSequential               // the memory is accessed sequentially
+	AdviceRandom                   // the memory is accessed randomly
+	AdviceHugePages                // the memory should be backed by huge pages
+)
+
+// MmapAllocator is an Allocator that allocates large buffers with anonymous
+// memory mappings, outside of the Go heap, so that huge arrays do not add to
+// the work of the garbage collector. Smaller buffers are allocated from the
+// Go heap, with a GoAllocator.
+//
+// The buffers returned by a MmapAllocator are aligned on 64 bytes.
+// Mapped buffers are unmapped when freed: using them afterwards crashes the
+// program, instead of silently reading or corrupting reused memory.
+// On platforms without memory mappings, all the buffers are allocated from
+// the Go heap.
+//
+// MmapAllocator is safe to use from multiple goroutines.
+type MmapAllocator struct {
+	heap      GoAllocator
+	threshold int
+	advice    Advice
+
+	mu   sync.Mutex
+	maps map[uintptr][]byte // mappings, by address
+}
+
+// NewMmapAllocator returns an allocator that maps buffers of at least
+// threshold bytes, and gives advice to the operating system about the
+// access pattern of the mapped memory.
+func NewMmapAllocator(threshold int, advice Advice) *MmapAllocator {
+	return &MmapAllocator{
+		threshold: threshold,
+		advice:    advice,
+		maps:      make(map[uintptr][]byte),
+	}
+}
+
+func (a *MmapAllocator) Allocate(size int) []byte {
+	if size == 0 || size < a.threshold {
+		return a.heap.Allocate(size)
+	}
+	m, err := mmap(size)
+	if err != nil {
+		// e.g. on platforms without memory mappings.
+		return a.heap.Allocate(size)
+	}
+	madvise(m, a.advice)
+
+	a.mu.Lock()
+	a.maps[uintptr(unsafe.Pointer(&m[0]))] = m
+	a.mu.Unlock()
+	return m[:size]
+}
+
+func (a *MmapAllocator) Reallocate(size int, b []byte) []byte {
+	if size == len(b) {
+		return b
+	}
+	if size < len(b) || size <= cap(b) && a.mapped(b) {
+		// the mapping is large enough. Memory past the old length is
+		// zeroed, as for newly allocated buffers.
+		old := len(b)
+		b = b[:size]
+		if size > old {
+			Set(b[old:], 0)
+		}
+		return b
+	}
+	out := a.Allocate(size)
+	copy(out, b)
+	a.Free(b)
+	return out
+}
+
+func (a *MmapAllocator) Free(b []byte) {
+	if cap(b) == 0 {
+		return
+	}
+	addr := uintptr(unsafe.Pointer(&b[:1][0]))
+	a.mu.Lock()
+	m, ok := a.maps[addr]
+	delete(a.maps, addr)
+	a.mu.Unlock()
+	if ok {
+		munmap(m)
+	}
+}
+
+// mapped returns whether b is the memory of a mapping.
+func (a *MmapAllocator) mapped(b []byte) bool {
+	if cap(b) == 0 {
+		return false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, ok := a.maps[uintptr(unsafe.Pointer(&b[:1][0]))]
+	return ok
+}
+
+var (
+	_ Allocator = (*MmapAllocator)(nil)
+)
@@ -0,0 +1,63 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMmapAllocator(t *testing.T) {
+	for _, advice := range []memory.Advice{memory.AdviceNormal, memory.AdviceSequential, memory.AdviceRandom, memory.AdviceHugePages} {
+		mem := memory.NewMmapAllocator(1<<16, advice)
+
+		small := mem.Allocate(100)
+		assert.Len(t, small, 100)
+		assert.True(t, memory.IsAligned(small, 64))
+		mem.Free(small)
+
+		big := mem.Allocate(1<<16 + 1)
+		assert.Len(t, big, 1<<16+1)
+		assert.True(t, memory.IsAligned(big, 64))
+		assert.Equal(t, make([]byte, len(big)), big)
+		for i := range big {
+			big[i] = byte(i)
+		}
+
+		// shrinking and growing again within the mapping zeroes the memory.
+		big = mem.Reallocate(1<<16, big)
+		big = mem.Reallocate(1<<16+10, big)
+		assert.Equal(t, byte(0), big[1<<16])
+		assert.Equal(t, byte(255), big[255])
+
+		bigger := mem.Reallocate(1<<20, big)
+		assert.Len(t, bigger, 1<<20)
+		assert.Equal(t, byte(255), bigger[255])
+		assert.Equal(t, byte(0), bigger[1<<16])
+
+		buf := memory.NewResizableBuffer(mem)
+		buf.Resize(1 << 17)
+		buf.Bytes()[1<<17-1] = 1
+		buf.Resize(10)
+		buf.Release()
+
+		mem.Free(bigger)
+		mem.Free(mem.Allocate(0))
+	}
+}
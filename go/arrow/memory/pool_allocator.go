@@ -0,0 +1,196 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"math/bits"
+	"sync"
+	"sync/atomic"
+)
+
+const (
+	minPoolClass = 6  // 64 bytes, the alignment of allocations
+	maxPoolClass = 26 // 64 MiB
+)
+
+// PoolAllocator is an Allocator that recycles freed buffers instead of
+// leaving them to the garbage collector.
+//
+// Allocations are rounded up to a power of two, their size class, and freed
+// buffers are kept in a free list per size class to serve later allocations
+// of the same class. Allocations larger than 64MiB are not pooled.
+// Recycled buffers are zeroed before being handed out again, as fresh ones.
+//
+// PoolAllocator is safe to use from multiple goroutines.
+type PoolAllocator struct {
+	mem     GoAllocator
+	limit   int64 // maximum number of bytes kept in the free lists, or 0
+	classes [maxPoolClass + 1]poolClass
+
+	allocated   int64
+	peak        int64
+	pooled      int64
+	allocations int64
+	reused      int64
+}
+
+type poolClass struct {
+	mu   sync.Mutex
+	free [][]byte
+}
+
+// NewPoolAllocator returns an allocator that keeps at most limit bytes of
+// freed buffers for reuse. If limit is zero, the number of kept bytes is
+// not bounded.
+func NewPoolAllocator(limit int) *PoolAllocator {
+	return &PoolAllocator{limit: int64(limit)}
+}
+
+// PoolStats holds statistics about the allocations of a PoolAllocator.
+type PoolStats struct {
+	Allocated   int64 // number of bytes currently allocated
+	Peak        int64 // maximum number of bytes allocated at once
+	Pooled      int64 // number of bytes of the freed buffers kept for reuse
+	Allocations int64 // number of buffers allocated
+	Reused      int64 // number of buffers allocated from the freed buffers
+}
+
+// ReuseRate returns the fraction of the buffers allocated from the freed
+// buffers.
+func (s PoolStats) ReuseRate() float64 {
+	if s.Allocations == 0 {
+		return 0
+	}
+	return float64(s.Reused) / float64(s.Allocations)
+}
+
+// Stats returns the statistics of the allocator.
+func (a *PoolAllocator) Stats() PoolStats {
+	return PoolStats{
+		Allocated:   atomic.LoadInt64(&a.allocated),
+		Peak:        atomic.LoadInt64(&a.peak),
+		Pooled:      atomic.LoadInt64(&a.pooled),
+		Allocations: atomic.LoadInt64(&a.allocations),
+		Reused:      atomic.LoadInt64(&a.reused),
+	}
+}
+
+// classOf returns the size class of allocations of size bytes, or -1 if
+// they are not pooled.
+func classOf(size int) int {
+	if size <= 1<<minPoolClass {
+		return minPoolClass
+	}
+	c := bits.Len(uint(size - 1))
+	if c > maxPoolClass {
+		return -1
+	}
+	return c
+}
+
+func (a *PoolAllocator) Allocate(size int) []byte {
+	atomic.AddInt64(&a.allocations, 1)
+	a.grow(int64(size))
+
+	c := classOf(size)
+	if c < 0 {
+		return a.mem.Allocate(size)
+	}
+	pc := &a.classes[c]
+	pc.mu.Lock()
+	if n := len(pc.free); n > 0 {
+		b := pc.free[n-1]
+		pc.free[n-1] = nil
+		pc.free = pc.free[:n-1]
+		pc.mu.Unlock()
+		atomic.AddInt64(&a.pooled, -int64(cap(b)))
+		atomic.AddInt64(&a.reused, 1)
+		b = b[:size]
+		Set(b, 0)
+		return b
+	}
+	pc.mu.Unlock()
+	return a.mem.Allocate(1 << uint(c))[:size]
+}
+
+func (a *PoolAllocator) Reallocate(size int, b []byte) []byte {
+	if size == len(b) {
+		return b
+	}
+	if size <= cap(b) {
+		// the buffer is large enough. Memory past the old length is
+		// zeroed, as for newly allocated buffers.
+		a.grow(int64(size - len(b)))
+		old := len(b)
+		b = b[:size]
+		if size > old {
+			Set(b[old:], 0)
+		}
+		return b
+	}
+	out := a.Allocate(size)
+	copy(out, b)
+	a.Free(b)
+	return out
+}
+
+func (a *PoolAllocator) Free(b []byte) {
+	a.grow(-int64(len(b)))
+
+	n := cap(b)
+	c := classOf(n)
+	if c < 0 || n != 1<<uint(c) {
+		return
+	}
+	if p := atomic.AddInt64(&a.pooled, int64(n)); a.limit > 0 && p > a.limit {
+		atomic.AddInt64(&a.pooled, -int64(n))
+		return
+	}
+	pc := &a.classes[c]
+	pc.mu.Lock()
+	pc.free = append(pc.free, b[:n])
+	pc.mu.Unlock()
+}
+
+// Purge drops the freed buffers kept for reuse, leaving them to the
+// garbage collector.
+func (a *PoolAllocator) Purge() {
+	for c := range a.classes {
+		pc := &a.classes[c]
+		pc.mu.Lock()
+		for _, b := range pc.free {
+			atomic.AddInt64(&a.pooled, -int64(cap(b)))
+		}
+		pc.free = nil
+		pc.mu.Unlock()
+	}
+}
+
+// grow adds n bytes to the allocated bytes, and updates the peak.
+func (a *PoolAllocator) grow(n int64) {
+	v := atomic.AddInt64(&a.allocated, n)
+	for {
+		peak := atomic.LoadInt64(&a.peak)
+		if v <= peak || atomic.CompareAndSwapInt64(&a.peak, peak, v) {
+			return
+		}
+	}
+}
+
+var (
+	_ Allocator = (*PoolAllocator)(nil)
+)
@@ -0,0 +1,112 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolAllocator(t *testing.T) {
+	mem := memory.NewPoolAllocator(0)
+
+	b1 := mem.Allocate(100)
+	assert.Len(t, b1, 100)
+	assert.Equal(t, 128, cap(b1))
+	for i := range b1 {
+		b1[i] = 0xff
+	}
+	mem.Free(b1)
+	assert.Equal(t, memory.PoolStats{Allocated: 0, Peak: 100, Pooled: 128, Allocations: 1}, mem.Stats())
+
+	// the freed buffer is recycled, zeroed.
+	b2 := mem.Allocate(120)
+	assert.Equal(t, &b1[:1][0], &b2[:1][0])
+	assert.Equal(t, make([]byte, 120), b2)
+	assert.Equal(t, memory.PoolStats{Allocated: 120, Peak: 120, Pooled: 0, Allocations: 2, Reused: 1}, mem.Stats())
+	assert.Equal(t, 0.5, mem.Stats().ReuseRate())
+
+	// growing within the size class keeps the buffer.
+	b2[119] = 1
+	b3 := mem.Reallocate(50, b2)
+	b3 = mem.Reallocate(128, b3)
+	assert.Equal(t, &b2[:1][0], &b3[:1][0])
+	assert.Equal(t, make([]byte, 128-50), b3[50:])
+
+	// growing past the size class moves the data to a new buffer.
+	b3[0] = 42
+	b4 := mem.Reallocate(200, b3)
+	assert.Len(t, b4, 200)
+	assert.Equal(t, 256, cap(b4))
+	assert.Equal(t, byte(42), b4[0])
+	assert.Equal(t, int64(128), mem.Stats().Pooled)
+
+	mem.Free(b4)
+	assert.Equal(t, int64(0), mem.Stats().Allocated)
+	assert.Equal(t, int64(128+256), mem.Stats().Pooled)
+	mem.Purge()
+	assert.Equal(t, int64(0), mem.Stats().Pooled)
+
+	// small and large allocations.
+	mem.Free(mem.Allocate(0))
+	big := mem.Allocate(1<<26 + 1)
+	assert.Len(t, big, 1<<26+1)
+	mem.Free(big)
+	assert.Equal(t, int64(64), mem.Stats().Pooled)
+}
+
+func TestPoolAllocatorLimit(t *testing.T) {
+	mem := memory.NewPoolAllocator(1024)
+
+	var bufs [][]byte
+	for i := 0; i < 3; i++ {
+		bufs = append(bufs, mem.Allocate(512))
+	}
+	for _, b := range bufs {
+		mem.Free(b)
+	}
+	assert.Equal(t, int64(1024), mem.Stats().Pooled)
+}
+
+func TestPoolAllocatorBuffers(t *testing.T) {
+	mem := memory.NewPoolAllocator(0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				buf := memory.NewResizableBuffer(mem)
+				buf.Resize(j * 10)
+				for k := range buf.Bytes() {
+					assert.Zero(t, buf.Bytes()[k])
+					buf.Bytes()[k] = byte(k)
+				}
+				buf.Release()
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := mem.Stats()
+	assert.Equal(t, int64(0), stats.Allocated)
+	assert.True(t, stats.Reused > 0)
+}
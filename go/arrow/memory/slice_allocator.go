@@ -0,0 +1,63 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package memory
+
+import (
+	"hash/crc32"
+
+	"github.com/apache/arrow/go/arrow/internal/debug"
+)
+
+// ownedAllocator is the allocator of the buffers created by NewBufferOwned,
+// whose memory is managed by the garbage collector.
+type ownedAllocator struct{}
+
+func (ownedAllocator) Allocate(size int) []byte {
+	panic("arrow/memory: owned buffers can not be resized")
+}
+
+func (ownedAllocator) Reallocate(size int, b []byte) []byte {
+	panic("arrow/memory: owned buffers can not be resized")
+}
+
+func (ownedAllocator) Free(b []byte) {}
+
+// borrowedAllocator is the allocator of the buffers created by
+// NewBufferBorrowed. It never frees their memory, which belongs to the
+// caller, and checks that it was not modified when assertions are enabled.
+type borrowedAllocator struct {
+	sum uint32 // checksum of the borrowed memory
+}
+
+func (*borrowedAllocator) Allocate(size int) []byte {
+	panic("arrow/memory: borrowed buffers can not be resized")
+}
+
+func (*borrowedAllocator) Reallocate(size int, b []byte) []byte {
+	panic("arrow/memory: borrowed buffers can not be resized")
+}
+
+func (a *borrowedAllocator) Free(b []byte) {
+	if debug.AssertEnabled {
+		debug.Assert(crc32.ChecksumIEEE(b) == a.sum, "arrow/memory: borrowed buffer modified while in use")
+	}
+}
+
+var (
+	_ Allocator = ownedAllocator{}
+	_ Allocator = (*borrowedAllocator)(nil)
+)
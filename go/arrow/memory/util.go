@@ -16,7 +16,10 @@
 
 package memory
 
-import "unsafe"
+import (
+	"fmt"
+	"unsafe"
+)
 
 func roundToPowerOf2(v, round int) int {
 	forceCarry := round - 1
@@ -35,3 +38,17 @@ func isMultipleOfPowerOf2(v int, d int) bool {
 func addressOf(b []byte) uintptr {
 	return uintptr(unsafe.Pointer(&b[0]))
 }
+
+// IsAligned returns whether the memory of b starts at an address that is a
+// multiple of n. Empty slices are always aligned.
+//
+// IsAligned panics if n is not a power of two.
+func IsAligned(b []byte, n int) bool {
+	if n <= 0 || n&(n-1) != 0 {
+		panic(fmt.Errorf("arrow/memory: invalid alignment %d", n))
+	}
+	if cap(b) == 0 {
+		return true
+	}
+	return isMultipleOfPowerOf2(int(uintptr(unsafe.Pointer(&b[:1][0]))), n)
+}
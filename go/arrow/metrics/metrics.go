@@ -0,0 +1,193 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics instruments Arrow allocators, readers and writers, so that
+// the memory they allocate, the bytes they read or write and the records they
+// produce can be exported to a metrics system.
+//
+// Measurements are reported to a Recorder as named counters. Expvar returns
+// a Recorder publishing them with the expvar package; other systems are
+// plugged in by implementing Recorder.
+package metrics
+
+import (
+	"expvar"
+	"io"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// Names of the counters reported by the instrumented values of the package.
+const (
+	Allocations    = "allocations"     // number of allocations
+	AllocatedBytes = "allocated_bytes" // bytes allocated, freed bytes not deducted
+	BytesInUse     = "bytes_in_use"    // bytes currently allocated
+	BytesRead      = "bytes_read"
+	BytesWritten   = "bytes_written"
+	Records        = "records" // number of records produced by a reader
+	Rows           = "rows"    // number of rows of the records produced by a reader
+
+	// Sizes of the pages written by Parquet writers, before and after
+	// compression. Their ratio is the compression ratio of the pages.
+	UncompressedBytes = "uncompressed_bytes"
+	CompressedBytes   = "compressed_bytes"
+)
+
+// Recorder receives the measurements of instrumented values.
+//
+// Add must be safe for concurrent use.
+type Recorder interface {
+	// Add adds delta to the counter named name. delta may be negative, for
+	// counters measuring a current level, such as BytesInUse.
+	Add(name string, delta int64)
+}
+
+// RecorderFunc adapts a function to the Recorder interface.
+type RecorderFunc func(name string, delta int64)
+
+// Add calls f(name, delta).
+func (f RecorderFunc) Add(name string, delta int64) { f(name, delta) }
+
+// Expvar returns a Recorder adding the measurements to the expvar.Map
+// published under name, creating it if needed.
+//
+// Expvar panics if a variable that is not a map is published under name.
+func Expvar(name string) Recorder {
+	if v := expvar.Get(name); v != nil {
+		return RecorderFunc(v.(*expvar.Map).Add)
+	}
+	return RecorderFunc(expvar.NewMap(name).Add)
+}
+
+type allocator struct {
+	mem memory.Allocator
+	r   Recorder
+}
+
+// NewAllocator returns an allocator allocating memory from mem and reporting
+// the Allocations, AllocatedBytes and BytesInUse to r.
+func NewAllocator(mem memory.Allocator, r Recorder) memory.Allocator {
+	return &allocator{mem: mem, r: r}
+}
+
+func (a *allocator) Allocate(size int) []byte {
+	a.r.Add(Allocations, 1)
+	a.r.Add(AllocatedBytes, int64(size))
+	a.r.Add(BytesInUse, int64(size))
+	return a.mem.Allocate(size)
+}
+
+func (a *allocator) Reallocate(size int, b []byte) []byte {
+	if size > len(b) {
+		a.r.Add(AllocatedBytes, int64(size-len(b)))
+	}
+	a.r.Add(BytesInUse, int64(size-len(b)))
+	return a.mem.Reallocate(size, b)
+}
+
+func (a *allocator) Free(b []byte) {
+	a.r.Add(BytesInUse, -int64(len(b)))
+	a.mem.Free(b)
+}
+
+type reader struct {
+	r   io.Reader
+	rec Recorder
+}
+
+// NewReader returns a reader reading from r and reporting the BytesRead to
+// rec, to count the input of CSV, JSON or Parquet readers.
+func NewReader(r io.Reader, rec Recorder) io.Reader {
+	return &reader{r: r, rec: rec}
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.rec.Add(BytesRead, int64(n))
+	return n, err
+}
+
+type writer struct {
+	w   io.Writer
+	rec Recorder
+}
+
+// NewWriter returns a writer writing to w and reporting the BytesWritten to
+// rec, to count the output of CSV, JSON or Parquet writers.
+func NewWriter(w io.Writer, rec Recorder) io.Writer {
+	return &writer{w: w, rec: rec}
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	n, err := w.w.Write(p)
+	w.rec.Add(BytesWritten, int64(n))
+	return n, err
+}
+
+type recordReader struct {
+	refs int64
+	rr   array.RecordReader
+	rec  Recorder
+}
+
+// NewRecordReader returns a record reader reading the records of rr and
+// reporting the Records and Rows it produces to rec.
+// The returned reader retains rr until it is released.
+func NewRecordReader(rr array.RecordReader, rec Recorder) array.RecordReader {
+	rr.Retain()
+	return &recordReader{refs: 1, rr: rr, rec: rec}
+}
+
+func (r *recordReader) Retain() {
+	atomic.AddInt64(&r.refs, 1)
+}
+
+func (r *recordReader) Release() {
+	debug.Assert(atomic.LoadInt64(&r.refs) > 0, "too many releases")
+
+	if atomic.AddInt64(&r.refs, -1) == 0 {
+		r.rr.Release()
+	}
+}
+
+func (r *recordReader) Schema() *arrow.Schema { return r.rr.Schema() }
+func (r *recordReader) Record() array.Record  { return r.rr.Record() }
+
+// Err returns the error of the underlying reader, if it has an Err method.
+func (r *recordReader) Err() error {
+	if rr, ok := r.rr.(interface{ Err() error }); ok {
+		return rr.Err()
+	}
+	return nil
+}
+
+func (r *recordReader) Next() bool {
+	if !r.rr.Next() {
+		return false
+	}
+	r.rec.Add(Records, 1)
+	r.rec.Add(Rows, r.rr.Record().NumRows())
+	return true
+}
+
+var (
+	_ memory.Allocator   = (*allocator)(nil)
+	_ array.RecordReader = (*recordReader)(nil)
+)
@@ -0,0 +1,122 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"bytes"
+	"expvar"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/csv"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/metrics"
+)
+
+// counters is a Recorder keeping the counters in a map.
+type counters struct {
+	mu sync.Mutex
+	m  map[string]int64
+}
+
+func (c *counters) Add(name string, delta int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.m == nil {
+		c.m = make(map[string]int64)
+	}
+	c.m[name] += delta
+}
+
+func TestAllocator(t *testing.T) {
+	checked := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer checked.AssertSize(t, 0)
+
+	var c counters
+	mem := metrics.NewAllocator(checked, &c)
+	b := mem.Allocate(64)
+	b = mem.Reallocate(128, b)
+	if got, want := c.m[metrics.BytesInUse], int64(128); got != want {
+		t.Fatalf("invalid bytes in use: got=%d, want=%d", got, want)
+	}
+	b = mem.Reallocate(32, b)
+	mem.Free(b)
+
+	for name, want := range map[string]int64{
+		metrics.Allocations:    1,
+		metrics.AllocatedBytes: 128,
+		metrics.BytesInUse:     0,
+	} {
+		if got := c.m[name]; got != want {
+			t.Errorf("invalid %s: got=%d, want=%d", name, got, want)
+		}
+	}
+}
+
+func TestReaderWriter(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "i64", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	raw := "1\n2\n3\n4\n5\n"
+
+	var c counters
+	r := metrics.NewRecordReader(
+		csv.NewReader(metrics.NewReader(strings.NewReader(raw), &c), schema, csv.WithAllocator(mem), csv.WithChunk(2)),
+		&c,
+	)
+	defer r.Release()
+
+	out := new(bytes.Buffer)
+	w := csv.NewWriter(metrics.NewWriter(out, &c), schema)
+	for r.Next() {
+		if err := w.Write(r.Record()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.(interface{ Err() error }).Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[string]int64{
+		metrics.BytesRead:    int64(len(raw)),
+		metrics.BytesWritten: int64(len(raw)),
+		metrics.Records:      3,
+		metrics.Rows:         5,
+	} {
+		if got := c.m[name]; got != want {
+			t.Errorf("invalid %s: got=%d, want=%d", name, got, want)
+		}
+	}
+}
+
+func TestExpvar(t *testing.T) {
+	rec := metrics.Expvar("arrow_metrics_test")
+	w := metrics.NewWriter(io.Discard, rec)
+	w.Write([]byte("hello"))
+
+	// recorders of the same name share their counters.
+	metrics.Expvar("arrow_metrics_test").Add(metrics.BytesWritten, 2)
+
+	m := expvar.Get("arrow_metrics_test").(*expvar.Map)
+	if got, want := m.Get(metrics.BytesWritten).String(), "7"; got != want {
+		t.Fatalf("invalid counter: got=%s, want=%s", got, want)
+	}
+}
@@ -0,0 +1,88 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package orc reads ORC files as Arrow records.
+//
+// The package supports files with flat schemas, whose columns hold values of
+// a primitive type. Nested columns, decimal values, and files compressed with
+// codecs other than zlib and snappy are not supported.
+//
+// See https://orc.apache.org/specification/ORCv1/ for a description of the
+// format.
+package orc
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// Option configures an ORC reader.
+type Option func(config)
+type config interface{}
+
+// WithAllocator specifies the Arrow memory allocator used while building records.
+func WithAllocator(mem memory.Allocator) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.mem = mem
+		default:
+			panic(fmt.Errorf("arrow/orc: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithColumns specifies the names of the columns to read, in the order of
+// the fields of the records. By default, all the columns are read.
+// The streams of the other columns are not read.
+func WithColumns(names ...string) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.names = names
+		default:
+			panic(fmt.Errorf("arrow/orc: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithStripes specifies the indices of the stripes to read.
+// By default, all the stripes are read.
+func WithStripes(indices ...int) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.stripes = indices
+		default:
+			panic(fmt.Errorf("arrow/orc: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithStripeFilter specifies a predicate selecting the stripes to read,
+// typically from the statistics of their columns. Stripes for which keep
+// returns false are skipped without being decoded.
+func WithStripeFilter(keep func(s Stripe) bool) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.filter = keep
+		default:
+			panic(fmt.Errorf("arrow/orc: unknown config type %T", cfg))
+		}
+	}
+}
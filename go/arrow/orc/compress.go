@@ -0,0 +1,93 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"bytes"
+	"compress/flate"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/apache/arrow/go/arrow/internal/snappy"
+)
+
+// decompress returns the uncompressed content of a stream compressed with the
+// given kind.
+//
+// Compressed streams are sequences of chunks prefixed by a 3-byte header
+// holding their length and whether they are stored uncompressed.
+func decompress(kind int, buf []byte) ([]byte, error) {
+	if kind == compressionNone {
+		return buf, nil
+	}
+
+	var out []byte
+	for len(buf) > 0 {
+		if len(buf) < 3 {
+			return nil, errCorrupt
+		}
+		h := int(buf[0]) | int(buf[1])<<8 | int(buf[2])<<16
+		n := h >> 1
+		if n > len(buf)-3 {
+			return nil, errCorrupt
+		}
+		chunk := buf[3 : 3+n]
+		buf = buf[3+n:]
+
+		if h&1 == 1 {
+			// original, uncompressed, chunk.
+			out = append(out, chunk...)
+			continue
+		}
+		switch kind {
+		case compressionZlib:
+			r := flate.NewReader(bytes.NewReader(chunk))
+			raw, err := ioutil.ReadAll(r)
+			if err != nil {
+				return nil, fmt.Errorf("arrow/orc: could not decompress zlib chunk: %w", err)
+			}
+			out = append(out, raw...)
+		case compressionSnappy:
+			raw, err := snappy.Decode(chunk)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, raw...)
+		default:
+			return nil, fmt.Errorf("arrow/orc: unsupported compression %s", compressionName(kind))
+		}
+	}
+	return out, nil
+}
+
+func compressionName(kind int) string {
+	switch kind {
+	case compressionNone:
+		return "none"
+	case compressionZlib:
+		return "zlib"
+	case compressionSnappy:
+		return "snappy"
+	case compressionLZO:
+		return "lzo"
+	case compressionLZ4:
+		return "lz4"
+	case compressionZstd:
+		return "zstd"
+	}
+	return fmt.Sprintf("compression(%d)", kind)
+}
@@ -0,0 +1,354 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+// The ORC file tail and stripe footers, as described by
+// https://github.com/apache/orc/blob/main/proto/orc_proto.proto
+//
+// Only the parts of the metadata used by this package are decoded; other
+// fields are skipped.
+
+// compression kinds.
+const (
+	compressionNone   = 0
+	compressionZlib   = 1
+	compressionSnappy = 2
+	compressionLZO    = 3
+	compressionLZ4    = 4
+	compressionZstd   = 5
+)
+
+// type kinds.
+const (
+	kindBoolean          = 0
+	kindByte             = 1
+	kindShort            = 2
+	kindInt              = 3
+	kindLong             = 4
+	kindFloat            = 5
+	kindDouble           = 6
+	kindString           = 7
+	kindBinary           = 8
+	kindTimestamp        = 9
+	kindList             = 10
+	kindMap              = 11
+	kindStruct           = 12
+	kindUnion            = 13
+	kindDecimal          = 14
+	kindDate             = 15
+	kindVarchar          = 16
+	kindChar             = 17
+	kindTimestampInstant = 18
+)
+
+// stream kinds.
+const (
+	streamPresent        = 0
+	streamData           = 1
+	streamLength         = 2
+	streamDictionaryData = 3
+	streamSecondary      = 5
+)
+
+// column encodings.
+const (
+	encodingDirect       = 0
+	encodingDictionary   = 1
+	encodingDirectV2     = 2
+	encodingDictionaryV2 = 3
+)
+
+type postScript struct {
+	footerLength   uint64
+	compression    int
+	blockSize      uint64
+	metadataLength uint64
+	magic          string
+}
+
+type footer struct {
+	stripes  []stripeInfo
+	types    []orcType
+	metadata []keyValue
+	numRows  uint64
+	stats    []statistics
+}
+
+type stripeInfo struct {
+	offset       uint64
+	indexLength  uint64
+	dataLength   uint64
+	footerLength uint64
+	numRows      uint64
+}
+
+type orcType struct {
+	kind       int
+	subtypes   []uint64
+	fieldNames []string
+}
+
+type keyValue struct {
+	key   string
+	value []byte
+}
+
+// statistics holds the statistics of a column.
+// Only the minimum and maximum matching the type of the column are set.
+type statistics struct {
+	numValues  uint64
+	hasNull    bool
+	intMin     int64 // integers and dates
+	intMax     int64
+	doubleMin  float64
+	doubleMax  float64
+	stringMin  string
+	stringMax  string
+	hasInts    bool
+	hasDates   bool
+	hasDoubles bool
+	hasStrings bool
+}
+
+type stripeFooter struct {
+	streams        []stream
+	encodings      []columnEncoding
+	writerTimezone string
+}
+
+type stream struct {
+	kind   int
+	column int
+	length uint64
+}
+
+type columnEncoding struct {
+	kind     int
+	dictSize int
+}
+
+func (d *decoder) postScript() postScript {
+	var ps postScript
+	d.fields(func(d *decoder, id, typ int) {
+		switch id {
+		case 1:
+			ps.footerLength = d.varint()
+		case 2:
+			ps.compression = int(d.varint())
+		case 3:
+			ps.blockSize = d.varint()
+		case 5:
+			ps.metadataLength = d.varint()
+		case 8000:
+			ps.magic = d.string()
+		default:
+			d.skip(typ)
+		}
+	})
+	return ps
+}
+
+func (d *decoder) footer() footer {
+	var ft footer
+	d.fields(func(d *decoder, id, typ int) {
+		switch id {
+		case 3:
+			var si stripeInfo
+			d.message(typ, func(d *decoder, id, typ int) {
+				switch id {
+				case 1:
+					si.offset = d.varint()
+				case 2:
+					si.indexLength = d.varint()
+				case 3:
+					si.dataLength = d.varint()
+				case 4:
+					si.footerLength = d.varint()
+				case 5:
+					si.numRows = d.varint()
+				default:
+					d.skip(typ)
+				}
+			})
+			ft.stripes = append(ft.stripes, si)
+		case 4:
+			var t orcType
+			d.message(typ, func(d *decoder, id, typ int) {
+				switch id {
+				case 1:
+					t.kind = int(d.varint())
+				case 2:
+					t.subtypes = d.uints(t.subtypes, typ)
+				case 3:
+					t.fieldNames = append(t.fieldNames, d.string())
+				default:
+					d.skip(typ)
+				}
+			})
+			ft.types = append(ft.types, t)
+		case 5:
+			var kv keyValue
+			d.message(typ, func(d *decoder, id, typ int) {
+				switch id {
+				case 1:
+					kv.key = d.string()
+				case 2:
+					kv.value = d.bytes()
+				default:
+					d.skip(typ)
+				}
+			})
+			ft.metadata = append(ft.metadata, kv)
+		case 6:
+			ft.numRows = d.varint()
+		case 7:
+			ft.stats = append(ft.stats, d.statistics(typ))
+		default:
+			d.skip(typ)
+		}
+	})
+	return ft
+}
+
+// metadata decodes the statistics of the stripes of a file.
+func (d *decoder) metadata() [][]statistics {
+	var stripes [][]statistics
+	d.fields(func(d *decoder, id, typ int) {
+		if id != 1 {
+			d.skip(typ)
+			return
+		}
+		var stats []statistics
+		d.message(typ, func(d *decoder, id, typ int) {
+			if id != 1 {
+				d.skip(typ)
+				return
+			}
+			stats = append(stats, d.statistics(typ))
+		})
+		stripes = append(stripes, stats)
+	})
+	return stripes
+}
+
+func (d *decoder) statistics(typ int) statistics {
+	var st statistics
+	d.message(typ, func(d *decoder, id, typ int) {
+		switch id {
+		case 1:
+			st.numValues = d.varint()
+		case 2:
+			var hasMin, hasMax bool
+			d.message(typ, func(d *decoder, id, typ int) {
+				switch id {
+				case 1:
+					st.intMin, hasMin = d.sint(), true
+				case 2:
+					st.intMax, hasMax = d.sint(), true
+				default:
+					d.skip(typ)
+				}
+			})
+			st.hasInts = hasMin && hasMax
+		case 3:
+			var hasMin, hasMax bool
+			d.message(typ, func(d *decoder, id, typ int) {
+				switch id {
+				case 1:
+					st.doubleMin, hasMin = d.double(), true
+				case 2:
+					st.doubleMax, hasMax = d.double(), true
+				default:
+					d.skip(typ)
+				}
+			})
+			st.hasDoubles = hasMin && hasMax
+		case 4:
+			var hasMin, hasMax bool
+			d.message(typ, func(d *decoder, id, typ int) {
+				switch id {
+				case 1:
+					st.stringMin, hasMin = d.string(), true
+				case 2:
+					st.stringMax, hasMax = d.string(), true
+				default:
+					d.skip(typ)
+				}
+			})
+			st.hasStrings = hasMin && hasMax
+		case 7:
+			var hasMin, hasMax bool
+			d.message(typ, func(d *decoder, id, typ int) {
+				switch id {
+				case 1:
+					st.intMin, hasMin = d.sint(), true
+				case 2:
+					st.intMax, hasMax = d.sint(), true
+				default:
+					d.skip(typ)
+				}
+			})
+			st.hasDates = hasMin && hasMax
+		case 10:
+			st.hasNull = d.varint() != 0
+		default:
+			d.skip(typ)
+		}
+	})
+	return st
+}
+
+func (d *decoder) stripeFooter() stripeFooter {
+	var sf stripeFooter
+	d.fields(func(d *decoder, id, typ int) {
+		switch id {
+		case 1:
+			var s stream
+			d.message(typ, func(d *decoder, id, typ int) {
+				switch id {
+				case 1:
+					s.kind = int(d.varint())
+				case 2:
+					s.column = int(d.varint())
+				case 3:
+					s.length = d.varint()
+				default:
+					d.skip(typ)
+				}
+			})
+			sf.streams = append(sf.streams, s)
+		case 2:
+			var ce columnEncoding
+			d.message(typ, func(d *decoder, id, typ int) {
+				switch id {
+				case 1:
+					ce.kind = int(d.varint())
+				case 2:
+					ce.dictSize = int(d.varint())
+				default:
+					d.skip(typ)
+				}
+			})
+			sf.encodings = append(sf.encodings, ce)
+		case 3:
+			sf.writerTimezone = d.string()
+		default:
+			d.skip(typ)
+		}
+	})
+	return sf
+}
@@ -0,0 +1,160 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// The ORC metadata is encoded with protocol buffers.
+// See https://developers.google.com/protocol-buffers/docs/encoding
+
+// wire types.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+var errProto = errors.New("arrow/orc: invalid protobuf data")
+
+// decoder decodes protocol buffers messages.
+// Errors are sticky: once an error occurred, values are zero.
+type decoder struct {
+	buf []byte
+	pos int
+	err error
+}
+
+func (d *decoder) fail() {
+	if d.err == nil {
+		d.err = errProto
+	}
+	d.pos = len(d.buf)
+}
+
+func (d *decoder) varint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.buf[d.pos:])
+	if n <= 0 {
+		d.fail()
+		return 0
+	}
+	d.pos += n
+	return v
+}
+
+// sint decodes a zigzag encoded signed varint.
+func (d *decoder) sint() int64 { return zigzag(d.varint()) }
+
+func (d *decoder) fixed64() uint64 {
+	if d.err != nil || len(d.buf)-d.pos < 8 {
+		d.fail()
+		return 0
+	}
+	v := binary.LittleEndian.Uint64(d.buf[d.pos:])
+	d.pos += 8
+	return v
+}
+
+func (d *decoder) double() float64 { return math.Float64frombits(d.fixed64()) }
+
+func (d *decoder) bytes() []byte {
+	n := d.varint()
+	if d.err != nil || n > uint64(len(d.buf)-d.pos) {
+		d.fail()
+		return nil
+	}
+	v := d.buf[d.pos : d.pos+int(n) : d.pos+int(n)]
+	d.pos += int(n)
+	return v
+}
+
+func (d *decoder) string() string { return string(d.bytes()) }
+
+// message decodes the fields of the embedded message of the field of type typ
+// with fn.
+func (d *decoder) message(typ int, fn func(d *decoder, id, typ int)) {
+	if typ != wireBytes {
+		d.fail()
+		return
+	}
+	sub := decoder{buf: d.bytes()}
+	sub.fields(fn)
+	if sub.err != nil {
+		d.fail()
+	}
+}
+
+// fields decodes the fields of the message of d with fn.
+// fn must decode or skip the value of each field.
+func (d *decoder) fields(fn func(d *decoder, id, typ int)) {
+	for d.err == nil && d.pos < len(d.buf) {
+		key := d.varint()
+		if d.err != nil {
+			return
+		}
+		fn(d, int(key>>3), int(key&7))
+	}
+}
+
+// uints decodes the values of a repeated integer field of type typ, packed
+// or not, and appends them to dst.
+func (d *decoder) uints(dst []uint64, typ int) []uint64 {
+	switch typ {
+	case wireVarint:
+		return append(dst, d.varint())
+	case wireBytes:
+		sub := decoder{buf: d.bytes()}
+		for sub.err == nil && sub.pos < len(sub.buf) {
+			dst = append(dst, sub.varint())
+		}
+		if sub.err != nil {
+			d.fail()
+		}
+		return dst
+	}
+	d.fail()
+	return dst
+}
+
+// skip skips the value of a field of type typ.
+func (d *decoder) skip(typ int) {
+	switch typ {
+	case wireVarint:
+		d.varint()
+	case wireFixed64:
+		d.fixed64()
+	case wireBytes:
+		d.bytes()
+	case wireFixed32:
+		if len(d.buf)-d.pos < 4 {
+			d.fail()
+			return
+		}
+		d.pos += 4
+	default:
+		d.fail()
+	}
+}
+
+func zigzag(v uint64) int64 { return int64(v>>1) ^ -int64(v&1) }
@@ -0,0 +1,607 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+const magic = "ORC"
+
+// File is an ORC file opened for reading.
+type File struct {
+	r           io.ReaderAt
+	compression int
+	footer      footer
+	stats       [][]statistics // statistics of the stripes, if any
+	schema      *arrow.Schema
+	cols        []column
+}
+
+// Open opens the ORC file of the given size read from r, and decodes its
+// metadata.
+func Open(r io.ReaderAt, size int64) (*File, error) {
+	if size < int64(len(magic))+1 {
+		return nil, fmt.Errorf("arrow/orc: file too small (%d bytes)", size)
+	}
+
+	var last [1]byte
+	if _, err := r.ReadAt(last[:], size-1); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("arrow/orc: could not read postscript: %w", err)
+	}
+	psEnd := size - 1
+	ps, err := readAt(r, psEnd-int64(last[0]), int64(last[0]), 0)
+	if err != nil {
+		return nil, fmt.Errorf("arrow/orc: could not read postscript: %w", err)
+	}
+	d := decoder{buf: ps}
+	post := d.postScript()
+	if d.err != nil {
+		return nil, fmt.Errorf("arrow/orc: could not decode postscript: %w", d.err)
+	}
+	if post.magic != magic {
+		return nil, fmt.Errorf("arrow/orc: invalid file magic %q", post.magic)
+	}
+	switch post.compression {
+	case compressionNone, compressionZlib, compressionSnappy:
+	default:
+		return nil, fmt.Errorf("arrow/orc: unsupported compression %s", compressionName(post.compression))
+	}
+
+	f := &File{r: r, compression: post.compression}
+	footerStart := psEnd - int64(last[0]) - int64(post.footerLength)
+	buf, err := readAt(r, footerStart, int64(post.footerLength), post.compression)
+	if err != nil {
+		return nil, fmt.Errorf("arrow/orc: could not read file footer: %w", err)
+	}
+	d = decoder{buf: buf}
+	f.footer = d.footer()
+	if d.err != nil {
+		return nil, fmt.Errorf("arrow/orc: could not decode file footer: %w", d.err)
+	}
+
+	if post.metadataLength > 0 {
+		buf, err := readAt(r, footerStart-int64(post.metadataLength), int64(post.metadataLength), post.compression)
+		if err != nil {
+			return nil, fmt.Errorf("arrow/orc: could not read file metadata: %w", err)
+		}
+		d = decoder{buf: buf}
+		f.stats = d.metadata()
+		if d.err != nil {
+			return nil, fmt.Errorf("arrow/orc: could not decode file metadata: %w", d.err)
+		}
+	}
+
+	f.schema, f.cols, err = schemaOf(&f.footer)
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// readAt reads n bytes at offset off of r, and decompresses them.
+func readAt(r io.ReaderAt, off, n int64, compression int) ([]byte, error) {
+	if off < 0 || n < 0 || n > math.MaxInt32 {
+		return nil, errCorrupt
+	}
+	buf := make([]byte, n)
+	if _, err := r.ReadAt(buf, off); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return decompress(compression, buf)
+}
+
+// Schema returns the schema of the records read from the file.
+// The user metadata of the file is the metadata of the schema.
+func (f *File) Schema() *arrow.Schema { return f.schema }
+
+// NumRows returns the number of rows of the file.
+func (f *File) NumRows() int64 { return int64(f.footer.numRows) }
+
+// NumStripes returns the number of stripes of the file.
+func (f *File) NumStripes() int { return len(f.footer.stripes) }
+
+// Stripe returns the description of the i-th stripe of the file.
+func (f *File) Stripe(i int) Stripe {
+	s := Stripe{
+		Index:   i,
+		NumRows: int64(f.footer.stripes[i].numRows),
+		Columns: make([]ColumnStatistics, len(f.cols)),
+	}
+	for j, col := range f.cols {
+		var st *statistics
+		if i < len(f.stats) && col.id < len(f.stats[i]) {
+			st = &f.stats[i][col.id]
+		}
+		s.Columns[j] = statisticsOf(col, st)
+	}
+	return s
+}
+
+// Stripe describes a stripe of an ORC file.
+type Stripe struct {
+	Index   int                // index of the stripe in the file
+	NumRows int64              // number of rows of the stripe
+	Columns []ColumnStatistics // statistics of each column of the file schema
+}
+
+// ColumnStatistics holds the statistics of a column of a stripe, when
+// written in the file.
+//
+// Min and Max hold values of the Go type of the Arrow array values of the
+// column: for example int64 for a bigint column, string for a string column,
+// arrow.Date32 for a date column. They are only set for integer, floating
+// point, string and date columns.
+type ColumnStatistics struct {
+	Name      string
+	NumValues int64 // number of non-null values
+	HasNull   bool
+	HasMinMax bool
+	Min, Max  interface{}
+}
+
+// statisticsOf returns the statistics of col.
+func statisticsOf(col column, st *statistics) ColumnStatistics {
+	out := ColumnStatistics{Name: col.field.Name}
+	if st == nil {
+		return out
+	}
+	out.NumValues, out.HasNull = int64(st.numValues), st.hasNull
+
+	switch col.kind {
+	case kindByte:
+		out.Min, out.Max, out.HasMinMax = int8(st.intMin), int8(st.intMax), st.hasInts
+	case kindShort:
+		out.Min, out.Max, out.HasMinMax = int16(st.intMin), int16(st.intMax), st.hasInts
+	case kindInt:
+		out.Min, out.Max, out.HasMinMax = int32(st.intMin), int32(st.intMax), st.hasInts
+	case kindLong:
+		out.Min, out.Max, out.HasMinMax = st.intMin, st.intMax, st.hasInts
+	case kindFloat:
+		out.Min, out.Max, out.HasMinMax = float32(st.doubleMin), float32(st.doubleMax), st.hasDoubles
+	case kindDouble:
+		out.Min, out.Max, out.HasMinMax = st.doubleMin, st.doubleMax, st.hasDoubles
+	case kindString, kindVarchar, kindChar:
+		out.Min, out.Max, out.HasMinMax = st.stringMin, st.stringMax, st.hasStrings
+	case kindDate:
+		out.Min, out.Max, out.HasMinMax = arrow.Date32(st.intMin), arrow.Date32(st.intMax), st.hasDates
+	}
+	if !out.HasMinMax {
+		out.Min, out.Max = nil, nil
+	}
+	return out
+}
+
+// Reader reads the stripes of an ORC file and creates one array.Record per
+// stripe.
+type Reader struct {
+	f      *File
+	schema *arrow.Schema
+	cols   []int // indices of the columns read
+
+	names   []string
+	stripes []int
+	filter  func(Stripe) bool
+
+	refs int64
+	cur  array.Record
+	err  error
+
+	mem memory.Allocator
+}
+
+// NewReader returns a reader of the stripes of the file.
+//
+// NewReader returns an error if a column selected with WithColumns, or a
+// stripe selected with WithStripes, does not exist.
+func (f *File) NewReader(opts ...Option) (*Reader, error) {
+	r := &Reader{f: f, refs: 1}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mem == nil {
+		r.mem = memory.DefaultAllocator
+	}
+
+	switch r.names {
+	case nil:
+		r.schema = f.schema
+		r.cols = make([]int, len(f.cols))
+		for i := range r.cols {
+			r.cols[i] = i
+		}
+	default:
+		fields := make([]arrow.Field, len(r.names))
+		r.cols = make([]int, len(r.names))
+		for i, name := range r.names {
+			r.cols[i] = f.schema.FieldIndex(name)
+			if r.cols[i] < 0 {
+				return nil, fmt.Errorf("arrow/orc: no column named %q", name)
+			}
+			fields[i] = f.schema.Field(r.cols[i])
+		}
+		md := f.schema.Metadata()
+		r.schema = arrow.NewSchema(fields, &md)
+	}
+
+	switch r.stripes {
+	case nil:
+		r.stripes = make([]int, f.NumStripes())
+		for i := range r.stripes {
+			r.stripes[i] = i
+		}
+	default:
+		r.stripes = append([]int(nil), r.stripes...)
+		for _, i := range r.stripes {
+			if i < 0 || i >= f.NumStripes() {
+				return nil, fmt.Errorf("arrow/orc: stripe %d out of range [0, %d)", i, f.NumStripes())
+			}
+		}
+	}
+	return r, nil
+}
+
+// Err returns the last error encountered while reading the file.
+func (r *Reader) Err() error { return r.err }
+
+func (r *Reader) Schema() *arrow.Schema { return r.schema }
+
+// Record returns the current record that has been read from the file.
+// It is valid until the next call to Next.
+func (r *Reader) Record() array.Record { return r.cur }
+
+// Next returns whether a Record could be read from the next selected stripe
+// of the file.
+func (r *Reader) Next() bool {
+	if r.cur != nil {
+		r.cur.Release()
+		r.cur = nil
+	}
+
+	for r.err == nil && len(r.stripes) > 0 {
+		i := r.stripes[0]
+		r.stripes = r.stripes[1:]
+		if r.filter != nil && !r.filter(r.f.Stripe(i)) {
+			continue
+		}
+		r.cur, r.err = r.readStripe(i)
+		return r.err == nil
+	}
+	return false
+}
+
+// streamKey identifies a stream of a stripe.
+type streamKey struct {
+	column int
+	kind   int
+}
+
+func (r *Reader) readStripe(i int) (array.Record, error) {
+	si := r.f.footer.stripes[i]
+	end := si.offset + si.indexLength + si.dataLength
+	buf, err := readAt(r.f.r, int64(end), int64(si.footerLength), r.f.compression)
+	if err != nil {
+		return nil, fmt.Errorf("arrow/orc: stripe %d: could not read stripe footer: %w", i, err)
+	}
+	d := decoder{buf: buf}
+	sf := d.stripeFooter()
+	if d.err != nil {
+		return nil, fmt.Errorf("arrow/orc: stripe %d: could not decode stripe footer: %w", i, d.err)
+	}
+
+	// streams are stored in the order of the stripe footer.
+	type extent struct{ off, n uint64 }
+	streams := make(map[streamKey]extent)
+	off := si.offset
+	for _, s := range sf.streams {
+		streams[streamKey{s.column, s.kind}] = extent{off, s.length}
+		off += s.length
+	}
+	if off > end {
+		return nil, fmt.Errorf("arrow/orc: stripe %d: streams overflow the stripe", i)
+	}
+
+	arrs := make([]array.Interface, 0, len(r.cols))
+	defer func() {
+		for _, arr := range arrs {
+			arr.Release()
+		}
+	}()
+
+	// timestamps without a time zone are stored relative to the ORC epoch
+	// in the time zone of the writer.
+	var loc *time.Location
+	if tz := sf.writerTimezone; tz != "" && tz != "UTC" && tz != "GMT" {
+		loc, err = time.LoadLocation(tz)
+		if err != nil {
+			return nil, fmt.Errorf("arrow/orc: stripe %d: unknown writer time zone %q: %w", i, tz, err)
+		}
+	}
+
+	n := int(si.numRows)
+	for _, j := range r.cols {
+		col := r.f.cols[j]
+		if col.id >= len(sf.encodings) {
+			return nil, fmt.Errorf("arrow/orc: stripe %d, column %q: missing column encoding", i, col.field.Name)
+		}
+		stream := func(kind int) ([]byte, error) {
+			ext, ok := streams[streamKey{col.id, kind}]
+			if !ok {
+				return nil, nil
+			}
+			return readAt(r.f.r, int64(ext.off), int64(ext.n), r.f.compression)
+		}
+		arr, err := r.readColumn(col, sf.encodings[col.id], stream, n, loc)
+		if err != nil {
+			return nil, fmt.Errorf("arrow/orc: stripe %d, column %q: %w", i, col.field.Name, err)
+		}
+		arrs = append(arrs, arr)
+	}
+	return array.NewRecord(r.schema, arrs, int64(n)), nil
+}
+
+// orcEpoch is the number of seconds between the Unix epoch and the ORC
+// epoch, 2015-01-01 00:00:00.
+const orcEpoch = 1420070400
+
+// readColumn reads the n values of col, from the streams returned by stream.
+// Timestamps without a time zone are converted to their wall clock time in
+// loc, the time zone of the writer, unless loc is nil.
+func (r *Reader) readColumn(col column, enc columnEncoding, stream func(kind int) ([]byte, error), n int, loc *time.Location) (array.Interface, error) {
+	var (
+		valid  []bool
+		nvalid = n
+	)
+	present, err := stream(streamPresent)
+	if err != nil {
+		return nil, err
+	}
+	if present != nil {
+		valid, err = decodeBools(present, n)
+		if err != nil {
+			return nil, err
+		}
+		nvalid = 0
+		for _, v := range valid {
+			if v {
+				nvalid++
+			}
+		}
+	}
+
+	data, err := stream(streamData)
+	if err != nil {
+		return nil, err
+	}
+	v2 := enc.kind == encodingDirectV2 || enc.kind == encodingDictionaryV2
+
+	b := array.NewBuilder(r.mem, col.field.Type)
+	defer b.Release()
+	b.Reserve(n)
+
+	var appendValue func(k int)
+	switch col.kind {
+	case kindBoolean:
+		vals, err := decodeBools(data, nvalid)
+		if err != nil {
+			return nil, err
+		}
+		bldr := b.(*array.BooleanBuilder)
+		appendValue = func(k int) { bldr.Append(vals[k]) }
+
+	case kindByte:
+		vals, err := decodeBytes(data, nvalid)
+		if err != nil {
+			return nil, err
+		}
+		bldr := b.(*array.Int8Builder)
+		appendValue = func(k int) { bldr.Append(int8(vals[k])) }
+
+	case kindShort, kindInt, kindLong, kindDate:
+		vals, err := decodeInts(data, nvalid, v2, true)
+		if err != nil {
+			return nil, err
+		}
+		switch bldr := b.(type) {
+		case *array.Int16Builder:
+			appendValue = func(k int) { bldr.Append(int16(vals[k])) }
+		case *array.Int32Builder:
+			appendValue = func(k int) { bldr.Append(int32(vals[k])) }
+		case *array.Int64Builder:
+			appendValue = func(k int) { bldr.Append(vals[k]) }
+		case *array.Date32Builder:
+			appendValue = func(k int) { bldr.Append(arrow.Date32(vals[k])) }
+		}
+
+	case kindFloat:
+		if len(data) < 4*nvalid {
+			return nil, errCorrupt
+		}
+		bldr := b.(*array.Float32Builder)
+		appendValue = func(k int) { bldr.Append(math.Float32frombits(binary.LittleEndian.Uint32(data[4*k:]))) }
+
+	case kindDouble:
+		if len(data) < 8*nvalid {
+			return nil, errCorrupt
+		}
+		bldr := b.(*array.Float64Builder)
+		appendValue = func(k int) { bldr.Append(math.Float64frombits(binary.LittleEndian.Uint64(data[8*k:]))) }
+
+	case kindString, kindVarchar, kindChar, kindBinary:
+		vals, err := readBinary(enc, stream, data, nvalid, v2)
+		if err != nil {
+			return nil, err
+		}
+		switch bldr := b.(type) {
+		case *array.StringBuilder:
+			appendValue = func(k int) { bldr.Append(string(vals[k])) }
+		case *array.BinaryBuilder:
+			appendValue = func(k int) { bldr.Append(vals[k]) }
+		}
+
+	case kindTimestamp, kindTimestampInstant:
+		secs, err := decodeInts(data, nvalid, v2, true)
+		if err != nil {
+			return nil, err
+		}
+		secondary, err := stream(streamSecondary)
+		if err != nil {
+			return nil, err
+		}
+		nanos, err := decodeInts(secondary, nvalid, v2, false)
+		if err != nil {
+			return nil, err
+		}
+		bldr := b.(*array.TimestampBuilder)
+		appendValue = func(k int) { bldr.Append(arrow.Timestamp(timestampNanos(secs[k], nanos[k]))) }
+		if col.kind == kindTimestamp && loc != nil {
+			appendValue = func(k int) { bldr.Append(arrow.Timestamp(wallNanos(timestampNanos(secs[k], nanos[k]), loc))) }
+		}
+	}
+
+	k := 0
+	for i := 0; i < n; i++ {
+		if valid != nil && !valid[i] {
+			b.AppendNull()
+			continue
+		}
+		appendValue(k)
+		k++
+	}
+	return b.NewArray(), nil
+}
+
+// readBinary reads n string or binary values, with the direct or the
+// dictionary encoding.
+func readBinary(enc columnEncoding, stream func(kind int) ([]byte, error), data []byte, n int, v2 bool) ([][]byte, error) {
+	lengths, err := stream(streamLength)
+	if err != nil {
+		return nil, err
+	}
+
+	switch enc.kind {
+	case encodingDirect, encodingDirectV2:
+		return splitBinary(data, lengths, n, v2)
+
+	case encodingDictionary, encodingDictionaryV2:
+		raw, err := stream(streamDictionaryData)
+		if err != nil {
+			return nil, err
+		}
+		dict, err := splitBinary(raw, lengths, enc.dictSize, v2)
+		if err != nil {
+			return nil, err
+		}
+		indices, err := decodeInts(data, n, v2, false)
+		if err != nil {
+			return nil, err
+		}
+		out := make([][]byte, n)
+		for i, idx := range indices {
+			if uint64(idx) >= uint64(len(dict)) {
+				return nil, fmt.Errorf("arrow/orc: dictionary index %d out of range [0, %d)", idx, len(dict))
+			}
+			out[i] = dict[idx]
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("arrow/orc: unsupported column encoding %d", enc.kind)
+}
+
+// splitBinary splits data in n values whose lengths are encoded in lengths.
+func splitBinary(data, lengths []byte, n int, v2 bool) ([][]byte, error) {
+	sizes, err := decodeInts(lengths, n, v2, false)
+	if err != nil {
+		return nil, err
+	}
+	out := make([][]byte, n)
+	for i, size := range sizes {
+		if uint64(size) > uint64(len(data)) {
+			return nil, errCorrupt
+		}
+		out[i] = data[:size:size]
+		data = data[size:]
+	}
+	return out, nil
+}
+
+// timestampNanos returns the nanoseconds since the Unix epoch of a timestamp
+// stored as seconds since the ORC epoch and encoded nanoseconds.
+//
+// Nanoseconds are stored without their trailing zeros: the 3 low bits hold
+// the number of zeros removed, minus one.
+func timestampNanos(secs, encoded int64) int64 {
+	nanos := int64(uint64(encoded) >> 3)
+	if zeros := encoded & 0x07; zeros != 0 {
+		for i := int64(0); i <= zeros; i++ {
+			nanos *= 10
+		}
+	}
+	// writers truncate timestamps before the Unix epoch with a fractional
+	// part toward zero.
+	unix := secs + orcEpoch
+	if unix < 0 && nanos > 999999 {
+		unix--
+	}
+	return unix*1e9 + nanos
+}
+
+// wallNanos returns the nanoseconds since the Unix epoch, in UTC, of the wall
+// clock time in loc of a timestamp written in loc.
+//
+// Writers store the seconds since the ORC epoch in their time zone, so nanos
+// is off by the offset of loc at the ORC epoch and its own offset is missing.
+func wallNanos(nanos int64, loc *time.Location) int64 {
+	_, base := time.Date(2015, time.January, 1, 0, 0, 0, 0, loc).Zone()
+	instant := nanos - int64(base)*1e9
+	_, off := time.Unix(0, instant).In(loc).Zone()
+	return instant + int64(off)*1e9
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (r *Reader) Retain() {
+	atomic.AddInt64(&r.refs, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+// Release may be called simultaneously from multiple goroutines.
+func (r *Reader) Release() {
+	debug.Assert(atomic.LoadInt64(&r.refs) > 0, "too many releases")
+
+	if atomic.AddInt64(&r.refs, -1) == 0 {
+		if r.cur != nil {
+			r.cur.Release()
+		}
+	}
+}
+
+var (
+	_ array.RecordReader = (*Reader)(nil)
+)
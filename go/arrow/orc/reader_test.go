@@ -0,0 +1,615 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/snappy"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+// pb encodes protocol buffers messages.
+type pb struct{ buf []byte }
+
+func (e *pb) key(id, typ int) { e.buf = binary.AppendUvarint(e.buf, uint64(id<<3|typ)) }
+
+func (e *pb) uint(id int, v uint64) *pb {
+	e.key(id, wireVarint)
+	e.buf = binary.AppendUvarint(e.buf, v)
+	return e
+}
+
+func (e *pb) sint(id int, v int64) *pb { return e.uint(id, uint64(v<<1)^uint64(v>>63)) }
+
+func (e *pb) bytes(id int, v []byte) *pb {
+	e.key(id, wireBytes)
+	e.buf = binary.AppendUvarint(e.buf, uint64(len(v)))
+	e.buf = append(e.buf, v...)
+	return e
+}
+
+func (e *pb) message(id int, m *pb) *pb { return e.bytes(id, m.buf) }
+
+// rleV1 encodes vals as literals of the version 1 of the integer run length
+// encoding.
+func rleV1(signed bool, vals ...int64) []byte {
+	var out []byte
+	for len(vals) > 0 {
+		n := len(vals)
+		if n > 128 {
+			n = 128
+		}
+		out = append(out, byte(-n))
+		for _, v := range vals[:n] {
+			u := uint64(v)
+			if signed {
+				u = uint64(v<<1) ^ uint64(v>>63)
+			}
+			out = binary.AppendUvarint(out, u)
+		}
+		vals = vals[n:]
+	}
+	return out
+}
+
+// rleV2 encodes vals as a run of 64-bit values of the version 2 of the
+// integer run length encoding.
+func rleV2(signed bool, vals ...int64) []byte {
+	out := []byte{0x40 | 31<<1 | byte((len(vals)-1)>>8), byte(len(vals) - 1)}
+	for _, v := range vals {
+		u := uint64(v)
+		if signed {
+			u = uint64(v<<1) ^ uint64(v>>63)
+		}
+		out = binary.BigEndian.AppendUint64(out, u)
+	}
+	return out
+}
+
+// byteRLE encodes vals as literals of the byte run length encoding.
+func byteRLE(vals ...byte) []byte {
+	var out []byte
+	for len(vals) > 0 {
+		n := len(vals)
+		if n > 128 {
+			n = 128
+		}
+		out = append(out, byte(-n))
+		out = append(out, vals[:n]...)
+		vals = vals[n:]
+	}
+	return out
+}
+
+func boolRLE(vals ...bool) []byte {
+	bytes := make([]byte, (len(vals)+7)/8)
+	for i, v := range vals {
+		if v {
+			bytes[i/8] |= 0x80 >> uint(i%8)
+		}
+	}
+	return byteRLE(bytes...)
+}
+
+func doubles(vals ...float64) []byte {
+	var out []byte
+	for _, v := range vals {
+		out = binary.LittleEndian.AppendUint64(out, math.Float64bits(v))
+	}
+	return out
+}
+
+// compress compresses buf in chunks of the given kind.
+func compress(t *testing.T, kind int, buf []byte) []byte {
+	t.Helper()
+
+	var body []byte
+	switch kind {
+	case compressionNone:
+		return buf
+	case compressionZlib:
+		var w bytes.Buffer
+		z, err := flate.NewWriter(&w, flate.BestCompression)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := z.Write(buf); err != nil {
+			t.Fatal(err)
+		}
+		if err := z.Close(); err != nil {
+			t.Fatal(err)
+		}
+		body = w.Bytes()
+	case compressionSnappy:
+		body = snappy.Encode(buf)
+	}
+	if len(body) >= len(buf) {
+		// store the chunk uncompressed.
+		return append([]byte{byte(len(buf)<<1 | 1), byte(len(buf) >> 7), byte(len(buf) >> 15)}, buf...)
+	}
+	return append([]byte{byte(len(body) << 1), byte(len(body) >> 7), byte(len(body) >> 15)}, body...)
+}
+
+type testStream struct {
+	column, kind int
+	data         []byte
+}
+
+type testStripe struct {
+	rows      int
+	streams   []testStream
+	encodings []int // encoding of each column
+	dictSize  map[int]int
+	stats     []*pb // statistics of each column
+}
+
+// testFile writes an ORC file of the given types and stripes.
+func testFile(t *testing.T, compression int, types []*pb, stripes []testStripe, kvs map[string]string) []byte {
+	t.Helper()
+
+	buf := []byte(magic)
+	var (
+		ft   pb
+		meta pb
+		rows uint64
+	)
+	ft.uint(1, uint64(len(magic)))
+	for _, s := range stripes {
+		var (
+			sf  pb
+			off = len(buf)
+		)
+		for _, st := range s.streams {
+			data := compress(t, compression, st.data)
+			buf = append(buf, data...)
+			sf.message(1, new(pb).uint(1, uint64(st.kind)).uint(2, uint64(st.column)).uint(3, uint64(len(data))))
+		}
+		for col, enc := range s.encodings {
+			ce := new(pb).uint(1, uint64(enc))
+			if n, ok := s.dictSize[col]; ok {
+				ce.uint(2, uint64(n))
+			}
+			sf.message(2, ce)
+		}
+		dataLength := len(buf) - off
+		footer := compress(t, compression, sf.buf)
+		buf = append(buf, footer...)
+		ft.message(3, new(pb).
+			uint(1, uint64(off)).
+			uint(2, 0).
+			uint(3, uint64(dataLength)).
+			uint(4, uint64(len(footer))).
+			uint(5, uint64(s.rows)))
+		rows += uint64(s.rows)
+
+		var ss pb
+		for _, st := range s.stats {
+			ss.message(1, st)
+		}
+		meta.message(1, &ss)
+	}
+	ft.uint(2, uint64(len(buf)-len(magic)))
+	for _, typ := range types {
+		ft.message(4, typ)
+	}
+	for k, v := range kvs {
+		ft.message(5, new(pb).bytes(1, []byte(k)).bytes(2, []byte(v)))
+	}
+	ft.uint(6, rows)
+
+	metadata := compress(t, compression, meta.buf)
+	buf = append(buf, metadata...)
+	footer := compress(t, compression, ft.buf)
+	buf = append(buf, footer...)
+
+	ps := new(pb).
+		uint(1, uint64(len(footer))).
+		uint(2, uint64(compression)).
+		uint(3, 256*1024).
+		uint(5, uint64(len(metadata))).
+		bytes(8000, []byte(magic))
+	buf = append(buf, ps.buf...)
+	return append(buf, byte(len(ps.buf)))
+}
+
+func structType(names ...string) *pb {
+	t := new(pb).uint(1, kindStruct)
+	for i := range names {
+		t.uint(2, uint64(i+1))
+	}
+	for _, name := range names {
+		t.bytes(3, []byte(name))
+	}
+	return t
+}
+
+func primitiveType(kind int) *pb { return new(pb).uint(1, uint64(kind)) }
+
+func intStats(n uint64, min, max int64) *pb {
+	return new(pb).uint(1, n).message(2, new(pb).sint(1, min).sint(2, max)).uint(10, 0)
+}
+
+func stringStats(n uint64, min, max string) *pb {
+	return new(pb).uint(1, n).message(4, new(pb).bytes(1, []byte(min)).bytes(2, []byte(max))).uint(10, 1)
+}
+
+// newTestFile returns a file with two stripes, holding rows [1, 3] and
+// [4, 5]. The first stripe uses the version 1 encodings, the second one the
+// version 2 encodings.
+func newTestFile(t *testing.T, compression int) []byte {
+	types := []*pb{
+		structType("id", "name", "score", "flag", "tiny", "day", "ts"),
+		primitiveType(kindLong),
+		primitiveType(kindString),
+		primitiveType(kindDouble),
+		primitiveType(kindBoolean),
+		primitiveType(kindByte),
+		primitiveType(kindDate),
+		primitiveType(kindTimestamp),
+	}
+	return testFile(t, compression, types, []testStripe{
+		{
+			rows: 3,
+			streams: []testStream{
+				{1, streamData, rleV1(true, 1, 2, 3)},
+				{2, streamPresent, boolRLE(true, false, true)},
+				{2, streamData, rleV1(false, 1, 0)},
+				{2, streamDictionaryData, []byte("alicebob")},
+				{2, streamLength, rleV1(false, 5, 3)},
+				{3, streamPresent, boolRLE(true, true, false)},
+				{3, streamData, doubles(1.5, 2.5)},
+				{4, streamData, boolRLE(true, false, true)},
+				{5, streamData, byteRLE(0xff, 0, 127)},
+				{6, streamData, rleV1(true, 0, 1, -1)},
+				{7, streamPresent, boolRLE(true, true, false)},
+				{7, streamData, rleV1(true, 0, -orcEpoch-1)},
+				{7, streamSecondary, rleV1(false, 5<<3|7, 0)},
+			},
+			encodings: []int{encodingDirect, encodingDirect, encodingDictionary, encodingDirect, encodingDirect, encodingDirect, encodingDirect, encodingDirect},
+			dictSize:  map[int]int{2: 2},
+			stats:     []*pb{new(pb).uint(1, 3), intStats(3, 1, 3), stringStats(2, "alice", "bob")},
+		},
+		{
+			rows: 2,
+			streams: []testStream{
+				{1, streamData, rleV2(true, 4, 5)},
+				{2, streamData, []byte("caroldave")},
+				{2, streamLength, rleV2(false, 5, 4)},
+				{3, streamPresent, boolRLE(false, false)},
+				{4, streamData, boolRLE(false, false)},
+				{5, streamData, byteRLE(1, 2)},
+				{6, streamData, rleV2(true, 10, 20)},
+				{7, streamPresent, boolRLE(false, false)},
+			},
+			encodings: []int{encodingDirect, encodingDirectV2, encodingDirectV2, encodingDirect, encodingDirect, encodingDirect, encodingDirectV2, encodingDirectV2},
+			stats:     []*pb{new(pb).uint(1, 2), intStats(2, 4, 5), stringStats(2, "carol", "dave")},
+		},
+	}, map[string]string{"origin": "test"})
+}
+
+func openTestFile(t *testing.T, raw []byte) *File {
+	t.Helper()
+	f, err := Open(bytes.NewReader(raw), int64(len(raw)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// readAll returns the rows of the records of r, one string per record.
+func readAll(t *testing.T, r *Reader) []string {
+	t.Helper()
+	var out []string
+	for r.Next() {
+		rec := r.Record()
+		rows := make([]string, rec.NumRows())
+		for i := range rows {
+			vals := make([]string, rec.NumCols())
+			for j, col := range rec.Columns() {
+				var v interface{}
+				switch col := col.(type) {
+				case *array.Int64:
+					v = col.Value(i)
+				case *array.String:
+					v = col.Value(i)
+				case *array.Float64:
+					v = col.Value(i)
+				case *array.Boolean:
+					v = col.Value(i)
+				case *array.Int8:
+					v = col.Value(i)
+				case *array.Date32:
+					v = col.Value(i)
+				case *array.Timestamp:
+					v = col.Value(i)
+				}
+				if col.IsNull(i) {
+					v = "null"
+				}
+				vals[j] = fmt.Sprintf("%s=%v", rec.ColumnName(j), v)
+			}
+			rows[i] = strings.Join(vals, " ")
+		}
+		out = append(out, strings.Join(rows, ", "))
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestReader(t *testing.T) {
+	md := arrow.NewMetadata([]string{"origin"}, []string{"test"})
+	want := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "flag", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+		{Name: "tiny", Type: arrow.PrimitiveTypes.Int8, Nullable: true},
+		{Name: "day", Type: arrow.PrimitiveTypes.Date32, Nullable: true},
+		{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Nanosecond}, Nullable: true},
+	}, &md)
+
+	for _, compression := range []int{compressionNone, compressionZlib, compressionSnappy} {
+		t.Run(compressionName(compression), func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			f := openTestFile(t, newTestFile(t, compression))
+			assert.Equal(t, int64(5), f.NumRows())
+			assert.Equal(t, 2, f.NumStripes())
+			if !f.Schema().Equal(want) {
+				t.Fatalf("invalid schema:\ngot= %v\nwant=%v", f.Schema(), want)
+			}
+
+			r, err := f.NewReader(WithAllocator(mem))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Release()
+
+			assert.Equal(t, []string{
+				"id=1 name=bob score=1.5 flag=true tiny=-1 day=0 ts=1420070400500000000, " +
+					"id=2 name=null score=2.5 flag=false tiny=0 day=1 ts=-1000000000, " +
+					"id=3 name=alice score=null flag=true tiny=127 day=-1 ts=null",
+				"id=4 name=carol score=null flag=false tiny=1 day=10 ts=null, " +
+					"id=5 name=dave score=null flag=false tiny=2 day=20 ts=null",
+			}, readAll(t, r))
+		})
+	}
+}
+
+func TestReaderProjection(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	f := openTestFile(t, newTestFile(t, compressionZlib))
+
+	r, err := f.NewReader(WithAllocator(mem), WithColumns("name", "id"), WithStripes(1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	assert.Equal(t, []string{
+		"name=carol id=4, name=dave id=5",
+		"name=bob id=1, name=null id=2, name=alice id=3",
+	}, readAll(t, r))
+
+	_, err = f.NewReader(WithColumns("id", "missing"))
+	assert.EqualError(t, err, `arrow/orc: no column named "missing"`)
+
+	_, err = f.NewReader(WithStripes(2))
+	assert.EqualError(t, err, "arrow/orc: stripe 2 out of range [0, 2)")
+}
+
+func TestReaderStripeFilter(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	f := openTestFile(t, newTestFile(t, compressionSnappy))
+
+	assert.Equal(t, Stripe{
+		Index:   0,
+		NumRows: 3,
+		Columns: []ColumnStatistics{
+			{Name: "id", NumValues: 3, HasMinMax: true, Min: int64(1), Max: int64(3)},
+			{Name: "name", NumValues: 2, HasNull: true, HasMinMax: true, Min: "alice", Max: "bob"},
+			{Name: "score"},
+			{Name: "flag"},
+			{Name: "tiny"},
+			{Name: "day"},
+			{Name: "ts"},
+		},
+	}, f.Stripe(0))
+
+	// select the stripes which may hold ids greater than 3.
+	r, err := f.NewReader(WithAllocator(mem), WithColumns("id"), WithStripeFilter(func(s Stripe) bool {
+		st := s.Columns[0]
+		return !st.HasMinMax || st.Max.(int64) > 3
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	assert.Equal(t, []string{"id=4, id=5"}, readAll(t, r))
+}
+
+func TestOpenErrors(t *testing.T) {
+	nested := testFile(t, compressionNone, []*pb{
+		structType("list"),
+		new(pb).uint(1, kindList).uint(2, 2),
+		primitiveType(kindInt),
+	}, nil, nil)
+
+	zstd := newTestFile(t, compressionNone)
+	ps := new(pb).uint(1, 0).uint(2, compressionZstd).bytes(8000, []byte(magic))
+	zstd = append(append(zstd[:len(zstd):len(zstd)], ps.buf...), byte(len(ps.buf)))
+
+	for _, tc := range []struct {
+		name string
+		raw  []byte
+		err  string
+	}{
+		{"empty", nil, "arrow/orc: file too small (0 bytes)"},
+		{"magic", []byte("ORC\x00\x00\x00"), `arrow/orc: invalid file magic ""`},
+		{"zstd", zstd, "arrow/orc: unsupported compression zstd"},
+		{"nested", nested, `arrow/orc: column "list": nested types are not supported`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Open(bytes.NewReader(tc.raw), int64(len(tc.raw)))
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestReaderCorrupt(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	// the data stream holds 2 values instead of 3.
+	f := openTestFile(t, testFile(t, compressionNone, []*pb{structType("id"), primitiveType(kindLong)}, []testStripe{{
+		rows:      3,
+		streams:   []testStream{{1, streamData, rleV1(true, 1, 2)}},
+		encodings: []int{encodingDirect, encodingDirect},
+	}}, nil))
+
+	r, err := f.NewReader(WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	assert.False(t, r.Next())
+	assert.EqualError(t, r.Err(), `arrow/orc: stripe 0, column "id": arrow/orc: corrupt stream data`)
+}
+
+// goldenDir holds ORC files written by the Java implementation, and the
+// JSON lines of their rows.
+const goldenDir = "../../../python/pyarrow/tests/data/orc"
+
+func openGoldenFile(t *testing.T, name string) (*File, error) {
+	t.Helper()
+	fh, err := os.Open(filepath.Join(goldenDir, name+".orc"))
+	if os.IsNotExist(err) {
+		t.Skipf("golden file %s not found", name)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { fh.Close() })
+
+	st, err := fh.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return Open(fh, st.Size())
+}
+
+func TestReaderGolden(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	f, err := openGoldenFile(t, "TestOrcFile.testDate1900")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(70000), f.NumRows())
+	assert.Equal(t, 8, f.NumStripes())
+
+	want := arrow.NewSchema([]arrow.Field{
+		{Name: "time", Type: &arrow.TimestampType{Unit: arrow.Nanosecond}, Nullable: true},
+		{Name: "date", Type: arrow.PrimitiveTypes.Date32, Nullable: true},
+	}, nil)
+	if !f.Schema().Equal(want) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", f.Schema(), want)
+	}
+
+	jf, err := os.Open(filepath.Join(goldenDir, "TestOrcFile.testDate1900.jsn.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer jf.Close()
+	zr, err := gzip.NewReader(jf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec := json.NewDecoder(zr)
+
+	r, err := f.NewReader(WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	row := 0
+	for r.Next() {
+		rec := r.Record()
+		ts := rec.Column(0).(*array.Timestamp)
+		days := rec.Column(1).(*array.Date32)
+		for i := 0; i < int(rec.NumRows()); i, row = i+1, row+1 {
+			var exp struct{ Time, Date string }
+			if err := dec.Decode(&exp); err != nil {
+				t.Fatalf("row %d: %v", row, err)
+			}
+			got := time.Unix(0, int64(ts.Value(i))).UTC().Format("2006-01-02 15:04:05.999999999")
+			day := time.Unix(int64(days.Value(i))*86400, 0).UTC().Format("2006-01-02")
+			if got != exp.Time || day != exp.Date {
+				t.Fatalf("row %d: got=%s %s, want=%s %s", row, got, day, exp.Time, exp.Date)
+			}
+		}
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 70000, row)
+	assert.False(t, dec.More(), "rows left in the JSON file")
+}
+
+// TestOpenGoldenUnsupported checks that the golden files with types the
+// reader does not support are rejected when opened.
+func TestOpenGoldenUnsupported(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		err  string
+	}{
+		{"TestOrcFile.test1", `arrow/orc: column "middle": nested types are not supported`},
+		{"TestOrcFile.emptyFile", `arrow/orc: column "middle": nested types are not supported`},
+		{"decimal", `arrow/orc: column "_col0": decimal values are not supported`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := openGoldenFile(t, tc.name)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+}
@@ -0,0 +1,376 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// The run length encodings of ORC streams.
+// See https://orc.apache.org/specification/ORCv1/
+
+var errCorrupt = errors.New("arrow/orc: corrupt stream data")
+
+// decodeBytes decodes n values of the byte run length encoding from buf.
+func decodeBytes(buf []byte, n int) ([]byte, error) {
+	out := make([]byte, 0, n)
+	for len(out) < n {
+		if len(buf) < 2 {
+			return nil, errCorrupt
+		}
+		h := int8(buf[0])
+		switch {
+		case h >= 0:
+			// run of h+3 repeated bytes.
+			for i := 0; i < int(h)+3 && len(out) < n; i++ {
+				out = append(out, buf[1])
+			}
+			buf = buf[2:]
+		default:
+			// -h literal bytes.
+			k := -int(h)
+			if len(buf) < 1+k {
+				return nil, errCorrupt
+			}
+			out = append(out, buf[1:1+k]...)
+			buf = buf[1+k:]
+		}
+	}
+	return out[:n], nil
+}
+
+// decodeBools decodes n booleans, stored as bytes of the byte run length
+// encoding with their most significant bit first, from buf.
+func decodeBools(buf []byte, n int) ([]bool, error) {
+	bytes, err := decodeBytes(buf, (n+7)/8)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]bool, n)
+	for i := range out {
+		out[i] = bytes[i/8]&(0x80>>uint(i%8)) != 0
+	}
+	return out, nil
+}
+
+// decodeInts decodes n integers of the version 1 or 2 of the integer run
+// length encoding from buf. Unsigned values hold the bits of uint64 values.
+func decodeInts(buf []byte, n int, v2, signed bool) ([]int64, error) {
+	if v2 {
+		return decodeIntsV2(buf, n, signed)
+	}
+	return decodeIntsV1(buf, n, signed)
+}
+
+// varint decodes a base 128 varint, zigzag encoded if signed.
+func varint(buf []byte, signed bool) (int64, int, error) {
+	v, k := binary.Uvarint(buf)
+	if k <= 0 {
+		return 0, 0, errCorrupt
+	}
+	if signed {
+		return zigzag(v), k, nil
+	}
+	return int64(v), k, nil
+}
+
+func decodeIntsV1(buf []byte, n int, signed bool) ([]int64, error) {
+	out := make([]int64, 0, n)
+	for len(out) < n {
+		if len(buf) < 1 {
+			return nil, errCorrupt
+		}
+		h := int8(buf[0])
+		buf = buf[1:]
+		switch {
+		case h >= 0:
+			// run of h+3 values, with a constant delta.
+			if len(buf) < 1 {
+				return nil, errCorrupt
+			}
+			delta := int64(int8(buf[0]))
+			base, k, err := varint(buf[1:], signed)
+			if err != nil {
+				return nil, err
+			}
+			buf = buf[1+k:]
+			for i := 0; i < int(h)+3 && len(out) < n; i++ {
+				out = append(out, base+int64(i)*delta)
+			}
+		default:
+			// -h literal values.
+			for i := 0; i < -int(h) && len(out) < n; i++ {
+				v, k, err := varint(buf, signed)
+				if err != nil {
+					return nil, err
+				}
+				buf = buf[k:]
+				out = append(out, v)
+			}
+		}
+	}
+	return out, nil
+}
+
+// sub-encodings of the version 2 of the integer run length encoding.
+const (
+	rleShortRepeat = 0
+	rleDirect      = 1
+	rlePatchedBase = 2
+	rleDelta       = 3
+)
+
+func decodeIntsV2(buf []byte, n int, signed bool) ([]int64, error) {
+	out := make([]int64, 0, n)
+	for len(out) < n {
+		if len(buf) < 1 {
+			return nil, errCorrupt
+		}
+		var (
+			vals []int64
+			k    int
+			err  error
+		)
+		switch buf[0] >> 6 {
+		case rleShortRepeat:
+			vals, k, err = shortRepeat(buf, signed)
+		case rleDirect:
+			vals, k, err = direct(buf, signed)
+		case rlePatchedBase:
+			vals, k, err = patchedBase(buf)
+		case rleDelta:
+			vals, k, err = delta(buf, signed)
+		}
+		if err != nil {
+			return nil, err
+		}
+		buf = buf[k:]
+		if len(vals) > n-len(out) {
+			vals = vals[:n-len(out)]
+		}
+		out = append(out, vals...)
+	}
+	return out, nil
+}
+
+// shortRepeat decodes a run of 3 to 10 repeated values.
+func shortRepeat(buf []byte, signed bool) ([]int64, int, error) {
+	width := int(buf[0]>>3&0x07) + 1
+	count := int(buf[0]&0x07) + 3
+	if len(buf) < 1+width {
+		return nil, 0, errCorrupt
+	}
+	var u uint64
+	for _, b := range buf[1 : 1+width] {
+		u = u<<8 | uint64(b)
+	}
+	v := int64(u)
+	if signed {
+		v = zigzag(u)
+	}
+	out := make([]int64, count)
+	for i := range out {
+		out[i] = v
+	}
+	return out, 1 + width, nil
+}
+
+// direct decodes a run of bit-packed values.
+func direct(buf []byte, signed bool) ([]int64, int, error) {
+	if len(buf) < 2 {
+		return nil, 0, errCorrupt
+	}
+	width := decodeWidth(int(buf[0] >> 1 & 0x1f))
+	length := (int(buf[0]&0x01)<<8 | int(buf[1])) + 1
+
+	out, k, err := unpack(buf[2:], length, width)
+	if err != nil {
+		return nil, 0, err
+	}
+	if signed {
+		for i, v := range out {
+			out[i] = zigzag(uint64(v))
+		}
+	}
+	return out, 2 + k, nil
+}
+
+// patchedBase decodes a run of bit-packed values relative to a base value,
+// whose highest bits are patched.
+func patchedBase(buf []byte) ([]int64, int, error) {
+	if len(buf) < 4 {
+		return nil, 0, errCorrupt
+	}
+	width := decodeWidth(int(buf[0] >> 1 & 0x1f))
+	length := (int(buf[0]&0x01)<<8 | int(buf[1])) + 1
+	baseWidth := int(buf[2]>>5&0x07) + 1
+	patchWidth := decodeWidth(int(buf[2] & 0x1f))
+	gapWidth := int(buf[3]>>5&0x07) + 1
+	npatches := int(buf[3] & 0x1f)
+	pos := 4
+
+	// the base value is in sign-magnitude representation.
+	if len(buf) < pos+baseWidth {
+		return nil, 0, errCorrupt
+	}
+	var u uint64
+	for _, b := range buf[pos : pos+baseWidth] {
+		u = u<<8 | uint64(b)
+	}
+	pos += baseWidth
+	mask := uint64(1) << uint(8*baseWidth-1)
+	base := int64(u &^ mask)
+	if u&mask != 0 {
+		base = -base
+	}
+
+	out, k, err := unpack(buf[pos:], length, width)
+	if err != nil {
+		return nil, 0, err
+	}
+	pos += k
+
+	patches, k, err := unpack(buf[pos:], npatches, closestWidth(gapWidth+patchWidth))
+	if err != nil {
+		return nil, 0, err
+	}
+	pos += k
+
+	if width+patchWidth > 64 {
+		return nil, 0, errCorrupt
+	}
+	i := 0
+	for _, p := range patches {
+		gap := int(uint64(p) >> uint(patchWidth))
+		patch := uint64(p) & (1<<uint(patchWidth) - 1)
+		i += gap
+		if gap == 255 && patch == 0 {
+			// gaps longer than 255 are split in several entries.
+			continue
+		}
+		if i >= length {
+			return nil, 0, errCorrupt
+		}
+		out[i] = int64(uint64(out[i]) | patch<<uint(width))
+	}
+	for i := range out {
+		out[i] += base
+	}
+	return out, pos, nil
+}
+
+// delta decodes a run of values from a base value and deltas.
+func delta(buf []byte, signed bool) ([]int64, int, error) {
+	if len(buf) < 2 {
+		return nil, 0, errCorrupt
+	}
+	width := 0
+	if code := int(buf[0] >> 1 & 0x1f); code != 0 {
+		width = decodeWidth(code)
+	}
+	length := (int(buf[0]&0x01)<<8 | int(buf[1])) + 1
+	pos := 2
+
+	base, k, err := varint(buf[pos:], signed)
+	if err != nil {
+		return nil, 0, err
+	}
+	pos += k
+	step, k, err := varint(buf[pos:], true)
+	if err != nil {
+		return nil, 0, err
+	}
+	pos += k
+
+	out := make([]int64, length)
+	out[0] = base
+	if length == 1 {
+		return out, pos, nil
+	}
+	out[1] = base + step
+	if width == 0 {
+		// fixed delta.
+		for i := 2; i < length; i++ {
+			out[i] = out[i-1] + step
+		}
+		return out, pos, nil
+	}
+
+	deltas, k, err := unpack(buf[pos:], length-2, width)
+	if err != nil {
+		return nil, 0, err
+	}
+	pos += k
+	for i, d := range deltas {
+		switch {
+		case step < 0:
+			out[i+2] = out[i+1] - d
+		default:
+			out[i+2] = out[i+1] + d
+		}
+	}
+	return out, pos, nil
+}
+
+// unpack decodes n values of width bits, packed with their most significant
+// bit first, from buf. It returns the values and the number of bytes read.
+func unpack(buf []byte, n, width int) ([]int64, int, error) {
+	size := (n*width + 7) / 8
+	if n < 0 || width > 64 || size > len(buf) {
+		return nil, 0, errCorrupt
+	}
+	out := make([]int64, n)
+	bit := 0
+	for i := range out {
+		var v uint64
+		for j := 0; j < width; j++ {
+			v <<= 1
+			if buf[bit/8]&(0x80>>uint(bit%8)) != 0 {
+				v |= 1
+			}
+			bit++
+		}
+		out[i] = int64(v)
+	}
+	return out, size, nil
+}
+
+// decodeWidth returns the bit width of the 5-bit width code.
+func decodeWidth(code int) int {
+	switch {
+	case code <= 23:
+		return code + 1
+	case code <= 27:
+		return 26 + 2*(code-24)
+	}
+	return 40 + 8*(code-28)
+}
+
+// closestWidth returns the smallest bit width of the version 2 encoding
+// greater than or equal to width.
+func closestWidth(width int) int {
+	switch {
+	case width <= 1:
+		return 1
+	case width <= 24:
+		return width
+	case width <= 32:
+		return (width + 1) &^ 1
+	}
+	return (width + 7) &^ 7
+}
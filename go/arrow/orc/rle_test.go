@@ -0,0 +1,129 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The examples of the ORC specification.
+
+func TestDecodeBytes(t *testing.T) {
+	got, err := decodeBytes([]byte{0x61, 0x00}, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, bytes.Repeat([]byte{0}, 100), got)
+
+	got, err = decodeBytes([]byte{0xfe, 0x44, 0x45}, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{0x44, 0x45}, got)
+
+	_, err = decodeBytes([]byte{0xfe, 0x44}, 2)
+	assert.Equal(t, errCorrupt, err)
+}
+
+func TestDecodeBools(t *testing.T) {
+	got, err := decodeBools([]byte{0xff, 0x80}, 8)
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{true, false, false, false, false, false, false, false}, got)
+}
+
+func TestDecodeIntsV1(t *testing.T) {
+	got, err := decodeInts([]byte{0x61, 0x00, 0x07}, 100, false, false)
+	assert.NoError(t, err)
+	want := make([]int64, 100)
+	for i := range want {
+		want[i] = 7
+	}
+	assert.Equal(t, want, got)
+
+	got, err = decodeInts([]byte{0x61, 0xff, 0x64}, 100, false, false)
+	assert.NoError(t, err)
+	for i := range want {
+		want[i] = int64(100 - i)
+	}
+	assert.Equal(t, want, got)
+
+	got, err = decodeInts([]byte{0xfb, 0x02, 0x03, 0x06, 0x07, 0xb}, 5, false, false)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2, 3, 6, 7, 11}, got)
+
+	got, err = decodeInts([]byte{0xfd, 0x03, 0x04, 0x80, 0x01}, 3, false, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{-2, 2, 64}, got)
+}
+
+func TestDecodeIntsV2(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		buf  []byte
+		want []int64
+	}{
+		{
+			name: "short repeat",
+			buf:  []byte{0x0a, 0x27, 0x10},
+			want: []int64{10000, 10000, 10000, 10000, 10000},
+		},
+		{
+			name: "direct",
+			buf:  []byte{0x5e, 0x03, 0x5c, 0xa1, 0xab, 0x1e, 0xde, 0xad, 0xbe, 0xef},
+			want: []int64{23713, 43806, 57005, 48879},
+		},
+		{
+			name: "patched base",
+			buf: []byte{
+				0x8e, 0x13, 0x2b, 0x21, 0x07, 0xd0, 0x1e, 0x00, 0x14, 0x70, 0x28, 0x32, 0x3c, 0x46,
+				0x50, 0x5a, 0x64, 0x6e, 0x78, 0x82, 0x8c, 0x96, 0xa0, 0xaa, 0xb4, 0xbe, 0xfc, 0xe8,
+			},
+			want: []int64{
+				2030, 2000, 2020, 1000000, 2040, 2050, 2060, 2070, 2080, 2090,
+				2100, 2110, 2120, 2130, 2140, 2150, 2160, 2170, 2180, 2190,
+			},
+		},
+		{
+			name: "delta",
+			buf:  []byte{0xc6, 0x09, 0x02, 0x02, 0x22, 0x42, 0x42, 0x46},
+			want: []int64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29},
+		},
+		{
+			name: "fixed delta",
+			buf:  []byte{0xc0, 0x04, 0x0a, 0x03},
+			want: []int64{10, 8, 6, 4, 2},
+		},
+		{
+			name: "runs",
+			buf:  []byte{0x0a, 0x27, 0x10, 0xc6, 0x09, 0x02, 0x02, 0x22, 0x42, 0x42, 0x46},
+			want: []int64{10000, 10000, 10000, 10000, 10000, 2, 3, 5, 7, 11, 13, 17, 19, 23, 29},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := decodeInts(tc.buf, len(tc.want), true, false)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+
+			_, err = decodeInts(tc.buf[:len(tc.buf)-1], len(tc.want), true, false)
+			assert.Equal(t, errCorrupt, err)
+		})
+	}
+
+	// signed values are zigzag encoded.
+	got, err := decodeInts([]byte{0x0a, 0x27, 0x0f}, 5, true, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{-5000, -5000, -5000, -5000, -5000}, got)
+}
@@ -0,0 +1,109 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package orc
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow"
+)
+
+// column describes a column of an ORC file.
+type column struct {
+	field arrow.Field
+	id    int // type id of the column
+	kind  int
+}
+
+// schemaOf returns the Arrow schema and the columns described by the footer
+// of an ORC file.
+func schemaOf(ft *footer) (*arrow.Schema, []column, error) {
+	if len(ft.types) == 0 {
+		return nil, nil, fmt.Errorf("arrow/orc: file has no schema")
+	}
+	root := ft.types[0]
+	if root.kind != kindStruct || len(root.subtypes) != len(root.fieldNames) {
+		return nil, nil, fmt.Errorf("arrow/orc: invalid root type")
+	}
+
+	fields := make([]arrow.Field, len(root.subtypes))
+	cols := make([]column, len(root.subtypes))
+	for i, id := range root.subtypes {
+		name := root.fieldNames[i]
+		if id == 0 || id >= uint64(len(ft.types)) {
+			return nil, nil, fmt.Errorf("arrow/orc: column %q: invalid type id %d", name, id)
+		}
+		kind := ft.types[id].kind
+		dt, err := dataTypeOf(kind)
+		if err != nil {
+			return nil, nil, fmt.Errorf("arrow/orc: column %q: %w", name, err)
+		}
+		// ORC columns are always nullable.
+		fields[i] = arrow.Field{Name: name, Type: dt, Nullable: true}
+		cols[i] = column{field: fields[i], id: int(id), kind: kind}
+	}
+
+	var meta *arrow.Metadata
+	if len(ft.metadata) > 0 {
+		keys := make([]string, len(ft.metadata))
+		vals := make([]string, len(ft.metadata))
+		for i, kv := range ft.metadata {
+			keys[i], vals[i] = kv.key, string(kv.value)
+		}
+		m := arrow.NewMetadata(keys, vals)
+		meta = &m
+	}
+	return arrow.NewSchema(fields, meta), cols, nil
+}
+
+// dataTypeOf returns the Arrow data type of the values of the ORC type kind.
+//
+// Timestamps are read as nanosecond timestamps: in the UTC time zone for
+// instants, without time zone otherwise.
+func dataTypeOf(kind int) (arrow.DataType, error) {
+	switch kind {
+	case kindBoolean:
+		return arrow.FixedWidthTypes.Boolean, nil
+	case kindByte:
+		return arrow.PrimitiveTypes.Int8, nil
+	case kindShort:
+		return arrow.PrimitiveTypes.Int16, nil
+	case kindInt:
+		return arrow.PrimitiveTypes.Int32, nil
+	case kindLong:
+		return arrow.PrimitiveTypes.Int64, nil
+	case kindFloat:
+		return arrow.PrimitiveTypes.Float32, nil
+	case kindDouble:
+		return arrow.PrimitiveTypes.Float64, nil
+	case kindString, kindVarchar, kindChar:
+		return arrow.BinaryTypes.String, nil
+	case kindBinary:
+		return arrow.BinaryTypes.Binary, nil
+	case kindDate:
+		return arrow.PrimitiveTypes.Date32, nil
+	case kindTimestamp:
+		return &arrow.TimestampType{Unit: arrow.Nanosecond}, nil
+	case kindTimestampInstant:
+		return &arrow.TimestampType{Unit: arrow.Nanosecond, TimeZone: "UTC"}, nil
+	case kindList, kindMap, kindStruct, kindUnion:
+		return nil, fmt.Errorf("nested types are not supported")
+	case kindDecimal:
+		return nil, fmt.Errorf("decimal values are not supported")
+	}
+	return nil, fmt.Errorf("unknown type kind %d", kind)
+}
@@ -0,0 +1,193 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package parquet reads and writes Parquet files as Arrow records.
+//
+// The package supports files with flat schemas, whose columns hold
+// required or optional values of a primitive type. Nested and repeated
+// columns, decimal values, and column chunks compressed with codecs other
+// than snappy and gzip are not supported, nor are the DELTA_* encodings.
+//
+// See https://parquet.apache.org/documentation/latest/ for a description of
+// the format.
+package parquet
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/metrics"
+)
+
+// Option configures a Parquet reader or writer.
+type Option func(config)
+type config interface{}
+
+// WithAllocator specifies the Arrow memory allocator used while building records.
+func WithAllocator(mem memory.Allocator) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.mem = mem
+		default:
+			panic(fmt.Errorf("arrow/parquet: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithColumns specifies the names of the columns to read, in the order of
+// the fields of the records. By default, all the columns are read.
+func WithColumns(names ...string) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.names = names
+		default:
+			panic(fmt.Errorf("arrow/parquet: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithRowGroups specifies the indices of the row groups to read.
+// By default, all the row groups are read.
+func WithRowGroups(indices ...int) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.groups = indices
+		default:
+			panic(fmt.Errorf("arrow/parquet: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithRowGroupFilter specifies a predicate selecting the row groups to read,
+// typically from the statistics of their column chunks. Row groups for which
+// keep returns false are skipped without being decoded.
+func WithRowGroupFilter(keep func(rg RowGroup) bool) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.filter = keep
+		default:
+			panic(fmt.Errorf("arrow/parquet: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithAllocationLimit bounds the memory allocated by the reader from the
+// sizes declared by the file, for reading untrusted files: column chunks and
+// pages whose declared size exceeds n bytes, and row groups of more than n
+// rows, fail with an error instead of being allocated.
+// By default, the sizes are only checked against the size of the file.
+func WithAllocationLimit(n int64) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			if n <= 0 {
+				panic(fmt.Errorf("arrow/parquet: invalid allocation limit %d", n))
+			}
+			cfg.limit = n
+		default:
+			panic(fmt.Errorf("arrow/parquet: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithRowGroupSize specifies the maximum number of rows of the row groups
+// written to the file. The default is 1Mi rows.
+func WithRowGroupSize(n int64) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			if n <= 0 {
+				panic(fmt.Errorf("arrow/parquet: invalid row group size %d", n))
+			}
+			cfg.rowGroupSize = n
+		default:
+			panic(fmt.Errorf("arrow/parquet: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithCompression specifies the codec compressing the pages of the columns
+// written to the file. The default is Snappy.
+func WithCompression(codec Codec) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			cfg.codec = codec
+		default:
+			panic(fmt.Errorf("arrow/parquet: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithColumnCompression specifies the codec compressing the pages of the
+// named column, overriding WithCompression.
+func WithColumnCompression(name string, codec Codec) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			cfg.codecs[name] = codec
+		default:
+			panic(fmt.Errorf("arrow/parquet: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithDictionary specifies whether the columns written to the file are
+// dictionary encoded. The default is true.
+//
+// Boolean columns are never dictionary encoded, and column chunks whose
+// dictionary would exceed 1MiB are PLAIN encoded.
+func WithDictionary(enabled bool) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			cfg.dict = enabled
+		default:
+			panic(fmt.Errorf("arrow/parquet: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithColumnDictionary specifies whether the named column is dictionary
+// encoded, overriding WithDictionary.
+func WithColumnDictionary(name string, enabled bool) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			cfg.dicts[name] = enabled
+		default:
+			panic(fmt.Errorf("arrow/parquet: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithRecorder specifies a recorder receiving the sizes of the pages written
+// to the file, before and after compression, as the metrics.UncompressedBytes
+// and metrics.CompressedBytes counters.
+func WithRecorder(r metrics.Recorder) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			cfg.rec = r
+		default:
+			panic(fmt.Errorf("arrow/parquet: unknown config type %T", cfg))
+		}
+	}
+}
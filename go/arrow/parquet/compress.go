@@ -0,0 +1,119 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/apache/arrow/go/arrow/internal/snappy"
+)
+
+// Codec is a compression codec of the column chunks of a Parquet file.
+type Codec int32
+
+const (
+	Uncompressed Codec = 0
+	Snappy       Codec = 1
+	Gzip         Codec = 2
+	LZO          Codec = 3
+	Brotli       Codec = 4
+	LZ4          Codec = 5
+	Zstd         Codec = 6
+)
+
+func (c Codec) String() string {
+	switch c {
+	case Uncompressed:
+		return "uncompressed"
+	case Snappy:
+		return "snappy"
+	case Gzip:
+		return "gzip"
+	case LZO:
+		return "lzo"
+	case Brotli:
+		return "brotli"
+	case LZ4:
+		return "lz4"
+	case Zstd:
+		return "zstd"
+	}
+	return fmt.Sprintf("Codec(%d)", int32(c))
+}
+
+// decompress returns the uncompressed content of buf, of the given size.
+func decompress(codec Codec, buf []byte, size int) ([]byte, error) {
+	out, err := decompressBlock(codec, buf, size)
+	if err != nil {
+		return nil, err
+	}
+	if len(out) != size {
+		return nil, fmt.Errorf("arrow/parquet: invalid uncompressed page size %d, want %d", len(out), size)
+	}
+	return out, nil
+}
+
+// decompressBlock decompresses buf with codec. It does not decompress more
+// than size+1 bytes, so that the size of the page can be checked without
+// allocating more than its declared size.
+func decompressBlock(codec Codec, buf []byte, size int) ([]byte, error) {
+	switch codec {
+	case Uncompressed:
+		return buf, nil
+	case Snappy:
+		if n, err := snappy.DecodedLen(buf); err != nil || n > size {
+			return nil, fmt.Errorf("arrow/parquet: invalid uncompressed page size %d, want %d", n, size)
+		}
+		return snappy.Decode(buf)
+	case Gzip:
+		r, err := gzip.NewReader(bytes.NewReader(buf))
+		if err != nil {
+			return nil, fmt.Errorf("arrow/parquet: could not decompress gzip page: %w", err)
+		}
+		out, err := ioutil.ReadAll(io.LimitReader(r, int64(size)+1))
+		if err != nil {
+			return nil, fmt.Errorf("arrow/parquet: could not decompress gzip page: %w", err)
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("arrow/parquet: unsupported compression codec %v", codec)
+}
+
+// compress compresses buf with codec.
+func compress(codec Codec, buf []byte) ([]byte, error) {
+	switch codec {
+	case Uncompressed:
+		return buf, nil
+	case Snappy:
+		return snappy.Encode(buf), nil
+	case Gzip:
+		var out bytes.Buffer
+		w := gzip.NewWriter(&out)
+		if _, err := w.Write(buf); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return out.Bytes(), nil
+	}
+	return nil, fmt.Errorf("arrow/parquet: unsupported compression codec %v", codec)
+}
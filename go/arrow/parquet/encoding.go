@@ -0,0 +1,377 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var errCorrupt = errors.New("arrow/parquet: corrupt page data")
+
+// values holds decoded values of a single physical type.
+// Only the field matching the physical type is set.
+type values struct {
+	bools   []bool
+	int32s  []int32
+	int64s  []int64
+	int96s  [][12]byte
+	floats  []float32
+	doubles []float64
+	bytes   [][]byte // byte arrays and fixed length byte arrays
+}
+
+func (v *values) len() int {
+	switch {
+	case v.bools != nil:
+		return len(v.bools)
+	case v.int32s != nil:
+		return len(v.int32s)
+	case v.int64s != nil:
+		return len(v.int64s)
+	case v.int96s != nil:
+		return len(v.int96s)
+	case v.floats != nil:
+		return len(v.floats)
+	case v.doubles != nil:
+		return len(v.doubles)
+	}
+	return len(v.bytes)
+}
+
+// take returns the values at the given indices, as used by dictionary pages.
+func (v *values) take(indices []uint32) (values, error) {
+	n := uint32(v.len())
+	for _, i := range indices {
+		if i >= n {
+			return values{}, fmt.Errorf("arrow/parquet: dictionary index %d out of range [0, %d)", i, n)
+		}
+	}
+
+	var out values
+	switch {
+	case v.bools != nil:
+		out.bools = make([]bool, len(indices))
+		for k, i := range indices {
+			out.bools[k] = v.bools[i]
+		}
+	case v.int32s != nil:
+		out.int32s = make([]int32, len(indices))
+		for k, i := range indices {
+			out.int32s[k] = v.int32s[i]
+		}
+	case v.int64s != nil:
+		out.int64s = make([]int64, len(indices))
+		for k, i := range indices {
+			out.int64s[k] = v.int64s[i]
+		}
+	case v.int96s != nil:
+		out.int96s = make([][12]byte, len(indices))
+		for k, i := range indices {
+			out.int96s[k] = v.int96s[i]
+		}
+	case v.floats != nil:
+		out.floats = make([]float32, len(indices))
+		for k, i := range indices {
+			out.floats[k] = v.floats[i]
+		}
+	case v.doubles != nil:
+		out.doubles = make([]float64, len(indices))
+		for k, i := range indices {
+			out.doubles[k] = v.doubles[i]
+		}
+	default:
+		out.bytes = make([][]byte, len(indices))
+		for k, i := range indices {
+			out.bytes[k] = v.bytes[i]
+		}
+	}
+	return out, nil
+}
+
+// decodePlain decodes n PLAIN encoded values of physical type typ from buf.
+// Decoded byte arrays share memory with buf.
+func decodePlain(typ int32, typeLength int, buf []byte, n int) (values, error) {
+	var out values
+	need := func(size int) error {
+		if n < 0 || size > len(buf) {
+			return errCorrupt
+		}
+		return nil
+	}
+
+	switch typ {
+	case typeBoolean:
+		if err := need((n + 7) / 8); err != nil {
+			return out, err
+		}
+		out.bools = make([]bool, n)
+		for i := range out.bools {
+			out.bools[i] = buf[i/8]&(1<<uint(i%8)) != 0
+		}
+	case typeInt32:
+		if err := need(4 * n); err != nil {
+			return out, err
+		}
+		out.int32s = make([]int32, n)
+		for i := range out.int32s {
+			out.int32s[i] = int32(binary.LittleEndian.Uint32(buf[4*i:]))
+		}
+	case typeInt64:
+		if err := need(8 * n); err != nil {
+			return out, err
+		}
+		out.int64s = make([]int64, n)
+		for i := range out.int64s {
+			out.int64s[i] = int64(binary.LittleEndian.Uint64(buf[8*i:]))
+		}
+	case typeInt96:
+		if err := need(12 * n); err != nil {
+			return out, err
+		}
+		out.int96s = make([][12]byte, n)
+		for i := range out.int96s {
+			copy(out.int96s[i][:], buf[12*i:])
+		}
+	case typeFloat:
+		if err := need(4 * n); err != nil {
+			return out, err
+		}
+		out.floats = make([]float32, n)
+		for i := range out.floats {
+			out.floats[i] = math.Float32frombits(binary.LittleEndian.Uint32(buf[4*i:]))
+		}
+	case typeDouble:
+		if err := need(8 * n); err != nil {
+			return out, err
+		}
+		out.doubles = make([]float64, n)
+		for i := range out.doubles {
+			out.doubles[i] = math.Float64frombits(binary.LittleEndian.Uint64(buf[8*i:]))
+		}
+	case typeByteArray:
+		if err := need(4 * n); err != nil { // each value has a 4-byte length
+			return out, err
+		}
+		out.bytes = make([][]byte, 0, n)
+		for i := 0; i < n; i++ {
+			if len(buf) < 4 {
+				return out, errCorrupt
+			}
+			size := binary.LittleEndian.Uint32(buf)
+			if uint64(size) > uint64(len(buf)-4) {
+				return out, errCorrupt
+			}
+			out.bytes = append(out.bytes, buf[4:4+size:4+size])
+			buf = buf[4+size:]
+		}
+	case typeFixedLenByteArray:
+		if typeLength <= 0 {
+			return out, errCorrupt
+		}
+		if err := need(typeLength * n); err != nil {
+			return out, err
+		}
+		out.bytes = make([][]byte, n)
+		for i := range out.bytes {
+			out.bytes[i] = buf[i*typeLength : (i+1)*typeLength : (i+1)*typeLength]
+		}
+	default:
+		return out, fmt.Errorf("arrow/parquet: unknown physical type %d", typ)
+	}
+	return out, nil
+}
+
+// decodeHybrid decodes n values of the RLE/bit-packing hybrid encoding,
+// of width bitWidth, from buf.
+func decodeHybrid(buf []byte, bitWidth, n int) ([]uint32, error) {
+	if bitWidth < 0 || bitWidth > 32 {
+		return nil, errCorrupt
+	}
+
+	out := make([]uint32, 0, n)
+	byteWidth := (bitWidth + 7) / 8
+	for len(out) < n {
+		header, k := binary.Uvarint(buf)
+		if k <= 0 {
+			return nil, errCorrupt
+		}
+		buf = buf[k:]
+
+		if header&1 == 0 {
+			// RLE run: a repeated value.
+			count := header >> 1
+			if len(buf) < byteWidth {
+				return nil, errCorrupt
+			}
+			var v uint32
+			for i := 0; i < byteWidth; i++ {
+				v |= uint32(buf[i]) << (8 * uint(i))
+			}
+			buf = buf[byteWidth:]
+			for i := uint64(0); i < count && len(out) < n; i++ {
+				out = append(out, v)
+			}
+			continue
+		}
+
+		// bit-packed run: groups of 8 values.
+		groups := header >> 1
+		if groups > uint64(len(buf)) {
+			return nil, errCorrupt
+		}
+		size := int(groups) * bitWidth
+		if size > len(buf) {
+			return nil, errCorrupt
+		}
+		packed := buf[:size]
+		buf = buf[size:]
+		for i := 0; i < int(groups)*8 && len(out) < n; i++ {
+			out = append(out, unpackBits(packed, i*bitWidth, bitWidth))
+		}
+	}
+	return out, nil
+}
+
+// unpackBits returns the width bits of buf starting at bit offset,
+// in least significant bit order.
+func unpackBits(buf []byte, offset, width int) uint32 {
+	var v uint32
+	for i := 0; i < width; i++ {
+		bit := offset + i
+		if buf[bit/8]&(1<<uint(bit%8)) != 0 {
+			v |= 1 << uint(i)
+		}
+	}
+	return v
+}
+
+// bitWidth returns the number of bits needed to represent v.
+func bitWidth(v int) int {
+	n := 0
+	for v > 0 {
+		n++
+		v >>= 1
+	}
+	return n
+}
+
+// encodePlain appends the PLAIN encoding of the values v of physical type typ
+// to dst.
+func encodePlain(dst []byte, typ int32, v *values) []byte {
+	switch typ {
+	case typeBoolean:
+		start := len(dst)
+		dst = append(dst, make([]byte, (len(v.bools)+7)/8)...)
+		for i, b := range v.bools {
+			if b {
+				dst[start+i/8] |= 1 << uint(i%8)
+			}
+		}
+	case typeInt32:
+		for _, x := range v.int32s {
+			dst = binary.LittleEndian.AppendUint32(dst, uint32(x))
+		}
+	case typeInt64:
+		for _, x := range v.int64s {
+			dst = binary.LittleEndian.AppendUint64(dst, uint64(x))
+		}
+	case typeInt96:
+		for _, x := range v.int96s {
+			dst = append(dst, x[:]...)
+		}
+	case typeFloat:
+		for _, x := range v.floats {
+			dst = binary.LittleEndian.AppendUint32(dst, math.Float32bits(x))
+		}
+	case typeDouble:
+		for _, x := range v.doubles {
+			dst = binary.LittleEndian.AppendUint64(dst, math.Float64bits(x))
+		}
+	case typeByteArray:
+		for _, x := range v.bytes {
+			dst = binary.LittleEndian.AppendUint32(dst, uint32(len(x)))
+			dst = append(dst, x...)
+		}
+	case typeFixedLenByteArray:
+		for _, x := range v.bytes {
+			dst = append(dst, x...)
+		}
+	}
+	return dst
+}
+
+// encodeHybrid appends the RLE/bit-packing hybrid encoding of vals, of width
+// bitWidth, to dst.
+// Runs of at least 8 repeated values are RLE encoded, others are bit-packed.
+func encodeHybrid(dst []byte, vals []uint32, bitWidth int) []byte {
+	const minRun = 8
+
+	runAt := func(i int) int {
+		j := i + 1
+		for j < len(vals) && vals[j] == vals[i] {
+			j++
+		}
+		return j - i
+	}
+
+	byteWidth := (bitWidth + 7) / 8
+	for i := 0; i < len(vals); {
+		if n := runAt(i); n >= minRun {
+			dst = binary.AppendUvarint(dst, uint64(n)<<1)
+			for k := 0; k < byteWidth; k++ {
+				dst = append(dst, byte(vals[i]>>(8*uint(k))))
+			}
+			i += n
+			continue
+		}
+
+		// bit-pack groups of 8 values, up to the start of the next run.
+		j := i
+		for j < len(vals) {
+			j += 8
+			if j < len(vals) && runAt(j) >= minRun {
+				break
+			}
+		}
+		if j > len(vals) {
+			j = len(vals)
+		}
+		groups := (j - i + 7) / 8
+		dst = binary.AppendUvarint(dst, uint64(groups)<<1|1)
+		start := len(dst)
+		dst = append(dst, make([]byte, groups*bitWidth)...)
+		for k, v := range vals[i:j] {
+			packBits(dst[start:], k*bitWidth, bitWidth, v)
+		}
+		i = j
+	}
+	return dst
+}
+
+// packBits sets the width bits of buf starting at bit offset to v,
+// in least significant bit order. The bits must be zero.
+func packBits(buf []byte, offset, width int, v uint32) {
+	for i := 0; i < width; i++ {
+		if v&(1<<uint(i)) != 0 {
+			bit := offset + i
+			buf[bit/8] |= 1 << uint(bit%8)
+		}
+	}
+}
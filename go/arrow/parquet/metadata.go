@@ -0,0 +1,708 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+// The Parquet file metadata, as described by
+// https://github.com/apache/parquet-format/blob/master/src/main/thrift/parquet.thrift
+//
+// Only the parts of the metadata used by this package are decoded; other
+// fields are skipped.
+
+// physical types.
+const (
+	typeBoolean int32 = iota
+	typeInt32
+	typeInt64
+	typeInt96
+	typeFloat
+	typeDouble
+	typeByteArray
+	typeFixedLenByteArray
+)
+
+// field repetitions.
+const (
+	repetitionRequired int32 = iota
+	repetitionOptional
+	repetitionRepeated
+)
+
+// converted types.
+const (
+	convertedNone            int32 = -1
+	convertedUTF8            int32 = 0
+	convertedEnum            int32 = 4
+	convertedDecimal         int32 = 5
+	convertedDate            int32 = 6
+	convertedTimeMillis      int32 = 7
+	convertedTimeMicros      int32 = 8
+	convertedTimestampMillis int32 = 9
+	convertedTimestampMicros int32 = 10
+	convertedUint8           int32 = 11
+	convertedUint16          int32 = 12
+	convertedUint32          int32 = 13
+	convertedUint64          int32 = 14
+	convertedInt8            int32 = 15
+	convertedInt16           int32 = 16
+	convertedInt32           int32 = 17
+	convertedInt64           int32 = 18
+	convertedJSON            int32 = 19
+)
+
+// logical types, identified by their field in the LogicalType union.
+const (
+	logicalNone      int16 = 0
+	logicalString    int16 = 1
+	logicalEnum      int16 = 4
+	logicalDecimal   int16 = 5
+	logicalDate      int16 = 6
+	logicalTime      int16 = 7
+	logicalTimestamp int16 = 8
+	logicalInteger   int16 = 10
+	logicalJSON      int16 = 12
+)
+
+// time units, identified by their field in the TimeUnit union.
+const (
+	unitMillis int16 = 1
+	unitMicros int16 = 2
+	unitNanos  int16 = 3
+)
+
+// encodings.
+const (
+	encodingPlain           int32 = 0
+	encodingPlainDictionary int32 = 2
+	encodingRLE             int32 = 3
+	encodingBitPacked       int32 = 4
+	encodingRLEDictionary   int32 = 8
+)
+
+// page types.
+const (
+	pageData       int32 = 0
+	pageIndex      int32 = 1
+	pageDictionary int32 = 2
+	pageDataV2     int32 = 3
+)
+
+type fileMetaData struct {
+	version   int32
+	schema    []schemaElement
+	numRows   int64
+	rowGroups []rowGroup
+	keyValues []keyValue
+	createdBy string
+}
+
+type schemaElement struct {
+	typ         int32 // physical type, -1 for groups
+	typeLength  int32
+	repetition  int32
+	name        string
+	numChildren int32
+	converted   int32
+	logical     logicalType
+}
+
+type logicalType struct {
+	kind      int16
+	unit      int16 // time and timestamp unit
+	utc       bool  // whether timestamps are adjusted to UTC
+	bitWidth  int8  // integer width
+	signed    bool  // integer signedness
+	scale     int32 // decimal scale
+	precision int32 // decimal precision
+}
+
+type keyValue struct {
+	key   string
+	value string
+}
+
+type rowGroup struct {
+	columns       []columnChunk
+	totalByteSize int64
+	numRows       int64
+}
+
+type columnChunk struct {
+	filePath   string
+	fileOffset int64
+	meta       columnMetaData
+}
+
+type columnMetaData struct {
+	typ               int32
+	encodings         []int32
+	path              []string
+	codec             int32
+	numValues         int64
+	uncompressedSize  int64
+	compressedSize    int64
+	dataPageOffset    int64
+	dictPageOffset    int64
+	hasDictPageOffset bool
+	stats             *statistics
+}
+
+type statistics struct {
+	max, min         []byte // deprecated, signed comparison only
+	nullCount        int64
+	hasNullCount     bool
+	maxValue         []byte
+	minValue         []byte
+	hasMaxValue      bool
+	hasMinValue      bool
+	hasDeprecatedMax bool
+	hasDeprecatedMin bool
+}
+
+type pageHeader struct {
+	typ              int32
+	uncompressedSize int32
+	compressedSize   int32
+	data             *dataPageHeader
+	dict             *dictionaryPageHeader
+	dataV2           *dataPageHeaderV2
+}
+
+type dataPageHeader struct {
+	numValues   int32
+	encoding    int32
+	defEncoding int32
+	repEncoding int32
+}
+
+type dictionaryPageHeader struct {
+	numValues int32
+	encoding  int32
+	sorted    bool
+}
+
+type dataPageHeaderV2 struct {
+	numValues    int32
+	numNulls     int32
+	numRows      int32
+	encoding     int32
+	defLength    int32
+	repLength    int32
+	isCompressed bool
+}
+
+func (d *decoder) fileMetaData() *fileMetaData {
+	md := &fileMetaData{}
+	d.fields(func(id int16, typ byte) {
+		switch {
+		case id == 1 && typ == tI32:
+			md.version = d.i32()
+		case id == 2 && typ == tList:
+			_, n := d.list()
+			md.schema = make([]schemaElement, n)
+			for i := range md.schema {
+				md.schema[i] = d.schemaElement()
+			}
+		case id == 3 && typ == tI64:
+			md.numRows = d.i64()
+		case id == 4 && typ == tList:
+			_, n := d.list()
+			md.rowGroups = make([]rowGroup, n)
+			for i := range md.rowGroups {
+				md.rowGroups[i] = d.rowGroup()
+			}
+		case id == 5 && typ == tList:
+			_, n := d.list()
+			md.keyValues = make([]keyValue, n)
+			for i := range md.keyValues {
+				md.keyValues[i] = d.keyValue()
+			}
+		case id == 6 && typ == tBinary:
+			md.createdBy = d.string()
+		default:
+			d.skip(typ, false)
+		}
+	})
+	return md
+}
+
+func (d *decoder) schemaElement() schemaElement {
+	se := schemaElement{typ: -1, converted: convertedNone}
+	d.fields(func(id int16, typ byte) {
+		switch {
+		case id == 1 && typ == tI32:
+			se.typ = d.i32()
+		case id == 2 && typ == tI32:
+			se.typeLength = d.i32()
+		case id == 3 && typ == tI32:
+			se.repetition = d.i32()
+		case id == 4 && typ == tBinary:
+			se.name = d.string()
+		case id == 5 && typ == tI32:
+			se.numChildren = d.i32()
+		case id == 6 && typ == tI32:
+			se.converted = d.i32()
+		case id == 7 && typ == tI32:
+			se.logical.scale = d.i32()
+		case id == 8 && typ == tI32:
+			se.logical.precision = d.i32()
+		case id == 10 && typ == tStruct:
+			d.logicalType(&se.logical)
+		default:
+			d.skip(typ, false)
+		}
+	})
+	return se
+}
+
+func (d *decoder) logicalType(lt *logicalType) {
+	d.fields(func(id int16, typ byte) {
+		if typ != tStruct {
+			d.skip(typ, false)
+			return
+		}
+		lt.kind = id
+		switch id {
+		case logicalDecimal:
+			d.fields(func(id int16, typ byte) {
+				switch {
+				case id == 1 && typ == tI32:
+					lt.scale = d.i32()
+				case id == 2 && typ == tI32:
+					lt.precision = d.i32()
+				default:
+					d.skip(typ, false)
+				}
+			})
+		case logicalTime, logicalTimestamp:
+			d.fields(func(id int16, typ byte) {
+				switch {
+				case id == 1 && (typ == tTrue || typ == tFalse):
+					lt.utc = d.bool(typ)
+				case id == 2 && typ == tStruct:
+					d.fields(func(id int16, typ byte) {
+						lt.unit = id
+						d.skip(typ, false)
+					})
+				default:
+					d.skip(typ, false)
+				}
+			})
+		case logicalInteger:
+			d.fields(func(id int16, typ byte) {
+				switch {
+				case id == 1 && typ == tByte:
+					lt.bitWidth = int8(d.byte())
+				case id == 2 && (typ == tTrue || typ == tFalse):
+					lt.signed = d.bool(typ)
+				default:
+					d.skip(typ, false)
+				}
+			})
+		default:
+			d.skip(typ, false)
+		}
+	})
+}
+
+func (d *decoder) keyValue() keyValue {
+	var kv keyValue
+	d.fields(func(id int16, typ byte) {
+		switch {
+		case id == 1 && typ == tBinary:
+			kv.key = d.string()
+		case id == 2 && typ == tBinary:
+			kv.value = d.string()
+		default:
+			d.skip(typ, false)
+		}
+	})
+	return kv
+}
+
+func (d *decoder) rowGroup() rowGroup {
+	var rg rowGroup
+	d.fields(func(id int16, typ byte) {
+		switch {
+		case id == 1 && typ == tList:
+			_, n := d.list()
+			rg.columns = make([]columnChunk, n)
+			for i := range rg.columns {
+				rg.columns[i] = d.columnChunk()
+			}
+		case id == 2 && typ == tI64:
+			rg.totalByteSize = d.i64()
+		case id == 3 && typ == tI64:
+			rg.numRows = d.i64()
+		default:
+			d.skip(typ, false)
+		}
+	})
+	return rg
+}
+
+func (d *decoder) columnChunk() columnChunk {
+	var cc columnChunk
+	d.fields(func(id int16, typ byte) {
+		switch {
+		case id == 1 && typ == tBinary:
+			cc.filePath = d.string()
+		case id == 2 && typ == tI64:
+			cc.fileOffset = d.i64()
+		case id == 3 && typ == tStruct:
+			cc.meta = d.columnMetaData()
+		default:
+			d.skip(typ, false)
+		}
+	})
+	return cc
+}
+
+func (d *decoder) columnMetaData() columnMetaData {
+	var md columnMetaData
+	d.fields(func(id int16, typ byte) {
+		switch {
+		case id == 1 && typ == tI32:
+			md.typ = d.i32()
+		case id == 2 && typ == tList:
+			_, n := d.list()
+			md.encodings = make([]int32, n)
+			for i := range md.encodings {
+				md.encodings[i] = d.i32()
+			}
+		case id == 3 && typ == tList:
+			_, n := d.list()
+			md.path = make([]string, n)
+			for i := range md.path {
+				md.path[i] = d.string()
+			}
+		case id == 4 && typ == tI32:
+			md.codec = d.i32()
+		case id == 5 && typ == tI64:
+			md.numValues = d.i64()
+		case id == 6 && typ == tI64:
+			md.uncompressedSize = d.i64()
+		case id == 7 && typ == tI64:
+			md.compressedSize = d.i64()
+		case id == 9 && typ == tI64:
+			md.dataPageOffset = d.i64()
+		case id == 11 && typ == tI64:
+			md.dictPageOffset = d.i64()
+			md.hasDictPageOffset = true
+		case id == 12 && typ == tStruct:
+			md.stats = d.statistics()
+		default:
+			d.skip(typ, false)
+		}
+	})
+	return md
+}
+
+func (d *decoder) statistics() *statistics {
+	st := &statistics{}
+	d.fields(func(id int16, typ byte) {
+		switch {
+		case id == 1 && typ == tBinary:
+			st.max, st.hasDeprecatedMax = d.binary(), true
+		case id == 2 && typ == tBinary:
+			st.min, st.hasDeprecatedMin = d.binary(), true
+		case id == 3 && typ == tI64:
+			st.nullCount, st.hasNullCount = d.i64(), true
+		case id == 5 && typ == tBinary:
+			st.maxValue, st.hasMaxValue = d.binary(), true
+		case id == 6 && typ == tBinary:
+			st.minValue, st.hasMinValue = d.binary(), true
+		default:
+			d.skip(typ, false)
+		}
+	})
+	return st
+}
+
+func (d *decoder) pageHeader() *pageHeader {
+	ph := &pageHeader{}
+	d.fields(func(id int16, typ byte) {
+		switch {
+		case id == 1 && typ == tI32:
+			ph.typ = d.i32()
+		case id == 2 && typ == tI32:
+			ph.uncompressedSize = d.i32()
+		case id == 3 && typ == tI32:
+			ph.compressedSize = d.i32()
+		case id == 5 && typ == tStruct:
+			ph.data = &dataPageHeader{}
+			d.fields(func(id int16, typ byte) {
+				switch {
+				case id == 1 && typ == tI32:
+					ph.data.numValues = d.i32()
+				case id == 2 && typ == tI32:
+					ph.data.encoding = d.i32()
+				case id == 3 && typ == tI32:
+					ph.data.defEncoding = d.i32()
+				case id == 4 && typ == tI32:
+					ph.data.repEncoding = d.i32()
+				default:
+					d.skip(typ, false)
+				}
+			})
+		case id == 7 && typ == tStruct:
+			ph.dict = &dictionaryPageHeader{}
+			d.fields(func(id int16, typ byte) {
+				switch {
+				case id == 1 && typ == tI32:
+					ph.dict.numValues = d.i32()
+				case id == 2 && typ == tI32:
+					ph.dict.encoding = d.i32()
+				case id == 3 && (typ == tTrue || typ == tFalse):
+					ph.dict.sorted = d.bool(typ)
+				default:
+					d.skip(typ, false)
+				}
+			})
+		case id == 8 && typ == tStruct:
+			ph.dataV2 = &dataPageHeaderV2{isCompressed: true}
+			d.fields(func(id int16, typ byte) {
+				switch {
+				case id == 1 && typ == tI32:
+					ph.dataV2.numValues = d.i32()
+				case id == 2 && typ == tI32:
+					ph.dataV2.numNulls = d.i32()
+				case id == 3 && typ == tI32:
+					ph.dataV2.numRows = d.i32()
+				case id == 4 && typ == tI32:
+					ph.dataV2.encoding = d.i32()
+				case id == 5 && typ == tI32:
+					ph.dataV2.defLength = d.i32()
+				case id == 6 && typ == tI32:
+					ph.dataV2.repLength = d.i32()
+				case id == 7 && (typ == tTrue || typ == tFalse):
+					ph.dataV2.isCompressed = d.bool(typ)
+				default:
+					d.skip(typ, false)
+				}
+			})
+		default:
+			d.skip(typ, false)
+		}
+	})
+	return ph
+}
+
+func (e *encoder) fileMetaData(md *fileMetaData) {
+	e.begin()
+	e.field(1, tI32)
+	e.i32(md.version)
+	e.field(2, tList)
+	e.list(tStruct, len(md.schema))
+	for i := range md.schema {
+		e.schemaElement(&md.schema[i])
+	}
+	e.field(3, tI64)
+	e.i64(md.numRows)
+	e.field(4, tList)
+	e.list(tStruct, len(md.rowGroups))
+	for i := range md.rowGroups {
+		e.rowGroup(&md.rowGroups[i])
+	}
+	if len(md.keyValues) > 0 {
+		e.field(5, tList)
+		e.list(tStruct, len(md.keyValues))
+		for _, kv := range md.keyValues {
+			e.begin()
+			e.field(1, tBinary)
+			e.string(kv.key)
+			e.field(2, tBinary)
+			e.string(kv.value)
+			e.end()
+		}
+	}
+	if md.createdBy != "" {
+		e.field(6, tBinary)
+		e.string(md.createdBy)
+	}
+	e.end()
+}
+
+func (e *encoder) schemaElement(se *schemaElement) {
+	e.begin()
+	if se.typ >= 0 {
+		e.field(1, tI32)
+		e.i32(se.typ)
+	}
+	if se.typ == typeFixedLenByteArray {
+		e.field(2, tI32)
+		e.i32(se.typeLength)
+	}
+	if se.numChildren == 0 {
+		e.field(3, tI32)
+		e.i32(se.repetition)
+	}
+	e.field(4, tBinary)
+	e.string(se.name)
+	if se.numChildren > 0 {
+		e.field(5, tI32)
+		e.i32(se.numChildren)
+	}
+	if se.converted != convertedNone {
+		e.field(6, tI32)
+		e.i32(se.converted)
+	}
+	if se.logical.kind != logicalNone {
+		e.field(10, tStruct)
+		e.logicalType(&se.logical)
+	}
+	e.end()
+}
+
+func (e *encoder) logicalType(lt *logicalType) {
+	e.begin()
+	e.field(lt.kind, tStruct)
+	e.begin()
+	switch lt.kind {
+	case logicalTime, logicalTimestamp:
+		e.boolField(1, lt.utc)
+		e.field(2, tStruct)
+		e.begin()
+		e.field(lt.unit, tStruct)
+		e.begin()
+		e.end()
+		e.end()
+	case logicalInteger:
+		e.field(1, tByte)
+		e.byte(byte(lt.bitWidth))
+		e.boolField(2, lt.signed)
+	}
+	e.end()
+	e.end()
+}
+
+func (e *encoder) rowGroup(rg *rowGroup) {
+	e.begin()
+	e.field(1, tList)
+	e.list(tStruct, len(rg.columns))
+	for i := range rg.columns {
+		cc := &rg.columns[i]
+		e.begin()
+		e.field(2, tI64)
+		e.i64(cc.fileOffset)
+		e.field(3, tStruct)
+		e.columnMetaData(&cc.meta)
+		e.end()
+	}
+	e.field(2, tI64)
+	e.i64(rg.totalByteSize)
+	e.field(3, tI64)
+	e.i64(rg.numRows)
+	e.end()
+}
+
+func (e *encoder) columnMetaData(md *columnMetaData) {
+	e.begin()
+	e.field(1, tI32)
+	e.i32(md.typ)
+	e.field(2, tList)
+	e.list(tI32, len(md.encodings))
+	for _, v := range md.encodings {
+		e.i32(v)
+	}
+	e.field(3, tList)
+	e.list(tBinary, len(md.path))
+	for _, v := range md.path {
+		e.string(v)
+	}
+	e.field(4, tI32)
+	e.i32(md.codec)
+	e.field(5, tI64)
+	e.i64(md.numValues)
+	e.field(6, tI64)
+	e.i64(md.uncompressedSize)
+	e.field(7, tI64)
+	e.i64(md.compressedSize)
+	e.field(9, tI64)
+	e.i64(md.dataPageOffset)
+	if md.hasDictPageOffset {
+		e.field(11, tI64)
+		e.i64(md.dictPageOffset)
+	}
+	if st := md.stats; st != nil {
+		e.field(12, tStruct)
+		e.begin()
+		if st.hasNullCount {
+			e.field(3, tI64)
+			e.i64(st.nullCount)
+		}
+		if st.hasMaxValue {
+			e.field(5, tBinary)
+			e.binary(st.maxValue)
+		}
+		if st.hasMinValue {
+			e.field(6, tBinary)
+			e.binary(st.minValue)
+		}
+		e.end()
+	}
+	e.end()
+}
+
+func (e *encoder) pageHeader(ph *pageHeader) {
+	e.begin()
+	e.field(1, tI32)
+	e.i32(ph.typ)
+	e.field(2, tI32)
+	e.i32(ph.uncompressedSize)
+	e.field(3, tI32)
+	e.i32(ph.compressedSize)
+	switch {
+	case ph.data != nil:
+		e.field(5, tStruct)
+		e.begin()
+		e.field(1, tI32)
+		e.i32(ph.data.numValues)
+		e.field(2, tI32)
+		e.i32(ph.data.encoding)
+		e.field(3, tI32)
+		e.i32(ph.data.defEncoding)
+		e.field(4, tI32)
+		e.i32(ph.data.repEncoding)
+		e.end()
+	case ph.dict != nil:
+		e.field(7, tStruct)
+		e.begin()
+		e.field(1, tI32)
+		e.i32(ph.dict.numValues)
+		e.field(2, tI32)
+		e.i32(ph.dict.encoding)
+		e.end()
+	case ph.dataV2 != nil:
+		e.field(8, tStruct)
+		e.begin()
+		e.field(1, tI32)
+		e.i32(ph.dataV2.numValues)
+		e.field(2, tI32)
+		e.i32(ph.dataV2.numNulls)
+		e.field(3, tI32)
+		e.i32(ph.dataV2.numRows)
+		e.field(4, tI32)
+		e.i32(ph.dataV2.encoding)
+		e.field(5, tI32)
+		e.i32(ph.dataV2.defLength)
+		e.field(6, tI32)
+		e.i32(ph.dataV2.repLength)
+		e.boolField(7, ph.dataV2.isCompressed)
+		e.end()
+	}
+	e.end()
+}
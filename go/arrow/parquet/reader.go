@@ -0,0 +1,616 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+const magic = "PAR1"
+
+// File is a Parquet file opened for reading.
+type File struct {
+	r      io.ReaderAt
+	meta   *fileMetaData
+	schema *arrow.Schema
+	cols   []column
+}
+
+// Open opens the Parquet file of the given size read from r, and decodes its
+// metadata.
+func Open(r io.ReaderAt, size int64) (*File, error) {
+	if size < 2*int64(len(magic))+4 {
+		return nil, fmt.Errorf("arrow/parquet: file too small (%d bytes)", size)
+	}
+
+	var tail [8]byte
+	if _, err := r.ReadAt(tail[:], size-8); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("arrow/parquet: could not read file footer: %w", err)
+	}
+	if string(tail[4:]) != magic {
+		return nil, fmt.Errorf("arrow/parquet: invalid file magic %q", tail[4:])
+	}
+
+	n := int64(binary.LittleEndian.Uint32(tail[:4]))
+	if n > size-8-int64(len(magic)) {
+		return nil, fmt.Errorf("arrow/parquet: invalid metadata size %d", n)
+	}
+	buf := make([]byte, n)
+	if _, err := r.ReadAt(buf, size-8-n); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("arrow/parquet: could not read file metadata: %w", err)
+	}
+
+	d := decoder{buf: buf}
+	meta := d.fileMetaData()
+	if d.err != nil {
+		return nil, fmt.Errorf("arrow/parquet: could not decode file metadata: %w", d.err)
+	}
+
+	schema, cols, err := schemaOf(meta)
+	if err != nil {
+		return nil, err
+	}
+	for i, rg := range meta.rowGroups {
+		if len(rg.columns) != len(cols) {
+			return nil, fmt.Errorf("arrow/parquet: row group %d has %d columns, want %d", i, len(rg.columns), len(cols))
+		}
+	}
+	return &File{r: r, meta: meta, schema: schema, cols: cols}, nil
+}
+
+// Schema returns the schema of the records read from the file.
+// The key-value metadata of the file is the metadata of the schema.
+func (f *File) Schema() *arrow.Schema { return f.schema }
+
+// NumRows returns the number of rows of the file.
+func (f *File) NumRows() int64 { return f.meta.numRows }
+
+// NumRowGroups returns the number of row groups of the file.
+func (f *File) NumRowGroups() int { return len(f.meta.rowGroups) }
+
+// RowGroup returns the description of the i-th row group of the file.
+func (f *File) RowGroup(i int) RowGroup {
+	rg := &f.meta.rowGroups[i]
+	out := RowGroup{Index: i, NumRows: rg.numRows, Columns: make([]ColumnStatistics, len(f.cols))}
+	for j, col := range f.cols {
+		out.Columns[j] = statisticsOf(col, rg.columns[j].meta.stats)
+	}
+	return out
+}
+
+// RowGroup describes a row group of a Parquet file.
+type RowGroup struct {
+	Index   int                // index of the row group in the file
+	NumRows int64              // number of rows of the row group
+	Columns []ColumnStatistics // statistics of each column of the file schema
+}
+
+// ColumnStatistics holds the statistics of a column chunk, when written in
+// the file.
+//
+// Min and Max hold values of the Go type of the Arrow array values of the
+// column: for example int64 for an int64 column, string for a utf8 column,
+// arrow.Timestamp for a timestamp column.
+type ColumnStatistics struct {
+	Name         string
+	HasNullCount bool
+	NullCount    int64
+	HasMinMax    bool
+	Min, Max     interface{}
+}
+
+// Reader reads the row groups of a Parquet file and creates one array.Record
+// per row group.
+type Reader struct {
+	f      *File
+	schema *arrow.Schema
+	cols   []int // indices of the columns read
+
+	names  []string
+	groups []int
+	filter func(RowGroup) bool
+
+	refs int64
+	cur  array.Record
+	err  error
+
+	mem   memory.Allocator
+	limit int64 // maximum size of the allocations, if positive
+}
+
+// NewReader returns a reader of the row groups of the file.
+//
+// NewReader returns an error if a column selected with WithColumns, or a row
+// group selected with WithRowGroups, does not exist.
+func (f *File) NewReader(opts ...Option) (*Reader, error) {
+	r := &Reader{f: f, refs: 1}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	if r.mem == nil {
+		r.mem = memory.DefaultAllocator
+	}
+
+	switch r.names {
+	case nil:
+		r.schema = f.schema
+		r.cols = make([]int, len(f.cols))
+		for i := range r.cols {
+			r.cols[i] = i
+		}
+	default:
+		fields := make([]arrow.Field, len(r.names))
+		r.cols = make([]int, len(r.names))
+		for i, name := range r.names {
+			r.cols[i] = f.schema.FieldIndex(name)
+			if r.cols[i] < 0 {
+				return nil, fmt.Errorf("arrow/parquet: no column named %q", name)
+			}
+			fields[i] = f.schema.Field(r.cols[i])
+		}
+		md := f.schema.Metadata()
+		r.schema = arrow.NewSchema(fields, &md)
+	}
+
+	switch r.groups {
+	case nil:
+		r.groups = make([]int, f.NumRowGroups())
+		for i := range r.groups {
+			r.groups[i] = i
+		}
+	default:
+		r.groups = append([]int(nil), r.groups...)
+		for _, i := range r.groups {
+			if i < 0 || i >= f.NumRowGroups() {
+				return nil, fmt.Errorf("arrow/parquet: row group %d out of range [0, %d)", i, f.NumRowGroups())
+			}
+		}
+	}
+	return r, nil
+}
+
+// Err returns the last error encountered while reading the file.
+func (r *Reader) Err() error { return r.err }
+
+func (r *Reader) Schema() *arrow.Schema { return r.schema }
+
+// Record returns the current record that has been read from the file.
+// It is valid until the next call to Next.
+func (r *Reader) Record() array.Record { return r.cur }
+
+// Next returns whether a Record could be read from the next selected row
+// group of the file.
+func (r *Reader) Next() bool {
+	if r.cur != nil {
+		r.cur.Release()
+		r.cur = nil
+	}
+
+	for r.err == nil && len(r.groups) > 0 {
+		i := r.groups[0]
+		r.groups = r.groups[1:]
+		if r.filter != nil && !r.filter(r.f.RowGroup(i)) {
+			continue
+		}
+		r.cur, r.err = r.readRowGroup(i)
+		return r.err == nil
+	}
+	return false
+}
+
+func (r *Reader) readRowGroup(i int) (array.Record, error) {
+	rg := &r.f.meta.rowGroups[i]
+	arrs := make([]array.Interface, 0, len(r.cols))
+	defer func() {
+		for _, arr := range arrs {
+			arr.Release()
+		}
+	}()
+
+	for _, j := range r.cols {
+		arr, err := r.readColumnChunk(r.f.cols[j], &rg.columns[j], rg.numRows)
+		if err != nil {
+			return nil, fmt.Errorf("arrow/parquet: row group %d, column %q: %w", i, r.f.cols[j].field.Name, err)
+		}
+		arrs = append(arrs, arr)
+	}
+	return array.NewRecord(r.schema, arrs, rg.numRows), nil
+}
+
+func (r *Reader) readColumnChunk(col column, cc *columnChunk, nrows int64) (array.Interface, error) {
+	md := &cc.meta
+	if cc.filePath != "" {
+		return nil, fmt.Errorf("column chunks in external files are not supported")
+	}
+
+	start := md.dataPageOffset
+	if md.hasDictPageOffset && md.dictPageOffset > 0 && md.dictPageOffset < start {
+		start = md.dictPageOffset
+	}
+	if start < 0 || md.compressedSize < 0 || md.compressedSize > 1<<31 || nrows < 0 {
+		return nil, errCorrupt
+	}
+	if r.limit > 0 && (md.compressedSize > r.limit || nrows > r.limit) {
+		return nil, fmt.Errorf("column chunk of %d bytes and %d rows exceeds the allocation limit of %d", md.compressedSize, nrows, r.limit)
+	}
+	buf := make([]byte, md.compressedSize)
+	if _, err := r.f.r.ReadAt(buf, start); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("could not read column chunk: %w", err)
+	}
+
+	b := array.NewBuilder(r.mem, col.field.Type)
+	defer b.Release()
+	b.Reserve(int(nrows))
+
+	var (
+		codec = Codec(md.codec)
+		dict  *values
+		read  int64
+	)
+	for read < md.numValues {
+		d := decoder{buf: buf}
+		ph := d.pageHeader()
+		if d.err != nil || ph.compressedSize < 0 || int(ph.compressedSize) > len(buf)-d.pos || ph.uncompressedSize < 0 {
+			return nil, errCorrupt
+		}
+		if r.limit > 0 && int64(ph.uncompressedSize) > r.limit {
+			return nil, fmt.Errorf("page of %d bytes exceeds the allocation limit of %d", ph.uncompressedSize, r.limit)
+		}
+		page := buf[d.pos : d.pos+int(ph.compressedSize)]
+		buf = buf[d.pos+int(ph.compressedSize):]
+
+		switch {
+		case ph.typ == pageDictionary && ph.dict != nil:
+			data, err := decompress(codec, page, int(ph.uncompressedSize))
+			if err != nil {
+				return nil, err
+			}
+			vals, err := decodePlain(col.elem.typ, int(col.elem.typeLength), data, int(ph.dict.numValues))
+			if err != nil {
+				return nil, err
+			}
+			dict = &vals
+
+		case ph.typ == pageData && ph.data != nil:
+			data, err := decompress(codec, page, int(ph.uncompressedSize))
+			if err != nil {
+				return nil, err
+			}
+			n := int(ph.data.numValues)
+			if n < 0 || int64(n) > nrows-read {
+				return nil, errCorrupt
+			}
+			var defs []uint32
+			if col.maxDef > 0 {
+				if ph.data.defEncoding != encodingRLE {
+					return nil, fmt.Errorf("unsupported definition level encoding %d", ph.data.defEncoding)
+				}
+				if len(data) < 4 {
+					return nil, errCorrupt
+				}
+				size := binary.LittleEndian.Uint32(data)
+				if uint64(size) > uint64(len(data)-4) {
+					return nil, errCorrupt
+				}
+				defs, err = decodeHybrid(data[4:4+size], bitWidth(col.maxDef), n)
+				if err != nil {
+					return nil, err
+				}
+				data = data[4+size:]
+			}
+			if err := appendPage(b, col, ph.data.encoding, data, n, defs, dict); err != nil {
+				return nil, err
+			}
+			read += int64(n)
+
+		case ph.typ == pageDataV2 && ph.dataV2 != nil:
+			h := ph.dataV2
+			levels := int(h.repLength) + int(h.defLength)
+			if h.repLength < 0 || h.defLength < 0 || levels > len(page) {
+				return nil, errCorrupt
+			}
+			n := int(h.numValues)
+			if n < 0 || int64(n) > nrows-read {
+				return nil, errCorrupt
+			}
+			var defs []uint32
+			if col.maxDef > 0 {
+				var err error
+				defs, err = decodeHybrid(page[h.repLength:levels], bitWidth(col.maxDef), n)
+				if err != nil {
+					return nil, err
+				}
+			}
+			data := page[levels:]
+			if h.isCompressed {
+				var err error
+				data, err = decompress(codec, data, int(ph.uncompressedSize)-levels)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if err := appendPage(b, col, h.encoding, data, n, defs, dict); err != nil {
+				return nil, err
+			}
+			read += int64(n)
+
+		case ph.typ == pageIndex:
+			// index pages are not used.
+
+		default:
+			return nil, fmt.Errorf("invalid page type %d", ph.typ)
+		}
+	}
+
+	if int64(b.Len()) != nrows {
+		return nil, fmt.Errorf("column chunk has %d values, want %d", b.Len(), nrows)
+	}
+	return b.NewArray(), nil
+}
+
+// appendPage decodes the n values of a data page and appends them to b.
+// defs holds the definition levels of the values, or nil for required columns.
+func appendPage(b array.Builder, col column, encoding int32, data []byte, n int, defs []uint32, dict *values) error {
+	nvalid := n
+	if defs != nil {
+		nvalid = 0
+		for _, def := range defs {
+			if int(def) == col.maxDef {
+				nvalid++
+			}
+		}
+	}
+
+	var (
+		vals values
+		err  error
+	)
+	switch encoding {
+	case encodingPlain:
+		vals, err = decodePlain(col.elem.typ, int(col.elem.typeLength), data, nvalid)
+	case encodingPlainDictionary, encodingRLEDictionary:
+		if dict == nil {
+			return fmt.Errorf("dictionary encoded page without dictionary")
+		}
+		var indices []uint32
+		if nvalid > 0 {
+			if len(data) < 1 {
+				return errCorrupt
+			}
+			indices, err = decodeHybrid(data[1:], int(data[0]), nvalid)
+			if err != nil {
+				return err
+			}
+		}
+		vals, err = dict.take(indices)
+	case encodingRLE:
+		if col.elem.typ != typeBoolean || len(data) < 4 {
+			return fmt.Errorf("unsupported RLE encoding of physical type %d", col.elem.typ)
+		}
+		var bits []uint32
+		bits, err = decodeHybrid(data[4:], 1, nvalid)
+		vals.bools = make([]bool, len(bits))
+		for i, v := range bits {
+			vals.bools[i] = v != 0
+		}
+	default:
+		return fmt.Errorf("unsupported encoding %d", encoding)
+	}
+	if err != nil {
+		return err
+	}
+	if vals.len() != nvalid {
+		return errCorrupt
+	}
+
+	k := 0
+	for i := 0; i < n; i++ {
+		if defs != nil && int(defs[i]) != col.maxDef {
+			b.AppendNull()
+			continue
+		}
+		appendValue(b, &vals, k)
+		k++
+	}
+	return nil
+}
+
+// julianUnixEpoch is the Julian day of the Unix epoch.
+const julianUnixEpoch = 2440588
+
+// int96Nanos returns the number of nanoseconds since the Unix epoch of an
+// INT96 timestamp, made of the nanoseconds of the day and the Julian day.
+func int96Nanos(v [12]byte) int64 {
+	nanos := int64(binary.LittleEndian.Uint64(v[:8]))
+	day := int64(binary.LittleEndian.Uint32(v[8:]))
+	return (day-julianUnixEpoch)*86400*1e9 + nanos
+}
+
+// appendValue appends the k-th value of vals to b.
+func appendValue(b array.Builder, vals *values, k int) {
+	switch b := b.(type) {
+	case *array.BooleanBuilder:
+		b.Append(vals.bools[k])
+	case *array.Int8Builder:
+		b.Append(int8(vals.int32s[k]))
+	case *array.Int16Builder:
+		b.Append(int16(vals.int32s[k]))
+	case *array.Int32Builder:
+		b.Append(vals.int32s[k])
+	case *array.Uint8Builder:
+		b.Append(uint8(vals.int32s[k]))
+	case *array.Uint16Builder:
+		b.Append(uint16(vals.int32s[k]))
+	case *array.Uint32Builder:
+		b.Append(uint32(vals.int32s[k]))
+	case *array.Int64Builder:
+		b.Append(vals.int64s[k])
+	case *array.Uint64Builder:
+		b.Append(uint64(vals.int64s[k]))
+	case *array.Float32Builder:
+		b.Append(vals.floats[k])
+	case *array.Float64Builder:
+		b.Append(vals.doubles[k])
+	case *array.StringBuilder:
+		b.Append(string(vals.bytes[k]))
+	case *array.BinaryBuilder:
+		b.Append(vals.bytes[k])
+	case *array.FixedSizeBinaryBuilder:
+		b.Append(vals.bytes[k])
+	case *array.Date32Builder:
+		b.Append(arrow.Date32(vals.int32s[k]))
+	case *array.Time32Builder:
+		b.Append(arrow.Time32(vals.int32s[k]))
+	case *array.Time64Builder:
+		b.Append(arrow.Time64(vals.int64s[k]))
+	case *array.TimestampBuilder:
+		if vals.int96s != nil {
+			b.Append(arrow.Timestamp(int96Nanos(vals.int96s[k])))
+			return
+		}
+		b.Append(arrow.Timestamp(vals.int64s[k]))
+	default:
+		panic(fmt.Errorf("arrow/parquet: unexpected builder %T", b))
+	}
+}
+
+// statisticsOf returns the statistics of a column chunk of col.
+func statisticsOf(col column, st *statistics) ColumnStatistics {
+	out := ColumnStatistics{Name: col.field.Name}
+	if st == nil {
+		return out
+	}
+	out.HasNullCount, out.NullCount = st.hasNullCount, st.nullCount
+
+	min, max := st.minValue, st.maxValue
+	ok := st.hasMinValue && st.hasMaxValue
+	if !ok && st.hasDeprecatedMin && st.hasDeprecatedMax && signedOrder(col) {
+		// the deprecated statistics were computed with a signed comparison.
+		min, max, ok = st.min, st.max, true
+	}
+	if !ok || col.elem.typ == typeInt96 {
+		return out
+	}
+
+	var err1, err2 error
+	out.Min, err1 = statValue(col, min)
+	out.Max, err2 = statValue(col, max)
+	out.HasMinMax = err1 == nil && err2 == nil
+	if !out.HasMinMax {
+		out.Min, out.Max = nil, nil
+	}
+	return out
+}
+
+// signedOrder reports whether the values of col are ordered as signed
+// integers or floating point values.
+func signedOrder(col column) bool {
+	switch col.field.Type.ID() {
+	case arrow.BOOL, arrow.INT8, arrow.INT16, arrow.INT32, arrow.INT64, arrow.FLOAT32, arrow.FLOAT64,
+		arrow.DATE32, arrow.TIME32, arrow.TIME64, arrow.TIMESTAMP:
+		return true
+	}
+	return false
+}
+
+// statValue decodes a statistics value of col.
+func statValue(col column, buf []byte) (interface{}, error) {
+	var (
+		vals values
+		err  error
+	)
+	switch col.elem.typ {
+	case typeByteArray, typeFixedLenByteArray:
+		vals.bytes = [][]byte{bytes.Repeat(buf, 1)}
+	default:
+		vals, err = decodePlain(col.elem.typ, int(col.elem.typeLength), buf, 1)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch col.field.Type.ID() {
+	case arrow.BOOL:
+		return vals.bools[0], nil
+	case arrow.INT8:
+		return int8(vals.int32s[0]), nil
+	case arrow.INT16:
+		return int16(vals.int32s[0]), nil
+	case arrow.INT32:
+		return vals.int32s[0], nil
+	case arrow.UINT8:
+		return uint8(vals.int32s[0]), nil
+	case arrow.UINT16:
+		return uint16(vals.int32s[0]), nil
+	case arrow.UINT32:
+		return uint32(vals.int32s[0]), nil
+	case arrow.INT64:
+		return vals.int64s[0], nil
+	case arrow.UINT64:
+		return uint64(vals.int64s[0]), nil
+	case arrow.FLOAT32:
+		return vals.floats[0], nil
+	case arrow.FLOAT64:
+		return vals.doubles[0], nil
+	case arrow.STRING:
+		return string(vals.bytes[0]), nil
+	case arrow.BINARY, arrow.FIXED_SIZE_BINARY:
+		return vals.bytes[0], nil
+	case arrow.DATE32:
+		return arrow.Date32(vals.int32s[0]), nil
+	case arrow.TIME32:
+		return arrow.Time32(vals.int32s[0]), nil
+	case arrow.TIME64:
+		return arrow.Time64(vals.int64s[0]), nil
+	case arrow.TIMESTAMP:
+		return arrow.Timestamp(vals.int64s[0]), nil
+	}
+	return nil, fmt.Errorf("unsupported statistics type %s", col.field.Type.Name())
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (r *Reader) Retain() {
+	atomic.AddInt64(&r.refs, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+// Release may be called simultaneously from multiple goroutines.
+func (r *Reader) Release() {
+	debug.Assert(atomic.LoadInt64(&r.refs) > 0, "too many releases")
+
+	if atomic.AddInt64(&r.refs, -1) == 0 {
+		if r.cur != nil {
+			r.cur.Release()
+		}
+	}
+}
+
+var (
+	_ array.RecordReader = (*Reader)(nil)
+)
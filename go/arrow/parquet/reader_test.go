@@ -0,0 +1,629 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+// testChunk describes a column chunk written by testFile.
+type testChunk struct {
+	codec Codec
+	dict  []byte // PLAIN encoded dictionary page, if any
+	ndict int
+	pages []testPage
+	stats *statistics
+}
+
+type testPage struct {
+	v2       bool
+	n        int
+	encoding int32
+	defs     []byte // RLE encoded definition levels, without length prefix
+	data     []byte
+}
+
+// testFile writes a Parquet file with the given schema and row groups made
+// of one chunk per column.
+func testFile(t testing.TB, schema []schemaElement, nrows []int64, groups [][]testChunk, kvs []keyValue) []byte {
+	t.Helper()
+
+	buf := []byte(magic)
+	md := fileMetaData{version: 1, schema: schema, keyValues: kvs}
+	for i, chunks := range groups {
+		rg := rowGroup{numRows: nrows[i]}
+		for j, c := range chunks {
+			se := schema[j+1]
+			cc := columnChunk{meta: columnMetaData{
+				typ:       se.typ,
+				encodings: []int32{encodingPlain, encodingRLE},
+				path:      []string{se.name},
+				codec:     int32(c.codec),
+				stats:     c.stats,
+			}}
+			start := int64(len(buf))
+			if c.dict != nil {
+				cc.meta.dictPageOffset, cc.meta.hasDictPageOffset = start, true
+				buf = writePage(t, buf, c.codec, &pageHeader{
+					typ:  pageDictionary,
+					dict: &dictionaryPageHeader{numValues: int32(c.ndict), encoding: encodingPlain},
+				}, nil, c.dict)
+			}
+			cc.meta.dataPageOffset = int64(len(buf))
+			for _, p := range c.pages {
+				cc.meta.numValues += int64(p.n)
+				ph := &pageHeader{typ: pageData}
+				switch {
+				case p.v2:
+					ph.typ = pageDataV2
+					ph.dataV2 = &dataPageHeaderV2{
+						numValues: int32(p.n), numRows: int32(p.n), encoding: p.encoding,
+						defLength: int32(len(p.defs)), isCompressed: true,
+					}
+					buf = writePage(t, buf, c.codec, ph, p.defs, p.data)
+				default:
+					ph.data = &dataPageHeader{numValues: int32(p.n), encoding: p.encoding, defEncoding: encodingRLE, repEncoding: encodingRLE}
+					var data []byte
+					if p.defs != nil {
+						data = binary.LittleEndian.AppendUint32(data, uint32(len(p.defs)))
+						data = append(data, p.defs...)
+					}
+					buf = writePage(t, buf, c.codec, ph, nil, append(data, p.data...))
+				}
+			}
+			cc.meta.compressedSize = int64(len(buf)) - start
+			cc.meta.uncompressedSize = cc.meta.compressedSize
+			cc.fileOffset = start
+			rg.columns = append(rg.columns, cc)
+		}
+		md.numRows += rg.numRows
+		md.rowGroups = append(md.rowGroups, rg)
+	}
+
+	var e encoder
+	e.fileMetaData(&md)
+	buf = append(buf, e.buf...)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(e.buf)))
+	return append(buf, magic...)
+}
+
+// writePage appends a page, with its uncompressed levels and its data
+// compressed with codec, to buf.
+func writePage(t testing.TB, buf []byte, codec Codec, ph *pageHeader, levels, data []byte) []byte {
+	t.Helper()
+
+	var body []byte
+	switch codec {
+	case Uncompressed:
+		body = data
+	case Snappy:
+		body = binary.AppendUvarint(nil, uint64(len(data)))
+		for rest := data; len(rest) > 0; {
+			n := len(rest)
+			if n > 60 {
+				n = 60
+			}
+			body = append(body, byte(n-1)<<2)
+			body = append(body, rest[:n]...)
+			rest = rest[n:]
+		}
+	case Gzip:
+		var w bytes.Buffer
+		z := gzip.NewWriter(&w)
+		if _, err := z.Write(data); err != nil {
+			t.Fatal(err)
+		}
+		if err := z.Close(); err != nil {
+			t.Fatal(err)
+		}
+		body = w.Bytes()
+	default:
+		t.Fatalf("unsupported codec %v", codec)
+	}
+
+	ph.uncompressedSize = int32(len(levels) + len(data))
+	ph.compressedSize = int32(len(levels) + len(body))
+	var e encoder
+	e.pageHeader(ph)
+	buf = append(buf, e.buf...)
+	buf = append(buf, levels...)
+	return append(buf, body...)
+}
+
+// rle encodes vals with the RLE/bit-packing hybrid encoding, as runs of
+// single values.
+func rle(width int, vals ...uint32) []byte {
+	var out []byte
+	for _, v := range vals {
+		out = binary.AppendUvarint(out, 1<<1)
+		for i := 0; i < (width+7)/8; i++ {
+			out = append(out, byte(v>>(8*uint(i))))
+		}
+	}
+	return out
+}
+
+func plainInt64(vs ...int64) []byte {
+	var out []byte
+	for _, v := range vs {
+		out = binary.LittleEndian.AppendUint64(out, uint64(v))
+	}
+	return out
+}
+
+func plainDouble(vs ...float64) []byte {
+	var out []byte
+	for _, v := range vs {
+		out = binary.LittleEndian.AppendUint64(out, math.Float64bits(v))
+	}
+	return out
+}
+
+func plainBytes(vs ...string) []byte {
+	var out []byte
+	for _, v := range vs {
+		out = binary.LittleEndian.AppendUint32(out, uint32(len(v)))
+		out = append(out, v...)
+	}
+	return out
+}
+
+var testSchema = []schemaElement{
+	{typ: -1, name: "schema", numChildren: 3, converted: convertedNone},
+	{typ: typeInt64, name: "id", repetition: repetitionRequired, converted: convertedNone},
+	{typ: typeByteArray, name: "name", repetition: repetitionOptional, converted: convertedUTF8, logical: logicalType{kind: logicalString}},
+	{typ: typeDouble, name: "score", repetition: repetitionOptional, converted: convertedNone},
+}
+
+// newTestFile returns a file with two row groups, holding rows [1, 3] and
+// [4, 5].
+func newTestFile(t testing.TB) []byte {
+	return testFile(t, testSchema, []int64{3, 2}, [][]testChunk{
+		{
+			{
+				pages: []testPage{
+					{n: 2, data: plainInt64(1, 2)},
+					{n: 1, data: plainInt64(3)},
+				},
+				stats: &statistics{
+					minValue: plainInt64(1), hasMinValue: true,
+					maxValue: plainInt64(3), hasMaxValue: true,
+					nullCount: 0, hasNullCount: true,
+				},
+			},
+			{
+				codec: Gzip,
+				dict:  plainBytes("alice", "bob"), ndict: 2,
+				pages: []testPage{{
+					n: 3, encoding: encodingRLEDictionary,
+					defs: rle(1, 1, 0, 1),
+					data: append([]byte{1}, rle(1, 1, 0)...),
+				}},
+			},
+			{
+				codec: Snappy,
+				pages: []testPage{{v2: true, n: 3, defs: rle(1, 1, 1, 0), data: plainDouble(1.5, 2.5)}},
+			},
+		},
+		{
+			{
+				pages: []testPage{{n: 2, data: plainInt64(4, 5)}},
+				stats: &statistics{
+					minValue: plainInt64(4), hasMinValue: true,
+					maxValue: plainInt64(5), hasMaxValue: true,
+				},
+			},
+			{
+				pages: []testPage{{n: 2, defs: rle(1, 1, 1), data: plainBytes("carol", "dave")}},
+				stats: &statistics{
+					minValue: []byte("carol"), hasMinValue: true,
+					maxValue: []byte("dave"), hasMaxValue: true,
+				},
+			},
+			{
+				pages: []testPage{{n: 2, defs: rle(1, 0, 0)}},
+				stats: &statistics{nullCount: 2, hasNullCount: true},
+			},
+		},
+	}, []keyValue{{key: "origin", value: "test"}})
+}
+
+func openTestFile(t *testing.T, raw []byte) *File {
+	t.Helper()
+	f, err := Open(bytes.NewReader(raw), int64(len(raw)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+// readAll returns the rows of the records of r, one string per record.
+func readAll(t *testing.T, r *Reader) []string {
+	t.Helper()
+	var out []string
+	for r.Next() {
+		rec := r.Record()
+		rows := make([]string, rec.NumRows())
+		for i := range rows {
+			vals := make([]string, rec.NumCols())
+			for j, col := range rec.Columns() {
+				var v interface{} = "null"
+				switch col := col.(type) {
+				case *array.Int64:
+					v = col.Value(i)
+				case *array.String:
+					v = col.Value(i)
+				case *array.Float64:
+					v = col.Value(i)
+				case *array.Timestamp:
+					v = col.Value(i)
+				}
+				if col.IsNull(i) {
+					v = "null"
+				}
+				vals[j] = fmt.Sprintf("%s=%v", rec.ColumnName(j), v)
+			}
+			rows[i] = strings.Join(vals, " ")
+		}
+		out = append(out, strings.Join(rows, ", "))
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	f := openTestFile(t, newTestFile(t))
+	assert.Equal(t, int64(5), f.NumRows())
+	assert.Equal(t, 2, f.NumRowGroups())
+
+	md := arrow.NewMetadata([]string{"origin"}, []string{"test"})
+	want := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, &md)
+	if !f.Schema().Equal(want) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", f.Schema(), want)
+	}
+
+	r, err := f.NewReader(WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	assert.Equal(t, []string{
+		"id=1 name=bob score=1.5, id=2 name=null score=2.5, id=3 name=alice score=null",
+		"id=4 name=carol score=null, id=5 name=dave score=null",
+	}, readAll(t, r))
+}
+
+func TestReaderProjection(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	f := openTestFile(t, newTestFile(t))
+
+	r, err := f.NewReader(WithAllocator(mem), WithColumns("score", "id"), WithRowGroups(1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	assert.Equal(t, []string{"score", "id"}, []string{r.Schema().Field(0).Name, r.Schema().Field(1).Name})
+	assert.Equal(t, []string{
+		"score=null id=4, score=null id=5",
+		"score=1.5 id=1, score=2.5 id=2, score=null id=3",
+	}, readAll(t, r))
+
+	_, err = f.NewReader(WithColumns("id", "missing"))
+	assert.EqualError(t, err, `arrow/parquet: no column named "missing"`)
+
+	_, err = f.NewReader(WithRowGroups(2))
+	assert.EqualError(t, err, "arrow/parquet: row group 2 out of range [0, 2)")
+}
+
+func TestReaderRowGroupFilter(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	f := openTestFile(t, newTestFile(t))
+
+	rg := f.RowGroup(1)
+	assert.Equal(t, RowGroup{
+		Index:   1,
+		NumRows: 2,
+		Columns: []ColumnStatistics{
+			{Name: "id", HasMinMax: true, Min: int64(4), Max: int64(5)},
+			{Name: "name", HasMinMax: true, Min: "carol", Max: "dave"},
+			{Name: "score", HasNullCount: true, NullCount: 2},
+		},
+	}, rg)
+
+	// select the row groups which may hold ids greater than 3.
+	r, err := f.NewReader(WithAllocator(mem), WithColumns("id"), WithRowGroupFilter(func(rg RowGroup) bool {
+		st := rg.Columns[0]
+		return !st.HasMinMax || st.Max.(int64) > 3
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	assert.Equal(t, []string{"id=4, id=5"}, readAll(t, r))
+}
+
+func TestOpenErrors(t *testing.T) {
+	raw := newTestFile(t)
+
+	for _, tc := range []struct {
+		name string
+		raw  []byte
+		err  string
+	}{
+		{"empty", nil, "arrow/parquet: file too small (0 bytes)"},
+		{"magic", append(raw[:len(raw)-4:len(raw)-4], "PAR0"...), `arrow/parquet: invalid file magic "PAR0"`},
+		{"size", append(append(raw[:len(raw)-8:len(raw)-8], 0xff, 0xff, 0xff, 0x0f), magic...), "arrow/parquet: invalid metadata size 268435455"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Open(bytes.NewReader(tc.raw), int64(len(tc.raw)))
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+
+	nested := testFile(t, []schemaElement{
+		{typ: -1, name: "schema", numChildren: 1, converted: convertedNone},
+		{typ: -1, name: "group", numChildren: 1, repetition: repetitionOptional, converted: convertedNone},
+		{typ: typeInt32, name: "leaf", repetition: repetitionOptional, converted: convertedNone},
+	}, nil, nil, nil)
+	_, err := Open(bytes.NewReader(nested), int64(len(nested)))
+	assert.EqualError(t, err, `arrow/parquet: nested column "group" is not supported`)
+}
+
+func TestReaderCorrupt(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	// the data page holds 2 values instead of 3.
+	f := openTestFile(t, testFile(t, []schemaElement{
+		{typ: -1, name: "schema", numChildren: 1, converted: convertedNone},
+		testSchema[1],
+	}, []int64{3}, [][]testChunk{{
+		{pages: []testPage{{n: 3, data: plainInt64(1, 2)}}},
+	}}, nil))
+
+	r, err := f.NewReader(WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	assert.False(t, r.Next())
+	assert.EqualError(t, r.Err(), `arrow/parquet: row group 0, column "id": arrow/parquet: corrupt page data`)
+}
+
+func TestReaderAllocationLimit(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	f := openTestFile(t, newTestFile(t))
+	r, err := f.NewReader(WithAllocator(mem), WithAllocationLimit(1<<10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Len(t, readAll(t, r), 2)
+	r.Release()
+
+	r, err = f.NewReader(WithAllocator(mem), WithAllocationLimit(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+	assert.False(t, r.Next())
+	assert.Error(t, r.Err())
+	assert.Contains(t, r.Err().Error(), "exceeds the allocation limit of 2")
+
+	assert.Panics(t, func() { f.NewReader(WithAllocationLimit(0)) })
+}
+
+func FuzzReader(f *testing.F) {
+	f.Add(newTestFile(f))
+
+	f.Fuzz(func(t *testing.T, raw []byte) {
+		mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+		defer mem.AssertSize(t, 0)
+
+		pf, err := Open(bytes.NewReader(raw), int64(len(raw)))
+		if err != nil {
+			return
+		}
+		for i := 0; i < pf.NumRowGroups(); i++ {
+			pf.RowGroup(i)
+		}
+		r, err := pf.NewReader(WithAllocator(mem), WithAllocationLimit(1<<20))
+		if err != nil {
+			return
+		}
+		defer r.Release()
+		for r.Next() {
+			for _, col := range r.Record().Columns() {
+				if err := array.ValidateFull(col); err != nil {
+					t.Fatalf("invalid column: %v", err)
+				}
+			}
+		}
+	})
+}
+
+// goldenDir holds the Parquet files used by the pyarrow tests.
+const goldenDir = "../../../python/pyarrow/tests/data/parquet"
+
+func openGoldenFile(t *testing.T, name string) *File {
+	t.Helper()
+	raw, err := os.ReadFile(filepath.Join(goldenDir, name))
+	if os.IsNotExist(err) {
+		t.Skipf("golden file %s not found", name)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return openTestFile(t, raw)
+}
+
+// diamonds are the rows of the v0.7.1 golden files, written by pyarrow 0.7.1
+// from the first rows of the diamonds data set.
+var diamonds = []struct {
+	carat               float64
+	cut, color, clarity string
+	depth, table        float64
+	price               int64
+	x, y, z             float64
+}{
+	{0.23, "Ideal", "E", "SI2", 61.5, 55.0, 326, 3.95, 3.98, 2.43},
+	{0.21, "Premium", "E", "SI1", 59.8, 61.0, 326, 3.89, 3.84, 2.31},
+	{0.23, "Good", "E", "VS1", 56.9, 65.0, 327, 4.05, 4.07, 2.31},
+	{0.29, "Premium", "I", "VS2", 62.4, 58.0, 334, 4.20, 4.23, 2.63},
+	{0.31, "Good", "J", "SI2", 63.3, 58.0, 335, 4.34, 4.35, 2.75},
+	{0.24, "Very Good", "J", "VVS2", 62.8, 57.0, 336, 3.94, 3.96, 2.48},
+	{0.24, "Very Good", "I", "VVS1", 62.3, 57.0, 336, 3.95, 3.98, 2.47},
+	{0.26, "Very Good", "H", "SI1", 61.9, 55.0, 337, 4.07, 4.11, 2.53},
+	{0.22, "Fair", "E", "VS2", 65.1, 61.0, 337, 3.87, 3.78, 2.49},
+	{0.23, "Very Good", "H", "VS1", 59.4, 61.0, 338, 4.00, 4.05, 2.39},
+}
+
+func TestReaderGolden(t *testing.T) {
+	// files with a named index store its columns last, sorted by the index.
+	sorted := make([]int, len(diamonds))
+	for i := range sorted {
+		sorted[i] = i
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := diamonds[sorted[i]], diamonds[sorted[j]]
+		if a.cut != b.cut {
+			return a.cut < b.cut
+		}
+		if a.color != b.color {
+			return a.color < b.color
+		}
+		return a.clarity < b.clarity
+	})
+
+	for _, tc := range []struct {
+		name  string
+		order []int
+		row   func(i int) string
+	}{
+		{
+			name: "v0.7.1.parquet",
+			row: func(i int) string {
+				d := diamonds[i]
+				return fmt.Sprintf("carat=%v cut=%s color=%s clarity=%s depth=%v table=%v price=%d x=%v y=%v z=%v __index_level_0__=%d",
+					d.carat, d.cut, d.color, d.clarity, d.depth, d.table, d.price, d.x, d.y, d.z, i)
+			},
+		},
+		{
+			name:  "v0.7.1.all-named-index.parquet",
+			order: sorted,
+			row: func(i int) string {
+				d := diamonds[i]
+				return fmt.Sprintf("carat=%v depth=%v table=%v price=%d x=%v y=%v z=%v cut=%s color=%s clarity=%s",
+					d.carat, d.depth, d.table, d.price, d.x, d.y, d.z, d.cut, d.color, d.clarity)
+			},
+		},
+		{
+			name:  "v0.7.1.some-named-index.parquet",
+			order: sorted,
+			row: func(i int) string {
+				d := diamonds[i]
+				return fmt.Sprintf("carat=%v depth=%v table=%v price=%d x=%v y=%v z=%v cut=%s __index_level_1__=%s clarity=%s",
+					d.carat, d.depth, d.table, d.price, d.x, d.y, d.z, d.cut, d.color, d.clarity)
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+			defer mem.AssertSize(t, 0)
+
+			f := openGoldenFile(t, tc.name)
+			assert.Equal(t, int64(len(diamonds)), f.NumRows())
+			r, err := f.NewReader(WithAllocator(mem))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Release()
+
+			rows := make([]string, len(diamonds))
+			for i := range rows {
+				j := i
+				if tc.order != nil {
+					j = tc.order[i]
+				}
+				rows[i] = tc.row(j)
+			}
+			assert.Equal(t, []string{strings.Join(rows, ", ")}, readAll(t, r))
+		})
+	}
+}
+
+func TestReaderGoldenTimestamps(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	f := openGoldenFile(t, "v0.7.1.column-metadata-handling.parquet")
+	utc := &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}
+	assert.Equal(t, utc, f.Schema().Field(2).Type)
+	assert.Equal(t, utc, f.Schema().Field(4).Type)
+
+	r, err := f.NewReader(WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	// the days from 2017-01-01 in Europe/Brussels, an hour ahead of UTC.
+	assert.Equal(t, []string{
+		"a=1 b=0.1 c=1483225200000000 index=a __index_level_1__=1483225200000000, " +
+			"a=2 b=0.2 c=1483311600000000 index=b __index_level_1__=1483311600000000, " +
+			"a=3 b=0.3 c=1483398000000000 index=c __index_level_1__=1483398000000000",
+	}, readAll(t, r))
+
+	r, err = f.NewReader(WithAllocator(mem), WithColumns("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+	assert.Equal(t, []string{"a=1, a=2, a=3"}, readAll(t, r))
+}
@@ -0,0 +1,304 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow"
+)
+
+// column describes a leaf column of a Parquet file.
+type column struct {
+	field  arrow.Field
+	elem   schemaElement
+	maxDef int // maximum definition level
+}
+
+// schemaOf returns the Arrow schema and the columns described by the
+// metadata of a Parquet file.
+func schemaOf(md *fileMetaData) (*arrow.Schema, []column, error) {
+	if len(md.schema) == 0 {
+		return nil, nil, fmt.Errorf("arrow/parquet: file has no schema")
+	}
+
+	elems := md.schema[1:]
+	cols := make([]column, len(elems))
+	fields := make([]arrow.Field, len(elems))
+	names := make(map[string]bool, len(elems))
+	for i, se := range elems {
+		if names[se.name] {
+			return nil, nil, fmt.Errorf("arrow/parquet: duplicate column %q", se.name)
+		}
+		names[se.name] = true
+		if se.numChildren > 0 || se.typ < 0 {
+			return nil, nil, fmt.Errorf("arrow/parquet: nested column %q is not supported", se.name)
+		}
+		if se.repetition == repetitionRepeated {
+			return nil, nil, fmt.Errorf("arrow/parquet: repeated column %q is not supported", se.name)
+		}
+		dt, err := dataTypeOf(se)
+		if err != nil {
+			return nil, nil, fmt.Errorf("arrow/parquet: column %q: %w", se.name, err)
+		}
+		fields[i] = arrow.Field{Name: se.name, Type: dt, Nullable: se.repetition == repetitionOptional}
+		cols[i] = column{field: fields[i], elem: se}
+		if fields[i].Nullable {
+			cols[i].maxDef = 1
+		}
+	}
+	if n := int(md.schema[0].numChildren); n != len(elems) {
+		return nil, nil, fmt.Errorf("arrow/parquet: nested columns are not supported")
+	}
+
+	var meta *arrow.Metadata
+	if len(md.keyValues) > 0 {
+		keys := make([]string, len(md.keyValues))
+		vals := make([]string, len(md.keyValues))
+		for i, kv := range md.keyValues {
+			keys[i], vals[i] = kv.key, kv.value
+		}
+		m := arrow.NewMetadata(keys, vals)
+		meta = &m
+	}
+	return arrow.NewSchema(fields, meta), cols, nil
+}
+
+// dataTypeOf returns the Arrow data type of the values of the leaf schema
+// element se.
+func dataTypeOf(se schemaElement) (arrow.DataType, error) {
+	lt := se.logical
+	if se.converted == convertedDecimal || lt.kind == logicalDecimal {
+		return nil, fmt.Errorf("decimal values are not supported")
+	}
+
+	switch se.typ {
+	case typeBoolean:
+		return arrow.FixedWidthTypes.Boolean, nil
+
+	case typeInt32:
+		switch {
+		case lt.kind == logicalInteger:
+			return integerType(int(lt.bitWidth), lt.signed)
+		case lt.kind == logicalDate || se.converted == convertedDate:
+			return arrow.PrimitiveTypes.Date32, nil
+		case lt.kind == logicalTime && lt.unit == unitMillis || se.converted == convertedTimeMillis:
+			return arrow.FixedWidthTypes.Time32ms, nil
+		}
+		switch se.converted {
+		case convertedInt8:
+			return arrow.PrimitiveTypes.Int8, nil
+		case convertedInt16:
+			return arrow.PrimitiveTypes.Int16, nil
+		case convertedUint8:
+			return arrow.PrimitiveTypes.Uint8, nil
+		case convertedUint16:
+			return arrow.PrimitiveTypes.Uint16, nil
+		case convertedUint32:
+			return arrow.PrimitiveTypes.Uint32, nil
+		}
+		return arrow.PrimitiveTypes.Int32, nil
+
+	case typeInt64:
+		switch {
+		case lt.kind == logicalInteger:
+			return integerType(int(lt.bitWidth), lt.signed)
+		case lt.kind == logicalTimestamp:
+			return timestampType(lt.unit, lt.utc)
+		case lt.kind == logicalTime && lt.unit == unitMicros:
+			return arrow.FixedWidthTypes.Time64us, nil
+		case lt.kind == logicalTime && lt.unit == unitNanos:
+			return arrow.FixedWidthTypes.Time64ns, nil
+		}
+		switch se.converted {
+		case convertedUint64:
+			return arrow.PrimitiveTypes.Uint64, nil
+		case convertedTimestampMillis:
+			return timestampType(unitMillis, true)
+		case convertedTimestampMicros:
+			return timestampType(unitMicros, true)
+		case convertedTimeMicros:
+			return arrow.FixedWidthTypes.Time64us, nil
+		}
+		return arrow.PrimitiveTypes.Int64, nil
+
+	case typeInt96:
+		return &arrow.TimestampType{Unit: arrow.Nanosecond}, nil
+
+	case typeFloat:
+		return arrow.PrimitiveTypes.Float32, nil
+
+	case typeDouble:
+		return arrow.PrimitiveTypes.Float64, nil
+
+	case typeByteArray:
+		switch {
+		case lt.kind == logicalString || lt.kind == logicalEnum || lt.kind == logicalJSON,
+			se.converted == convertedUTF8 || se.converted == convertedEnum || se.converted == convertedJSON:
+			return arrow.BinaryTypes.String, nil
+		}
+		return arrow.BinaryTypes.Binary, nil
+
+	case typeFixedLenByteArray:
+		if se.typeLength <= 0 {
+			return nil, fmt.Errorf("invalid fixed length byte array width %d", se.typeLength)
+		}
+		return &arrow.FixedSizeBinaryType{ByteWidth: int(se.typeLength)}, nil
+	}
+	return nil, fmt.Errorf("unknown physical type %d", se.typ)
+}
+
+func integerType(bitWidth int, signed bool) (arrow.DataType, error) {
+	switch {
+	case bitWidth == 8 && signed:
+		return arrow.PrimitiveTypes.Int8, nil
+	case bitWidth == 16 && signed:
+		return arrow.PrimitiveTypes.Int16, nil
+	case bitWidth == 32 && signed:
+		return arrow.PrimitiveTypes.Int32, nil
+	case bitWidth == 64 && signed:
+		return arrow.PrimitiveTypes.Int64, nil
+	case bitWidth == 8:
+		return arrow.PrimitiveTypes.Uint8, nil
+	case bitWidth == 16:
+		return arrow.PrimitiveTypes.Uint16, nil
+	case bitWidth == 32:
+		return arrow.PrimitiveTypes.Uint32, nil
+	case bitWidth == 64:
+		return arrow.PrimitiveTypes.Uint64, nil
+	}
+	return nil, fmt.Errorf("invalid integer width %d", bitWidth)
+}
+
+func timestampType(unit int16, utc bool) (arrow.DataType, error) {
+	dt := &arrow.TimestampType{}
+	switch unit {
+	case unitMillis:
+		dt.Unit = arrow.Millisecond
+	case unitMicros:
+		dt.Unit = arrow.Microsecond
+	case unitNanos:
+		dt.Unit = arrow.Nanosecond
+	default:
+		return nil, fmt.Errorf("invalid timestamp unit %d", unit)
+	}
+	if utc {
+		dt.TimeZone = "UTC"
+	}
+	return dt, nil
+}
+
+// schemaElements returns the Parquet schema elements and the columns of the
+// fields of schema.
+func schemaElements(schema *arrow.Schema) ([]schemaElement, []column, error) {
+	elems := []schemaElement{{typ: -1, name: "schema", numChildren: int32(len(schema.Fields())), converted: convertedNone}}
+	cols := make([]column, len(schema.Fields()))
+	for i, f := range schema.Fields() {
+		se, err := elementOf(f.Type)
+		if err != nil {
+			return nil, nil, fmt.Errorf("arrow/parquet: column %q: %w", f.Name, err)
+		}
+		se.name = f.Name
+		se.repetition = repetitionRequired
+		if f.Nullable {
+			se.repetition = repetitionOptional
+		}
+		elems = append(elems, se)
+		cols[i] = column{field: f, elem: se}
+		if f.Nullable {
+			cols[i].maxDef = 1
+		}
+	}
+	return elems, cols, nil
+}
+
+// elementOf returns the leaf schema element, without name and repetition,
+// of the values of type dt.
+//
+// Second resolution timestamps and times are written with a millisecond
+// resolution, and date64 values as dates.
+func elementOf(dt arrow.DataType) (schemaElement, error) {
+	se := schemaElement{converted: convertedNone}
+	integer := func(typ int32, converted int32, bitWidth int8, signed bool) {
+		se.typ, se.converted = typ, converted
+		se.logical = logicalType{kind: logicalInteger, bitWidth: bitWidth, signed: signed}
+	}
+
+	switch dt := dt.(type) {
+	case *arrow.BooleanType:
+		se.typ = typeBoolean
+	case *arrow.Int8Type:
+		integer(typeInt32, convertedInt8, 8, true)
+	case *arrow.Int16Type:
+		integer(typeInt32, convertedInt16, 16, true)
+	case *arrow.Int32Type:
+		se.typ = typeInt32
+	case *arrow.Int64Type:
+		se.typ = typeInt64
+	case *arrow.Uint8Type:
+		integer(typeInt32, convertedUint8, 8, false)
+	case *arrow.Uint16Type:
+		integer(typeInt32, convertedUint16, 16, false)
+	case *arrow.Uint32Type:
+		integer(typeInt32, convertedUint32, 32, false)
+	case *arrow.Uint64Type:
+		integer(typeInt64, convertedUint64, 64, false)
+	case *arrow.Float32Type:
+		se.typ = typeFloat
+	case *arrow.Float64Type:
+		se.typ = typeDouble
+	case *arrow.StringType:
+		se.typ, se.converted = typeByteArray, convertedUTF8
+		se.logical.kind = logicalString
+	case *arrow.BinaryType:
+		se.typ = typeByteArray
+	case *arrow.FixedSizeBinaryType:
+		se.typ, se.typeLength = typeFixedLenByteArray, int32(dt.ByteWidth)
+	case *arrow.Date32Type, *arrow.Date64Type:
+		se.typ, se.converted = typeInt32, convertedDate
+		se.logical.kind = logicalDate
+	case *arrow.Time32Type:
+		se.typ, se.converted = typeInt32, convertedTimeMillis
+		se.logical = logicalType{kind: logicalTime, unit: unitMillis, utc: true}
+	case *arrow.Time64Type:
+		se.typ = typeInt64
+		se.logical = logicalType{kind: logicalTime, unit: unitNanos, utc: true}
+		if dt.Unit == arrow.Microsecond {
+			se.converted, se.logical.unit = convertedTimeMicros, unitMicros
+		}
+	case *arrow.TimestampType:
+		se.typ = typeInt64
+		se.logical = logicalType{kind: logicalTimestamp, utc: dt.TimeZone != ""}
+		switch dt.Unit {
+		case arrow.Second, arrow.Millisecond:
+			se.logical.unit = unitMillis
+			if se.logical.utc {
+				se.converted = convertedTimestampMillis
+			}
+		case arrow.Microsecond:
+			se.logical.unit = unitMicros
+			if se.logical.utc {
+				se.converted = convertedTimestampMicros
+			}
+		default:
+			se.logical.unit = unitNanos
+		}
+	default:
+		return se, fmt.Errorf("unsupported type %s", dt.Name())
+	}
+	return se, nil
+}
@@ -0,0 +1,256 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// Parquet metadata is serialized with the Thrift compact protocol.
+// See https://github.com/apache/thrift/blob/master/doc/specs/thrift-compact-protocol.md
+
+const (
+	tStop   byte = 0
+	tTrue   byte = 1
+	tFalse  byte = 2
+	tByte   byte = 3
+	tI16    byte = 4
+	tI32    byte = 5
+	tI64    byte = 6
+	tDouble byte = 7
+	tBinary byte = 8
+	tList   byte = 9
+	tSet    byte = 10
+	tMap    byte = 11
+	tStruct byte = 12
+)
+
+var errThrift = errors.New("arrow/parquet: invalid thrift data")
+
+// decoder decodes Thrift compact protocol values.
+// The first error encountered is sticky: later reads return zero values.
+type decoder struct {
+	buf []byte
+	pos int
+	err error
+}
+
+func (d *decoder) fail() {
+	if d.err == nil {
+		d.err = errThrift
+	}
+}
+
+func (d *decoder) byte() byte {
+	if d.err != nil || d.pos >= len(d.buf) {
+		d.fail()
+		return 0
+	}
+	b := d.buf[d.pos]
+	d.pos++
+	return b
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.buf[d.pos:])
+	if n <= 0 {
+		d.fail()
+		return 0
+	}
+	d.pos += n
+	return v
+}
+
+func (d *decoder) varint() int64 {
+	v := d.uvarint()
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+func (d *decoder) i32() int32 { return int32(d.varint()) }
+func (d *decoder) i64() int64 { return d.varint() }
+
+func (d *decoder) double() float64 {
+	if d.err != nil || d.pos+8 > len(d.buf) {
+		d.fail()
+		return 0
+	}
+	v := math.Float64frombits(binary.LittleEndian.Uint64(d.buf[d.pos:]))
+	d.pos += 8
+	return v
+}
+
+func (d *decoder) binary() []byte {
+	n := d.uvarint()
+	if d.err != nil || n > uint64(len(d.buf)-d.pos) {
+		d.fail()
+		return nil
+	}
+	v := d.buf[d.pos : d.pos+int(n)]
+	d.pos += int(n)
+	return v
+}
+
+func (d *decoder) string() string { return string(d.binary()) }
+
+// list reads a list header and returns the type and number of its elements.
+func (d *decoder) list() (byte, int) {
+	b := d.byte()
+	n := int(b >> 4)
+	if n == 15 {
+		n = int(d.uvarint())
+	}
+	if d.err != nil || n < 0 || n > len(d.buf)-d.pos {
+		// every element takes at least one byte.
+		d.fail()
+		return 0, 0
+	}
+	return b & 0x0f, n
+}
+
+// fields reads the fields of a struct, calling fn with the identifier
+// and type of each field. fn must consume the value of the field, or
+// skip it.
+func (d *decoder) fields(fn func(id int16, typ byte)) {
+	var last int16
+	for d.err == nil {
+		b := d.byte()
+		if b == tStop {
+			return
+		}
+		typ := b & 0x0f
+		id := last + int16(b>>4)
+		if b>>4 == 0 {
+			id = int16(d.varint())
+		}
+		last = id
+		fn(id, typ)
+	}
+}
+
+// bool returns the value of a boolean struct field of type typ.
+func (d *decoder) bool(typ byte) bool { return typ == tTrue }
+
+// skip skips a value of type typ. elem reports whether the value is a
+// collection element, in which case booleans take one byte.
+func (d *decoder) skip(typ byte, elem bool) {
+	switch typ {
+	case tTrue, tFalse:
+		if elem {
+			d.byte()
+		}
+	case tByte:
+		d.byte()
+	case tI16, tI32, tI64:
+		d.uvarint()
+	case tDouble:
+		d.double()
+	case tBinary:
+		d.binary()
+	case tList, tSet:
+		et, n := d.list()
+		for i := 0; i < n && d.err == nil; i++ {
+			d.skip(et, true)
+		}
+	case tMap:
+		n := int(d.uvarint())
+		if n == 0 {
+			return
+		}
+		kv := d.byte()
+		for i := 0; i < n && d.err == nil; i++ {
+			d.skip(kv>>4, true)
+			d.skip(kv&0x0f, true)
+		}
+	case tStruct:
+		d.fields(func(id int16, typ byte) { d.skip(typ, false) })
+	default:
+		d.fail()
+	}
+}
+
+// encoder encodes Thrift compact protocol values.
+type encoder struct {
+	buf  []byte
+	last []int16 // last field identifier of each open struct
+}
+
+func (e *encoder) byte(b byte) { e.buf = append(e.buf, b) }
+
+func (e *encoder) uvarint(v uint64) {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	e.buf = append(e.buf, tmp[:n]...)
+}
+
+func (e *encoder) varint(v int64) { e.uvarint(uint64(v<<1) ^ uint64(v>>63)) }
+
+func (e *encoder) i32(v int32) { e.varint(int64(v)) }
+func (e *encoder) i64(v int64) { e.varint(v) }
+
+func (e *encoder) binary(v []byte) {
+	e.uvarint(uint64(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+func (e *encoder) string(v string) {
+	e.uvarint(uint64(len(v)))
+	e.buf = append(e.buf, v...)
+}
+
+func (e *encoder) list(typ byte, n int) {
+	if n < 15 {
+		e.byte(byte(n<<4) | typ)
+		return
+	}
+	e.byte(0xf0 | typ)
+	e.uvarint(uint64(n))
+}
+
+// begin starts a struct, as a top-level value or as the value of a field.
+func (e *encoder) begin() { e.last = append(e.last, 0) }
+
+// end terminates the current struct.
+func (e *encoder) end() {
+	e.byte(tStop)
+	e.last = e.last[:len(e.last)-1]
+}
+
+// field writes the header of the field id of type typ of the current struct.
+func (e *encoder) field(id int16, typ byte) {
+	last := &e.last[len(e.last)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		e.byte(byte(delta<<4) | typ)
+	} else {
+		e.byte(typ)
+		e.varint(int64(id))
+	}
+	*last = id
+}
+
+// boolField writes the boolean field id of the current struct.
+func (e *encoder) boolField(id int16, v bool) {
+	if v {
+		e.field(id, tTrue)
+		return
+	}
+	e.field(id, tFalse)
+}
@@ -0,0 +1,574 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/metrics"
+)
+
+var (
+	ErrMismatchFields = errors.New("arrow/parquet: schema mismatch")
+)
+
+const (
+	defaultRowGroupSize = 1 << 20
+	dictionaryPageLimit = 1 << 20 // maximum size of the PLAIN encoded dictionaries
+	createdBy           = "github.com/apache/arrow/go/arrow/parquet"
+)
+
+// Writer writes Arrow records to a Parquet file.
+//
+// Rows are buffered until a row group is complete, or until Flush or Close
+// is called. Each column chunk is written as a single data page, preceded by
+// a dictionary page when dictionary encoded.
+type Writer struct {
+	w      io.Writer
+	pos    int64
+	schema *arrow.Schema
+	elems  []schemaElement
+	cols   []column
+
+	rowGroupSize int64
+	codec        Codec
+	codecs       map[string]Codec
+	dict         bool
+	dicts        map[string]bool
+	rec          metrics.Recorder
+
+	pending [][]array.Interface // slices of the columns of the current row group
+	nrows   int64               // number of rows of pending
+	groups  []rowGroup
+	closed  bool
+}
+
+// NewWriter returns a writer that writes array.Records with the given schema
+// to a Parquet file.
+// The metadata of the schema is written as the key-value metadata of the file.
+//
+// NewWriter returns an error if the schema holds fields of an unsupported
+// type, or if a codec is not supported.
+func NewWriter(w io.Writer, schema *arrow.Schema, opts ...Option) (*Writer, error) {
+	elems, cols, err := schemaElements(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	pw := &Writer{
+		w:            w,
+		schema:       schema,
+		elems:        elems,
+		cols:         cols,
+		rowGroupSize: defaultRowGroupSize,
+		codec:        Snappy,
+		codecs:       make(map[string]Codec),
+		dict:         true,
+		dicts:        make(map[string]bool),
+		pending:      make([][]array.Interface, len(cols)),
+	}
+	for _, opt := range opts {
+		opt(pw)
+	}
+
+	if err := validCodec(pw.codec); err != nil {
+		return nil, err
+	}
+	for name, codec := range pw.codecs {
+		if schema.FieldIndex(name) < 0 {
+			return nil, fmt.Errorf("arrow/parquet: no column named %q", name)
+		}
+		if err := validCodec(codec); err != nil {
+			return nil, err
+		}
+	}
+	for name := range pw.dicts {
+		if schema.FieldIndex(name) < 0 {
+			return nil, fmt.Errorf("arrow/parquet: no column named %q", name)
+		}
+	}
+
+	if err := pw.write([]byte(magic)); err != nil {
+		return nil, err
+	}
+	return pw, nil
+}
+
+func validCodec(codec Codec) error {
+	switch codec {
+	case Uncompressed, Snappy, Gzip:
+		return nil
+	}
+	return fmt.Errorf("arrow/parquet: unsupported compression codec %v", codec)
+}
+
+func (w *Writer) Schema() *arrow.Schema { return w.schema }
+
+// Write buffers the rows of the record, and writes the row groups they
+// complete.
+func (w *Writer) Write(rec array.Record) error {
+	if w.closed {
+		return fmt.Errorf("arrow/parquet: write to closed writer")
+	}
+	if !rec.Schema().Equal(w.schema) {
+		return ErrMismatchFields
+	}
+	for i, col := range rec.Columns() {
+		if !w.cols[i].field.Nullable && col.NullN() > 0 {
+			return fmt.Errorf("arrow/parquet: column %q: null values in non-nullable column", w.cols[i].field.Name)
+		}
+	}
+
+	for off := int64(0); off < rec.NumRows(); {
+		n := rec.NumRows() - off
+		if n > w.rowGroupSize-w.nrows {
+			n = w.rowGroupSize - w.nrows
+		}
+		for i, col := range rec.Columns() {
+			w.pending[i] = append(w.pending[i], array.NewSlice(col, off, off+n))
+		}
+		w.nrows += n
+		off += n
+
+		if w.nrows == w.rowGroupSize {
+			if err := w.Flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteTable writes the rows of the table.
+func (w *Writer) WriteTable(tbl array.Table) error {
+	if !tbl.Schema().Equal(w.schema) {
+		return ErrMismatchFields
+	}
+
+	tr := array.NewTableReader(tbl, w.rowGroupSize)
+	defer tr.Release()
+
+	for tr.Next() {
+		if err := w.Write(tr.Record()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush writes the buffered rows as a row group.
+func (w *Writer) Flush() error {
+	if w.nrows == 0 {
+		return nil
+	}
+	defer w.release()
+
+	rg := rowGroup{numRows: w.nrows}
+	for i, arrs := range w.pending {
+		cc, err := w.writeColumnChunk(w.cols[i], arrs)
+		if err != nil {
+			return fmt.Errorf("arrow/parquet: column %q: %w", w.cols[i].field.Name, err)
+		}
+		rg.totalByteSize += cc.meta.uncompressedSize
+		rg.columns = append(rg.columns, cc)
+	}
+	w.groups = append(w.groups, rg)
+	return nil
+}
+
+// release releases the buffered rows.
+func (w *Writer) release() {
+	for i, arrs := range w.pending {
+		for _, arr := range arrs {
+			arr.Release()
+		}
+		w.pending[i] = nil
+	}
+	w.nrows = 0
+}
+
+// Close writes the buffered rows and the metadata of the file.
+// Close does not close the underlying io.Writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	defer w.release()
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	md := fileMetaData{
+		version:   1,
+		schema:    w.elems,
+		rowGroups: w.groups,
+		createdBy: createdBy,
+	}
+	for _, rg := range w.groups {
+		md.numRows += rg.numRows
+	}
+	meta := w.schema.Metadata()
+	for i, key := range meta.Keys() {
+		md.keyValues = append(md.keyValues, keyValue{key: key, value: meta.Values()[i]})
+	}
+
+	var e encoder
+	e.fileMetaData(&md)
+	buf := binary.LittleEndian.AppendUint32(e.buf, uint32(len(e.buf)))
+	return w.write(append(buf, magic...))
+}
+
+func (w *Writer) write(p []byte) error {
+	n, err := w.w.Write(p)
+	w.pos += int64(n)
+	return err
+}
+
+// codecOf returns the codec of the column named name.
+func (w *Writer) codecOf(name string) Codec {
+	if codec, ok := w.codecs[name]; ok {
+		return codec
+	}
+	return w.codec
+}
+
+// dictionaryOf returns whether the column named name is dictionary encoded.
+func (w *Writer) dictionaryOf(name string) bool {
+	if dict, ok := w.dicts[name]; ok {
+		return dict
+	}
+	return w.dict
+}
+
+func (w *Writer) writeColumnChunk(col column, arrs []array.Interface) (columnChunk, error) {
+	var (
+		vals  values
+		defs  []uint32
+		nulls int
+		n     int
+	)
+	for _, arr := range arrs {
+		appendValues(&vals, arr)
+		if col.maxDef > 0 {
+			for i := 0; i < arr.Len(); i++ {
+				def := uint32(0)
+				if arr.IsValid(i) {
+					def = 1
+				}
+				defs = append(defs, def)
+			}
+		}
+		nulls += arr.NullN()
+		n += arr.Len()
+	}
+
+	name := col.field.Name
+	codec := w.codecOf(name)
+	cc := columnChunk{
+		fileOffset: w.pos,
+		meta: columnMetaData{
+			typ:       col.elem.typ,
+			encodings: []int32{encodingPlain, encodingRLE},
+			path:      []string{name},
+			codec:     int32(codec),
+			numValues: int64(n),
+			stats:     newStatistics(col, &vals, nulls),
+		},
+	}
+	md := &cc.meta
+
+	var page []byte
+	if col.maxDef > 0 {
+		levels := encodeHybrid(nil, defs, bitWidth(col.maxDef))
+		page = binary.LittleEndian.AppendUint32(page, uint32(len(levels)))
+		page = append(page, levels...)
+	}
+
+	encoding := encodingPlain
+	if w.dictionaryOf(name) && col.elem.typ != typeBoolean && vals.len() > 0 {
+		dict, indices := dictionaryOf(col.elem.typ, &vals)
+		if plain := encodePlain(nil, col.elem.typ, &dict); len(plain) <= dictionaryPageLimit {
+			md.dictPageOffset, md.hasDictPageOffset = w.pos, true
+			ph := pageHeader{
+				typ:  pageDictionary,
+				dict: &dictionaryPageHeader{numValues: int32(dict.len()), encoding: encodingPlain},
+			}
+			if err := w.writePage(md, codec, &ph, plain); err != nil {
+				return cc, err
+			}
+
+			width := bitWidth(dict.len() - 1)
+			if width == 0 {
+				width = 1
+			}
+			page = append(page, byte(width))
+			page = encodeHybrid(page, indices, width)
+			encoding = encodingRLEDictionary
+			md.encodings = append(md.encodings, encodingRLEDictionary)
+		}
+	}
+	if encoding == encodingPlain {
+		page = encodePlain(page, col.elem.typ, &vals)
+	}
+
+	md.dataPageOffset = w.pos
+	ph := pageHeader{
+		typ: pageData,
+		data: &dataPageHeader{
+			numValues:   int32(n),
+			encoding:    encoding,
+			defEncoding: encodingRLE,
+			repEncoding: encodingRLE,
+		},
+	}
+	return cc, w.writePage(md, codec, &ph, page)
+}
+
+// writePage writes a page with the header ph and the uncompressed data, and
+// updates the sizes of the column chunk md.
+func (w *Writer) writePage(md *columnMetaData, codec Codec, ph *pageHeader, data []byte) error {
+	body, err := compress(codec, data)
+	if err != nil {
+		return err
+	}
+	if len(data) > math.MaxInt32 || len(body) > math.MaxInt32 {
+		return fmt.Errorf("page too large (%d bytes)", len(data))
+	}
+	ph.uncompressedSize = int32(len(data))
+	ph.compressedSize = int32(len(body))
+	if w.rec != nil {
+		w.rec.Add(metrics.UncompressedBytes, int64(len(data)))
+		w.rec.Add(metrics.CompressedBytes, int64(len(body)))
+	}
+
+	var e encoder
+	e.pageHeader(ph)
+	md.uncompressedSize += int64(len(e.buf) + len(data))
+	md.compressedSize += int64(len(e.buf) + len(body))
+	if err := w.write(e.buf); err != nil {
+		return err
+	}
+	return w.write(body)
+}
+
+const (
+	millisPerSecond = 1000
+	millisPerDay    = 86400 * 1000
+)
+
+// appendValues appends the valid values of arr to v, converted to their
+// physical type.
+func appendValues(v *values, arr array.Interface) {
+	for i := 0; i < arr.Len(); i++ {
+		if arr.IsNull(i) {
+			continue
+		}
+		switch arr := arr.(type) {
+		case *array.Boolean:
+			v.bools = append(v.bools, arr.Value(i))
+		case *array.Int8:
+			v.int32s = append(v.int32s, int32(arr.Value(i)))
+		case *array.Int16:
+			v.int32s = append(v.int32s, int32(arr.Value(i)))
+		case *array.Int32:
+			v.int32s = append(v.int32s, arr.Value(i))
+		case *array.Int64:
+			v.int64s = append(v.int64s, arr.Value(i))
+		case *array.Uint8:
+			v.int32s = append(v.int32s, int32(arr.Value(i)))
+		case *array.Uint16:
+			v.int32s = append(v.int32s, int32(arr.Value(i)))
+		case *array.Uint32:
+			v.int32s = append(v.int32s, int32(arr.Value(i)))
+		case *array.Uint64:
+			v.int64s = append(v.int64s, int64(arr.Value(i)))
+		case *array.Float32:
+			v.floats = append(v.floats, arr.Value(i))
+		case *array.Float64:
+			v.doubles = append(v.doubles, arr.Value(i))
+		case *array.String:
+			v.bytes = append(v.bytes, []byte(arr.Value(i)))
+		case *array.Binary:
+			v.bytes = append(v.bytes, arr.Value(i))
+		case *array.FixedSizeBinary:
+			v.bytes = append(v.bytes, arr.Value(i))
+		case *array.Date32:
+			v.int32s = append(v.int32s, int32(arr.Value(i)))
+		case *array.Date64:
+			ms := int64(arr.Value(i))
+			days := ms / millisPerDay
+			if ms%millisPerDay < 0 {
+				days--
+			}
+			v.int32s = append(v.int32s, int32(days))
+		case *array.Time32:
+			t := int32(arr.Value(i))
+			if arr.DataType().(*arrow.Time32Type).Unit == arrow.Second {
+				t *= millisPerSecond
+			}
+			v.int32s = append(v.int32s, t)
+		case *array.Time64:
+			v.int64s = append(v.int64s, int64(arr.Value(i)))
+		case *array.Timestamp:
+			t := int64(arr.Value(i))
+			if arr.DataType().(*arrow.TimestampType).Unit == arrow.Second {
+				t *= millisPerSecond
+			}
+			v.int64s = append(v.int64s, t)
+		default:
+			panic(fmt.Errorf("arrow/parquet: unexpected array %T", arr))
+		}
+	}
+}
+
+// dictionaryOf returns the distinct values of v, in order of first
+// occurrence, and the indices of the values of v in the dictionary.
+func dictionaryOf(typ int32, v *values) (values, []uint32) {
+	var (
+		indices = make([]uint32, 0, v.len())
+		index   = make(map[interface{}]uint32)
+		keys    []uint32 // indices in v of the dictionary values
+	)
+	add := func(i int, key interface{}) {
+		k, ok := index[key]
+		if !ok {
+			k = uint32(len(keys))
+			index[key] = k
+			keys = append(keys, uint32(i))
+		}
+		indices = append(indices, k)
+	}
+
+	switch typ {
+	case typeInt32:
+		for i, x := range v.int32s {
+			add(i, x)
+		}
+	case typeInt64:
+		for i, x := range v.int64s {
+			add(i, x)
+		}
+	case typeFloat:
+		for i, x := range v.floats {
+			add(i, math.Float32bits(x))
+		}
+	case typeDouble:
+		for i, x := range v.doubles {
+			add(i, math.Float64bits(x))
+		}
+	default:
+		for i, x := range v.bytes {
+			add(i, string(x))
+		}
+	}
+
+	dict, _ := v.take(keys)
+	return dict, indices
+}
+
+// newStatistics returns the statistics of the values v of col, holding
+// nulls null values.
+func newStatistics(col column, v *values, nulls int) *statistics {
+	st := &statistics{nullCount: int64(nulls), hasNullCount: true}
+
+	min, max := -1, -1
+	less := lessFunc(col, v)
+	for i := 0; i < v.len(); i++ {
+		if v.doubles != nil && math.IsNaN(v.doubles[i]) || v.floats != nil && math.IsNaN(float64(v.floats[i])) {
+			continue
+		}
+		if min < 0 || less(i, min) {
+			min = i
+		}
+		if max < 0 || less(max, i) {
+			max = i
+		}
+	}
+	if min < 0 {
+		return st
+	}
+
+	bounds, _ := v.take([]uint32{uint32(min), uint32(max)})
+	switch {
+	case bounds.floats != nil:
+		// the minimum of zeros is -0, their maximum +0.
+		if bounds.floats[0] == 0 {
+			bounds.floats[0] = float32(math.Copysign(0, -1))
+		}
+		if bounds.floats[1] == 0 {
+			bounds.floats[1] = 0
+		}
+	case bounds.doubles != nil:
+		if bounds.doubles[0] == 0 {
+			bounds.doubles[0] = math.Copysign(0, -1)
+		}
+		if bounds.doubles[1] == 0 {
+			bounds.doubles[1] = 0
+		}
+	}
+
+	st.minValue, st.hasMinValue = statBytes(col.elem.typ, &bounds, 0), true
+	st.maxValue, st.hasMaxValue = statBytes(col.elem.typ, &bounds, 1), true
+	return st
+}
+
+// statBytes returns the encoding of the i-th value of v in statistics:
+// PLAIN, without length for byte arrays.
+func statBytes(typ int32, v *values, i int) []byte {
+	if v.bytes != nil {
+		return append([]byte(nil), v.bytes[i]...)
+	}
+	one, _ := v.take([]uint32{uint32(i)})
+	return encodePlain(nil, typ, &one)
+}
+
+// lessFunc returns the comparison of the values v of col, following the
+// sort order of their logical type.
+func lessFunc(col column, v *values) func(i, j int) bool {
+	unsigned := false
+	switch col.field.Type.ID() {
+	case arrow.UINT8, arrow.UINT16, arrow.UINT32, arrow.UINT64:
+		unsigned = true
+	}
+
+	switch {
+	case v.bools != nil:
+		return func(i, j int) bool { return !v.bools[i] && v.bools[j] }
+	case v.int32s != nil && unsigned:
+		return func(i, j int) bool { return uint32(v.int32s[i]) < uint32(v.int32s[j]) }
+	case v.int32s != nil:
+		return func(i, j int) bool { return v.int32s[i] < v.int32s[j] }
+	case v.int64s != nil && unsigned:
+		return func(i, j int) bool { return uint64(v.int64s[i]) < uint64(v.int64s[j]) }
+	case v.int64s != nil:
+		return func(i, j int) bool { return v.int64s[i] < v.int64s[j] }
+	case v.floats != nil:
+		return func(i, j int) bool { return v.floats[i] < v.floats[j] }
+	case v.doubles != nil:
+		return func(i, j int) bool { return v.doubles[i] < v.doubles[j] }
+	}
+	return func(i, j int) bool { return bytes.Compare(v.bytes[i], v.bytes[j]) < 0 }
+}
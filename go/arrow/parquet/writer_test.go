@@ -0,0 +1,335 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package parquet
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/json"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordFromJSON returns a record from line-delimited JSON rows.
+func recordFromJSON(t *testing.T, mem memory.Allocator, schema *arrow.Schema, rows ...string) array.Record {
+	t.Helper()
+
+	r := json.NewReader(strings.NewReader(strings.Join(rows, "\n")), schema,
+		json.WithAllocator(mem), json.WithChunk(-1))
+	defer r.Release()
+	if !r.Next() {
+		t.Fatalf("could not read rows: %v", r.Err())
+	}
+	rec := r.Record()
+	rec.Retain()
+	return rec
+}
+
+func jsonOf(t *testing.T, rec array.Record) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := json.NewWriter(&buf, rec.Schema(), json.WithTemporalAsNumbers(true)).Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+// writeFile writes the records to a Parquet file.
+func writeFile(t *testing.T, schema *arrow.Schema, recs []array.Record, opts ...Option) *File {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, schema, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rec := range recs {
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return openTestFile(t, buf.Bytes())
+}
+
+func TestWriter(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	md := arrow.NewMetadata([]string{"k1", "k2"}, []string{"v1", "v2"})
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "bool", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+		{Name: "i8", Type: arrow.PrimitiveTypes.Int8},
+		{Name: "i16", Type: arrow.PrimitiveTypes.Int16},
+		{Name: "i32", Type: arrow.PrimitiveTypes.Int32, Nullable: true},
+		{Name: "i64", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "u8", Type: arrow.PrimitiveTypes.Uint8},
+		{Name: "u16", Type: arrow.PrimitiveTypes.Uint16},
+		{Name: "u32", Type: arrow.PrimitiveTypes.Uint32},
+		{Name: "u64", Type: arrow.PrimitiveTypes.Uint64},
+		{Name: "f32", Type: arrow.PrimitiveTypes.Float32},
+		{Name: "f64", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "str", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "bin", Type: arrow.BinaryTypes.Binary},
+		{Name: "fsb", Type: &arrow.FixedSizeBinaryType{ByteWidth: 2}},
+		{Name: "date", Type: arrow.PrimitiveTypes.Date32},
+		{Name: "time32", Type: arrow.FixedWidthTypes.Time32ms},
+		{Name: "time64", Type: arrow.FixedWidthTypes.Time64us},
+		{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}, Nullable: true},
+		{Name: "ts_local", Type: &arrow.TimestampType{Unit: arrow.Nanosecond}},
+	}, &md)
+
+	rows := []string{
+		`{"bool":true,"i8":-1,"i16":-2,"i32":-3,"i64":-4,"u8":1,"u16":2,"u32":4294967295,"u64":18446744073709551615,"f32":1.5,"f64":2.5,"str":"a","bin":"AAE=","fsb":"AAE=","date":1,"time32":1000,"time64":2000,"ts":3000,"ts_local":4000}`,
+		`{"bool":null,"i8":1,"i16":2,"i32":null,"i64":4,"u8":0,"u16":0,"u32":0,"u64":0,"f32":-1.5,"f64":null,"str":null,"bin":"","fsb":"AgM=","date":-1,"time32":0,"time64":0,"ts":null,"ts_local":-1}`,
+		`{"bool":false,"i8":1,"i16":2,"i32":3,"i64":4,"u8":255,"u16":65535,"u32":1,"u64":1,"f32":0,"f64":2.5,"str":"a","bin":"AAE=","fsb":"AAE=","date":1,"time32":1000,"time64":2000,"ts":3000,"ts_local":4000}`,
+	}
+	rec := recordFromJSON(t, mem, schema, rows...)
+	defer rec.Release()
+
+	for _, tc := range []struct {
+		name string
+		opts []Option
+	}{
+		{"default", nil},
+		{"plain", []Option{WithDictionary(false), WithCompression(Uncompressed)}},
+		{"gzip", []Option{WithCompression(Gzip), WithColumnDictionary("str", false)}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			f := writeFile(t, schema, []array.Record{rec}, tc.opts...)
+			if !f.Schema().Equal(schema) {
+				t.Fatalf("invalid schema:\ngot= %v\nwant=%v", f.Schema(), schema)
+			}
+
+			r, err := f.NewReader(WithAllocator(mem))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer r.Release()
+
+			if !r.Next() {
+				t.Fatalf("could not read record: %v", r.Err())
+			}
+			assert.Equal(t, jsonOf(t, rec), jsonOf(t, r.Record()))
+			assert.False(t, r.Next())
+			assert.NoError(t, r.Err())
+		})
+	}
+}
+
+func TestWriterRowGroups(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+	}, nil)
+
+	recs := []array.Record{
+		recordFromJSON(t, mem, schema,
+			`{"id":1,"name":"bob","score":1.5}`,
+			`{"id":2,"name":null,"score":2.5}`,
+			`{"id":3,"name":"alice","score":null}`,
+		),
+		recordFromJSON(t, mem, schema,
+			`{"id":4,"name":"carol","score":null}`,
+			`{"id":5,"name":"dave","score":null}`,
+		),
+	}
+	for _, rec := range recs {
+		defer rec.Release()
+	}
+
+	f := writeFile(t, schema, recs,
+		WithRowGroupSize(2),
+		WithColumnCompression("name", Gzip),
+		WithColumnDictionary("id", false),
+	)
+	assert.Equal(t, int64(5), f.NumRows())
+	assert.Equal(t, 3, f.NumRowGroups())
+
+	for i, rg := range f.meta.rowGroups {
+		assert.Equal(t, []int32{int32(Snappy), int32(Gzip), int32(Snappy)},
+			[]int32{rg.columns[0].meta.codec, rg.columns[1].meta.codec, rg.columns[2].meta.codec}, "row group %d", i)
+		assert.False(t, rg.columns[0].meta.hasDictPageOffset, "row group %d", i)
+	}
+	assert.True(t, f.meta.rowGroups[0].columns[1].meta.hasDictPageOffset)
+	assert.False(t, f.meta.rowGroups[2].columns[2].meta.hasDictPageOffset, "all-null chunk")
+
+	assert.Equal(t, RowGroup{
+		Index:   1,
+		NumRows: 2,
+		Columns: []ColumnStatistics{
+			{Name: "id", HasNullCount: true, HasMinMax: true, Min: int64(3), Max: int64(4)},
+			{Name: "name", HasNullCount: true, HasMinMax: true, Min: "alice", Max: "carol"},
+			{Name: "score", HasNullCount: true, NullCount: 2},
+		},
+	}, f.RowGroup(1))
+
+	r, err := f.NewReader(WithAllocator(mem), WithRowGroupFilter(func(rg RowGroup) bool {
+		return rg.Columns[0].Max.(int64) >= 4
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	assert.Equal(t, []string{
+		"id=3 name=alice score=null, id=4 name=carol score=null",
+		"id=5 name=dave score=null",
+	}, readAll(t, r))
+}
+
+func TestWriterTable(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	recs := []array.Record{
+		recordFromJSON(t, mem, schema, `{"id":1}`, `{"id":2}`),
+		recordFromJSON(t, mem, schema, `{"id":3}`),
+	}
+	for _, rec := range recs {
+		defer rec.Release()
+	}
+	tbl := array.NewTableFromRecords(schema, recs)
+	defer tbl.Release()
+
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, schema, WithRowGroupSize(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteTable(tbl); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	f := openTestFile(t, buf.Bytes())
+	r, err := f.NewReader(WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	assert.Equal(t, []string{"id=1, id=2", "id=3"}, readAll(t, r))
+}
+
+func TestWriterRecorder(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "name", Type: arrow.BinaryTypes.String}}, nil)
+	rows := make([]string, 100)
+	for i := range rows {
+		rows[i] = `{"name":"` + strings.Repeat("abc", i%7) + `"}`
+	}
+	rec := recordFromJSON(t, mem, schema, rows...)
+	defer rec.Release()
+
+	sizes := make(map[string]int64)
+	writeFile(t, schema, []array.Record{rec},
+		WithCompression(Gzip), WithDictionary(false),
+		WithRecorder(metrics.RecorderFunc(func(name string, delta int64) { sizes[name] += delta })),
+	)
+	assert.Len(t, sizes, 2)
+	assert.True(t, sizes[metrics.UncompressedBytes] > 0)
+	assert.True(t, sizes[metrics.CompressedBytes] < sizes[metrics.UncompressedBytes])
+}
+
+func TestWriterErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+
+	for _, tc := range []struct {
+		name   string
+		schema *arrow.Schema
+		opts   []Option
+		err    string
+	}{
+		{
+			name:   "type",
+			schema: arrow.NewSchema([]arrow.Field{{Name: "list", Type: arrow.ListOf(arrow.PrimitiveTypes.Int64)}}, nil),
+			err:    `arrow/parquet: column "list": unsupported type list`,
+		},
+		{
+			name:   "codec",
+			schema: schema,
+			opts:   []Option{WithCompression(Zstd)},
+			err:    "arrow/parquet: unsupported compression codec zstd",
+		},
+		{
+			name:   "column",
+			schema: schema,
+			opts:   []Option{WithColumnCompression("missing", Gzip)},
+			err:    `arrow/parquet: no column named "missing"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewWriter(new(bytes.Buffer), tc.schema, tc.opts...)
+			assert.EqualError(t, err, tc.err)
+		})
+	}
+
+	w, err := NewWriter(new(bytes.Buffer), schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	other := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64, Nullable: true}}, nil)
+	rec := recordFromJSON(t, mem, other, `{"id":null}`)
+	defer rec.Release()
+	assert.Equal(t, ErrMismatchFields, w.Write(rec))
+
+	nulls := array.NewRecord(schema, rec.Columns(), rec.NumRows())
+	defer nulls.Release()
+	assert.EqualError(t, w.Write(nulls), `arrow/parquet: column "id": null values in non-nullable column`)
+
+	assert.NoError(t, w.Close())
+	assert.EqualError(t, w.Write(nulls), "arrow/parquet: write to closed writer")
+}
+
+func TestEncodeHybrid(t *testing.T) {
+	for _, tc := range []struct {
+		width int
+		vals  []uint32
+	}{
+		{1, []uint32{1, 0, 1}},
+		{1, []uint32{1, 1, 1, 1, 1, 1, 1, 1, 1, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}},
+		{3, []uint32{7, 6, 5, 4, 3, 2, 1, 0, 7, 7, 7, 7, 7, 7, 7, 7, 7, 1, 2}},
+		{12, []uint32{4095, 0, 4095, 1, 1, 1, 1, 1, 1, 1, 1}},
+	} {
+		buf := encodeHybrid(nil, tc.vals, tc.width)
+		got, err := decodeHybrid(buf, tc.width, len(tc.vals))
+		assert.NoError(t, err)
+		assert.Equal(t, tc.vals, got)
+	}
+}
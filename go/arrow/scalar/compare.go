@@ -0,0 +1,200 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalar
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+)
+
+// Equal returns true if a and b have the same data type and are either
+// both null or both valid with equal values.
+// Floating point NaN values are equal to each other.
+func Equal(a, b Scalar) bool {
+	if !typeEqual(a.DataType(), b.DataType()) || a.IsValid() != b.IsValid() {
+		return false
+	}
+	if !a.IsValid() {
+		return true
+	}
+
+	if c, ok := compareValues(a, b); ok {
+		return c == 0
+	}
+	switch a := a.(type) {
+	case *DayTimeInterval:
+		return a.Value == b.(*DayTimeInterval).Value
+	case *MonthDayNanoInterval:
+		return a.Value == b.(*MonthDayNanoInterval).Value
+	case *List:
+		return arraysEqual(a.Value, b.(*List).Value)
+	case *LargeList:
+		return arraysEqual(a.Value, b.(*LargeList).Value)
+	case *FixedSizeList:
+		return arraysEqual(a.Value, b.(*FixedSizeList).Value)
+	case *Map:
+		return arraysEqual(a.Value, b.(*Map).Value)
+	case *Struct:
+		for i, v := range a.Value {
+			if !Equal(v, b.(*Struct).Value[i]) {
+				return false
+			}
+		}
+		return true
+	case *Union:
+		b := b.(*Union)
+		return a.TypeCode == b.TypeCode && Equal(a.Value, b.Value)
+	case *RunEndEncoded:
+		return Equal(a.Value, b.(*RunEndEncoded).Value)
+	case *Extension:
+		return Equal(a.Value, b.(*Extension).Value)
+	}
+	return false
+}
+
+// Compare returns -1, 0 or +1 depending on whether a is less than, equal
+// to, or greater than b. Nulls are less than any valid value, and
+// floating point NaN values are greater than any other value.
+//
+// Compare returns an error if a and b do not have the same data type, or
+// if the values of that type are not ordered, such as lists or structs.
+func Compare(a, b Scalar) (int, error) {
+	if !typeEqual(a.DataType(), b.DataType()) {
+		return 0, fmt.Errorf("arrow/scalar: cannot compare %s and %s scalars", a.DataType().Name(), b.DataType().Name())
+	}
+	if !a.IsValid() || !b.IsValid() {
+		if !ordered(a) && a.DataType().ID() != arrow.NULL {
+			return 0, fmt.Errorf("arrow/scalar: %s scalars are not ordered", a.DataType().Name())
+		}
+		return compareNulls(!a.IsValid(), !b.IsValid()), nil
+	}
+	c, ok := compareValues(a, b)
+	if !ok {
+		return 0, fmt.Errorf("arrow/scalar: %s scalars are not ordered", a.DataType().Name())
+	}
+	return c, nil
+}
+
+// compareValues compares the values of the valid scalars a and b, of the
+// same type. It returns false if the values of that type are not ordered.
+func compareValues(a, b Scalar) (int, bool) {
+	if c, ok := compareNumeric(a, b); ok {
+		return c, true
+	}
+	switch a := a.(type) {
+	case *Boolean:
+		x, y := a.Value, b.(*Boolean).Value
+		switch {
+		case x == y:
+			return 0, true
+		case y:
+			return -1, true
+		}
+		return +1, true
+	case *String:
+		return strings.Compare(a.Value, b.(*String).Value), true
+	case *LargeString:
+		return strings.Compare(a.Value, b.(*LargeString).Value), true
+	case *Binary:
+		return bytes.Compare(a.Value, b.(*Binary).Value), true
+	case *LargeBinary:
+		return bytes.Compare(a.Value, b.(*LargeBinary).Value), true
+	case *FixedSizeBinary:
+		return bytes.Compare(a.Value, b.(*FixedSizeBinary).Value), true
+	case *Float16:
+		x, y := a.Value.Float32(), b.(*Float16).Value.Float32()
+		if x != x || y != y { // NaN
+			return compareNaN(x != x, y != y), true
+		}
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return +1, true
+		}
+		return 0, true
+	case *Decimal256:
+		return a.Value.Cmp(b.(*Decimal256).Value), true
+	case *MonthInterval:
+		x, y := a.Value, b.(*MonthInterval).Value
+		switch {
+		case x < y:
+			return -1, true
+		case x > y:
+			return +1, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// ordered returns true if the values of the type of s are ordered.
+func ordered(s Scalar) bool {
+	_, ok := compareValues(s, s)
+	return ok
+}
+
+// compareNaN orders values of which at least one is NaN, NaN being
+// greater than any other value.
+func compareNaN(xnan, ynan bool) int {
+	switch {
+	case xnan && ynan:
+		return 0
+	case xnan:
+		return +1
+	}
+	return -1
+}
+
+// compareNulls orders values of which at least one is null, null being
+// less than any other value.
+func compareNulls(xnull, ynull bool) int {
+	return -compareNaN(xnull, ynull)
+}
+
+func arraysEqual(a, b array.Interface) bool {
+	if a.Len() != b.Len() {
+		return false
+	}
+	for i := 0; i < a.Len(); i++ {
+		x, err := getScalar(a, i)
+		if err != nil {
+			return false
+		}
+		y, err := getScalar(b, i)
+		if err != nil {
+			Release(x)
+			return false
+		}
+		eq := Equal(x, y)
+		Release(x)
+		Release(y)
+		if !eq {
+			return false
+		}
+	}
+	return true
+}
+
+func typeEqual(a, b arrow.DataType) bool {
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,136 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalar_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/float16"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	ms := &arrow.Time32Type{Unit: arrow.Millisecond}
+	for _, tc := range []struct {
+		a, b scalar.Scalar
+		want int
+	}{
+		{scalar.NewInt8Scalar(-1), scalar.NewInt8Scalar(2), -1},
+		{scalar.NewUint64Scalar(math.MaxUint64), scalar.NewUint64Scalar(1), +1},
+		{scalar.NewFloat64Scalar(1.5), scalar.NewFloat64Scalar(1.5), 0},
+		{scalar.NewFloat64Scalar(math.NaN()), scalar.NewFloat64Scalar(math.Inf(1)), +1},
+		{scalar.NewFloat32Scalar(float32(math.NaN())), scalar.NewFloat32Scalar(float32(math.NaN())), 0},
+		{scalar.NewFloat16Scalar(float16.New(-1)), scalar.NewFloat16Scalar(float16.New(0.5)), -1},
+		{scalar.NewFloat16Scalar(float16.FromBits(0x7e00)), scalar.NewFloat16Scalar(float16.New(1)), +1},
+		{scalar.NewTime32Scalar(10, ms), scalar.NewTime32Scalar(20, ms), -1},
+		{scalar.NewDurationScalar(-5, &arrow.DurationType{Unit: arrow.Second}), scalar.NewDurationScalar(5, &arrow.DurationType{Unit: arrow.Second}), -1},
+		{scalar.NewMonthIntervalScalar(12), scalar.NewMonthIntervalScalar(1), +1},
+		{scalar.NewBooleanScalar(true), scalar.NewBooleanScalar(false), +1},
+		{scalar.NewStringScalar("abc"), scalar.NewStringScalar("abd"), -1},
+		{scalar.NewBinaryScalar([]byte("b")), scalar.NewBinaryScalar([]byte("a")), +1},
+		{scalar.MakeNullScalar(arrow.PrimitiveTypes.Int32), scalar.NewInt32Scalar(math.MinInt32), -1},
+		{scalar.MakeNullScalar(arrow.BinaryTypes.String), scalar.MakeNullScalar(arrow.BinaryTypes.String), 0},
+	} {
+		got, err := scalar.Compare(tc.a, tc.b)
+		assert.NoError(t, err, "%v <=> %v", tc.a, tc.b)
+		assert.Equal(t, tc.want, got, "%v <=> %v", tc.a, tc.b)
+
+		got, err = scalar.Compare(tc.b, tc.a)
+		assert.NoError(t, err)
+		assert.Equal(t, -tc.want, got, "%v <=> %v", tc.b, tc.a)
+
+		assert.Equal(t, tc.want == 0, scalar.Equal(tc.a, tc.b), "%v == %v", tc.a, tc.b)
+	}
+
+	_, err := scalar.Compare(scalar.NewInt32Scalar(1), scalar.NewInt64Scalar(1))
+	assert.Error(t, err)
+
+	_, err = scalar.Compare(
+		scalar.NewTime32Scalar(1, ms),
+		scalar.NewTime32Scalar(1, &arrow.Time32Type{Unit: arrow.Second}),
+	)
+	assert.Error(t, err)
+
+	dt := arrow.StructOf(arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int8})
+	s := scalar.NewStructScalar([]scalar.Scalar{scalar.NewInt8Scalar(1)}, dt)
+	_, err = scalar.Compare(s, s)
+	assert.Error(t, err)
+	_, err = scalar.Compare(scalar.MakeNullScalar(dt), s)
+	assert.Error(t, err)
+
+	// day-time and month-day-nano intervals are not ordered, a month not
+	// being a fixed number of days.
+	a := scalar.NewMonthDayNanoIntervalScalar(arrow.MonthDayNanoInterval{Months: 1})
+	b := scalar.NewMonthDayNanoIntervalScalar(arrow.MonthDayNanoInterval{Days: 30})
+	_, err = scalar.Compare(a, b)
+	assert.Error(t, err)
+	assert.False(t, scalar.Equal(a, b))
+	assert.True(t, scalar.Equal(a, scalar.NewMonthDayNanoIntervalScalar(arrow.MonthDayNanoInterval{Months: 1})))
+	assert.True(t, scalar.Equal(
+		scalar.NewDayTimeIntervalScalar(arrow.DayTimeInterval{Days: 1}),
+		scalar.NewDayTimeIntervalScalar(arrow.DayTimeInterval{Days: 1}),
+	))
+}
+
+func TestEqualNested(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	bld := array.NewListBuilder(mem, arrow.PrimitiveTypes.Int64)
+	defer bld.Release()
+	vb := bld.ValueBuilder().(*array.Int64Builder)
+	for _, vs := range [][]int64{{1, 2}, {3}, {1, 2}, {1, 2, 3}} {
+		bld.Append(true)
+		vb.AppendValues(vs, nil)
+	}
+	arr := bld.NewListArray()
+	defer arr.Release()
+
+	get := func(i int) scalar.Scalar {
+		s, err := scalar.GetScalar(arr, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s
+	}
+	l0, l1, l2, l3 := get(0), get(1), get(2), get(3)
+	defer scalar.Release(l0)
+	defer scalar.Release(l1)
+	defer scalar.Release(l2)
+	defer scalar.Release(l3)
+
+	assert.True(t, scalar.Equal(l0, l2))
+	assert.False(t, scalar.Equal(l0, l1))
+	assert.False(t, scalar.Equal(l0, l3))
+	assert.False(t, scalar.Equal(l0, scalar.MakeNullScalar(l0.DataType())))
+
+	dt := arrow.StructOf(
+		arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int8},
+		arrow.Field{Name: "b", Type: arrow.BinaryTypes.String},
+	)
+	s1 := scalar.NewStructScalar([]scalar.Scalar{scalar.NewInt8Scalar(1), scalar.NewStringScalar("x")}, dt)
+	s2 := scalar.NewStructScalar([]scalar.Scalar{scalar.NewInt8Scalar(1), scalar.NewStringScalar("x")}, dt)
+	s3 := scalar.NewStructScalar([]scalar.Scalar{scalar.NewInt8Scalar(1), scalar.MakeNullScalar(arrow.BinaryTypes.String)}, dt)
+	assert.True(t, scalar.Equal(s1, s2))
+	assert.False(t, scalar.Equal(s1, s3))
+	assert.False(t, scalar.Equal(s1, scalar.NewInt8Scalar(1)))
+}
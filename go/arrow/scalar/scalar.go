@@ -0,0 +1,673 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scalar provides typed values of the Arrow data types, such as a
+// single element of an array.
+package scalar
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/float16"
+)
+
+// Scalar represents a single value of an Arrow data type, possibly null.
+type Scalar interface {
+	fmt.Stringer
+
+	// DataType returns the type of the scalar.
+	DataType() arrow.DataType
+
+	// IsValid returns true if the scalar is not null.
+	IsValid() bool
+}
+
+// Releasable is implemented by the scalars holding arrays, directly or
+// through their children, such as lists. They must be released after use.
+type Releasable interface {
+	// Retain increases the reference count of the held arrays by 1.
+	Retain()
+
+	// Release decreases the reference count of the held arrays by 1.
+	Release()
+}
+
+// Release releases s if it is Releasable, and does nothing otherwise.
+func Release(s Scalar) {
+	if r, ok := s.(Releasable); ok {
+		r.Release()
+	}
+}
+
+type scalar struct {
+	Type  arrow.DataType
+	Valid bool
+}
+
+func (s scalar) DataType() arrow.DataType { return s.Type }
+func (s scalar) IsValid() bool            { return s.Valid }
+
+// Null is the scalar of the null type, which is never valid.
+type Null struct {
+	scalar
+}
+
+// NewNullScalar returns the scalar of the null type.
+func NewNullScalar() *Null { return &Null{scalar{Type: arrow.Null}} }
+
+func (s *Null) String() string { return "(null)" }
+
+// Boolean is a scalar of type bool.
+type Boolean struct {
+	scalar
+	Value bool
+}
+
+// NewBooleanScalar returns a valid scalar holding v.
+func NewBooleanScalar(v bool) *Boolean {
+	return &Boolean{scalar: scalar{Type: arrow.FixedWidthTypes.Boolean, Valid: true}, Value: v}
+}
+
+func (s *Boolean) String() string {
+	if !s.Valid {
+		return "(null)"
+	}
+	return strconv.FormatBool(s.Value)
+}
+
+// String is a scalar of type utf8.
+type String struct {
+	scalar
+	Value string
+}
+
+// NewStringScalar returns a valid scalar holding v.
+func NewStringScalar(v string) *String {
+	return &String{scalar: scalar{Type: arrow.BinaryTypes.String, Valid: true}, Value: v}
+}
+
+func (s *String) String() string {
+	if !s.Valid {
+		return "(null)"
+	}
+	return strconv.Quote(s.Value)
+}
+
+// LargeString is a scalar of type large_utf8.
+type LargeString struct {
+	scalar
+	Value string
+}
+
+// NewLargeStringScalar returns a valid scalar holding v.
+func NewLargeStringScalar(v string) *LargeString {
+	return &LargeString{scalar: scalar{Type: arrow.BinaryTypes.LargeString, Valid: true}, Value: v}
+}
+
+func (s *LargeString) String() string {
+	if !s.Valid {
+		return "(null)"
+	}
+	return strconv.Quote(s.Value)
+}
+
+// Binary is a scalar of type binary.
+type Binary struct {
+	scalar
+	Value []byte
+}
+
+// NewBinaryScalar returns a valid scalar holding v.
+func NewBinaryScalar(v []byte) *Binary {
+	return &Binary{scalar: scalar{Type: arrow.BinaryTypes.Binary, Valid: true}, Value: v}
+}
+
+func (s *Binary) String() string {
+	if !s.Valid {
+		return "(null)"
+	}
+	return fmt.Sprintf("%q", s.Value)
+}
+
+// LargeBinary is a scalar of type large_binary.
+type LargeBinary struct {
+	scalar
+	Value []byte
+}
+
+// NewLargeBinaryScalar returns a valid scalar holding v.
+func NewLargeBinaryScalar(v []byte) *LargeBinary {
+	return &LargeBinary{scalar: scalar{Type: arrow.BinaryTypes.LargeBinary, Valid: true}, Value: v}
+}
+
+func (s *LargeBinary) String() string {
+	if !s.Valid {
+		return "(null)"
+	}
+	return fmt.Sprintf("%q", s.Value)
+}
+
+// FixedSizeBinary is a scalar of type fixed_size_binary.
+type FixedSizeBinary struct {
+	scalar
+	Value []byte
+}
+
+// NewFixedSizeBinaryScalar returns a valid scalar of type dt holding v.
+//
+// NewFixedSizeBinaryScalar panics if the length of v is not the byte width
+// of dt.
+func NewFixedSizeBinaryScalar(v []byte, dt *arrow.FixedSizeBinaryType) *FixedSizeBinary {
+	if len(v) != dt.ByteWidth {
+		panic(fmt.Errorf("arrow/scalar: %d bytes for a fixed size binary of width %d", len(v), dt.ByteWidth))
+	}
+	return &FixedSizeBinary{scalar: scalar{Type: dt, Valid: true}, Value: v}
+}
+
+func (s *FixedSizeBinary) String() string {
+	if !s.Valid {
+		return "(null)"
+	}
+	return fmt.Sprintf("%q", s.Value)
+}
+
+// Float16 is a scalar of type float16.
+type Float16 struct {
+	scalar
+	Value float16.Num
+}
+
+// NewFloat16Scalar returns a valid scalar holding v.
+func NewFloat16Scalar(v float16.Num) *Float16 {
+	return &Float16{scalar: scalar{Type: arrow.FixedWidthTypes.Float16, Valid: true}, Value: v}
+}
+
+func (s *Float16) String() string {
+	if !s.Valid {
+		return "(null)"
+	}
+	return s.Value.String()
+}
+
+// Decimal256 is a scalar of type decimal256.
+type Decimal256 struct {
+	scalar
+	Value decimal256.Num
+}
+
+// NewDecimal256Scalar returns a valid scalar of type dt holding v.
+func NewDecimal256Scalar(v decimal256.Num, dt *arrow.Decimal256Type) *Decimal256 {
+	return &Decimal256{scalar: scalar{Type: dt, Valid: true}, Value: v}
+}
+
+func (s *Decimal256) String() string {
+	if !s.Valid {
+		return "(null)"
+	}
+	return s.Value.ToString(s.Type.(*arrow.Decimal256Type).Scale)
+}
+
+// MonthInterval is a scalar of type month_interval.
+type MonthInterval struct {
+	scalar
+	Value arrow.MonthInterval
+}
+
+// NewMonthIntervalScalar returns a valid month interval scalar holding v.
+func NewMonthIntervalScalar(v arrow.MonthInterval) *MonthInterval {
+	return &MonthInterval{scalar: scalar{Type: arrow.FixedWidthTypes.MonthInterval, Valid: true}, Value: v}
+}
+
+func (s *MonthInterval) String() string {
+	if !s.Valid {
+		return "(null)"
+	}
+	return fmt.Sprintf("%v", s.Value)
+}
+
+// DayTimeInterval is a scalar of type day_time_interval.
+type DayTimeInterval struct {
+	scalar
+	Value arrow.DayTimeInterval
+}
+
+// NewDayTimeIntervalScalar returns a valid day-time interval scalar holding v.
+func NewDayTimeIntervalScalar(v arrow.DayTimeInterval) *DayTimeInterval {
+	return &DayTimeInterval{scalar: scalar{Type: arrow.FixedWidthTypes.DayTimeInterval, Valid: true}, Value: v}
+}
+
+func (s *DayTimeInterval) String() string {
+	if !s.Valid {
+		return "(null)"
+	}
+	return s.Value.String()
+}
+
+// MonthDayNanoInterval is a scalar of type month_day_nano_interval.
+type MonthDayNanoInterval struct {
+	scalar
+	Value arrow.MonthDayNanoInterval
+}
+
+// NewMonthDayNanoIntervalScalar returns a valid month-day-nano interval
+// scalar holding v.
+func NewMonthDayNanoIntervalScalar(v arrow.MonthDayNanoInterval) *MonthDayNanoInterval {
+	return &MonthDayNanoInterval{scalar: scalar{Type: arrow.FixedWidthTypes.MonthDayNanoInterval, Valid: true}, Value: v}
+}
+
+func (s *MonthDayNanoInterval) String() string {
+	if !s.Valid {
+		return "(null)"
+	}
+	return s.Value.String()
+}
+
+type list struct {
+	scalar
+	Value array.Interface
+}
+
+func (s *list) Retain() {
+	if s.Value != nil {
+		s.Value.Retain()
+	}
+}
+
+func (s *list) Release() {
+	if s.Value != nil {
+		s.Value.Release()
+	}
+}
+
+func (s *list) String() string {
+	if !s.Valid {
+		return "(null)"
+	}
+	o := new(strings.Builder)
+	o.WriteString("[")
+	for i := 0; i < s.Value.Len(); i++ {
+		if i > 0 {
+			o.WriteString(" ")
+		}
+		o.WriteString(array.FormatValue(s.Value, i))
+	}
+	o.WriteString("]")
+	return o.String()
+}
+
+// List is a scalar of type list, holding the array of its elements.
+type List struct {
+	list
+}
+
+// NewListScalar returns a valid scalar holding the elements of v, which it
+// retains.
+func NewListScalar(v array.Interface) *List {
+	v.Retain()
+	return &List{list{scalar: scalar{Type: arrow.ListOf(v.DataType()), Valid: true}, Value: v}}
+}
+
+// LargeList is a scalar of type large_list, holding the array of its
+// elements.
+type LargeList struct {
+	list
+}
+
+// NewLargeListScalar returns a valid scalar holding the elements of v,
+// which it retains.
+func NewLargeListScalar(v array.Interface) *LargeList {
+	v.Retain()
+	return &LargeList{list{scalar: scalar{Type: arrow.LargeListOf(v.DataType()), Valid: true}, Value: v}}
+}
+
+// FixedSizeList is a scalar of type fixed_size_list, holding the array of
+// its elements.
+type FixedSizeList struct {
+	list
+}
+
+// NewFixedSizeListScalar returns a valid scalar holding the elements of v,
+// which it retains. The list length is the length of v.
+func NewFixedSizeListScalar(v array.Interface) *FixedSizeList {
+	v.Retain()
+	return &FixedSizeList{list{scalar: scalar{Type: arrow.FixedSizeListOf(int32(v.Len()), v.DataType()), Valid: true}, Value: v}}
+}
+
+// Map is a scalar of type map, holding the struct array of its key/item
+// pairs.
+type Map struct {
+	list
+}
+
+// NewMapScalar returns a valid scalar of type dt holding the key/item
+// pairs of v, which it retains.
+func NewMapScalar(v *array.Struct, dt *arrow.MapType) *Map {
+	v.Retain()
+	return &Map{list{scalar: scalar{Type: dt, Valid: true}, Value: v}}
+}
+
+// Struct is a scalar of type struct, holding a scalar per field.
+type Struct struct {
+	scalar
+	Value []Scalar
+}
+
+// NewStructScalar returns a valid scalar of type dt holding the values of
+// its fields. The values are owned by the returned scalar.
+//
+// NewStructScalar panics if the number of values is not the number of
+// fields of dt.
+func NewStructScalar(v []Scalar, dt *arrow.StructType) *Struct {
+	if len(v) != len(dt.Fields()) {
+		panic(fmt.Errorf("arrow/scalar: %d values for a struct of %d fields", len(v), len(dt.Fields())))
+	}
+	return &Struct{scalar: scalar{Type: dt, Valid: true}, Value: v}
+}
+
+// Field returns the value of the field with the given name.
+func (s *Struct) Field(name string) (Scalar, bool) {
+	if !s.Valid {
+		return nil, false
+	}
+	for i, f := range s.Type.(*arrow.StructType).Fields() {
+		if f.Name == name {
+			return s.Value[i], true
+		}
+	}
+	return nil, false
+}
+
+func (s *Struct) Retain() {
+	for _, v := range s.Value {
+		if r, ok := v.(Releasable); ok {
+			r.Retain()
+		}
+	}
+}
+
+func (s *Struct) Release() {
+	for _, v := range s.Value {
+		Release(v)
+	}
+}
+
+func (s *Struct) String() string {
+	if !s.Valid {
+		return "(null)"
+	}
+	o := new(strings.Builder)
+	o.WriteString("{")
+	for i, f := range s.Type.(*arrow.StructType).Fields() {
+		if i > 0 {
+			o.WriteString(", ")
+		}
+		o.WriteString(f.Name)
+		o.WriteString(": ")
+		o.WriteString(s.Value[i].String())
+	}
+	o.WriteString("}")
+	return o.String()
+}
+
+// wrapper is embedded by the scalars holding a single child scalar.
+type wrapper struct {
+	scalar
+	Value Scalar
+}
+
+func (s *wrapper) Retain() {
+	if r, ok := s.Value.(Releasable); ok {
+		r.Retain()
+	}
+}
+
+func (s *wrapper) Release() {
+	if s.Value != nil {
+		Release(s.Value)
+	}
+}
+
+func (s *wrapper) String() string {
+	if !s.Valid {
+		return "(null)"
+	}
+	return s.Value.String()
+}
+
+// Union is a scalar of type union, holding the value of the child selected
+// by its type code.
+type Union struct {
+	wrapper
+	TypeCode int8
+}
+
+// NewUnionScalar returns a valid scalar of type dt holding the value v of
+// the child with the given type code. The value is owned by the returned
+// scalar.
+//
+// NewUnionScalar panics if dt has no child with that type code.
+func NewUnionScalar(v Scalar, code int8, dt *arrow.UnionType) *Union {
+	if dt.ChildID(code) < 0 {
+		panic(fmt.Errorf("arrow/scalar: invalid union type code %d", code))
+	}
+	return &Union{wrapper: wrapper{scalar: scalar{Type: dt, Valid: true}, Value: v}, TypeCode: code}
+}
+
+// RunEndEncoded is a scalar of type run_end_encoded, holding the encoded
+// value.
+type RunEndEncoded struct {
+	wrapper
+}
+
+// NewRunEndEncodedScalar returns a valid scalar of type dt holding v.
+// The value is owned by the returned scalar.
+func NewRunEndEncodedScalar(v Scalar, dt *arrow.RunEndEncodedType) *RunEndEncoded {
+	return &RunEndEncoded{wrapper{scalar: scalar{Type: dt, Valid: true}, Value: v}}
+}
+
+// Extension is a scalar of an extension type, holding the value of its
+// storage type.
+type Extension struct {
+	wrapper
+}
+
+// NewExtensionScalar returns a valid scalar of type dt holding the storage
+// value v. The value is owned by the returned scalar.
+func NewExtensionScalar(v Scalar, dt arrow.ExtensionType) *Extension {
+	return &Extension{wrapper{scalar: scalar{Type: dt, Valid: true}, Value: v}}
+}
+
+// MakeNullScalar returns the null scalar of type dt.
+//
+// MakeNullScalar panics if dt is not supported.
+func MakeNullScalar(dt arrow.DataType) Scalar {
+	s := makeNull(dt)
+	if s == nil {
+		panic(fmt.Errorf("arrow/scalar: unsupported data type %s", dt.Name()))
+	}
+	return s
+}
+
+// makeNull returns the null scalar of type dt, or nil if dt is not
+// supported.
+func makeNull(dt arrow.DataType) Scalar {
+	if s := nullNumeric(dt); s != nil {
+		return s
+	}
+
+	null := scalar{Type: dt}
+	switch dt.ID() {
+	case arrow.NULL:
+		return &Null{null}
+	case arrow.BOOL:
+		return &Boolean{scalar: null}
+	case arrow.STRING:
+		return &String{scalar: null}
+	case arrow.LARGE_STRING:
+		return &LargeString{scalar: null}
+	case arrow.BINARY:
+		return &Binary{scalar: null}
+	case arrow.LARGE_BINARY:
+		return &LargeBinary{scalar: null}
+	case arrow.FIXED_SIZE_BINARY:
+		return &FixedSizeBinary{scalar: null}
+	case arrow.HALF_FLOAT:
+		return &Float16{scalar: null}
+	case arrow.DECIMAL256:
+		return &Decimal256{scalar: null}
+	case arrow.INTERVAL:
+		switch dt.(type) {
+		case *arrow.MonthIntervalType:
+			return &MonthInterval{scalar: null}
+		case *arrow.DayTimeIntervalType:
+			return &DayTimeInterval{scalar: null}
+		case *arrow.MonthDayNanoIntervalType:
+			return &MonthDayNanoInterval{scalar: null}
+		}
+	case arrow.LIST:
+		return &List{list{scalar: null}}
+	case arrow.LARGE_LIST:
+		return &LargeList{list{scalar: null}}
+	case arrow.FIXED_SIZE_LIST:
+		return &FixedSizeList{list{scalar: null}}
+	case arrow.MAP:
+		return &Map{list{scalar: null}}
+	case arrow.STRUCT:
+		return &Struct{scalar: null}
+	case arrow.UNION:
+		return &Union{wrapper: wrapper{scalar: null}}
+	case arrow.RUN_END_ENCODED:
+		return &RunEndEncoded{wrapper{scalar: null}}
+	case arrow.EXTENSION:
+		return &Extension{wrapper{scalar: null}}
+	}
+	return nil
+}
+
+// GetScalar returns the scalar of the i-th element of arr.
+// Binary values are copied, while list and map scalars hold a slice of the
+// values of arr: Releasable scalars must be released after use.
+//
+// GetScalar returns an error if i is out of range, or if the type of arr
+// is not supported.
+func GetScalar(arr array.Interface, i int) (Scalar, error) {
+	if i < 0 || i >= arr.Len() {
+		return nil, fmt.Errorf("arrow/scalar: index %d out of range [0, %d)", i, arr.Len())
+	}
+	s, err := getScalar(arr, i)
+	if err != nil {
+		return nil, fmt.Errorf("arrow/scalar: %w", err)
+	}
+	return s, nil
+}
+
+func getScalar(arr array.Interface, i int) (Scalar, error) {
+	if arr.IsNull(i) {
+		if s := makeNull(arr.DataType()); s != nil {
+			return s, nil
+		}
+		return nil, fmt.Errorf("unsupported data type %s", arr.DataType().Name())
+	}
+	if s := getNumeric(arr, i); s != nil {
+		return s, nil
+	}
+
+	valid := scalar{Type: arr.DataType(), Valid: true}
+	switch arr := arr.(type) {
+	case *array.Boolean:
+		return &Boolean{scalar: valid, Value: arr.Value(i)}, nil
+	case *array.String:
+		return &String{scalar: valid, Value: arr.Value(i)}, nil
+	case *array.LargeString:
+		return &LargeString{scalar: valid, Value: arr.Value(i)}, nil
+	case *array.Binary:
+		return &Binary{scalar: valid, Value: append([]byte(nil), arr.Value(i)...)}, nil
+	case *array.LargeBinary:
+		return &LargeBinary{scalar: valid, Value: append([]byte(nil), arr.Value(i)...)}, nil
+	case *array.FixedSizeBinary:
+		return &FixedSizeBinary{scalar: valid, Value: append([]byte(nil), arr.Value(i)...)}, nil
+	case *array.Float16:
+		return &Float16{scalar: valid, Value: arr.Value(i)}, nil
+	case *array.Decimal256:
+		return &Decimal256{scalar: valid, Value: arr.Value(i)}, nil
+	case *array.MonthInterval:
+		return &MonthInterval{scalar: valid, Value: arr.Value(i)}, nil
+	case *array.DayTimeInterval:
+		return &DayTimeInterval{scalar: valid, Value: arr.Value(i)}, nil
+	case *array.MonthDayNanoInterval:
+		return &MonthDayNanoInterval{scalar: valid, Value: arr.Value(i)}, nil
+	case *array.Map:
+		j := arr.Data().Offset() + i
+		beg, end := int64(arr.Offsets()[j]), int64(arr.Offsets()[j+1])
+		return &Map{list{scalar: valid, Value: array.NewSlice(arr.ListValues(), beg, end)}}, nil
+	case *array.List:
+		j := arr.Data().Offset() + i
+		beg, end := int64(arr.Offsets()[j]), int64(arr.Offsets()[j+1])
+		return &List{list{scalar: valid, Value: array.NewSlice(arr.ListValues(), beg, end)}}, nil
+	case *array.LargeList:
+		j := arr.Data().Offset() + i
+		beg, end := arr.Offsets()[j], arr.Offsets()[j+1]
+		return &LargeList{list{scalar: valid, Value: array.NewSlice(arr.ListValues(), beg, end)}}, nil
+	case *array.FixedSizeList:
+		return &FixedSizeList{list{scalar: valid, Value: arr.ValueSlice(i)}}, nil
+	case *array.Struct:
+		s := &Struct{scalar: valid, Value: make([]Scalar, arr.NumField())}
+		for k := range s.Value {
+			v, err := getScalar(arr.Field(k), i)
+			if err != nil {
+				s.Release()
+				return nil, err
+			}
+			s.Value[k] = v
+		}
+		return s, nil
+	case *array.Union:
+		v, err := getScalar(arr.Field(arr.ChildID(i)), arr.ValueOffset(i))
+		if err != nil {
+			return nil, err
+		}
+		return &Union{wrapper: wrapper{scalar: valid, Value: v}, TypeCode: arr.TypeCode(i)}, nil
+	case *array.RunEndEncoded:
+		v, err := getScalar(arr.Values(), arr.PhysicalIndex(i))
+		if err != nil {
+			return nil, err
+		}
+		return &RunEndEncoded{wrapper{scalar: valid, Value: v}}, nil
+	case array.ExtensionArray:
+		v, err := getScalar(arr.Storage(), i)
+		if err != nil {
+			return nil, err
+		}
+		return &Extension{wrapper{scalar: valid, Value: v}}, nil
+	}
+	return nil, fmt.Errorf("unsupported data type %s", arr.DataType().Name())
+}
+
+var (
+	_ Releasable = (*List)(nil)
+	_ Releasable = (*LargeList)(nil)
+	_ Releasable = (*FixedSizeList)(nil)
+	_ Releasable = (*Map)(nil)
+	_ Releasable = (*Struct)(nil)
+	_ Releasable = (*Union)(nil)
+	_ Releasable = (*RunEndEncoded)(nil)
+	_ Releasable = (*Extension)(nil)
+)
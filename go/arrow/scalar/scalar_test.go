@@ -0,0 +1,233 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scalar_test
+
+import (
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/float16"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/scalar"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetScalarPrimitive(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	ib := array.NewInt32Builder(mem)
+	defer ib.Release()
+	ib.AppendValues([]int32{1, 0, 3}, []bool{true, false, true})
+	ints := ib.NewInt32Array()
+	defer ints.Release()
+
+	s, err := scalar.GetScalar(ints, 2)
+	assert.NoError(t, err)
+	assert.IsType(t, (*scalar.Int32)(nil), s)
+	assert.Equal(t, int32(3), s.(*scalar.Int32).Value)
+	assert.True(t, s.IsValid())
+	assert.Equal(t, "3", s.String())
+
+	s, err = scalar.GetScalar(ints, 1)
+	assert.NoError(t, err)
+	assert.IsType(t, (*scalar.Int32)(nil), s)
+	assert.False(t, s.IsValid())
+	assert.Equal(t, "(null)", s.String())
+
+	_, err = scalar.GetScalar(ints, 3)
+	assert.Error(t, err)
+
+	tb := array.NewTimestampBuilder(mem, &arrow.TimestampType{Unit: arrow.Millisecond})
+	defer tb.Release()
+	tb.Append(42)
+	ts := tb.NewTimestampArray()
+	defer ts.Release()
+
+	s, err = scalar.GetScalar(ts, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, arrow.Timestamp(42), s.(*scalar.Timestamp).Value)
+	assert.Equal(t, ts.DataType(), s.DataType())
+
+	sb := array.NewStringBuilder(mem)
+	defer sb.Release()
+	sb.AppendValues([]string{"a", "héllo"}, nil)
+	strs := sb.NewStringArray()
+	defer strs.Release()
+
+	s, err = scalar.GetScalar(strs, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "héllo", s.(*scalar.String).Value)
+	assert.Equal(t, `"héllo"`, s.String())
+
+	bb := array.NewBinaryBuilder(mem, arrow.BinaryTypes.Binary)
+	defer bb.Release()
+	bb.Append([]byte("xyz"))
+	bins := bb.NewBinaryArray()
+	defer bins.Release()
+
+	s, err = scalar.GetScalar(bins, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("xyz"), s.(*scalar.Binary).Value)
+
+	dt := &arrow.Decimal256Type{Precision: 10, Scale: 2}
+	db := array.NewDecimal256Builder(mem, dt)
+	defer db.Release()
+	db.Append(decimal256.FromI64(12345))
+	decs := db.NewDecimal256Array()
+	defer decs.Release()
+
+	s, err = scalar.GetScalar(decs, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "123.45", s.String())
+
+	fb := array.NewFloat16Builder(mem)
+	defer fb.Release()
+	fb.AppendFloat32(0.1)
+	fb.AppendNull()
+	f16s := fb.NewArray()
+	defer f16s.Release()
+
+	s, err = scalar.GetScalar(f16s, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, float16.New(0.1), s.(*scalar.Float16).Value)
+	assert.Equal(t, "0.1", s.String())
+
+	s, err = scalar.GetScalar(f16s, 1)
+	assert.NoError(t, err)
+	assert.IsType(t, (*scalar.Float16)(nil), s)
+	assert.False(t, s.IsValid())
+
+	mb := array.NewMonthDayNanoIntervalBuilder(mem)
+	defer mb.Release()
+	mb.Append(arrow.MonthDayNanoInterval{Months: 1, Days: 2, Nanoseconds: 3})
+	mb.AppendNull()
+	ivs := mb.NewMonthDayNanoIntervalArray()
+	defer ivs.Release()
+
+	s, err = scalar.GetScalar(ivs, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, arrow.MonthDayNanoInterval{Months: 1, Days: 2, Nanoseconds: 3}, s.(*scalar.MonthDayNanoInterval).Value)
+	assert.Equal(t, "1M2d3ns", s.String())
+
+	s, err = scalar.GetScalar(ivs, 1)
+	assert.NoError(t, err)
+	assert.IsType(t, (*scalar.MonthDayNanoInterval)(nil), s)
+	assert.False(t, s.IsValid())
+}
+
+func TestGetScalarNested(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	dt := arrow.StructOf(
+		arrow.Field{Name: "name", Type: arrow.BinaryTypes.String},
+		arrow.Field{Name: "tags", Type: arrow.ListOf(arrow.PrimitiveTypes.Int64), Nullable: true},
+	)
+	bld := array.NewStructBuilder(mem, dt)
+	defer bld.Release()
+
+	nb := bld.FieldBuilder(0).(*array.StringBuilder)
+	lb := bld.FieldBuilder(1).(*array.ListBuilder)
+	vb := lb.ValueBuilder().(*array.Int64Builder)
+
+	bld.Append(true)
+	nb.Append("a")
+	lb.Append(true)
+	vb.AppendValues([]int64{1, 2}, nil)
+	bld.AppendNull()
+	bld.Append(true)
+	nb.Append("b")
+	lb.Append(true)
+	vb.Append(3)
+
+	arr := bld.NewStructArray()
+	defer arr.Release()
+
+	s, err := scalar.GetScalar(arr, 0)
+	assert.NoError(t, err)
+	defer scalar.Release(s)
+
+	st := s.(*scalar.Struct)
+	assert.Equal(t, `{name: "a", tags: [1 2]}`, st.String())
+	tags, ok := st.Field("tags")
+	assert.True(t, ok)
+	assert.Equal(t, 2, tags.(*scalar.List).Value.Len())
+	_, ok = st.Field("missing")
+	assert.False(t, ok)
+
+	null, err := scalar.GetScalar(arr, 1)
+	assert.NoError(t, err)
+	assert.False(t, null.IsValid())
+	assert.Equal(t, "(null)", null.String())
+
+	last := array.NewSlice(arr, 2, 3)
+	defer last.Release()
+	s, err = scalar.GetScalar(last, 0)
+	assert.NoError(t, err)
+	defer scalar.Release(s)
+	assert.Equal(t, `{name: "b", tags: [3]}`, s.String())
+}
+
+func TestGetScalarUnion(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	dt := arrow.DenseUnionOf([]arrow.Field{
+		{Name: "i", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "s", Type: arrow.BinaryTypes.String},
+	}, []int8{5, 7})
+	bld := array.NewUnionBuilder(mem, dt)
+	defer bld.Release()
+
+	bld.Append(5)
+	bld.Child(0).(*array.Int32Builder).Append(10)
+	bld.Append(7)
+	bld.Child(1).(*array.StringBuilder).Append("x")
+
+	arr := bld.NewUnionArray()
+	defer arr.Release()
+
+	s, err := scalar.GetScalar(arr, 1)
+	assert.NoError(t, err)
+	u := s.(*scalar.Union)
+	assert.Equal(t, int8(7), u.TypeCode)
+	assert.Equal(t, "x", u.Value.(*scalar.String).Value)
+}
+
+func TestMakeNullScalar(t *testing.T) {
+	for _, dt := range []arrow.DataType{
+		arrow.Null,
+		arrow.FixedWidthTypes.Boolean,
+		arrow.PrimitiveTypes.Float32,
+		arrow.PrimitiveTypes.Date64,
+		&arrow.Time32Type{Unit: arrow.Second},
+		arrow.BinaryTypes.LargeString,
+		&arrow.FixedSizeBinaryType{ByteWidth: 4},
+		arrow.ListOf(arrow.PrimitiveTypes.Int8),
+		arrow.FixedSizeListOf(2, arrow.PrimitiveTypes.Int8),
+		arrow.MapOf(arrow.BinaryTypes.String, arrow.PrimitiveTypes.Int8),
+		arrow.StructOf(arrow.Field{Name: "a", Type: arrow.PrimitiveTypes.Int8}),
+	} {
+		s := scalar.MakeNullScalar(dt)
+		assert.False(t, s.IsValid(), dt.Name())
+		assert.Equal(t, dt, s.DataType(), dt.Name())
+		assert.Equal(t, "(null)", s.String(), dt.Name())
+	}
+}
@@ -17,8 +17,10 @@
 package arrow
 
 import (
+	"errors"
 	"fmt"
 	"sort"
+	"strings"
 )
 
 type Metadata struct {
@@ -64,6 +66,41 @@ func (md Metadata) Len() int         { return len(md.keys) }
 func (md Metadata) Keys() []string   { return md.keys }
 func (md Metadata) Values() []string { return md.values }
 
+// FindKey returns the index of the key k, or -1 if md does not hold k.
+func (md Metadata) FindKey(k string) int {
+	for i, key := range md.keys {
+		if key == k {
+			return i
+		}
+	}
+	return -1
+}
+
+// GetValue returns the value of the key k, and whether md holds k.
+func (md Metadata) GetValue(k string) (string, bool) {
+	i := md.FindKey(k)
+	if i < 0 {
+		return "", false
+	}
+	return md.values[i], true
+}
+
+// Merge returns the metadata holding the keys of md and of o. The values of
+// o replace the values of md for the keys they share; the keys of o that md
+// does not hold come after the keys of md.
+func (md Metadata) Merge(o Metadata) Metadata {
+	out := md.clone()
+	for i, k := range o.keys {
+		if j := out.FindKey(k); j >= 0 {
+			out.values[j] = o.values[i]
+			continue
+		}
+		out.keys = append(out.keys, k)
+		out.values = append(out.values, o.values[i])
+	}
+	return out
+}
+
 func (kv Metadata) clone() Metadata {
 	if len(kv.keys) == 0 {
 		return Metadata{}
@@ -139,6 +176,99 @@ func (sc *Schema) HasField(n string) bool {
 
 func (sc *Schema) HasMetadata() bool { return len(sc.meta.keys) > 0 }
 
+// FieldIndexFold returns the index of the first field whose name is equal
+// to n under Unicode case-folding, or -1.
+func (sc *Schema) FieldIndexFold(n string) int {
+	if i, ok := sc.index[n]; ok {
+		return i
+	}
+	for i, f := range sc.fields {
+		if strings.EqualFold(f.Name, n) {
+			return i
+		}
+	}
+	return -1
+}
+
+// FieldByNameFold returns the first field whose name is equal to n under
+// Unicode case-folding.
+func (sc *Schema) FieldByNameFold(n string) (Field, bool) {
+	i := sc.FieldIndexFold(n)
+	if i < 0 {
+		return Field{}, false
+	}
+	return sc.fields[i], true
+}
+
+// AddField returns a new schema with the field f inserted at index i, so
+// that 0 ≤ i ≤ len(sc.Fields()), and the metadata of sc.
+func (sc *Schema) AddField(i int, f Field) (*Schema, error) {
+	if i < 0 || i > len(sc.fields) {
+		return nil, fmt.Errorf("arrow: invalid field index %d", i)
+	}
+	fields := make([]Field, 0, len(sc.fields)+1)
+	fields = append(fields, sc.fields[:i]...)
+	fields = append(fields, f)
+	fields = append(fields, sc.fields[i:]...)
+	return sc.withFields(fields)
+}
+
+// RemoveField returns a new schema without the i-th field of sc, and with
+// the metadata of sc.
+func (sc *Schema) RemoveField(i int) (*Schema, error) {
+	if i < 0 || i >= len(sc.fields) {
+		return nil, fmt.Errorf("arrow: invalid field index %d", i)
+	}
+	fields := make([]Field, 0, len(sc.fields)-1)
+	fields = append(fields, sc.fields[:i]...)
+	fields = append(fields, sc.fields[i+1:]...)
+	return sc.withFields(fields)
+}
+
+// SetField returns a new schema with the i-th field of sc replaced by f,
+// and with the metadata of sc.
+func (sc *Schema) SetField(i int, f Field) (*Schema, error) {
+	if i < 0 || i >= len(sc.fields) {
+		return nil, fmt.Errorf("arrow: invalid field index %d", i)
+	}
+	fields := make([]Field, len(sc.fields))
+	copy(fields, sc.fields)
+	fields[i] = f
+	return sc.withFields(fields)
+}
+
+// RenameField returns a new schema with the i-th field of sc renamed to
+// name. The field keeps its type, nullability and metadata.
+func (sc *Schema) RenameField(i int, name string) (*Schema, error) {
+	if i < 0 || i >= len(sc.fields) {
+		return nil, fmt.Errorf("arrow: invalid field index %d", i)
+	}
+	f := sc.fields[i]
+	f.Name = name
+	return sc.SetField(i, f)
+}
+
+// WithMetadata returns a new schema with the fields of sc and the metadata md.
+func (sc *Schema) WithMetadata(md Metadata) *Schema {
+	return NewSchema(sc.fields, &md)
+}
+
+// withFields returns a new schema with the given fields and the metadata of
+// sc, or an error if the fields have duplicated names or invalid types.
+func (sc *Schema) withFields(fields []Field) (*Schema, error) {
+	seen := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		if f.Type == nil {
+			return nil, errors.New("arrow: field with nil DataType")
+		}
+		if seen[f.Name] {
+			return nil, fmt.Errorf("arrow: duplicate field with name %q", f.Name)
+		}
+		seen[f.Name] = true
+	}
+	return NewSchema(fields, &sc.meta), nil
+}
+
 // Equal returns whether two schema are equal.
 // Equal does not compare the metadata.
 func (sc *Schema) Equal(o *Schema) bool {
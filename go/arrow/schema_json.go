@@ -0,0 +1,539 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// The JSON representation of schemas follows the schema part of the JSON
+// format of the Arrow integration tests:
+//
+//	{"fields": [{"name": "f1", "nullable": true, "type": {"name": "int", "bitWidth": 32, "isSigned": true}, "children": []}]}
+//
+// Extension types are written as their storage type, with their name and
+// parameters in the field metadata, and dictionary types as their value type,
+// with their index type in the dictionary member of the field.
+
+type jsonSchema struct {
+	Fields   []jsonField    `json:"fields"`
+	Metadata []jsonKeyValue `json:"metadata,omitempty"`
+}
+
+type jsonField struct {
+	Name       string          `json:"name"`
+	Nullable   bool            `json:"nullable"`
+	Type       jsonType        `json:"type"`
+	Children   []jsonField     `json:"children"`
+	Dictionary *jsonDictionary `json:"dictionary,omitempty"`
+	Metadata   []jsonKeyValue  `json:"metadata,omitempty"`
+}
+
+type jsonType struct {
+	Name       string      `json:"name"`
+	BitWidth   int         `json:"bitWidth,omitempty"`
+	IsSigned   *bool       `json:"isSigned,omitempty"`
+	Precision  interface{} `json:"precision,omitempty"` // string for floating point types, number for decimals
+	Scale      *int32      `json:"scale,omitempty"`
+	Unit       string      `json:"unit,omitempty"`
+	Timezone   string      `json:"timezone,omitempty"`
+	ByteWidth  int         `json:"byteWidth,omitempty"`
+	ListSize   *int32      `json:"listSize,omitempty"`
+	KeysSorted *bool       `json:"keysSorted,omitempty"`
+	Mode       string      `json:"mode,omitempty"`
+	TypeIDs    []int8      `json:"typeIds,omitempty"`
+}
+
+type jsonDictionary struct {
+	ID        int64    `json:"id"`
+	IndexType jsonType `json:"indexType"`
+	IsOrdered bool     `json:"isOrdered"`
+}
+
+type jsonKeyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// MarshalJSON returns the JSON representation of the schema, in the JSON
+// format of the Arrow integration tests.
+func (sc *Schema) MarshalJSON() ([]byte, error) {
+	var (
+		ids int64 // next dictionary id
+		out = jsonSchema{Fields: make([]jsonField, len(sc.fields)), Metadata: metadataToJSON(sc.meta)}
+	)
+	for i, f := range sc.fields {
+		jf, err := fieldToJSON(f, &ids)
+		if err != nil {
+			return nil, err
+		}
+		out.Fields[i] = jf
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON sets the schema from its JSON representation, in the JSON
+// format of the Arrow integration tests.
+//
+// Fields whose metadata names a registered extension type get that type.
+func (sc *Schema) UnmarshalJSON(data []byte) error {
+	var in jsonSchema
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	fields := make([]Field, len(in.Fields))
+	seen := make(map[string]bool, len(in.Fields))
+	for i, jf := range in.Fields {
+		f, err := fieldFromJSON(jf)
+		if err != nil {
+			return err
+		}
+		if seen[f.Name] {
+			return fmt.Errorf("arrow: duplicate field with name %q", f.Name)
+		}
+		seen[f.Name] = true
+		fields[i] = f
+	}
+
+	var md *Metadata
+	if len(in.Metadata) > 0 {
+		m := metadataFromJSON(in.Metadata)
+		md = &m
+	}
+	*sc = *NewSchema(fields, md)
+	return nil
+}
+
+func fieldToJSON(f Field, ids *int64) (jsonField, error) {
+	jf := jsonField{Name: f.Name, Nullable: f.Nullable, Children: []jsonField{}}
+	md := f.Metadata
+
+	dt := f.Type
+	if ext, ok := dt.(ExtensionType); ok {
+		md = ExtensionMetadata(ext, md)
+		dt = ext.StorageType()
+	}
+	if dict, ok := dt.(*DictionaryType); ok {
+		idx, _, err := typeToJSON(dict.IndexType())
+		if err != nil {
+			return jf, err
+		}
+		jf.Dictionary = &jsonDictionary{ID: *ids, IndexType: idx, IsOrdered: dict.Ordered}
+		*ids++
+		dt = dict.ValueType()
+	}
+
+	typ, children, err := typeToJSON(dt)
+	if err != nil {
+		return jf, fmt.Errorf("arrow: field %q: %w", f.Name, err)
+	}
+	jf.Type = typ
+	for _, c := range children {
+		jc, err := fieldToJSON(c, ids)
+		if err != nil {
+			return jf, err
+		}
+		jf.Children = append(jf.Children, jc)
+	}
+	jf.Metadata = metadataToJSON(md)
+	return jf, nil
+}
+
+// typeToJSON returns the JSON representation of dt, and its children fields.
+func typeToJSON(dt DataType) (jsonType, []Field, error) {
+	var (
+		yes, no = true, false
+		item    = func(t DataType) []Field { return []Field{{Name: "item", Type: t, Nullable: true}} }
+	)
+
+	switch dt := dt.(type) {
+	case *NullType:
+		return jsonType{Name: "null"}, nil, nil
+	case *BooleanType:
+		return jsonType{Name: "bool"}, nil, nil
+	case *Int8Type:
+		return jsonType{Name: "int", BitWidth: 8, IsSigned: &yes}, nil, nil
+	case *Int16Type:
+		return jsonType{Name: "int", BitWidth: 16, IsSigned: &yes}, nil, nil
+	case *Int32Type:
+		return jsonType{Name: "int", BitWidth: 32, IsSigned: &yes}, nil, nil
+	case *Int64Type:
+		return jsonType{Name: "int", BitWidth: 64, IsSigned: &yes}, nil, nil
+	case *Uint8Type:
+		return jsonType{Name: "int", BitWidth: 8, IsSigned: &no}, nil, nil
+	case *Uint16Type:
+		return jsonType{Name: "int", BitWidth: 16, IsSigned: &no}, nil, nil
+	case *Uint32Type:
+		return jsonType{Name: "int", BitWidth: 32, IsSigned: &no}, nil, nil
+	case *Uint64Type:
+		return jsonType{Name: "int", BitWidth: 64, IsSigned: &no}, nil, nil
+	case *Float16Type:
+		return jsonType{Name: "floatingpoint", Precision: "HALF"}, nil, nil
+	case *Float32Type:
+		return jsonType{Name: "floatingpoint", Precision: "SINGLE"}, nil, nil
+	case *Float64Type:
+		return jsonType{Name: "floatingpoint", Precision: "DOUBLE"}, nil, nil
+	case *BinaryType:
+		return jsonType{Name: "binary"}, nil, nil
+	case *LargeBinaryType:
+		return jsonType{Name: "largebinary"}, nil, nil
+	case *StringType:
+		return jsonType{Name: "utf8"}, nil, nil
+	case *LargeStringType:
+		return jsonType{Name: "largeutf8"}, nil, nil
+	case *BinaryViewType:
+		return jsonType{Name: "binaryview"}, nil, nil
+	case *StringViewType:
+		return jsonType{Name: "utf8view"}, nil, nil
+	case *FixedSizeBinaryType:
+		return jsonType{Name: "fixedsizebinary", ByteWidth: dt.ByteWidth}, nil, nil
+	case *Decimal256Type:
+		return jsonType{Name: "decimal", BitWidth: 256, Precision: dt.Precision, Scale: &dt.Scale}, nil, nil
+	case *Date32Type:
+		return jsonType{Name: "date", Unit: "DAY"}, nil, nil
+	case *Date64Type:
+		return jsonType{Name: "date", Unit: "MILLISECOND"}, nil, nil
+	case *Time32Type:
+		return jsonType{Name: "time", Unit: unitToJSON(dt.Unit), BitWidth: 32}, nil, nil
+	case *Time64Type:
+		return jsonType{Name: "time", Unit: unitToJSON(dt.Unit), BitWidth: 64}, nil, nil
+	case *TimestampType:
+		return jsonType{Name: "timestamp", Unit: unitToJSON(dt.Unit), Timezone: dt.TimeZone}, nil, nil
+	case *DurationType:
+		return jsonType{Name: "duration", Unit: unitToJSON(dt.Unit)}, nil, nil
+	case *MonthIntervalType:
+		return jsonType{Name: "interval", Unit: "YEAR_MONTH"}, nil, nil
+	case *DayTimeIntervalType:
+		return jsonType{Name: "interval", Unit: "DAY_TIME"}, nil, nil
+	case *MonthDayNanoIntervalType:
+		return jsonType{Name: "interval", Unit: "MONTH_DAY_NANO"}, nil, nil
+	case *ListType:
+		return jsonType{Name: "list"}, item(dt.Elem()), nil
+	case *LargeListType:
+		return jsonType{Name: "largelist"}, item(dt.Elem()), nil
+	case *FixedSizeListType:
+		n := dt.Len()
+		return jsonType{Name: "fixedsizelist", ListSize: &n}, item(dt.Elem()), nil
+	case *StructType:
+		return jsonType{Name: "struct"}, dt.Fields(), nil
+	case *MapType:
+		sorted := dt.KeysSorted
+		return jsonType{Name: "map", KeysSorted: &sorted}, []Field{{Name: "entries", Type: dt.ValueType()}}, nil
+	case *UnionType:
+		mode := "SPARSE"
+		if dt.Mode() == DenseMode {
+			mode = "DENSE"
+		}
+		return jsonType{Name: "union", Mode: mode, TypeIDs: dt.TypeCodes()}, dt.Fields(), nil
+	case *RunEndEncodedType:
+		return jsonType{Name: "runendencoded"}, dt.Fields(), nil
+	}
+	return jsonType{}, nil, fmt.Errorf("arrow: unsupported data type %s for JSON schema", dt.Name())
+}
+
+func unitToJSON(u TimeUnit) string {
+	return [...]string{"NANOSECOND", "MICROSECOND", "MILLISECOND", "SECOND"}[uint(u)&3]
+}
+
+func unitFromJSON(s string) (TimeUnit, error) {
+	switch s {
+	case "NANOSECOND":
+		return Nanosecond, nil
+	case "MICROSECOND":
+		return Microsecond, nil
+	case "MILLISECOND":
+		return Millisecond, nil
+	case "SECOND":
+		return Second, nil
+	}
+	return 0, fmt.Errorf("arrow: invalid time unit %q", s)
+}
+
+func fieldFromJSON(jf jsonField) (Field, error) {
+	children := make([]Field, len(jf.Children))
+	for i, jc := range jf.Children {
+		c, err := fieldFromJSON(jc)
+		if err != nil {
+			return Field{}, err
+		}
+		children[i] = c
+	}
+
+	dt, err := typeFromJSON(jf.Type, children)
+	if err != nil {
+		return Field{}, fmt.Errorf("arrow: field %q: %w", jf.Name, err)
+	}
+	if jf.Dictionary != nil {
+		idx, err := typeFromJSON(jf.Dictionary.IndexType, nil)
+		if err != nil {
+			return Field{}, fmt.Errorf("arrow: field %q: %w", jf.Name, err)
+		}
+		switch idx.(type) {
+		case *Int8Type, *Int16Type, *Int32Type, *Int64Type,
+			*Uint8Type, *Uint16Type, *Uint32Type, *Uint64Type:
+		default:
+			return Field{}, fmt.Errorf("arrow: field %q: invalid dictionary index type %s", jf.Name, idx.Name())
+		}
+		dict := DictionaryOf(idx, dt)
+		dict.Ordered = jf.Dictionary.IsOrdered
+		dt = dict
+	}
+
+	md := metadataFromJSON(jf.Metadata)
+	ext, err := ExtensionTypeFromMetadata(dt, md)
+	if err != nil {
+		return Field{}, fmt.Errorf("arrow: field %q: %w", jf.Name, err)
+	}
+	if ext != nil {
+		dt = ext
+		md = stripExtensionMetadata(md)
+	}
+	return Field{Name: jf.Name, Type: dt, Nullable: jf.Nullable, Metadata: md}, nil
+}
+
+func typeFromJSON(jt jsonType, children []Field) (DataType, error) {
+	nchildren := func(n int) error {
+		if len(children) != n {
+			return fmt.Errorf("%s type with %d children, expected %d", jt.Name, len(children), n)
+		}
+		return nil
+	}
+
+	switch jt.Name {
+	case "null":
+		return Null, nil
+	case "bool":
+		return FixedWidthTypes.Boolean, nil
+	case "int":
+		signed := jt.IsSigned != nil && *jt.IsSigned
+		switch jt.BitWidth {
+		case 8:
+			if signed {
+				return PrimitiveTypes.Int8, nil
+			}
+			return PrimitiveTypes.Uint8, nil
+		case 16:
+			if signed {
+				return PrimitiveTypes.Int16, nil
+			}
+			return PrimitiveTypes.Uint16, nil
+		case 32:
+			if signed {
+				return PrimitiveTypes.Int32, nil
+			}
+			return PrimitiveTypes.Uint32, nil
+		case 64:
+			if signed {
+				return PrimitiveTypes.Int64, nil
+			}
+			return PrimitiveTypes.Uint64, nil
+		}
+		return nil, fmt.Errorf("invalid int bit width %d", jt.BitWidth)
+	case "floatingpoint":
+		switch jt.Precision {
+		case "HALF":
+			return FixedWidthTypes.Float16, nil
+		case "SINGLE":
+			return PrimitiveTypes.Float32, nil
+		case "DOUBLE":
+			return PrimitiveTypes.Float64, nil
+		}
+		return nil, fmt.Errorf("invalid floating point precision %v", jt.Precision)
+	case "binary":
+		return BinaryTypes.Binary, nil
+	case "largebinary":
+		return BinaryTypes.LargeBinary, nil
+	case "utf8":
+		return BinaryTypes.String, nil
+	case "largeutf8":
+		return BinaryTypes.LargeString, nil
+	case "binaryview":
+		return BinaryTypes.BinaryView, nil
+	case "utf8view":
+		return BinaryTypes.StringView, nil
+	case "fixedsizebinary":
+		return &FixedSizeBinaryType{ByteWidth: jt.ByteWidth}, nil
+	case "decimal":
+		if jt.BitWidth != 256 {
+			return nil, fmt.Errorf("unsupported decimal bit width %d", jt.BitWidth)
+		}
+		precision, ok := jt.Precision.(float64)
+		if !ok {
+			return nil, fmt.Errorf("invalid decimal precision %v", jt.Precision)
+		}
+		dt := &Decimal256Type{Precision: int32(precision)}
+		if jt.Scale != nil {
+			dt.Scale = *jt.Scale
+		}
+		return dt, nil
+	case "date":
+		switch jt.Unit {
+		case "DAY":
+			return PrimitiveTypes.Date32, nil
+		case "MILLISECOND":
+			return PrimitiveTypes.Date64, nil
+		}
+		return nil, fmt.Errorf("invalid date unit %q", jt.Unit)
+	case "time":
+		unit, err := unitFromJSON(jt.Unit)
+		if err != nil {
+			return nil, err
+		}
+		switch jt.BitWidth {
+		case 32:
+			return &Time32Type{Unit: unit}, nil
+		case 64:
+			return &Time64Type{Unit: unit}, nil
+		}
+		return nil, fmt.Errorf("invalid time bit width %d", jt.BitWidth)
+	case "timestamp":
+		unit, err := unitFromJSON(jt.Unit)
+		if err != nil {
+			return nil, err
+		}
+		return &TimestampType{Unit: unit, TimeZone: jt.Timezone}, nil
+	case "duration":
+		unit, err := unitFromJSON(jt.Unit)
+		if err != nil {
+			return nil, err
+		}
+		return &DurationType{Unit: unit}, nil
+	case "interval":
+		switch jt.Unit {
+		case "YEAR_MONTH":
+			return FixedWidthTypes.MonthInterval, nil
+		case "DAY_TIME":
+			return FixedWidthTypes.DayTimeInterval, nil
+		case "MONTH_DAY_NANO":
+			return FixedWidthTypes.MonthDayNanoInterval, nil
+		}
+		return nil, fmt.Errorf("invalid interval unit %q", jt.Unit)
+	case "list":
+		if err := nchildren(1); err != nil {
+			return nil, err
+		}
+		return ListOf(children[0].Type), nil
+	case "largelist":
+		if err := nchildren(1); err != nil {
+			return nil, err
+		}
+		return LargeListOf(children[0].Type), nil
+	case "fixedsizelist":
+		if err := nchildren(1); err != nil {
+			return nil, err
+		}
+		if jt.ListSize == nil || *jt.ListSize < 0 {
+			return nil, fmt.Errorf("invalid fixed size list size")
+		}
+		return FixedSizeListOf(*jt.ListSize, children[0].Type), nil
+	case "struct":
+		seen := make(map[string]bool, len(children))
+		for _, c := range children {
+			if seen[c.Name] {
+				return nil, fmt.Errorf("duplicate field with name %q", c.Name)
+			}
+			seen[c.Name] = true
+		}
+		return StructOf(children...), nil
+	case "map":
+		if err := nchildren(1); err != nil {
+			return nil, err
+		}
+		entries, ok := children[0].Type.(*StructType)
+		if !ok || len(entries.Fields()) != 2 {
+			return nil, fmt.Errorf("invalid map entries type %s", children[0].Type.Name())
+		}
+		dt := MapOf(entries.Field(0).Type, entries.Field(1).Type)
+		dt.KeysSorted = jt.KeysSorted != nil && *jt.KeysSorted
+		return dt, nil
+	case "union":
+		var mode UnionMode
+		switch jt.Mode {
+		case "SPARSE":
+			mode = SparseMode
+		case "DENSE":
+			mode = DenseMode
+		default:
+			return nil, fmt.Errorf("invalid union mode %q", jt.Mode)
+		}
+		codes := jt.TypeIDs
+		if codes == nil {
+			codes = make([]int8, len(children))
+			for i := range codes {
+				codes[i] = int8(i)
+			}
+		}
+		if len(codes) != len(children) {
+			return nil, fmt.Errorf("union with %d children and %d type ids", len(children), len(codes))
+		}
+		seen := make(map[int8]bool, len(codes))
+		for _, c := range codes {
+			if c < 0 || seen[c] {
+				return nil, fmt.Errorf("invalid union type id %d", c)
+			}
+			seen[c] = true
+		}
+		return UnionOf(mode, children, codes), nil
+	case "runendencoded":
+		if err := nchildren(2); err != nil {
+			return nil, err
+		}
+		switch children[0].Type.(type) {
+		case *Int16Type, *Int32Type, *Int64Type:
+		default:
+			return nil, fmt.Errorf("invalid run ends type %s", children[0].Type.Name())
+		}
+		return RunEndEncodedOf(children[0].Type, children[1].Type), nil
+	}
+	return nil, fmt.Errorf("unsupported JSON type %q", jt.Name)
+}
+
+func metadataToJSON(md Metadata) []jsonKeyValue {
+	if md.Len() == 0 {
+		return nil
+	}
+	kvs := make([]jsonKeyValue, md.Len())
+	for i, k := range md.Keys() {
+		kvs[i] = jsonKeyValue{Key: k, Value: md.Values()[i]}
+	}
+	return kvs
+}
+
+func metadataFromJSON(kvs []jsonKeyValue) Metadata {
+	keys := make([]string, len(kvs))
+	values := make([]string, len(kvs))
+	for i, kv := range kvs {
+		keys[i], values[i] = kv.Key, kv.Value
+	}
+	return NewMetadata(keys, values)
+}
+
+// stripExtensionMetadata returns md without the keys describing an
+// extension type.
+func stripExtensionMetadata(md Metadata) Metadata {
+	var keys, values []string
+	for i, k := range md.Keys() {
+		if k == ExtensionNameKey || k == ExtensionMetadataKey {
+			continue
+		}
+		keys = append(keys, k)
+		values = append(values, md.Values()[i])
+	}
+	return NewMetadata(keys, values)
+}
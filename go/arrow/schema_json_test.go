@@ -0,0 +1,160 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package arrow
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type jsonTestUUIDType struct {
+	ExtensionBase
+}
+
+func (*jsonTestUUIDType) Name() string            { return "uuid" }
+func (*jsonTestUUIDType) ArrayType() reflect.Type { return nil }
+func (*jsonTestUUIDType) ExtensionName() string   { return "arrow.test.uuid" }
+func (*jsonTestUUIDType) Serialize() string       { return "v1" }
+func (t *jsonTestUUIDType) ExtensionEquals(o ExtensionType) bool {
+	_, ok := o.(*jsonTestUUIDType)
+	return ok
+}
+func (*jsonTestUUIDType) Deserialize(storage DataType, data string) (ExtensionType, error) {
+	return &jsonTestUUIDType{ExtensionBase{Storage: storage}}, nil
+}
+
+func TestSchemaJSON(t *testing.T) {
+	want := `{"fields":[{"name":"i32","nullable":true,"type":{"name":"int","bitWidth":32,"isSigned":true},"children":[]},` +
+		`{"name":"tags","nullable":false,"type":{"name":"list"},"children":[{"name":"item","nullable":true,"type":{"name":"utf8"},"children":[]}],` +
+		`"metadata":[{"key":"k","value":"v"}]}],"metadata":[{"key":"origin","value":"test"}]}`
+
+	md := NewMetadata([]string{"origin"}, []string{"test"})
+	sc := NewSchema([]Field{
+		{Name: "i32", Type: PrimitiveTypes.Int32, Nullable: true},
+		{Name: "tags", Type: ListOf(BinaryTypes.String), Metadata: NewMetadata([]string{"k"}, []string{"v"})},
+	}, &md)
+
+	got, err := json.Marshal(sc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Fatalf("invalid JSON:\ngot= %s\nwant=%s", got, want)
+	}
+
+	var back Schema
+	if err := json.Unmarshal(got, &back); err != nil {
+		t.Fatal(err)
+	}
+	if !back.Equal(sc) || !reflect.DeepEqual(back.Metadata(), sc.Metadata()) {
+		t.Fatalf("invalid schema:\ngot= %#v\nwant=%#v", back, sc)
+	}
+}
+
+func TestSchemaJSONRoundTrip(t *testing.T) {
+	if err := RegisterExtensionType(&jsonTestUUIDType{ExtensionBase{Storage: &FixedSizeBinaryType{ByteWidth: 16}}}); err != nil {
+		t.Fatal(err)
+	}
+	defer UnregisterExtensionType("arrow.test.uuid")
+
+	ordered := DictionaryOf(PrimitiveTypes.Int16, BinaryTypes.String)
+	ordered.Ordered = true
+	sorted := MapOf(BinaryTypes.String, PrimitiveTypes.Float64)
+	sorted.KeysSorted = true
+
+	types := []DataType{
+		Null, FixedWidthTypes.Boolean,
+		PrimitiveTypes.Int8, PrimitiveTypes.Int16, PrimitiveTypes.Int32, PrimitiveTypes.Int64,
+		PrimitiveTypes.Uint8, PrimitiveTypes.Uint16, PrimitiveTypes.Uint32, PrimitiveTypes.Uint64,
+		FixedWidthTypes.Float16, PrimitiveTypes.Float32, PrimitiveTypes.Float64,
+		BinaryTypes.Binary, BinaryTypes.LargeBinary, BinaryTypes.String, BinaryTypes.LargeString,
+		BinaryTypes.BinaryView, BinaryTypes.StringView,
+		&FixedSizeBinaryType{ByteWidth: 3}, &Decimal256Type{Precision: 40, Scale: 5},
+		PrimitiveTypes.Date32, PrimitiveTypes.Date64,
+		FixedWidthTypes.Time32s, FixedWidthTypes.Time64ns,
+		&TimestampType{Unit: Microsecond, TimeZone: "Europe/Paris"}, FixedWidthTypes.Duration_ms,
+		FixedWidthTypes.MonthInterval, FixedWidthTypes.DayTimeInterval, FixedWidthTypes.MonthDayNanoInterval,
+		ListOf(PrimitiveTypes.Int64), LargeListOf(BinaryTypes.String), FixedSizeListOf(2, PrimitiveTypes.Float32),
+		StructOf(Field{Name: "a", Type: PrimitiveTypes.Int32, Nullable: true}, Field{Name: "b", Type: BinaryTypes.String}),
+		MapOf(PrimitiveTypes.Int32, BinaryTypes.String), sorted,
+		SparseUnionOf([]Field{{Name: "i", Type: PrimitiveTypes.Int32}, {Name: "s", Type: BinaryTypes.String}}, []int8{2, 5}),
+		DenseUnionOf([]Field{{Name: "i", Type: PrimitiveTypes.Int32}}, nil),
+		RunEndEncodedOf(PrimitiveTypes.Int32, BinaryTypes.String),
+		DictionaryOf(PrimitiveTypes.Int8, PrimitiveTypes.Int64), ordered,
+		ListOf(DictionaryOf(PrimitiveTypes.Uint32, BinaryTypes.String)),
+		&jsonTestUUIDType{ExtensionBase{Storage: &FixedSizeBinaryType{ByteWidth: 16}}},
+	}
+
+	fields := make([]Field, len(types))
+	for i, dt := range types {
+		fields[i] = Field{Name: "f" + string(rune('a'+i)), Type: dt, Nullable: i%2 == 0}
+	}
+	sc := NewSchema(fields, nil)
+
+	data, err := json.Marshal(sc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back Schema
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	for i, f := range sc.Fields() {
+		if got := back.Field(i); !got.Equal(f) {
+			t.Errorf("field %d: got=%#v, want=%#v", i, got, f)
+		}
+	}
+	if !strings.Contains(string(data), `"dictionary":{"id":2,"indexType":{"name":"int","bitWidth":32,"isSigned":false},"isOrdered":false}`) {
+		t.Fatalf("missing dictionary of nested field in %s", data)
+	}
+}
+
+func TestSchemaJSONErrors(t *testing.T) {
+	for _, tc := range []struct {
+		json string
+		err  string
+	}{
+		{
+			`{"fields":[{"name":"f","type":{"name":"int","bitWidth":12,"isSigned":true},"children":[]}]}`,
+			`arrow: field "f": invalid int bit width 12`,
+		},
+		{
+			`{"fields":[{"name":"f","type":{"name":"list"},"children":[]}]}`,
+			`arrow: field "f": list type with 0 children, expected 1`,
+		},
+		{
+			`{"fields":[{"name":"f","type":{"name":"utf8"},"children":[],"dictionary":{"id":0,"indexType":{"name":"utf8"}}}]}`,
+			`arrow: field "f": invalid dictionary index type utf8`,
+		},
+		{
+			`{"fields":[{"name":"f","type":{"name":"bool"},"children":[]},{"name":"f","type":{"name":"bool"},"children":[]}]}`,
+			`arrow: duplicate field with name "f"`,
+		},
+		{
+			`{"fields":[{"name":"f","type":{"name":"decimal","precision":10,"scale":2,"bitWidth":128},"children":[]}]}`,
+			`arrow: field "f": unsupported decimal bit width 128`,
+		},
+	} {
+		var sc Schema
+		err := json.Unmarshal([]byte(tc.json), &sc)
+		if err == nil || err.Error() != tc.err {
+			t.Errorf("%s: got=%v, want=%s", tc.json, err, tc.err)
+		}
+	}
+}
@@ -284,3 +284,93 @@ func TestSchemaEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestMetadataMerge(t *testing.T) {
+	md := NewMetadata([]string{"k1", "k2"}, []string{"v1", "v2"})
+	got := md.Merge(NewMetadata([]string{"k3", "k1"}, []string{"v3", "new"}))
+
+	if want := NewMetadata([]string{"k1", "k2", "k3"}, []string{"new", "v2", "v3"}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid merged metadata: got=%v, want=%v", got, want)
+	}
+	if v, ok := md.GetValue("k1"); !ok || v != "v1" {
+		t.Fatalf("merge modified its receiver: got=%q", v)
+	}
+	if got, want := got.FindKey("k3"), 2; got != want {
+		t.Fatalf("invalid key index: got=%d, want=%d", got, want)
+	}
+	if _, ok := got.GetValue("k4"); ok {
+		t.Fatalf("unexpected value for k4")
+	}
+}
+
+func TestSchemaFieldManipulation(t *testing.T) {
+	md := NewMetadata([]string{"k"}, []string{"v"})
+	fmd := NewMetadata([]string{"unit"}, []string{"m"})
+	sc := NewSchema([]Field{
+		{Name: "f1", Type: PrimitiveTypes.Int32},
+		{Name: "Dist", Type: PrimitiveTypes.Float64, Nullable: true, Metadata: fmd},
+	}, &md)
+
+	names := func(sc *Schema) []string {
+		var names []string
+		for _, f := range sc.Fields() {
+			names = append(names, f.Name)
+		}
+		return names
+	}
+
+	added, err := sc.AddField(1, Field{Name: "f0", Type: BinaryTypes.String})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := names(added), []string{"f1", "f0", "Dist"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid fields: got=%v, want=%v", got, want)
+	}
+	if got, want := added.FieldIndex("Dist"), 2; got != want {
+		t.Fatalf("invalid field index: got=%d, want=%d", got, want)
+	}
+	if !reflect.DeepEqual(added.Metadata(), md) {
+		t.Fatalf("schema metadata lost: got=%v", added.Metadata())
+	}
+
+	renamed, err := added.RenameField(2, "distance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f := renamed.Field(2); f.Name != "distance" || !f.Nullable || !reflect.DeepEqual(f.Metadata, fmd) {
+		t.Fatalf("invalid renamed field: %#v", f)
+	}
+
+	removed, err := renamed.RemoveField(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := names(removed), []string{"f0", "distance"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid fields: got=%v, want=%v", got, want)
+	}
+	if got, want := names(sc), []string{"f1", "Dist"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("original schema modified: got=%v, want=%v", got, want)
+	}
+
+	if _, err := sc.AddField(3, Field{Name: "f3", Type: BinaryTypes.String}); err == nil || err.Error() != "arrow: invalid field index 3" {
+		t.Fatalf("invalid error: %v", err)
+	}
+	if _, err := sc.SetField(0, Field{Name: "Dist", Type: BinaryTypes.String}); err == nil || err.Error() != `arrow: duplicate field with name "Dist"` {
+		t.Fatalf("invalid error: %v", err)
+	}
+	if _, err := sc.AddField(0, Field{Name: "f3"}); err == nil || err.Error() != "arrow: field with nil DataType" {
+		t.Fatalf("invalid error: %v", err)
+	}
+
+	if f, ok := sc.FieldByNameFold("DIST"); !ok || f.Name != "Dist" {
+		t.Fatalf("invalid case-insensitive lookup: %#v, %v", f, ok)
+	}
+	if got, want := sc.FieldIndexFold("missing"), -1; got != want {
+		t.Fatalf("invalid field index: got=%d, want=%d", got, want)
+	}
+
+	other := NewMetadata([]string{"k2"}, []string{"v2"})
+	if got, want := sc.WithMetadata(sc.Metadata().Merge(other)).Metadata(), NewMetadata([]string{"k", "k2"}, []string{"v", "v2"}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid metadata: got=%v, want=%v", got, want)
+	}
+}
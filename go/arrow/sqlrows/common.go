@@ -0,0 +1,232 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlrows reads the rows of a database/sql query as records, and
+// inserts records with batches of database/sql statements.
+package sqlrows
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// Option configures a SQL rows reader or Insert.
+type Option func(config)
+type config interface{}
+
+// Placeholder is a style of the placeholders of the arguments of a SQL
+// statement, which depends on the database driver.
+type Placeholder int
+
+const (
+	QuestionMark Placeholder = iota // ?, as used by MySQL and SQLite
+	Dollar                          // $1, $2, ..., as used by PostgreSQL
+	Colon                           // :1, :2, ..., as used by Oracle
+	AtP                             // @p1, @p2, ..., as used by SQL Server
+)
+
+// WithAllocator specifies the Arrow memory allocator used while building records.
+func WithAllocator(mem memory.Allocator) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.mem = mem
+		default:
+			panic(fmt.Errorf("arrow/sqlrows: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithChunk specifies the chunk size used while reading SQL rows.
+//
+// If n is zero or 1, no chunking will take place and the reader will create
+// one record per row.
+// If n is greater than 1, chunks of n rows will be read.
+// If n is negative, the reader will load all the rows into memory and
+// create one big record with all the rows.
+func WithChunk(n int) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.chunk = n
+		default:
+			panic(fmt.Errorf("arrow/sqlrows: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithSchema specifies the schema of the records created by the reader,
+// instead of the one derived from the SQL column types.
+// The schema must have one field per SQL column, in the same order.
+func WithSchema(schema *arrow.Schema) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Reader:
+			cfg.schema = schema
+		default:
+			panic(fmt.Errorf("arrow/sqlrows: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithBatchSize specifies the number of rows inserted by each statement
+// executed by Insert. If n is zero or negative, a default size is used.
+func WithBatchSize(n int) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *inserter:
+			cfg.batch = n
+		default:
+			panic(fmt.Errorf("arrow/sqlrows: unknown config type %T", cfg))
+		}
+	}
+}
+
+// WithPlaceholder specifies the style of the placeholders of the statements
+// executed by Insert.
+// The default value is QuestionMark.
+func WithPlaceholder(p Placeholder) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *inserter:
+			cfg.placeholder = p
+		default:
+			panic(fmt.Errorf("arrow/sqlrows: unknown config type %T", cfg))
+		}
+	}
+}
+
+// SchemaOf returns the schema of the records holding rows of the given
+// SQL column types.
+//
+// The Arrow type of a column is derived from the Go type its driver scans
+// values into, or from its database type name if the driver does not
+// report a specific Go type. Columns are nullable unless the driver reports
+// otherwise.
+func SchemaOf(cols []*sql.ColumnType) (*arrow.Schema, error) {
+	fields := make([]arrow.Field, len(cols))
+	for i, col := range cols {
+		dt, err := dataTypeOf(col)
+		if err != nil {
+			return nil, fmt.Errorf("arrow/sqlrows: column %d (%s): %w", i, col.Name(), err)
+		}
+		nullable, ok := col.Nullable()
+		fields[i] = arrow.Field{Name: col.Name(), Type: dt, Nullable: nullable || !ok}
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+var (
+	typeBool    = reflect.TypeOf(false)
+	typeInt8    = reflect.TypeOf(int8(0))
+	typeInt16   = reflect.TypeOf(int16(0))
+	typeInt32   = reflect.TypeOf(int32(0))
+	typeInt64   = reflect.TypeOf(int64(0))
+	typeUint8   = reflect.TypeOf(uint8(0))
+	typeUint16  = reflect.TypeOf(uint16(0))
+	typeUint32  = reflect.TypeOf(uint32(0))
+	typeUint64  = reflect.TypeOf(uint64(0))
+	typeFloat32 = reflect.TypeOf(float32(0))
+	typeFloat64 = reflect.TypeOf(float64(0))
+	typeString  = reflect.TypeOf("")
+	typeBytes   = reflect.TypeOf([]byte(nil))
+	typeTime    = reflect.TypeOf(time.Time{})
+)
+
+func dataTypeOf(col *sql.ColumnType) (arrow.DataType, error) {
+	switch col.ScanType() {
+	case typeBool, reflect.TypeOf(sql.NullBool{}):
+		return arrow.FixedWidthTypes.Boolean, nil
+	case typeInt8:
+		return arrow.PrimitiveTypes.Int8, nil
+	case typeInt16:
+		return arrow.PrimitiveTypes.Int16, nil
+	case typeInt32, reflect.TypeOf(sql.NullInt32{}):
+		return arrow.PrimitiveTypes.Int32, nil
+	case typeInt64, reflect.TypeOf(sql.NullInt64{}):
+		return arrow.PrimitiveTypes.Int64, nil
+	case typeUint8:
+		return arrow.PrimitiveTypes.Uint8, nil
+	case typeUint16:
+		return arrow.PrimitiveTypes.Uint16, nil
+	case typeUint32:
+		return arrow.PrimitiveTypes.Uint32, nil
+	case typeUint64:
+		return arrow.PrimitiveTypes.Uint64, nil
+	case typeFloat32:
+		return arrow.PrimitiveTypes.Float32, nil
+	case typeFloat64, reflect.TypeOf(sql.NullFloat64{}):
+		return arrow.PrimitiveTypes.Float64, nil
+	case typeString, reflect.TypeOf(sql.NullString{}):
+		return arrow.BinaryTypes.String, nil
+	case typeBytes, reflect.TypeOf(sql.RawBytes(nil)):
+		return arrow.BinaryTypes.Binary, nil
+	case typeTime, reflect.TypeOf(sql.NullTime{}):
+		return &arrow.TimestampType{Unit: arrow.Nanosecond}, nil
+	}
+
+	// the driver did not report a specific Go type: use the database type name.
+	name := strings.ToUpper(col.DatabaseTypeName())
+	if i := strings.IndexByte(name, '('); i >= 0 {
+		name = strings.TrimSpace(name[:i])
+	}
+	switch name {
+	case "BOOL", "BOOLEAN", "BIT":
+		return arrow.FixedWidthTypes.Boolean, nil
+	case "TINYINT":
+		return arrow.PrimitiveTypes.Int8, nil
+	case "SMALLINT", "INT2":
+		return arrow.PrimitiveTypes.Int16, nil
+	case "INT", "INT4", "INTEGER", "MEDIUMINT":
+		return arrow.PrimitiveTypes.Int32, nil
+	case "BIGINT", "INT8":
+		return arrow.PrimitiveTypes.Int64, nil
+	case "REAL", "FLOAT4":
+		return arrow.PrimitiveTypes.Float32, nil
+	case "FLOAT", "FLOAT8", "DOUBLE", "DOUBLE PRECISION":
+		return arrow.PrimitiveTypes.Float64, nil
+	case "CHAR", "VARCHAR", "TEXT", "NCHAR", "NVARCHAR", "CLOB", "STRING":
+		return arrow.BinaryTypes.String, nil
+	case "BLOB", "BYTEA", "BINARY", "VARBINARY":
+		return arrow.BinaryTypes.Binary, nil
+	case "DATE":
+		return arrow.PrimitiveTypes.Date32, nil
+	case "DATETIME", "TIMESTAMP", "TIMESTAMPTZ":
+		return &arrow.TimestampType{Unit: arrow.Nanosecond}, nil
+	}
+	return nil, fmt.Errorf("unsupported SQL column type %q", col.DatabaseTypeName())
+}
+
+func validate(schema *arrow.Schema) {
+	for i, f := range schema.Fields() {
+		switch ft := f.Type.(type) {
+		case *arrow.BooleanType:
+		case *arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type, *arrow.Int64Type:
+		case *arrow.Uint8Type, *arrow.Uint16Type, *arrow.Uint32Type, *arrow.Uint64Type:
+		case *arrow.Float32Type, *arrow.Float64Type:
+		case *arrow.StringType, *arrow.BinaryType:
+		case *arrow.Date32Type, *arrow.Date64Type, *arrow.TimestampType:
+		default:
+			panic(fmt.Errorf("arrow/sqlrows: field %d (%s) has invalid data type %T", i, f.Name, ft))
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlrows_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// testDriver is an in-memory database/sql driver.
+// Queries return the rows of the table named by the query, and executed
+// statements record their query and arguments.
+type testDriver struct {
+	mu       sync.Mutex
+	tables   map[string]*testTable
+	queries  []string
+	executed [][]driver.Value
+	fail     bool // whether executed statements fail
+}
+
+type testTable struct {
+	cols []testColumn
+	rows [][]driver.Value
+}
+
+type testColumn struct {
+	name     string
+	dbType   string
+	scanType reflect.Type
+	nullable bool
+}
+
+var drv = &testDriver{tables: make(map[string]*testTable)}
+
+func init() {
+	sql.Register("arrowtest", drv)
+}
+
+func (d *testDriver) Open(name string) (driver.Conn, error) { return &testConn{d: d}, nil }
+
+type testConn struct{ d *testDriver }
+
+func (c *testConn) Prepare(query string) (driver.Stmt, error) {
+	return &testStmt{d: c.d, query: query}, nil
+}
+func (c *testConn) Close() error              { return nil }
+func (c *testConn) Begin() (driver.Tx, error) { return nil, fmt.Errorf("transactions not supported") }
+
+type testStmt struct {
+	d     *testDriver
+	query string
+}
+
+func (s *testStmt) Close() error  { return nil }
+func (s *testStmt) NumInput() int { return -1 }
+
+func (s *testStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	if s.d.fail {
+		return nil, fmt.Errorf("statement failed")
+	}
+	s.d.queries = append(s.d.queries, s.query)
+	s.d.executed = append(s.d.executed, append([]driver.Value(nil), args...))
+	return driver.RowsAffected(1), nil
+}
+
+func (s *testStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	tbl, ok := s.d.tables[s.query]
+	if !ok {
+		return nil, fmt.Errorf("no table %q", s.query)
+	}
+	return &testRows{tbl: tbl}, nil
+}
+
+type testRows struct {
+	tbl *testTable
+	row int
+}
+
+func (r *testRows) Columns() []string {
+	names := make([]string, len(r.tbl.cols))
+	for i, c := range r.tbl.cols {
+		names[i] = c.name
+	}
+	return names
+}
+
+func (r *testRows) Close() error { return nil }
+
+func (r *testRows) Next(dest []driver.Value) error {
+	if r.row >= len(r.tbl.rows) {
+		return io.EOF
+	}
+	copy(dest, r.tbl.rows[r.row])
+	r.row++
+	return nil
+}
+
+func (r *testRows) ColumnTypeScanType(i int) reflect.Type {
+	if r.tbl.cols[i].scanType == nil {
+		return reflect.TypeOf(new(interface{})).Elem()
+	}
+	return r.tbl.cols[i].scanType
+}
+
+func (r *testRows) ColumnTypeDatabaseTypeName(i int) string { return r.tbl.cols[i].dbType }
+
+func (r *testRows) ColumnTypeNullable(i int) (nullable, ok bool) { return r.tbl.cols[i].nullable, true }
@@ -0,0 +1,332 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlrows
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// Reader reads the rows of a database/sql query and creates array.Records.
+//
+// Values are scanned directly into typed destinations, one per column,
+// rather than through interface{} values.
+type Reader struct {
+	rows   *sql.Rows
+	schema *arrow.Schema
+
+	refs int64
+	bld  *array.RecordBuilder
+	cur  array.Record
+	err  error
+
+	chunk int
+	done  bool
+	dest  []interface{} // scan destinations, one per column
+
+	mem memory.Allocator
+}
+
+// NewReader returns a reader that reads from rows and creates array.Records.
+// The schema of the records is derived from the SQL column types (see
+// SchemaOf), unless one is given with WithSchema.
+//
+// The reader does not close rows.
+//
+// NewReader panics if the given schema contains fields that have types that
+// are not supported.
+func NewReader(rows *sql.Rows, opts ...Option) (*Reader, error) {
+	rr := &Reader{rows: rows, refs: 1, chunk: 1}
+	for _, opt := range opts {
+		opt(rr)
+	}
+
+	cols, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, fmt.Errorf("arrow/sqlrows: could not retrieve column types: %w", err)
+	}
+
+	switch rr.schema {
+	case nil:
+		rr.schema, err = SchemaOf(cols)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		validate(rr.schema)
+		if n := len(rr.schema.Fields()); n != len(cols) {
+			return nil, fmt.Errorf("arrow/sqlrows: schema has %d fields, rows have %d columns", n, len(cols))
+		}
+	}
+
+	if rr.mem == nil {
+		rr.mem = memory.DefaultAllocator
+	}
+
+	rr.bld = array.NewRecordBuilder(rr.mem, rr.schema)
+	rr.dest = make([]interface{}, len(cols))
+	for i, f := range rr.schema.Fields() {
+		rr.dest[i] = destOf(f.Type)
+	}
+	return rr, nil
+}
+
+// Err returns the last error encountered during the iteration over the
+// SQL rows.
+func (r *Reader) Err() error { return r.err }
+
+func (r *Reader) Schema() *arrow.Schema { return r.schema }
+
+// Record returns the current record that has been extracted from the
+// SQL rows.
+// It is valid until the next call to Next.
+func (r *Reader) Record() array.Record { return r.cur }
+
+// Next returns whether a Record could be extracted from the SQL rows.
+func (r *Reader) Next() bool {
+	if r.cur != nil {
+		r.cur.Release()
+		r.cur = nil
+	}
+
+	if r.err != nil || r.done {
+		return false
+	}
+
+	limit := r.chunk
+	if limit == 0 {
+		limit = 1
+	}
+
+	n := 0
+	for limit < 0 || n < limit {
+		if !r.rows.Next() {
+			r.done = true
+			r.err = r.rows.Err()
+			break
+		}
+		if r.err = r.rows.Scan(r.dest...); r.err != nil {
+			break
+		}
+		if r.err = r.read(); r.err != nil {
+			break
+		}
+		n++
+	}
+
+	if r.err != nil {
+		// drop the partially built record.
+		r.bld.NewRecord().Release()
+		return false
+	}
+
+	r.cur = r.bld.NewRecord()
+	if n == 0 {
+		r.cur.Release()
+		r.cur = nil
+		return false
+	}
+	return true
+}
+
+// destOf returns the scan destination for values of type dt.
+func destOf(dt arrow.DataType) interface{} {
+	switch dt.(type) {
+	case *arrow.BooleanType:
+		return new(sql.NullBool)
+	case *arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type, *arrow.Int64Type,
+		*arrow.Uint8Type, *arrow.Uint16Type, *arrow.Uint32Type:
+		return new(sql.NullInt64)
+	case *arrow.Uint64Type:
+		// values above math.MaxInt64 can not be scanned into an int64.
+		return new(sql.NullString)
+	case *arrow.Float32Type, *arrow.Float64Type:
+		return new(sql.NullFloat64)
+	case *arrow.StringType:
+		return new(sql.NullString)
+	case *arrow.BinaryType:
+		return new(sql.RawBytes)
+	case *arrow.Date32Type, *arrow.Date64Type, *arrow.TimestampType:
+		return new(sql.NullTime)
+	}
+	panic(fmt.Errorf("arrow/sqlrows: unsupported data type %s", dt.Name()))
+}
+
+// read appends the scanned values of the current row to the record builder.
+func (r *Reader) read() error {
+	for i, dest := range r.dest {
+		field := r.bld.Field(i)
+		switch dest := dest.(type) {
+		case *sql.NullBool:
+			if !dest.Valid {
+				field.AppendNull()
+				continue
+			}
+			field.(*array.BooleanBuilder).Append(dest.Bool)
+
+		case *sql.NullInt64:
+			if !dest.Valid {
+				field.AppendNull()
+				continue
+			}
+			if !appendInt(field, dest.Int64) {
+				return fmt.Errorf("arrow/sqlrows: column %d (%s): value %d overflows %s", i, r.schema.Field(i).Name, dest.Int64, r.schema.Field(i).Type.Name())
+			}
+
+		case *sql.NullFloat64:
+			if !dest.Valid {
+				field.AppendNull()
+				continue
+			}
+			switch field := field.(type) {
+			case *array.Float32Builder:
+				field.Append(float32(dest.Float64))
+			case *array.Float64Builder:
+				field.Append(dest.Float64)
+			}
+
+		case *sql.NullString:
+			if !dest.Valid {
+				field.AppendNull()
+				continue
+			}
+			switch field := field.(type) {
+			case *array.StringBuilder:
+				field.Append(dest.String)
+			case *array.Uint64Builder:
+				var v uint64
+				if _, err := fmt.Sscan(dest.String, &v); err != nil {
+					return fmt.Errorf("arrow/sqlrows: column %d (%s): could not parse %q as uint64", i, r.schema.Field(i).Name, dest.String)
+				}
+				field.Append(v)
+			}
+
+		case *sql.RawBytes:
+			if *dest == nil {
+				field.AppendNull()
+				continue
+			}
+			field.(*array.BinaryBuilder).Append(*dest)
+
+		case *sql.NullTime:
+			if !dest.Valid {
+				field.AppendNull()
+				continue
+			}
+			t := dest.Time
+			switch field := field.(type) {
+			case *array.Date32Builder:
+				field.Append(arrow.Date32(floorDiv(t.Unix(), 86400)))
+			case *array.Date64Builder:
+				field.Append(arrow.Date64(t.Unix()*1e3 + int64(t.Nanosecond())/1e6))
+			case *array.TimestampBuilder:
+				d := unitNanos[r.schema.Field(i).Type.(*arrow.TimestampType).Unit]
+				field.Append(arrow.Timestamp(t.Unix()*(1e9/d) + int64(t.Nanosecond())/d))
+			}
+		}
+	}
+	return nil
+}
+
+var unitNanos = map[arrow.TimeUnit]int64{
+	arrow.Second:      1e9,
+	arrow.Millisecond: 1e6,
+	arrow.Microsecond: 1e3,
+	arrow.Nanosecond:  1,
+}
+
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// appendInt appends v to the integer builder b.
+// appendInt returns false if v overflows the type of b.
+func appendInt(b array.Builder, v int64) bool {
+	var lo, hi int64
+	switch b.(type) {
+	case *array.Int8Builder:
+		lo, hi = math.MinInt8, math.MaxInt8
+	case *array.Int16Builder:
+		lo, hi = math.MinInt16, math.MaxInt16
+	case *array.Int32Builder:
+		lo, hi = math.MinInt32, math.MaxInt32
+	case *array.Uint8Builder:
+		lo, hi = 0, math.MaxUint8
+	case *array.Uint16Builder:
+		lo, hi = 0, math.MaxUint16
+	case *array.Uint32Builder:
+		lo, hi = 0, math.MaxUint32
+	default:
+		lo, hi = math.MinInt64, math.MaxInt64
+	}
+	if v < lo || v > hi {
+		return false
+	}
+
+	switch b := b.(type) {
+	case *array.Int8Builder:
+		b.Append(int8(v))
+	case *array.Int16Builder:
+		b.Append(int16(v))
+	case *array.Int32Builder:
+		b.Append(int32(v))
+	case *array.Int64Builder:
+		b.Append(v)
+	case *array.Uint8Builder:
+		b.Append(uint8(v))
+	case *array.Uint16Builder:
+		b.Append(uint16(v))
+	case *array.Uint32Builder:
+		b.Append(uint32(v))
+	}
+	return true
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (r *Reader) Retain() {
+	atomic.AddInt64(&r.refs, 1)
+}
+
+// Release decreases the reference count by 1.
+// When the reference count goes to zero, the memory is freed.
+// Release may be called simultaneously from multiple goroutines.
+func (r *Reader) Release() {
+	debug.Assert(atomic.LoadInt64(&r.refs) > 0, "too many releases")
+
+	if atomic.AddInt64(&r.refs, -1) == 0 {
+		if r.cur != nil {
+			r.cur.Release()
+		}
+		r.bld.Release()
+	}
+}
+
+var (
+	_ array.RecordReader = (*Reader)(nil)
+)
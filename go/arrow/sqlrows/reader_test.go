@@ -0,0 +1,225 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlrows_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/sqlrows"
+)
+
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("arrowtest", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func init() {
+	when := time.Date(2021, 3, 4, 5, 6, 7, 8000, time.UTC)
+	drv.tables["users"] = &testTable{
+		cols: []testColumn{
+			{name: "id", dbType: "BIGINT", scanType: reflect.TypeOf(int64(0))},
+			{name: "name", dbType: "VARCHAR", scanType: reflect.TypeOf(sql.NullString{}), nullable: true},
+			{name: "score", dbType: "DOUBLE PRECISION", nullable: true},
+			{name: "active", dbType: "BOOLEAN", nullable: true},
+			{name: "avatar", dbType: "BYTEA", nullable: true},
+			{name: "created", dbType: "TIMESTAMP", nullable: true},
+			{name: "age", dbType: "SMALLINT", nullable: true},
+		},
+		rows: [][]driver.Value{
+			{int64(1), "alice", 1.5, true, []byte{1}, when, int64(30)},
+			{int64(2), nil, nil, nil, nil, nil, nil},
+			{int64(3), "carol", -2.0, false, []byte{}, when, int64(40)},
+		},
+	}
+	drv.tables["overflow"] = &testTable{
+		cols: []testColumn{{name: "v", dbType: "SMALLINT"}},
+		rows: [][]driver.Value{{int64(1 << 20)}},
+	}
+	drv.tables["unknown"] = &testTable{
+		cols: []testColumn{{name: "v", dbType: "GEOMETRY"}},
+	}
+}
+
+func TestReader(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	db := openDB(t)
+	defer db.Close()
+
+	rows, err := db.Query("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	r, err := sqlrows.NewReader(rows, sqlrows.WithAllocator(mem), sqlrows.WithChunk(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	want := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float64, Nullable: true},
+		{Name: "active", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+		{Name: "avatar", Type: arrow.BinaryTypes.Binary, Nullable: true},
+		{Name: "created", Type: &arrow.TimestampType{Unit: arrow.Nanosecond}, Nullable: true},
+		{Name: "age", Type: arrow.PrimitiveTypes.Int16, Nullable: true},
+	}, nil)
+	if !r.Schema().Equal(want) {
+		t.Fatalf("invalid schema:\ngot= %v\nwant=%v", r.Schema(), want)
+	}
+
+	var nrows []int64
+	var recs []array.Record
+	for r.Next() {
+		rec := r.Record()
+		rec.Retain()
+		recs = append(recs, rec)
+		nrows = append(nrows, rec.NumRows())
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		for _, rec := range recs {
+			rec.Release()
+		}
+	}()
+
+	if !reflect.DeepEqual(nrows, []int64{2, 1}) {
+		t.Fatalf("invalid chunks: got=%v, want=[2 1]", nrows)
+	}
+
+	rec := recs[0]
+	if got, want := rec.Column(0).(*array.Int64).Int64Values(), []int64{1, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid ids: got=%v, want=%v", got, want)
+	}
+	for i := 1; i < int(rec.NumCols()); i++ {
+		if !rec.Column(i).IsNull(1) {
+			t.Fatalf("column %q: expected a null value", rec.ColumnName(i))
+		}
+	}
+	if got, want := rec.Column(1).(*array.String).Value(0), "alice"; got != want {
+		t.Fatalf("invalid name: got=%q, want=%q", got, want)
+	}
+	if got, want := rec.Column(5).(*array.Timestamp).Value(0), arrow.Timestamp(time.Date(2021, 3, 4, 5, 6, 7, 8000, time.UTC).UnixNano()); got != want {
+		t.Fatalf("invalid timestamp: got=%v, want=%v", got, want)
+	}
+	if got, want := rec.Column(6).(*array.Int16).Value(0), int16(30); got != want {
+		t.Fatalf("invalid age: got=%v, want=%v", got, want)
+	}
+	if got := recs[1].Column(4).(*array.Binary); got.IsNull(0) || got.ValueLen(0) != 0 {
+		t.Fatalf("empty binary value should not be null")
+	}
+}
+
+func TestReaderWithSchema(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	db := openDB(t)
+	defer db.Close()
+
+	rows, err := db.Query("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Uint64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "score", Type: arrow.PrimitiveTypes.Float32, Nullable: true},
+		{Name: "active", Type: arrow.FixedWidthTypes.Boolean, Nullable: true},
+		{Name: "avatar", Type: arrow.BinaryTypes.Binary, Nullable: true},
+		{Name: "created", Type: arrow.PrimitiveTypes.Date32, Nullable: true},
+		{Name: "age", Type: arrow.PrimitiveTypes.Uint8, Nullable: true},
+	}, nil)
+	r, err := sqlrows.NewReader(rows, sqlrows.WithAllocator(mem), sqlrows.WithSchema(schema), sqlrows.WithChunk(-1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	if !r.Next() {
+		t.Fatalf("no record: %v", r.Err())
+	}
+	rec := r.Record()
+	if got, want := rec.NumRows(), int64(3); got != want {
+		t.Fatalf("invalid number of rows: got=%d, want=%d", got, want)
+	}
+	if got, want := rec.Column(0).(*array.Uint64).Uint64Values(), []uint64{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid ids: got=%v, want=%v", got, want)
+	}
+	if got, want := rec.Column(5).(*array.Date32).Value(2), arrow.Date32(18690); got != want {
+		t.Fatalf("invalid date: got=%v, want=%v", got, want)
+	}
+	if r.Next() {
+		t.Fatalf("unexpected record")
+	}
+}
+
+func TestReaderErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	db := openDB(t)
+	defer db.Close()
+
+	rows, err := db.Query("unknown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = sqlrows.NewReader(rows, sqlrows.WithAllocator(mem))
+	rows.Close()
+	if got, want := err, `arrow/sqlrows: column 0 (v): unsupported SQL column type "GEOMETRY"`; got == nil || got.Error() != want {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%s", got, want)
+	}
+
+	rows, err = db.Query("overflow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+
+	r, err := sqlrows.NewReader(rows, sqlrows.WithAllocator(mem))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Release()
+
+	if r.Next() {
+		t.Fatalf("unexpected record")
+	}
+	if got, want := r.Err(), `arrow/sqlrows: column 0 (v): value 1048576 overflows int16`; got == nil || got.Error() != want {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%s", got, want)
+	}
+}
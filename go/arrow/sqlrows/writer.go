@@ -0,0 +1,222 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlrows
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+)
+
+// Default size of the batches of Insert: 100 rows, but no more than the 999
+// arguments older SQLite versions accept in a statement.
+const (
+	defaultBatchRows = 100
+	defaultBatchArgs = 999
+)
+
+// Execer executes SQL statements. It is implemented by *sql.DB, *sql.Conn
+// and *sql.Tx.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// inserter holds the configuration of Insert.
+type inserter struct {
+	batch       int
+	placeholder Placeholder
+}
+
+// Insert inserts the rows of rec into table, by batches of rows sent in
+// multi-row INSERT statements executed with db:
+//
+//	INSERT INTO table VALUES (?, ?), (?, ?), ...
+//
+// table is written as is in the statements, and may be followed by the list
+// of the columns receiving the values, as in "items (id, name)". The values
+// of the rows are passed as arguments, in column order, as int64, float64,
+// bool, string, []byte or time.Time, and nulls as nil.
+//
+// By default, a statement holds up to 100 rows and 999 arguments, and its
+// placeholders are question marks: WithBatchSize and WithPlaceholder adapt
+// them to the database. Statements are executed in order, and Insert stops
+// at the first error: db may be a transaction so that no row is inserted in
+// that case.
+func Insert(ctx context.Context, db Execer, table string, rec array.Record, opts ...Option) error {
+	ins := inserter{placeholder: QuestionMark}
+	for _, opt := range opts {
+		opt(&ins)
+	}
+
+	cols := rec.Columns()
+	for i, col := range cols {
+		if err := validValue(col.DataType()); err != nil {
+			return fmt.Errorf("arrow/sqlrows: column %d (%s): %w", i, rec.ColumnName(i), err)
+		}
+	}
+	nrows := int(rec.NumRows())
+	switch {
+	case nrows == 0:
+		return nil
+	case len(cols) == 0:
+		return fmt.Errorf("arrow/sqlrows: no columns to insert")
+	}
+
+	batch := ins.batch
+	if batch <= 0 {
+		batch = defaultBatchArgs / len(cols)
+		switch {
+		case batch > defaultBatchRows:
+			batch = defaultBatchRows
+		case batch == 0:
+			batch = 1
+		}
+	}
+
+	var (
+		query string
+		args  = make([]interface{}, 0, batch*len(cols))
+	)
+	for beg := 0; beg < nrows; beg += batch {
+		end := beg + batch
+		if end > nrows {
+			end = nrows
+		}
+
+		args = args[:0]
+		for row := beg; row < end; row++ {
+			for j, col := range cols {
+				v, err := valueOf(col, row)
+				if err != nil {
+					return fmt.Errorf("arrow/sqlrows: row %d, column %d (%s): %w", row, j, rec.ColumnName(j), err)
+				}
+				args = append(args, v)
+			}
+		}
+
+		if query == "" || end-beg < batch {
+			query = ins.query(table, end-beg, len(cols))
+		}
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("arrow/sqlrows: rows %d to %d: %w", beg, end-1, err)
+		}
+	}
+	return nil
+}
+
+// query returns the INSERT statement of rows rows of cols values into table.
+func (ins *inserter) query(table string, rows, cols int) string {
+	var b strings.Builder
+	b.WriteString("INSERT INTO ")
+	b.WriteString(table)
+	b.WriteString(" VALUES ")
+	n := 0
+	for i := 0; i < rows; i++ {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('(')
+		for j := 0; j < cols; j++ {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			n++
+			switch ins.placeholder {
+			case Dollar:
+				b.WriteString("$" + strconv.Itoa(n))
+			case Colon:
+				b.WriteString(":" + strconv.Itoa(n))
+			case AtP:
+				b.WriteString("@p" + strconv.Itoa(n))
+			default:
+				b.WriteByte('?')
+			}
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+func validValue(dt arrow.DataType) error {
+	switch dt.(type) {
+	case *arrow.BooleanType,
+		*arrow.Int8Type, *arrow.Int16Type, *arrow.Int32Type, *arrow.Int64Type,
+		*arrow.Uint8Type, *arrow.Uint16Type, *arrow.Uint32Type, *arrow.Uint64Type,
+		*arrow.Float32Type, *arrow.Float64Type,
+		*arrow.StringType, *arrow.BinaryType,
+		*arrow.Date32Type, *arrow.Date64Type, *arrow.TimestampType:
+		return nil
+	}
+	return fmt.Errorf("unsupported data type %s", dt.Name())
+}
+
+// valueOf returns the SQL argument for the i-th value of arr.
+func valueOf(arr array.Interface, i int) (interface{}, error) {
+	if arr.IsNull(i) {
+		return nil, nil
+	}
+
+	switch arr := arr.(type) {
+	case *array.Boolean:
+		return arr.Value(i), nil
+	case *array.Int8:
+		return int64(arr.Value(i)), nil
+	case *array.Int16:
+		return int64(arr.Value(i)), nil
+	case *array.Int32:
+		return int64(arr.Value(i)), nil
+	case *array.Int64:
+		return arr.Value(i), nil
+	case *array.Uint8:
+		return int64(arr.Value(i)), nil
+	case *array.Uint16:
+		return int64(arr.Value(i)), nil
+	case *array.Uint32:
+		return int64(arr.Value(i)), nil
+	case *array.Uint64:
+		v := arr.Value(i)
+		if v > math.MaxInt64 {
+			return nil, fmt.Errorf("value %d overflows int64", v)
+		}
+		return int64(v), nil
+	case *array.Float32:
+		return float64(arr.Value(i)), nil
+	case *array.Float64:
+		return arr.Value(i), nil
+	case *array.String:
+		return arr.Value(i), nil
+	case *array.Binary:
+		return arr.Value(i), nil
+	case *array.Date32:
+		return time.Unix(int64(arr.Value(i))*86400, 0).UTC(), nil
+	case *array.Date64:
+		v := int64(arr.Value(i))
+		return time.Unix(v/1e3, (v%1e3)*1e6).UTC(), nil
+	case *array.Timestamp:
+		d := unitNanos[arr.DataType().(*arrow.TimestampType).Unit]
+		v := int64(arr.Value(i))
+		return time.Unix(v/(1e9/d), (v%(1e9/d))*d).UTC(), nil
+	}
+	return nil, fmt.Errorf("unsupported data type %s", arr.DataType().Name())
+}
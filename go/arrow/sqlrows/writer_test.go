@@ -0,0 +1,198 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlrows_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/sqlrows"
+)
+
+func TestInsert(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+		{Name: "day", Type: arrow.PrimitiveTypes.Date32},
+	}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 2, 3}, nil)
+	b.Field(1).(*array.StringBuilder).AppendValues([]string{"a", "", "c"}, []bool{true, false, true})
+	b.Field(2).(*array.Date32Builder).AppendValues([]arrow.Date32{0, 1, 2}, nil)
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	db := openDB(t)
+	defer db.Close()
+
+	for _, tc := range []struct {
+		name     string
+		opts     []sqlrows.Option
+		queries  []string
+		executed [][]driver.Value
+	}{
+		{
+			name:    "default",
+			queries: []string{"INSERT INTO t VALUES (?, ?, ?), (?, ?, ?), (?, ?, ?)"},
+			executed: [][]driver.Value{{
+				int64(1), "a", time.Unix(0, 0).UTC(),
+				int64(2), nil, time.Unix(86400, 0).UTC(),
+				int64(3), "c", time.Unix(2*86400, 0).UTC(),
+			}},
+		},
+		{
+			name: "batches",
+			opts: []sqlrows.Option{sqlrows.WithBatchSize(2), sqlrows.WithPlaceholder(sqlrows.Dollar)},
+			queries: []string{
+				"INSERT INTO t VALUES ($1, $2, $3), ($4, $5, $6)",
+				"INSERT INTO t VALUES ($1, $2, $3)",
+			},
+			executed: [][]driver.Value{
+				{int64(1), "a", time.Unix(0, 0).UTC(), int64(2), nil, time.Unix(86400, 0).UTC()},
+				{int64(3), "c", time.Unix(2*86400, 0).UTC()},
+			},
+		},
+		{
+			name: "rows",
+			opts: []sqlrows.Option{sqlrows.WithBatchSize(1), sqlrows.WithPlaceholder(sqlrows.AtP)},
+			queries: []string{
+				"INSERT INTO t VALUES (@p1, @p2, @p3)",
+				"INSERT INTO t VALUES (@p1, @p2, @p3)",
+				"INSERT INTO t VALUES (@p1, @p2, @p3)",
+			},
+			executed: [][]driver.Value{
+				{int64(1), "a", time.Unix(0, 0).UTC()},
+				{int64(2), nil, time.Unix(86400, 0).UTC()},
+				{int64(3), "c", time.Unix(2*86400, 0).UTC()},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			drv.mu.Lock()
+			drv.queries, drv.executed = nil, nil
+			drv.mu.Unlock()
+
+			if err := sqlrows.Insert(context.Background(), db, "t", rec, tc.opts...); err != nil {
+				t.Fatal(err)
+			}
+
+			drv.mu.Lock()
+			defer drv.mu.Unlock()
+			if !reflect.DeepEqual(drv.queries, tc.queries) {
+				t.Fatalf("invalid queries:\ngot= %q\nwant=%q", drv.queries, tc.queries)
+			}
+			if !reflect.DeepEqual(drv.executed, tc.executed) {
+				t.Fatalf("invalid arguments:\ngot= %v\nwant=%v", drv.executed, tc.executed)
+			}
+		})
+	}
+}
+
+func TestInsertDefaultBatch(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	fields := make([]arrow.Field, 400)
+	for i := range fields {
+		fields[i] = arrow.Field{Name: fmt.Sprint("c", i), Type: arrow.PrimitiveTypes.Int64}
+	}
+	schema := arrow.NewSchema(fields, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	for row := 0; row < 5; row++ {
+		for i := range fields {
+			b.Field(i).(*array.Int64Builder).Append(int64(row))
+		}
+	}
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	db := openDB(t)
+	defer db.Close()
+
+	drv.mu.Lock()
+	drv.executed = nil
+	drv.mu.Unlock()
+
+	if err := sqlrows.Insert(context.Background(), db, "wide", rec); err != nil {
+		t.Fatal(err)
+	}
+
+	// 400 columns leave room for 2 rows in 999 arguments.
+	drv.mu.Lock()
+	defer drv.mu.Unlock()
+	var got []int
+	for _, args := range drv.executed {
+		got = append(got, len(args)/len(fields))
+	}
+	if want := []int{2, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid batches: got=%v, want=%v", got, want)
+	}
+}
+
+func TestInsertErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	db := openDB(t)
+	defer db.Close()
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "l", Type: arrow.ListOf(arrow.PrimitiveTypes.Int32)}}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	b.Field(0).AppendNull()
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	err := sqlrows.Insert(context.Background(), db, "t", rec)
+	if got, want := err, "arrow/sqlrows: column 0 (l): unsupported data type list"; got == nil || got.Error() != want {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%s", got, want)
+	}
+
+	ints := arrow.NewSchema([]arrow.Field{{Name: "i", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	ib := array.NewRecordBuilder(mem, ints)
+	defer ib.Release()
+	ib.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2, 3}, nil)
+	irec := ib.NewRecord()
+	defer irec.Release()
+
+	drv.mu.Lock()
+	drv.fail = true
+	drv.mu.Unlock()
+	defer func() {
+		drv.mu.Lock()
+		drv.fail = false
+		drv.mu.Unlock()
+	}()
+
+	err = sqlrows.Insert(context.Background(), db, "t", irec, sqlrows.WithBatchSize(2))
+	if got, want := err, "arrow/sqlrows: rows 0 to 1: statement failed"; got == nil || got.Error() != want {
+		t.Fatalf("invalid error:\ngot= %v\nwant=%s", got, want)
+	}
+}
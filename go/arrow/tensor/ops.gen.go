@@ -0,0 +1,763 @@
+// Code generated by tensor/ops.gen.go.tmpl. DO NOT EDIT.
+
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensor
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+func elementwise(mem memory.Allocator, op binaryOp, a, b Interface) (Interface, error) {
+	if err := checkElementwise(a, b); err != nil {
+		return nil, err
+	}
+	switch a := a.(type) {
+	case *Int8:
+		return elementwiseInt8(mem, op, a, b.(*Int8))
+	case *Int16:
+		return elementwiseInt16(mem, op, a, b.(*Int16))
+	case *Int32:
+		return elementwiseInt32(mem, op, a, b.(*Int32))
+	case *Int64:
+		return elementwiseInt64(mem, op, a, b.(*Int64))
+	case *Uint8:
+		return elementwiseUint8(mem, op, a, b.(*Uint8))
+	case *Uint16:
+		return elementwiseUint16(mem, op, a, b.(*Uint16))
+	case *Uint32:
+		return elementwiseUint32(mem, op, a, b.(*Uint32))
+	case *Uint64:
+		return elementwiseUint64(mem, op, a, b.(*Uint64))
+	case *Float32:
+		return elementwiseFloat32(mem, op, a, b.(*Float32))
+	case *Float64:
+		return elementwiseFloat64(mem, op, a, b.(*Float64))
+	}
+	return nil, fmt.Errorf("arrow/tensor: unsupported data type %s", a.DataType().Name())
+}
+
+func matMul(mem memory.Allocator, a, b Interface) (Interface, error) {
+	switch a := a.(type) {
+	case *Int8:
+		return matMulInt8(mem, a, b.(*Int8)), nil
+	case *Int16:
+		return matMulInt16(mem, a, b.(*Int16)), nil
+	case *Int32:
+		return matMulInt32(mem, a, b.(*Int32)), nil
+	case *Int64:
+		return matMulInt64(mem, a, b.(*Int64)), nil
+	case *Uint8:
+		return matMulUint8(mem, a, b.(*Uint8)), nil
+	case *Uint16:
+		return matMulUint16(mem, a, b.(*Uint16)), nil
+	case *Uint32:
+		return matMulUint32(mem, a, b.(*Uint32)), nil
+	case *Uint64:
+		return matMulUint64(mem, a, b.(*Uint64)), nil
+	case *Float32:
+		return matMulFloat32(mem, a, b.(*Float32)), nil
+	case *Float64:
+		return matMulFloat64(mem, a, b.(*Float64)), nil
+	}
+	return nil, fmt.Errorf("arrow/tensor: unsupported data type %s", a.DataType().Name())
+}
+
+// rowMajorValues returns the elements of the tensor in row-major order.
+func (tsr *Int8) rowMajorValues() []int8 {
+	if tsr.IsRowMajor() {
+		return tsr.values
+	}
+	idx := indices(tsr)
+	out := make([]int8, len(idx))
+	for i, j := range idx {
+		out[i] = tsr.values[j]
+	}
+	return out
+}
+
+func elementwiseInt8(mem memory.Allocator, op binaryOp, a, b *Int8) (Interface, error) {
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(a.Len() * arrow.Int8SizeBytes)
+	var (
+		out = arrow.Int8Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	switch op {
+	case opAdd:
+		for i := range out {
+			out[i] = x[i] + y[i]
+		}
+	case opSub:
+		for i := range out {
+			out[i] = x[i] - y[i]
+		}
+	case opMul:
+		for i := range out {
+			out[i] = x[i] * y[i]
+		}
+	case opDiv:
+		for i := range out {
+			if y[i] == 0 {
+				buf.Release()
+				return nil, fmt.Errorf("arrow/tensor: integer division by zero")
+			}
+			out[i] = x[i] / y[i]
+		}
+	}
+	return newResult(a.DataType(), buf, append([]int64(nil), a.Shape()...), dimNames(a)), nil
+}
+
+func matMulInt8(mem memory.Allocator, a, b *Int8) Interface {
+	m, k, n := int(a.Shape()[0]), int(a.Shape()[1]), int(b.Shape()[1])
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(m * n * arrow.Int8SizeBytes)
+	memory.Set(buf.Bytes(), 0)
+	var (
+		out = arrow.Int8Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	for i := 0; i < m; i++ {
+		row := out[i*n : (i+1)*n]
+		for p := 0; p < k; p++ {
+			v := x[i*k+p]
+			for j, w := range y[p*n : (p+1)*n] {
+				row[j] += v * w
+			}
+		}
+	}
+	names := []string{dimNames(a)[0], dimNames(b)[1]}
+	return newResult(a.DataType(), buf, []int64{int64(m), int64(n)}, names)
+}
+
+// rowMajorValues returns the elements of the tensor in row-major order.
+func (tsr *Int16) rowMajorValues() []int16 {
+	if tsr.IsRowMajor() {
+		return tsr.values
+	}
+	idx := indices(tsr)
+	out := make([]int16, len(idx))
+	for i, j := range idx {
+		out[i] = tsr.values[j]
+	}
+	return out
+}
+
+func elementwiseInt16(mem memory.Allocator, op binaryOp, a, b *Int16) (Interface, error) {
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(a.Len() * arrow.Int16SizeBytes)
+	var (
+		out = arrow.Int16Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	switch op {
+	case opAdd:
+		for i := range out {
+			out[i] = x[i] + y[i]
+		}
+	case opSub:
+		for i := range out {
+			out[i] = x[i] - y[i]
+		}
+	case opMul:
+		for i := range out {
+			out[i] = x[i] * y[i]
+		}
+	case opDiv:
+		for i := range out {
+			if y[i] == 0 {
+				buf.Release()
+				return nil, fmt.Errorf("arrow/tensor: integer division by zero")
+			}
+			out[i] = x[i] / y[i]
+		}
+	}
+	return newResult(a.DataType(), buf, append([]int64(nil), a.Shape()...), dimNames(a)), nil
+}
+
+func matMulInt16(mem memory.Allocator, a, b *Int16) Interface {
+	m, k, n := int(a.Shape()[0]), int(a.Shape()[1]), int(b.Shape()[1])
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(m * n * arrow.Int16SizeBytes)
+	memory.Set(buf.Bytes(), 0)
+	var (
+		out = arrow.Int16Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	for i := 0; i < m; i++ {
+		row := out[i*n : (i+1)*n]
+		for p := 0; p < k; p++ {
+			v := x[i*k+p]
+			for j, w := range y[p*n : (p+1)*n] {
+				row[j] += v * w
+			}
+		}
+	}
+	names := []string{dimNames(a)[0], dimNames(b)[1]}
+	return newResult(a.DataType(), buf, []int64{int64(m), int64(n)}, names)
+}
+
+// rowMajorValues returns the elements of the tensor in row-major order.
+func (tsr *Int32) rowMajorValues() []int32 {
+	if tsr.IsRowMajor() {
+		return tsr.values
+	}
+	idx := indices(tsr)
+	out := make([]int32, len(idx))
+	for i, j := range idx {
+		out[i] = tsr.values[j]
+	}
+	return out
+}
+
+func elementwiseInt32(mem memory.Allocator, op binaryOp, a, b *Int32) (Interface, error) {
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(a.Len() * arrow.Int32SizeBytes)
+	var (
+		out = arrow.Int32Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	switch op {
+	case opAdd:
+		for i := range out {
+			out[i] = x[i] + y[i]
+		}
+	case opSub:
+		for i := range out {
+			out[i] = x[i] - y[i]
+		}
+	case opMul:
+		for i := range out {
+			out[i] = x[i] * y[i]
+		}
+	case opDiv:
+		for i := range out {
+			if y[i] == 0 {
+				buf.Release()
+				return nil, fmt.Errorf("arrow/tensor: integer division by zero")
+			}
+			out[i] = x[i] / y[i]
+		}
+	}
+	return newResult(a.DataType(), buf, append([]int64(nil), a.Shape()...), dimNames(a)), nil
+}
+
+func matMulInt32(mem memory.Allocator, a, b *Int32) Interface {
+	m, k, n := int(a.Shape()[0]), int(a.Shape()[1]), int(b.Shape()[1])
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(m * n * arrow.Int32SizeBytes)
+	memory.Set(buf.Bytes(), 0)
+	var (
+		out = arrow.Int32Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	for i := 0; i < m; i++ {
+		row := out[i*n : (i+1)*n]
+		for p := 0; p < k; p++ {
+			v := x[i*k+p]
+			for j, w := range y[p*n : (p+1)*n] {
+				row[j] += v * w
+			}
+		}
+	}
+	names := []string{dimNames(a)[0], dimNames(b)[1]}
+	return newResult(a.DataType(), buf, []int64{int64(m), int64(n)}, names)
+}
+
+// rowMajorValues returns the elements of the tensor in row-major order.
+func (tsr *Int64) rowMajorValues() []int64 {
+	if tsr.IsRowMajor() {
+		return tsr.values
+	}
+	idx := indices(tsr)
+	out := make([]int64, len(idx))
+	for i, j := range idx {
+		out[i] = tsr.values[j]
+	}
+	return out
+}
+
+func elementwiseInt64(mem memory.Allocator, op binaryOp, a, b *Int64) (Interface, error) {
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(a.Len() * arrow.Int64SizeBytes)
+	var (
+		out = arrow.Int64Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	switch op {
+	case opAdd:
+		for i := range out {
+			out[i] = x[i] + y[i]
+		}
+	case opSub:
+		for i := range out {
+			out[i] = x[i] - y[i]
+		}
+	case opMul:
+		for i := range out {
+			out[i] = x[i] * y[i]
+		}
+	case opDiv:
+		for i := range out {
+			if y[i] == 0 {
+				buf.Release()
+				return nil, fmt.Errorf("arrow/tensor: integer division by zero")
+			}
+			out[i] = x[i] / y[i]
+		}
+	}
+	return newResult(a.DataType(), buf, append([]int64(nil), a.Shape()...), dimNames(a)), nil
+}
+
+func matMulInt64(mem memory.Allocator, a, b *Int64) Interface {
+	m, k, n := int(a.Shape()[0]), int(a.Shape()[1]), int(b.Shape()[1])
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(m * n * arrow.Int64SizeBytes)
+	memory.Set(buf.Bytes(), 0)
+	var (
+		out = arrow.Int64Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	for i := 0; i < m; i++ {
+		row := out[i*n : (i+1)*n]
+		for p := 0; p < k; p++ {
+			v := x[i*k+p]
+			for j, w := range y[p*n : (p+1)*n] {
+				row[j] += v * w
+			}
+		}
+	}
+	names := []string{dimNames(a)[0], dimNames(b)[1]}
+	return newResult(a.DataType(), buf, []int64{int64(m), int64(n)}, names)
+}
+
+// rowMajorValues returns the elements of the tensor in row-major order.
+func (tsr *Uint8) rowMajorValues() []uint8 {
+	if tsr.IsRowMajor() {
+		return tsr.values
+	}
+	idx := indices(tsr)
+	out := make([]uint8, len(idx))
+	for i, j := range idx {
+		out[i] = tsr.values[j]
+	}
+	return out
+}
+
+func elementwiseUint8(mem memory.Allocator, op binaryOp, a, b *Uint8) (Interface, error) {
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(a.Len() * arrow.Uint8SizeBytes)
+	var (
+		out = arrow.Uint8Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	switch op {
+	case opAdd:
+		for i := range out {
+			out[i] = x[i] + y[i]
+		}
+	case opSub:
+		for i := range out {
+			out[i] = x[i] - y[i]
+		}
+	case opMul:
+		for i := range out {
+			out[i] = x[i] * y[i]
+		}
+	case opDiv:
+		for i := range out {
+			if y[i] == 0 {
+				buf.Release()
+				return nil, fmt.Errorf("arrow/tensor: integer division by zero")
+			}
+			out[i] = x[i] / y[i]
+		}
+	}
+	return newResult(a.DataType(), buf, append([]int64(nil), a.Shape()...), dimNames(a)), nil
+}
+
+func matMulUint8(mem memory.Allocator, a, b *Uint8) Interface {
+	m, k, n := int(a.Shape()[0]), int(a.Shape()[1]), int(b.Shape()[1])
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(m * n * arrow.Uint8SizeBytes)
+	memory.Set(buf.Bytes(), 0)
+	var (
+		out = arrow.Uint8Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	for i := 0; i < m; i++ {
+		row := out[i*n : (i+1)*n]
+		for p := 0; p < k; p++ {
+			v := x[i*k+p]
+			for j, w := range y[p*n : (p+1)*n] {
+				row[j] += v * w
+			}
+		}
+	}
+	names := []string{dimNames(a)[0], dimNames(b)[1]}
+	return newResult(a.DataType(), buf, []int64{int64(m), int64(n)}, names)
+}
+
+// rowMajorValues returns the elements of the tensor in row-major order.
+func (tsr *Uint16) rowMajorValues() []uint16 {
+	if tsr.IsRowMajor() {
+		return tsr.values
+	}
+	idx := indices(tsr)
+	out := make([]uint16, len(idx))
+	for i, j := range idx {
+		out[i] = tsr.values[j]
+	}
+	return out
+}
+
+func elementwiseUint16(mem memory.Allocator, op binaryOp, a, b *Uint16) (Interface, error) {
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(a.Len() * arrow.Uint16SizeBytes)
+	var (
+		out = arrow.Uint16Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	switch op {
+	case opAdd:
+		for i := range out {
+			out[i] = x[i] + y[i]
+		}
+	case opSub:
+		for i := range out {
+			out[i] = x[i] - y[i]
+		}
+	case opMul:
+		for i := range out {
+			out[i] = x[i] * y[i]
+		}
+	case opDiv:
+		for i := range out {
+			if y[i] == 0 {
+				buf.Release()
+				return nil, fmt.Errorf("arrow/tensor: integer division by zero")
+			}
+			out[i] = x[i] / y[i]
+		}
+	}
+	return newResult(a.DataType(), buf, append([]int64(nil), a.Shape()...), dimNames(a)), nil
+}
+
+func matMulUint16(mem memory.Allocator, a, b *Uint16) Interface {
+	m, k, n := int(a.Shape()[0]), int(a.Shape()[1]), int(b.Shape()[1])
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(m * n * arrow.Uint16SizeBytes)
+	memory.Set(buf.Bytes(), 0)
+	var (
+		out = arrow.Uint16Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	for i := 0; i < m; i++ {
+		row := out[i*n : (i+1)*n]
+		for p := 0; p < k; p++ {
+			v := x[i*k+p]
+			for j, w := range y[p*n : (p+1)*n] {
+				row[j] += v * w
+			}
+		}
+	}
+	names := []string{dimNames(a)[0], dimNames(b)[1]}
+	return newResult(a.DataType(), buf, []int64{int64(m), int64(n)}, names)
+}
+
+// rowMajorValues returns the elements of the tensor in row-major order.
+func (tsr *Uint32) rowMajorValues() []uint32 {
+	if tsr.IsRowMajor() {
+		return tsr.values
+	}
+	idx := indices(tsr)
+	out := make([]uint32, len(idx))
+	for i, j := range idx {
+		out[i] = tsr.values[j]
+	}
+	return out
+}
+
+func elementwiseUint32(mem memory.Allocator, op binaryOp, a, b *Uint32) (Interface, error) {
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(a.Len() * arrow.Uint32SizeBytes)
+	var (
+		out = arrow.Uint32Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	switch op {
+	case opAdd:
+		for i := range out {
+			out[i] = x[i] + y[i]
+		}
+	case opSub:
+		for i := range out {
+			out[i] = x[i] - y[i]
+		}
+	case opMul:
+		for i := range out {
+			out[i] = x[i] * y[i]
+		}
+	case opDiv:
+		for i := range out {
+			if y[i] == 0 {
+				buf.Release()
+				return nil, fmt.Errorf("arrow/tensor: integer division by zero")
+			}
+			out[i] = x[i] / y[i]
+		}
+	}
+	return newResult(a.DataType(), buf, append([]int64(nil), a.Shape()...), dimNames(a)), nil
+}
+
+func matMulUint32(mem memory.Allocator, a, b *Uint32) Interface {
+	m, k, n := int(a.Shape()[0]), int(a.Shape()[1]), int(b.Shape()[1])
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(m * n * arrow.Uint32SizeBytes)
+	memory.Set(buf.Bytes(), 0)
+	var (
+		out = arrow.Uint32Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	for i := 0; i < m; i++ {
+		row := out[i*n : (i+1)*n]
+		for p := 0; p < k; p++ {
+			v := x[i*k+p]
+			for j, w := range y[p*n : (p+1)*n] {
+				row[j] += v * w
+			}
+		}
+	}
+	names := []string{dimNames(a)[0], dimNames(b)[1]}
+	return newResult(a.DataType(), buf, []int64{int64(m), int64(n)}, names)
+}
+
+// rowMajorValues returns the elements of the tensor in row-major order.
+func (tsr *Uint64) rowMajorValues() []uint64 {
+	if tsr.IsRowMajor() {
+		return tsr.values
+	}
+	idx := indices(tsr)
+	out := make([]uint64, len(idx))
+	for i, j := range idx {
+		out[i] = tsr.values[j]
+	}
+	return out
+}
+
+func elementwiseUint64(mem memory.Allocator, op binaryOp, a, b *Uint64) (Interface, error) {
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(a.Len() * arrow.Uint64SizeBytes)
+	var (
+		out = arrow.Uint64Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	switch op {
+	case opAdd:
+		for i := range out {
+			out[i] = x[i] + y[i]
+		}
+	case opSub:
+		for i := range out {
+			out[i] = x[i] - y[i]
+		}
+	case opMul:
+		for i := range out {
+			out[i] = x[i] * y[i]
+		}
+	case opDiv:
+		for i := range out {
+			if y[i] == 0 {
+				buf.Release()
+				return nil, fmt.Errorf("arrow/tensor: integer division by zero")
+			}
+			out[i] = x[i] / y[i]
+		}
+	}
+	return newResult(a.DataType(), buf, append([]int64(nil), a.Shape()...), dimNames(a)), nil
+}
+
+func matMulUint64(mem memory.Allocator, a, b *Uint64) Interface {
+	m, k, n := int(a.Shape()[0]), int(a.Shape()[1]), int(b.Shape()[1])
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(m * n * arrow.Uint64SizeBytes)
+	memory.Set(buf.Bytes(), 0)
+	var (
+		out = arrow.Uint64Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	for i := 0; i < m; i++ {
+		row := out[i*n : (i+1)*n]
+		for p := 0; p < k; p++ {
+			v := x[i*k+p]
+			for j, w := range y[p*n : (p+1)*n] {
+				row[j] += v * w
+			}
+		}
+	}
+	names := []string{dimNames(a)[0], dimNames(b)[1]}
+	return newResult(a.DataType(), buf, []int64{int64(m), int64(n)}, names)
+}
+
+// rowMajorValues returns the elements of the tensor in row-major order.
+func (tsr *Float32) rowMajorValues() []float32 {
+	if tsr.IsRowMajor() {
+		return tsr.values
+	}
+	idx := indices(tsr)
+	out := make([]float32, len(idx))
+	for i, j := range idx {
+		out[i] = tsr.values[j]
+	}
+	return out
+}
+
+func elementwiseFloat32(mem memory.Allocator, op binaryOp, a, b *Float32) (Interface, error) {
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(a.Len() * arrow.Float32SizeBytes)
+	var (
+		out = arrow.Float32Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	switch op {
+	case opAdd:
+		for i := range out {
+			out[i] = x[i] + y[i]
+		}
+	case opSub:
+		for i := range out {
+			out[i] = x[i] - y[i]
+		}
+	case opMul:
+		for i := range out {
+			out[i] = x[i] * y[i]
+		}
+	case opDiv:
+		for i := range out {
+			out[i] = x[i] / y[i]
+		}
+	}
+	return newResult(a.DataType(), buf, append([]int64(nil), a.Shape()...), dimNames(a)), nil
+}
+
+func matMulFloat32(mem memory.Allocator, a, b *Float32) Interface {
+	m, k, n := int(a.Shape()[0]), int(a.Shape()[1]), int(b.Shape()[1])
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(m * n * arrow.Float32SizeBytes)
+	memory.Set(buf.Bytes(), 0)
+	var (
+		out = arrow.Float32Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	for i := 0; i < m; i++ {
+		row := out[i*n : (i+1)*n]
+		for p := 0; p < k; p++ {
+			v := x[i*k+p]
+			for j, w := range y[p*n : (p+1)*n] {
+				row[j] += v * w
+			}
+		}
+	}
+	names := []string{dimNames(a)[0], dimNames(b)[1]}
+	return newResult(a.DataType(), buf, []int64{int64(m), int64(n)}, names)
+}
+
+// rowMajorValues returns the elements of the tensor in row-major order.
+func (tsr *Float64) rowMajorValues() []float64 {
+	if tsr.IsRowMajor() {
+		return tsr.values
+	}
+	idx := indices(tsr)
+	out := make([]float64, len(idx))
+	for i, j := range idx {
+		out[i] = tsr.values[j]
+	}
+	return out
+}
+
+func elementwiseFloat64(mem memory.Allocator, op binaryOp, a, b *Float64) (Interface, error) {
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(a.Len() * arrow.Float64SizeBytes)
+	var (
+		out = arrow.Float64Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	switch op {
+	case opAdd:
+		for i := range out {
+			out[i] = x[i] + y[i]
+		}
+	case opSub:
+		for i := range out {
+			out[i] = x[i] - y[i]
+		}
+	case opMul:
+		for i := range out {
+			out[i] = x[i] * y[i]
+		}
+	case opDiv:
+		for i := range out {
+			out[i] = x[i] / y[i]
+		}
+	}
+	return newResult(a.DataType(), buf, append([]int64(nil), a.Shape()...), dimNames(a)), nil
+}
+
+func matMulFloat64(mem memory.Allocator, a, b *Float64) Interface {
+	m, k, n := int(a.Shape()[0]), int(a.Shape()[1]), int(b.Shape()[1])
+	buf := memory.NewResizableBuffer(mem)
+	buf.Resize(m * n * arrow.Float64SizeBytes)
+	memory.Set(buf.Bytes(), 0)
+	var (
+		out = arrow.Float64Traits.CastFromBytes(buf.Bytes())
+		x   = a.rowMajorValues()
+		y   = b.rowMajorValues()
+	)
+	for i := 0; i < m; i++ {
+		row := out[i*n : (i+1)*n]
+		for p := 0; p < k; p++ {
+			v := x[i*k+p]
+			for j, w := range y[p*n : (p+1)*n] {
+				row[j] += v * w
+			}
+		}
+	}
+	names := []string{dimNames(a)[0], dimNames(b)[1]}
+	return newResult(a.DataType(), buf, []int64{int64(m), int64(n)}, names)
+}
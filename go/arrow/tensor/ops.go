@@ -0,0 +1,309 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensor
+
+import (
+	"fmt"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// byteWidth returns the size in bytes of the elements of t.
+func byteWidth(t Interface) int64 {
+	return int64(t.DataType().(arrow.FixedWidthDataType).BitWidth()) / 8
+}
+
+// view returns a tensor sharing the data of t, whose first element is at
+// the given element offset, with the given shape, strides and names.
+func view(t Interface, start int64, shape, strides []int64, names []string) Interface {
+	bw := byteWidth(t)
+	n := int64(1)
+	last := start
+	for i, v := range shape {
+		n *= v
+		last += (v - 1) * strides[i] / bw
+	}
+	end := last + 1
+	if n == 0 {
+		end = start
+	}
+	data := array.NewSliceData(t.Data(), start, end)
+	defer data.Release()
+	return New(data, shape, strides, names)
+}
+
+// dimNames returns the names of the dimensions of t, or empty names if t
+// has none.
+func dimNames(t Interface) []string {
+	names := make([]string, t.NumDims())
+	copy(names, t.DimNames())
+	return names
+}
+
+// Slice returns a tensor holding the elements of t with indices in [i, j)
+// along the given dimension. The returned tensor shares the data of t, and
+// must be released after use.
+//
+// Slice panics if the dimension or the indices are out of range.
+func Slice(t Interface, dim int, i, j int64) Interface {
+	shape := append([]int64(nil), t.Shape()...)
+	if dim < 0 || dim >= len(shape) {
+		panic(fmt.Errorf("arrow/tensor: dimension %d out of range [0, %d)", dim, len(shape)))
+	}
+	if i < 0 || j < i || j > shape[dim] {
+		panic(fmt.Errorf("arrow/tensor: slice [%d:%d] out of range [0, %d]", i, j, shape[dim]))
+	}
+	shape[dim] = j - i
+	strides := append([]int64(nil), t.Strides()...)
+	return view(t, i*strides[dim]/byteWidth(t), shape, strides, dimNames(t))
+}
+
+// Subtensor returns the tensor of the elements of t whose leading indices
+// are index, with the remaining dimensions of t. The returned tensor shares
+// the data of t, and must be released after use.
+//
+// Subtensor panics if there are more indices than dimensions, or if an
+// index is out of range.
+func Subtensor(t Interface, index ...int64) Interface {
+	shape := t.Shape()
+	if len(index) > len(shape) {
+		panic(fmt.Errorf("arrow/tensor: %d indices for a tensor with %d dimensions", len(index), len(shape)))
+	}
+	var start int64
+	for i, v := range index {
+		if v < 0 || v >= shape[i] {
+			panic(fmt.Errorf("arrow/tensor: index %d out of range [0, %d) in dimension %d", v, shape[i], i))
+		}
+		start += v * t.Strides()[i]
+	}
+	k := len(index)
+	return view(t, start/byteWidth(t),
+		append([]int64(nil), shape[k:]...),
+		append([]int64(nil), t.Strides()[k:]...),
+		dimNames(t)[k:],
+	)
+}
+
+// Transpose returns the tensor whose i-th dimension is the dimension
+// axes[i] of t. If axes is empty, the dimensions are reversed.
+// The returned tensor shares the data of t, and must be released after use.
+//
+// Transpose panics if axes is not a permutation of the dimensions of t.
+func Transpose(t Interface, axes ...int) Interface {
+	ndims := t.NumDims()
+	if len(axes) == 0 {
+		axes = make([]int, ndims)
+		for i := range axes {
+			axes[i] = ndims - 1 - i
+		}
+	}
+	if len(axes) != ndims {
+		panic(fmt.Errorf("arrow/tensor: %d axes for a tensor with %d dimensions", len(axes), ndims))
+	}
+
+	var (
+		seen    = make([]bool, ndims)
+		shape   = make([]int64, ndims)
+		strides = make([]int64, ndims)
+		names   = make([]string, ndims)
+		src     = dimNames(t)
+	)
+	for i, ax := range axes {
+		if ax < 0 || ax >= ndims || seen[ax] {
+			panic(fmt.Errorf("arrow/tensor: invalid axes %v", axes))
+		}
+		seen[ax] = true
+		shape[i] = t.Shape()[ax]
+		strides[i] = t.Strides()[ax]
+		names[i] = src[ax]
+	}
+	return New(t.Data(), shape, strides, names)
+}
+
+// Reshape returns a tensor with the elements of t in row-major order, and
+// the given shape. The returned tensor shares the data of t, and must be
+// released after use.
+//
+// Reshape returns an error if t is not a row-major tensor, in which case
+// Contiguous may be used first, or if the number of elements of the shape
+// differs from that of t.
+func Reshape(t Interface, shape ...int64) (Interface, error) {
+	if !t.IsRowMajor() {
+		return nil, fmt.Errorf("arrow/tensor: reshape of a tensor that is not row-major")
+	}
+	n := int64(1)
+	for _, v := range shape {
+		n *= v
+	}
+	if n != int64(t.Len()) {
+		return nil, fmt.Errorf("arrow/tensor: cannot reshape a tensor of %d elements to %v", t.Len(), shape)
+	}
+	shape = append([]int64(nil), shape...)
+	return New(t.Data(), shape, nil, make([]string, len(shape))), nil
+}
+
+// Contiguous returns a row-major tensor with the elements of t.
+// If t is row-major, Contiguous returns t with an additional reference.
+// Otherwise, the elements are copied to a new tensor allocated from mem.
+// The returned tensor must be released after use.
+func Contiguous(mem memory.Allocator, t Interface) Interface {
+	if t.IsRowMajor() {
+		t.Retain()
+		return t
+	}
+
+	bw := byteWidth(t)
+	buf := memory.NewResizableBuffer(mem)
+	defer buf.Release()
+	buf.Resize(t.Len() * int(bw))
+
+	var src []byte
+	if vals := t.Data().Buffers()[1]; vals != nil {
+		src = vals.Bytes()[int64(t.Data().Offset())*bw:]
+	}
+	dst := buf.Bytes()
+	for i, j := range indices(t) {
+		copy(dst[int64(i)*bw:int64(i+1)*bw], src[int64(j)*bw:int64(j+1)*bw])
+	}
+
+	data := array.NewData(t.DataType(), t.Len(), []*memory.Buffer{nil, buf}, nil, 0, 0)
+	defer data.Release()
+	return New(data, append([]int64(nil), t.Shape()...), nil, dimNames(t))
+}
+
+// indices returns the offsets, in elements, of the elements of t in
+// row-major order.
+func indices(t Interface) []int {
+	var (
+		shape = t.Shape()
+		bw    = byteWidth(t)
+		out   = make([]int, 0, t.Len())
+		index = make([]int64, len(shape))
+	)
+	if t.Len() == 0 {
+		return out
+	}
+	for {
+		var off int64
+		for i, v := range index {
+			off += v * t.Strides()[i]
+		}
+		out = append(out, int(off/bw))
+
+		// next index, in row-major order.
+		i := len(index) - 1
+		for ; i >= 0; i-- {
+			index[i]++
+			if index[i] < shape[i] {
+				break
+			}
+			index[i] = 0
+		}
+		if i < 0 {
+			return out
+		}
+	}
+}
+
+type binaryOp int8
+
+const (
+	opAdd binaryOp = iota
+	opSub
+	opMul
+	opDiv
+)
+
+// Add returns the element-wise sum of a and b, allocated from mem.
+// The returned tensor must be released after use.
+//
+// Add returns an error if a and b do not have the same numeric data type
+// and the same shape.
+func Add(mem memory.Allocator, a, b Interface) (Interface, error) {
+	return elementwise(mem, opAdd, a, b)
+}
+
+// Sub returns the element-wise difference of a and b, allocated from mem.
+// The returned tensor must be released after use.
+//
+// Sub returns an error if a and b do not have the same numeric data type
+// and the same shape.
+func Sub(mem memory.Allocator, a, b Interface) (Interface, error) {
+	return elementwise(mem, opSub, a, b)
+}
+
+// Mul returns the element-wise product of a and b, allocated from mem.
+// The returned tensor must be released after use.
+//
+// Mul returns an error if a and b do not have the same numeric data type
+// and the same shape.
+func Mul(mem memory.Allocator, a, b Interface) (Interface, error) {
+	return elementwise(mem, opMul, a, b)
+}
+
+// Div returns the element-wise quotient of a and b, allocated from mem.
+// The returned tensor must be released after use.
+//
+// Div returns an error if a and b do not have the same numeric data type
+// and the same shape, or if an element of b is zero for integer tensors.
+func Div(mem memory.Allocator, a, b Interface) (Interface, error) {
+	return elementwise(mem, opDiv, a, b)
+}
+
+func checkElementwise(a, b Interface) error {
+	if a.DataType().ID() != b.DataType().ID() {
+		return fmt.Errorf("arrow/tensor: mismatched data types %s and %s", a.DataType().Name(), b.DataType().Name())
+	}
+	if !equalInt64s(a.Shape(), b.Shape()) {
+		return fmt.Errorf("arrow/tensor: mismatched shapes %v and %v", a.Shape(), b.Shape())
+	}
+	return nil
+}
+
+// MatMul returns the matrix product of the 2-dimensional tensors a and b,
+// allocated from mem. The returned tensor must be released after use.
+//
+// MatMul returns an error if a and b do not have the same numeric data type,
+// if they are not 2-dimensional, or if the number of columns of a differs
+// from the number of rows of b.
+func MatMul(mem memory.Allocator, a, b Interface) (Interface, error) {
+	if a.DataType().ID() != b.DataType().ID() {
+		return nil, fmt.Errorf("arrow/tensor: mismatched data types %s and %s", a.DataType().Name(), b.DataType().Name())
+	}
+	if a.NumDims() != 2 || b.NumDims() != 2 {
+		return nil, fmt.Errorf("arrow/tensor: matrix product of tensors with %d and %d dimensions", a.NumDims(), b.NumDims())
+	}
+	if a.Shape()[1] != b.Shape()[0] {
+		return nil, fmt.Errorf("arrow/tensor: mismatched shapes %v and %v for a matrix product", a.Shape(), b.Shape())
+	}
+	return matMul(mem, a, b)
+}
+
+// newResult returns the row-major tensor of type dtype holding the elements
+// of buf, which is released.
+func newResult(dtype arrow.DataType, buf *memory.Buffer, shape []int64, names []string) Interface {
+	defer buf.Release()
+	n := int64(1)
+	for _, v := range shape {
+		n *= v
+	}
+	data := array.NewData(dtype, int(n), []*memory.Buffer{nil, buf}, nil, 0, 0)
+	defer data.Release()
+	return New(data, shape, nil, names)
+}
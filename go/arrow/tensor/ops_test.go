@@ -0,0 +1,343 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensor_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/tensor"
+)
+
+func newInt32(mem memory.Allocator, vals []int32, shape []int64, names []string) tensor.Interface {
+	bld := array.NewInt32Builder(mem)
+	defer bld.Release()
+	bld.AppendValues(vals, nil)
+	arr := bld.NewInt32Array()
+	defer arr.Release()
+	return tensor.New(arr.Data(), shape, nil, names)
+}
+
+func newFloat64(mem memory.Allocator, vals []float64, shape []int64, names []string) tensor.Interface {
+	bld := array.NewFloat64Builder(mem)
+	defer bld.Release()
+	bld.AppendValues(vals, nil)
+	arr := bld.NewFloat64Array()
+	defer arr.Release()
+	return tensor.New(arr.Data(), shape, nil, names)
+}
+
+// elements returns the elements of t in row-major order, read with Value.
+func elements(t tensor.Interface) []float64 {
+	out := []float64{}
+	index := make([]int64, t.NumDims())
+	if t.Len() == 0 {
+		return out
+	}
+	for {
+		switch t := t.(type) {
+		case *tensor.Int32:
+			out = append(out, float64(t.Value(index)))
+		case *tensor.Float64:
+			out = append(out, t.Value(index))
+		}
+		i := len(index) - 1
+		for ; i >= 0; i-- {
+			index[i]++
+			if index[i] < t.Shape()[i] {
+				break
+			}
+			index[i] = 0
+		}
+		if i < 0 {
+			return out
+		}
+	}
+}
+
+func TestSliceTransposeReshape(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	// [[[0 1 2 3] [4 5 6 7] [8 9 10 11]] [[12 ...] ...]]
+	vals := make([]int32, 24)
+	for i := range vals {
+		vals[i] = int32(i)
+	}
+	tsr := newInt32(mem, vals, []int64{2, 3, 4}, []string{"x", "y", "z"})
+	defer tsr.Release()
+
+	for _, tc := range []struct {
+		name  string
+		op    func() tensor.Interface
+		shape []int64
+		names []string
+		elems []float64
+		row   bool
+	}{
+		{
+			name:  "slice",
+			op:    func() tensor.Interface { return tensor.Slice(tsr, 1, 1, 3) },
+			shape: []int64{2, 2, 4},
+			names: []string{"x", "y", "z"},
+			elems: []float64{4, 5, 6, 7, 8, 9, 10, 11, 16, 17, 18, 19, 20, 21, 22, 23},
+		},
+		{
+			name:  "slice-last",
+			op:    func() tensor.Interface { return tensor.Slice(tsr, 2, 3, 4) },
+			shape: []int64{2, 3, 1},
+			names: []string{"x", "y", "z"},
+			elems: []float64{3, 7, 11, 15, 19, 23},
+		},
+		{
+			name:  "slice-empty",
+			op:    func() tensor.Interface { return tensor.Slice(tsr, 0, 1, 1) },
+			shape: []int64{0, 3, 4},
+			names: []string{"x", "y", "z"},
+			elems: []float64{},
+		},
+		{
+			name:  "subtensor",
+			op:    func() tensor.Interface { return tensor.Subtensor(tsr, 1) },
+			shape: []int64{3, 4},
+			names: []string{"y", "z"},
+			elems: []float64{12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23},
+			row:   true,
+		},
+		{
+			name:  "subtensor-row",
+			op:    func() tensor.Interface { return tensor.Subtensor(tsr, 1, 2) },
+			shape: []int64{4},
+			names: []string{"z"},
+			elems: []float64{20, 21, 22, 23},
+			row:   true,
+		},
+		{
+			name:  "subtensor-scalar",
+			op:    func() tensor.Interface { return tensor.Subtensor(tsr, 0, 2, 1) },
+			shape: nil,
+			names: []string{},
+			elems: []float64{9},
+			row:   true,
+		},
+		{
+			name:  "transpose",
+			op:    func() tensor.Interface { return tensor.Transpose(tsr) },
+			shape: []int64{4, 3, 2},
+			names: []string{"z", "y", "x"},
+			elems: []float64{0, 12, 4, 16, 8, 20, 1, 13, 5, 17, 9, 21, 2, 14, 6, 18, 10, 22, 3, 15, 7, 19, 11, 23},
+		},
+		{
+			name:  "transpose-axes",
+			op:    func() tensor.Interface { return tensor.Transpose(tsr, 0, 2, 1) },
+			shape: []int64{2, 4, 3},
+			names: []string{"x", "z", "y"},
+			elems: []float64{0, 4, 8, 1, 5, 9, 2, 6, 10, 3, 7, 11, 12, 16, 20, 13, 17, 21, 14, 18, 22, 15, 19, 23},
+		},
+		{
+			name: "reshape",
+			op: func() tensor.Interface {
+				r, err := tensor.Reshape(tsr, 6, 4)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return r
+			},
+			shape: []int64{6, 4},
+			names: []string{"", ""},
+			elems: []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23},
+			row:   true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.op()
+			defer got.Release()
+
+			if !reflect.DeepEqual(got.Shape(), tc.shape) {
+				t.Fatalf("invalid shape: got=%v, want=%v", got.Shape(), tc.shape)
+			}
+			if !reflect.DeepEqual(got.DimNames(), tc.names) {
+				t.Fatalf("invalid names: got=%q, want=%q", got.DimNames(), tc.names)
+			}
+			if got, want := got.IsRowMajor(), tc.row; got != want {
+				t.Fatalf("invalid row-major: got=%v, want=%v", got, want)
+			}
+			if got := elements(got); !reflect.DeepEqual(got, tc.elems) {
+				t.Fatalf("invalid elements:\ngot= %v\nwant=%v", got, tc.elems)
+			}
+
+			// a contiguous copy holds the same elements.
+			c := tensor.Contiguous(mem, got)
+			defer c.Release()
+			if !c.IsRowMajor() {
+				t.Fatalf("contiguous tensor should be row-major")
+			}
+			if got := elements(c); !reflect.DeepEqual(got, tc.elems) {
+				t.Fatalf("invalid contiguous elements:\ngot= %v\nwant=%v", got, tc.elems)
+			}
+			if got := c.(*tensor.Int32).Int32Values(); len(got) != len(tc.elems) {
+				t.Fatalf("invalid contiguous values: %v", got)
+			}
+		})
+	}
+
+	tr := tensor.Transpose(tsr)
+	defer tr.Release()
+	if _, err := tensor.Reshape(tr, 24); err == nil || err.Error() != "arrow/tensor: reshape of a tensor that is not row-major" {
+		t.Fatalf("invalid error: %v", err)
+	}
+	if _, err := tensor.Reshape(tsr, 5, 5); err == nil || err.Error() != "arrow/tensor: cannot reshape a tensor of 24 elements to [5 5]" {
+		t.Fatalf("invalid error: %v", err)
+	}
+
+	for _, f := range []func(){
+		func() { tensor.Slice(tsr, 3, 0, 1) },
+		func() { tensor.Slice(tsr, 0, 1, 3) },
+		func() { tensor.Subtensor(tsr, 0, 3) },
+		func() { tensor.Subtensor(tsr, 0, 0, 0, 0) },
+		func() { tensor.Transpose(tsr, 0, 0, 1) },
+		func() { tensor.Transpose(tsr, 0, 1) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected a panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestElementwise(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	a := newFloat64(mem, []float64{1, 2, 3, 4, 5, 6}, []int64{2, 3}, []string{"x", "y"})
+	defer a.Release()
+	b := newFloat64(mem, []float64{6, 5, 4, 3, 2, 1}, []int64{3, 2}, nil)
+	defer b.Release()
+	bt := tensor.Transpose(b) // [[6 4 2] [5 3 1]]
+	defer bt.Release()
+
+	for _, tc := range []struct {
+		name string
+		op   func(memory.Allocator, tensor.Interface, tensor.Interface) (tensor.Interface, error)
+		want []float64
+	}{
+		{"add", tensor.Add, []float64{7, 6, 5, 9, 8, 7}},
+		{"sub", tensor.Sub, []float64{-5, -2, 1, -1, 2, 5}},
+		{"mul", tensor.Mul, []float64{6, 8, 6, 20, 15, 6}},
+		{"div", tensor.Div, []float64{1. / 6, 0.5, 1.5, 0.8, 5. / 3, 6}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.op(mem, a, bt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer got.Release()
+			if !reflect.DeepEqual(got.Shape(), []int64{2, 3}) || !reflect.DeepEqual(got.DimNames(), []string{"x", "y"}) {
+				t.Fatalf("invalid shape or names: %v, %q", got.Shape(), got.DimNames())
+			}
+			if got := got.(*tensor.Float64).Float64Values(); !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("invalid values:\ngot= %v\nwant=%v", got, tc.want)
+			}
+		})
+	}
+
+	i1 := newInt32(mem, []int32{7, 8}, []int64{2}, nil)
+	defer i1.Release()
+	i2 := newInt32(mem, []int32{2, 0}, []int64{2}, nil)
+	defer i2.Release()
+	i3 := newInt32(mem, []int32{2, 4}, []int64{2}, nil)
+	defer i3.Release()
+
+	got, err := tensor.Div(mem, i1, i3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+	if got := got.(*tensor.Int32).Int32Values(); !reflect.DeepEqual(got, []int32{3, 2}) {
+		t.Fatalf("invalid values: %v", got)
+	}
+
+	for _, tc := range []struct {
+		name string
+		a, b tensor.Interface
+		err  string
+	}{
+		{"zero", i1, i2, "arrow/tensor: integer division by zero"},
+		{"types", a, i1, "arrow/tensor: mismatched data types float64 and int32"},
+		{"shapes", a, b, "arrow/tensor: mismatched shapes [2 3] and [3 2]"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tensor.Div(mem, tc.a, tc.b)
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("invalid error: got=%v, want=%q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestMatMul(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	a := newInt32(mem, []int32{1, 2, 3, 4, 5, 6}, []int64{2, 3}, []string{"row", "k"})
+	defer a.Release()
+	b := newInt32(mem, []int32{1, 0, 2, -1, 0, 3, 1, 1, 1, 2, 0, 1}, []int64{4, 3}, []string{"col", "k"})
+	defer b.Release()
+	bt := tensor.Transpose(b) // 3x4
+	defer bt.Release()
+
+	got, err := tensor.MatMul(mem, a, bt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer got.Release()
+
+	if got, want := got.Shape(), []int64{2, 4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+	}
+	if got, want := got.DimNames(), []string{"row", "col"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid names: got=%q, want=%q", got, want)
+	}
+	if got, want := got.(*tensor.Int32).Int32Values(), []int32{7, 8, 6, 5, 16, 14, 15, 14}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid values: got=%v, want=%v", got, want)
+	}
+
+	c := newFloat64(mem, []float64{1, 2}, []int64{2}, nil)
+	defer c.Release()
+	for _, tc := range []struct {
+		name string
+		a, b tensor.Interface
+		err  string
+	}{
+		{"types", a, c, "arrow/tensor: mismatched data types int32 and float64"},
+		{"dims", a, a.(*tensor.Int32), "arrow/tensor: mismatched shapes [2 3] and [2 3] for a matrix product"},
+		{"1d", c, c, "arrow/tensor: matrix product of tensors with 1 and 1 dimensions"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tensor.MatMul(mem, tc.a, tc.b)
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("invalid error: got=%v, want=%q", err, tc.err)
+			}
+		})
+	}
+}
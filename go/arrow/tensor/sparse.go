@@ -0,0 +1,416 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensor
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/internal/debug"
+	"github.com/apache/arrow/go/arrow/memory"
+)
+
+// SparseIndexFormat describes how the indices of the non-zero elements of
+// a sparse tensor are stored.
+type SparseIndexFormat int8
+
+const (
+	// SparseCOOIndex stores the coordinates of each non-zero element.
+	SparseCOOIndex SparseIndexFormat = iota
+	// SparseCSRIndex stores a compressed sparse row index of a matrix.
+	SparseCSRIndex
+)
+
+func (f SparseIndexFormat) String() string {
+	switch f {
+	case SparseCOOIndex:
+		return "COO"
+	case SparseCSRIndex:
+		return "CSR"
+	}
+	return fmt.Sprintf("SparseIndexFormat(%d)", int8(f))
+}
+
+// Sparse represents an n-dimensional array of numerical data, of which only
+// the non-zero elements are stored.
+type Sparse interface {
+	// Retain increases the reference count by 1.
+	// Retain may be called simultaneously from multiple goroutines.
+	Retain()
+
+	// Release decreases the reference count by 1.
+	// Release may be called simultaneously from multiple goroutines.
+	// When the reference count goes to zero, the memory is freed.
+	Release()
+
+	// Len returns the number of elements, zero or not, in the tensor.
+	Len() int
+
+	// NonZeroLen returns the number of stored elements in the tensor.
+	NonZeroLen() int
+
+	// Shape returns the size - in each dimension - of the tensor.
+	Shape() []int64
+
+	// NumDims returns the number of dimensions of the tensor.
+	NumDims() int
+
+	// DimName returns the name of the i-th dimension.
+	DimName(i int) string
+
+	// DimNames returns the names for all dimensions
+	DimNames() []string
+
+	DataType() arrow.DataType
+
+	// Format returns the format of the sparse index.
+	Format() SparseIndexFormat
+
+	// Values returns the 1-dim tensor of the stored elements.
+	Values() Interface
+
+	// ToDense returns the row-major dense tensor of the elements, allocated
+	// from mem. The returned tensor must be released after use.
+	ToDense(mem memory.Allocator) Interface
+}
+
+type sparseBase struct {
+	refCount int64
+	values   Interface
+	shape    []int64
+	names    []string
+}
+
+func newSparse(values Interface, shape []int64, names []string) sparseBase {
+	if values.NumDims() != 1 {
+		panic(fmt.Errorf("arrow/tensor: sparse values with %d dimensions", values.NumDims()))
+	}
+	if names == nil {
+		names = make([]string, len(shape))
+	}
+	if len(names) != len(shape) {
+		panic(fmt.Errorf("arrow/tensor: %d names for a tensor with %d dimensions", len(names), len(shape)))
+	}
+	values.Retain()
+	return sparseBase{refCount: 1, values: values, shape: shape, names: names}
+}
+
+// Retain increases the reference count by 1.
+// Retain may be called simultaneously from multiple goroutines.
+func (sb *sparseBase) Retain() {
+	atomic.AddInt64(&sb.refCount, 1)
+}
+
+func (sb *sparseBase) release() bool {
+	debug.Assert(atomic.LoadInt64(&sb.refCount) > 0, "too many releases")
+
+	if atomic.AddInt64(&sb.refCount, -1) == 0 {
+		sb.values.Release()
+		sb.values = nil
+		return true
+	}
+	return false
+}
+
+func (sb *sparseBase) Len() int {
+	o := int64(1)
+	for _, v := range sb.shape {
+		o *= v
+	}
+	return int(o)
+}
+
+func (sb *sparseBase) NonZeroLen() int          { return sb.values.Len() }
+func (sb *sparseBase) Shape() []int64           { return sb.shape }
+func (sb *sparseBase) NumDims() int             { return len(sb.shape) }
+func (sb *sparseBase) DimName(i int) string     { return sb.names[i] }
+func (sb *sparseBase) DimNames() []string       { return sb.names }
+func (sb *sparseBase) DataType() arrow.DataType { return sb.values.DataType() }
+func (sb *sparseBase) Values() Interface        { return sb.values }
+
+// SparseCOOTensor is a sparse tensor storing the coordinates of its
+// non-zero elements.
+type SparseCOOTensor struct {
+	sparseBase
+	indices *Int64
+}
+
+// NewSparseCOOTensor returns a new sparse tensor with the given shape, whose
+// non-zero elements are values, at the coordinates given by the rows of the
+// (nnz, ndim) indices tensor.
+// If names is nil, a slice of empty strings will be created.
+//
+// NewSparseCOOTensor panics if values is not a 1-dim tensor, or if the
+// shape of indices does not match those of values and of the tensor.
+func NewSparseCOOTensor(indices *Int64, values Interface, shape []int64, names []string) *SparseCOOTensor {
+	is := indices.Shape()
+	if len(is) != 2 || is[0] != int64(values.Len()) || is[1] != int64(len(shape)) {
+		panic(fmt.Errorf("arrow/tensor: COO indices of shape %v for %d values of a tensor with %d dimensions", is, values.Len(), len(shape)))
+	}
+	t := &SparseCOOTensor{sparseBase: newSparse(values, shape, names), indices: indices}
+	indices.Retain()
+	return t
+}
+
+// Release decreases the reference count by 1.
+// Release may be called simultaneously from multiple goroutines.
+// When the reference count goes to zero, the memory is freed.
+func (t *SparseCOOTensor) Release() {
+	if t.release() {
+		t.indices.Release()
+		t.indices = nil
+	}
+}
+
+func (t *SparseCOOTensor) Format() SparseIndexFormat { return SparseCOOIndex }
+
+// Indices returns the (nnz, ndim) tensor of the coordinates of the stored
+// elements.
+func (t *SparseCOOTensor) Indices() *Int64 { return t.indices }
+
+// ToDense returns the row-major dense tensor of the elements, allocated
+// from mem. The returned tensor must be released after use.
+func (t *SparseCOOTensor) ToDense(mem memory.Allocator) Interface {
+	return scatter(mem, t, func(k int) int64 {
+		var off int64
+		for i := range t.shape {
+			off = off*t.shape[i] + t.indices.Value([]int64{int64(k), int64(i)})
+		}
+		return off
+	})
+}
+
+// NewSparseCOOFromDense returns the sparse tensor of the non-zero elements
+// of t, in row-major order. Indices and values are allocated from mem.
+// An element is zero if all its bytes are zero.
+// The returned tensor must be released after use.
+func NewSparseCOOFromDense(mem memory.Allocator, t Interface) *SparseCOOTensor {
+	var (
+		shape = t.Shape()
+		ndim  = int64(len(shape))
+		coord []int64
+	)
+	values := gather(mem, t, func(i int) {
+		for d := ndim - 1; d >= 0; d-- {
+			coord = append(coord, int64(i)%shape[d])
+			i /= int(shape[d])
+		}
+		n := int64(len(coord))
+		reverse(coord[n-ndim:])
+	})
+	defer values.Release()
+
+	indices := newInt64s(mem, coord, []int64{int64(values.Len()), ndim})
+	defer indices.Release()
+	return NewSparseCOOTensor(indices, values, append([]int64(nil), shape...), dimNames(t))
+}
+
+// SparseCSRTensor is a sparse matrix stored in compressed sparse row format:
+// the values of the i-th row are the stored elements in [indptr[i],
+// indptr[i+1]), at the columns given by the same range of indices.
+type SparseCSRTensor struct {
+	sparseBase
+	indptr  *Int64
+	indices *Int64
+}
+
+// NewSparseCSRTensor returns a new sparse matrix with the given shape, from
+// its 1-dim row pointers, column indices and values.
+// If names is nil, a slice of empty strings will be created.
+//
+// NewSparseCSRTensor panics if shape is not that of a matrix, or if the
+// lengths of indptr, indices and values are inconsistent.
+func NewSparseCSRTensor(indptr, indices *Int64, values Interface, shape []int64, names []string) *SparseCSRTensor {
+	if len(shape) != 2 {
+		panic(fmt.Errorf("arrow/tensor: CSR index for a tensor with %d dimensions", len(shape)))
+	}
+	if indptr.NumDims() != 1 || int64(indptr.Len()) != shape[0]+1 {
+		panic(fmt.Errorf("arrow/tensor: CSR indptr of shape %v for %d rows", indptr.Shape(), shape[0]))
+	}
+	if indices.NumDims() != 1 || indices.Len() != values.Len() {
+		panic(fmt.Errorf("arrow/tensor: CSR indices of shape %v for %d values", indices.Shape(), values.Len()))
+	}
+	t := &SparseCSRTensor{sparseBase: newSparse(values, shape, names), indptr: indptr, indices: indices}
+	indptr.Retain()
+	indices.Retain()
+	return t
+}
+
+// Release decreases the reference count by 1.
+// Release may be called simultaneously from multiple goroutines.
+// When the reference count goes to zero, the memory is freed.
+func (t *SparseCSRTensor) Release() {
+	if t.release() {
+		t.indptr.Release()
+		t.indices.Release()
+		t.indptr, t.indices = nil, nil
+	}
+}
+
+func (t *SparseCSRTensor) Format() SparseIndexFormat { return SparseCSRIndex }
+
+// Indptr returns the 1-dim tensor of the row pointers.
+func (t *SparseCSRTensor) Indptr() *Int64 { return t.indptr }
+
+// Indices returns the 1-dim tensor of the column indices of the stored
+// elements.
+func (t *SparseCSRTensor) Indices() *Int64 { return t.indices }
+
+// ToDense returns the row-major dense matrix of the elements, allocated
+// from mem. The returned tensor must be released after use.
+func (t *SparseCSRTensor) ToDense(mem memory.Allocator) Interface {
+	var (
+		indptr  = t.indptr.Int64Values()
+		indices = t.indices.Int64Values()
+		row     int64
+	)
+	return scatter(mem, t, func(k int) int64 {
+		for int64(k) >= indptr[row+1] {
+			row++
+		}
+		return row*t.shape[1] + indices[k]
+	})
+}
+
+// NewSparseCSRFromDense returns the sparse matrix of the non-zero elements
+// of t. Indices and values are allocated from mem.
+// An element is zero if all its bytes are zero.
+// The returned tensor must be released after use.
+//
+// NewSparseCSRFromDense returns an error if t is not a matrix.
+func NewSparseCSRFromDense(mem memory.Allocator, t Interface) (*SparseCSRTensor, error) {
+	shape := t.Shape()
+	if len(shape) != 2 {
+		return nil, fmt.Errorf("arrow/tensor: CSR index for a tensor with %d dimensions", len(shape))
+	}
+
+	var (
+		indptr  = make([]int64, 1, shape[0]+1)
+		indices []int64
+		row     int64
+	)
+	values := gather(mem, t, func(i int) {
+		r := int64(i) / shape[1]
+		for ; row < r; row++ {
+			indptr = append(indptr, int64(len(indices)))
+		}
+		indices = append(indices, int64(i)%shape[1])
+	})
+	defer values.Release()
+	for ; row < shape[0]; row++ {
+		indptr = append(indptr, int64(len(indices)))
+	}
+
+	ptr := newInt64s(mem, indptr, []int64{int64(len(indptr))})
+	defer ptr.Release()
+	idx := newInt64s(mem, indices, []int64{int64(len(indices))})
+	defer idx.Release()
+	return NewSparseCSRTensor(ptr, idx, values, append([]int64(nil), shape...), dimNames(t)), nil
+}
+
+// gather returns the 1-dim tensor of the non-zero elements of t, in
+// row-major order, calling found with the row-major position of each.
+func gather(mem memory.Allocator, t Interface, found func(i int)) Interface {
+	var (
+		bw  = byteWidth(t)
+		src []byte
+		buf = memory.NewResizableBuffer(mem)
+		nnz int
+	)
+	defer buf.Release()
+	if vals := t.Data().Buffers()[1]; vals != nil {
+		src = vals.Bytes()[int64(t.Data().Offset())*bw:]
+	}
+
+	for i, j := range indices(t) {
+		v := src[int64(j)*bw : int64(j+1)*bw]
+		if isZero(v) {
+			continue
+		}
+		if (nnz+1)*int(bw) > buf.Cap() {
+			buf.Reserve(2 * (nnz + 1) * int(bw))
+		}
+		buf.ResizeNoShrink((nnz + 1) * int(bw))
+		copy(buf.Bytes()[nnz*int(bw):], v)
+		nnz++
+		found(i)
+	}
+
+	data := array.NewData(t.DataType(), nnz, []*memory.Buffer{nil, buf}, nil, 0, 0)
+	defer data.Release()
+	return New(data, []int64{int64(nnz)}, nil, nil)
+}
+
+// scatter returns the row-major dense tensor of the elements of t, with
+// the k-th stored element at the row-major position pos(k).
+func scatter(mem memory.Allocator, t Sparse, pos func(k int) int64) Interface {
+	values := t.Values()
+	bw := byteWidth(values)
+	buf := memory.NewResizableBuffer(mem)
+	defer buf.Release()
+	buf.Resize(t.Len() * int(bw))
+	memory.Set(buf.Bytes(), 0)
+
+	var src []byte
+	if vals := values.Data().Buffers()[1]; vals != nil {
+		src = vals.Bytes()[int64(values.Data().Offset())*bw:]
+	}
+	dst := buf.Bytes()
+	for k, j := range indices(values) {
+		i := pos(k)
+		copy(dst[i*bw:(i+1)*bw], src[int64(j)*bw:int64(j+1)*bw])
+	}
+
+	data := array.NewData(t.DataType(), t.Len(), []*memory.Buffer{nil, buf}, nil, 0, 0)
+	defer data.Release()
+	return New(data, append([]int64(nil), t.Shape()...), nil, append([]string(nil), t.DimNames()...))
+}
+
+// newInt64s returns a row-major tensor of the given values and shape,
+// allocated from mem.
+func newInt64s(mem memory.Allocator, vs []int64, shape []int64) *Int64 {
+	buf := memory.NewResizableBuffer(mem)
+	defer buf.Release()
+	buf.Resize(arrow.Int64Traits.BytesRequired(len(vs)))
+	copy(arrow.Int64Traits.CastFromBytes(buf.Bytes()), vs)
+
+	data := array.NewData(arrow.PrimitiveTypes.Int64, len(vs), []*memory.Buffer{nil, buf}, nil, 0, 0)
+	defer data.Release()
+	return NewInt64(data, shape, nil, nil)
+}
+
+func isZero(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func reverse(vs []int64) {
+	for i, j := 0, len(vs)-1; i < j; i, j = i+1, j-1 {
+		vs[i], vs[j] = vs[j], vs[i]
+	}
+}
+
+var (
+	_ Sparse = (*SparseCOOTensor)(nil)
+	_ Sparse = (*SparseCSRTensor)(nil)
+)
@@ -0,0 +1,182 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tensor_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/tensor"
+)
+
+func TestSparseCOO(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	vals := []float64{
+		0, 1, 0,
+		2, 0, 0,
+
+		0, 0, 0,
+		0, 0, 3,
+	}
+	dense := newFloat64(mem, vals, []int64{2, 2, 3}, []string{"a", "b", "c"})
+	defer dense.Release()
+
+	coo := tensor.NewSparseCOOFromDense(mem, dense)
+	defer coo.Release()
+
+	if got, want := coo.Format(), tensor.SparseCOOIndex; got != want {
+		t.Fatalf("invalid format: got=%v, want=%v", got, want)
+	}
+	if got, want := coo.NonZeroLen(), 3; got != want {
+		t.Fatalf("invalid non-zero length: got=%d, want=%d", got, want)
+	}
+	if got, want := coo.Len(), 12; got != want {
+		t.Fatalf("invalid length: got=%d, want=%d", got, want)
+	}
+	if got, want := coo.DimNames(), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid names: got=%v, want=%v", got, want)
+	}
+	if got, want := coo.Indices().Shape(), []int64{3, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid indices shape: got=%v, want=%v", got, want)
+	}
+	if got, want := coo.Indices().Int64Values(), []int64{0, 0, 1, 0, 1, 0, 1, 1, 2}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid indices: got=%v, want=%v", got, want)
+	}
+	if got, want := elements(coo.Values()), []float64{1, 2, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid values: got=%v, want=%v", got, want)
+	}
+
+	back := coo.ToDense(mem)
+	defer back.Release()
+	if !back.IsRowMajor() {
+		t.Fatalf("dense tensor is not row-major")
+	}
+	if got, want := back.Shape(), dense.Shape(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid shape: got=%v, want=%v", got, want)
+	}
+	if got, want := elements(back), vals; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid dense values: got=%v, want=%v", got, want)
+	}
+}
+
+func TestSparseCOOFromView(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	dense := newInt32(mem, []int32{0, 1, 2, 0, 0, 3}, []int64{2, 3}, nil)
+	defer dense.Release()
+	tr := tensor.Transpose(dense)
+	defer tr.Release()
+
+	coo := tensor.NewSparseCOOFromDense(mem, tr)
+	defer coo.Release()
+
+	if got, want := coo.Indices().Int64Values(), []int64{1, 0, 2, 0, 2, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid indices: got=%v, want=%v", got, want)
+	}
+	back := coo.ToDense(mem)
+	defer back.Release()
+	if got, want := elements(back), elements(tr); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid dense values: got=%v, want=%v", got, want)
+	}
+}
+
+func TestSparseCSR(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	vals := []int32{
+		1, 0, 2, 0,
+		0, 0, 0, 0,
+		0, 3, 0, 4,
+	}
+	dense := newInt32(mem, vals, []int64{3, 4}, []string{"row", "col"})
+	defer dense.Release()
+
+	csr, err := tensor.NewSparseCSRFromDense(mem, dense)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer csr.Release()
+
+	if got, want := csr.Format(), tensor.SparseCSRIndex; got != want {
+		t.Fatalf("invalid format: got=%v, want=%v", got, want)
+	}
+	if got, want := csr.Indptr().Int64Values(), []int64{0, 2, 2, 4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid indptr: got=%v, want=%v", got, want)
+	}
+	if got, want := csr.Indices().Int64Values(), []int64{0, 2, 1, 3}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid indices: got=%v, want=%v", got, want)
+	}
+	if got, want := elements(csr.Values()), []float64{1, 2, 3, 4}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid values: got=%v, want=%v", got, want)
+	}
+
+	back := csr.ToDense(mem)
+	defer back.Release()
+	if got, want := back.DimNames(), []string{"row", "col"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid names: got=%v, want=%v", got, want)
+	}
+	if got, want := elements(back), elements(dense); !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid dense values: got=%v, want=%v", got, want)
+	}
+}
+
+func TestSparseEmpty(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	dense := newFloat64(mem, make([]float64, 6), []int64{2, 3}, nil)
+	defer dense.Release()
+
+	coo := tensor.NewSparseCOOFromDense(mem, dense)
+	defer coo.Release()
+	csr, err := tensor.NewSparseCSRFromDense(mem, dense)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer csr.Release()
+
+	for _, sp := range []tensor.Sparse{coo, csr} {
+		if got := sp.NonZeroLen(); got != 0 {
+			t.Fatalf("%v: invalid non-zero length: got=%d, want=0", sp.Format(), got)
+		}
+		back := sp.ToDense(mem)
+		if got, want := elements(back), make([]float64, 6); !reflect.DeepEqual(got, want) {
+			t.Fatalf("%v: invalid dense values: got=%v, want=%v", sp.Format(), got, want)
+		}
+		back.Release()
+	}
+	if got, want := csr.Indptr().Int64Values(), []int64{0, 0, 0}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid indptr: got=%v, want=%v", got, want)
+	}
+}
+
+func TestSparseCSRInvalid(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	dense := newFloat64(mem, []float64{1, 2, 3}, []int64{3}, nil)
+	defer dense.Release()
+
+	if _, err := tensor.NewSparseCSRFromDense(mem, dense); err == nil {
+		t.Fatalf("expected an error")
+	}
+}
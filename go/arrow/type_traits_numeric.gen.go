@@ -38,6 +38,7 @@ var (
 	TimestampTraits timestampTraits
 	Time32Traits    time32Traits
 	Time64Traits    time64Traits
+	DurationTraits  durationTraits
 	Date32Traits    date32Traits
 	Date64Traits    date64Traits
 )
@@ -666,6 +667,54 @@ func (time64Traits) CastToBytes(b []Time64) []byte {
 // Copy copies src to dst.
 func (time64Traits) Copy(dst, src []Time64) { copy(dst, src) }
 
+// Duration traits
+
+const (
+	// DurationSizeBytes specifies the number of bytes required to store a single Duration in memory
+	DurationSizeBytes = int(unsafe.Sizeof(Duration(0)))
+)
+
+type durationTraits struct{}
+
+// BytesRequired returns the number of bytes required to store n elements in memory.
+func (durationTraits) BytesRequired(n int) int { return DurationSizeBytes * n }
+
+// PutValue
+func (durationTraits) PutValue(b []byte, v Duration) {
+	binary.LittleEndian.PutUint64(b, uint64(v))
+}
+
+// CastFromBytes reinterprets the slice b to a slice of type Duration.
+//
+// NOTE: len(b) must be a multiple of DurationSizeBytes.
+func (durationTraits) CastFromBytes(b []byte) []Duration {
+	h := (*reflect.SliceHeader)(unsafe.Pointer(&b))
+
+	var res []Duration
+	s := (*reflect.SliceHeader)(unsafe.Pointer(&res))
+	s.Data = h.Data
+	s.Len = h.Len / DurationSizeBytes
+	s.Cap = h.Cap / DurationSizeBytes
+
+	return res
+}
+
+// CastToBytes reinterprets the slice b to a slice of bytes.
+func (durationTraits) CastToBytes(b []Duration) []byte {
+	h := (*reflect.SliceHeader)(unsafe.Pointer(&b))
+
+	var res []byte
+	s := (*reflect.SliceHeader)(unsafe.Pointer(&res))
+	s.Data = h.Data
+	s.Len = h.Len * DurationSizeBytes
+	s.Cap = h.Cap * DurationSizeBytes
+
+	return res
+}
+
+// Copy copies src to dst.
+func (durationTraits) Copy(dst, src []Duration) { copy(dst, src) }
+
 // Date32 traits
 
 const (
@@ -0,0 +1,65 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package xlsx writes records as the sheets of Office Open XML spreadsheet
+// (.xlsx) files, with typed cells: numbers, booleans, dates and times are
+// written as such rather than as text.
+//
+// Spreadsheet numbers are doubles: integers beyond 2^53 and decimals of more
+// than 15 digits, which they can not hold exactly, are written as text.
+package xlsx
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrMismatchFields = errors.New("arrow/xlsx: schema mismatch")
+	ErrNoSheet        = errors.New("arrow/xlsx: no sheet to write to")
+	ErrClosed         = errors.New("arrow/xlsx: writer is closed")
+)
+
+const (
+	maxRows      = 1 << 20 // rows in a sheet
+	maxCols      = 1 << 14 // columns in a sheet
+	maxSheetName = 31      // runes in a sheet name
+	maxText      = 32767   // characters in a cell
+
+	// maxExact is the largest integer the numbers of spreadsheets, which
+	// are doubles, hold exactly, and maxExactDigits their number of exact
+	// decimal digits. Values beyond them are written as text.
+	maxExact       = 1 << 53
+	maxExactDigits = 15
+)
+
+// Option configures an XLSX writer.
+type Option func(config)
+type config interface{}
+
+// WithHeader specifies whether the sheets start with a header row, holding
+// the names of the fields.
+// The default value is true.
+func WithHeader(header bool) Option {
+	return func(cfg config) {
+		switch cfg := cfg.(type) {
+		case *Writer:
+			cfg.header = header
+		default:
+			panic(fmt.Errorf("arrow/xlsx: unknown config type %T", cfg))
+		}
+	}
+}
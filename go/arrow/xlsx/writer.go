@@ -0,0 +1,520 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xlsx
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+)
+
+// Styles of the cells, indices of the cellXfs of styles.xml.
+const (
+	styleDefault = iota
+	styleDate
+	styleDateTime
+	styleTime
+	styleDuration
+)
+
+// Serial numbers of dates count days since 1899-12-30, in the 1900 date
+// system: serialEpoch is the serial number of 1970-01-01.
+const (
+	serialEpoch = 25569
+	secsPerDay  = 24 * 60 * 60
+)
+
+// cellFunc appends the XML of a cell, at reference ref, holding the i-th
+// value of arr. It returns an error if the value can not be written.
+type cellFunc func(buf []byte, ref string, arr array.Interface, i int) ([]byte, error)
+
+// Writer writes records to an XLSX file, one sheet after the other.
+// The file is complete once the writer is closed.
+type Writer struct {
+	zw     *zip.Writer
+	header bool
+	sheets []string
+	closed bool
+
+	// current sheet
+	out    *bufio.Writer
+	schema *arrow.Schema
+	cells  []cellFunc
+	refs   []string // column letters
+	rows   int
+	buf    []byte
+}
+
+// NewWriter returns a writer of an XLSX file to w.
+func NewWriter(w io.Writer, opts ...Option) *Writer {
+	ww := &Writer{zw: zip.NewWriter(w), header: true}
+	for _, opt := range opts {
+		opt(ww)
+	}
+	return ww
+}
+
+// Schema returns the schema of the current sheet, or nil if no sheet has
+// been started.
+func (w *Writer) Schema() *arrow.Schema { return w.schema }
+
+// NewSheet ends the current sheet, if any, and starts a new sheet called
+// name, holding records with the given schema.
+//
+// Sheet names must be unique, ignoring case, hold at most 31 characters
+// and none of []:*?/\. Sheets hold at most 16384 columns and 1048576 rows,
+// and their cells at most 32767 characters of text.
+func (w *Writer) NewSheet(name string, schema *arrow.Schema) error {
+	if w.closed {
+		return ErrClosed
+	}
+	if err := w.checkSheetName(name); err != nil {
+		return err
+	}
+	if len(schema.Fields()) > maxCols {
+		return fmt.Errorf("arrow/xlsx: sheet %q has more than %d columns", name, maxCols)
+	}
+	cells := make([]cellFunc, len(schema.Fields()))
+	for i, f := range schema.Fields() {
+		cell, err := cellFuncOf(f.Type)
+		if err == nil && w.header {
+			err = checkText(f.Name)
+		}
+		if err != nil {
+			return fmt.Errorf("arrow/xlsx: field %d (%s): %w", i, f.Name, err)
+		}
+		cells[i] = cell
+	}
+
+	if err := w.endSheet(); err != nil {
+		return err
+	}
+	w.sheets = append(w.sheets, name)
+	f, err := w.zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", len(w.sheets)))
+	if err != nil {
+		return err
+	}
+
+	w.out = bufio.NewWriter(f)
+	w.schema, w.cells, w.rows = schema, cells, 0
+	w.refs = make([]string, len(cells))
+	for i := range w.refs {
+		w.refs[i] = columnName(i)
+	}
+	w.out.WriteString(xml.Header)
+	w.out.WriteString(`<worksheet xmlns="` + nsMain + `"><sheetData>`)
+
+	if w.header {
+		w.buf = w.startRow(w.buf[:0])
+		for i, f := range schema.Fields() {
+			w.buf, _ = appendString(w.buf, w.cellRef(i), f.Name)
+		}
+		w.buf = append(w.buf, "</row>"...)
+		w.out.Write(w.buf)
+	}
+	return nil
+}
+
+// Write appends the rows of rec to the current sheet.
+func (w *Writer) Write(rec array.Record) error {
+	switch {
+	case w.closed:
+		return ErrClosed
+	case w.schema == nil:
+		return ErrNoSheet
+	case !rec.Schema().Equal(w.schema):
+		return ErrMismatchFields
+	case w.rows+int(rec.NumRows()) > maxRows:
+		return fmt.Errorf("arrow/xlsx: sheet %q has more than %d rows", w.sheets[len(w.sheets)-1], maxRows)
+	}
+
+	cols := rec.Columns()
+	for i := 0; i < int(rec.NumRows()); i++ {
+		w.buf = w.startRow(w.buf[:0])
+		for j, col := range cols {
+			if col.IsNull(i) {
+				continue
+			}
+			var err error
+			w.buf, err = w.cells[j](w.buf, w.cellRef(j), col, i)
+			if err != nil {
+				w.rows--
+				return fmt.Errorf("arrow/xlsx: row %d, field %d (%s): %w", i, j, w.schema.Field(j).Name, err)
+			}
+		}
+		w.buf = append(w.buf, "</row>"...)
+		if _, err := w.out.Write(w.buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTable writes tbl to a new sheet called name, as NewSheet does.
+func (w *Writer) WriteTable(name string, tbl array.Table) error {
+	if err := w.NewSheet(name, tbl.Schema()); err != nil {
+		return err
+	}
+	tr := array.NewTableReader(tbl, -1)
+	defer tr.Release()
+	for tr.Next() {
+		if err := w.Write(tr.Record()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close ends the current sheet and writes the rest of the file. A file
+// without sheets is given an empty sheet, as spreadsheets need at least
+// one. Close does not close the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	if err := w.endSheet(); err != nil {
+		return err
+	}
+	if len(w.sheets) == 0 {
+		if err := w.NewSheet("Sheet1", arrow.NewSchema(nil, nil)); err != nil {
+			return err
+		}
+		if err := w.endSheet(); err != nil {
+			return err
+		}
+	}
+	w.closed = true
+
+	var (
+		types strings.Builder
+		book  strings.Builder
+		rels  strings.Builder
+	)
+	types.WriteString(xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`)
+	book.WriteString(xml.Header + `<workbook xmlns="` + nsMain + `" xmlns:r="` + nsRels + `"><sheets>`)
+	rels.WriteString(xml.Header + `<Relationships xmlns="` + nsPkgRels + `">`)
+	for i, name := range w.sheets {
+		n := i + 1
+		fmt.Fprintf(&types, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		book.WriteString(`<sheet name="`)
+		xml.EscapeText(&book, []byte(name))
+		fmt.Fprintf(&book, `" sheetId="%d" r:id="rId%d"/>`, n, n)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="`+nsRels+`/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+	}
+	types.WriteString(`</Types>`)
+	book.WriteString(`</sheets></workbook>`)
+	fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="`+nsRels+`/styles" Target="styles.xml"/></Relationships>`, len(w.sheets)+1)
+
+	for _, part := range []struct{ name, data string }{
+		{"[Content_Types].xml", types.String()},
+		{"_rels/.rels", xml.Header + `<Relationships xmlns="` + nsPkgRels + `">` +
+			`<Relationship Id="rId1" Type="` + nsRels + `/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", book.String()},
+		{"xl/_rels/workbook.xml.rels", rels.String()},
+		{"xl/styles.xml", stylesXML},
+	} {
+		f, err := w.zw.Create(part.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, part.data); err != nil {
+			return err
+		}
+	}
+	return w.zw.Close()
+}
+
+// endSheet writes the end of the current sheet, if any.
+func (w *Writer) endSheet() error {
+	if w.out == nil {
+		return nil
+	}
+	w.out.WriteString(`</sheetData></worksheet>`)
+	err := w.out.Flush()
+	w.out, w.schema, w.cells = nil, nil, nil
+	return err
+}
+
+func (w *Writer) checkSheetName(name string) error {
+	switch n := utf8.RuneCountInString(name); {
+	case n == 0:
+		return fmt.Errorf("arrow/xlsx: empty sheet name")
+	case n > maxSheetName:
+		return fmt.Errorf("arrow/xlsx: sheet name %q is longer than %d characters", name, maxSheetName)
+	case strings.ContainsAny(name, `[]:*?/\`):
+		return fmt.Errorf("arrow/xlsx: sheet name %q holds one of []:*?/\\", name)
+	case name[0] == '\'' || name[len(name)-1] == '\'':
+		return fmt.Errorf("arrow/xlsx: sheet name %q starts or ends with an apostrophe", name)
+	}
+	for _, s := range w.sheets {
+		if strings.EqualFold(s, name) {
+			return fmt.Errorf("arrow/xlsx: duplicate sheet name %q", name)
+		}
+	}
+	return nil
+}
+
+func (w *Writer) startRow(buf []byte) []byte {
+	w.rows++
+	buf = append(buf, `<row r="`...)
+	buf = strconv.AppendInt(buf, int64(w.rows), 10)
+	return append(buf, `">`...)
+}
+
+// cellRef returns the reference of the cell of column i of the current row.
+func (w *Writer) cellRef(i int) string {
+	return w.refs[i] + strconv.Itoa(w.rows)
+}
+
+// columnName returns the letters of the column i, starting at 0: A, B, ...,
+// Z, AA, AB, ...
+func columnName(i int) string {
+	var b []byte
+	for i++; i > 0; i = (i - 1) / 26 {
+		b = append([]byte{byte('A' + (i-1)%26)}, b...)
+	}
+	return string(b)
+}
+
+// cellFuncOf returns the function writing the cells of values of type dt.
+func cellFuncOf(dt arrow.DataType) (cellFunc, error) {
+	switch dt := dt.(type) {
+	case *arrow.BooleanType:
+		return func(buf []byte, ref string, arr array.Interface, i int) ([]byte, error) {
+			v := "0"
+			if arr.(*array.Boolean).Value(i) {
+				v = "1"
+			}
+			return appendCell(buf, ref, ` t="b"`, v), nil
+		}, nil
+	case *arrow.Int8Type:
+		return intCell(func(arr array.Interface, i int) int64 { return int64(arr.(*array.Int8).Value(i)) }), nil
+	case *arrow.Int16Type:
+		return intCell(func(arr array.Interface, i int) int64 { return int64(arr.(*array.Int16).Value(i)) }), nil
+	case *arrow.Int32Type:
+		return intCell(func(arr array.Interface, i int) int64 { return int64(arr.(*array.Int32).Value(i)) }), nil
+	case *arrow.Int64Type:
+		return intCell(func(arr array.Interface, i int) int64 { return arr.(*array.Int64).Value(i) }), nil
+	case *arrow.Uint8Type:
+		return intCell(func(arr array.Interface, i int) int64 { return int64(arr.(*array.Uint8).Value(i)) }), nil
+	case *arrow.Uint16Type:
+		return intCell(func(arr array.Interface, i int) int64 { return int64(arr.(*array.Uint16).Value(i)) }), nil
+	case *arrow.Uint32Type:
+		return intCell(func(arr array.Interface, i int) int64 { return int64(arr.(*array.Uint32).Value(i)) }), nil
+	case *arrow.Uint64Type:
+		return func(buf []byte, ref string, arr array.Interface, i int) ([]byte, error) {
+			v := arr.(*array.Uint64).Value(i)
+			if v > maxExact {
+				return appendString(buf, ref, strconv.FormatUint(v, 10))
+			}
+			return appendCell(buf, ref, "", strconv.FormatUint(v, 10)), nil
+		}, nil
+	case *arrow.Float16Type:
+		return floatCell(32, func(arr array.Interface, i int) float64 { return float64(arr.(*array.Float16).Value(i).Float32()) }), nil
+	case *arrow.Float32Type:
+		return floatCell(32, func(arr array.Interface, i int) float64 { return float64(arr.(*array.Float32).Value(i)) }), nil
+	case *arrow.Float64Type:
+		return floatCell(64, func(arr array.Interface, i int) float64 { return arr.(*array.Float64).Value(i) }), nil
+	case *arrow.Decimal256Type:
+		return func(buf []byte, ref string, arr array.Interface, i int) ([]byte, error) {
+			v := arr.(*array.Decimal256).ValueStr(i)
+			if dt.Precision > maxExactDigits {
+				return appendString(buf, ref, v)
+			}
+			return appendCell(buf, ref, "", v), nil
+		}, nil
+	case *arrow.StringType:
+		return func(buf []byte, ref string, arr array.Interface, i int) ([]byte, error) {
+			return appendString(buf, ref, arr.(*array.String).Value(i))
+		}, nil
+	case *arrow.LargeStringType:
+		return func(buf []byte, ref string, arr array.Interface, i int) ([]byte, error) {
+			return appendString(buf, ref, arr.(*array.LargeString).Value(i))
+		}, nil
+	case *arrow.Date32Type:
+		return func(buf []byte, ref string, arr array.Interface, i int) ([]byte, error) {
+			v := int64(arr.(*array.Date32).Value(i)) + serialEpoch
+			return appendCell(buf, ref, styleAttr(styleDate), strconv.FormatInt(v, 10)), nil
+		}, nil
+	case *arrow.Date64Type:
+		return func(buf []byte, ref string, arr array.Interface, i int) ([]byte, error) {
+			ms := int64(arr.(*array.Date64).Value(i))
+			return appendCell(buf, ref, styleAttr(styleDate), formatSerial(float64(ms)/(1000*secsPerDay)+serialEpoch)), nil
+		}, nil
+	case *arrow.TimestampType:
+		loc, err := dt.Location()
+		if err != nil {
+			return nil, err
+		}
+		return func(buf []byte, ref string, arr array.Interface, i int) ([]byte, error) {
+			t := arr.(*array.Timestamp).Value(i).ToTime(dt.Unit).In(loc)
+			return appendCell(buf, ref, styleAttr(styleDateTime), formatSerial(timeSerial(t))), nil
+		}, nil
+	case *arrow.Time32Type:
+		return timeCell(dt.Unit, styleTime, func(arr array.Interface, i int) int64 { return int64(arr.(*array.Time32).Value(i)) }), nil
+	case *arrow.Time64Type:
+		return timeCell(dt.Unit, styleTime, func(arr array.Interface, i int) int64 { return int64(arr.(*array.Time64).Value(i)) }), nil
+	case *arrow.DurationType:
+		return timeCell(dt.Unit, styleDuration, func(arr array.Interface, i int) int64 { return int64(arr.(*array.Duration).Value(i)) }), nil
+	}
+	return nil, fmt.Errorf("unsupported data type %s", dt.Name())
+}
+
+// intCell returns the function writing integer values, those beyond
+// maxExact being written as text.
+func intCell(value func(arr array.Interface, i int) int64) cellFunc {
+	return func(buf []byte, ref string, arr array.Interface, i int) ([]byte, error) {
+		v := value(arr, i)
+		if v > maxExact || v < -maxExact {
+			return appendString(buf, ref, strconv.FormatInt(v, 10))
+		}
+		return appendCell(buf, ref, "", strconv.FormatInt(v, 10)), nil
+	}
+}
+
+// floatCell returns the function writing floating point values, NaN and
+// infinite values, which spreadsheets do not represent, being written as
+// text.
+func floatCell(bits int, value func(arr array.Interface, i int) float64) cellFunc {
+	return func(buf []byte, ref string, arr array.Interface, i int) ([]byte, error) {
+		v := value(arr, i)
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return appendString(buf, ref, strconv.FormatFloat(v, 'g', -1, bits))
+		}
+		return appendCell(buf, ref, "", strconv.FormatFloat(v, 'g', -1, bits)), nil
+	}
+}
+
+// timeCell returns the function writing times of day and durations, as
+// fractions of days.
+func timeCell(unit arrow.TimeUnit, style int, value func(arr array.Interface, i int) int64) cellFunc {
+	perDay := float64(secsPerDay * time.Second / unit.Multiplier())
+	return func(buf []byte, ref string, arr array.Interface, i int) ([]byte, error) {
+		return appendCell(buf, ref, styleAttr(style), formatSerial(float64(value(arr, i))/perDay)), nil
+	}
+}
+
+// timeSerial returns the serial number of the wall clock time of t.
+func timeSerial(t time.Time) float64 {
+	_, offset := t.Zone()
+	secs := t.Unix() + int64(offset)
+	days, rem := secs/secsPerDay, secs%secsPerDay
+	if rem < 0 {
+		days, rem = days-1, rem+secsPerDay
+	}
+	return float64(days+serialEpoch) + (float64(rem)+float64(t.Nanosecond())/1e9)/secsPerDay
+}
+
+func formatSerial(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+
+func styleAttr(style int) string { return ` s="` + strconv.Itoa(style) + `"` }
+
+func appendCell(buf []byte, ref, attrs, v string) []byte {
+	buf = append(buf, `<c r="`...)
+	buf = append(buf, ref...)
+	buf = append(buf, '"')
+	buf = append(buf, attrs...)
+	buf = append(buf, "><v>"...)
+	buf = append(buf, v...)
+	return append(buf, "</v></c>"...)
+}
+
+// appendString appends a cell holding the text v, which is stored in the
+// cell rather than in a table of shared strings.
+func appendString(buf []byte, ref, v string) ([]byte, error) {
+	if err := checkText(v); err != nil {
+		return buf, err
+	}
+	buf = append(buf, `<c r="`...)
+	buf = append(buf, ref...)
+	buf = append(buf, `" t="inlineStr"><is><t xml:space="preserve">`...)
+	buf = appendEscaped(buf, v)
+	return append(buf, "</t></is></c>"...), nil
+}
+
+// checkText returns an error if v is too long for a cell.
+func checkText(v string) error {
+	if len(v) > maxText && utf8.RuneCountInString(v) > maxText {
+		return fmt.Errorf("text of %d characters is longer than %d", utf8.RuneCountInString(v), maxText)
+	}
+	return nil
+}
+
+// appendEscaped appends the XML escaping of v, replacing the characters
+// XML can not represent with U+FFFD.
+func appendEscaped(buf []byte, v string) []byte {
+	for _, r := range v {
+		switch {
+		case r == '<':
+			buf = append(buf, "&lt;"...)
+		case r == '>':
+			buf = append(buf, "&gt;"...)
+		case r == '&':
+			buf = append(buf, "&amp;"...)
+		case r == '\t' || r == '\n' || r == '\r',
+			r >= 0x20 && r <= 0xd7ff,
+			r >= 0xe000 && r <= 0xfffd,
+			r >= 0x10000 && r <= 0x10ffff:
+			buf = utf8.AppendRune(buf, r)
+		default:
+			buf = utf8.AppendRune(buf, utf8.RuneError)
+		}
+	}
+	return buf
+}
+
+const (
+	nsMain    = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	nsRels    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	nsPkgRels = "http://schemas.openxmlformats.org/package/2006/relationships"
+)
+
+// stylesXML holds the styles of the cells, in the order of the style
+// constants.
+const stylesXML = xml.Header + `<styleSheet xmlns="` + nsMain + `">` +
+	`<numFmts count="3">` +
+	`<numFmt numFmtId="164" formatCode="yyyy-mm-dd"/>` +
+	`<numFmt numFmtId="165" formatCode="yyyy-mm-dd hh:mm:ss"/>` +
+	`<numFmt numFmtId="166" formatCode="[h]:mm:ss"/>` +
+	`</numFmts>` +
+	`<fonts count="1"><font><sz val="11"/><name val="Calibri"/></font></fonts>` +
+	`<fills count="2"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill></fills>` +
+	`<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>` +
+	`<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>` +
+	`<cellXfs count="5">` +
+	`<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>` +
+	`<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="165" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="21" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`<xf numFmtId="166" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>` +
+	`</cellXfs>` +
+	`<cellStyles count="1"><cellStyle name="Normal" xfId="0" builtinId="0"/></cellStyles>` +
+	`</styleSheet>`
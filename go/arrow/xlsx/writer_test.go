@@ -0,0 +1,312 @@
+// Licensed to the Apache Software Foundation (ASF) under one
+// or more contributor license agreements.  See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership.  The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License.  You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package xlsx_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal256"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/apache/arrow/go/arrow/xlsx"
+)
+
+type sheetXML struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R string `xml:"r,attr"`
+			T string `xml:"t,attr"`
+			S string `xml:"s,attr"`
+			V string `xml:"v"`
+			I string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+type workbookXML struct {
+	Sheets []struct {
+		Name string `xml:"name,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// readPart decodes the XML of the part name of the XLSX file data into v.
+func readPart(t *testing.T, data []byte, name string, v interface{}) {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := zr.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	raw, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := xml.Unmarshal(raw, v); err != nil {
+		t.Fatalf("could not decode %s: %v\n%s", name, err, raw)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "bool", Type: arrow.FixedWidthTypes.Boolean},
+		{Name: "i64", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "f64", Type: arrow.PrimitiveTypes.Float64},
+		{Name: "str", Type: arrow.BinaryTypes.String},
+		{Name: "date", Type: arrow.PrimitiveTypes.Date32},
+		{Name: "ts", Type: &arrow.TimestampType{Unit: arrow.Millisecond, TimeZone: "America/New_York"}},
+		{Name: "time", Type: arrow.FixedWidthTypes.Time32s},
+		{Name: "dur", Type: &arrow.DurationType{Unit: arrow.Second}},
+	}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.BooleanBuilder).AppendValues([]bool{true, false}, nil)
+	b.Field(1).(*array.Int64Builder).AppendValues([]int64{-42, 0}, []bool{true, false})
+	b.Field(2).(*array.Float64Builder).AppendValues([]float64{1.5, math.NaN()}, nil)
+	b.Field(3).(*array.StringBuilder).AppendValues([]string{" a<&b ", "x\x00y"}, nil)
+	b.Field(4).(*array.Date32Builder).AppendValues([]arrow.Date32{0, 19723}, nil)
+	// 1970-01-01T05:00:00Z and 2024-01-01T17:00:00Z, at midnight and noon in New York
+	b.Field(5).(*array.TimestampBuilder).AppendValues([]arrow.Timestamp{5 * 3600e3, 1704128400e3}, nil)
+	b.Field(6).(*array.Time32Builder).AppendValues([]arrow.Time32{0, 6 * 3600}, nil)
+	b.Field(7).(*array.DurationBuilder).AppendValues([]arrow.Duration{36 * 3600, 90}, nil)
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	tbl := array.NewTableFromRecords(schema, []array.Record{rec, rec})
+	defer tbl.Release()
+
+	var out bytes.Buffer
+	w := xlsx.NewWriter(&out)
+	if err := w.NewSheet("records", schema); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteTable("table", tbl); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var book workbookXML
+	readPart(t, out.Bytes(), "xl/workbook.xml", &book)
+	if len(book.Sheets) != 2 || book.Sheets[0].Name != "records" || book.Sheets[1].Name != "table" {
+		t.Fatalf("invalid sheets: %+v", book.Sheets)
+	}
+
+	var sheet sheetXML
+	readPart(t, out.Bytes(), "xl/worksheets/sheet1.xml", &sheet)
+	if len(sheet.Rows) != 3 {
+		t.Fatalf("invalid number of rows: got=%d, want=3", len(sheet.Rows))
+	}
+
+	var got []string
+	for _, row := range sheet.Rows {
+		var cells []string
+		for _, c := range row.Cells {
+			cells = append(cells, strings.Join([]string{c.R, c.T, c.S, c.V + c.I}, "|"))
+		}
+		got = append(got, strings.Join(cells, " "))
+	}
+	want := []string{
+		"A1|inlineStr||bool B1|inlineStr||i64 C1|inlineStr||f64 D1|inlineStr||str E1|inlineStr||date F1|inlineStr||ts G1|inlineStr||time H1|inlineStr||dur",
+		"A2|b||1 B2|||-42 C2|||1.5 D2|inlineStr|| a<&b  E2||1|25569 F2||2|25569 G2||3|0 H2||4|1.5",
+		"A3|b||0 C3|inlineStr||NaN D3|inlineStr||x�y E3||1|45292 F3||2|45292.5 G3||3|0.25 H3||4|0.0010416666666666667",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid cells:\ngot= %q\nwant=%q", got, want)
+	}
+
+	var sheet2 sheetXML
+	readPart(t, out.Bytes(), "xl/worksheets/sheet2.xml", &sheet2)
+	if len(sheet2.Rows) != 5 {
+		t.Fatalf("invalid number of rows: got=%d, want=5", len(sheet2.Rows))
+	}
+	var styles struct {
+		XMLName xml.Name `xml:"styleSheet"`
+	}
+	readPart(t, out.Bytes(), "xl/styles.xml", &styles)
+}
+
+func TestWriterNoHeader(t *testing.T) {
+	var out bytes.Buffer
+	w := xlsx.NewWriter(&out, xlsx.WithHeader(false))
+	schema := arrow.NewSchema([]arrow.Field{{Name: "i64", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	if err := w.NewSheet("data", schema); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var sheet sheetXML
+	readPart(t, out.Bytes(), "xl/worksheets/sheet1.xml", &sheet)
+	if len(sheet.Rows) != 0 {
+		t.Fatalf("invalid number of rows: got=%d, want=0", len(sheet.Rows))
+	}
+}
+
+func TestWriterImpreciseNumbers(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "i64", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "u64", Type: arrow.PrimitiveTypes.Uint64},
+		{Name: "short", Type: &arrow.Decimal256Type{Precision: 15, Scale: 2}},
+		{Name: "long", Type: &arrow.Decimal256Type{Precision: 40, Scale: 2}},
+	}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+
+	b.Field(0).(*array.Int64Builder).AppendValues([]int64{1 << 53, -1<<53 - 1}, nil)
+	b.Field(1).(*array.Uint64Builder).AppendValues([]uint64{1 << 53, math.MaxUint64}, nil)
+	for _, s := range []string{"1234567890123.45", "-0.01"} {
+		v, err := decimal256.FromString(s, 15, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b.Field(2).(*array.Decimal256Builder).Append(v)
+		b.Field(3).(*array.Decimal256Builder).Append(v)
+	}
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	var out bytes.Buffer
+	w := xlsx.NewWriter(&out, xlsx.WithHeader(false))
+	if err := w.NewSheet("data", schema); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(rec); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var sheet sheetXML
+	readPart(t, out.Bytes(), "xl/worksheets/sheet1.xml", &sheet)
+	var got []string
+	for _, row := range sheet.Rows {
+		for _, c := range row.Cells {
+			got = append(got, c.T+"|"+c.V+c.I)
+		}
+	}
+	want := []string{
+		"|9007199254740992", "|9007199254740992", "|1234567890123.45", "inlineStr|1234567890123.45",
+		"inlineStr|-9007199254740993", "inlineStr|18446744073709551615", "|-0.01", "inlineStr|-0.01",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("invalid cells:\ngot= %q\nwant=%q", got, want)
+	}
+}
+
+func TestWriterErrors(t *testing.T) {
+	mem := memory.NewCheckedAllocator(memory.NewGoAllocator())
+	defer mem.AssertSize(t, 0)
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "i64", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	b := array.NewRecordBuilder(mem, schema)
+	defer b.Release()
+	rec := b.NewRecord()
+	defer rec.Release()
+
+	w := xlsx.NewWriter(io.Discard)
+	if err := w.Write(rec); err != xlsx.ErrNoSheet {
+		t.Fatalf("invalid error: got=%v, want=%v", err, xlsx.ErrNoSheet)
+	}
+
+	wide := make([]arrow.Field, 1<<14+1)
+	for i := range wide {
+		wide[i] = arrow.Field{Name: fmt.Sprint(i), Type: arrow.PrimitiveTypes.Int64}
+	}
+
+	for _, tc := range []struct {
+		name   string
+		schema *arrow.Schema
+		want   string
+	}{
+		{"", schema, "empty sheet name"},
+		{"a:b", schema, `sheet name "a:b" holds one of []:*?/\`},
+		{strings.Repeat("x", 32), schema, "longer than 31 characters"},
+		{"list", arrow.NewSchema([]arrow.Field{{Name: "l", Type: arrow.ListOf(arrow.PrimitiveTypes.Int64)}}, nil), "field 0 (l): unsupported data type list"},
+		{"wide", arrow.NewSchema(wide, nil), `sheet "wide" has more than 16384 columns`},
+		{"name", arrow.NewSchema([]arrow.Field{{Name: strings.Repeat("é", 32768), Type: arrow.PrimitiveTypes.Int64}}, nil), "text of 32768 characters is longer than 32767"},
+	} {
+		err := w.NewSheet(tc.name, tc.schema)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("sheet %q: invalid error: got=%v, want=%q", tc.name, err, tc.want)
+		}
+	}
+
+	if err := xlsx.NewWriter(io.Discard, xlsx.WithHeader(false)).NewSheet("name", arrow.NewSchema([]arrow.Field{{Name: strings.Repeat("é", 32768), Type: arrow.PrimitiveTypes.Int64}}, nil)); err != nil {
+		t.Fatalf("field names are not written without header: %v", err)
+	}
+
+	strs := arrow.NewSchema([]arrow.Field{{Name: "s", Type: arrow.BinaryTypes.String}}, nil)
+	sb := array.NewRecordBuilder(mem, strs)
+	defer sb.Release()
+	sb.Field(0).(*array.StringBuilder).AppendValues([]string{"ok", strings.Repeat("é", 32767), strings.Repeat("x", 32768)}, nil)
+	srec := sb.NewRecord()
+	defer srec.Release()
+	if err := w.NewSheet("Text", strs); err != nil {
+		t.Fatal(err)
+	}
+	if err, want := w.Write(srec), "arrow/xlsx: row 2, field 0 (s): text of 32768 characters is longer than 32767"; err == nil || err.Error() != want {
+		t.Fatalf("invalid error: got=%v, want=%q", err, want)
+	}
+
+	if err := w.NewSheet("Data", schema); err != nil {
+		t.Fatal(err)
+	}
+	if err, want := w.NewSheet("data", schema), `duplicate sheet name "data"`; err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("invalid error: got=%v, want=%q", err, want)
+	}
+	other := arrow.NewSchema([]arrow.Field{{Name: "f64", Type: arrow.PrimitiveTypes.Float64}}, nil)
+	ob := array.NewRecordBuilder(mem, other)
+	defer ob.Release()
+	orec := ob.NewRecord()
+	defer orec.Release()
+	if err := w.Write(orec); err != xlsx.ErrMismatchFields {
+		t.Fatalf("invalid error: got=%v, want=%v", err, xlsx.ErrMismatchFields)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Write(rec); err != xlsx.ErrClosed {
+		t.Fatalf("invalid error: got=%v, want=%v", err, xlsx.ErrClosed)
+	}
+}